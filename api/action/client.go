@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 // Client provides access to the action facade.
@@ -83,13 +86,70 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel attempts to cancel queued up Actions, given by tag, from running.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
 }
 
+// WatchActionResults returns a StringsWatcher that notifies of the IDs
+// of the receiver's Actions as they finish running. The initial event
+// holds the IDs of all of its Actions that have already finished.
+func (c *Client) WatchActionResults(receiver names.Tag) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("WatchActionResults() (need V3+)")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: receiver.String()}},
+	}
+	err := c.facade.FacadeCall("WatchActionResults", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// AddSchedules adds schedules for enqueueing Actions on all the units
+// of applications.
+func (c *Client) AddSchedules(arg params.ActionSchedules) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotImplementedf("AddSchedules() (need V3+)")
+	}
+	err := c.facade.FacadeCall("AddSchedules", arg, &results)
+	return results, err
+}
+
+// ListSchedules returns all the action schedules in the model.
+func (c *Client) ListSchedules() (params.ActionSchedules, error) {
+	results := params.ActionSchedules{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotImplementedf("ListSchedules() (need V3+)")
+	}
+	err := c.facade.FacadeCall("ListSchedules", nil, &results)
+	return results, err
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotImplementedf("RemoveSchedules() (need V3+)")
+	}
+	err := c.facade.FacadeCall("RemoveSchedules", arg, &results)
+	return results, err
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -109,6 +110,55 @@ func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestWatchActionResultsErrors(c *gc.C) {
+	for i, t := range []struct {
+		patchResults []params.StringsWatchResult
+		patchErr     string
+		expectedErr  string
+	}{{
+		patchErr:    "boom",
+		expectedErr: "boom",
+	}, {
+		patchResults: []params.StringsWatchResult{{}, {}},
+		expectedErr:  "expected 1 result, got 2",
+	}, {
+		patchResults: []params.StringsWatchResult{{
+			Error: &params.Error{Message: "id not found", Code: params.CodeNotFound},
+		}},
+		expectedErr: "id not found",
+	}} {
+		c.Logf("test %d", i)
+		cleanup := action.PatchClientFacadeCall(s.client,
+			func(req string, paramsIn interface{}, resp interface{}) error {
+				c.Check(req, gc.Equals, "WatchActionResults")
+				c.Check(paramsIn, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+				})
+				resp.(*params.StringsWatchResults).Results = t.patchResults
+				if t.patchErr != "" {
+					return errors.New(t.patchErr)
+				}
+				return nil
+			},
+		)
+		_, err := s.client.WatchActionResults(names.NewUnitTag("mysql/0"))
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+		cleanup()
+	}
+}
+
+func (s *actionSuite) TestNewMethodsNotImplementedBeforeV3(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionResults(names.NewUnitTag("mysql/0"))
+	c.Check(err, gc.ErrorMatches, `WatchActionResults\(\) \(need V3\+\) not implemented`)
+	_, err = client.ListSchedules()
+	c.Check(err, gc.ErrorMatches, `ListSchedules\(\) \(need V3\+\) not implemented`)
+}
+
 // replace sCharmActions" facade call with required results and error
 // if desired
 func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ApplicationCharmActionsResult, err string) func() {
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.api.actionscheduler")
+
+// NewWatcherFunc exists to let us test Watch properly.
+type NewWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// API makes calls to the ActionScheduler facade.
+type API struct {
+	caller     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc) *API {
+	return &API{
+		caller:     base.NewFacadeCaller(caller, "ActionScheduler"),
+		newWatcher: newWatcher,
+	}
+}
+
+// Watch returns a NotifyWatcher that triggers when action schedules
+// are added, removed or run.
+func (api *API) Watch() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := api.caller.FacadeCall("Watch", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// Schedules returns the time at which each action schedule in the
+// model is next due to run, keyed by schedule id.
+func (api *API) Schedules() (map[string]time.Time, error) {
+	var result params.ActionSchedules
+	err := api.caller.FacadeCall("Schedules", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules := make(map[string]time.Time)
+	for _, schedule := range result.Schedules {
+		schedules[schedule.Id] = schedule.NextRun
+	}
+	return schedules, nil
+}
+
+// Run requests that the actions of all the identified schedules which
+// are due be enqueued. It returns the first error it encounters.
+func (api *API) Run(ids []string) error {
+	args := params.ActionScheduleIds{Ids: ids}
+	var results params.ErrorResults
+	err := api.caller.FacadeCall("Run", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results.Results {
+		if result.Error != nil {
+			if err == nil {
+				err = result.Error
+			} else {
+				logger.Errorf("additional run error: %v", result.Error)
+			}
+		}
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestSchedules(c *gc.C) {
+	t0 := time.Date(2016, 11, 10, 0, 0, 0, 0, time.UTC)
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Schedules")
+		c.Check(arg, gc.IsNil)
+		resultPtr, ok := result.(*params.ActionSchedules)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ActionSchedules{Schedules: []params.ActionSchedule{
+			{Id: "1", NextRun: t0},
+			{Id: "2", NextRun: t0.Add(time.Hour)},
+		}}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	schedules, err := api.Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, jc.DeepEquals, map[string]time.Time{
+		"1": t0,
+		"2": t0.Add(time.Hour),
+	})
+}
+
+func (s *APISuite) TestSchedulesError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("snorble flip")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	_, err := api.Schedules()
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func (s *APISuite) TestRun(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Run")
+		c.Check(arg, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"1", "2"}})
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	err := api.Run([]string{"1", "2"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestRunCallError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("snorble flip")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	err := api.Run(nil)
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func (s *APISuite) TestRunFirstError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ErrorResults{Results: []params.ErrorResult{{
+			nil,
+		}, {
+			&params.Error{Message: "expect this error"},
+		}, {
+			&params.Error{Message: "not this one"},
+		}}}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	err := api.Run([]string{"1", "2", "3"})
+	c.Check(err, gc.ErrorMatches, "expect this error")
+}
+
+func (s *APISuite) TestWatchError(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Watch")
+		return errors.New("blam pow")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	watcher, err := api.Watch()
+	c.Check(watcher, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "blam pow")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestWatchSuccess(c *gc.C) {
+	expectResult := params.NotifyWatchResult{
+		NotifyWatcherId: "123",
+	}
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.NotifyWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = expectResult
+		return nil
+	})
+	expectWatcher := &stubWatcher{}
+	newWatcher := func(gotCaller base.APICaller, gotResult params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(gotCaller, gc.NotNil) // uncomparable
+		c.Check(gotResult, jc.DeepEquals, expectResult)
+		return expectWatcher
+	}
+	api := actionscheduler.NewAPI(caller, newWatcher)
+
+	watcher, err := api.Watch()
+	c.Check(watcher, gc.Equals, expectWatcher)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ActionScheduler")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+type stubWatcher struct {
+	watcher.NotifyWatcher
+}
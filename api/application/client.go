@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -262,6 +263,16 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 	return c.facade.FacadeCall("SetConstraints", params, nil)
 }
 
+// SetEndpointBindings binds the given endpoints of the application
+// to spaces. Endpoints not included in bindings are left unchanged.
+func (c *Client) SetEndpointBindings(application string, bindings map[string]string) error {
+	params := params.ApplicationSetEndpointBindings{
+		ApplicationName: application,
+		Bindings:        bindings,
+	}
+	return c.facade.FacadeCall("SetEndpointBindings", params, nil)
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(application string) error {
@@ -269,6 +280,17 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeToCIDRs changes the juju-managed firewall to expose any ports
+// that were also explicitly marked by units as open, to the given CIDRs
+// only.
+func (c *Client) ExposeToCIDRs(application string, cidrs []string) error {
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		ToCIDRs:         cidrs,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -276,6 +298,20 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// SetEgressRules restricts the outbound traffic of the machines hosting
+// the application's units to the given rules. If no rules are given,
+// all outbound traffic is allowed.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	args := params.ApplicationSetEgressRules{
+		ApplicationName: application,
+		Rules:           make([]params.EgressRule, len(rules)),
+	}
+	for i, rule := range rules {
+		args.Rules[i] = params.FromNetworkEgressRule(rule)
+	}
+	return c.facade.FacadeCall("SetEgressRules", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
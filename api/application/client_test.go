@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -138,6 +139,61 @@ func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestSetEndpointBindings(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEndpointBindings")
+		args, ok := a.(params.ApplicationSetEndpointBindings)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ApplicationSetEndpointBindings{
+			ApplicationName: "application",
+			Bindings:        map[string]string{"db": "internal"},
+		})
+		return nil
+	})
+	err := s.client.SetEndpointBindings("application", map[string]string{"db": "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeToCIDRs(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "application",
+			ToCIDRs:         []string{"10.0.0.0/8"},
+		})
+		return nil
+	})
+	err := s.client.ExposeToCIDRs("application", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetEgressRules(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEgressRules")
+		c.Assert(a, jc.DeepEquals, params.ApplicationSetEgressRules{
+			ApplicationName: "application",
+			Rules: []params.EgressRule{{
+				PortRange:       params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDR: "10.0.0.0/8",
+			}},
+		})
+		return nil
+	})
+	err := s.client.SetEgressRules("application", []network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	var called bool
 	toUint64Ptr := func(v uint64) *uint64 {
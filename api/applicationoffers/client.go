@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationoffers provides access to the ApplicationOffers
+// API facade, used to offer application endpoints to other models and
+// to consume the endpoints offered by other models.
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the application offers API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ApplicationOffers")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Offer makes the named endpoints of the application available to other
+// models hosted by the controller, under the supplied offer name. If
+// the offer name is empty, the application name is used.
+func (c *Client) Offer(application string, endpoints []string, offerName string) error {
+	args := params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			OfferName:       offerName,
+			ApplicationName: application,
+			Endpoints:       endpoints,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Offer", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Consume adds a remote application to the model, through which local
+// applications may relate to the offer at the supplied URL. The remote
+// application is named after the offer unless an alias is supplied;
+// its name is returned.
+func (c *Client) Consume(url, alias string) (string, error) {
+	args := params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{
+			ApplicationURL:   url,
+			ApplicationAlias: alias,
+		}},
+	}
+	var results params.ConsumeApplicationResults
+	if err := c.facade.FacadeCall("Consume", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.LocalName, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/applicationoffers"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type applicationOffersSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&applicationOffersSuite{})
+
+func (s *applicationOffersSuite) TestOffer(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Offer")
+			c.Check(a, jc.DeepEquals, params.AddApplicationOffers{
+				Offers: []params.AddApplicationOffer{{
+					OfferName:       "db",
+					ApplicationName: "mysql",
+					Endpoints:       []string{"server"},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.Offer("mysql", []string{"server"}, "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationOffersSuite) TestOfferError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.Offer("mysql", []string{"server"}, "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationOffersSuite) TestConsume(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Consume")
+			c.Check(a, jc.DeepEquals, params.ConsumeApplicationArgs{
+				Args: []params.ConsumeApplicationArg{{
+					ApplicationURL:   "admin/prod.db",
+					ApplicationAlias: "proddb",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ConsumeApplicationResults{})
+			*(result.(*params.ConsumeApplicationResults)) = params.ConsumeApplicationResults{
+				Results: []params.ConsumeApplicationResult{{
+					LocalName: "proddb",
+				}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	name, err := client.Consume("admin/prod.db", "proddb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(name, gc.Equals, "proddb")
+}
+
+func (s *applicationOffersSuite) TestConsumeError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.Consume("admin/prod.db", "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationOffersSuite) TestConsumeResultError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ConsumeApplicationResults)) = params.ConsumeApplicationResults{
+				Results: []params.ConsumeApplicationResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.Consume("admin/prod.db", "")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
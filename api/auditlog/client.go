@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides access to the AuditLog API facade, used
+// to query the controller's audit log.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+)
+
+// Client provides access to the audit log.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit log entries matching the supplied query,
+// ordered from oldest to newest.
+func (c *Client) Query(query audit.Query) ([]audit.AuditEntry, error) {
+	args := params.AuditLogQuery{
+		ModelUUID:  query.ModelUUID,
+		OriginType: query.OriginType,
+		OriginName: query.OriginName,
+		Operation:  query.Operation,
+		Limit:      query.Limit,
+	}
+	if !query.After.IsZero() {
+		after := query.After
+		args.After = &after
+	}
+	if !query.Before.IsZero() {
+		before := query.Before
+		args.Before = &before
+	}
+	var result params.AuditLogEntries
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	entries := make([]audit.AuditEntry, len(result.Entries))
+	for i, entry := range result.Entries {
+		entries[i] = audit.AuditEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp.UTC(),
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	after := coretesting.NonZeroTime().UTC()
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AuditLogQuery{
+				OriginName: "user-admin",
+				After:      &after,
+				Limit:      5,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogEntries{})
+			*(result.(*params.AuditLogEntries)) = params.AuditLogEntries{
+				Entries: []params.AuditLogEntry{{
+					JujuServerVersion: version.MustParse("2.0.1"),
+					ModelUUID:         coretesting.ModelTag.Id(),
+					Timestamp:         after.Add(time.Minute),
+					RemoteAddress:     "10.0.0.1",
+					OriginType:        "API request",
+					OriginName:        "user-admin",
+					Operation:         "Client.FullStatus",
+				}},
+			}
+			return nil
+		})
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Query(audit.Query{
+		OriginName: "user-admin",
+		After:      after,
+		Limit:      5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         after.Add(time.Minute),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Client.FullStatus",
+	}})
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(audit.Query{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
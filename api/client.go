@@ -561,6 +561,35 @@ type DebugLogParams struct {
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
 	NoTail bool
+	// StartTime, if non-zero, tells the server to only return log lines
+	// written at or after this time.
+	StartTime time.Time
+	// EndTime, if non-zero, tells the server to only return log lines
+	// written before this time. The server does not wait for new logs
+	// to arrive when an end time is given.
+	EndTime time.Time
+	// MessageRegex, if set, restricts the response to log lines whose
+	// message matches the regular expression.
+	MessageRegex string
+	// IncludeApplication lists applications whose unit agents' logs are
+	// included in the response.
+	IncludeApplication []string
+	// ExcludeApplication lists applications whose unit agents' logs are
+	// excluded from the response.
+	ExcludeApplication []string
+	// IncludeMachine lists machine ids whose agents' logs are included in
+	// the response. The logs of containers on those machines also match.
+	IncludeMachine []string
+	// ExcludeMachine lists machine ids whose agents' logs are excluded
+	// from the response. The logs of containers on those machines are
+	// also excluded.
+	ExcludeMachine []string
+	// IncludeUnit lists units whose agents' logs are included in the
+	// response.
+	IncludeUnit []string
+	// ExcludeUnit lists units whose agents' logs are excluded from the
+	// response.
+	ExcludeUnit []string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -569,6 +598,13 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"includeModule": args.IncludeModule,
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
+
+		"includeApplication": args.IncludeApplication,
+		"excludeApplication": args.ExcludeApplication,
+		"includeMachine":     args.IncludeMachine,
+		"excludeMachine":     args.ExcludeMachine,
+		"includeUnit":        args.IncludeUnit,
+		"excludeUnit":        args.ExcludeUnit,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -585,6 +621,15 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.UTC().Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.UTC().Format(time.RFC3339Nano))
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
 	return attrs
 }
 
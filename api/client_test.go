@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -439,6 +440,41 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestWatchDebugLogFilterParamsEncoded(c *gc.C) {
+	catcher := urlCatcher{}
+	s.PatchValue(api.WebsocketDialConfig, catcher.recordLocation)
+
+	params := api.DebugLogParams{
+		StartTime:          time.Date(2016, 11, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:            time.Date(2016, 11, 2, 10, 30, 0, 500, time.UTC),
+		MessageRegex:       "fail(ed|ure)",
+		IncludeApplication: []string{"mysql"},
+		ExcludeApplication: []string{"wordpress"},
+		IncludeMachine:     []string{"0"},
+		ExcludeMachine:     []string{"1/lxd/0"},
+		IncludeUnit:        []string{"mysql/0"},
+		ExcludeUnit:        []string{"wordpress/1"},
+	}
+
+	client := s.APIState.Client()
+	_, err := client.WatchDebugLog(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	connectURL := catcher.location
+	values := connectURL.Query()
+	c.Assert(values, jc.DeepEquals, url.Values{
+		"startTime":          {"2016-11-02T09:00:00Z"},
+		"endTime":            {"2016-11-02T10:30:00.0000005Z"},
+		"messageRegex":       {"fail(ed|ure)"},
+		"includeApplication": params.IncludeApplication,
+		"excludeApplication": params.ExcludeApplication,
+		"includeMachine":     params.IncludeMachine,
+		"excludeMachine":     params.ExcludeMachine,
+		"includeUnit":        params.IncludeUnit,
+		"excludeUnit":        params.ExcludeUnit,
+	})
+}
+
 func (s *clientSuite) TestConnectStreamAtUUIDPath(c *gc.C) {
 	catcher := urlCatcher{}
 	s.PatchValue(api.WebsocketDialConfig, catcher.recordLocation)
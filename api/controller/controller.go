@@ -259,16 +259,68 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
-	if err := spec.Validate(); err != nil {
+	args, err := makeMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
 		return "", errors.Trace(err)
 	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationBlockers holds the problems that would prevent a model
+// migration, as found by the prechecks of the source and target
+// controllers.
+type MigrationBlockers struct {
+	Source []string
+	Target []string
+}
+
+// MigrationPrechecks runs the migration prechecks of the source and
+// target controllers for the specified migration, without starting
+// it, and returns the problems found.
+func (c *Client) MigrationPrechecks(spec MigrationSpec) (MigrationBlockers, error) {
+	var blockers MigrationBlockers
+	args, err := makeMigrationArgs(spec)
+	if err != nil {
+		return blockers, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("MigrationPrechecks", args, &response); err != nil {
+		return blockers, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return blockers, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return blockers, errors.Trace(result.Error)
+	}
+	blockers.Source = result.SourceBlockers
+	blockers.Target = result.TargetBlockers
+	return blockers, nil
+}
+
+func makeMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
+	if err := spec.Validate(); err != nil {
+		return params.InitiateMigrationArgs{}, errors.Trace(err)
+	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Trace(err)
+		return params.InitiateMigrationArgs{}, errors.Trace(err)
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -282,19 +334,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 			ExternalControl:      spec.ExternalControl,
 			SkipInitialPrechecks: spec.SkipInitialPrechecks,
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
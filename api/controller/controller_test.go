@@ -126,6 +126,51 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					SourceBlockers: []string{"cleanup needed"},
+					TargetBlockers: []string{"upgrade in progress"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	blockers, err := client.MigrationPrechecks(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, controller.MigrationBlockers{
+		Source: []string{"cleanup needed"},
+		Target: []string{"upgrade in progress"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPrechecks", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationPrechecksError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	)
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
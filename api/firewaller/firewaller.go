@@ -21,6 +21,7 @@ const firewallerFacade = "Firewaller"
 type State struct {
 	facade base.FacadeCaller
 	*common.ModelWatcher
+	*common.APIAddresser
 	*cloudspec.CloudSpecAPI
 }
 
@@ -30,6 +31,7 @@ func NewState(caller base.APICaller) *State {
 	return &State{
 		facade:       facadeCaller,
 		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		APIAddresser: common.NewAPIAddresser(facadeCaller),
 		CloudSpecAPI: cloudspec.NewCloudSpecAPI(facadeCaller),
 	}
 }
@@ -117,3 +119,18 @@ func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchIngressNetworks returns a NotifyWatcher that notifies of changes
+// to the ingress networks of the relations in the current model.
+func (st *State) WatchIngressNetworks() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchIngressNetworks", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
@@ -27,6 +28,12 @@ func (m *Machine) Tag() names.MachineTag {
 	return m.tag
 }
 
+// Watch returns a watcher for observing changes to the machine, such
+// as it being provisioned.
+func (m *Machine) Watch() (watcher.NotifyWatcher, error) {
+	return common.Watch(m.st.facade, m.tag)
+}
+
 // WatchUnits starts a StringsWatcher to watch all units assigned to
 // the machine.
 func (m *Machine) WatchUnits() (watcher.StringsWatcher, error) {
@@ -67,6 +67,26 @@ func (s *machineSuite) TestInstanceId(c *gc.C) {
 	c.Assert(instanceId, gc.Equals, instance.Id("i-manager"))
 }
 
+func (s *machineSuite) TestWatch(c *gc.C) {
+	newMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	apiNewMachine, err := s.firewaller.Machine(newMachine.Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := apiNewMachine.Watch()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Provision the machine and check it's detected.
+	err = newMachine.SetProvisioned("i-new", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	w, err := s.apiMachine.WatchUnits()
 	c.Assert(err, jc.ErrorIsNil)
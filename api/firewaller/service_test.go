@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +92,55 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposedCIDRs(c *gc.C) {
+	err := s.application.SetExposedCIDRs([]string{"192.168.1.0/24", "10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.ExposedCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.ExposedCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *serviceSuite) TestIngressNetworks(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.IngressNetworks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	err = rel.SetIngressNetworks([]string{"10.0.0.1/32"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.IngressNetworks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.1/32"})
+}
+
+func (s *serviceSuite) TestEgressRules(c *gc.C) {
+	rule := network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8")
+	err := s.application.SetEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{rule})
+
+	err = s.application.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
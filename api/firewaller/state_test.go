@@ -15,6 +15,7 @@ import (
 
 type stateSuite struct {
 	firewallerSuite
+	*apitesting.APIAddresserTests
 	*apitesting.ModelWatcherTests
 }
 
@@ -22,6 +23,7 @@ var _ = gc.Suite(&stateSuite{})
 
 func (s *stateSuite) SetUpTest(c *gc.C) {
 	s.firewallerSuite.SetUpTest(c)
+	s.APIAddresserTests = apitesting.NewAPIAddresserTests(s.firewaller, s.BackingState)
 	s.ModelWatcherTests = apitesting.NewModelWatcherTests(s.firewaller, s.BackingState)
 }
 
@@ -100,3 +102,23 @@ func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertChange("1:")
 	wc.AssertNoChange()
 }
+
+func (s *stateSuite) TestWatchIngressNetworks(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.firewaller.WatchIngressNetworks()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = rel.SetIngressNetworks([]string{"10.0.0.1/32"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logexport provides a client for exporting a model's logs
+// as a compressed archive, and for importing such an archive.
+package logexport
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the log export endpoint of the API server.
+type Client struct {
+	st     base.APICallCloser
+	client *httprequest.Client
+}
+
+// NewClient returns a new log export client.
+func NewClient(st base.APICallCloser) (*Client, error) {
+	client, err := st.HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{st: st, client: client}, nil
+}
+
+// Close closes the underlying API connection.
+func (c *Client) Close() error {
+	return c.st.Close()
+}
+
+// Export returns a gzip-compressed stream of the model's log records,
+// encoded as JSON with one record per line. If start or end are
+// non-zero, only records logged after start and before end are
+// included. The caller is responsible for closing the stream.
+func (c *Client) Export(start, end time.Time) (io.ReadCloser, error) {
+	query := make(url.Values)
+	if !start.IsZero() {
+		query.Set("startTime", start.UTC().Format(time.RFC3339Nano))
+	}
+	if !end.IsZero() {
+		query.Set("endTime", end.UTC().Format(time.RFC3339Nano))
+	}
+	req, err := http.NewRequest("GET", "/logs?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp *http.Response
+	if err := c.client.Do(req, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Body, nil
+}
+
+// Import sends an archive, as produced by Export, to be loaded into
+// the model's logs. It returns the number of records imported.
+func (c *Client) Import(archive io.ReadSeeker) (int, error) {
+	req, err := http.NewRequest("POST", "/logs", nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", params.ContentTypeGzip)
+	var result params.LogImportResult
+	if err := c.client.Do(req, archive, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return result.Imported, nil
+}
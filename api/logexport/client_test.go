@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logexport_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/api/logexport"
+	"github.com/juju/juju/apiserver/params"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type clientSuite struct {
+	jujutesting.JujuConnSuite
+	client *logexport.Client
+	t0     time.Time
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	client, err := logexport.NewClient(s.APIState)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client = client
+
+	s.t0 = time.Date(2016, 11, 1, 9, 0, 0, 0, time.UTC)
+	logger := state.NewDbLogger(s.State, names.NewMachineTag("0"), jujuversion.Current)
+	defer logger.Close()
+	for i, msg := range []string{"first", "second", "third"} {
+		err := logger.Log(s.t0.Add(time.Duration(i)*time.Minute), "juju.foo", "foo.go:42", loggo.INFO, msg)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *clientSuite) export(c *gc.C, start, end time.Time) []byte {
+	archive, err := s.client.Export(start, end)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *clientSuite) messages(c *gc.C, data []byte) []string {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	decoder := json.NewDecoder(zr)
+	var messages []string
+	for decoder.More() {
+		var rec params.ExportedLogRecord
+		err := decoder.Decode(&rec)
+		c.Assert(err, jc.ErrorIsNil)
+		messages = append(messages, rec.Message)
+	}
+	return messages
+}
+
+func (s *clientSuite) TestExport(c *gc.C) {
+	data := s.export(c, time.Time{}, time.Time{})
+	c.Assert(s.messages(c, data), jc.DeepEquals, []string{"first", "second", "third"})
+}
+
+func (s *clientSuite) TestExportTimeRange(c *gc.C) {
+	data := s.export(c, s.t0.Add(30*time.Second), time.Time{})
+	c.Assert(s.messages(c, data), jc.DeepEquals, []string{"second", "third"})
+
+	data = s.export(c, time.Time{}, s.t0.Add(30*time.Second))
+	c.Assert(s.messages(c, data), jc.DeepEquals, []string{"first"})
+}
+
+func (s *clientSuite) TestExportBadTimeRange(c *gc.C) {
+	_, err := s.client.Export(s.t0, s.t0.Add(-time.Minute))
+	c.Assert(err, gc.ErrorMatches, `.*endTime value ".*" is not after startTime`)
+	c.Assert(err, jc.Satisfies, params.IsBadRequest)
+}
+
+func (s *clientSuite) TestImport(c *gc.C) {
+	data := s.export(c, time.Time{}, time.Time{})
+	count, err := s.client.Import(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 3)
+
+	logsColl := s.State.MongoSession().DB("logs").C("logs")
+	n, err := logsColl.Find(bson.M{"e": s.State.ModelUUID(), "x": "second"}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 2)
+}
+
+func (s *clientSuite) TestImportInvalidArchive(c *gc.C) {
+	_, err := s.client.Import(strings.NewReader("not an archive"))
+	c.Assert(err, gc.ErrorMatches, ".*reading log archive: .*")
+}
@@ -121,6 +121,63 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// AddTransferredBytes records that count more bytes of the given
+// kind of binary (e.g. migration.TransferCharms) have been sent to
+// the target controller.
+func (c *Client) AddTransferredBytes(kind string, count int64) error {
+	args := params.MigrationTransferredBytesArgs{
+		Kind:  kind,
+		Count: count,
+	}
+	return c.caller.FacadeCall("AddTransferredBytes", args, nil)
+}
+
+// MigrationProgress returns the detailed progress of the latest
+// migration of the model, for display to the end user.
+func (c *Client) MigrationProgress() (migration.MigrationProgress, error) {
+	var in params.MigrationProgress
+	var out migration.MigrationProgress
+
+	err := c.caller.FacadeCall("MigrationProgress", nil, &in)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	phase, ok := migration.ParsePhase(in.Phase)
+	if !ok {
+		return out, errors.Errorf("invalid phase: %q", in.Phase)
+	}
+	controllerTag, err := names.ParseControllerTag(in.TargetControllerTag)
+	if err != nil {
+		return out, errors.Annotate(err, "parsing controller tag")
+	}
+
+	out.MigrationId = in.MigrationId
+	out.TargetControllerTag = controllerTag
+	out.Phase = phase
+	out.StatusMessage = in.StatusMessage
+	for _, pt := range in.PhaseTimes {
+		phase, ok := migration.ParsePhase(pt.Phase)
+		if !ok {
+			return out, errors.Errorf("invalid phase: %q", pt.Phase)
+		}
+		out.PhaseTimes = append(out.PhaseTimes, migration.PhaseTime{
+			Phase: phase,
+			Time:  pt.Time,
+		})
+	}
+	for _, counts := range in.MinionReports {
+		out.MinionCounts = append(out.MinionCounts, migration.MinionCounts{
+			Kind:      counts.Kind,
+			Succeeded: counts.Succeeded,
+			Failed:    counts.Failed,
+			Pending:   counts.Pending,
+		})
+	}
+	out.TransferredBytes = in.TransferredBytes
+	return out, nil
+}
+
 // ModelInfo return basic information about the model to migrated.
 func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	var info params.MigrationModelInfo
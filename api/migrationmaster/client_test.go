@@ -163,6 +163,74 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestAddTransferredBytes(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.AddTransferredBytes(migration.TransferCharms, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.MigrationTransferredBytesArgs{Kind: "charms", Count: 1024}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.AddTransferredBytes", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestMigrationProgress(c *gc.C) {
+	var stub jujutesting.Stub
+	controllerTag := names.NewControllerTag(utils.MustNewUUID().String())
+	t0 := time.Date(2016, 6, 22, 16, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationProgress)) = params.MigrationProgress{
+			MigrationId:         "id",
+			TargetControllerTag: controllerTag.String(),
+			Phase:               "IMPORT",
+			StatusMessage:       "importing",
+			PhaseTimes: []params.MigrationPhaseTime{
+				{Phase: "QUIESCE", Time: t0},
+				{Phase: "IMPORT", Time: t0.Add(time.Minute)},
+			},
+			MinionReports: []params.MigrationMinionCounts{
+				{Kind: "machine", Succeeded: 2, Failed: 1, Pending: 3},
+			},
+			TransferredBytes: map[string]int64{"charms": 2048},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	progress, err := client.MigrationProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.MigrationProgress", []interface{}{"", nil}},
+	})
+	c.Check(progress, jc.DeepEquals, migration.MigrationProgress{
+		MigrationId:         "id",
+		TargetControllerTag: controllerTag,
+		Phase:               migration.IMPORT,
+		StatusMessage:       "importing",
+		PhaseTimes: []migration.PhaseTime{
+			{Phase: migration.QUIESCE, Time: t0},
+			{Phase: migration.IMPORT, Time: t0.Add(time.Minute)},
+		},
+		MinionCounts: []migration.MinionCounts{
+			{Kind: "machine", Succeeded: 2, Failed: 1, Pending: 3},
+		},
+		TransferredBytes: map[string]int64{"charms": 2048},
+	})
+}
+
+func (s *ClientSuite) TestMigrationProgressError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	_, err := client.MigrationProgress()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	var stub jujutesting.Stub
 	owner := names.NewUserTag("owner")
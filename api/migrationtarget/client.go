@@ -23,6 +23,12 @@ type Client struct {
 	caller base.FacadeCaller
 }
 
+// BestAPIVersion returns the version of the MigrationTarget facade
+// supported by both the client and the target controller.
+func (c *Client) BestAPIVersion() int {
+	return c.caller.BestAPIVersion()
+}
+
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	args := params.MigrationModelInfo{
 		UUID:         model.UUID,
@@ -33,6 +39,22 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
+// PrecheckReport runs the target controller's migration prechecks for
+// the model, returning all the problems found.
+func (c *Client) PrecheckReport(model coremigration.ModelInfo) ([]string, error) {
+	args := params.MigrationModelInfo{
+		UUID:         model.UUID,
+		Name:         model.Name,
+		OwnerTag:     model.Owner.String(),
+		AgentVersion: model.AgentVersion,
+	}
+	var report params.MigrationPrecheckReport
+	if err := c.caller.FacadeCall("PrecheckReport", args, &report); err != nil {
+		return nil, err
+	}
+	return report.Blockers, nil
+}
+
 // Import takes a serialized model and imports it into the target
 // controller.
 func (c *Client) Import(bytes []byte) error {
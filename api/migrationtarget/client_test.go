@@ -6,6 +6,7 @@ package migrationtarget_test
 import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -57,6 +58,39 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestPrecheckReport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationPrecheckReport)) = params.MigrationPrecheckReport{
+			Blockers: []string{"upgrade in progress"},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	blockers, err := client.PrecheckReport(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{"upgrade in progress"})
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		OwnerTag:     ownerTag.String(),
+		AgentVersion: vers,
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.PrecheckReport", []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -6,11 +6,13 @@ package modelmanager
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/permission"
@@ -242,6 +244,42 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 	return result.Result, nil
 }
 
+// ExportModel returns the serialized representation of the model,
+// along with the charms and tools it uses, so that it can be imported
+// into another controller.
+func (c *Client) ExportModel(model names.ModelTag) (migration.SerializedModel, error) {
+	var results params.SerializedModelResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+
+	err := c.facade.FacadeCall("ExportModels", entities, &results)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return migration.SerializedModel{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return migration.SerializedModel{}, result.Error
+	}
+
+	tools := make(map[version.Binary]string)
+	for _, toolsInfo := range result.Result.Tools {
+		v, err := version.ParseBinary(toolsInfo.Version)
+		if err != nil {
+			return migration.SerializedModel{}, errors.Annotate(err, "error parsing tools version")
+		}
+		tools[v] = toolsInfo.URI
+	}
+	return migration.SerializedModel{
+		Bytes:  result.Result.Bytes,
+		Charms: result.Result.Charms,
+		Tools:  tools,
+	}, nil
+}
+
 // DestroyModel puts the specified model into a "dying" state, which will
 // cause the model's resources to be cleaned up, after which the model will
 // be removed.
@@ -6,6 +6,7 @@ package modelmanager_test
 import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -13,6 +14,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/testing"
@@ -287,6 +289,57 @@ func (s *dumpModelSuite) TestDumpModelError(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *dumpModelSuite) TestExportModel(c *gc.C) {
+	results := params.SerializedModelResults{Results: []params.SerializedModelResult{{
+		Result: &params.SerializedModel{
+			Bytes:  []byte("model-uuid: some-uuid\n"),
+			Charms: []string{"cs:trusty/mysql-1"},
+			Tools: []params.SerializedModelTools{{
+				Version: "2.0.0-trusty-amd64",
+				URI:     "/tools/2.0.0-trusty-amd64",
+			}},
+		},
+	}}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "ExportModels")
+			in, ok := args.(params.Entities)
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(in, gc.DeepEquals, params.Entities{[]params.Entity{{testing.ModelTag.String()}}})
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			*res = results
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	out, err := client.ExportModel(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, migration.SerializedModel{
+		Bytes:  []byte("model-uuid: some-uuid\n"),
+		Charms: []string{"cs:trusty/mysql-1"},
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.0.0-trusty-amd64"): "/tools/2.0.0-trusty-amd64",
+		},
+	})
+}
+
+func (s *dumpModelSuite) TestExportModelError(c *gc.C) {
+	results := params.SerializedModelResults{Results: []params.SerializedModelResult{{
+		Error: &params.Error{Message: "fake error"},
+	}}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			*res = results
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(testing.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "fake error")
+}
+
 func (s *dumpModelSuite) TestDumpModelDB(c *gc.C) {
 	expected := map[string]interface{}{
 		"models": []map[string]interface{}{{
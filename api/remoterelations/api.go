@@ -0,0 +1,122 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides access to the RemoteRelations API
+// facade, used by the remote relations worker to relay cross-model
+// relations.
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us test the watch methods properly.
+type NewWatcherFunc func(base.APICaller, params.StringsWatchResult) watcher.StringsWatcher
+
+// NewNotifyWatcherFunc exists to let us test the watch methods properly.
+type NewNotifyWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// API makes calls to the RemoteRelations facade.
+type API struct {
+	caller           base.FacadeCaller
+	newWatcher       NewWatcherFunc
+	newNotifyWatcher NewNotifyWatcherFunc
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc, newNotifyWatcher NewNotifyWatcherFunc) *API {
+	return &API{
+		caller:           base.NewFacadeCaller(caller, "RemoteRelations"),
+		newWatcher:       newWatcher,
+		newNotifyWatcher: newNotifyWatcher,
+	}
+}
+
+// WatchRemoteApplications returns a StringsWatcher that delivers the
+// names of remote applications whose lifecycles have changed.
+func (api *API) WatchRemoteApplications() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := api.caller.FacadeCall("WatchRemoteApplications", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchRemoteApplicationRelations returns a StringsWatcher that
+// delivers the keys of relations involving the named remote
+// application whose lifecycles have changed.
+func (api *API) WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error) {
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.StringsWatchResults
+	err := api.caller.FacadeCall("WatchRemoteApplicationRelations", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchRemoteRelationUnits returns a NotifyWatcher that delivers
+// changes to the units in scope, and their settings, on both sides of
+// the synchronised relation with the supplied key.
+func (api *API) WatchRemoteRelationUnits(key string) (watcher.NotifyWatcher, error) {
+	if !names.IsValidRelation(key) {
+		return nil, errors.NotValidf("relation key %q", key)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewRelationTag(key).String()}},
+	}
+	var results params.NotifyWatchResults
+	err := api.caller.FacadeCall("WatchRemoteRelationUnits", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newNotifyWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// SyncRemoteRelation requests that the relation with the supplied key
+// be synchronised with the model hosting its remote application.
+func (api *API) SyncRemoteRelation(key string) error {
+	if !names.IsValidRelation(key) {
+		return errors.NotValidf("relation key %q", key)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewRelationTag(key).String()}},
+	}
+	var results params.ErrorResults
+	err := api.caller.FacadeCall("SyncRemoteRelations", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
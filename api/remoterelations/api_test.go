@@ -0,0 +1,227 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestWatchRemoteApplicationsError(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "WatchRemoteApplications")
+		return errors.New("blam pow")
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	watcher, err := api.WatchRemoteApplications()
+	c.Check(watcher, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "blam pow")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestWatchRemoteApplicationsSuccess(c *gc.C) {
+	expectResult := params.StringsWatchResult{
+		StringsWatcherId: "123",
+		Changes:          []string{"mysql", "mediawiki"},
+	}
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.StringsWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = expectResult
+		return nil
+	})
+	expectWatcher := &stubWatcher{}
+	newWatcher := func(gotCaller base.APICaller, gotResult params.StringsWatchResult) watcher.StringsWatcher {
+		c.Check(gotCaller, gc.NotNil) // uncomparable
+		c.Check(gotResult, jc.DeepEquals, expectResult)
+		return expectWatcher
+	}
+	api := remoterelations.NewAPI(caller, newWatcher, nil)
+
+	watcher, err := api.WatchRemoteApplications()
+	c.Check(watcher, gc.Equals, expectWatcher)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *APISuite) TestWatchRemoteApplicationRelationsBadArgs(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		panic("should not be called")
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	_, err := api.WatchRemoteApplicationRelations("bad/name")
+	c.Check(err, gc.ErrorMatches, `application name "bad/name" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *APISuite) TestWatchRemoteApplicationRelationsResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.StringsWatchResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "snorble flip"},
+			}},
+		}
+		return nil
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	watcher, err := api.WatchRemoteApplicationRelations("mysql")
+	c.Check(watcher, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func (s *APISuite) TestWatchRemoteApplicationRelationsSuccess(c *gc.C) {
+	expectResult := params.StringsWatchResult{
+		StringsWatcherId: "123",
+		Changes:          []string{"wordpress:db mysql:server"},
+	}
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchRemoteApplicationRelations")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		resultPtr, ok := result.(*params.StringsWatchResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{expectResult},
+		}
+		return nil
+	})
+	expectWatcher := &stubWatcher{}
+	newWatcher := func(gotCaller base.APICaller, gotResult params.StringsWatchResult) watcher.StringsWatcher {
+		c.Check(gotResult, jc.DeepEquals, expectResult)
+		return expectWatcher
+	}
+	api := remoterelations.NewAPI(caller, newWatcher, nil)
+
+	watcher, err := api.WatchRemoteApplicationRelations("mysql")
+	c.Check(watcher, gc.Equals, expectWatcher)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *APISuite) TestWatchRemoteRelationUnitsBadArgs(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		panic("should not be called")
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	_, err := api.WatchRemoteRelationUnits("bad")
+	c.Check(err, gc.ErrorMatches, `relation key "bad" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *APISuite) TestWatchRemoteRelationUnitsResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.NotifyWatchResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "snorble flip"},
+			}},
+		}
+		return nil
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	watcher, err := api.WatchRemoteRelationUnits("wordpress:db mysql:server")
+	c.Check(watcher, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func (s *APISuite) TestWatchRemoteRelationUnitsSuccess(c *gc.C) {
+	expectResult := params.NotifyWatchResult{NotifyWatcherId: "123"}
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchRemoteRelationUnits")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "relation-wordpress.db#mysql.server"}},
+		})
+		resultPtr, ok := result.(*params.NotifyWatchResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{expectResult},
+		}
+		return nil
+	})
+	expectWatcher := &stubNotifyWatcher{}
+	newNotifyWatcher := func(gotCaller base.APICaller, gotResult params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(gotResult, jc.DeepEquals, expectResult)
+		return expectWatcher
+	}
+	api := remoterelations.NewAPI(caller, nil, newNotifyWatcher)
+
+	watcher, err := api.WatchRemoteRelationUnits("wordpress:db mysql:server")
+	c.Check(watcher, gc.Equals, expectWatcher)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *APISuite) TestSyncRemoteRelation(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "SyncRemoteRelations")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "relation-wordpress.db#mysql.server"}},
+		})
+		resultPtr, ok := result.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ErrorResults{Results: []params.ErrorResult{{}}}
+		return nil
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	err := api.SyncRemoteRelation("wordpress:db mysql:server")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestSyncRemoteRelationError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "expect this error"},
+		}}}
+		return nil
+	})
+	api := remoterelations.NewAPI(caller, nil, nil)
+
+	err := api.SyncRemoteRelation("wordpress:db mysql:server")
+	c.Check(err, gc.ErrorMatches, "expect this error")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "RemoteRelations")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+type stubWatcher struct {
+	watcher.StringsWatcher
+}
+
+type stubNotifyWatcher struct {
+	watcher.NotifyWatcher
+}
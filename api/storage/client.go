@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // Client allows access to the storage API end point.
@@ -89,6 +90,32 @@ func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}
 	return c.facade.FacadeCall("CreatePool", args, nil)
 }
 
+// UpdatePool replaces the configuration attributes of the existing pool
+// with the specified name.
+func (c *Client) UpdatePool(pname string, attrs map[string]interface{}) error {
+	args := params.StoragePools{[]params.StoragePool{{
+		Name:  pname,
+		Attrs: attrs,
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdatePools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemovePool removes the pool with the specified name. A pool cannot
+// be removed while it is in use by any volume or filesystem, or named
+// in any application's storage constraints.
+func (c *Client) RemovePool(pname string) error {
+	args := params.StoragePoolNames{[]string{pname}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemovePools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // ListVolumes lists volumes for desired machines.
 // If no machines provided, a list of all volumes is returned.
 func (c *Client) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
@@ -147,3 +174,157 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Attach attaches existing storage instances to the specified unit.
+func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	unitTag := names.NewUnitTag(unitId).String()
+	in := params.StorageAttachmentIds{make([]params.StorageAttachmentId, len(storageIds))}
+	for i, storageId := range storageIds {
+		in.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			UnitTag:    unitTag,
+		}
+	}
+	return c.storageAttachmentsCall("Attach", in)
+}
+
+// Detach detaches the specified storage instances from the units
+// they are attached to, leaving them in the model.
+func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	in := params.StorageAttachmentIds{make([]params.StorageAttachmentId, len(storageIds))}
+	for i, storageId := range storageIds {
+		in.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+		}
+	}
+	return c.storageAttachmentsCall("Detach", in)
+}
+
+func (c *Client) storageAttachmentsCall(method string, in params.StorageAttachmentIds) ([]params.ErrorResult, error) {
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall(method, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(in.Ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(in.Ids), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Remove removes the specified storage instances from the model. If
+// release is true, the storage instances' volumes and filesystems are
+// released from the model rather than destroyed.
+func (c *Client) Remove(storageIds []string, release bool) ([]params.ErrorResult, error) {
+	in := params.RemoveStorage{make([]params.RemoveStorageInstance, len(storageIds))}
+	for i, storageId := range storageIds {
+		in.Storage[i] = params.RemoveStorageInstance{
+			Tag:     names.NewStorageTag(storageId).String(),
+			Release: release,
+		}
+	}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("Remove", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Import imports existing storage into the model.
+// A "CHANGE" block can block this operation.
+func (c *Client) Import(
+	kind storage.StorageKind,
+	storagePool string,
+	storageProviderId string,
+	storageName string,
+) (names.StorageTag, error) {
+	var paramsKind params.StorageKind
+	switch kind {
+	case storage.StorageKindBlock:
+		paramsKind = params.StorageKindBlock
+	case storage.StorageKindFilesystem:
+		paramsKind = params.StorageKindFilesystem
+	}
+	args := params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        paramsKind,
+		Pool:        storagePool,
+		ProviderId:  storageProviderId,
+		StorageName: storageName,
+	}}}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
+
+// CreateSnapshots takes a snapshot of the volume underlying each of the
+// specified storage instances.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.CreateStorageSnapshotResult, error) {
+	in := params.Entities{make([]params.Entity, len(storageIds))}
+	for i, storageId := range storageIds {
+		in.Entities[i].Tag = names.NewStorageTag(storageId).String()
+	}
+	var out params.CreateStorageSnapshotResults
+	if err := c.facade.FacadeCall("CreateSnapshots", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// ListSnapshots returns the snapshots of the volume underlying the
+// specified storage instance.
+func (c *Client) ListSnapshots(storageId string) ([]params.StorageSnapshot, error) {
+	in := params.Entities{[]params.Entity{{names.NewStorageTag(storageId).String()}}}
+	var out params.StorageSnapshotsResults
+	if err := c.facade.FacadeCall("ListSnapshots", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return out.Results[0].Result, nil
+}
+
+// RestoreSnapshot restores the volume underlying the specified storage
+// instance from a snapshot. The storage instance must not be attached.
+func (c *Client) RestoreSnapshot(storageId, snapshotId string) error {
+	in := params.RestoreStorageSnapshots{[]params.RestoreStorageSnapshot{{
+		StorageTag: names.NewStorageTag(storageId).String(),
+		SnapshotId: snapshotId,
+	}}}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("RestoreSnapshots", in, &out); err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
+
+// Resize requests that the volume underlying the specified storage
+// instance be grown to the given size, in MiB. The resize is carried
+// out asynchronously by the storage provisioner.
+func (c *Client) Resize(storageId string, size uint64) error {
+	in := params.StorageResizes{[]params.StorageResize{{
+		StorageTag: names.NewStorageTag(storageId).String(),
+		Size:       size,
+	}}}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", in, &out); err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
 
@@ -581,3 +583,352 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-foo-0", UnitTag: "unit-foo-0"},
+				{StorageTag: "storage-bar-1", UnitTag: "unit-foo-0"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{
+				{nil},
+				{&params.Error{Message: "bar"}},
+			}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	results, err := client.Attach("foo/0", []string{"foo/0", "bar/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{nil},
+		{&params.Error{Message: "bar"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-foo-0"},
+				{StorageTag: "storage-bar-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{
+				{nil},
+				{&params.Error{Message: "bar"}},
+			}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	results, err := client.Detach([]string{"foo/0", "bar/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{nil},
+		{&params.Error{Message: "bar"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetachArityMismatch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{
+				{nil}, {nil}, {nil},
+			}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	_, err := client.Detach([]string{"foo/0", "bar/1"})
+	c.Check(err, gc.ErrorMatches, `expected 2 result\(s\), got 3`)
+}
+
+func (s *storageMockSuite) TestRemove(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Remove")
+			c.Check(a, jc.DeepEquals, params.RemoveStorage{[]params.RemoveStorageInstance{
+				{Tag: "storage-foo-0", Release: true},
+				{Tag: "storage-bar-1", Release: true},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{
+				{nil},
+				{&params.Error{Message: "bar"}},
+			}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	results, err := client.Remove([]string{"foo/0", "bar/1"}, true)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{nil},
+		{&params.Error{Message: "bar"}},
+	})
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{[]params.ImportStorageParams{{
+				Kind:        params.StorageKindFilesystem,
+				Pool:        "foo",
+				ProviderId:  "bar",
+				StorageName: "baz",
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+			*(result.(*params.ImportStorageResults)) = params.ImportStorageResults{[]params.ImportStorageResult{{
+				Result: &params.ImportStorageDetails{StorageTag: "storage-qux-0"},
+			}}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	storageTag, err := client.Import(jujustorage.StorageKindFilesystem, "foo", "bar", "baz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("qux/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ImportStorageResults)) = params.ImportStorageResults{[]params.ImportStorageResult{{
+				Error: &params.Error{Message: "qux"},
+			}}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	_, err := client.Import(jujustorage.StorageKindFilesystem, "foo", "bar", "baz")
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	created := time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{"storage-foo-0"}, {"storage-bar-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.CreateStorageSnapshotResults{})
+			*(result.(*params.CreateStorageSnapshotResults)) = params.CreateStorageSnapshotResults{
+				[]params.CreateStorageSnapshotResult{{
+					Result: &params.StorageSnapshot{
+						SnapshotId: "snap-0",
+						StorageTag: "storage-foo-0",
+						Size:       1024,
+						Created:    created,
+					},
+				}, {
+					Error: &params.Error{Message: "qux"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"foo/0", "bar/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.CreateStorageSnapshotResult{{
+		Result: &params.StorageSnapshot{
+			SnapshotId: "snap-0",
+			StorageTag: "storage-foo-0",
+			Size:       1024,
+			Created:    created,
+		},
+	}, {
+		Error: &params.Error{Message: "qux"},
+	}})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsArityMismatch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/0"})
+	c.Check(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	snapshots := []params.StorageSnapshot{{
+		SnapshotId: "snap-0",
+		StorageTag: "storage-foo-0",
+		Size:       1024,
+		Created:    time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{{"storage-foo-0"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotsResults{})
+			*(result.(*params.StorageSnapshotsResults)) = params.StorageSnapshotsResults{
+				[]params.StorageSnapshotsResult{{Result: snapshots}},
+			}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	result, err := client.ListSnapshots("foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, snapshots)
+}
+
+func (s *storageMockSuite) TestRestoreSnapshot(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RestoreSnapshots")
+			c.Check(a, jc.DeepEquals, params.RestoreStorageSnapshots{[]params.RestoreStorageSnapshot{{
+				StorageTag: "storage-foo-0",
+				SnapshotId: "snap-0",
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{{
+				Error: &params.Error{Message: "qux"},
+			}}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	err := client.RestoreSnapshot("foo/0", "snap-0")
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Resize")
+			c.Check(a, jc.DeepEquals, params.StorageResizes{[]params.StorageResize{{
+				StorageTag: "storage-foo-0",
+				Size:       2048,
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{{
+				Error: &params.Error{Message: "qux"},
+			}}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdatePools")
+			c.Check(a, jc.DeepEquals, params.StoragePools{[]params.StoragePool{{
+				Name:  "pname",
+				Attrs: map[string]interface{}{"foo": "bar"},
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{{
+				Error: &params.Error{Message: "qux"},
+			}}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	err := client.UpdatePool("pname", map[string]interface{}{"foo": "bar"})
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestRemovePool(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RemovePools")
+			c.Check(a, jc.DeepEquals, params.StoragePoolNames{[]string{"pname"}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{[]params.ErrorResult{{
+				Error: &params.Error{Message: "qux"},
+			}}}
+			return nil
+		},
+	)
+	client := storage.NewClient(apiCaller)
+	err := client.RemovePool("pname")
+	c.Check(err, gc.ErrorMatches, "qux")
+}
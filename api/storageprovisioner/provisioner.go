@@ -87,6 +87,24 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeResizes watches for requests to resize volumes scoped
+// to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
+// WatchFilesystemResizes watches for requests to resize filesystems
+// scoped to the entity with the tag passed to NewState.
+func (st *State) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchFilesystemResizes")
+}
+
+// WatchVolumeSnapshotRequests watches for requests to snapshot volumes
+// scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeSnapshotRequests() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshotRequests")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -243,6 +261,66 @@ func (st *State) VolumeParams(tags []names.VolumeTag) ([]params.VolumeParamsResu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for snapshotting the
+// volumes with the specified tags.
+func (st *State) VolumeSnapshotParams(tags []names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// FilesystemResizeParams returns the parameters for resizing the
+// filesystems with the specified tags.
+func (st *State) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.FilesystemResizeParamsResults
+	err := st.facade.FacadeCall("FilesystemResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -349,6 +427,36 @@ func (st *State) SetFilesystemAttachmentInfo(filesystemAttachments []params.File
 	return results.Results, nil
 }
 
+// SetStorageUsage records the usage of volumes and filesystems
+// attached to machines.
+func (st *State) SetStorageUsage(usages []params.MachineStorageUsage) ([]params.ErrorResult, error) {
+	args := params.MachineStorageUsages{Usages: usages}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetStorageUsage", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(usages) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(usages), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshots records the snapshots of volumes, completing
+// any pending snapshot requests.
+func (st *State) SetVolumeSnapshots(snapshots []params.VolumeSnapshots) ([]params.ErrorResult, error) {
+	args := params.SetVolumeSnapshots{Volumes: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // Life requests the life cycle of the entities with the specified tags.
 func (st *State) Life(tags []names.Tag) ([]params.LifeResult, error) {
 	var results params.LifeResults
@@ -3,15 +3,20 @@
 
 package uniter
 
+import "time"
+
 // Action represents a single instance of an Action call, by name and params.
 type Action struct {
-	name   string
-	params map[string]interface{}
+	name     string
+	params   map[string]interface{}
+	parallel bool
+	timeout  time.Duration
 }
 
-// NewAction makes a new Action with specified name and params map.
-func NewAction(name string, params map[string]interface{}) (*Action, error) {
-	return &Action{name: name, params: params}, nil
+// NewAction makes a new Action with specified name, params map,
+// parallel flag and timeout.
+func NewAction(name string, params map[string]interface{}, parallel bool, timeout time.Duration) (*Action, error) {
+	return &Action{name: name, params: params, parallel: parallel, timeout: timeout}, nil
 }
 
 // Name retrieves the name of the Action.
@@ -23,3 +28,15 @@ func (a *Action) Name() string {
 func (a *Action) Params() map[string]interface{} {
 	return a.params
 }
+
+// Parallel returns whether the Action may be run in parallel with other
+// actions, outside the machine lock.
+func (a *Action) Parallel() bool {
+	return a.parallel
+}
+
+// Timeout returns the time the Action is allowed to run for, or zero if
+// it may run indefinitely.
+func (a *Action) Timeout() time.Duration {
+	return a.timeout
+}
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -71,12 +73,25 @@ func (s *actionSuite) TestActionNotFound(c *gc.C) {
 }
 
 func (s *actionSuite) TestNewActionAndAccessors(c *gc.C) {
-	testAction, err := uniter.NewAction("snapshot", basicParams)
+	testAction, err := uniter.NewAction("snapshot", basicParams, true, time.Minute)
 	c.Assert(err, jc.ErrorIsNil)
 	testName := testAction.Name()
 	testParams := testAction.Params()
 	c.Assert(testName, gc.Equals, "snapshot")
 	c.Assert(testParams, gc.DeepEquals, basicParams)
+	c.Assert(testAction.Parallel(), jc.IsTrue)
+	c.Assert(testAction.Timeout(), gc.Equals, time.Minute)
+}
+
+func (s *actionSuite) TestActionTimeout(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Name(), gc.Equals, "fakeaction")
+	c.Assert(retrievedAction.Parallel(), jc.IsFalse)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
 }
 
 func (s *actionSuite) TestActionComplete(c *gc.C) {
@@ -549,7 +549,9 @@ func (u *Unit) WatchConfigSettings() (watcher.NotifyWatcher, error) {
 }
 
 // WatchAddresses returns a watcher for observing changes to the
-// unit's addresses. The unit must be assigned to a machine before
+// unit's addresses, and to its application's endpoint bindings, either
+// of which may change the results of network-get. The unit must be
+// assigned to a machine before
 // this method is called, and the returned watcher will be valid only
 // while the unit's assigned machine is not changed.
 func (u *Unit) WatchAddresses() (watcher.NotifyWatcher, error) {
@@ -12,10 +12,15 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 func init() {
 	common.RegisterStandardFacade("Action", 2, NewActionAPI)
+
+	// Facade version 3 adds WatchActionResults, and AddSchedules,
+	// ListSchedules and RemoveSchedules.
+	common.RegisterStandardFacade("Action", 3, NewActionAPI)
 }
 
 // ActionAPI implements the client API for interacting with Actions
@@ -177,7 +182,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -232,7 +237,8 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running. Actions
+// which have already started cannot be cancelled.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -260,6 +266,10 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		if status := action.Status(); status != state.ActionPending {
+			currentResult.Error = common.ServerError(errors.Errorf("cannot cancel action %q: action is %s", actionTag.Id(), status))
+			continue
+		}
 		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
@@ -276,6 +286,108 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// WatchActionResults starts a StringsWatcher for each given
+// ActionReceiver, notifying of the IDs of its Actions as they finish
+// running, whether completed, failed or cancelled. The initial event
+// holds the IDs of the receiver's Actions that have already finished.
+func (a *ActionAPI) WatchActionResults(arg params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
+	response := params.StringsWatchResults{Results: make([]params.StringsWatchResult, len(arg.Entities))}
+	for i, entity := range arg.Entities {
+		currentResult := &response.Results[i]
+		receiver, err := tagToActionReceiver(entity.Tag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		w := a.state.WatchActionResultsFilteredBy(receiver)
+		// Consume the initial event and forward it to the result.
+		if changes, ok := <-w.Changes(); ok {
+			currentResult.StringsWatcherId = a.resources.Register(w)
+			currentResult.Changes = changes
+		} else {
+			currentResult.Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return response, nil
+}
+
+// AddSchedules adds schedules for enqueueing actions on all the units
+// of applications, returning each schedule added, or an error if there
+// was a problem adding it.
+func (a *ActionAPI) AddSchedules(arg params.ActionSchedules) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	response := params.ActionScheduleResults{Results: make([]params.ActionScheduleResult, len(arg.Schedules))}
+	for i, schedule := range arg.Schedules {
+		currentResult := &response.Results[i]
+		appTag, err := names.ParseApplicationTag(schedule.ApplicationTag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		added, err := a.state.AddActionSchedule(state.AddActionScheduleArgs{
+			Application: appTag.Id(),
+			Name:        schedule.Name,
+			Parameters:  schedule.Parameters,
+			Schedule:    schedule.Schedule,
+		})
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionSchedule(added)
+		currentResult.Schedule = &result
+	}
+	return response, nil
+}
+
+// ListSchedules returns all the action schedules in the model.
+func (a *ActionAPI) ListSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+
+	schedules, err := a.state.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	response := params.ActionSchedules{Schedules: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		response.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return response, nil
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+// Actions already enqueued by the schedules are not affected.
+func (a *ActionAPI) RemoveSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Ids))}
+	for i, id := range arg.Ids {
+		err := a.state.RemoveActionSchedule(id)
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
 // ApplicationsCharmsActions returns a slice of charm Actions for a slice of
 // services.
 func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.ApplicationsCharmActionsResults, error) {
@@ -380,3 +492,16 @@ func runningActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 func completedActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, ar.CompletedActions)
 }
+
+// makeActionSchedule converts a state.ActionSchedule for the API.
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	return params.ActionSchedule{
+		Id:             schedule.Id(),
+		ApplicationTag: names.NewApplicationTag(schedule.Application()).String(),
+		Name:           schedule.Name(),
+		Parameters:     schedule.Parameters(),
+		Schedule:       schedule.Schedule(),
+		NextRun:        schedule.NextRun(),
+		LastRun:        schedule.LastRun(),
+	}
+}
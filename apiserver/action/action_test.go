@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -19,6 +20,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	jujuFactory "github.com/juju/juju/testing/factory"
 )
@@ -273,6 +275,31 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithTimeout(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Timeout:  time.Minute,
+		}, {
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Timeout:  -time.Minute,
+		}},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action.Timeout, gc.Equals, time.Minute)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "negative action timeout -1m0s not valid")
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Timeout(), gc.Equals, time.Minute)
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -630,6 +657,133 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelStartedAction(c *gc.C) {
+	running, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	completed, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = completed.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Cancel(params.Entities{
+		Entities: []params.Entity{
+			{Tag: running.Tag().String()},
+			{Tag: completed.Tag().String()},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot cancel action ".*": action is running`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot cancel action ".*": action is completed`)
+
+	action, err := s.State.ActionByTag(running.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionRunning)
+}
+
+func (s *actionSuite) TestWatchActionResults(c *gc.C) {
+	api, err := action.NewActionAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	completed, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = completed.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.WatchActionResults(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.wordpressUnit.Tag().String()},
+			{Tag: "unit-unknown-0"},
+			{Tag: "invalid"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, jc.SameContents, []string{completed.Id()})
+	expectedError := &params.Error{Message: "id not found", Code: "not found"}
+	c.Assert(results.Results[1].Error, jc.DeepEquals, expectedError)
+	c.Assert(results.Results[2].Error, jc.DeepEquals, expectedError)
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1").(state.StringsWatcher)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	_, err = pending.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(pending.Id())
+	wc.AssertNoChange()
+}
+
+func (s *actionSuite) TestBlockAddSchedules(c *gc.C) {
+	// block all changes
+	s.BlockAllChanges(c, "AddSchedules")
+	_, err := s.action.AddSchedules(params.ActionSchedules{})
+	s.AssertBlocked(c, err, "AddSchedules")
+}
+
+func (s *actionSuite) TestBlockRemoveSchedules(c *gc.C) {
+	// block all changes
+	s.BlockAllChanges(c, "RemoveSchedules")
+	_, err := s.action.RemoveSchedules(params.ActionScheduleIds{})
+	s.AssertBlocked(c, err, "RemoveSchedules")
+}
+
+func (s *actionSuite) TestSchedules(c *gc.C) {
+	wordpressTag := s.wordpress.Tag().String()
+	results, err := s.action.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			ApplicationTag: wordpressTag,
+			Name:           "fakeaction",
+			Parameters:     map[string]interface{}{"foo": "bar"},
+			Schedule:       "@daily",
+		}, {
+			ApplicationTag: wordpressTag,
+			Name:           "fakeaction",
+			Schedule:       "whenever",
+		}, {
+			ApplicationTag: "invalid",
+			Name:           "fakeaction",
+			Schedule:       "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	added := results.Results[0].Schedule
+	c.Assert(added, gc.NotNil)
+	c.Check(added.Id, gc.Equals, "1")
+	c.Check(added.ApplicationTag, gc.Equals, wordpressTag)
+	c.Check(added.Name, gc.Equals, "fakeaction")
+	c.Check(added.Parameters, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	c.Check(added.Schedule, gc.Equals, "@daily")
+	c.Check(added.NextRun.IsZero(), jc.IsFalse)
+	c.Check(added.LastRun.IsZero(), jc.IsTrue)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `.*schedule "whenever" not valid`)
+	c.Check(results.Results[2].Error, jc.DeepEquals, &params.Error{Message: "id not found", Code: "not found"})
+
+	listed, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Schedules, gc.HasLen, 1)
+	c.Check(listed.Schedules[0].Id, gc.Equals, added.Id)
+	c.Check(listed.Schedules[0].Schedule, gc.Equals, added.Schedule)
+
+	removed, err := s.action.RemoveSchedules(params.ActionScheduleIds{Ids: []string{"1", "1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Check(removed.Results[0].Error, gc.IsNil)
+	c.Check(removed.Results[1].Error, gc.ErrorMatches, `action schedule "1" not found`)
+	c.Check(removed.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	listed, err = s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Schedules, gc.HasLen, 0)
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
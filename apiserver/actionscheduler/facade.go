@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Schedule exposes the details of an action schedule required by Facade.
+type Schedule interface {
+	Id() string
+	NextRun() time.Time
+}
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchActionSchedules returns a watcher that notifies when
+	// action schedules are added, removed or run.
+	WatchActionSchedules() state.NotifyWatcher
+
+	// ActionSchedules returns all the action schedules in the model.
+	ActionSchedules() ([]Schedule, error)
+
+	// RunActionSchedule enqueues the action of the identified
+	// schedule, if it is due to run.
+	RunActionSchedule(id string) error
+}
+
+// Facade allows model-manager clients to watch and run action schedules.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// Watch returns a watcher that notifies when action schedules are
+// added, removed or run.
+func (facade *Facade) Watch() (params.NotifyWatchResult, error) {
+	watch := facade.backend.WatchActionSchedules()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// Schedules returns the id and next run time of every action schedule
+// in the model.
+func (facade *Facade) Schedules() (params.ActionSchedules, error) {
+	schedules, err := facade.backend.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, common.ServerError(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = params.ActionSchedule{
+			Id:      schedule.Id(),
+			NextRun: schedule.NextRun(),
+		}
+	}
+	return result, nil
+}
+
+// Run enqueues the actions of the identified schedules which are due
+// to run.
+func (facade *Facade) Run(args params.ActionScheduleIds) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := facade.backend.RunActionSchedule(id)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/actionscheduler"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+	backend   *mockBackend
+	resources *common.Resources
+	facade    *actionscheduler.Facade
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	var err error
+	s.facade, err = actionscheduler.NewFacade(s.backend, s.resources, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(s.backend, s.resources, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatch(c *gc.C) {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	s.backend.watcher = w
+
+	result, err := s.facade.Watch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(s.resources.Get(result.NotifyWatcherId), gc.Equals, w)
+}
+
+func (s *FacadeSuite) TestSchedules(c *gc.C) {
+	t0 := time.Date(2016, 11, 10, 0, 0, 0, 0, time.UTC)
+	s.backend.schedules = []actionscheduler.Schedule{
+		mockSchedule{"1", t0},
+		mockSchedule{"2", t0.Add(time.Hour)},
+	}
+	result, err := s.facade.Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{
+			{Id: "1", NextRun: t0},
+			{Id: "2", NextRun: t0.Add(time.Hour)},
+		},
+	})
+}
+
+func (s *FacadeSuite) TestSchedulesError(c *gc.C) {
+	s.backend.err = errors.New("blammo")
+	_, err := s.facade.Schedules()
+	c.Assert(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *FacadeSuite) TestRun(c *gc.C) {
+	result := s.facade.Run(params.ActionScheduleIds{Ids: []string{"1", "missing", "error"}})
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `action schedule "missing" not found`)
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "blammo")
+	c.Check(s.backend.run, jc.DeepEquals, []string{"1", "missing", "error"})
+}
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthModelManager() bool {
+	return mock.modelManager
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(modelManager bool) facade.Authorizer {
+	return mockAuth{modelManager: modelManager}
+}
+
+type mockSchedule struct {
+	id      string
+	nextRun time.Time
+}
+
+func (s mockSchedule) Id() string {
+	return s.id
+}
+
+func (s mockSchedule) NextRun() time.Time {
+	return s.nextRun
+}
+
+type mockBackend struct {
+	watcher   state.NotifyWatcher
+	schedules []actionscheduler.Schedule
+	run       []string
+	err       error
+}
+
+func (b *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *mockBackend) ActionSchedules() ([]actionscheduler.Schedule, error) {
+	return b.schedules, b.err
+}
+
+func (b *mockBackend) RunActionSchedule(id string) error {
+	b.run = append(b.run, id)
+	switch id {
+	case "missing":
+		return errors.NotFoundf("action schedule %q", id)
+	case "error":
+		return errors.New("blammo")
+	}
+	return nil
+}
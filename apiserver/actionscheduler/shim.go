@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+func init() {
+	common.RegisterStandardFacade("ActionScheduler", 1, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the Facade.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	st *state.State
+}
+
+// WatchActionSchedules is part of the Backend interface.
+func (shim backendShim) WatchActionSchedules() state.NotifyWatcher {
+	return shim.st.WatchActionSchedules()
+}
+
+// ActionSchedules is part of the Backend interface.
+func (shim backendShim) ActionSchedules() ([]Schedule, error) {
+	schedules, err := shim.st.ActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Schedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
+
+// RunActionSchedule is part of the Backend interface.
+func (shim backendShim) RunActionSchedule(id string) error {
+	schedule, err := shim.st.ActionSchedule(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = schedule.Run(shim.st.NowToTheSecond())
+	return errors.Trace(err)
+}
@@ -362,6 +362,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/logsink", logSinkHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/logs", srv.trackRequests(&logExportHandler{
+		ctxt: httpCtxt,
+	}))
 
 	charmsHandler := &charmsHandler{
 		ctxt:    httpCtxt,
@@ -16,10 +16,13 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 	statestorage "github.com/juju/juju/state/storage"
 )
 
@@ -35,6 +38,10 @@ func init() {
 	// Facade version 2 adds support for the ConfigSettings
 	// and StorageConstraints fields in SetCharm.
 	common.RegisterStandardFacade("Application", 2, newAPI)
+
+	// Facade version 3 adds SetEndpointBindings and SetEgressRules,
+	// and support for the ToCIDRs field in Expose.
+	common.RegisterStandardFacade("Application", 3, newAPI)
 }
 
 // API implements the application interface and is the concrete
@@ -49,6 +56,8 @@ type API struct {
 	// should pass a charm.Charm and charm.URL back into
 	// state wherever we pass in a state.Charm currently.
 	stateCharm func(Charm) *state.Charm
+
+	newEnviron func() (environs.Environ, error)
 }
 
 func newAPI(
@@ -59,11 +68,16 @@ func newAPI(
 	backend := NewStateBackend(st)
 	blockChecker := common.NewBlockChecker(st)
 	stateCharm := CharmToStateCharm
+	configGetter := stateenvirons.EnvironConfigGetter{st}
+	newEnviron := func() (environs.Environ, error) {
+		return environs.GetEnviron(configGetter, environs.New)
+	}
 	return NewAPI(
 		backend,
 		authorizer,
 		blockChecker,
 		stateCharm,
+		newEnviron,
 	)
 }
 
@@ -73,6 +87,7 @@ func NewAPI(
 	authorizer facade.Authorizer,
 	blockChecker BlockChecker,
 	stateCharm func(Charm) *state.Charm,
+	newEnviron func() (environs.Environ, error),
 ) (*API, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -82,6 +97,7 @@ func NewAPI(
 		authorizer: authorizer,
 		check:      blockChecker,
 		stateCharm: stateCharm,
+		newEnviron: newEnviron,
 	}, nil
 }
 
@@ -552,7 +568,8 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If any CIDRs are given,
+// the ports are exposed only to those CIDRs.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -564,6 +581,9 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ToCIDRs) > 0 {
+		return app.SetExposedCIDRs(args.ToCIDRs)
+	}
 	return app.SetExposed()
 }
 
@@ -583,6 +603,50 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return app.ClearExposed()
 }
 
+// SetEgressRules restricts the outbound traffic of the machines hosting
+// an application's units to the given rules. If no rules are given, all
+// outbound traffic is allowed. Rules are refused if the model's firewall
+// cannot enforce them.
+func (api *API) SetEgressRules(args params.ApplicationSetEgressRules) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args.Rules) > 0 {
+		if err := api.checkEgressSupported(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	rules := make([]network.EgressRule, len(args.Rules))
+	for i, rule := range args.Rules {
+		rules[i] = rule.NetworkEgressRule()
+	}
+	return app.SetEgressRules(rules)
+}
+
+// checkEgressSupported returns an error satisfying errors.IsNotSupported
+// if the model's firewall cannot restrict outbound traffic.
+func (api *API) checkEgressSupported() error {
+	env, err := api.newEnviron()
+	if err != nil {
+		return errors.Annotate(err, "getting environ")
+	}
+	supported, err := environs.SupportsEgressRules(env)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !supported {
+		return errors.NotSupportedf("restricting outbound traffic in this model")
+	}
+	return nil
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := backend.Application(args.ApplicationName)
@@ -686,6 +750,22 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	return app.SetConstraints(args.Constraints)
 }
 
+// SetEndpointBindings binds the given endpoints of an application to
+// spaces, leaving the bindings of any other endpoints unchanged.
+func (api *API) SetEndpointBindings(args params.ApplicationSetEndpointBindings) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetEndpointBindings(args.Bindings)
+}
+
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
 func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults, error) {
 	if err := api.checkCanWrite(); err != nil {
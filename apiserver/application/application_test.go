@@ -28,9 +28,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -79,10 +82,15 @@ func (s *serviceSuite) SetUpTest(c *gc.C) {
 	s.applicationAPI, err = application.NewAPI(
 		backend, s.authorizer, blockChecker,
 		application.CharmToStateCharm,
+		s.newEnviron,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *serviceSuite) newEnviron() (environs.Environ, error) {
+	return environs.GetEnviron(stateenvirons.EnvironConfigGetter{s.State}, environs.New)
+}
+
 func (s *serviceSuite) TearDownTest(c *gc.C) {
 	s.CharmStoreSuite.TearDownTest(c)
 	s.JujuConnSuite.TearDownTest(c)
@@ -1820,7 +1828,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1832,6 +1840,93 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRs(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"192.168.1.0/24", "10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.1" not valid`)
+}
+
+func (s *serviceSuite) TestServiceSetEgressRules(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+
+	rule := network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8")
+	err := s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "dummy-service",
+		Rules:           []params.EgressRule{params.FromNetworkEgressRule(rule)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := application.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{rule})
+
+	err = s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "dummy-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = application.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "unknown-service",
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-service" not found`)
+}
+
+func (s *serviceSuite) TestServiceSetEgressRulesNotSupported(c *gc.C) {
+	app := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	rule := network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8")
+	err := app.SetEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Hide the environ's EgressFirewaller implementation.
+	api, err := application.NewAPI(
+		application.NewStateBackend(s.State), s.authorizer,
+		common.NewBlockChecker(s.State), application.CharmToStateCharm,
+		func() (environs.Environ, error) {
+			env, err := s.newEnviron()
+			return struct{ environs.Environ }{env}, err
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "dummy-service",
+		Rules:           []params.EgressRule{params.FromNetworkEgressRule(rule)},
+	})
+	c.Assert(err, gc.ErrorMatches, "restricting outbound traffic in this model not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	// Lifting the restriction is always allowed.
+	err = api.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "dummy-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := app.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1872,7 +1967,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1887,7 +1982,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2338,6 +2433,44 @@ func (s *serviceSuite) TestClientSetServiceConstraints(c *gc.C) {
 	c.Assert(obtained, gc.DeepEquals, cons)
 }
 
+func (s *serviceSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	err = s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName: "wordpress",
+		Bindings:        map[string]string{"db": "internal"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["db"], gc.Equals, "internal")
+	c.Assert(bindings["url"], gc.Equals, "")
+}
+
+func (s *serviceSuite) TestSetEndpointBindingsUnknownSpace(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	err := s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName: "wordpress",
+		Bindings:        map[string]string{"db": "missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "wordpress": unknown space "missing" not valid`)
+}
+
+func (s *serviceSuite) TestBlockChangesSetEndpointBindings(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.BlockAllChanges(c, "TestBlockChangesSetEndpointBindings")
+
+	err := s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName: "wordpress",
+		Bindings:        map[string]string{"db": ""},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetEndpointBindings")
+}
+
 func (s *serviceSuite) setupSetServiceConstraints(c *gc.C) (*state.Application, constraints.Value) {
 	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
 	// Update constraints for the application.
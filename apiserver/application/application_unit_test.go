@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -57,6 +58,9 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		func(application.Charm) *state.Charm {
 			return &state.Charm{}
 		},
+		func() (environs.Environ, error) {
+			return nil, errors.NotImplementedf("environ")
+		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -56,7 +57,10 @@ type Application interface {
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEgressRules([]network.EgressRule) error
+	SetEndpointBindings(map[string]string) error
 	SetExposed() error
+	SetExposedCIDRs([]string) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateConfigSettings(charm.Settings) error
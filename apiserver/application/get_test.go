@@ -16,7 +16,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 type getSuite struct {
@@ -40,6 +42,9 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 	s.serviceAPI, err = application.NewAPI(
 		backend, s.authorizer, blockChecker,
 		application.CharmToStateCharm,
+		func() (environs.Environ, error) {
+			return environs.GetEnviron(stateenvirons.EnvironConfigGetter{s.State}, environs.New)
+		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 }
@@ -0,0 +1,155 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationoffers provides the API used by clients to offer
+// application endpoints to other models, and to consume the endpoints
+// offered by other models.
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ApplicationOffers", 1, newAPI)
+}
+
+// API implements the application offers facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+func newAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	return NewAPI(NewStateBackend(st), authorizer)
+}
+
+// NewAPI returns a new application offers API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanWrite() error {
+	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Offer makes the specified application endpoints available to
+// other models hosted by the controller.
+func (api *API) Offer(args params.AddApplicationOffers) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	for i, arg := range args.Offers {
+		offerName := arg.OfferName
+		if offerName == "" {
+			offerName = arg.ApplicationName
+		}
+		err := api.backend.AddApplicationOffer(state.AddApplicationOfferParams{
+			OfferName:       offerName,
+			ApplicationName: arg.ApplicationName,
+			Endpoints:       arg.Endpoints,
+		})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// Consume adds a remote application to the model for each of the
+// specified application offers, through which local applications
+// may relate to the offered endpoints.
+func (api *API) Consume(args params.ConsumeApplicationArgs) (params.ConsumeApplicationResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ConsumeApplicationResults{}, errors.Trace(err)
+	}
+	results := make([]params.ConsumeApplicationResult, len(args.Args))
+	for i, arg := range args.Args {
+		localName, err := api.consumeOne(arg)
+		results[i].LocalName = localName
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ConsumeApplicationResults{Results: results}, nil
+}
+
+func (api *API) consumeOne(arg params.ConsumeApplicationArg) (string, error) {
+	url, err := crossmodel.ParseApplicationURL(arg.ApplicationURL)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	modelTag, err := api.backend.OfferModel(*url)
+	if errors.IsNotFound(err) {
+		// Don't reveal the existence of models
+		// that the user has no access to.
+		return "", common.ErrPerm
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, modelTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	if !canRead {
+		return "", common.ErrPerm
+	}
+	if modelTag == api.backend.ModelTag() {
+		return "", errors.NotSupportedf("consuming an offer from the same model")
+	}
+	localName := arg.ApplicationAlias
+	if localName == "" {
+		localName = url.OfferName
+	}
+	// Consuming an offer that's already been consumed under
+	// the same name is a no-op.
+	existingURL, err := api.backend.RemoteApplicationURL(localName)
+	if err == nil && existingURL == url.String() {
+		return localName, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	eps, err := api.backend.OfferedEndpoints(modelTag, url.OfferName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	relations := make([]charm.Relation, len(eps))
+	for i, ep := range eps {
+		relations[i] = ep.Relation
+	}
+	err = api.backend.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        localName,
+		URL:         url.String(),
+		SourceModel: modelTag,
+		OfferName:   url.OfferName,
+		Endpoints:   relations,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return localName, nil
+}
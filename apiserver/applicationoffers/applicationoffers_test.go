@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ApplicationOffersSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *applicationoffers.API
+}
+
+var _ = gc.Suite(&ApplicationOffersSuite{})
+
+var mysqlEndpoint = state.Endpoint{
+	ApplicationName: "mysql",
+	Relation: charm.Relation{
+		Name:      "server",
+		Role:      charm.RoleProvider,
+		Interface: "mysql",
+		Scope:     charm.ScopeGlobal,
+	},
+}
+
+func (s *ApplicationOffersSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		modelTag:      coretesting.ModelTag,
+		offerModelTag: names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		offeredEps:    []state.Endpoint{mysqlEndpoint},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	var err error
+	s.api, err = applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationOffersSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := applicationoffers.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationOffersSuite) TestOffer(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.Offer(params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			ApplicationName: "mysql",
+			Endpoints:       []string{"server"},
+		}, {
+			OfferName:       "db",
+			ApplicationName: "postgresql",
+			Endpoints:       []string{"db"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"AddApplicationOffer", []interface{}{state.AddApplicationOfferParams{
+			OfferName:       "mysql",
+			ApplicationName: "mysql",
+			Endpoints:       []string{"server"},
+		}}},
+		{"AddApplicationOffer", []interface{}{state.AddApplicationOfferParams{
+			OfferName:       "db",
+			ApplicationName: "postgresql",
+			Endpoints:       []string{"db"},
+		}}},
+	})
+}
+
+func (s *ApplicationOffersSuite) TestConsume(c *gc.C) {
+	results, err := s.api.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{
+			ApplicationURL:   "admin/prod.mysql",
+			ApplicationAlias: "proddb",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ConsumeApplicationResults{
+		Results: []params.ConsumeApplicationResult{{LocalName: "proddb"}},
+	})
+	url := crossmodel.ApplicationURL{User: "admin", ModelName: "prod", OfferName: "mysql"}
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"OfferModel", []interface{}{url}},
+		{"RemoteApplicationURL", []interface{}{"proddb"}},
+		{"OfferedEndpoints", []interface{}{s.backend.offerModelTag, "mysql"}},
+		{"AddRemoteApplication", []interface{}{state.AddRemoteApplicationParams{
+			Name:        "proddb",
+			URL:         "admin/prod.mysql",
+			SourceModel: s.backend.offerModelTag,
+			OfferName:   "mysql",
+			Endpoints:   []charm.Relation{mysqlEndpoint.Relation},
+		}}},
+	})
+}
+
+func (s *ApplicationOffersSuite) TestConsumeAlreadyConsumed(c *gc.C) {
+	s.backend.remoteURLs = map[string]string{
+		"mysql":  "admin/prod.mysql",
+		"proddb": "admin/staging.mysql",
+	}
+	results, err := s.api.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{
+			{ApplicationURL: "admin/prod.mysql"},
+			{ApplicationURL: "admin/prod.mysql", ApplicationAlias: "proddb"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ConsumeApplicationResult{LocalName: "mysql"})
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	s.backend.CheckCallNames(c,
+		"OfferModel", "RemoteApplicationURL",
+		"OfferModel", "RemoteApplicationURL", "OfferedEndpoints", "AddRemoteApplication",
+	)
+}
+
+func (s *ApplicationOffersSuite) TestConsumeModelNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("model admin/prod"))
+	results, err := s.api.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{ApplicationURL: "admin/prod.mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "OfferModel")
+}
+
+func (s *ApplicationOffersSuite) TestConsumeNoAccess(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:         names.NewUserTag("fred"),
+		HasWriteTag: names.NewUserTag("fred"),
+	}
+	api, err := applicationoffers.NewAPI(s.backend, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{ApplicationURL: "admin/prod.mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "OfferModel")
+}
+
+func (s *ApplicationOffersSuite) TestConsumeInvalidURL(c *gc.C) {
+	results, err := s.api.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{ApplicationURL: "mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `application URL "mysql": missing user not valid`)
+	s.backend.CheckNoCalls(c)
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the application
+// offers facade. For details on the methods, see the methods on
+// state.State with the same names.
+type Backend interface {
+	AddApplicationOffer(state.AddApplicationOfferParams) error
+	AddRemoteApplication(state.AddRemoteApplicationParams) error
+	ModelTag() names.ModelTag
+
+	// OfferModel returns the tag of the model hosting the application
+	// offer with the supplied URL.
+	OfferModel(crossmodel.ApplicationURL) (names.ModelTag, error)
+
+	// OfferedEndpoints returns the endpoints offered under the named
+	// application offer in the specified model.
+	OfferedEndpoints(modelTag names.ModelTag, offerName string) ([]state.Endpoint, error)
+
+	// RemoteApplicationURL returns the URL of the offer consumed by
+	// the named remote application.
+	RemoteApplicationURL(name string) (string, error)
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) AddApplicationOffer(args state.AddApplicationOfferParams) error {
+	_, err := s.State.AddApplicationOffer(args)
+	return err
+}
+
+func (s stateShim) AddRemoteApplication(args state.AddRemoteApplicationParams) error {
+	_, err := s.State.AddRemoteApplication(args)
+	return err
+}
+
+func (s stateShim) OfferModel(url crossmodel.ApplicationURL) (names.ModelTag, error) {
+	owner := names.NewUserTag(url.User)
+	models, err := s.AllModels()
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	for _, model := range models {
+		if model.Name() == url.ModelName && model.Owner().Id() == owner.Id() {
+			return model.ModelTag(), nil
+		}
+	}
+	return names.ModelTag{}, errors.NotFoundf("model %s/%s", url.User, url.ModelName)
+}
+
+func (s stateShim) OfferedEndpoints(modelTag names.ModelTag, offerName string) ([]state.Endpoint, error) {
+	st, err := s.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Close()
+	offer, err := st.ApplicationOffer(offerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return offer.Endpoints()
+}
+
+func (s stateShim) RemoteApplicationURL(name string) (string, error) {
+	app, err := s.RemoteApplication(name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return app.URL(), nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	testing.Stub
+	modelTag      names.ModelTag
+	offerModelTag names.ModelTag
+	offeredEps    []state.Endpoint
+	remoteURLs    map[string]string
+}
+
+func (m *mockBackend) AddApplicationOffer(args state.AddApplicationOfferParams) error {
+	m.MethodCall(m, "AddApplicationOffer", args)
+	return m.NextErr()
+}
+
+func (m *mockBackend) AddRemoteApplication(args state.AddRemoteApplicationParams) error {
+	m.MethodCall(m, "AddRemoteApplication", args)
+	return m.NextErr()
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return m.modelTag
+}
+
+func (m *mockBackend) OfferModel(url crossmodel.ApplicationURL) (names.ModelTag, error) {
+	m.MethodCall(m, "OfferModel", url)
+	if err := m.NextErr(); err != nil {
+		return names.ModelTag{}, err
+	}
+	return m.offerModelTag, nil
+}
+
+func (m *mockBackend) OfferedEndpoints(modelTag names.ModelTag, offerName string) ([]state.Endpoint, error) {
+	m.MethodCall(m, "OfferedEndpoints", modelTag, offerName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.offeredEps, nil
+}
+
+func (m *mockBackend) RemoteApplicationURL(name string) (string, error) {
+	m.MethodCall(m, "RemoteApplicationURL", name)
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	url, ok := m.remoteURLs[name]
+	if !ok {
+		return "", errors.NotFoundf("remote application %q", name)
+	}
+	return url, nil
+}
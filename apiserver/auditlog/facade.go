@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog implements the API endpoint used by Juju clients
+// to query the controller's audit log.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/permission"
+)
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, newFacade)
+}
+
+// Facade implements the API used to query the audit log.
+type Facade struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// New returns a new API facade for querying the audit log. Only
+// controller superusers may query the audit log.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend, authorizer: authorizer}, nil
+}
+
+func (facade *Facade) checkIsSuperuser() error {
+	isSuperuser, err := facade.authorizer.HasPermission(permission.SuperuserAccess, facade.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isSuperuser {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Query returns the audit log entries matching the supplied query,
+// ordered from oldest to newest.
+func (facade *Facade) Query(args params.AuditLogQuery) (params.AuditLogEntries, error) {
+	if err := facade.checkIsSuperuser(); err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+
+	query := audit.Query{
+		ModelUUID:  args.ModelUUID,
+		OriginType: args.OriginType,
+		OriginName: args.OriginName,
+		Operation:  args.Operation,
+		Limit:      args.Limit,
+	}
+	if args.After != nil {
+		query.After = *args.After
+	}
+	if args.Before != nil {
+		query.Before = *args.Before
+	}
+	if err := query.Validate(); err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+
+	entries, err := facade.backend.AuditEntries(query)
+	if err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+	result := params.AuditLogEntries{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *auditlog.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.backend = &mockBackend{}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	facade, err := auditlog.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestMachineAuthNotAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestQueryNotSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.facade.Query(params.AuditLogQuery{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *facadeSuite) TestQuery(c *gc.C) {
+	after := testing.NonZeroTime().UTC()
+	before := after.Add(time.Hour)
+	s.backend.entries = []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         testing.ModelTag.Id(),
+		Timestamp:         after.Add(time.Minute),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Client.FullStatus",
+		Data:              map[string]interface{}{"foo": "bar"},
+	}}
+
+	result, err := s.facade.Query(params.AuditLogQuery{
+		ModelUUID: testing.ModelTag.Id(),
+		Operation: "Client.FullStatus",
+		After:     &after,
+		Before:    &before,
+		Limit:     10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			JujuServerVersion: version.MustParse("2.0.1"),
+			ModelUUID:         testing.ModelTag.Id(),
+			Timestamp:         after.Add(time.Minute),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-admin",
+			Operation:         "Client.FullStatus",
+			Data:              map[string]interface{}{"foo": "bar"},
+		}},
+	})
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"ControllerTag", nil},
+		{"AuditEntries", []interface{}{audit.Query{
+			ModelUUID: testing.ModelTag.Id(),
+			Operation: "Client.FullStatus",
+			After:     after,
+			Before:    before,
+			Limit:     10,
+		}}},
+	})
+}
+
+func (s *facadeSuite) TestQueryInvalid(c *gc.C) {
+	_, err := s.facade.Query(params.AuditLogQuery{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative Limit not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *facadeSuite) TestQueryError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.facade.Query(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.backend.CheckCallNames(c, "ControllerTag", "AuditEntries")
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	entries []audit.AuditEntry
+}
+
+func (m *mockBackend) ControllerTag() names.ControllerTag {
+	m.MethodCall(m, "ControllerTag")
+	return testing.ControllerTag
+}
+
+func (m *mockBackend) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	m.MethodCall(m, "AuditEntries", query)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.entries, nil
+}
@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the auditlog facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	AuditEntries(audit.Query) ([]audit.AuditEntry, error)
+}
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return New(st, res, auth)
+}
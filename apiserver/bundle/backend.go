@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Export() (description.Model, error)
+}
+
+// NewStateBackend creates a backend for the facade to use.
+func NewStateBackend(st *state.State) Backend {
+	return st
+}
@@ -22,18 +22,22 @@ import (
 // init registers the Bundle facade.
 func init() {
 	common.RegisterStandardFacade("Bundle", 1, newFacade)
+	common.RegisterStandardFacade("Bundle", 2, newFacade)
 }
 
-func newFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewFacade(auth)
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
+	return NewFacade(auth, NewStateBackend(st))
 }
 
 // NewFacade creates and returns a new Bundle API facade.
-func NewFacade(auth facade.Authorizer) (Bundle, error) {
+func NewFacade(auth facade.Authorizer, backend Backend) (Bundle, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &bundleAPI{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
 }
 
 // Bundle defines the API endpoint used to retrieve bundle changes.
@@ -41,11 +45,17 @@ type Bundle interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
+
+	// ExportBundle returns the current model serialised as bundle YAML.
+	ExportBundle() (params.StringResult, error)
 }
 
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
-type bundleAPI struct{}
+type bundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
@@ -16,7 +16,8 @@ import (
 
 type bundleSuite struct {
 	coretesting.BaseSuite
-	facade bundle.Bundle
+	backend *mockBackend
+	facade  bundle.Bundle
 }
 
 var _ = gc.Suite(&bundleSuite{})
@@ -26,7 +27,8 @@ func (s *bundleSuite) SetUpTest(c *gc.C) {
 	auth := apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("who"),
 	}
-	facade, err := bundle.NewFacade(auth)
+	s.backend = &mockBackend{}
+	facade, err := bundle.NewFacade(auth, s.backend)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
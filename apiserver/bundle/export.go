@@ -0,0 +1,243 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// ExportBundle exports the current model as bundle YAML which can be
+// passed to "juju deploy" to recreate the applications, machines and
+// relations of the model.
+func (b *bundleAPI) ExportBundle() (params.StringResult, error) {
+	var result params.StringResult
+	if err := b.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	model, err := b.backend.Export()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	data, err := bundleDataFromModel(model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	bytes, err := yaml.Marshal(data)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Result = string(bytes)
+	return result, nil
+}
+
+func (b *bundleAPI) checkCanRead() error {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// bundleDataFromModel converts the exported model into bundle data.
+// Only top level machines which host units are included in the bundle.
+// The first unit placed in a container creates it, with a
+// "<container-type>:<machine-id>" directive; later units in the same
+// container are placed with that unit, so that they share it again
+// when the bundle is deployed. Bundles cannot express nested
+// containers, so a unit in one cannot be exported.
+func bundleDataFromModel(model description.Model) (*charm.BundleData, error) {
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	if series, ok := model.Config()["default-series"].(string); ok {
+		data.Series = series
+	}
+
+	machines := make(map[string]description.Machine)
+	for _, machine := range model.Machines() {
+		machines[machine.Id()] = machine
+	}
+	// containerUnits maps the id of each container to the bundle
+	// placement of the first unit exported into it.
+	containerUnits := make(map[string]string)
+
+	// Applications are exported in name order, so that the unit which
+	// creates each shared container is chosen consistently.
+	applications := model.Applications()
+	sort.Sort(applicationsByName(applications))
+	for _, application := range applications {
+		spec := &charm.ApplicationSpec{
+			Charm:            application.CharmURL(),
+			Series:           application.Series(),
+			Expose:           application.Exposed(),
+			Options:          application.Settings(),
+			Annotations:      application.Annotations(),
+			Constraints:      constraintsString(application.Constraints()),
+			EndpointBindings: application.EndpointBindings(),
+		}
+		if storage := application.StorageConstraints(); len(storage) > 0 {
+			spec.Storage = make(map[string]string)
+			for name, cons := range storage {
+				spec.Storage[name] = storageDirective(cons)
+			}
+		}
+		if !application.Subordinate() {
+			units := application.Units()
+			sort.Sort(unitsByNumber(units))
+			spec.NumUnits = len(units)
+			for i, unit := range units {
+				placement, err := unitPlacement(unit, containerUnits)
+				if err != nil {
+					return nil, errors.Annotatef(err, "unit %q", unit.Name())
+				}
+				if machineId := unit.Machine().Id(); names.IsContainerMachine(machineId) {
+					if _, ok := containerUnits[machineId]; !ok {
+						containerUnits[machineId] = fmt.Sprintf("%s/%d", application.Name(), i)
+					}
+				}
+				topLevel := state.TopParentId(unit.Machine().Id())
+				machine, ok := machines[topLevel]
+				if !ok {
+					return nil, errors.NotFoundf("machine %q for unit %q", topLevel, unit.Name())
+				}
+				if _, ok := data.Machines[topLevel]; !ok {
+					data.Machines[topLevel] = &charm.MachineSpec{
+						Series:      machine.Series(),
+						Constraints: constraintsString(machine.Constraints()),
+						Annotations: machine.Annotations(),
+					}
+				}
+				spec.To = append(spec.To, placement)
+			}
+		}
+		data.Applications[application.Name()] = spec
+	}
+
+	for _, relation := range model.Relations() {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established automatically.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].ApplicationName() + ":" + endpoints[0].Name(),
+			endpoints[1].ApplicationName() + ":" + endpoints[1].Name(),
+		})
+	}
+	return data, nil
+}
+
+// unitPlacement returns the bundle placement directive for the unit:
+// a top level machine id, a "<container-type>:<machine-id>" directive
+// for a new container, or the placement of another unit already
+// exported into the same container.
+func unitPlacement(unit description.Unit, containerUnits map[string]string) (string, error) {
+	machineId := unit.Machine().Id()
+	if machineId == "" {
+		return "", errors.NotAssignedf("unit %q", unit.Name())
+	}
+	if !names.IsContainerMachine(machineId) {
+		return machineId, nil
+	}
+	if state.NestingLevel(machineId) > 1 {
+		return "", errors.NotSupportedf("nested container %q in bundle", machineId)
+	}
+	if placement, ok := containerUnits[machineId]; ok {
+		return placement, nil
+	}
+	containerType := state.ContainerTypeFromId(machineId)
+	return fmt.Sprintf("%s:%s", containerType, state.TopParentId(machineId)), nil
+}
+
+// storageDirective returns the storage constraints in the
+// "[pool,][count,][size]" form used by bundles. The pool is left out
+// if it is not set, so that the model's default pool is used.
+func storageDirective(cons description.StorageConstraint) string {
+	directive := fmt.Sprintf("%d,%dM", cons.Count(), cons.Size())
+	if pool := cons.Pool(); pool != "" {
+		directive = pool + "," + directive
+	}
+	return directive
+}
+
+// constraintsString returns the constraints in the textual form
+// used by bundles, or an empty string if there are none.
+func constraintsString(cons description.Constraints) string {
+	var result constraints.Value
+	if cons == nil {
+		return ""
+	}
+	if arch := cons.Architecture(); arch != "" {
+		result.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		result.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		result.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		result.CpuPower = &power
+	}
+	if inst := cons.InstanceType(); inst != "" {
+		result.InstanceType = &inst
+	}
+	if mem := cons.Memory(); mem != 0 {
+		result.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		result.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		result.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		result.Tags = &tags
+	}
+	if virt := cons.VirtType(); virt != "" {
+		result.VirtType = &virt
+	}
+	return result.String()
+}
+
+type applicationsByName []description.Application
+
+func (a applicationsByName) Len() int           { return len(a) }
+func (a applicationsByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a applicationsByName) Less(i, j int) bool { return a[i].Name() < a[j].Name() }
+
+type unitsByNumber []description.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i]) < unitNumber(u[j])
+}
+
+func unitNumber(unit description.Unit) int {
+	name := unit.Name()
+	number, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return number
+}
@@ -0,0 +1,257 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/description"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type exportBundleSuite struct {
+	coretesting.BaseSuite
+	backend *mockBackend
+	facade  bundle.Bundle
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{model: s.newModel()}
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	facade, err := bundle.NewFacade(auth, s.backend)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleSuite) newModel() description.Model {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"default-series": "xenial",
+		},
+	})
+	machine0 := model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+	})
+	machine0.AddContainer(description.MachineArgs{
+		Id:            names.NewMachineTag("0/kvm/0"),
+		Series:        "xenial",
+		ContainerType: "kvm",
+	})
+	machine := model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("1"),
+		Series: "trusty",
+	})
+	machine.SetConstraints(description.ConstraintsArgs{
+		Memory: 4096,
+	})
+	machine.AddContainer(description.MachineArgs{
+		Id:            names.NewMachineTag("1/lxd/0"),
+		Series:        "xenial",
+		ContainerType: "lxd",
+	})
+
+	wordpress := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/wordpress-5",
+		Exposed:  true,
+		EndpointBindings: map[string]string{
+			"db": "internal",
+		},
+		Settings: map[string]interface{}{
+			"blog-title": "engineering",
+		},
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"uploads": {Pool: "ebs", Size: 1024, Count: 1},
+		},
+	})
+	wordpress.SetConstraints(description.ConstraintsArgs{
+		CpuCores: 2,
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/1"),
+		Machine: names.NewMachineTag("1/lxd/0"),
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/2"),
+		Machine: names.NewMachineTag("0/kvm/0"),
+	})
+
+	mysql := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		Series:   "trusty",
+		CharmURL: "cs:trusty/mysql-57",
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"data": {Size: 2048, Count: 1},
+		},
+	})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("1"),
+	})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/1"),
+		Machine: names.NewMachineTag("1/lxd/0"),
+	})
+
+	model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("logging"),
+		Series:      "xenial",
+		Subordinate: true,
+		CharmURL:    "cs:xenial/logging-1",
+	})
+
+	relation := model.AddRelation(description.RelationArgs{
+		Id:  1,
+		Key: "wordpress:db mysql:server",
+	})
+	relation.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "wordpress",
+		Name:            "db",
+	})
+	relation.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "server",
+	})
+	peer := model.AddRelation(description.RelationArgs{
+		Id:  2,
+		Key: "mysql:cluster",
+	})
+	peer.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "cluster",
+	})
+	return model
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelTag", "Export")
+
+	var data charm.BundleData
+	err = yaml.Unmarshal([]byte(result.Result), &data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, charm.BundleData{
+		Series: "xenial",
+		Applications: map[string]*charm.ApplicationSpec{
+			"wordpress": {
+				Charm:       "cs:xenial/wordpress-5",
+				Series:      "xenial",
+				NumUnits:    3,
+				To:          []string{"0", "mysql/1", "kvm:0"},
+				Expose:      true,
+				Constraints: "cores=2",
+				Options: map[string]interface{}{
+					"blog-title": "engineering",
+				},
+				Storage: map[string]string{
+					"uploads": "ebs,1,1024M",
+				},
+				EndpointBindings: map[string]string{
+					"db": "internal",
+				},
+			},
+			"mysql": {
+				Charm:    "cs:trusty/mysql-57",
+				Series:   "trusty",
+				NumUnits: 2,
+				To:       []string{"1", "lxd:1"},
+				Storage: map[string]string{
+					"data": "1,2048M",
+				},
+			},
+			"logging": {
+				Charm:  "cs:xenial/logging-1",
+				Series: "xenial",
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {Series: "xenial"},
+			"1": {Series: "trusty", Constraints: "mem=4096M"},
+		},
+		Relations: [][]string{
+			{"wordpress:db", "mysql:server"},
+		},
+	})
+}
+
+func (s *exportBundleSuite) TestExportBundleRoundTripsThroughGetChanges(c *gc.C) {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err := s.facade.GetChanges(params.BundleChangesParams{
+		BundleDataYAML: result.Result,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Errors, gc.HasLen, 0)
+	c.Assert(changes.Changes, gc.Not(gc.HasLen), 0)
+}
+
+func (s *exportBundleSuite) TestExportBundleNestedContainer(c *gc.C) {
+	model := s.newModel()
+	machine := model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("2"),
+		Series: "xenial",
+	})
+	container := machine.AddContainer(description.MachineArgs{
+		Id:            names.NewMachineTag("2/lxd/0"),
+		Series:        "xenial",
+		ContainerType: "lxd",
+	})
+	container.AddContainer(description.MachineArgs{
+		Id:            names.NewMachineTag("2/lxd/0/kvm/0"),
+		Series:        "xenial",
+		ContainerType: "kvm",
+	})
+	application := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("nested"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/nested-1",
+	})
+	application.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("nested/0"),
+		Machine: names.NewMachineTag("2/lxd/0/kvm/0"),
+	})
+	s.backend.model = model
+
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, `unit "nested/0": nested container "2/lxd/0/kvm/0" in bundle not supported`)
+}
+
+func (s *exportBundleSuite) TestExportBundleError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *exportBundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("who"),
+	}
+	facade, err := bundle.NewFacade(auth, s.backend)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
@@ -0,0 +1,30 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	model description.Model
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) Export() (description.Model, error) {
+	m.MethodCall(m, "Export")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.model, nil
+}
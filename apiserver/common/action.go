@@ -153,6 +153,8 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 		results.Results[i].Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Parallel:   action.Parallel(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -239,6 +241,9 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Parallel:   action.Parallel(),
+			Timeout:    action.Timeout(),
+			Schedule:   action.Schedule(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
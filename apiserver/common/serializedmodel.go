@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+)
+
+// SerializeModel serializes the given model description, and lists the
+// charms and tools it uses so that they can be sent along with it.
+func SerializeModel(model description.Model) (params.SerializedModel, error) {
+	var serialized params.SerializedModel
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
+	serialized.Bytes = bytes
+	serialized.Charms = getUsedCharms(model)
+	serialized.Tools = getUsedTools(model)
+	return serialized, nil
+}
+
+func getUsedCharms(model description.Model) []string {
+	result := set.NewStrings()
+	for _, application := range model.Applications() {
+		result.Add(application.CharmURL())
+	}
+	return result.Values()
+}
+
+func getUsedTools(model description.Model) []params.SerializedModelTools {
+	// Iterate through the model for all tools, and make a map of them.
+	usedVersions := make(map[version.Binary]bool)
+	// It is most likely that the preconditions will limit the number of
+	// tools versions in use, but that is not relied on here.
+	for _, machine := range model.Machines() {
+		addToolsVersionForMachine(machine, usedVersions)
+	}
+
+	for _, application := range model.Applications() {
+		for _, unit := range application.Units() {
+			tools := unit.Tools()
+			usedVersions[tools.Version()] = true
+		}
+	}
+
+	out := make([]params.SerializedModelTools, 0, len(usedVersions))
+	for v := range usedVersions {
+		out = append(out, params.SerializedModelTools{
+			Version: v.String(),
+			URI:     ToolsURL("", v),
+		})
+	}
+	return out
+}
+
+func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
+	tools := machine.Tools()
+	usedVersions[tools.Version()] = true
+	for _, container := range machine.Containers() {
+		addToolsVersionForMachine(container, usedVersions)
+	}
+}
@@ -103,9 +103,9 @@ func FilesystemFromState(f state.Filesystem) (params.Filesystem, error) {
 		return params.Filesystem{}, errors.Trace(err)
 	}
 	result := params.Filesystem{
-		f.FilesystemTag().String(),
-		"",
-		FilesystemInfoFromState(info),
+		FilesystemTag: f.FilesystemTag().String(),
+		Info:          FilesystemInfoFromState(info),
+		Releasing:     f.Releasing(),
 	}
 	volumeTag, err := f.Volume()
 	if err == nil {
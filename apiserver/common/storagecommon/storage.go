@@ -42,6 +42,12 @@ type StorageInterface interface {
 	// corresponding to the identfified unit and storage instance.
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) state.NotifyWatcher
 
+	// WatchVolume watches for changes to the specified volume.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
+	// WatchFilesystem watches for changes to the specified filesystem.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchFilesystemAttachment watches for changes to the filesystem
 	// attachment corresponding to the identfified machine and filesystem.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
@@ -131,8 +137,9 @@ func volumeStorageAttachmentInfo(
 		return nil, errors.Trace(err)
 	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindBlock,
-		devicePath,
+		Kind:     storage.StorageKindBlock,
+		Location: devicePath,
+		Size:     volumeInfo.Size,
 	}, nil
 }
 
@@ -154,15 +161,20 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindFilesystem,
-		filesystemAttachmentInfo.MountPoint,
+		Kind:     storage.StorageKindFilesystem,
+		Location: filesystemAttachmentInfo.MountPoint,
+		Size:     filesystemInfo.Size,
 	}, nil
 }
 
 // WatchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the
-// tags specified.
+// tags specified, and to the size of the volume or filesystem.
 func WatchStorageAttachment(
 	st StorageInterface,
 	storageTag names.StorageTag,
@@ -183,7 +195,9 @@ func WatchStorageAttachment(
 		// We need to watch both the volume attachment, and the
 		// machine's block devices. A volume attachment's block
 		// device could change (most likely, become present).
+		// The volume is watched for changes to its size.
 		watchers = []state.NotifyWatcher{
+			st.WatchVolume(volume.VolumeTag()),
 			st.WatchVolumeAttachment(machineTag, volume.VolumeTag()),
 			// TODO(axw) 2015-09-30 #1501203
 			// We should filter the events to only those relevant
@@ -200,6 +214,7 @@ func WatchStorageAttachment(
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
 		watchers = []state.NotifyWatcher{
+			st.WatchFilesystem(filesystem.FilesystemTag()),
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
 		}
 	default:
@@ -263,7 +278,9 @@ func storageTags(
 	)
 	if storageInstance != nil {
 		storageTags[tags.JujuStorageInstance] = storageInstance.Tag().Id()
-		storageTags[tags.JujuStorageOwner] = storageInstance.Owner().Id()
+		if owner, ok := storageInstance.Owner(); ok {
+			storageTags[tags.JujuStorageOwner] = owner.Id()
+		}
 	}
 	return storageTags, nil
 }
@@ -84,6 +84,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDeviceNa
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/sda"),
+		Size:     1024,
 	})
 }
 
@@ -105,6 +106,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDeviceLi
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/disk/by-id/verbatim",
+		Size:     1024,
 	})
 }
 
@@ -116,6 +118,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentHardware
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/disk/by-id/whatever"),
+		Size:     1024,
 	})
 }
 
@@ -136,6 +139,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBlockDevic
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/sdb"),
+		Size:     1024,
 	})
 }
 
@@ -156,6 +160,7 @@ type watchStorageAttachmentSuite struct {
 	st                       *fakeStorage
 	storageInstance          *fakeStorageInstance
 	volume                   *fakeVolume
+	volumeWatcher            *apiservertesting.FakeNotifyWatcher
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
@@ -173,6 +178,7 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		kind:  state.StorageKindBlock,
 	}
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
+	s.volumeWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.volumeAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
@@ -183,6 +189,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		storageInstanceVolume: func(tag names.StorageTag) (state.Volume, error) {
 			return s.volume, nil
 		},
+		watchVolume: func(names.VolumeTag) state.NotifyWatcher {
+			return s.volumeWatcher
+		},
 		watchVolumeAttachment: func(names.MachineTag, names.VolumeTag) state.NotifyWatcher {
 			return s.volumeAttachmentWatcher
 		},
@@ -195,6 +204,12 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	}
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeChanges(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.volumeWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeAttachmentChanges(c *gc.C) {
 	s.testWatchBlockStorageAttachment(c, func() {
 		s.volumeAttachmentWatcher.C <- struct{}{}
@@ -218,6 +233,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 	s.st.CheckCallNames(c,
 		"StorageInstance",
 		"StorageInstanceVolume",
+		"WatchVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
@@ -66,6 +66,7 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		"",  // encryption key set by the caller
 	}, nil
 }
 
@@ -119,6 +120,7 @@ func VolumeToState(v params.Volume) (names.VolumeTag, state.VolumeInfo, error) {
 		"", // pool is set by state
 		v.Info.VolumeId,
 		v.Info.Persistent,
+		false, // encryption is set by state
 	}, nil
 }
 
@@ -129,8 +131,9 @@ func VolumeFromState(v state.Volume) (params.Volume, error) {
 		return params.Volume{}, errors.Trace(err)
 	}
 	return params.Volume{
-		v.VolumeTag().String(),
-		VolumeInfoFromState(info),
+		VolumeTag: v.VolumeTag().String(),
+		Info:      VolumeInfoFromState(info),
+		Releasing: v.Releasing(),
 	}, nil
 }
 
@@ -7,6 +7,7 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/juju/errors"
@@ -33,6 +34,9 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+
+	// Facade version 4 adds MigrationPrechecks.
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPI)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -48,6 +52,7 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
+	MigrationPrechecks(params.InitiateMigrationArgs) (params.MigrationPrecheckResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
 }
 
@@ -406,29 +411,9 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	}
 	defer hostedState.Close()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
-	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
-	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
-	}
-	authTag, err := names.ParseUserTag(specTarget.AuthTag)
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
-	}
-	var macs []macaroon.Slice
-	if specTarget.Macaroons != "" {
-		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
-		}
-	}
-	targetInfo := coremigration.TargetInfo{
-		ControllerTag: controllerTag,
-		Addrs:         specTarget.Addrs,
-		CACert:        specTarget.CACert,
-		AuthTag:       authTag,
-		Password:      specTarget.Password,
-		Macaroons:     macs,
+		return "", errors.Trace(err)
 	}
 
 	// Check if the migration is likely to succeed.
@@ -450,6 +435,83 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	return mig.Id(), nil
 }
 
+// MigrationPrechecks runs the migration prechecks of the source and
+// target controllers for one or more models, reporting the problems
+// that would prevent their migration. No migrations are started.
+func (c *ControllerAPI) MigrationPrechecks(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		sourceBlockers, targetBlockers, err := c.precheckOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.SourceBlockers = sourceBlockers
+			result.TargetBlockers = targetBlockers
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) precheckOneMigration(spec params.MigrationSpec) ([]string, []string, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, nil, errors.Annotate(err, "unable to read model")
+	}
+
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return reportMigrationPrechecks(hostedState, targetInfo)
+}
+
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
+	if err != nil {
+		return empty, errors.Annotate(err, "controller tag")
+	}
+	authTag, err := names.ParseUserTag(specTarget.AuthTag)
+	if err != nil {
+		return empty, errors.Annotate(err, "auth tag")
+	}
+	var macs []macaroon.Slice
+	if specTarget.Macaroons != "" {
+		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
+			return empty, errors.Annotate(err, "invalid macaroons")
+		}
+	}
+	return coremigration.TargetInfo{
+		ControllerTag: controllerTag,
+		Addrs:         specTarget.Addrs,
+		CACert:        specTarget.CACert,
+		AuthTag:       authTag,
+		Password:      specTarget.Password,
+		Macaroons:     macs,
+	}, nil
+}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -508,6 +570,46 @@ var runMigrationPrechecks = func(st *state.State, targetInfo coremigration.Targe
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// reportMigrationPrechecks returns the problems found by the prechecks
+// of the source and target controllers. Failing to connect to the
+// target controller, or lacking permission to migrate models into it,
+// are reported as problems with the target.
+var reportMigrationPrechecks = func(st *state.State, targetInfo coremigration.TargetInfo) ([]string, []string, error) {
+	sourceBlockers := migration.SourcePrecheckReport(migration.PrecheckShim(st))
+
+	modelInfo, err := makeModelInfo(st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return sourceBlockers, []string{
+			fmt.Sprintf("cannot connect to target controller: %v", err),
+		}, nil
+	}
+	defer conn.Close()
+	client := migrationtarget.NewClient(conn)
+	var targetBlockers []string
+	if client.BestAPIVersion() >= 2 {
+		targetBlockers, err = client.PrecheckReport(modelInfo)
+	} else {
+		// Older target controllers can only report the first
+		// problem found.
+		err = client.Prechecks(modelInfo)
+		if err != nil && !params.IsCodeUnauthorized(err) {
+			targetBlockers, err = []string{err.Error()}, nil
+		}
+	}
+	if params.IsCodeUnauthorized(err) {
+		targetBlockers = []string{fmt.Sprintf(
+			"user %q is not a superuser of the target controller", targetInfo.AuthTag.Id(),
+		)}
+	} else if err != nil {
+		return nil, nil, errors.Annotate(err, "target prechecks failed")
+	}
+	return sourceBlockers, targetBlockers, nil
+}
+
 func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -547,6 +547,65 @@ func (s *controllerSuite) TestInitiateMigrationSkipPrechecks(c *gc.C) {
 	c.Check(out.Results[0].Error, gc.IsNil)
 }
 
+func (s *controllerSuite) TestMigrationPrechecks(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckReport(s,
+		[]string{"machine 0 is dying"},
+		[]string{"upgrade in progress"},
+		nil,
+	)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: st.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationPrecheckResult{
+		ModelTag:       st.ModelTag().String(),
+		SourceBlockers: []string{"machine 0 is dying"},
+		TargetBlockers: []string{"upgrade in progress"},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
+
+	// No migration was started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationPrechecksSpecError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			// TargetInfo missing
+		}},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "controller tag: .+ is not a valid tag")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
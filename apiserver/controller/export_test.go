@@ -17,3 +17,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetPrecheckReport(p patcher, sourceBlockers, targetBlockers []string, err error) {
+	p.PatchValue(&reportMigrationPrechecks, func(*state.State, migration.TargetInfo) ([]string, []string, error) {
+		return sourceBlockers, targetBlockers, err
+	})
+}
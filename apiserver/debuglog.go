@@ -7,12 +7,15 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"golang.org/x/net/websocket"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -66,6 +69,16 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 timestamp; only show lines logged at or after this time
+//   endTime -> string - RFC3339 timestamp; only show lines logged before this time
+//      - existing logs are sent back, but the command does not wait for new ones.
+//   messageRegex -> string - only show lines whose message matches this regular expression
+//   includeApplication -> []string - lists applications whose unit agents' lines to include
+//   excludeApplication -> []string - lists applications whose unit agents' lines to exclude
+//   includeMachine -> []string - lists machine ids whose lines to include, including containers
+//   excludeMachine -> []string - lists machine ids whose lines to exclude, including containers
+//   includeUnit -> []string - lists unit names whose lines to include
+//   excludeUnit -> []string - lists unit names whose lines to exclude
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -144,15 +157,24 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	maxLines           uint
+	fromTheStart       bool
+	noTail             bool
+	backlog            uint
+	filterLevel        loggo.Level
+	startTime          time.Time
+	endTime            time.Time
+	messageRegex       string
+	includeEntity      []string
+	excludeEntity      []string
+	includeModule      []string
+	excludeModule      []string
+	includeApplication []string
+	excludeApplication []string
+	includeMachine     []string
+	excludeMachine     []string
+	includeUnit        []string
+	excludeUnit        []string
 }
 
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
@@ -200,10 +222,57 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.filterLevel = level
 	}
 
+	if value := queryMap.Get("startTime"); value != "" {
+		startTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid RFC3339 timestamp", value)
+		}
+		params.startTime = startTime
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("endTime value %q is not a valid RFC3339 timestamp", value)
+		}
+		if !params.startTime.IsZero() && !endTime.After(params.startTime) {
+			return nil, errors.Errorf("endTime value %q is not after startTime", value)
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("messageRegex value %q is not a valid regular expression", value)
+		}
+		params.messageRegex = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	labels := []struct {
+		key    string
+		valid  func(string) bool
+		target *[]string
+	}{
+		{"includeApplication", names.IsValidApplication, &params.includeApplication},
+		{"excludeApplication", names.IsValidApplication, &params.excludeApplication},
+		{"includeMachine", names.IsValidMachine, &params.includeMachine},
+		{"excludeMachine", names.IsValidMachine, &params.excludeMachine},
+		{"includeUnit", names.IsValidUnit, &params.includeUnit},
+		{"excludeUnit", names.IsValidUnit, &params.excludeUnit},
+	}
+	for _, label := range labels {
+		for _, value := range queryMap[label.key] {
+			if !label.valid(value) {
+				return nil, errors.Errorf("%s value %q is not valid", label.key, value)
+			}
+		}
+		*label.target = queryMap[label.key]
+	}
+
 	return params, nil
 }
@@ -56,13 +56,22 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	params := &state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:           reqParams.filterLevel,
+		NoTail:             reqParams.noTail,
+		InitialLines:       int(reqParams.backlog),
+		StartTime:          reqParams.startTime,
+		EndTime:            reqParams.endTime,
+		MessageRegex:       reqParams.messageRegex,
+		IncludeEntity:      reqParams.includeEntity,
+		ExcludeEntity:      reqParams.excludeEntity,
+		IncludeModule:      reqParams.includeModule,
+		ExcludeModule:      reqParams.excludeModule,
+		IncludeApplication: reqParams.includeApplication,
+		ExcludeApplication: reqParams.excludeApplication,
+		IncludeMachine:     reqParams.includeMachine,
+		ExcludeMachine:     reqParams.excludeMachine,
+		IncludeUnit:        reqParams.includeUnit,
+		ExcludeUnit:        reqParams.excludeUnit,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
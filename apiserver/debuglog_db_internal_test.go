@@ -30,31 +30,48 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
+	startTime := time.Date(2016, 11, 2, 9, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
 	reqParams := &debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:       false,
+		noTail:             true,
+		backlog:            11,
+		filterLevel:        loggo.INFO,
+		startTime:          startTime,
+		endTime:            endTime,
+		messageRegex:       "fail(ed|ure)",
+		includeEntity:      []string{"foo"},
+		includeModule:      []string{"bar"},
+		excludeEntity:      []string{"baz"},
+		excludeModule:      []string{"qux"},
+		includeApplication: []string{"mysql"},
+		excludeApplication: []string{"wordpress"},
+		includeMachine:     []string{"0"},
+		excludeMachine:     []string{"1/lxd/0"},
+		includeUnit:        []string{"mysql/0"},
+		excludeUnit:        []string{"wordpress/1"},
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
-		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		c.Assert(params.EndTime, gc.Equals, endTime)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
+		c.Assert(params.MessageRegex, gc.Equals, "fail(ed|ure)")
 		c.Assert(params.IncludeEntity, jc.DeepEquals, []string{"foo"})
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeApplication, jc.DeepEquals, []string{"mysql"})
+		c.Assert(params.ExcludeApplication, jc.DeepEquals, []string{"wordpress"})
+		c.Assert(params.IncludeMachine, jc.DeepEquals, []string{"0"})
+		c.Assert(params.ExcludeMachine, jc.DeepEquals, []string{"1/lxd/0"})
+		c.Assert(params.IncludeUnit, jc.DeepEquals, []string{"mysql/0"})
+		c.Assert(params.ExcludeUnit, jc.DeepEquals, []string{"wordpress/1"})
 
 		return newFakeLogTailer(), nil
 	})
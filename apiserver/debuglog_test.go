@@ -28,6 +28,42 @@ func (s *debugLogBaseSuite) TestBadParams(c *gc.C) {
 	assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogBaseSuite) TestBadFilterParams(c *gc.C) {
+	for i, test := range []struct {
+		query    url.Values
+		expected string
+	}{{
+		query:    url.Values{"startTime": {"yesterday"}},
+		expected: `startTime value "yesterday" is not a valid RFC3339 timestamp`,
+	}, {
+		query:    url.Values{"endTime": {"tomorrow"}},
+		expected: `endTime value "tomorrow" is not a valid RFC3339 timestamp`,
+	}, {
+		query: url.Values{
+			"startTime": {"2016-11-02T10:00:00Z"},
+			"endTime":   {"2016-11-02T09:00:00Z"},
+		},
+		expected: `endTime value "2016-11-02T09:00:00Z" is not after startTime`,
+	}, {
+		query:    url.Values{"messageRegex": {"fail("}},
+		expected: `messageRegex value "fail\(" is not a valid regular expression`,
+	}, {
+		query:    url.Values{"includeApplication": {"My_App"}},
+		expected: `includeApplication value "My_App" is not valid`,
+	}, {
+		query:    url.Values{"excludeMachine": {"zero"}},
+		expected: `excludeMachine value "zero" is not valid`,
+	}, {
+		query:    url.Values{"includeUnit": {"mysql"}},
+		expected: `includeUnit value "mysql" is not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.query)
+		reader := s.openWebsocket(c, test.query)
+		assertJSONError(c, reader, test.expected)
+		assertWebsocketClosed(c, reader)
+	}
+}
+
 func (s *debugLogBaseSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
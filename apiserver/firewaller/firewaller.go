@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -20,6 +21,9 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+
+	// Facade version 4 adds GetIngressNetworks and WatchIngressNetworks.
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -30,6 +34,7 @@ type FirewallerAPI struct {
 	*common.UnitsWatcher
 	*common.ModelMachinesWatcher
 	*common.InstanceIdGetter
+	*common.APIAddresser
 	cloudspec.CloudSpecAPI
 
 	st            *state.State
@@ -71,11 +76,11 @@ func NewFirewallerAPI(
 		resources,
 		authorizer,
 	)
-	// Watch() is supported for applications only.
+	// Watch() is supported for applications and machines.
 	entityWatcher := common.NewAgentEntityWatcher(
 		st,
 		resources,
-		accessService,
+		common.AuthEither(accessService, accessMachine),
 	)
 	// WatchUnits() is supported for machines.
 	unitsWatcher := common.NewUnitsWatcher(st,
@@ -94,6 +99,10 @@ func NewFirewallerAPI(
 		accessMachine,
 	)
 
+	// APIHostPorts() is used to keep the controllers reachable from
+	// machines with restricted egress.
+	apiAddresser := common.NewAPIAddresser(st, resources)
+
 	environConfigGetter := stateenvirons.EnvironConfigGetter{st}
 	cloudSpecAPI := cloudspec.NewCloudSpec(environConfigGetter.CloudSpec, common.AuthFuncForTag(st.ModelTag()))
 
@@ -104,6 +113,7 @@ func NewFirewallerAPI(
 		UnitsWatcher:         unitsWatcher,
 		ModelMachinesWatcher: machinesWatcher,
 		InstanceIdGetter:     instanceIdGetter,
+		APIAddresser:         apiAddresser,
 		CloudSpecAPI:         cloudSpecAPI,
 		st:                   st,
 		resources:            resources,
@@ -287,6 +297,142 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedCIDRs returns the CIDRs to which each given service is
+// exposed. An empty result means the service is exposed to all.
+func (f *FirewallerAPI) GetExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = service.ExposedCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GetIngressNetworks returns, for each given service, the networks from
+// which the units related to it from other models connect. Its opened
+// ports must be reachable from these networks, whether or not it is
+// exposed.
+func (f *FirewallerAPI) GetIngressNetworks(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		cidrs, err := f.ingressNetworks(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = cidrs
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPI) ingressNetworks(canAccess common.AuthFunc, tag names.ApplicationTag) ([]string, error) {
+	service, err := f.getService(canAccess, tag)
+	if err != nil {
+		return nil, err
+	}
+	relations, err := service.Relations()
+	if err != nil {
+		return nil, err
+	}
+	cidrs := set.NewStrings()
+	for _, rel := range relations {
+		relationCIDRs, err := rel.IngressNetworks()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		cidrs = cidrs.Union(set.NewStrings(relationCIDRs...))
+	}
+	if cidrs.IsEmpty() {
+		return nil, nil
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// WatchIngressNetworks returns a NotifyWatcher that notifies of changes
+// to the ingress networks of the model's relations.
+func (f *FirewallerAPI) WatchIngressNetworks() (params.NotifyWatchResult, error) {
+	var result params.NotifyWatchResult
+	watch := f.st.WatchRelationIngressNetworks()
+	// Consume the initial event and forward it to the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = f.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// GetEgressRules returns the rules to which the outbound traffic of
+// the machines hosting each given service's units is restricted. An
+// empty result means all outbound traffic is allowed.
+func (f *FirewallerAPI) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rules, err := f.egressRules(canAccess, tag)
+		if err == nil {
+			result.Results[i].Rules = rules
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPI) egressRules(canAccess common.AuthFunc, tag names.ApplicationTag) ([]params.EgressRule, error) {
+	service, err := f.getService(canAccess, tag)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := service.EgressRules()
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	results := make([]params.EgressRule, len(rules))
+	for i, rule := range rules {
+		results[i] = params.FromNetworkEgressRule(rule)
+	}
+	return results, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -229,10 +229,10 @@ func (s *firewallerBaseSuite) testWatch(
 	if allowUnits {
 		c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
 			Results: []params.NotifyWatchResult{
-				{Error: apiservertesting.ErrUnauthorized},
 				{NotifyWatcherId: "1"},
 				{NotifyWatcherId: "2"},
-				{Error: apiservertesting.ErrUnauthorized},
+				{NotifyWatcherId: "3"},
+				{Error: apiservertesting.NotFoundError("machine 42")},
 				{Error: apiservertesting.NotFoundError(`unit "foo/0"`)},
 				{Error: apiservertesting.NotFoundError(`application "bar"`)},
 				{Error: apiservertesting.ErrUnauthorized},
@@ -243,10 +243,10 @@ func (s *firewallerBaseSuite) testWatch(
 	} else {
 		c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
 			Results: []params.NotifyWatchResult{
-				{Error: apiservertesting.ErrUnauthorized},
 				{NotifyWatcherId: "1"},
+				{NotifyWatcherId: "2"},
 				{Error: apiservertesting.ErrUnauthorized},
-				{Error: apiservertesting.ErrUnauthorized},
+				{Error: apiservertesting.NotFoundError("machine 42")},
 				{Error: apiservertesting.ErrUnauthorized},
 				{Error: apiservertesting.NotFoundError(`application "bar"`)},
 				{Error: apiservertesting.ErrUnauthorized},
@@ -258,27 +258,23 @@ func (s *firewallerBaseSuite) testWatch(
 
 	// Verify the resources were registered and stop when done.
 	if allowUnits {
-		c.Assert(s.resources.Count(), gc.Equals, 2)
+		c.Assert(s.resources.Count(), gc.Equals, 3)
 	} else {
-		c.Assert(s.resources.Count(), gc.Equals, 1)
+		c.Assert(s.resources.Count(), gc.Equals, 2)
 	}
-	c.Assert(result.Results[1].NotifyWatcherId, gc.Equals, "1")
-	watcher1 := s.resources.Get("1")
-	defer statetesting.AssertStop(c, watcher1)
-	var watcher2 facade.Resource
+	watcherIds := []string{"1", "2"}
 	if allowUnits {
-		c.Assert(result.Results[2].NotifyWatcherId, gc.Equals, "2")
-		watcher2 = s.resources.Get("2")
-		defer statetesting.AssertStop(c, watcher2)
+		watcherIds = append(watcherIds, "3")
 	}
-
-	// Check that the Watch has consumed the initial event ("returned" in
-	// the Watch call)
-	wc1 := statetesting.NewNotifyWatcherC(c, s.State, watcher1.(state.NotifyWatcher))
-	wc1.AssertNoChange()
-	if allowUnits {
-		wc2 := statetesting.NewNotifyWatcherC(c, s.State, watcher2.(state.NotifyWatcher))
-		wc2.AssertNoChange()
+	for i, id := range watcherIds {
+		c.Assert(result.Results[i].NotifyWatcherId, gc.Equals, id)
+		resource := s.resources.Get(id)
+		defer statetesting.AssertStop(c, resource)
+
+		// Check that the Watch has consumed the initial event ("returned"
+		// in the Watch call)
+		wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+		wc.AssertNoChange()
 	}
 }
 
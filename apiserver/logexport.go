@@ -0,0 +1,255 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// logImportBatchSize is the number of log records written to the
+// database at a time when importing a log archive.
+const logImportBatchSize = 1000
+
+// logExportHandler handles requests to export a model's logs as a
+// gzip-compressed stream of JSON records, one per line, and to import
+// such an archive back into a model.
+type logExportHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *logExportHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	switch req.Method {
+	case "GET":
+		logger.Infof("handling log export request")
+		if err := h.checkAccess(st, entity.Tag(), permission.AdminAccess); err != nil {
+			sendError(w, err)
+			return
+		}
+		if err := h.export(st, w, req); err != nil {
+			sendError(w, err)
+		}
+	case "POST":
+		logger.Infof("handling log import request")
+		if err := h.checkAccess(st, entity.Tag(), permission.SuperuserAccess); err != nil {
+			sendError(w, err)
+			return
+		}
+		count, err := h.importLogs(st, req)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		logger.Infof("imported %d log records into model %q", count, st.ModelUUID())
+		if err := sendStatusAndJSON(w, http.StatusOK, &params.LogImportResult{Imported: count}); err != nil {
+			logger.Errorf("%v", err)
+		}
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+	}
+}
+
+// checkAccess verifies that the authenticated user either has the
+// requested access to the model, or is a controller superuser.
+func (h *logExportHandler) checkAccess(st *state.State, tag names.Tag, access permission.Access) error {
+	isSuperuser, err := common.HasPermission(st.UserAccess, tag, permission.SuperuserAccess, st.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isSuperuser {
+		return nil
+	}
+	if access == permission.SuperuserAccess {
+		return common.ErrPerm
+	}
+	allowed, err := common.HasPermission(st.UserAccess, tag, access, st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// export streams the logs recorded for the model, between the optional
+// startTime and endTime query parameters, to the response writer.
+func (h *logExportHandler) export(st *state.State, w http.ResponseWriter, req *http.Request) error {
+	tailerParams, err := exportLogTailerParams(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tailer, err := newLogTailer(st, tailerParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer tailer.Stop()
+
+	w.Header().Set("Content-Type", params.ContentTypeGzip)
+	w.WriteHeader(http.StatusOK)
+
+	// Once the header has been written, any error can only be
+	// reported by truncating the stream; the client will fail to
+	// read the incomplete gzip archive.
+	zw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(zw)
+	var count int
+	for {
+		select {
+		case <-h.ctxt.stop():
+			logger.Infof("log export interrupted after %d records", count)
+			return nil
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				if err := tailer.Err(); err != nil {
+					logger.Errorf("log export failed after %d records: %v", count, err)
+					return nil
+				}
+				if err := zw.Close(); err != nil {
+					logger.Errorf("finishing log export: %v", err)
+					return nil
+				}
+				logger.Infof("exported %d log records from model %q", count, st.ModelUUID())
+				return nil
+			}
+			if err := encoder.Encode(exportLogRecord(rec)); err != nil {
+				logger.Errorf("log export failed after %d records: %v", count, err)
+				return nil
+			}
+			count++
+		}
+	}
+}
+
+func exportLogTailerParams(req *http.Request) (*state.LogTailerParams, error) {
+	query := req.URL.Query()
+	tailerParams := &state.LogTailerParams{
+		NoTail: true,
+	}
+	if value := query.Get("startTime"); value != "" {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.BadRequestf("startTime value %q is not a valid RFC3339 timestamp", value)
+		}
+		tailerParams.StartTime = t
+	}
+	if value := query.Get("endTime"); value != "" {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.BadRequestf("endTime value %q is not a valid RFC3339 timestamp", value)
+		}
+		if !tailerParams.StartTime.IsZero() && !t.After(tailerParams.StartTime) {
+			return nil, errors.BadRequestf("endTime value %q is not after startTime", value)
+		}
+		tailerParams.EndTime = t
+	}
+	return tailerParams, nil
+}
+
+// importLogs reads a gzip-compressed stream of JSON log records from
+// the request body, and writes them to the model's logs.
+func (h *logExportHandler) importLogs(st *state.State, req *http.Request) (int, error) {
+	defer req.Body.Close()
+
+	zr, err := gzip.NewReader(req.Body)
+	if err != nil {
+		return 0, errors.NewBadRequest(err, "reading log archive")
+	}
+	defer zr.Close()
+
+	decoder := json.NewDecoder(zr)
+	batch := make([]*state.LogRecord, 0, logImportBatchSize)
+	var count int
+	flush := func() error {
+		if err := state.ImportLogs(st, batch); err != nil {
+			return errors.Trace(err)
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		var exported params.ExportedLogRecord
+		if err := decoder.Decode(&exported); err == io.EOF {
+			break
+		} else if err != nil {
+			return count, errors.NewBadRequest(err, fmt.Sprintf("reading log record %d", count+len(batch)))
+		}
+		rec, err := importLogRecord(exported)
+		if err != nil {
+			return count, errors.NewBadRequest(err, fmt.Sprintf("reading log record %d", count+len(batch)))
+		}
+		batch = append(batch, rec)
+		if len(batch) == logImportBatchSize {
+			if err := flush(); err != nil {
+				return count, errors.Trace(err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return count, errors.Trace(err)
+	}
+	return count, nil
+}
+
+func exportLogRecord(rec *state.LogRecord) *params.ExportedLogRecord {
+	exported := &params.ExportedLogRecord{
+		Entity:    rec.Entity.String(),
+		Timestamp: rec.Time.UTC(),
+		Severity:  rec.Level.String(),
+		Module:    rec.Module,
+		Location:  rec.Location,
+		Message:   rec.Message,
+	}
+	if rec.Version != version.Zero {
+		exported.Version = rec.Version.String()
+	}
+	return exported
+}
+
+func importLogRecord(exported params.ExportedLogRecord) (*state.LogRecord, error) {
+	entity, err := names.ParseTag(exported.Entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	level, ok := loggo.ParseLevel(exported.Severity)
+	if !ok {
+		return nil, errors.NotValidf("severity %q", exported.Severity)
+	}
+	rec := &state.LogRecord{
+		Time:     exported.Timestamp,
+		Entity:   entity,
+		Level:    level,
+		Module:   exported.Module,
+		Location: exported.Location,
+		Message:  exported.Message,
+	}
+	if exported.Version != "" {
+		rec.Version, err = version.Parse(exported.Version)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return rec, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+type logExportSuite struct {
+	authHTTPSuite
+	t0 time.Time
+}
+
+var _ = gc.Suite(&logExportSuite{})
+
+func (s *logExportSuite) SetUpTest(c *gc.C) {
+	s.authHTTPSuite.SetUpTest(c)
+
+	// MongoDB only stores timestamps with ms precision.
+	s.t0 = time.Date(2016, 11, 1, 9, 0, 0, 0, time.UTC)
+	logger := state.NewDbLogger(s.State, names.NewMachineTag("0"), version.MustParse("2.0.1"))
+	defer logger.Close()
+	for i, msg := range []string{"first", "second", "third"} {
+		err := logger.Log(s.t0.Add(time.Duration(i)*time.Minute), "juju.foo", "foo.go:42", loggo.INFO, msg)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *logExportSuite) logsURL(c *gc.C, query string) string {
+	uri := s.baseURL(c)
+	uri.Path = fmt.Sprintf("/model/%s/logs", s.State.ModelUUID())
+	uri.RawQuery = query
+	return uri.String()
+}
+
+func (s *logExportSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *logExportSuite) readArchive(c *gc.C, resp *http.Response) []params.ExportedLogRecord {
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeGzip)
+	zr, err := gzip.NewReader(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	decoder := json.NewDecoder(zr)
+	var records []params.ExportedLogRecord
+	for {
+		var rec params.ExportedLogRecord
+		err := decoder.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		records = append(records, rec)
+	}
+	return records
+}
+
+func (s *logExportSuite) makeArchive(c *gc.C, records ...params.ExportedLogRecord) io.Reader {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(zw)
+	for _, rec := range records {
+		err := encoder.Encode(rec)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := zw.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	return &buf
+}
+
+func (s *logExportSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.logsURL(c, "")})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *logExportSuite) TestInvalidHTTPMethods(c *gc.C) {
+	for _, method := range []string{"PUT", "DELETE", "OPTIONS"} {
+		c.Logf("testing HTTP method: %s", method)
+		resp := s.authRequest(c, httpRequestParams{method: method, url: s.logsURL(c, "")})
+		s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "`+method+`"`)
+	}
+}
+
+func (s *logExportSuite) TestExport(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.logsURL(c, "")})
+	records := s.readArchive(c, resp)
+	c.Assert(records, gc.HasLen, 3)
+	c.Check(records[0], jc.DeepEquals, params.ExportedLogRecord{
+		Entity:    "machine-0",
+		Version:   "2.0.1",
+		Timestamp: s.t0,
+		Severity:  "INFO",
+		Module:    "juju.foo",
+		Location:  "foo.go:42",
+		Message:   "first",
+	})
+	c.Check(records[2].Message, gc.Equals, "third")
+}
+
+func (s *logExportSuite) TestExportTimeRange(c *gc.C) {
+	query := fmt.Sprintf("startTime=%s&endTime=%s",
+		s.t0.Add(30*time.Second).Format(time.RFC3339Nano),
+		s.t0.Add(90*time.Second).Format(time.RFC3339Nano),
+	)
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.logsURL(c, query)})
+	records := s.readArchive(c, resp)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Message, gc.Equals, "second")
+}
+
+func (s *logExportSuite) TestExportBadTimeRange(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.logsURL(c, "startTime=yesterday")})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `startTime value "yesterday" is not a valid RFC3339 timestamp`)
+
+	query := fmt.Sprintf("startTime=%s&endTime=%s",
+		s.t0.Format(time.RFC3339Nano),
+		s.t0.Add(-time.Minute).Format(time.RFC3339Nano),
+	)
+	resp = s.authRequest(c, httpRequestParams{method: "GET", url: s.logsURL(c, query)})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `endTime value ".*" is not after startTime`)
+}
+
+func (s *logExportSuite) TestExportRequiresModelAdmin(c *gc.C) {
+	user := s.Factory.MakeModelUser(c, nil)
+	_, err := s.State.SetUserAccess(user.UserTag, s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.logsURL(c, ""),
+		tag:      user.UserTag.String(),
+		password: "password",
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *logExportSuite) TestImportRequiresSuperuser(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.logsURL(c, ""),
+		contentType: params.ContentTypeGzip,
+		body:        s.makeArchive(c),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *logExportSuite) TestImport(c *gc.C) {
+	_, err := s.State.SetUserAccess(s.userTag, s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	t1 := s.t0.Add(time.Hour)
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.logsURL(c, ""),
+		contentType: params.ContentTypeGzip,
+		body: s.makeArchive(c, params.ExportedLogRecord{
+			Entity:    "unit-mysql-0",
+			Version:   "2.0.2",
+			Timestamp: t1,
+			Severity:  "WARNING",
+			Module:    "juju.bar",
+			Location:  "bar.go:7",
+			Message:   "imported",
+		}, params.ExportedLogRecord{
+			Entity:    "machine-1",
+			Timestamp: t1.Add(time.Second),
+			Severity:  "ERROR",
+			Message:   "also imported",
+		}),
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var result params.LogImportResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Imported, gc.Equals, 2)
+
+	var docs []bson.M
+	logsColl := s.State.MongoSession().DB("logs").C("logs")
+	err = logsColl.Find(bson.M{"t": bson.M{"$gte": t1.UnixNano()}}).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0]["e"], gc.Equals, s.State.ModelUUID())
+	c.Check(docs[0]["n"], gc.Equals, "unit-mysql-0")
+	c.Check(docs[0]["r"], gc.Equals, "2.0.2")
+	c.Check(docs[0]["v"], gc.Equals, int(loggo.WARNING))
+	c.Check(docs[0]["x"], gc.Equals, "imported")
+	c.Check(docs[1]["n"], gc.Equals, "machine-1")
+	c.Check(docs[1]["x"], gc.Equals, "also imported")
+}
+
+func (s *logExportSuite) TestImportBadRecord(c *gc.C) {
+	_, err := s.State.SetUserAccess(s.userTag, s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.logsURL(c, ""),
+		contentType: params.ContentTypeGzip,
+		body: s.makeArchive(c, params.ExportedLogRecord{
+			Entity:    "machine-0",
+			Timestamp: s.t0,
+			Severity:  "LOUD",
+			Message:   "hello",
+		}),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `reading log record 0: severity "LOUD" not valid`)
+}
+
+func (s *logExportSuite) TestImportNotGzip(c *gc.C) {
+	_, err := s.State.SetUserAccess(s.userTag, s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.logsURL(c, ""),
+		contentType: params.ContentTypeGzip,
+		body:        bytes.NewBufferString("not an archive"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "reading log archive: .*")
+}
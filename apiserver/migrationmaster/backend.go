@@ -17,6 +17,8 @@ type Backend interface {
 	WatchForMigration() state.NotifyWatcher
 	LatestMigration() (state.ModelMigration, error)
 	ModelUUID() string
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
 	ModelName() (string, error)
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
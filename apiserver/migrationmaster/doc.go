@@ -2,5 +2,6 @@
 // Licensed under the AGPLv3, see LICENCE file for details.
 
 // This package defines the API facade for use by the migration master
-// worker when communicating to it's own controller.
+// worker when communicating to it's own controller. Model admins may
+// also use it to view the progress of a migration.
 package migrationmaster
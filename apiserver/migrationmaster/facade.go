@@ -5,28 +5,34 @@ package migrationmaster
 
 import (
 	"encoding/json"
+	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
-	"github.com/juju/utils/set"
-	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
 func init() {
 	common.RegisterStandardFacade("MigrationMaster", 1, newAPIForRegistration)
+
+	// Facade version 2 adds MigrationProgress and AddTransferredBytes,
+	// and admits model admins for MigrationProgress.
+	common.RegisterStandardFacade("MigrationMaster", 2, newAPIForRegistration)
 }
 
 // API implements the API required for the model migration
-// master worker.
+// master worker. Clients with admin access to the model may also
+// connect, but are only permitted to call MigrationProgress.
 type API struct {
 	backend         Backend
 	precheckBackend migration.PrecheckBackend
@@ -43,7 +49,12 @@ func NewAPI(
 	authorizer facade.Authorizer,
 ) (*API, error) {
 	if !authorizer.AuthModelManager() {
-		return nil, common.ErrPerm
+		if !authorizer.AuthClient() {
+			return nil, common.ErrPerm
+		}
+		if err := checkClientAccess(backend, authorizer); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	return &API{
 		backend:         backend,
@@ -53,10 +64,43 @@ func NewAPI(
 	}, nil
 }
 
+// checkClientAccess returns ErrPerm unless the authenticated client
+// is an admin of the model or a superuser of the controller.
+func checkClientAccess(backend Backend, authorizer facade.Authorizer) error {
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	isModelAdmin, err := authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkModelManager returns ErrPerm if the API connection isn't for
+// the model manager, which is the only entity allowed to drive a
+// migration.
+func (api *API) checkModelManager() error {
+	if !api.authorizer.AuthModelManager() {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // Watch starts watching for an active migration for the model
 // associated with the API connection. The returned id should be used
 // with the NotifyWatcher facade to receive events.
 func (api *API) Watch() params.NotifyWatchResult {
+	if err := api.checkModelManager(); err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}
+	}
 	watch := api.backend.WatchForMigration()
 	if _, ok := <-watch.Changes(); ok {
 		return params.NotifyWatchResult{
@@ -73,6 +117,10 @@ func (api *API) Watch() params.NotifyWatchResult {
 func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 	empty := params.MasterMigrationStatus{}
 
+	if err := api.checkModelManager(); err != nil {
+		return empty, err
+	}
+
 	mig, err := api.backend.LatestMigration()
 	if err != nil {
 		return empty, errors.Annotate(err, "retrieving model migration")
@@ -113,6 +161,10 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 	empty := params.MigrationModelInfo{}
 
+	if err := api.checkModelManager(); err != nil {
+		return empty, err
+	}
+
 	name, err := api.backend.ModelName()
 	if err != nil {
 		return empty, errors.Annotate(err, "retrieving model name")
@@ -140,6 +192,9 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 // phase must be a valid phase value, for example QUIESCE" or
 // "ABORT". See the core/migration package for the complete list.
 func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
+	if err := api.checkModelManager(); err != nil {
+		return err
+	}
 	mig, err := api.backend.LatestMigration()
 	if err != nil {
 		return errors.Annotate(err, "could not get migration")
@@ -157,6 +212,9 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 // Prechecks performs pre-migration checks on the model and
 // (source) controller.
 func (api *API) Prechecks() error {
+	if err := api.checkModelManager(); err != nil {
+		return err
+	}
 	return migration.SourcePrecheck(api.precheckBackend)
 }
 
@@ -164,6 +222,9 @@ func (api *API) Prechecks() error {
 // information about the migration's progress. This will be shown in
 // status output shown to the end user.
 func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) error {
+	if err := api.checkModelManager(); err != nil {
+		return err
+	}
 	mig, err := api.backend.LatestMigration()
 	if err != nil {
 		return errors.Annotate(err, "could not get migration")
@@ -172,34 +233,51 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// AddTransferredBytes records the number of bytes of a kind of binary
+// (e.g. "charms" or "tools") which have been sent to the target
+// controller by the migrationmaster worker.
+func (api *API) AddTransferredBytes(args params.MigrationTransferredBytesArgs) error {
+	if err := api.checkModelManager(); err != nil {
+		return err
+	}
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.AddTransferredBytes(args.Kind, args.Count)
+	return errors.Annotate(err, "failed to record transferred bytes")
+}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
 
-	model, err := api.backend.Export()
-	if err != nil {
+	if err := api.checkModelManager(); err != nil {
 		return serialized, err
 	}
 
-	bytes, err := description.Serialize(model)
+	model, err := api.backend.Export()
 	if err != nil {
 		return serialized, err
 	}
-	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
-	serialized.Tools = getUsedTools(model)
-	return serialized, nil
+	return common.SerializeModel(model)
 }
 
 // Reap removes all documents for the model associated with the API
 // connection.
 func (api *API) Reap() error {
+	if err := api.checkModelManager(); err != nil {
+		return err
+	}
 	return api.backend.RemoveExportingModelDocs()
 }
 
 // WatchMinionReports sets up a watcher which reports when a report
 // for a migration minion has arrived.
 func (api *API) WatchMinionReports() params.NotifyWatchResult {
+	if err := api.checkModelManager(); err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}
+	}
 	mig, err := api.backend.LatestMigration()
 	if err != nil {
 		return params.NotifyWatchResult{Error: common.ServerError(err)}
@@ -225,6 +303,10 @@ func (api *API) WatchMinionReports() params.NotifyWatchResult {
 func (api *API) MinionReports() (params.MinionReports, error) {
 	var out params.MinionReports
 
+	if err := api.checkModelManager(); err != nil {
+		return out, err
+	}
+
 	mig, err := api.backend.LatestMigration()
 	if err != nil {
 		return out, errors.Trace(err)
@@ -268,44 +350,94 @@ func (api *API) MinionReports() (params.MinionReports, error) {
 	return out, nil
 }
 
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
+// MigrationProgress returns the detailed progress of the latest
+// migration of the model, for display to the end user. It reports
+// when each phase was entered, how many agents have reported for the
+// current phase and how much data has been sent to the target
+// controller.
+func (api *API) MigrationProgress() (params.MigrationProgress, error) {
+	var out params.MigrationProgress
 
-func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return out, errors.Trace(err)
 	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
+	phase, err := mig.Phase()
+	if err != nil {
+		return out, errors.Annotate(err, "retrieving phase")
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return out, errors.Annotate(err, "retrieving target info")
+	}
+	reports, err := mig.MinionReports()
+	if err != nil {
+		return out, errors.Annotate(err, "retrieving minion reports")
 	}
 
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
-		out = append(out, params.SerializedModelTools{
-			Version: v.String(),
-			URI:     common.ToolsURL("", v),
+	out.MigrationId = mig.Id()
+	out.TargetControllerTag = target.ControllerTag.String()
+	out.Phase = phase.String()
+	out.StatusMessage = mig.StatusMessage()
+	out.PhaseTimes = phaseTimes(mig.PhaseTimes())
+	out.MinionReports = minionCounts(reports)
+	out.TransferredBytes = mig.TransferredBytes()
+	return out, nil
+}
+
+// phaseTimes converts the phase entry times of a migration into a
+// slice ordered by the time each phase was entered.
+func phaseTimes(times map[coremigration.Phase]time.Time) []params.MigrationPhaseTime {
+	out := make([]params.MigrationPhaseTime, 0, len(times))
+	for phase, t := range times {
+		out = append(out, params.MigrationPhaseTime{
+			Phase: phase.String(),
+			Time:  t,
 		})
 	}
+	sort.Sort(byPhaseTime(out))
 	return out
 }
 
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
+type byPhaseTime []params.MigrationPhaseTime
+
+func (s byPhaseTime) Len() int      { return len(s) }
+func (s byPhaseTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byPhaseTime) Less(i, j int) bool {
+	if !s[i].Time.Equal(s[j].Time) {
+		return s[i].Time.Before(s[j].Time)
 	}
+	pi, _ := coremigration.ParsePhase(s[i].Phase)
+	pj, _ := coremigration.ParsePhase(s[j].Phase)
+	return pi < pj
+}
+
+// minionCounts tallies minion reports by the kind of agent which made
+// (or is yet to make) them, ordered by kind.
+func minionCounts(reports *state.MinionReports) []params.MigrationMinionCounts {
+	byKind := make(map[string]*params.MigrationMinionCounts)
+	count := func(tags []names.Tag, inc func(*params.MigrationMinionCounts)) {
+		for _, tag := range tags {
+			counts, ok := byKind[tag.Kind()]
+			if !ok {
+				counts = &params.MigrationMinionCounts{Kind: tag.Kind()}
+				byKind[tag.Kind()] = counts
+			}
+			inc(counts)
+		}
+	}
+	count(reports.Succeeded, func(c *params.MigrationMinionCounts) { c.Succeeded++ })
+	count(reports.Failed, func(c *params.MigrationMinionCounts) { c.Failed++ })
+	count(reports.Unknown, func(c *params.MigrationMinionCounts) { c.Pending++ })
+
+	kinds := make([]string, 0, len(byKind))
+	for kind := range byKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	out := make([]params.MigrationMinionCounts, len(kinds))
+	for i, kind := range kinds {
+		out[i] = *byKind[kind]
+	}
+	return out
 }
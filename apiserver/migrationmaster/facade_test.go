@@ -71,6 +71,37 @@ func (s *Suite) TestNotEnvironManager(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
+func (s *Suite) TestClientModelAdmin(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend.migration.minionReports = &state.MinionReports{}
+
+	api, err := s.makeAPI()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.MigrationProgress()
+	c.Check(err, jc.ErrorIsNil)
+
+	// Clients can't drive the migration.
+	_, err = api.MigrationStatus()
+	c.Check(err, gc.Equals, common.ErrPerm)
+	err = api.SetPhase(params.SetMigrationPhaseArgs{Phase: "ABORT"})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	result := api.Watch()
+	c.Check(result.Error, gc.DeepEquals, common.ServerError(common.ErrPerm))
+}
+
+func (s *Suite) TestClientNotModelAdmin(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+
+	api, err := s.makeAPI()
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
 func (s *Suite) TestWatch(c *gc.C) {
 	api := s.mustMakeAPI(c)
 
@@ -187,6 +218,28 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestAddTransferredBytes(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.AddTransferredBytes(params.MigrationTransferredBytesArgs{
+		Kind:  "charms",
+		Count: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.migration.transferredBytes, jc.DeepEquals, map[string]int64{
+		"charms": 1024,
+	})
+}
+
+func (s *Suite) TestAddTransferredBytesError(c *gc.C) {
+	s.backend.migration.transferErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+	err := api.AddTransferredBytes(params.MigrationTransferredBytesArgs{
+		Kind:  "charms",
+		Count: 1024,
+	})
+	c.Assert(err, gc.ErrorMatches, "failed to record transferred bytes: blam")
+}
+
 func (s *Suite) TestPrechecks(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	err := api.Prechecks()
@@ -304,6 +357,54 @@ func (s *Suite) TestMinionReports(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestMigrationProgress(c *gc.C) {
+	t0 := time.Date(2016, 6, 22, 16, 0, 0, 0, time.UTC)
+	s.backend.migration.phaseTimes = map[coremigration.Phase]time.Time{
+		coremigration.IMPORT:  t0.Add(time.Minute),
+		coremigration.QUIESCE: t0,
+	}
+	s.backend.migration.transferredBytes = map[string]int64{
+		"charms": 2048,
+		"tools":  4096,
+	}
+	s.backend.migration.minionReports = &state.MinionReports{
+		Succeeded: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewMachineTag("1"),
+			names.NewUnitTag("foo/0"),
+		},
+		Failed:  []names.Tag{names.NewUnitTag("foo/1")},
+		Unknown: []names.Tag{names.NewUnitTag("foo/2"), names.NewMachineTag("2")},
+	}
+
+	progress, err := s.mustMakeAPI(c).MigrationProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(progress, jc.DeepEquals, params.MigrationProgress{
+		MigrationId:         "id",
+		TargetControllerTag: names.NewControllerTag(controllerUUID).String(),
+		Phase:               "IMPORT",
+		StatusMessage:       "importing",
+		PhaseTimes: []params.MigrationPhaseTime{
+			{Phase: "QUIESCE", Time: t0},
+			{Phase: "IMPORT", Time: t0.Add(time.Minute)},
+		},
+		MinionReports: []params.MigrationMinionCounts{
+			{Kind: "machine", Succeeded: 2, Pending: 1},
+			{Kind: "unit", Succeeded: 1, Failed: 1, Pending: 1},
+		},
+		TransferredBytes: map[string]int64{
+			"charms": 2048,
+			"tools":  4096,
+		},
+	})
+}
+
+func (s *Suite) TestMigrationProgressNoMigration(c *gc.C) {
+	s.backend.getErr = errors.NotFoundf("migration")
+	_, err := s.mustMakeAPI(c).MigrationProgress()
+	c.Assert(err, gc.ErrorMatches, "migration not found")
+}
+
 func (s *Suite) makeAPI() (*migrationmaster.API, error) {
 	return migrationmaster.NewAPI(s.backend, new(failingPrecheckBackend),
 		s.resources, s.authorizer)
@@ -342,6 +443,14 @@ func (b *stubBackend) ModelUUID() string {
 	return "model-uuid"
 }
 
+func (b *stubBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag(modelUUID)
+}
+
+func (b *stubBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
 func (b *stubBackend) ModelName() (string, error) {
 	return "model-name", nil
 }
@@ -367,13 +476,16 @@ func (b *stubBackend) Export() (description.Model, error) {
 type stubMigration struct {
 	state.ModelMigration
 
-	stub            *testing.Stub
-	setPhaseErr     error
-	phaseSet        coremigration.Phase
-	setMessageErr   error
-	messageSet      string
-	minionReports   *state.MinionReports
-	externalControl bool
+	stub             *testing.Stub
+	setPhaseErr      error
+	phaseSet         coremigration.Phase
+	setMessageErr    error
+	messageSet       string
+	minionReports    *state.MinionReports
+	externalControl  bool
+	phaseTimes       map[coremigration.Phase]time.Time
+	transferredBytes map[string]int64
+	transferErr      error
 }
 
 func (m *stubMigration) Id() string {
@@ -388,6 +500,29 @@ func (m *stubMigration) PhaseChangedTime() time.Time {
 	return time.Date(2016, 6, 22, 16, 38, 0, 0, time.UTC)
 }
 
+func (m *stubMigration) PhaseTimes() map[coremigration.Phase]time.Time {
+	return m.phaseTimes
+}
+
+func (m *stubMigration) StatusMessage() string {
+	return "importing"
+}
+
+func (m *stubMigration) TransferredBytes() map[string]int64 {
+	return m.transferredBytes
+}
+
+func (m *stubMigration) AddTransferredBytes(kind string, count int64) error {
+	if m.transferErr != nil {
+		return m.transferErr
+	}
+	if m.transferredBytes == nil {
+		m.transferredBytes = make(map[string]int64)
+	}
+	m.transferredBytes[kind] += count
+	return nil
+}
+
 func (m *stubMigration) Attempt() (int, error) {
 	return 1, nil
 }
@@ -18,6 +18,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MigrationTarget", 1, NewAPI)
+
+	// Facade version 2 adds PrecheckReport.
+	common.RegisterStandardFacade("MigrationTarget", 2, NewAPI)
 }
 
 // API implements the API required for the model migration
@@ -76,6 +79,30 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	)
 }
 
+// PrecheckReport runs the same checks as Prechecks, but returns all
+// the problems found rather than failing at the first.
+func (api *API) PrecheckReport(model params.MigrationModelInfo) (params.MigrationPrecheckReport, error) {
+	var report params.MigrationPrecheckReport
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	blockers, err := migration.TargetPrecheckReport(
+		migration.PrecheckShim(api.state),
+		coremigration.ModelInfo{
+			UUID:         model.UUID,
+			Name:         model.Name,
+			Owner:        ownerTag,
+			AgentVersion: model.AgentVersion,
+		},
+	)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	report.Blockers = blockers
+	return report, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
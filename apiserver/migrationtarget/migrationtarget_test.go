@@ -4,6 +4,8 @@
 package migrationtarget_test
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -108,6 +110,41 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckReport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "some-model",
+		OwnerTag:     names.NewUserTag("someone").String(),
+		AgentVersion: s.controllerVersion(c),
+	}
+	report, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, gc.HasLen, 0)
+}
+
+func (s *Suite) TestPrecheckReportBlockers(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set the model version ahead of the controller.
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "some-model",
+		OwnerTag:     names.NewUserTag("someone").String(),
+		AgentVersion: modelVersion,
+	}
+	report, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{
+		fmt.Sprintf("model has higher version than target controller (%s > %s)",
+			modelVersion, controllerVersion),
+	})
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
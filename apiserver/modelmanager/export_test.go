@@ -9,6 +9,10 @@ import (
 	"gopkg.in/juju/names.v2"
 )
 
+func SetExportPrecheck(mm *ModelManagerAPI, precheck func(names.ModelTag) error) {
+	mm.exportPrecheck = precheck
+}
+
 func AuthCheck(c *gc.C, mm *ModelManagerAPI, user names.UserTag) bool {
 	mm.authCheck(user)
 	return mm.isAdmin
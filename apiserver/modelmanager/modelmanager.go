@@ -38,6 +38,9 @@ var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
 	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+
+	// Facade version 3 adds ExportModels.
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -45,6 +48,7 @@ type ModelManager interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
 	DumpModels(args params.Entities) params.MapResults
 	DumpModelsDB(args params.Entities) params.MapResults
+	ExportModels(args params.Entities) params.SerializedModelResults
 	ListModels(user params.Entity) (params.UserModelList, error)
 	DestroyModels(args params.Entities) (params.ErrorResults, error)
 }
@@ -59,13 +63,33 @@ type ModelManagerAPI struct {
 	toolsFinder *common.ToolsFinder
 	apiUser     names.UserTag
 	isAdmin     bool
+
+	// exportPrecheck returns an error if exporting the specified
+	// model would lose any of its contents.
+	exportPrecheck func(names.ModelTag) error
 }
 
 var _ ModelManager = (*ModelManagerAPI)(nil)
 
 func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelManagerAPI, error) {
 	configGetter := stateenvirons.EnvironConfigGetter{st}
-	return NewModelManagerAPI(common.NewModelManagerBackend(st), configGetter, auth)
+	api, err := NewModelManagerAPI(common.NewModelManagerBackend(st), configGetter, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.exportPrecheck = func(modelTag names.ModelTag) error {
+		modelSt := st
+		if st.ModelTag() != modelTag {
+			otherSt, err := st.ForModel(modelTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer otherSt.Close()
+			modelSt = otherSt
+		}
+		return migration.ExportPrecheck(migration.PrecheckShim(modelSt))
+	}
+	return api, nil
 }
 
 // NewModelManagerAPI creates a new api server endpoint for managing
@@ -96,6 +120,9 @@ func NewModelManagerAPI(
 		toolsFinder:    common.NewToolsFinder(configGetter, st, urlGetter),
 		apiUser:        apiUser,
 		isAdmin:        isAdmin,
+		// The export prechecks need a *state.State, so they are
+		// only run by the facade registered by newFacade.
+		exportPrecheck: func(names.ModelTag) error { return nil },
 	}, nil
 }
 
@@ -437,6 +464,61 @@ func (m *ModelManagerAPI) DumpModelsDB(args params.Entities) params.MapResults {
 	return results
 }
 
+func (m *ModelManagerAPI) exportModel(args params.Entity) (*params.SerializedModel, error) {
+	modelTag, err := names.ParseModelTag(args.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !m.isAdmin {
+		return nil, common.ErrPerm
+	}
+
+	st := m.state
+	if st.ModelTag() != modelTag {
+		st, err = m.state.ForModel(modelTag)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, errors.Trace(common.ErrBadId)
+			}
+			return nil, errors.Trace(err)
+		}
+		defer st.Close()
+	}
+
+	if err := m.exportPrecheck(modelTag); err != nil {
+		return nil, errors.Annotate(err, "cannot export model")
+	}
+	model, err := st.Export()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	serialized, err := common.SerializeModel(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &serialized, nil
+}
+
+// ExportModels serializes the specified models, and lists the charms
+// and tools they use, so that they can be imported into another
+// controller. As the serialized models include secrets such as cloud
+// credentials and agent passwords, the user needs to be a controller
+// admin.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.SerializedModelResults {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		serialized, err := m.exportModel(entity)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = serialized
+	}
+	return results
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Only that controller owner
 // can list models for any user (at this stage).  Other users
@@ -532,6 +532,98 @@ func (s *modelManagerSuite) TestDumpModelUsers(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestExportModels(c *gc.C) {
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{
+		Tag: "bad-tag",
+	}, {
+		Tag: s.st.ModelTag().String(),
+	}}})
+
+	c.Assert(results.Results, gc.HasLen, 2)
+	bad, good := results.Results[0], results.Results[1]
+	c.Check(bad.Result, gc.IsNil)
+	c.Check(bad.Error.Message, gc.Equals, `"bad-tag" is not a valid tag`)
+
+	c.Check(good.Error, gc.IsNil)
+	c.Check(good.Result, jc.DeepEquals, &params.SerializedModel{
+		Bytes:  []byte("model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d\n"),
+		Charms: []string{},
+		Tools:  []params.SerializedModelTools{},
+	})
+}
+
+func (s *modelManagerSuite) TestExportModelsMissingModel(c *gc.C) {
+	s.st.SetErrors(errors.NotFoundf("boom"))
+	tag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f000")
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{Tag: tag.String()}}})
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, `not found`)
+	c.Check(result.Error.Message, gc.Equals, `id not found`)
+}
+
+func (s *modelManagerSuite) TestExportModelsPrecheckFailed(c *gc.C) {
+	var checked []names.ModelTag
+	modelmanager.SetExportPrecheck(s.api, func(tag names.ModelTag) error {
+		checked = append(checked, tag)
+		return errors.New("model has applications with resources (mysql), which cannot be migrated")
+	})
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}})
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals,
+		"cannot export model: model has applications with resources (mysql), which cannot be migrated")
+	c.Check(checked, jc.DeepEquals, []names.ModelTag{s.st.ModelTag()})
+}
+
+func (s *modelManagerSuite) TestExportModelsUsers(c *gc.C) {
+	models := params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}}
+	for _, user := range []names.UserTag{
+		names.NewUserTag("otheruser"),
+		names.NewUserTag("unknown"),
+	} {
+		s.setAPIUser(c, user)
+		results := s.api.ExportModels(models)
+		c.Assert(results.Results, gc.HasLen, 1)
+		result := results.Results[0]
+		c.Assert(result.Result, gc.IsNil)
+		c.Assert(result.Error, gc.NotNil)
+		c.Check(result.Error.Message, gc.Equals, `permission denied`)
+	}
+}
+
+func (s *modelManagerSuite) TestExportModelsModelAdmin(c *gc.C) {
+	// Model admins who are not controller admins may not export the
+	// model, as it holds the controller's secrets for the model.
+	authorizer := modelAdminAuthorizer{s.authoriser}
+	authorizer.Tag = names.NewUserTag("modeladmin")
+	api, err := modelmanager.NewModelManagerAPI(s.st, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := api.ExportModels(params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, `permission denied`)
+}
+
+// modelAdminAuthorizer grants admin access to models, and nothing
+// else.
+type modelAdminAuthorizer struct {
+	apiservertesting.FakeAuthorizer
+}
+
+func (a modelAdminAuthorizer) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
+	return operation == permission.AdminAccess && target.Kind() == names.ModelTagKind, nil
+}
+
 func (s *modelManagerSuite) TestDumpModelsDB(c *gc.C) {
 	results := s.api.DumpModelsDB(params.Entities{[]params.Entity{{
 		Tag: "bad-tag",
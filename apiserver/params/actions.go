@@ -38,6 +38,9 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Parallel   bool                   `json:"parallel,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
+	Schedule   string                 `json:"schedule,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -138,3 +141,36 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionSchedule describes an action which is enqueued on all the units
+// of an application at the times given by a schedule.
+type ActionSchedule struct {
+	Id             string                 `json:"id,omitempty"`
+	ApplicationTag string                 `json:"application-tag"`
+	Name           string                 `json:"name"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	Schedule       string                 `json:"schedule"`
+	NextRun        time.Time              `json:"next-run,omitempty"`
+	LastRun        time.Time              `json:"last-run,omitempty"`
+}
+
+// ActionSchedules holds a slice of ActionSchedule for bulk requests.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleResult holds an action schedule, or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds a slice of ActionScheduleResult.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
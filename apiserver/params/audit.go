@@ -0,0 +1,39 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// AuditLogQuery holds the parameters for the AuditLog.Query call.
+// Empty fields are not used to filter the entries.
+type AuditLogQuery struct {
+	ModelUUID  string     `json:"model-uuid,omitempty"`
+	OriginType string     `json:"origin-type,omitempty"`
+	OriginName string     `json:"origin-name,omitempty"`
+	Operation  string     `json:"operation,omitempty"`
+	After      *time.Time `json:"after,omitempty"`
+	Before     *time.Time `json:"before,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single entry recorded in the audit log.
+type AuditLogEntry struct {
+	JujuServerVersion version.Number         `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogEntries holds the results of the AuditLog.Query call.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
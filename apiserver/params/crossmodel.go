@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// AddApplicationOffer holds the parameters for offering some of an
+// application's endpoints to other models.
+type AddApplicationOffer struct {
+	OfferName       string   `json:"offer-name"`
+	ApplicationName string   `json:"application-name"`
+	Endpoints       []string `json:"endpoints"`
+}
+
+// AddApplicationOffers holds the parameters for making one or more
+// application offers.
+type AddApplicationOffers struct {
+	Offers []AddApplicationOffer `json:"offers"`
+}
+
+// ConsumeApplicationArg holds the parameters for consuming an
+// application offer, by adding a remote application to the model.
+type ConsumeApplicationArg struct {
+	// ApplicationURL is the URL of the offer to consume.
+	ApplicationURL string `json:"application-url"`
+
+	// ApplicationAlias is the name to give the remote application in
+	// the consuming model. If empty, the offer name is used.
+	ApplicationAlias string `json:"application-alias,omitempty"`
+}
+
+// ConsumeApplicationArgs holds the parameters for consuming one or
+// more application offers.
+type ConsumeApplicationArgs struct {
+	Args []ConsumeApplicationArg `json:"args"`
+}
+
+// ConsumeApplicationResult holds the name of the remote application
+// added to the model by consuming an application offer, or an error.
+type ConsumeApplicationResult struct {
+	LocalName string `json:"local-name,omitempty"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// ConsumeApplicationResults holds the results of consuming one or
+// more application offers.
+type ConsumeApplicationResults struct {
+	Results []ConsumeApplicationResult `json:"results"`
+}
@@ -29,6 +29,10 @@ const (
 
 	// ContentTypeXJS is the outdated HTTP content-type value used for javascript.
 	ContentTypeXJS = "application/x-javascript"
+
+	// ContentTypeGzip is the HTTP content-type value used for gzip
+	// compressed content.
+	ContentTypeGzip = "application/gzip"
 )
 
 // EncodeChecksum base64 encodes a sha256 checksum according to RFC 4648 and
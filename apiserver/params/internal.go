@@ -722,3 +722,20 @@ type LogMessage struct {
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
 }
+
+// ExportedLogRecord is a single log record, as written to and read from
+// an exported log archive.
+type ExportedLogRecord struct {
+	Entity    string    `json:"tag"`
+	Version   string    `json:"ver,omitempty"`
+	Timestamp time.Time `json:"ts"`
+	Severity  string    `json:"sev"`
+	Module    string    `json:"mod"`
+	Location  string    `json:"loc"`
+	Message   string    `json:"msg"`
+}
+
+// LogImportResult holds the result of importing a log archive.
+type LogImportResult struct {
+	Imported int `json:"imported"`
+}
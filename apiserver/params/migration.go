@@ -53,6 +53,28 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationPrecheckResults is used to return the result of the
+// Controller.MigrationPrechecks API call.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
+// MigrationPrecheckResult holds the problems preventing the migration
+// of a single model, as found by the prechecks of the source and
+// target controllers.
+type MigrationPrecheckResult struct {
+	ModelTag       string   `json:"model-tag"`
+	Error          *Error   `json:"error,omitempty"`
+	SourceBlockers []string `json:"source-blockers,omitempty"`
+	TargetBlockers []string `json:"target-blockers,omitempty"`
+}
+
+// MigrationPrecheckReport holds the problems found by the migration
+// prechecks of a controller.
+type MigrationPrecheckReport struct {
+	Blockers []string `json:"blockers"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -65,6 +87,14 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// MigrationTransferredBytesArgs provides the number of bytes of a
+// kind of binary sent to the target controller to the
+// migrationmaster.AddTransferredBytes API method.
+type MigrationTransferredBytesArgs struct {
+	Kind  string `json:"kind"`
+	Count int64  `json:"count"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
@@ -73,6 +103,19 @@ type SerializedModel struct {
 	Tools  []SerializedModelTools `json:"tools"`
 }
 
+// SerializedModelResults holds the results of exporting one or more
+// models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
+// SerializedModelResult holds a single serialized model, or an error
+// if the model couldn't be exported.
+type SerializedModelResult struct {
+	Result *SerializedModel `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
 // SerializedModelTools holds the version and URI for a given tools
 // version.
 type SerializedModelTools struct {
@@ -178,3 +221,49 @@ type MinionReports struct {
 	// failed to complete a given migration phase.
 	Failed []string `json:"failed"`
 }
+
+// MigrationProgress reports the detailed progress of the latest
+// migration of a model, for display to the end user.
+type MigrationProgress struct {
+	// MigrationId holds the id of the migration.
+	MigrationId string `json:"migration-id"`
+
+	// TargetControllerTag holds the tag of the controller the model
+	// is being migrated to.
+	TargetControllerTag string `json:"target-controller-tag"`
+
+	// Phase holds the current phase of the migration.
+	Phase string `json:"phase"`
+
+	// StatusMessage holds the latest human readable status message
+	// recorded for the migration.
+	StatusMessage string `json:"status-message"`
+
+	// PhaseTimes holds the time at which the migration entered each
+	// phase it has passed through, in the order they were entered.
+	PhaseTimes []MigrationPhaseTime `json:"phase-times"`
+
+	// MinionReports holds the counts of migration minion reports for
+	// the current phase, grouped by the kind of agent reporting.
+	MinionReports []MigrationMinionCounts `json:"minion-reports"`
+
+	// TransferredBytes holds the number of bytes sent to the target
+	// controller, keyed by the kind of binary (e.g. "charms").
+	TransferredBytes map[string]int64 `json:"transferred-bytes"`
+}
+
+// MigrationPhaseTime records when a migration entered a phase.
+type MigrationPhaseTime struct {
+	Phase string    `json:"phase"`
+	Time  time.Time `json:"time"`
+}
+
+// MigrationMinionCounts holds the number of agents of a given kind
+// (e.g. "machine" or "unit") which have reported success or failure
+// for the current migration phase, or are yet to report.
+type MigrationMinionCounts struct {
+	Kind      string `json:"kind"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Pending   int    `json:"pending"`
+}
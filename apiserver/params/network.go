@@ -219,6 +219,30 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// EgressRule represents a range of ports, and the destination CIDR to
+// which outbound traffic on those ports is allowed. It is used in API
+// requests/responses. See also network.EgressRule, from/to which this
+// is transformed.
+type EgressRule struct {
+	PortRange       PortRange `json:"port-range"`
+	DestinationCIDR string    `json:"destination-cidr"`
+}
+
+// FromNetworkEgressRule is a convenience helper to create a parameter
+// out of the network type, here for EgressRule.
+func FromNetworkEgressRule(rule network.EgressRule) EgressRule {
+	return EgressRule{
+		PortRange:       FromNetworkPortRange(rule.PortRange),
+		DestinationCIDR: rule.DestinationCIDR,
+	}
+}
+
+// NetworkEgressRule is a convenience helper to return the parameter
+// as network type, here for EgressRule.
+func (r EgressRule) NetworkEgressRule() network.EgressRule {
+	return network.NewEgressRule(r.PortRange.NetworkPortRange(), r.DestinationCIDR)
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"tag"`
@@ -435,6 +459,19 @@ type PortsResult struct {
 	Ports []Port `json:"ports"`
 }
 
+// EgressRulesResults holds the bulk operation result of an API call
+// that returns a slice of EgressRule.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// EgressRulesResult holds the result of an API call that returns a
+// slice of EgressRule or an error.
+type EgressRulesResult struct {
+	Error *Error       `json:"error,omitempty"`
+	Rules []EgressRule `json:"rules"`
+}
+
 // UnitNetworkConfigResult holds network configuration for a single unit.
 type UnitNetworkConfigResult struct {
 	Error *Error `json:"error,omitempty"`
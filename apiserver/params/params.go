@@ -281,6 +281,21 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ToCIDRs optionally restricts access to the application's open
+	// ports to the given CIDRs.
+	ToCIDRs []string `json:"to-cidrs,omitempty"`
+}
+
+// ApplicationSetEgressRules holds the parameters for making the
+// application SetEgressRules call.
+type ApplicationSetEgressRules struct {
+	ApplicationName string `json:"application"`
+
+	// Rules holds the rules to which outbound traffic from the
+	// application's machines is restricted. If empty, all outbound
+	// traffic is allowed.
+	Rules []EgressRule `json:"rules"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -328,6 +343,13 @@ type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
 }
 
+// ApplicationSetEndpointBindings holds the parameters for the
+// application SetEndpointBindings call.
+type ApplicationSetEndpointBindings struct {
+	ApplicationName string            `json:"application"`
+	Bindings        map[string]string `json:"bindings"`
+}
+
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
 type ApplicationMetricCredential struct {
 	ApplicationName   string `json:"application"`
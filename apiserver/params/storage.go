@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the storage attachment's volume or
+	// filesystem, in MiB.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -166,6 +174,10 @@ type MachineStorageIds struct {
 type Volume struct {
 	VolumeTag string     `json:"volume-tag"`
 	Info      VolumeInfo `json:"info"`
+
+	// Releasing reports whether the volume is to be released
+	// from the model when it is removed, rather than destroyed.
+	Releasing bool `json:"releasing,omitempty"`
 }
 
 // Volume describes a storage volume in the model.
@@ -204,23 +216,25 @@ type VolumeAttachments struct {
 
 // VolumeParams holds the parameters for creating a storage volume.
 type VolumeParams struct {
-	VolumeTag  string                  `json:"volume-tag"`
-	Size       uint64                  `json:"size"`
-	Provider   string                  `json:"provider"`
-	Attributes map[string]interface{}  `json:"attributes,omitempty"`
-	Tags       map[string]string       `json:"tags,omitempty"`
-	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	VolumeTag     string                  `json:"volume-tag"`
+	Size          uint64                  `json:"size"`
+	Provider      string                  `json:"provider"`
+	Attributes    map[string]interface{}  `json:"attributes,omitempty"`
+	Tags          map[string]string       `json:"tags,omitempty"`
+	Attachment    *VolumeAttachmentParams `json:"attachment,omitempty"`
+	EncryptionKey string                  `json:"encryption-key,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
-	VolumeTag  string `json:"volume-tag"`
-	MachineTag string `json:"machine-tag"`
-	VolumeId   string `json:"volume-id,omitempty"`
-	InstanceId string `json:"instance-id,omitempty"`
-	Provider   string `json:"provider"`
-	ReadOnly   bool   `json:"read-only,omitempty"`
+	VolumeTag     string `json:"volume-tag"`
+	MachineTag    string `json:"machine-tag"`
+	VolumeId      string `json:"volume-id,omitempty"`
+	InstanceId    string `json:"instance-id,omitempty"`
+	Provider      string `json:"provider"`
+	ReadOnly      bool   `json:"read-only,omitempty"`
+	EncryptionKey string `json:"encryption-key,omitempty"`
 }
 
 // VolumeAttachmentsResult holds the volume attachments for a single
@@ -283,11 +297,74 @@ type VolumeAttachmentParamsResults struct {
 	Results []VolumeAttachmentParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Provider  string `json:"provider"`
+
+	// Size is the size, in MiB, that the volume is to be grown to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeResizeParamsResult holds resize parameters for a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resize parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotParams holds the parameters for snapshotting a volume.
+type VolumeSnapshotParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Provider  string `json:"provider"`
+}
+
+// VolumeSnapshotParamsResult holds snapshot parameters for a volume.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds snapshot parameters for multiple
+// volumes.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshot describes a snapshot of a volume.
+type VolumeSnapshot struct {
+	SnapshotId string    `json:"snapshot-id"`
+	VolumeId   string    `json:"volume-id"`
+	Size       uint64    `json:"size"`
+	Created    time.Time `json:"created"`
+}
+
+// VolumeSnapshots holds the snapshots of a volume.
+type VolumeSnapshots struct {
+	VolumeTag string           `json:"volume-tag"`
+	Snapshots []VolumeSnapshot `json:"snapshots"`
+}
+
+// SetVolumeSnapshots holds the snapshots of a set of volumes.
+type SetVolumeSnapshots struct {
+	Volumes []VolumeSnapshots `json:"volumes"`
+}
+
 // Filesystem identifies and describes a storage filesystem in the model.
 type Filesystem struct {
 	FilesystemTag string         `json:"filesystem-tag"`
 	VolumeTag     string         `json:"volume-tag,omitempty"`
 	Info          FilesystemInfo `json:"info"`
+
+	// Releasing reports whether the filesystem is to be released
+	// from the model when it is removed, rather than destroyed.
+	Releasing bool `json:"releasing,omitempty"`
 }
 
 // Filesystem describes a storage filesystem in the model.
@@ -390,6 +467,29 @@ type FilesystemAttachmentParamsResults struct {
 	Results []FilesystemAttachmentParamsResult `json:"results,omitempty"`
 }
 
+// FilesystemResizeParams holds the parameters for resizing a filesystem.
+type FilesystemResizeParams struct {
+	FilesystemTag string `json:"filesystem-tag"`
+	FilesystemId  string `json:"filesystem-id"`
+	VolumeTag     string `json:"volume-tag,omitempty"`
+	Provider      string `json:"provider"`
+
+	// Size is the size, in MiB, that the filesystem is to be grown to.
+	Size uint64 `json:"size"`
+}
+
+// FilesystemResizeParamsResult holds resize parameters for a filesystem.
+type FilesystemResizeParamsResult struct {
+	Result FilesystemResizeParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// FilesystemResizeParamsResults holds resize parameters for multiple
+// filesystems.
+type FilesystemResizeParamsResults struct {
+	Results []FilesystemResizeParamsResult `json:"results,omitempty"`
+}
+
 // StorageDetails holds information about storage.
 type StorageDetails struct {
 	// StorageTag holds tag for this storage.
@@ -409,11 +509,41 @@ type StorageDetails struct {
 	// the machine that it is attached to.
 	Persistent bool `json:"persistent"`
 
+	// Usage contains the most recently reported usage of the
+	// underlying volume or filesystem, if any.
+	Usage *StorageUsage `json:"usage,omitempty"`
+
 	// Attachments contains a mapping from unit tag to
 	// storage attachment details.
 	Attachments map[string]StorageAttachmentDetails `json:"attachments,omitempty"`
 }
 
+// StorageUsage describes how much of a volume or filesystem's
+// capacity is in use.
+type StorageUsage struct {
+	// Capacity is the total capacity, in bytes.
+	Capacity uint64 `json:"capacity"`
+
+	// Used is the amount of the capacity in use, in bytes.
+	Used uint64 `json:"used"`
+}
+
+// MachineStorageUsage holds the usage of a volume or filesystem,
+// as observed by the machine that it is attached to.
+type MachineStorageUsage struct {
+	MachineTag string `json:"machine-tag"`
+	// AttachmentTag is the tag of the volume or filesystem
+	// whose usage is reported.
+	AttachmentTag string       `json:"attachment-tag"`
+	Usage         StorageUsage `json:"usage"`
+}
+
+// MachineStorageUsages holds the usage of a set of volumes
+// and filesystems.
+type MachineStorageUsages struct {
+	Usages []MachineStorageUsage `json:"usages"`
+}
+
 // StorageFilter holds filter terms for listing storage details.
 type StorageFilter struct {
 	// We don't currently implement any filters. This exists to get the
@@ -476,6 +606,16 @@ type StoragePool struct {
 	Attrs map[string]interface{} `json:"attrs"`
 }
 
+// StoragePools holds a collection of storage pools.
+type StoragePools struct {
+	Pools []StoragePool `json:"pools"`
+}
+
+// StoragePoolNames holds a collection of storage pool names.
+type StoragePoolNames struct {
+	Names []string `json:"names"`
+}
+
 // StoragePoolFilter holds a filter for matching storage pools.
 type StoragePoolFilter struct {
 	// Names are pool's names to filter on.
@@ -554,6 +694,10 @@ type VolumeDetails struct {
 	// machine tag to volume attachment information.
 	MachineAttachments map[string]VolumeAttachmentInfo `json:"machine-attachments,omitempty"`
 
+	// Usage contains the most recently reported usage of
+	// the volume, if any.
+	Usage *StorageUsage `json:"usage,omitempty"`
+
 	// Storage contains details about the storage instance
 	// that the volume is assigned to, if any.
 	Storage *StorageDetails `json:"storage,omitempty"`
@@ -610,6 +754,10 @@ type FilesystemDetails struct {
 	// machine tag to filesystem attachment information.
 	MachineAttachments map[string]FilesystemAttachmentInfo `json:"machine-attachments,omitempty"`
 
+	// Usage contains the most recently reported usage of
+	// the filesystem, if any.
+	Usage *StorageUsage `json:"usage,omitempty"`
+
 	// Storage contains details about the storage instance
 	// that the volume is assigned to, if any.
 	Storage *StorageDetails `json:"storage,omitempty"`
@@ -668,3 +816,143 @@ type StorageAddParams struct {
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// RemoveStorageInstance holds the parameters for removing a storage
+// instance from the model.
+type RemoveStorageInstance struct {
+	// Tag is the tag of the storage instance to remove.
+	Tag string `json:"tag"`
+
+	// Release, if true, causes the storage instance's volume or
+	// filesystem to be released from the model rather than destroyed,
+	// leaving the underlying storage intact.
+	Release bool `json:"release,omitempty"`
+}
+
+// RemoveStorage holds the parameters for removing storage
+// instances from the model.
+type RemoveStorage struct {
+	Storage []RemoveStorageInstance `json:"storage"`
+}
+
+// BulkImportStorageParams contains the parameters for importing
+// a collection of existing storage entities into the model.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageParams contains the parameters for importing an
+// existing storage entity into the model.
+type ImportStorageParams struct {
+	// Kind is the kind of the storage entity to import.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool into which the storage is
+	// to be imported.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's unique ID for the storage,
+	// e.g. the EBS volume ID.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the storage to assign to the entity.
+	StorageName string `json:"storage-name"`
+}
+
+// ImportStorageResults contains the results of importing a collection
+// of storage entities.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// ImportStorageResult contains the result of importing a storage entity.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageDetails contains the details of an imported storage entity.
+type ImportStorageDetails struct {
+	// StorageTag contains the string representation of the storage tag
+	// assigned to the imported storage entity.
+	StorageTag string `json:"storage-tag"`
+}
+
+// StorageSnapshot contains the details of a snapshot of the volume
+// underlying a storage instance.
+type StorageSnapshot struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// StorageTag contains the string representation of the tag of the
+	// storage instance whose volume was snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time `json:"created"`
+}
+
+// CreateStorageSnapshotResults contains the results of snapshotting a
+// collection of storage instances.
+type CreateStorageSnapshotResults struct {
+	Results []CreateStorageSnapshotResult `json:"results"`
+}
+
+// CreateStorageSnapshotResult contains the result of snapshotting a
+// storage instance.
+type CreateStorageSnapshotResult struct {
+	Result *StorageSnapshot `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+
+	// Pending is true if the snapshot has been requested, and will
+	// be taken asynchronously by the storage provisioner responsible
+	// for the volume. Result is not set if Pending is true.
+	Pending bool `json:"pending,omitempty"`
+}
+
+// StorageSnapshotsResults contains the snapshots of a collection of
+// storage instances.
+type StorageSnapshotsResults struct {
+	Results []StorageSnapshotsResult `json:"results"`
+}
+
+// StorageSnapshotsResult contains the snapshots of a storage instance.
+type StorageSnapshotsResult struct {
+	Result []StorageSnapshot `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// RestoreStorageSnapshots contains the parameters for restoring a
+// collection of storage instances from snapshots.
+type RestoreStorageSnapshots struct {
+	Snapshots []RestoreStorageSnapshot `json:"snapshots"`
+}
+
+// RestoreStorageSnapshot contains the parameters for restoring a
+// storage instance from a snapshot.
+type RestoreStorageSnapshot struct {
+	// StorageTag contains the string representation of the tag of the
+	// storage instance to restore.
+	StorageTag string `json:"storage-tag"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot
+	// to restore the storage instance from.
+	SnapshotId string `json:"snapshot-id"`
+}
+
+// StorageResize holds the parameters for growing a storage instance.
+type StorageResize struct {
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StorageResizes holds the parameters for growing multiple storage
+// instances.
+type StorageResizes struct {
+	Resizes []StorageResize `json:"resizes"`
+}
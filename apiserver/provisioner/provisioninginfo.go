@@ -168,6 +168,7 @@ func (p *ProvisionerAPI) machineVolumeParams(m *state.Machine) ([]params.VolumeP
 			"", // we're creating the machine, so it has no instance ID.
 			volumeParams.Provider,
 			volumeAttachmentParams.ReadOnly,
+			"", // only dynamic volumes may be encrypted.
 		}
 		allVolumeParams = append(allVolumeParams, volumeParams)
 	}
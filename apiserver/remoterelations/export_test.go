@@ -0,0 +1,11 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+var (
+	SyncRemoteRelation = syncRemoteRelation
+	ConsumerProxyName  = consumerProxyName
+
+	WatchRemoteRelationUnits = watchRemoteRelationUnits
+)
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides the API used by the remote relations
+// worker to relay the state of cross-model relations between the models
+// hosted by a controller.
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchRemoteApplications returns a watcher that sends the names
+	// of remote applications whose lifecycles have changed.
+	WatchRemoteApplications() state.StringsWatcher
+
+	// WatchRemoteApplicationRelations returns a watcher that sends
+	// the keys of relations involving the named remote application
+	// whose lifecycles have changed.
+	WatchRemoteApplicationRelations(applicationName string) (state.StringsWatcher, error)
+
+	// WatchRemoteRelationUnits returns a watcher that notifies of
+	// changes to the units in scope, and their settings, on both
+	// sides of the synchronised relation with the supplied key.
+	WatchRemoteRelationUnits(key string) (state.NotifyWatcher, error)
+
+	// SyncRemoteRelation relays the units and settings of the relation
+	// with the supplied key to and from the model hosting its remote
+	// application, creating or destroying the relation there as needed.
+	SyncRemoteRelation(key string) error
+}
+
+// Facade allows model-manager clients to watch and relay cross-model
+// relations.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// WatchRemoteApplications returns a watcher that sends the names of
+// remote applications whose lifecycles have changed.
+func (facade *Facade) WatchRemoteApplications() (params.StringsWatchResult, error) {
+	watch := facade.backend.WatchRemoteApplications()
+	if changes, ok := <-watch.Changes(); ok {
+		id := facade.resources.Register(watch)
+		return params.StringsWatchResult{
+			StringsWatcherId: id,
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// WatchRemoteApplicationRelations returns, for each of the supplied
+// remote applications, a watcher that sends the keys of relations
+// involving the application whose lifecycles have changed.
+func (facade *Facade) WatchRemoteApplicationRelations(args params.Entities) params.StringsWatchResults {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		result, err := facade.watchRelationsOne(entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results
+}
+
+func (facade *Facade) watchRelationsOne(tagString string) (params.StringsWatchResult, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return params.StringsWatchResult{}, common.ErrPerm
+	}
+	watch, err := facade.backend.WatchRemoteApplicationRelations(tag.Id())
+	if err != nil {
+		return params.StringsWatchResult{}, errors.Trace(err)
+	}
+	if changes, ok := <-watch.Changes(); ok {
+		id := facade.resources.Register(watch)
+		return params.StringsWatchResult{
+			StringsWatcherId: id,
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// WatchRemoteRelationUnits returns, for each of the supplied relations,
+// a watcher that notifies of changes to the units in scope, and their
+// settings, in the relation and in its mirror in the offering model.
+// The relations must already have been synchronised.
+func (facade *Facade) WatchRemoteRelationUnits(args params.Entities) params.NotifyWatchResults {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		id, err := facade.watchRelationUnitsOne(entity.Tag)
+		results.Results[i].NotifyWatcherId = id
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+func (facade *Facade) watchRelationUnitsOne(tagString string) (string, error) {
+	tag, err := names.ParseRelationTag(tagString)
+	if err != nil {
+		return "", common.ErrPerm
+	}
+	watch, err := facade.backend.WatchRemoteRelationUnits(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// Consume the initial event; the relation has just been
+	// synchronised, so there is nothing to report.
+	if _, ok := <-watch.Changes(); ok {
+		return facade.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// SyncRemoteRelations relays the units and settings of the supplied
+// relations to and from the models hosting their remote applications.
+// A removed relation has its mirror torn down, and reports a NotFound
+// error.
+func (facade *Facade) SyncRemoteRelations(args params.Entities) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := facade.syncOne(entity.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+func (facade *Facade) syncOne(tagString string) error {
+	tag, err := names.ParseRelationTag(tagString)
+	if err != nil {
+		return common.ErrPerm
+	}
+	return facade.backend.SyncRemoteRelation(tag.Id())
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/remoterelations"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := remoterelations.NewFacade(nil, nil, auth(true))
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := remoterelations.NewFacade(nil, nil, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatchRemoteApplicationsError(c *gc.C) {
+	fix := newFixture(c, false)
+	result, err := fix.Facade.WatchRemoteApplications()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(result, gc.DeepEquals, params.StringsWatchResult{})
+	c.Check(fix.Resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestWatchRemoteApplicationsSuccess(c *gc.C) {
+	fix := newFixture(c, true)
+	result, err := fix.Facade.WatchRemoteApplications()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result.Changes, jc.DeepEquals, []string{"pow", "zap", "kerblooie"})
+	c.Check(fix.Resources.Count(), gc.Equals, 1)
+	resource := fix.Resources.Get(result.StringsWatcherId)
+	c.Check(resource, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestWatchRemoteApplicationRelations(c *gc.C) {
+	fix := newFixture(c, true)
+	results := fix.Facade.WatchRemoteApplicationRelations(entities(
+		"application-mysql", "application-missing", "unit-mysql-0",
+	))
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Changes, jc.DeepEquals, []string{"pow", "zap", "kerblooie"})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `remote application "missing" not found`)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Check(fix.Resources.Count(), gc.Equals, 1)
+}
+
+func (s *FacadeSuite) TestWatchRemoteApplicationRelationsError(c *gc.C) {
+	fix := newFixture(c, false)
+	results := fix.Facade.WatchRemoteApplicationRelations(entities("application-mysql"))
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "blammo")
+	c.Check(fix.Resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestWatchRemoteRelationUnits(c *gc.C) {
+	fix := newFixture(c, true)
+	results := fix.Facade.WatchRemoteRelationUnits(entities(
+		"relation-wordpress.db#mysql.server",
+		"relation-wordpress.db#mediawiki.server",
+		"application-mysql",
+	))
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].NotifyWatcherId, gc.Not(gc.Equals), "")
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `relation "wordpress:db mediawiki:server" not found`)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Check(fix.Resources.Count(), gc.Equals, 1)
+	resource := fix.Resources.Get(results.Results[0].NotifyWatcherId)
+	c.Check(resource, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestWatchRemoteRelationUnitsError(c *gc.C) {
+	fix := newFixture(c, false)
+	results := fix.Facade.WatchRemoteRelationUnits(entities("relation-wordpress.db#mysql.server"))
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "blammo")
+	c.Check(fix.Resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestSyncRemoteRelations(c *gc.C) {
+	fix := newFixture(c, true)
+	results := fix.Facade.SyncRemoteRelations(entities(
+		"relation-wordpress.db#mysql.server",
+		"relation-wordpress.db#mediawiki.server",
+		"application-mysql",
+	))
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "blammo")
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
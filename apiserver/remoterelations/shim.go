@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+func init() {
+	common.RegisterStandardFacade("RemoteRelations", 1, newFacade)
+
+	// Facade version 2 adds WatchRemoteRelationUnits.
+	common.RegisterStandardFacade("RemoteRelations", 2, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the Facade.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	st *state.State
+}
+
+// WatchRemoteApplications is part of the Backend interface.
+func (shim backendShim) WatchRemoteApplications() state.StringsWatcher {
+	return shim.st.WatchRemoteApplications()
+}
+
+// WatchRemoteApplicationRelations is part of the Backend interface.
+func (shim backendShim) WatchRemoteApplicationRelations(applicationName string) (state.StringsWatcher, error) {
+	app, err := shim.st.RemoteApplication(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.WatchRelations(), nil
+}
+
+// WatchRemoteRelationUnits is part of the Backend interface.
+func (shim backendShim) WatchRemoteRelationUnits(key string) (state.NotifyWatcher, error) {
+	return watchRemoteRelationUnits(shim.st, key)
+}
+
+// SyncRemoteRelation is part of the Backend interface.
+func (shim backendShim) SyncRemoteRelation(key string) error {
+	return syncRemoteRelation(shim.st, key)
+}
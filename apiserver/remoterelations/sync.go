@@ -0,0 +1,480 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.remoterelations")
+
+// syncRemoteRelation relays the units and settings of the relation with
+// the supplied key, in the model of the supplied state, to and from the
+// model that offers its remote application.
+//
+// The relation is mirrored in the offering model by a relation between
+// the offered application and a consumer proxy: a remote application
+// named after the consuming application and model. Each unit of the
+// consuming application in scope is represented in the mirror relation
+// by a unit of the proxy with the same number, and each unit of the offered
+// application in scope in the mirror relation is represented in the
+// consuming model by a unit of the remote application with the same
+// number. The private addresses of the consuming units are recorded as
+// the mirror relation's ingress networks.
+//
+// Relations of consumer proxies are mirrors, so they are left alone;
+// they are synchronised from the consuming model. If the relation has
+// been removed, its mirror is torn down and a NotFound error returned,
+// so that callers may stop synchronising it.
+func syncRemoteRelation(st *state.State, key string) error {
+	rel, err := st.KeyRelation(key)
+	if errors.IsNotFound(err) {
+		// The relation has been removed, but its mirror may remain.
+		if err := teardownRemovedRelation(st, key); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.NotFoundf("relation %q", key)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	s, err := newRelationSyncer(st, rel.Endpoints())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s == nil {
+		return nil
+	}
+	defer s.close()
+	s.rel = rel
+	if rel.Life() != state.Alive {
+		return errors.Trace(s.teardown())
+	}
+	return errors.Trace(s.sync())
+}
+
+// teardownRemovedRelation tears down the mirror of the removed relation
+// with the supplied key, if its remote application still exists.
+func teardownRemovedRelation(st *state.State, key string) error {
+	var eps []state.Endpoint
+	for _, part := range strings.Fields(key) {
+		fields := strings.SplitN(part, ":", 2)
+		if len(fields) != 2 {
+			return errors.NotValidf("relation key %q", key)
+		}
+		eps = append(eps, state.Endpoint{
+			ApplicationName: fields[0],
+			Relation:        charm.Relation{Name: fields[1]},
+		})
+	}
+	s, err := newRelationSyncer(st, eps)
+	if errors.IsNotValid(err) {
+		logger.Debugf("not tearing down removed relation %q: %v", key, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if s == nil {
+		return nil
+	}
+	defer s.close()
+	return errors.Trace(s.teardown())
+}
+
+// relationSyncer holds the state of both sides of a cross-model
+// relation being synchronised.
+type relationSyncer struct {
+	// st and rel are the consuming model and its relation; rel is nil
+	// if the relation has already been removed.
+	st  *state.State
+	rel *state.Relation
+
+	// localEp and remoteEp are the relation's endpoints for the
+	// consuming application and for remoteApp, the remote application
+	// standing for the offered application in the consuming model.
+	localEp   state.Endpoint
+	remoteEp  state.Endpoint
+	remoteApp *state.RemoteApplication
+
+	// offerSt is the offering model.
+	offerSt *state.State
+}
+
+// newRelationSyncer returns a relationSyncer for the relation with the
+// supplied endpoints, or nil if the relation does not need synchronising
+// from this model.
+func newRelationSyncer(st *state.State, eps []state.Endpoint) (*relationSyncer, error) {
+	s := &relationSyncer{st: st}
+	for _, ep := range eps {
+		app, err := st.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			s.localEp = ep
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s.remoteEp = ep
+		s.remoteApp = app
+	}
+	if s.remoteApp == nil {
+		return nil, errors.NotValidf("relation without remote application")
+	}
+	if s.remoteApp.IsConsumerProxy() {
+		return nil, nil
+	}
+	offerSt, err := st.ForModel(s.remoteApp.SourceModel())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot open model of remote application %q", s.remoteApp)
+	}
+	s.offerSt = offerSt
+	return s, nil
+}
+
+func (s *relationSyncer) close() {
+	if err := s.offerSt.Close(); err != nil {
+		logger.Errorf("error closing state for model %q: %v", s.remoteApp.SourceModel().Id(), err)
+	}
+}
+
+// sync ensures that the relation is mirrored in the offering model,
+// and relays units and settings in both directions.
+func (s *relationSyncer) sync() error {
+	offer, err := s.offerSt.ApplicationOffer(s.remoteApp.OfferName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offeredEp, err := offerEndpoint(offer, s.remoteEp.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	proxyEp, err := s.ensureProxy()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mirror, err := s.offerSt.EndpointsRelation(proxyEp, offeredEp)
+	if errors.IsNotFound(err) {
+		mirror, err = s.offerSt.AddRelation(proxyEp, offeredEp)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot mirror relation %q", s.rel)
+	}
+	if mirror.Life() != state.Alive {
+		return errors.Errorf("mirror of relation %q is not alive", s.rel)
+	}
+	if err := s.relayToOffer(mirror); err != nil {
+		return errors.Annotatef(err, "cannot relay units of %q", s.localEp.ApplicationName)
+	}
+	if err := s.relayFromOffer(mirror, offeredEp.ApplicationName); err != nil {
+		return errors.Annotatef(err, "cannot relay units of %q", s.remoteApp)
+	}
+	return nil
+}
+
+// proxyName returns the name of the consumer proxy standing for the
+// consuming application in the offering model.
+func (s *relationSyncer) proxyName() string {
+	return consumerProxyName(s.localEp.ApplicationName, s.st.ModelUUID())
+}
+
+// ensureProxy ensures that the consuming application is represented in
+// the offering model by a consumer proxy, and returns the proxy endpoint
+// corresponding to the relation's local endpoint.
+func (s *relationSyncer) ensureProxy() (state.Endpoint, error) {
+	name := s.proxyName()
+	proxy, err := s.offerSt.RemoteApplication(name)
+	if errors.IsNotFound(err) {
+		app, err := s.st.Application(s.localEp.ApplicationName)
+		if err != nil {
+			return state.Endpoint{}, errors.Trace(err)
+		}
+		eps, err := app.Endpoints()
+		if err != nil {
+			return state.Endpoint{}, errors.Trace(err)
+		}
+		var relations []charm.Relation
+		for _, ep := range eps {
+			if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+				continue
+			}
+			relations = append(relations, ep.Relation)
+		}
+		proxy, err = s.offerSt.AddRemoteApplication(state.AddRemoteApplicationParams{
+			Name:            name,
+			SourceModel:     s.st.ModelTag(),
+			Endpoints:       relations,
+			IsConsumerProxy: true,
+		})
+		if err != nil {
+			return state.Endpoint{}, errors.Trace(err)
+		}
+	} else if err != nil {
+		return state.Endpoint{}, errors.Trace(err)
+	} else if !proxy.IsConsumerProxy() || proxy.SourceModel() != s.st.ModelTag() {
+		return state.Endpoint{}, errors.AlreadyExistsf(
+			"application %q in model %q", name, s.offerSt.ModelUUID(),
+		)
+	}
+	return proxy.Endpoint(s.localEp.Name)
+}
+
+// relayToOffer relays the consuming application's units in scope, and
+// their settings, to the consumer proxy in the mirror relation.
+func (s *relationSyncer) relayToOffer(mirror *state.Relation) error {
+	name := s.localEp.ApplicationName
+	unitNames, err := s.rel.UnitsInScope(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	proxyName := s.proxyName()
+	var proxyNames, cidrs []string
+	for _, unitName := range unitNames {
+		unit, err := s.st.Unit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ru, err := s.rel.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		settings, err := ru.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		proxyUnitName := renameUnit(unitName, proxyName)
+		proxyUnit, err := mirror.RemoteUnit(proxyUnitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := enterScopeWithSettings(proxyUnit, settings.Map()); err != nil {
+			return errors.Trace(err)
+		}
+		proxyNames = append(proxyNames, proxyUnitName)
+		if cidr := addressCIDR(settings.Map()["private-address"]); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	if err := leaveScopeExcept(mirror, proxyName, proxyNames); err != nil {
+		return errors.Trace(err)
+	}
+	sort.Strings(cidrs)
+	existing, err := mirror.IngressNetworks()
+	if errors.IsNotFound(err) {
+		if len(cidrs) == 0 {
+			return nil
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	} else if strings.Join(existing, ",") == strings.Join(cidrs, ",") {
+		return nil
+	}
+	return mirror.SetIngressNetworks(cidrs)
+}
+
+// relayFromOffer relays the offered application's units in scope in
+// the mirror relation, and their settings, to the remote application
+// in the consumer's relation.
+func (s *relationSyncer) relayFromOffer(mirror *state.Relation, offeredName string) error {
+	unitNames, err := mirror.UnitsInScope(offeredName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var remoteNames []string
+	for _, unitName := range unitNames {
+		unit, err := s.offerSt.Unit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ru, err := mirror.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		settings, err := ru.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		remoteName := renameUnit(unitName, s.remoteApp.Name())
+		remoteUnit, err := s.rel.RemoteUnit(remoteName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := enterScopeWithSettings(remoteUnit, settings.Map()); err != nil {
+			return errors.Trace(err)
+		}
+		remoteNames = append(remoteNames, remoteName)
+	}
+	return leaveScopeExcept(s.rel, s.remoteApp.Name(), remoteNames)
+}
+
+// teardown removes the units relayed into either model, and destroys
+// the mirror relation in the offering model, along with the consumer
+// proxy if it is no longer in use.
+func (s *relationSyncer) teardown() error {
+	if s.rel != nil {
+		if err := leaveScopeExcept(s.rel, s.remoteApp.Name(), nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	proxy, err := s.offerSt.RemoteApplication(s.proxyName())
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	} else if !proxy.IsConsumerProxy() || proxy.SourceModel() != s.st.ModelTag() {
+		return nil
+	}
+	rels, err := proxy.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, mirror := range rels {
+		if !s.isMirror(mirror) {
+			continue
+		}
+		if err := leaveScopeExcept(mirror, proxy.Name(), nil); err != nil {
+			return errors.Trace(err)
+		}
+		if err := mirror.Destroy(); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	// The proxy will be removed along with the last of its relations
+	// once it is dying; so it can be destroyed once none of them is
+	// alive.
+	rels, err = proxy.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range rels {
+		if rel.Life() == state.Alive {
+			return nil
+		}
+	}
+	return proxy.Destroy()
+}
+
+// isMirror returns whether the supplied relation in the offering model
+// mirrors the relation being synchronised.
+func (s *relationSyncer) isMirror(rel *state.Relation) bool {
+	proxyName := s.proxyName()
+	proxyEp, err := rel.Endpoint(proxyName)
+	if err != nil || proxyEp.Name != s.localEp.Name {
+		return false
+	}
+	for _, ep := range rel.Endpoints() {
+		if ep.ApplicationName != proxyName {
+			return ep.Name == s.remoteEp.Name
+		}
+	}
+	return false
+}
+
+// offerEndpoint returns the offered endpoint with the supplied name.
+func offerEndpoint(offer *state.ApplicationOffer, name string) (state.Endpoint, error) {
+	eps, err := offer.Endpoints()
+	if err != nil {
+		return state.Endpoint{}, errors.Trace(err)
+	}
+	for _, ep := range eps {
+		if ep.Name == name {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint %q in offer %q", name, offer.Name())
+}
+
+// enterScopeWithSettings ensures that the supplied relation unit is in
+// scope, with exactly the supplied settings.
+func enterScopeWithSettings(ru *state.RelationUnit, settings map[string]interface{}) error {
+	inScope, err := ru.InScope()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !inScope {
+		return ru.EnterScope(settings)
+	}
+	node, err := ru.Settings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if reflect.DeepEqual(node.Map(), settings) {
+		return nil
+	}
+	for key := range node.Map() {
+		if _, ok := settings[key]; !ok {
+			node.Delete(key)
+		}
+	}
+	node.Update(settings)
+	_, err = node.Write()
+	return errors.Trace(err)
+}
+
+// leaveScopeExcept causes all units of the named remote application in
+// scope in the supplied relation, other than those named, to leave it.
+func leaveScopeExcept(rel *state.Relation, applicationName string, keep []string) error {
+	unitNames, err := rel.UnitsInScope(applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	keepSet := make(map[string]bool)
+	for _, name := range keep {
+		keepSet[name] = true
+	}
+	for _, unitName := range unitNames {
+		if keepSet[unitName] {
+			continue
+		}
+		ru, err := rel.RemoteUnit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// consumerProxyName returns the name of the consumer proxy standing
+// for the named application of the model with the supplied UUID. The
+// model UUID is included so that applications of the same name in
+// different consuming models are kept apart; its hex digits follow a
+// letter so that the result is a valid application name.
+func consumerProxyName(applicationName, modelUUID string) string {
+	return fmt.Sprintf("%s-m%s", applicationName, strings.Replace(modelUUID, "-", "", -1))
+}
+
+// renameUnit returns the name of the unit of the named application
+// with the same number as the supplied unit.
+func renameUnit(unitName, applicationName string) string {
+	number := unitName[strings.Index(unitName, "/")+1:]
+	return fmt.Sprintf("%s/%s", applicationName, number)
+}
+
+// addressCIDR returns the host CIDR of the supplied address value, as
+// found in the "private-address" relation setting; or the empty string
+// if it is not an IP address.
+func addressCIDR(value interface{}) string {
+	s, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return ""
+	}
+	if ip.To4() != nil {
+		return s + "/32"
+	}
+	return s + "/128"
+}
@@ -0,0 +1,260 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/remoterelations"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/testing/factory"
+)
+
+type syncSuite struct {
+	statetesting.StateSuite
+
+	offerSt *state.State
+	mysql   *state.Application
+	rel     *state.Relation
+}
+
+var _ = gc.Suite(&syncSuite{})
+
+func (s *syncSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+
+	// The offering model hosts mysql, offered as "db".
+	s.offerSt = s.Factory.MakeModel(c, &factory.ModelParams{Name: "prod"})
+	s.AddCleanup(func(*gc.C) { s.offerSt.Close() })
+	offerFactory := factory.NewFactory(s.offerSt)
+	s.mysql = offerFactory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: offerFactory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	_, err := s.offerSt.AddApplicationOffer(state.AddApplicationOfferParams{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The consuming model relates wordpress to the offer.
+	s.rel = s.consume(c, s.State, s.Factory)
+}
+
+// consume relates a new wordpress application in the supplied model
+// to the offer, returning the relation.
+func (s *syncSuite) consume(c *gc.C, st *state.State, f *factory.Factory) *state.Relation {
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	_, err := st.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "proddb",
+		URL:         "admin/prod.db",
+		SourceModel: s.offerSt.ModelTag(),
+		OfferName:   "db",
+		Endpoints: []charm.Relation{{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := st.InferEndpoints("wordpress", "proddb")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := st.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *syncSuite) proxyName() string {
+	return remoterelations.ConsumerProxyName("wordpress", s.State.ModelUUID())
+}
+
+func (s *syncSuite) sync(c *gc.C) {
+	err := remoterelations.SyncRemoteRelation(s.State, s.rel.String())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *syncSuite) mirror(c *gc.C) *state.Relation {
+	proxy, err := s.offerSt.RemoteApplication(s.proxyName())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxy.IsConsumerProxy(), jc.IsTrue)
+	c.Assert(proxy.SourceModel(), gc.Equals, s.State.ModelTag())
+	rels, err := proxy.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].String(), gc.Equals, s.proxyName()+":db mysql:server")
+	return rels[0]
+}
+
+func (s *syncSuite) enterScope(c *gc.C, rel *state.Relation, unit *state.Unit, settings map[string]interface{}) {
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(settings)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *syncSuite) TestSyncCreatesMirror(c *gc.C) {
+	s.sync(c)
+	mirror := s.mirror(c)
+	c.Assert(mirror.Life(), gc.Equals, state.Alive)
+
+	// Syncing again is a no-op.
+	s.sync(c)
+	c.Assert(s.mirror(c).Id(), gc.Equals, mirror.Id())
+}
+
+func (s *syncSuite) TestSyncRelaysUnits(c *gc.C) {
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	s.enterScope(c, s.rel, wordpress0, map[string]interface{}{
+		"private-address": "10.0.0.1",
+	})
+	s.sync(c)
+
+	mirror := s.mirror(c)
+	inScope, err := mirror.UnitsInScope(s.proxyName())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.DeepEquals, []string{s.proxyName() + "/0"})
+	cidrs, err := mirror.IngressNetworks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.1/32"})
+
+	offerFactory := factory.NewFactory(s.offerSt)
+	offerFactory.MakeUnit(c, &factory.UnitParams{Application: s.mysql})
+	mysql1 := offerFactory.MakeUnit(c, &factory.UnitParams{Application: s.mysql})
+	s.enterScope(c, mirror, mysql1, map[string]interface{}{
+		"private-address": "10.1.0.1",
+		"user":            "wordpress",
+	})
+	s.sync(c)
+
+	inScope, err = s.rel.UnitsInScope("proddb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.DeepEquals, []string{"proddb/1"})
+	ru, err := s.rel.RemoteUnit("proddb/1")
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.1.0.1",
+		"user":            "wordpress",
+	})
+
+	// Departing units are relayed too.
+	mysqlRU, err := mirror.Unit(mysql1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRU.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	s.sync(c)
+	inScope, err = s.rel.UnitsInScope("proddb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, gc.HasLen, 0)
+}
+
+func (s *syncSuite) TestSyncTearsDownRemovedRelation(c *gc.C) {
+	s.sync(c)
+	mirror := s.mirror(c)
+
+	// With no units in scope, the relation is removed immediately.
+	err := s.rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoterelations.SyncRemoteRelation(s.State, "wordpress:db proddb:server")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = mirror.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.offerSt.RemoteApplication(s.proxyName())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *syncSuite) TestSyncMissingRelation(c *gc.C) {
+	err := remoterelations.SyncRemoteRelation(s.State, "wordpress:db mysql:server")
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *syncSuite) TestSyncTearsDownDyingRelation(c *gc.C) {
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	s.enterScope(c, s.rel, wordpress0, nil)
+	s.sync(c)
+	mirror := s.mirror(c)
+
+	err = s.rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.sync(c)
+
+	err = mirror.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.offerSt.RemoteApplication(s.proxyName())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *syncSuite) TestSyncProxyPerConsumingModel(c *gc.C) {
+	// Another model consumes the offer with an application of the
+	// same name.
+	otherSt := s.Factory.MakeModel(c, &factory.ModelParams{Name: "staging"})
+	defer otherSt.Close()
+	otherRel := s.consume(c, otherSt, factory.NewFactory(otherSt))
+
+	s.sync(c)
+	err := remoterelations.SyncRemoteRelation(otherSt, otherRel.String())
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherProxyName := remoterelations.ConsumerProxyName("wordpress", otherSt.ModelUUID())
+	c.Assert(otherProxyName, gc.Not(gc.Equals), s.proxyName())
+	otherProxy, err := s.offerSt.RemoteApplication(otherProxyName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(otherProxy.SourceModel(), gc.Equals, otherSt.ModelTag())
+	s.mirror(c)
+
+	rels, err := s.mysql.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 2)
+}
+
+func (s *syncSuite) TestWatchRemoteRelationUnits(c *gc.C) {
+	// The offering model's changes are seen through a state opened
+	// by the watcher, which cannot be synced by the test.
+	s.PatchValue(&watcher.Period, 10*time.Millisecond)
+	s.sync(c)
+	w, err := remoterelations.WatchRemoteRelationUnits(s.State, s.rel.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Consuming units entering scope are reported.
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	s.enterScope(c, s.rel, wordpress0, map[string]interface{}{
+		"private-address": "10.0.0.1",
+	})
+	wc.AssertOneChange()
+
+	// So are offered units entering the scope of the mirror.
+	offerFactory := factory.NewFactory(s.offerSt)
+	mysql0 := offerFactory.MakeUnit(c, &factory.UnitParams{Application: s.mysql})
+	s.enterScope(c, s.mirror(c), mysql0, map[string]interface{}{
+		"private-address": "10.1.0.1",
+	})
+	wc.AssertOneChange()
+}
+
+func (s *syncSuite) TestWatchRemoteRelationUnitsNotSynced(c *gc.C) {
+	_, err := remoterelations.WatchRemoteRelationUnits(s.State, s.rel.String())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/remoterelations"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthModelManager() bool {
+	return mock.modelManager
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(modelManager bool) facade.Authorizer {
+	return mockAuth{modelManager: modelManager}
+}
+
+// mockWatcher implements state.StringsWatcher for the tests' convenience.
+type mockWatcher struct {
+	state.StringsWatcher
+	working bool
+}
+
+func (mock *mockWatcher) Changes() <-chan []string {
+	ch := make(chan []string, 1)
+	if mock.working {
+		ch <- []string{"pow", "zap", "kerblooie"}
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (mock *mockWatcher) Err() error {
+	return errors.New("blammo")
+}
+
+// mockNotifyWatcher implements state.NotifyWatcher for the tests'
+// convenience.
+type mockNotifyWatcher struct {
+	state.NotifyWatcher
+	working bool
+}
+
+func (mock *mockNotifyWatcher) Changes() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	if mock.working {
+		ch <- struct{}{}
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (mock *mockNotifyWatcher) Err() error {
+	return errors.New("blammo")
+}
+
+// mockBackend implements remoterelations.Backend for the tests'
+// convenience.
+type mockBackend struct {
+	working bool
+}
+
+func (backend *mockBackend) WatchRemoteApplications() state.StringsWatcher {
+	return &mockWatcher{working: backend.working}
+}
+
+func (backend *mockBackend) WatchRemoteApplicationRelations(name string) (state.StringsWatcher, error) {
+	if name == "missing" {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	return &mockWatcher{working: backend.working}, nil
+}
+
+func (backend *mockBackend) WatchRemoteRelationUnits(key string) (state.NotifyWatcher, error) {
+	if key == "wordpress:db mediawiki:server" {
+		return nil, errors.NotFoundf("relation %q", key)
+	}
+	return &mockNotifyWatcher{working: backend.working}, nil
+}
+
+func (backend *mockBackend) SyncRemoteRelation(key string) error {
+	switch key {
+	case "wordpress:db mysql:server":
+		return nil
+	default:
+		return errors.New("blammo")
+	}
+}
+
+// fixture collects components needed to test the Facade.
+type fixture struct {
+	Facade    *remoterelations.Facade
+	Resources *common.Resources
+}
+
+func newFixture(c *gc.C, working bool) *fixture {
+	backend := &mockBackend{working: working}
+	resources := common.NewResources()
+	facade, err := remoterelations.NewFacade(backend, resources, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return &fixture{facade, resources}
+}
+
+// entities is a convenience constructor for params.Entities.
+func entities(tags ...string) params.Entities {
+	entities := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		entities.Entities[i].Tag = tag
+	}
+	return entities
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// watchRemoteRelationUnits returns a watcher that notifies of changes
+// to the units in scope, and to their settings, on both sides of the
+// relation with the supplied key: in the consuming model's relation,
+// and in its mirror in the offering model.
+//
+// The relation must already have been synchronised, so that its
+// mirror exists; if it does not, a NotFound error is returned.
+func watchRemoteRelationUnits(st *state.State, key string) (state.NotifyWatcher, error) {
+	rel, err := st.KeyRelation(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s, err := newRelationSyncer(st, rel.Endpoints())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s == nil {
+		// Mirror relations are synchronised, and so
+		// watched, from the consuming model.
+		return nil, errors.NotSupportedf("watching mirror relation %q", key)
+	}
+	s.rel = rel
+	w, err := s.watchUnits()
+	if err != nil {
+		s.close()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// watchUnits returns a watcher that notifies of changes to the units
+// in scope on either side of the relation. The returned watcher takes
+// ownership of the syncer's offering model state, closing it when the
+// watcher stops.
+func (s *relationSyncer) watchUnits() (state.NotifyWatcher, error) {
+	mirror, err := s.mirror()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The units of the consuming application are seen from the
+	// remote application's side of the relation, and the units of
+	// the offered application from the consumer proxy's side of the
+	// mirror; any unit name of the watching application will do.
+	localRU, err := s.rel.RemoteUnit(fmt.Sprintf("%s/0", s.remoteApp.Name()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerRU, err := mirror.RemoteUnit(fmt.Sprintf("%s/0", s.proxyName()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		newRelationUnitsNotifyWatcher(localRU.Watch(), nil),
+		newRelationUnitsNotifyWatcher(offerRU.Watch(), s.close),
+	), nil
+}
+
+// mirror returns the relation mirroring the relation being synchronised
+// in the offering model.
+func (s *relationSyncer) mirror() (*state.Relation, error) {
+	offer, err := s.offerSt.ApplicationOffer(s.remoteApp.OfferName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offeredEp, err := offerEndpoint(offer, s.remoteEp.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	proxy, err := s.offerSt.RemoteApplication(s.proxyName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	proxyEp, err := proxy.Endpoint(s.localEp.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.offerSt.EndpointsRelation(proxyEp, offeredEp)
+}
+
+// relationUnitsNotifyWatcher implements state.NotifyWatcher, sending an
+// event for each change reported by a RelationUnitsWatcher. The details
+// of the changes are not needed, since relations are synchronised in
+// full.
+type relationUnitsNotifyWatcher struct {
+	tomb    tomb.Tomb
+	source  state.RelationUnitsWatcher
+	changes chan struct{}
+	cleanup func()
+}
+
+// newRelationUnitsNotifyWatcher returns a relationUnitsNotifyWatcher
+// reporting the changes of the supplied watcher, which it stops when it
+// stops. The cleanup function, if not nil, is called once the source
+// watcher has stopped.
+func newRelationUnitsNotifyWatcher(source state.RelationUnitsWatcher, cleanup func()) *relationUnitsNotifyWatcher {
+	w := &relationUnitsNotifyWatcher{
+		source:  source,
+		changes: make(chan struct{}),
+		cleanup: cleanup,
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.changes)
+		if w.cleanup != nil {
+			defer w.cleanup()
+		}
+		defer watcher.Stop(w.source, &w.tomb)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+func (w *relationUnitsNotifyWatcher) loop() error {
+	var out chan<- struct{}
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.source.Changes():
+			if !ok {
+				return watcher.EnsureErr(w.source)
+			}
+			out = w.changes
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// Kill is part of the state.NotifyWatcher interface.
+func (w *relationUnitsNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the state.NotifyWatcher interface.
+func (w *relationUnitsNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop is part of the state.NotifyWatcher interface.
+func (w *relationUnitsNotifyWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Err is part of the state.NotifyWatcher interface.
+func (w *relationUnitsNotifyWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+// Changes is part of the state.NotifyWatcher interface.
+func (w *relationUnitsNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
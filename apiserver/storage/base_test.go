@@ -84,6 +84,15 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	attachStorageCall                       = "attachStorage"
+	detachStorageCall                       = "detachStorage"
+	destroyStorageInstanceCall              = "destroyStorageInstance"
+	releaseStorageInstanceCall              = "releaseStorageInstance"
+	addExistingFilesystemCall               = "addExistingFilesystem"
+	restoreVolumeInfoCall                   = "restoreVolumeInfo"
+	resizeVolumeCall                        = "resizeVolume"
+	requestVolumeSnapshotCall               = "requestVolumeSnapshot"
+	removeStoragePoolCall                   = "removeStoragePool"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 )
@@ -224,6 +233,46 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			return nil
+		},
+		detachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			return nil
+		},
+		destroyStorageInstance: func(tag names.StorageTag) error {
+			s.calls = append(s.calls, destroyStorageInstanceCall)
+			return nil
+		},
+		releaseStorageInstance: func(tag names.StorageTag) error {
+			s.calls = append(s.calls, releaseStorageInstanceCall)
+			return nil
+		},
+		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.calls = append(s.calls, addExistingFilesystemCall)
+			return names.NewStorageTag(storageName + "/0"), nil
+		},
+		restoreVolumeInfo: func(tag names.VolumeTag, info state.VolumeInfo) error {
+			s.calls = append(s.calls, restoreVolumeInfoCall)
+			return nil
+		},
+		resizeVolume: func(tag names.VolumeTag, size uint64) error {
+			s.calls = append(s.calls, resizeVolumeCall)
+			return nil
+		},
+		requestVolumeSnapshot: func(tag names.VolumeTag) error {
+			s.calls = append(s.calls, requestVolumeSnapshotCall)
+			return nil
+		},
+		removeStoragePool: func(name string) error {
+			s.calls = append(s.calls, removeStoragePoolCall)
+			if _, ok := s.pools[name]; !ok {
+				return errors.NotFoundf("pool %q", name)
+			}
+			delete(s.pools, name)
+			return nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -269,6 +318,15 @@ func (s *baseStorageSuite) constructPoolManager() *mockPoolManager {
 			s.pools[name] = pool
 			return pool, err
 		},
+		replacePool: func(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+			existing, ok := s.pools[name]
+			if !ok {
+				return nil, errors.NotFoundf("mock pool manager: replace pool %v", name)
+			}
+			pool, err := jujustorage.NewConfig(name, existing.Provider(), attrs)
+			s.pools[name] = pool
+			return pool, err
+		},
 		deletePool: func(name string) error {
 			delete(s.pools, name)
 			return nil
@@ -115,11 +115,16 @@ func (s *filesystemSuite) TestListFilesystemsFilesystemInfo(c *gc.C) {
 }
 
 func (s *filesystemSuite) TestListFilesystemsAttachmentInfo(c *gc.C) {
+	// The filesystem is provisioned before it can be attached.
+	s.filesystem.info = &state.FilesystemInfo{
+		Size: 123,
+	}
 	s.filesystemAttachment.info = &state.FilesystemAttachmentInfo{
 		MountPoint: "/tmp",
 		ReadOnly:   true,
 	}
 	expected := s.expectedFilesystemDetails()
+	expected.Info.Size = 123
 	expected.MachineAttachments[s.machineTag.String()] = params.FilesystemAttachmentInfo{
 		MountPoint: "/tmp",
 		ReadOnly:   true,
@@ -148,3 +153,17 @@ func (s *filesystemSuite) TestListFilesystemsVolumeBacked(c *gc.C) {
 	c.Assert(found.Results[0].Result, gc.HasLen, 1)
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
 }
+
+func (s *filesystemSuite) TestListFilesystemsUsage(c *gc.C) {
+	s.filesystem.usage = &state.StorageUsage{Capacity: 1000, Used: 250}
+	expected := s.expectedFilesystemDetails()
+	expected.Usage = &params.StorageUsage{Capacity: 1000, Used: 250}
+	expected.Storage.Usage = &params.StorageUsage{Capacity: 1000, Used: 250}
+	found, err := s.api.ListFilesystems(params.FilesystemFilters{
+		[]params.FilesystemFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
+}
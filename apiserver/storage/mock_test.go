@@ -14,10 +14,11 @@ import (
 )
 
 type mockPoolManager struct {
-	getPool    func(name string) (*jujustorage.Config, error)
-	createPool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
-	deletePool func(name string) error
-	listPools  func() ([]*jujustorage.Config, error)
+	getPool     func(name string) (*jujustorage.Config, error)
+	createPool  func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
+	replacePool func(name string, attrs map[string]interface{}) (*jujustorage.Config, error)
+	deletePool  func(name string) error
+	listPools   func() ([]*jujustorage.Config, error)
 }
 
 func (m *mockPoolManager) Get(name string) (*jujustorage.Config, error) {
@@ -28,6 +29,10 @@ func (m *mockPoolManager) Create(name string, providerType jujustorage.ProviderT
 	return m.createPool(name, providerType, attrs)
 }
 
+func (m *mockPoolManager) Replace(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+	return m.replacePool(name, attrs)
+}
+
 func (m *mockPoolManager) Delete(name string) error {
 	return m.deletePool(name)
 }
@@ -46,6 +51,8 @@ type mockState struct {
 	storageInstanceFilesystem           func(names.StorageTag) (state.Filesystem, error)
 	storageInstanceFilesystemAttachment func(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error)
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchVolume                         func(names.VolumeTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
@@ -60,6 +67,15 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	destroyStorageInstance              func(names.StorageTag) error
+	releaseStorageInstance              func(names.StorageTag) error
+	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	restoreVolumeInfo                   func(names.VolumeTag, state.VolumeInfo) error
+	resizeVolume                        func(names.VolumeTag, uint64) error
+	requestVolumeSnapshot               func(names.VolumeTag) error
+	removeStoragePool                   func(string) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -100,6 +116,14 @@ func (st *mockState) WatchStorageAttachment(s names.StorageTag, u names.UnitTag)
 	return st.watchStorageAttachment(s, u)
 }
 
+func (st *mockState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return st.watchVolume(v)
+}
+
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
 func (st *mockState) WatchFilesystemAttachment(mtag names.MachineTag, f names.FilesystemTag) state.NotifyWatcher {
 	return st.watchFilesystemAttachment(mtag, f)
 }
@@ -156,6 +180,42 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) AttachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.attachStorage(storage, unit)
+}
+
+func (st *mockState) DetachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.detachStorage(storage, unit)
+}
+
+func (st *mockState) DestroyStorageInstance(tag names.StorageTag) error {
+	return st.destroyStorageInstance(tag)
+}
+
+func (st *mockState) ReleaseStorageInstance(tag names.StorageTag) error {
+	return st.releaseStorageInstance(tag)
+}
+
+func (st *mockState) AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
+	return st.addExistingFilesystem(f, v, storageName)
+}
+
+func (st *mockState) RestoreVolumeInfo(tag names.VolumeTag, info state.VolumeInfo) error {
+	return st.restoreVolumeInfo(tag, info)
+}
+
+func (st *mockState) ResizeVolume(tag names.VolumeTag, size uint64) error {
+	return st.resizeVolume(tag, size)
+}
+
+func (st *mockState) RequestVolumeSnapshot(tag names.VolumeTag) error {
+	return st.requestVolumeSnapshot(tag)
+}
+
+func (st *mockState) RemoveStoragePool(name string) error {
+	return st.removeStoragePool(name)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -178,9 +238,11 @@ func (m *mockNotifyWatcher) Changes() <-chan struct{} {
 
 type mockVolume struct {
 	state.Volume
-	tag     names.VolumeTag
-	storage *names.StorageTag
-	info    *state.VolumeInfo
+	tag       names.VolumeTag
+	storage   *names.StorageTag
+	info      *state.VolumeInfo
+	usage     *state.StorageUsage
+	snapshots []state.VolumeSnapshot
 }
 
 func (m *mockVolume) StorageInstance() (names.StorageTag, error) {
@@ -212,12 +274,24 @@ func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Attached}, nil
 }
 
+func (m *mockVolume) Snapshots() []state.VolumeSnapshot {
+	return m.snapshots
+}
+
+func (m *mockVolume) Usage() (state.StorageUsage, bool) {
+	if m.usage != nil {
+		return *m.usage, true
+	}
+	return state.StorageUsage{}, false
+}
+
 type mockFilesystem struct {
 	state.Filesystem
 	tag     names.FilesystemTag
 	storage *names.StorageTag
 	volume  *names.VolumeTag
 	info    *state.FilesystemInfo
+	usage   *state.StorageUsage
 }
 
 func (m *mockFilesystem) Storage() (names.StorageTag, error) {
@@ -238,6 +312,13 @@ func (m *mockFilesystem) Volume() (names.VolumeTag, error) {
 	return names.VolumeTag{}, state.ErrNoBackingVolume
 }
 
+func (m *mockFilesystem) Params() (state.FilesystemParams, bool) {
+	return state.FilesystemParams{
+		Pool: "rootfs",
+		Size: 1024,
+	}, true
+}
+
 func (m *mockFilesystem) Info() (state.FilesystemInfo, error) {
 	if m.info != nil {
 		return *m.info, nil
@@ -249,6 +330,13 @@ func (m *mockFilesystem) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Attached}, nil
 }
 
+func (m *mockFilesystem) Usage() (state.StorageUsage, bool) {
+	if m.usage != nil {
+		return *m.usage, true
+	}
+	return state.StorageUsage{}, false
+}
+
 type mockFilesystemAttachment struct {
 	state.FilesystemAttachment
 	filesystem names.FilesystemTag
@@ -282,8 +370,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -308,7 +396,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
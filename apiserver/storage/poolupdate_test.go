@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	pool, err := jujustorage.NewConfig("pname", provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["pname"] = pool
+}
+
+func (s *poolUpdateSuite) TestUpdatePools(c *gc.C) {
+	results, err := s.api.UpdatePools(params.StoragePools{[]params.StoragePool{{
+		Name:  "pname",
+		Attrs: map[string]interface{}{"baz": "qux"},
+	}, {
+		Name:     "pname",
+		Provider: string(provider.LoopProviderType),
+		Attrs:    map[string]interface{}{"foo": "baz"},
+	}, {
+		Name: "missing",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "mock pool manager: replace pool missing not found")
+
+	pool := s.pools["pname"]
+	c.Assert(pool.Provider(), gc.Equals, provider.LoopProviderType)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "baz"})
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolsChangeProvider(c *gc.C) {
+	results, err := s.api.UpdatePools(params.StoragePools{[]params.StoragePool{{
+		Name:     "pname",
+		Provider: "tmpfs",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `changing provider of pool "pname" from "loop" to "tmpfs" not supported`)
+	c.Assert(s.pools["pname"].Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolsError(c *gc.C) {
+	s.poolManager.replacePool = func(string, map[string]interface{}) (*jujustorage.Config, error) {
+		return nil, errors.New("validating storage provider config: no good")
+	}
+	results, err := s.api.UpdatePools(params.StoragePools{[]params.StoragePool{{Name: "pname"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "validating storage provider config: no good")
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestUpdatePoolsBlocked")
+	_, err := s.api.UpdatePools(params.StoragePools{[]params.StoragePool{{Name: "pname"}}})
+	s.assertBlocked(c, err, "TestUpdatePoolsBlocked")
+}
+
+func (s *poolUpdateSuite) TestRemovePools(c *gc.C) {
+	results, err := s.api.RemovePools(params.StoragePoolNames{[]string{"pname", "missing"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `pool "missing" not found`)
+	c.Assert(s.pools, gc.HasLen, 0)
+	s.assertCalls(c, []string{getBlockForTypeCall, getBlockForTypeCall, removeStoragePoolCall, removeStoragePoolCall})
+}
+
+func (s *poolUpdateSuite) TestRemovePoolsInUse(c *gc.C) {
+	s.state.removeStoragePool = func(name string) error {
+		return errors.Errorf("storage pool %q in use", name)
+	}
+	results, err := s.api.RemovePools(params.StoragePoolNames{[]string{"pname"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `storage pool "pname" in use`)
+	c.Assert(s.pools, gc.HasLen, 1)
+}
+
+func (s *poolUpdateSuite) TestRemovePoolsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemovePoolsBlocked")
+	_, err := s.api.RemovePools(params.StoragePoolNames{[]string{"pname"}})
+	s.assertBlocked(c, err, "TestRemovePoolsBlocked")
+	c.Assert(s.pools, gc.HasLen, 1)
+}
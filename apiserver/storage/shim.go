@@ -22,6 +22,11 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Storage", 3, newAPI)
+
+	// Facade version 4 adds Attach, Detach, Remove, Import,
+	// CreateSnapshots, ListSnapshots, RestoreSnapshots, Resize,
+	// UpdatePools and RemovePools.
+	common.RegisterStandardFacade("Storage", 4, newAPI)
 }
 
 func newAPI(
@@ -66,6 +71,12 @@ type storageAccess interface {
 	// WatchStorageAttachment is required for storage functionality.
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) state.NotifyWatcher
 
+	// WatchVolume is required for storage functionality.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchFilesystemAttachment is required for storage functionality.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
@@ -111,6 +122,33 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
+	// DestroyStorageInstance is required for storage remove functionality.
+	DestroyStorageInstance(names.StorageTag) error
+
+	// ReleaseStorageInstance is required for storage remove functionality.
+	ReleaseStorageInstance(names.StorageTag) error
+
+	// AddExistingFilesystem is required for storage import functionality.
+	AddExistingFilesystem(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+
+	// RestoreVolumeInfo is required for storage snapshot functionality.
+	RestoreVolumeInfo(names.VolumeTag, state.VolumeInfo) error
+
+	// ResizeVolume is required for storage resize functionality.
+	ResizeVolume(names.VolumeTag, uint64) error
+
+	// RequestVolumeSnapshot is required for storage snapshot functionality.
+	RequestVolumeSnapshot(names.VolumeTag) error
+
+	// RemoveStoragePool is required for pool removal functionality.
+	RemoveStoragePool(string) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
@@ -148,6 +148,7 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 	// Get information from underlying volume or filesystem.
 	var persistent bool
 	var statusEntity status.StatusGetter
+	var usage *params.StorageUsage
 	if si.Kind() != state.StorageKindBlock {
 		// TODO(axw) when we support persistent filesystems,
 		// e.g. CephFS, we'll need to do set "persistent"
@@ -157,6 +158,7 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 			return nil, errors.Trace(err)
 		}
 		statusEntity = filesystem
+		usage = storageUsageFromState(filesystem.Usage())
 	} else {
 		volume, err := st.StorageInstanceVolume(si.StorageTag())
 		if err != nil {
@@ -166,6 +168,7 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 			persistent = info.Persistent
 		}
 		statusEntity = volume
+		usage = storageUsageFromState(volume.Usage())
 	}
 	status, err := statusEntity.Status()
 	if err != nil {
@@ -195,16 +198,35 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	// Storage that has been detached from its unit has no owner.
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
+
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
+		Usage:       usage,
 		Attachments: storageAttachmentDetails,
 	}, nil
 }
 
+// storageUsageFromState converts the usage of a volume or filesystem
+// into its params form, returning nil if no usage has been reported.
+func storageUsageFromState(usage state.StorageUsage, ok bool) *params.StorageUsage {
+	if !ok {
+		return nil
+	}
+	return &params.StorageUsage{
+		Capacity: usage.Capacity,
+		Used:     usage.Used,
+	}
+}
+
 func storageAttachmentInfo(st storageAccess, a state.StorageAttachment) (_ names.MachineTag, location string, _ error) {
 	machineTag, err := st.UnitAssignedMachine(a.Unit())
 	if errors.IsNotAssigned(err) {
@@ -364,6 +386,66 @@ func (a *API) CreatePool(p params.StoragePool) error {
 	return err
 }
 
+// UpdatePools replaces the configuration attributes of existing storage
+// pools. The attributes are validated by the pool's storage provider.
+func (a *API) UpdatePools(args params.StoragePools) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, p := range args.Pools {
+		if p.Provider != "" {
+			// The provider type of a pool cannot be changed.
+			pool, err := a.poolManager.Get(p.Name)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+			if pool.Provider() != storage.ProviderType(p.Provider) {
+				err := errors.NotSupportedf(
+					"changing provider of pool %q from %q to %q",
+					p.Name, pool.Provider(), p.Provider,
+				)
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
+		_, err := a.poolManager.Replace(p.Name, p.Attrs)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemovePools removes the storage pools with the specified names. A pool
+// may not be removed while any volume or filesystem is in the pool, or
+// while any application's storage constraints name the pool.
+func (a *API) RemovePools(args params.StoragePoolNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		// The pool is removed by state rather than by the pool
+		// manager, so that it cannot come into use while it is
+		// being removed.
+		err := a.storage.RemoveStoragePool(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
@@ -477,6 +559,7 @@ func createVolumeDetails(
 	if info, err := v.Info(); err == nil {
 		details.Info = storagecommon.VolumeInfoFromState(info)
 	}
+	details.Usage = storageUsageFromState(v.Usage())
 
 	if len(attachments) > 0 {
 		details.MachineAttachments = make(map[string]params.VolumeAttachmentInfo, len(attachments))
@@ -629,6 +712,7 @@ func createFilesystemDetails(
 	if info, err := f.Info(); err == nil {
 		details.Info = storagecommon.FilesystemInfoFromState(info)
 	}
+	details.Usage = storageUsageFromState(f.Usage())
 
 	if len(attachments) > 0 {
 		details.MachineAttachments = make(map[string]params.FilesystemAttachmentInfo, len(attachments))
@@ -709,3 +793,536 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// Attach attaches existing storage instances, which have been detached
+// from their previous units, to units.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	return a.modifyStorageAttachments(args, func(storageTag names.StorageTag, unitTag names.UnitTag) error {
+		if unitTag == (names.UnitTag{}) {
+			return errors.NotValidf("empty unit tag")
+		}
+		return a.storage.AttachStorage(storageTag, unitTag)
+	})
+}
+
+// Detach detaches storage instances from their units, leaving the storage
+// instances in the model so that they may be attached to other units. If
+// no unit is specified, the storage instance is detached from all of the
+// units it is attached to.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	return a.modifyStorageAttachments(args, a.detachStorage)
+}
+
+func (a *API) detachStorage(storageTag names.StorageTag, unitTag names.UnitTag) error {
+	if unitTag != (names.UnitTag{}) {
+		return a.storage.DetachStorage(storageTag, unitTag)
+	}
+	attachments, err := a.storage.StorageAttachments(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, attachment := range attachments {
+		if err := a.storage.DetachStorage(storageTag, attachment.Unit()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (a *API) modifyStorageAttachments(
+	args params.StorageAttachmentIds,
+	modify func(names.StorageTag, names.UnitTag) error,
+) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	one := func(id params.StorageAttachmentId) error {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			return err
+		}
+		var unitTag names.UnitTag
+		if id.UnitTag != "" {
+			unitTag, err = names.ParseUnitTag(id.UnitTag)
+			if err != nil {
+				return err
+			}
+		}
+		return modify(storageTag, unitTag)
+	}
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if err := one(id); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Remove removes storage instances from the model. The volume or
+// filesystem of each storage instance is either destroyed, or released
+// from the model leaving the underlying storage intact.
+// A "REMOVE" block can block this operation.
+func (a *API) Remove(args params.RemoveStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	one := func(arg params.RemoveStorageInstance) error {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return err
+		}
+		if arg.Release {
+			return a.storage.ReleaseStorageInstance(tag)
+		}
+		return a.storage.DestroyStorageInstance(tag)
+	}
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		if err := one(arg); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Import imports existing storage into the model.
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		details, err := a.importStorage(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *API) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
+	if arg.Kind != params.StorageKindFilesystem {
+		// Only filesystems may be imported for now.
+		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
+	}
+	providerType, cfg, err := storagecommon.StoragePoolConfig(arg.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Supports(storage.StorageKindFilesystem) || !provider.Supports(storage.StorageKindBlock) {
+		// Only filesystems managed by Juju on a volume may be
+		// imported, as storage providers cannot describe
+		// native filesystems.
+		return nil, errors.NotSupportedf("importing filesystem with storage provider %q", providerType)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := volumeSource.DescribeVolumes([]string{arg.ProviderId})
+	if err != nil {
+		return nil, errors.Annotatef(err, "describing volume %q", arg.ProviderId)
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotatef(results[0].Error, "describing volume %q", arg.ProviderId)
+	}
+	volumeInfo := results[0].VolumeInfo
+	storageTag, err := a.storage.AddExistingFilesystem(
+		state.FilesystemInfo{
+			Size: volumeInfo.Size,
+			Pool: arg.Pool,
+		},
+		&state.VolumeInfo{
+			HardwareId: volumeInfo.HardwareId,
+			Size:       volumeInfo.Size,
+			Pool:       arg.Pool,
+			VolumeId:   volumeInfo.VolumeId,
+			Persistent: volumeInfo.Persistent,
+		},
+		arg.StorageName,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// CreateSnapshots takes a snapshot of the volume underlying each of the
+// specified storage instances. Snapshots of volumes in machine-scoped
+// pools are taken asynchronously by the storage provisioner on the
+// volume's machine; the results for those are marked as pending.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateSnapshots(args params.Entities) (params.CreateStorageSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.CreateStorageSnapshotResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.CreateStorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	one := func(arg params.Entity) (*params.StorageSnapshot, error) {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return nil, err
+		}
+		vs, err := a.storageVolumeSnapshotter(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if vs.snapshotter == nil {
+			// The snapshot will be taken by the storage
+			// provisioner on the volume's machine.
+			err := a.storage.RequestVolumeSnapshot(vs.volume.VolumeTag())
+			return nil, errors.Trace(err)
+		}
+		results, err := vs.snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+			Tag:      vs.volume.VolumeTag(),
+			VolumeId: vs.info.VolumeId,
+		}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(results) != 1 {
+			return nil, errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		if results[0].Error != nil {
+			return nil, errors.Trace(results[0].Error)
+		}
+		snapshot := storageSnapshot(tag, *results[0].Snapshot)
+		return &snapshot, nil
+	}
+	results := make([]params.CreateStorageSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshot, err := one(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		// A snapshot of a machine-scoped volume has no
+		// result until the storage provisioner has taken it.
+		results[i].Result = snapshot
+		results[i].Pending = snapshot == nil
+	}
+	return params.CreateStorageSnapshotResults{Results: results}, nil
+}
+
+// ListSnapshots returns the snapshots of the volume underlying each of
+// the specified storage instances. The snapshots of volumes in
+// machine-scoped pools are those last recorded by the storage
+// provisioner on the volume's machine.
+func (a *API) ListSnapshots(args params.Entities) (params.StorageSnapshotsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotsResults{}, errors.Trace(err)
+	}
+
+	one := func(arg params.Entity) ([]params.StorageSnapshot, error) {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return nil, err
+		}
+		vs, err := a.storageVolumeSnapshotter(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if vs.snapshotter == nil {
+			recorded := vs.volume.Snapshots()
+			snapshots := make([]params.StorageSnapshot, len(recorded))
+			for i, snapshot := range recorded {
+				snapshots[i] = storageSnapshot(tag, storage.VolumeSnapshot{
+					SnapshotId: snapshot.SnapshotId,
+					VolumeId:   snapshot.VolumeId,
+					Size:       snapshot.Size,
+					Created:    snapshot.Created,
+				})
+			}
+			return snapshots, nil
+		}
+		volumeSnapshots, err := vs.snapshotter.ListVolumeSnapshots(storage.VolumeSnapshotParams{
+			Tag:      vs.volume.VolumeTag(),
+			VolumeId: vs.info.VolumeId,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots := make([]params.StorageSnapshot, len(volumeSnapshots))
+		for i, snapshot := range volumeSnapshots {
+			snapshots[i] = storageSnapshot(tag, snapshot)
+		}
+		return snapshots, nil
+	}
+	results := make([]params.StorageSnapshotsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshots, err := one(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshots
+	}
+	return params.StorageSnapshotsResults{Results: results}, nil
+}
+
+// RestoreSnapshots restores the volume underlying each of the specified
+// storage instances from a snapshot. The volumes must not be attached
+// to any machine, so storage instances must be detached from their
+// units before they are restored. Storage in machine-scoped pools
+// cannot be detached from its machine, and so cannot be restored.
+// A "CHANGE" block can block this operation.
+func (a *API) RestoreSnapshots(args params.RestoreStorageSnapshots) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Snapshots))
+	for i, arg := range args.Snapshots {
+		if err := a.restoreSnapshot(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) restoreSnapshot(arg params.RestoreStorageSnapshot) error {
+	tag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return err
+	}
+	vs, err := a.storageVolumeSnapshotter(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if vs.snapshotter == nil {
+		return errors.NotSupportedf("restoring snapshots of storage in machine-scoped pool %q", vs.info.Pool)
+	}
+	restorer, ok := vs.snapshotter.(storage.VolumeSnapshotRestorer)
+	if !ok {
+		return errors.NotSupportedf("restoring snapshots with storage provider %q", vs.providerType)
+	}
+	volume := vs.volume
+	attachments, err := a.storage.VolumeAttachments(volume.VolumeTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(attachments) > 0 {
+		return errors.Errorf(
+			"%s is attached to %s",
+			names.ReadableString(tag),
+			names.ReadableString(attachments[0].Machine()),
+		)
+	}
+	results, err := restorer.RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+		Tag:        volume.VolumeTag(),
+		VolumeId:   vs.info.VolumeId,
+		SnapshotId: arg.SnapshotId,
+	}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return errors.Trace(results[0].Error)
+	}
+	restoredInfo := results[0].VolumeInfo
+	return a.storage.RestoreVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		HardwareId: restoredInfo.HardwareId,
+		Size:       restoredInfo.Size,
+		VolumeId:   restoredInfo.VolumeId,
+		Persistent: restoredInfo.Persistent,
+	})
+}
+
+// Resize requests that storage instances be grown to new sizes. Each
+// storage instance must be backed by a provisioned volume, and its new
+// size must be larger than its current size. The volumes are resized
+// asynchronously by the storage provisioner responsible for them.
+func (a *API) Resize(args params.StorageResizes) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Resizes))
+	for i, arg := range args.Resizes {
+		if err := a.resize(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) resize(arg params.StorageResize) error {
+	tag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return err
+	}
+	si, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := storageInstanceVolume(a.storage, si, "resizing")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Dynamic() {
+		return errors.NotSupportedf("resizing storage with non-dynamic storage provider %q", providerType)
+	}
+	// Volume sources for machine-scoped pools can only be created
+	// on the machine, so we leave it to the storage provisioner to
+	// check that their volumes can be resized.
+	if provider.Scope() != storage.ScopeMachine {
+		volumeSource, err := provider.VolumeSource(cfg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := volumeSource.(storage.VolumeResizer); !ok {
+			return errors.NotSupportedf("resizing storage with storage provider %q", providerType)
+		}
+	}
+	return a.storage.ResizeVolume(volume.VolumeTag(), arg.Size)
+}
+
+// volumeSnapshotter holds the volume underlying a storage instance,
+// and the means of taking snapshots of it.
+type volumeSnapshotter struct {
+	volume       state.Volume
+	info         state.VolumeInfo
+	providerType storage.ProviderType
+
+	// snapshotter is the VolumeSnapshotter for the volume's storage
+	// pool. It is nil if the pool is machine-scoped, in which case
+	// snapshots are taken by the storage provisioner on the volume's
+	// machine.
+	snapshotter storage.VolumeSnapshotter
+}
+
+// storageVolumeSnapshotter returns the volume underlying the storage
+// instance with the specified tag, along with the volume's information
+// and a VolumeSnapshotter for the volume's storage pool.
+func (a *API) storageVolumeSnapshotter(tag names.StorageTag) (*volumeSnapshotter, error) {
+	si, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, err := storageInstanceVolume(a.storage, si, "snapshots")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	vs := &volumeSnapshotter{
+		volume:       volume,
+		info:         info,
+		providerType: providerType,
+	}
+	// Volume sources for machine-scoped pools can only be created
+	// on the machine, so we leave it to the storage provisioner to
+	// take snapshots of their volumes.
+	if provider.Scope() == storage.ScopeMachine {
+		return vs, nil
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots with storage provider %q", providerType)
+	}
+	vs.snapshotter = snapshotter
+	return vs, nil
+}
+
+// storageInstanceVolume returns the volume underlying the specified
+// storage instance. For filesystem storage, this is the volume backing
+// the filesystem. The operation requiring the volume is described in
+// any NotSupported error returned.
+func storageInstanceVolume(st storageAccess, si state.StorageInstance, operation string) (state.Volume, error) {
+	switch si.Kind() {
+	case state.StorageKindBlock:
+		return st.StorageInstanceVolume(si.StorageTag())
+	case state.StorageKindFilesystem:
+		filesystem, err := st.StorageInstanceFilesystem(si.StorageTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeTag, err := filesystem.Volume()
+		if err == state.ErrNoBackingVolume {
+			return nil, errors.NotSupportedf("%s of filesystem without a backing volume", operation)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.Volume(volumeTag)
+	}
+	return nil, errors.NotSupportedf("%s of %s storage", operation, si.Kind())
+}
+
+func storageSnapshot(tag names.StorageTag, snapshot storage.VolumeSnapshot) params.StorageSnapshot {
+	return params.StorageSnapshot{
+		SnapshotId: snapshot.SnapshotId,
+		StorageTag: tag.String(),
+		Size:       snapshot.Size,
+		Created:    snapshot.Created,
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	var attached []string
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		attached = append(attached, storage.Id()+":"+unit.Id())
+		if unit.Id() == "mysql/1" {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
+		{StorageTag: "storage-data-1", UnitTag: "unit-mysql-1"},
+		{StorageTag: "volume-0", UnitTag: "unit-mysql-0"},
+		{StorageTag: "storage-data-2", UnitTag: "machine-0"},
+		{StorageTag: "storage-data-2"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: `"machine-0" is not a valid unit tag`}},
+		{Error: &params.Error{Message: "empty unit tag not valid"}},
+	}})
+	c.Assert(attached, jc.DeepEquals, []string{"data/0:mysql/0", "data/1:mysql/1"})
+	s.assertCalls(c, []string{getBlockForTypeCall, attachStorageCall, attachStorageCall})
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
+	}})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
+		{StorageTag: "storage-data-1", UnitTag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}, {}}})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0:mysql/0", "data/1:mysql/0"})
+	s.assertCalls(c, []string{getBlockForTypeCall, detachStorageCall, detachStorageCall})
+}
+
+func (s *storageAttachSuite) TestDetachAllUnits(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
+		{StorageTag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0:mysql/0"})
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceAttachmentsCall, detachStorageCall})
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
+	}})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
+
+func (s *storageAttachSuite) TestRemove(c *gc.C) {
+	results, err := s.api.Remove(params.RemoveStorage{[]params.RemoveStorageInstance{
+		{Tag: "storage-data-0"},
+		{Tag: "storage-data-1", Release: true},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{
+		{},
+		{},
+		{Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`}},
+	}})
+	s.assertCalls(c, []string{getBlockForTypeCall, destroyStorageInstanceCall, releaseStorageInstanceCall})
+}
+
+func (s *storageAttachSuite) TestRemoveBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemoveBlocked")
+	_, err := s.api.Remove(params.RemoveStorage{[]params.RemoveStorageInstance{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "TestRemoveBlocked")
+}
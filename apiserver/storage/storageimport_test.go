@@ -0,0 +1,135 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageImportSuite struct {
+	baseStorageSuite
+	volumeSource *dummystorage.VolumeSource
+}
+
+var _ = gc.Suite(&storageImportSuite{})
+
+func (s *storageImportSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummystorage.VolumeSource{
+		DescribeVolumesFunc: func(volIds []string) ([]jujustorage.DescribeVolumesResult, error) {
+			results := make([]jujustorage.DescribeVolumesResult, len(volIds))
+			for i, id := range volIds {
+				results[i].VolumeInfo = &jujustorage.VolumeInfo{
+					VolumeId:   id,
+					HardwareId: "hw-" + id,
+					Size:       1024,
+					Persistent: true,
+				}
+			}
+			return results, nil
+		},
+	}
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind jujustorage.StorageKind) bool {
+			return kind == jujustorage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["native"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+	}
+	pool, err := jujustorage.NewConfig("radiance-pool", "radiance", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["radiance-pool"] = pool
+}
+
+func (s *storageImportSuite) TestImportFilesystem(c *gc.C) {
+	var fsInfo state.FilesystemInfo
+	var volInfo state.VolumeInfo
+	s.state.addExistingFilesystem = func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingFilesystemCall)
+		fsInfo = f
+		volInfo = *v
+		c.Assert(storageName, gc.Equals, "pgdata")
+		return names.NewStorageTag("pgdata/0"), nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "radiance-pool",
+		ProviderId:  "vol-123",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{[]params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-0"},
+	}}})
+	c.Assert(fsInfo, jc.DeepEquals, state.FilesystemInfo{Pool: "radiance-pool", Size: 1024})
+	c.Assert(volInfo, jc.DeepEquals, state.VolumeInfo{
+		HardwareId: "hw-vol-123",
+		Size:       1024,
+		Pool:       "radiance-pool",
+		VolumeId:   "vol-123",
+		Persistent: true,
+	})
+	s.volumeSource.CheckCall(c, 0, "DescribeVolumes", []string{"vol-123"})
+	s.assertCalls(c, []string{getBlockForTypeCall, addExistingFilesystemCall})
+}
+
+func (s *storageImportSuite) TestImportFilesystemErrors(c *gc.C) {
+	s.volumeSource.DescribeVolumesFunc = func(volIds []string) ([]jujustorage.DescribeVolumesResult, error) {
+		return []jujustorage.DescribeVolumesResult{{Error: errors.NotFoundf("volume %q", volIds[0])}}, nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance-pool",
+		ProviderId:  "vol-123",
+		StorageName: "pgdata",
+	}, {
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "native",
+		ProviderId:  "fs-123",
+		StorageName: "pgdata",
+	}, {
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "missing",
+		ProviderId:  "vol-123",
+		StorageName: "pgdata",
+	}, {
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "radiance-pool",
+		ProviderId:  "vol-123",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `storage kind "block" not supported`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `importing filesystem with storage provider "native" not supported`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `mock pool manager: get pool missing not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `describing volume "vol-123": volume "vol-123" not found`)
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
+
+func (s *storageImportSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "radiance-pool",
+		ProviderId:  "vol-123",
+		StorageName: "pgdata",
+	}}})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
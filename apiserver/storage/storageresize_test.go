@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &resizingVolumeSource{}, nil
+		},
+	}
+	s.registry.Providers["dusk"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &dummystorage.VolumeSource{}, nil
+		},
+	}
+	s.registry.Providers["machine"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+		IsDynamic:    true,
+	}
+	s.registry.Providers["static"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+	}
+	for _, name := range []string{"radiance", "dusk", "machine", "static"} {
+		pool, err := jujustorage.NewConfig(name+"-pool", jujustorage.ProviderType(name), map[string]interface{}{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.pools[name+"-pool"] = pool
+	}
+
+	// The storage instance is a filesystem backed by a volume
+	// in the "radiance" pool.
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance-pool", VolumeId: "vol-123", Size: 1024}
+}
+
+func (s *storageResizeSuite) TestResize(c *gc.C) {
+	var resizedTag names.VolumeTag
+	var resizedSize uint64
+	s.state.resizeVolume = func(tag names.VolumeTag, size uint64) error {
+		s.calls = append(s.calls, resizeVolumeCall)
+		resizedTag, resizedSize = tag, size
+		return nil
+	}
+	results, err := s.api.Resize(params.StorageResizes{[]params.StorageResize{
+		{StorageTag: s.storageTag.String(), Size: 2048},
+		{StorageTag: "storage-missing-0", Size: 2048},
+		{StorageTag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage missing/0 not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+	c.Assert(resizedTag, gc.Equals, s.volumeTag)
+	c.Assert(resizedSize, gc.Equals, uint64(2048))
+}
+
+func (s *storageResizeSuite) TestResizeMachineScoped(c *gc.C) {
+	// Machine-scoped volumes are checked by the storage provisioner.
+	s.volume.info.Pool = "machine-pool"
+	results, err := s.api.Resize(params.StorageResizes{[]params.StorageResize{
+		{StorageTag: s.storageTag.String(), Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		volumeCall,
+		resizeVolumeCall,
+	})
+}
+
+func (s *storageResizeSuite) TestResizeNotSupported(c *gc.C) {
+	for i, t := range []struct {
+		pool   string
+		expect string
+	}{{
+		pool:   "dusk-pool",
+		expect: `resizing storage with storage provider "dusk" not supported`,
+	}, {
+		pool:   "static-pool",
+		expect: `resizing storage with non-dynamic storage provider "static" not supported`,
+	}} {
+		c.Logf("test %d: %s", i, t.pool)
+		s.volume.info.Pool = t.pool
+		results, err := s.api.Resize(params.StorageResizes{[]params.StorageResize{
+			{StorageTag: s.storageTag.String(), Size: 2048},
+		}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Check(results.Results[0].Error, gc.ErrorMatches, t.expect)
+	}
+
+	s.filesystem.volume = nil
+	results, err := s.api.Resize(params.StorageResizes{[]params.StorageResize{
+		{StorageTag: s.storageTag.String(), Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `resizing of filesystem without a backing volume not supported`)
+}
+
+func (s *storageResizeSuite) TestResizeError(c *gc.C) {
+	s.state.resizeVolume = func(tag names.VolumeTag, size uint64) error {
+		return errors.New("new size 512MiB must be larger than current size 1024MiB")
+	}
+	results, err := s.api.Resize(params.StorageResizes{[]params.StorageResize{
+		{StorageTag: s.storageTag.String(), Size: 512},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `new size 512MiB must be larger than current size 1024MiB`)
+}
+
+func (s *storageResizeSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.api.Resize(params.StorageResizes{[]params.StorageResize{
+		{StorageTag: s.storageTag.String(), Size: 2048},
+	}})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
+
+// resizingVolumeSource is a volume source that also implements
+// storage.VolumeResizer.
+type resizingVolumeSource struct {
+	dummystorage.VolumeSource
+}
+
+func (s *resizingVolumeSource) ResizeVolumes(args []jujustorage.ResizeVolumeParams) ([]jujustorage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", args)
+	return make([]jujustorage.ResizeVolumesResult, len(args)), s.NextErr()
+}
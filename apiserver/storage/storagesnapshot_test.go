@@ -0,0 +1,353 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+	volumeSource *snapshottingVolumeSource
+	created      time.Time
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.created = time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC)
+	s.volumeSource = &snapshottingVolumeSource{}
+	s.volumeSource.createVolumeSnapshots = func(args []jujustorage.VolumeSnapshotParams) ([]jujustorage.CreateVolumeSnapshotsResult, error) {
+		results := make([]jujustorage.CreateVolumeSnapshotsResult, len(args))
+		for i, arg := range args {
+			results[i].Snapshot = &jujustorage.VolumeSnapshot{
+				SnapshotId: "snap-" + arg.VolumeId,
+				VolumeId:   arg.VolumeId,
+				Size:       1024,
+				Created:    s.created,
+			}
+		}
+		return results, nil
+	}
+	s.volumeSource.listVolumeSnapshots = func(arg jujustorage.VolumeSnapshotParams) ([]jujustorage.VolumeSnapshot, error) {
+		// The first snapshot was taken of the volume before it
+		// was last restored, when it had a different volume ID.
+		return []jujustorage.VolumeSnapshot{{
+			SnapshotId: "snap-1",
+			VolumeId:   "vol-122",
+			Size:       1024,
+			Created:    s.created,
+		}, {
+			SnapshotId: "snap-2",
+			VolumeId:   arg.VolumeId,
+			Size:       2048,
+			Created:    s.created.Add(time.Hour),
+		}}, nil
+	}
+	s.volumeSource.restoreVolumeSnapshots = func(args []jujustorage.RestoreVolumeSnapshotParams) ([]jujustorage.RestoreVolumeSnapshotsResult, error) {
+		results := make([]jujustorage.RestoreVolumeSnapshotsResult, len(args))
+		for i, arg := range args {
+			results[i].VolumeInfo = &jujustorage.VolumeInfo{
+				VolumeId:   "from-" + arg.SnapshotId,
+				Size:       1024,
+				Persistent: true,
+			}
+		}
+		return results, nil
+	}
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["dusk"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &dummystorage.VolumeSource{}, nil
+		},
+	}
+	s.registry.Providers["dawn"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &nonRestoringVolumeSource{s.volumeSource, s.volumeSource}, nil
+		},
+	}
+	s.registry.Providers["machine"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+		IsDynamic:    true,
+	}
+	for _, name := range []string{"radiance", "dusk", "dawn", "machine"} {
+		pool, err := jujustorage.NewConfig(name+"-pool", jujustorage.ProviderType(name), map[string]interface{}{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.pools[name+"-pool"] = pool
+	}
+
+	// The storage instance is a filesystem backed by a volume
+	// in the "radiance" pool.
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance-pool", VolumeId: "vol-123", Size: 1024}
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshots(c *gc.C) {
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{s.storageTag.String()},
+		{"storage-missing-0"},
+		{"volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.CreateStorageSnapshotResult{
+		Result: &params.StorageSnapshot{
+			SnapshotId: "snap-vol-123",
+			StorageTag: "storage-data-0",
+			Size:       1024,
+			Created:    s.created,
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage missing/0 not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+	s.volumeSource.CheckCall(c, 0, "CreateVolumeSnapshots", []jujustorage.VolumeSnapshotParams{{
+		Tag:      s.volumeTag,
+		VolumeId: "vol-123",
+	}})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.volume.info.Pool = "dusk-pool"
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `snapshots with storage provider "dusk" not supported`)
+
+	s.filesystem.volume = nil
+	results, err = s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `snapshots of filesystem without a backing volume not supported`)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsMachineScoped(c *gc.C) {
+	s.volume.info.Pool = "machine-pool"
+	var requested []names.VolumeTag
+	s.state.requestVolumeSnapshot = func(tag names.VolumeTag) error {
+		s.calls = append(s.calls, requestVolumeSnapshotCall)
+		requested = append(requested, tag)
+		return nil
+	}
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CreateStorageSnapshotResult{{Pending: true}})
+	c.Assert(requested, jc.DeepEquals, []names.VolumeTag{s.volumeTag})
+	s.volumeSource.CheckNoCalls(c)
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		volumeCall,
+		requestVolumeSnapshotCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsMachineScopedError(c *gc.C) {
+	s.volume.info.Pool = "machine-pool"
+	s.state.requestVolumeSnapshot = func(tag names.VolumeTag) error {
+		return errors.New("volume is not alive")
+	}
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "volume is not alive")
+	c.Assert(results.Results[0].Pending, jc.IsFalse)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.storageTag.String()}}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestListSnapshots(c *gc.C) {
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{
+		{s.storageTag.String()},
+		{"storage-missing-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StorageSnapshotsResult{
+		Result: []params.StorageSnapshot{{
+			SnapshotId: "snap-1",
+			StorageTag: "storage-data-0",
+			Size:       1024,
+			Created:    s.created,
+		}, {
+			SnapshotId: "snap-2",
+			StorageTag: "storage-data-0",
+			Size:       2048,
+			Created:    s.created.Add(time.Hour),
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage missing/0 not found`)
+	s.volumeSource.CheckCall(c, 0, "ListVolumeSnapshots", jujustorage.VolumeSnapshotParams{
+		Tag:      s.volumeTag,
+		VolumeId: "vol-123",
+	})
+}
+
+func (s *storageSnapshotSuite) TestListSnapshotsMachineScoped(c *gc.C) {
+	s.volume.info.Pool = "machine-pool"
+	s.volume.snapshots = []state.VolumeSnapshot{{
+		SnapshotId: "volume-0@20161016T120000.000000000Z",
+		VolumeId:   "volume-0",
+		Size:       1024,
+		Created:    s.created,
+	}}
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{{s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{
+			SnapshotId: "volume-0@20161016T120000.000000000Z",
+			StorageTag: "storage-data-0",
+			Size:       1024,
+			Created:    s.created,
+		}},
+	}})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestRestoreSnapshots(c *gc.C) {
+	s.state.volumeAttachments = func(names.VolumeTag) ([]state.VolumeAttachment, error) {
+		s.calls = append(s.calls, volumeAttachmentsCall)
+		return nil, nil
+	}
+	var restoredInfo state.VolumeInfo
+	s.state.restoreVolumeInfo = func(tag names.VolumeTag, info state.VolumeInfo) error {
+		s.calls = append(s.calls, restoreVolumeInfoCall)
+		c.Assert(tag, gc.Equals, s.volumeTag)
+		restoredInfo = info
+		return nil
+	}
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshots{[]params.RestoreStorageSnapshot{{
+		StorageTag: s.storageTag.String(),
+		SnapshotId: "snap-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	c.Assert(restoredInfo, jc.DeepEquals, state.VolumeInfo{
+		VolumeId:   "from-snap-1",
+		Size:       1024,
+		Persistent: true,
+	})
+	s.volumeSource.CheckCall(c, 0, "RestoreVolumeSnapshots", []jujustorage.RestoreVolumeSnapshotParams{{
+		Tag:        s.volumeTag,
+		VolumeId:   "vol-123",
+		SnapshotId: "snap-1",
+	}})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		volumeCall,
+		volumeAttachmentsCall,
+		restoreVolumeInfoCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestRestoreSnapshotsAttached(c *gc.C) {
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshots{[]params.RestoreStorageSnapshot{{
+		StorageTag: s.storageTag.String(),
+		SnapshotId: "snap-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `storage data/0 is attached to machine 66`)
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestRestoreSnapshotsError(c *gc.C) {
+	s.state.volumeAttachments = func(names.VolumeTag) ([]state.VolumeAttachment, error) {
+		return nil, nil
+	}
+	s.volumeSource.restoreVolumeSnapshots = func(args []jujustorage.RestoreVolumeSnapshotParams) ([]jujustorage.RestoreVolumeSnapshotsResult, error) {
+		return []jujustorage.RestoreVolumeSnapshotsResult{{Error: errors.NotFoundf("snapshot %q", args[0].SnapshotId)}}, nil
+	}
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshots{[]params.RestoreStorageSnapshot{{
+		StorageTag: s.storageTag.String(),
+		SnapshotId: "snap-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `snapshot "snap-1" not found`)
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall, volumeCall})
+}
+
+func (s *storageSnapshotSuite) TestRestoreSnapshotsNotSupported(c *gc.C) {
+	for i, t := range []struct {
+		pool   string
+		expect string
+	}{{
+		pool:   "dawn-pool",
+		expect: `restoring snapshots with storage provider "dawn" not supported`,
+	}, {
+		pool:   "machine-pool",
+		expect: `restoring snapshots of storage in machine-scoped pool "machine-pool" not supported`,
+	}} {
+		c.Logf("test %d: %s", i, t.pool)
+		s.volume.info.Pool = t.pool
+		results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshots{[]params.RestoreStorageSnapshot{{
+			StorageTag: s.storageTag.String(),
+			SnapshotId: "snap-1",
+		}}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Check(results.Results[0].Error, gc.ErrorMatches, t.expect)
+	}
+	s.volumeSource.CheckNoCalls(c)
+}
+
+// snapshottingVolumeSource is a volume source that also implements
+// storage.VolumeSnapshotRestorer.
+type snapshottingVolumeSource struct {
+	dummystorage.VolumeSource
+
+	createVolumeSnapshots  func([]jujustorage.VolumeSnapshotParams) ([]jujustorage.CreateVolumeSnapshotsResult, error)
+	listVolumeSnapshots    func(jujustorage.VolumeSnapshotParams) ([]jujustorage.VolumeSnapshot, error)
+	restoreVolumeSnapshots func([]jujustorage.RestoreVolumeSnapshotParams) ([]jujustorage.RestoreVolumeSnapshotsResult, error)
+}
+
+func (s *snapshottingVolumeSource) CreateVolumeSnapshots(args []jujustorage.VolumeSnapshotParams) ([]jujustorage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", args)
+	return s.createVolumeSnapshots(args)
+}
+
+func (s *snapshottingVolumeSource) ListVolumeSnapshots(arg jujustorage.VolumeSnapshotParams) ([]jujustorage.VolumeSnapshot, error) {
+	s.MethodCall(s, "ListVolumeSnapshots", arg)
+	return s.listVolumeSnapshots(arg)
+}
+
+func (s *snapshottingVolumeSource) RestoreVolumeSnapshots(args []jujustorage.RestoreVolumeSnapshotParams) ([]jujustorage.RestoreVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "RestoreVolumeSnapshots", args)
+	return s.restoreVolumeSnapshots(args)
+}
+
+// nonRestoringVolumeSource is a volume source that implements
+// storage.VolumeSnapshotter, but not storage.VolumeSnapshotRestorer.
+type nonRestoringVolumeSource struct {
+	jujustorage.VolumeSource
+	jujustorage.VolumeSnapshotter
+}
@@ -126,6 +126,17 @@ func (s *volumeSuite) TestListVolumesVolumeInfo(c *gc.C) {
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
 }
 
+func (s *volumeSuite) TestListVolumesUsage(c *gc.C) {
+	s.volume.usage = &state.StorageUsage{Capacity: 2000, Used: 500}
+	expected := s.expectedVolumeDetails()
+	expected.Usage = &params.StorageUsage{Capacity: 2000, Used: 500}
+	found, err := s.api.ListVolumes(params.VolumeFilters{[]params.VolumeFilter{{}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
+}
+
 func (s *volumeSuite) TestListVolumesAttachmentInfo(c *gc.C) {
 	s.volumeAttachment.info = &state.VolumeAttachmentInfo{
 		DeviceName: "xvdf1",
@@ -24,6 +24,17 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("StorageProvisioner", 3, newStorageProvisionerAPI)
+
+	// Facade version 4 adds WatchVolumeResizes, VolumeResizeParams,
+	// WatchFilesystemResizes and FilesystemResizeParams.
+	common.RegisterStandardFacade("StorageProvisioner", 4, newStorageProvisionerAPI)
+
+	// Facade version 5 adds SetStorageUsage.
+	common.RegisterStandardFacade("StorageProvisioner", 5, newStorageProvisionerAPI)
+
+	// Facade version 6 adds WatchVolumeSnapshotRequests,
+	// VolumeSnapshotParams and SetVolumeSnapshots.
+	common.RegisterStandardFacade("StorageProvisioner", 6, newStorageProvisionerAPI)
 }
 
 func newStorageProvisionerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPI, error) {
@@ -55,6 +66,12 @@ type Backend interface {
 	WatchEnvironVolumeAttachments() state.StringsWatcher
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeSnapshotRequests() state.StringsWatcher
+	WatchMachineVolumeSnapshotRequests(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
@@ -65,6 +82,7 @@ type Backend interface {
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
+	VolumeEncryptionKey(names.VolumeTag) (string, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.MachineTag, names.FilesystemTag) error
@@ -75,6 +93,10 @@ type Backend interface {
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+
+	SetFilesystemUsage(names.FilesystemTag, state.StorageUsage) error
+	SetVolumeUsage(names.VolumeTag, state.StorageUsage) error
+	SetVolumeSnapshots(names.VolumeTag, []state.VolumeSnapshot) error
 }
 
 type stateShim struct {
@@ -260,6 +260,24 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchModelFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeResizes watches for requests to resize volumes scoped
+// to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchFilesystemResizes watches for requests to resize filesystems
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelFilesystemResizes, s.st.WatchMachineFilesystemResizes)
+}
+
+// WatchVolumeSnapshotRequests watches for requests to snapshot volumes
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshotRequests(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeSnapshotRequests, s.st.WatchMachineVolumeSnapshotRequests)
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -577,6 +595,10 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 		if err != nil {
 			return params.VolumeParams{}, err
 		}
+		volumeParams.EncryptionKey, err = s.volumeEncryptionKey(volume)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
 		if len(volumeAttachments) == 1 {
 			// There is exactly one attachment to be made, so make
 			// it immediately. Otherwise we will defer attachments
@@ -605,6 +627,7 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 				string(instanceId),
 				volumeParams.Provider,
 				volumeAttachmentParams.ReadOnly,
+				volumeParams.EncryptionKey,
 			}
 		}
 		return volumeParams, nil
@@ -622,6 +645,27 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// volumeEncryptionKey returns the key with which the specified volume
+// is encrypted, if the volume was created encrypted, or the empty string
+// otherwise. Whether or not a volume is encrypted is recorded when it is
+// created, so later changes to its storage pool have no effect.
+func (s *StorageProvisionerAPI) volumeEncryptionKey(volume state.Volume) (string, error) {
+	var encrypted bool
+	if volumeParams, ok := volume.Params(); ok {
+		encrypted = volumeParams.Encrypted
+	} else {
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		encrypted = volumeInfo.Encrypted
+	}
+	if !encrypted {
+		return "", nil
+	}
+	return s.st.VolumeEncryptionKey(volume.VolumeTag())
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -724,6 +768,10 @@ func (s *StorageProvisionerAPI) VolumeAttachmentParams(
 		if err != nil {
 			return params.VolumeAttachmentParams{}, errors.Trace(err)
 		}
+		encryptionKey, err := s.volumeEncryptionKey(volume)
+		if err != nil {
+			return params.VolumeAttachmentParams{}, err
+		}
 		var readOnly bool
 		if volumeAttachmentParams, ok := volumeAttachment.Params(); ok {
 			readOnly = volumeAttachmentParams.ReadOnly
@@ -743,6 +791,7 @@ func (s *StorageProvisionerAPI) VolumeAttachmentParams(
 			string(instanceId),
 			string(providerType),
 			readOnly,
+			encryptionKey,
 		}, nil
 	}
 	for i, arg := range args.Ids {
@@ -927,6 +976,208 @@ func (s *StorageProvisionerAPI) oneFilesystemAttachment(
 	return filesystemAttachment, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. It is an error to request the parameters
+// for a volume that has no resize pending.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.PendingSize()
+		if !ok {
+			return params.VolumeResizeParams{}, errors.NotFoundf("pending resize of volume %q", tag.Id())
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		return params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  info.VolumeId,
+			Provider:  string(providerType),
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for snapshotting the
+// volumes with the specified tags. It is an error to request the
+// parameters for a volume that has no snapshot pending.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.Entities) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeSnapshotParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if !volume.SnapshotPending() {
+			return params.VolumeSnapshotParams{}, errors.NotFoundf("pending snapshot of volume %q", tag.Id())
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return params.VolumeSnapshotParams{
+			VolumeTag: tag.String(),
+			VolumeId:  info.VolumeId,
+			Provider:  string(providerType),
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshots records the snapshots of the volumes with the
+// specified tags, completing any pending snapshot requests.
+func (s *StorageProvisionerAPI) SetVolumeSnapshots(args params.SetVolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Volumes)),
+	}
+	one := func(arg params.VolumeSnapshots) error {
+		tag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		snapshots := make([]state.VolumeSnapshot, len(arg.Snapshots))
+		for i, snapshot := range arg.Snapshots {
+			snapshots[i] = state.VolumeSnapshot{
+				SnapshotId: snapshot.SnapshotId,
+				VolumeId:   snapshot.VolumeId,
+				Size:       snapshot.Size,
+				Created:    snapshot.Created,
+			}
+		}
+		return s.st.SetVolumeSnapshots(tag, snapshots)
+	}
+	for i, arg := range args.Volumes {
+		err := one(arg)
+		if errors.IsNotFound(err) {
+			err = common.ErrPerm
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// FilesystemResizeParams returns the parameters for resizing the
+// filesystems with the specified tags. It is an error to request the
+// parameters for a filesystem that has no resize pending.
+func (s *StorageProvisionerAPI) FilesystemResizeParams(args params.Entities) (params.FilesystemResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemResizeParamsResults{}, err
+	}
+	results := params.FilesystemResizeParamsResults{
+		Results: make([]params.FilesystemResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.FilesystemResizeParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		size, ok := filesystem.PendingSize()
+		if !ok {
+			return params.FilesystemResizeParams{}, errors.NotFoundf("pending resize of filesystem %q", tag.Id())
+		}
+		info, err := filesystem.Info()
+		if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		result := params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  info.FilesystemId,
+			Provider:      string(providerType),
+			Size:          size,
+		}
+		if volumeTag, err := filesystem.Volume(); err == nil {
+			result.VolumeTag = volumeTag.String()
+		} else if errors.Cause(err) != state.ErrNoBackingVolume {
+			return params.FilesystemResizeParams{}, err
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.FilesystemResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // SetVolumeInfo records the details of newly provisioned volumes.
 func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.ErrorResults, error) {
 	canAccessVolume, err := s.getStorageEntityAuthFunc()
@@ -943,6 +1194,21 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		volume, err := s.st.Volume(volumeTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if oldInfo, err := volume.Info(); err == nil {
+			// The pool is recorded by state when the volume is
+			// first provisioned, and must not change when the
+			// info of a provisioned volume is updated, e.g. after
+			// it has been resized.
+			volumeInfo.Pool = oldInfo.Pool
+		} else if !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -972,6 +1238,21 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		filesystem, err := s.st.Filesystem(filesystemTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if oldInfo, err := filesystem.Info(); err == nil {
+			// The pool is recorded by state when the filesystem
+			// is first provisioned, and must not change when the
+			// info of a provisioned filesystem is updated, e.g.
+			// after it has been resized.
+			filesystemInfo.Pool = oldInfo.Pool
+		} else if !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -1051,6 +1332,60 @@ func (s *StorageProvisionerAPI) SetFilesystemAttachmentInfo(
 	return results, nil
 }
 
+// SetStorageUsage records the usage of volumes and filesystems, as
+// observed by the machines that they are attached to.
+func (s *StorageProvisionerAPI) SetStorageUsage(args params.MachineStorageUsages) (params.ErrorResults, error) {
+	canAccess, err := s.getAttachmentAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Usages)),
+	}
+	one := func(arg params.MachineStorageUsage) error {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			return err
+		}
+		attachmentTag, err := names.ParseTag(arg.AttachmentTag)
+		if err != nil {
+			return err
+		}
+		if !canAccess(machineTag, attachmentTag) {
+			return common.ErrPerm
+		}
+		usage := state.StorageUsage{
+			Capacity: arg.Usage.Capacity,
+			Used:     arg.Usage.Used,
+		}
+		// Only the machines that a volume or filesystem
+		// is attached to may report its usage.
+		switch attachmentTag := attachmentTag.(type) {
+		case names.VolumeTag:
+			if _, err := s.st.VolumeAttachment(machineTag, attachmentTag); err != nil {
+				return err
+			}
+			err = s.st.SetVolumeUsage(attachmentTag, usage)
+		case names.FilesystemTag:
+			if _, err := s.st.FilesystemAttachment(machineTag, attachmentTag); err != nil {
+				return err
+			}
+			err = s.st.SetFilesystemUsage(attachmentTag, usage)
+		default:
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Usages {
+		err := one(arg)
+		if errors.IsNotFound(err) {
+			err = common.ErrPerm
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // AttachmentLife returns the lifecycle state of each specified machine
 // storage attachment.
 func (s *StorageProvisionerAPI) AttachmentLife(args params.MachineStorageIds) (params.LifeResults, error) {
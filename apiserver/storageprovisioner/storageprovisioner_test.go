@@ -5,6 +5,7 @@ package storageprovisioner_test
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -24,6 +25,7 @@ import (
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -34,10 +36,11 @@ type provisionerSuite struct {
 	// TODO(wallyworld) remove JujuConnSuite
 	jujutesting.JujuConnSuite
 
-	factory    *factory.Factory
-	resources  *common.Resources
-	authorizer *apiservertesting.FakeAuthorizer
-	api        *storageprovisioner.StorageProvisionerAPI
+	factory     *factory.Factory
+	resources   *common.Resources
+	authorizer  *apiservertesting.FakeAuthorizer
+	api         *storageprovisioner.StorageProvisionerAPI
+	poolManager poolmanager.PoolManager
 }
 
 func (s *provisionerSuite) SetUpTest(c *gc.C) {
@@ -53,6 +56,7 @@ func (s *provisionerSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	registry := stateenvirons.NewStorageProviderRegistry(env)
 	pm := poolmanager.New(state.NewStateSettings(s.State), registry)
+	s.poolManager = pm
 
 	s.authorizer = &apiservertesting.FakeAuthorizer{
 		Tag:            names.NewMachineTag("0"),
@@ -403,6 +407,117 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "environscoped",
+				Size:      8192,
+			}},
+			{Error: &params.Error{Message: `pending resize of volume "0/0" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.RequestVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "environscoped",
+			}},
+			{Error: &params.Error{Message: `pending snapshot of volume "0/0" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.RequestVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	created := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	results, err := s.api.SetVolumeSnapshots(params.SetVolumeSnapshots{
+		Volumes: []params.VolumeSnapshots{{
+			VolumeTag: "volume-2",
+			Snapshots: []params.VolumeSnapshot{{
+				SnapshotId: "def@1",
+				VolumeId:   "def",
+				Size:       1024,
+				Created:    created,
+			}},
+		}, {
+			VolumeTag: "volume-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume.SnapshotPending(), jc.IsFalse)
+	c.Assert(volume.Snapshots(), jc.DeepEquals, []state.VolumeSnapshot{{
+		SnapshotId: "def@1",
+		VolumeId:   "def",
+		Size:       1024,
+		Created:    created,
+	}})
+}
+
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	s.setupFilesystems(c)
+
+	// Filesystems only have a resize pending once their
+	// backing volumes have been resized.
+	results, err := s.api.FilesystemResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"filesystem-0-0"},
+			{"filesystem-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemResizeParamsResults{
+		Results: []params.FilesystemResizeParamsResult{
+			{Error: &params.Error{Message: `pending resize of filesystem "0/0" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
 func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	results, err := s.api.VolumeParams(params.Entities{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -512,6 +627,123 @@ func (s *provisionerSuite) TestVolumeAttachmentParams(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestVolumeParamsEncrypted(c *gc.C) {
+	_, err := s.poolManager.Create("encrypted-loop", provider.LoopProviderType, map[string]interface{}{
+		storage.ConfigEncrypted: "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("inst-id"),
+		Volumes: []state.MachineVolumeParams{
+			{Volume: state.VolumeParams{Pool: "encrypted-loop", Size: 1024}},
+		},
+	})
+	key, err := s.State.VolumeEncryptionKey(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Not(gc.Equals), "")
+
+	volumeResults, err := s.api.VolumeParams(params.Entities{
+		Entities: []params.Entity{{"volume-0-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeResults, jc.DeepEquals, params.VolumeParamsResults{
+		Results: []params.VolumeParamsResult{
+			{Result: params.VolumeParams{
+				VolumeTag:  "volume-0-0",
+				Size:       1024,
+				Provider:   "loop",
+				Attributes: map[string]interface{}{storage.ConfigEncrypted: "true"},
+				Tags: map[string]string{
+					tags.JujuController: testing.ControllerTag.Id(),
+					tags.JujuModel:      testing.ModelTag.Id(),
+				},
+				Attachment: &params.VolumeAttachmentParams{
+					MachineTag:    "machine-0",
+					VolumeTag:     "volume-0-0",
+					Provider:      "loop",
+					InstanceId:    "inst-id",
+					EncryptionKey: key,
+				},
+				EncryptionKey: key,
+			}},
+		},
+	})
+
+	attachmentResults, err := s.api.VolumeAttachmentParams(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "volume-0-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachmentResults, jc.DeepEquals, params.VolumeAttachmentParamsResults{
+		Results: []params.VolumeAttachmentParamsResult{
+			{Result: params.VolumeAttachmentParams{
+				MachineTag:    "machine-0",
+				VolumeTag:     "volume-0-0",
+				InstanceId:    "inst-id",
+				Provider:      "loop",
+				EncryptionKey: key,
+			}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestVolumeAttachmentParamsEncryptedPoolChanged(c *gc.C) {
+	_, err := s.poolManager.Create("encrypted-loop", provider.LoopProviderType, map[string]interface{}{
+		storage.ConfigEncrypted: "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.poolManager.Create("plain-loop", provider.LoopProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("inst-id"),
+		Volumes: []state.MachineVolumeParams{
+			{Volume: state.VolumeParams{Pool: "encrypted-loop", Size: 1024}},
+			{Volume: state.VolumeParams{Pool: "plain-loop", Size: 1024}},
+		},
+	})
+	key, err := s.State.VolumeEncryptionKey(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Whether or not a volume is encrypted is recorded when
+	// it is created; later changes to its pool are ignored.
+	_, err = s.poolManager.Replace("encrypted-loop", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.poolManager.Replace("plain-loop", map[string]interface{}{
+		storage.ConfigEncrypted: "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeAttachmentParams(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "volume-0-0",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "volume-0-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeAttachmentParamsResults{
+		Results: []params.VolumeAttachmentParamsResult{
+			{Result: params.VolumeAttachmentParams{
+				MachineTag:    "machine-0",
+				VolumeTag:     "volume-0-0",
+				InstanceId:    "inst-id",
+				Provider:      "loop",
+				EncryptionKey: key,
+			}},
+			{Result: params.VolumeAttachmentParams{
+				MachineTag: "machine-0",
+				VolumeTag:  "volume-0-1",
+				InstanceId: "inst-id",
+				Provider:   "loop",
+			}},
+		},
+	})
+}
+
 func (s *provisionerSuite) TestFilesystemAttachmentParams(c *gc.C) {
 	s.setupFilesystems(c)
 
@@ -671,6 +903,60 @@ func (s *provisionerSuite) TestSetFilesystemAttachmentInfo(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestSetStorageUsage(c *gc.C) {
+	s.setupFilesystems(c)
+
+	results, err := s.api.SetStorageUsage(params.MachineStorageUsages{
+		Usages: []params.MachineStorageUsage{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-0-0",
+			Usage:         params.StorageUsage{Capacity: 1000, Used: 250},
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-2",
+			Usage:         params.StorageUsage{Capacity: 4000, Used: 1000},
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-1",
+			Usage:         params.StorageUsage{Capacity: 1000, Used: 2000},
+		}, {
+			MachineTag:    "machine-1",
+			AttachmentTag: "filesystem-2",
+			Usage:         params.StorageUsage{Capacity: 4000, Used: 1000},
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-42",
+			Usage:         params.StorageUsage{Capacity: 1000},
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "unit-mysql-0",
+			Usage:         params.StorageUsage{Capacity: 1000},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: `cannot set usage of filesystem "1": used 2000 bytes exceeds capacity 1000 bytes`}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	usage, ok := filesystem.Usage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(usage, jc.DeepEquals, state.StorageUsage{Capacity: 1000, Used: 250})
+
+	filesystem, err = s.State.Filesystem(names.NewFilesystemTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = filesystem.Usage()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
@@ -711,6 +997,166 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestSetVolumeInfoProvisioned(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeInfo(params.Volumes{[]params.Volume{{
+		VolumeTag: "volume-2",
+		Info: params.VolumeInfo{
+			VolumeId:   "def",
+			HardwareId: "456",
+			Size:       8192,
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+		VolumeId:   "def",
+		HardwareId: "456",
+		Pool:       "environscoped",
+		Size:       8192,
+	})
+	_, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *provisionerSuite) TestSetFilesystemInfoProvisioned(c *gc.C) {
+	s.setupFilesystems(c)
+
+	results, err := s.api.SetFilesystemInfo(params.Filesystems{[]params.Filesystem{{
+		FilesystemTag: "filesystem-2",
+		Info: params.FilesystemInfo{
+			FilesystemId: "def",
+			Size:         8192,
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.FilesystemInfo{
+		FilesystemId: "def",
+		Pool:         "environscoped",
+		Size:         8192,
+	})
+}
+
+func (s *provisionerSuite) TestWatchFilesystemResizes(c *gc.C) {
+	s.setupFilesystems(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchFilesystemResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1"},
+			{StringsWatcherId: "2"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	// Check that the Watch has consumed the initial events ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1"},
+			{StringsWatcherId: "2", Changes: []string{"2"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	// Check that the Watch has consumed the initial events ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshotRequests(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.RequestVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshotRequests(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1"},
+			{StringsWatcherId: "2", Changes: []string{"2"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	// Check that the Watch has consumed the initial events ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
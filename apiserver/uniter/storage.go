@@ -181,13 +181,18 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 	if err != nil {
 		return params.StorageAttachment{}, err
 	}
+	var ownerTag string
+	if owner, ok := stateStorageInstance.Owner(); ok {
+		ownerTag = owner.String()
+	}
 	return params.StorageAttachment{
-		stateStorageAttachment.StorageInstance().String(),
-		stateStorageInstance.Owner().String(),
-		stateStorageAttachment.Unit().String(),
-		params.StorageKind(stateStorageInstance.Kind()),
-		info.Location,
-		params.Life(stateStorageAttachment.Life().String()),
+		StorageTag: stateStorageAttachment.StorageInstance().String(),
+		OwnerTag:   ownerTag,
+		UnitTag:    stateStorageAttachment.Unit().String(),
+		Kind:       params.StorageKind(stateStorageInstance.Kind()),
+		Location:   info.Location,
+		Life:       params.Life(stateStorageAttachment.Life().String()),
+		Size:       info.Size,
 	}, nil
 }
 
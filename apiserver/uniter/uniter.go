@@ -1213,7 +1213,7 @@ func (u *UniterAPIV3) WatchRelationUnits(args params.RelationUnits) (params.Rela
 }
 
 // WatchUnitAddresses returns a NotifyWatcher for observing changes
-// to each unit's addresses.
+// to each unit's addresses, and to its application's endpoint bindings.
 func (u *UniterAPIV3) WatchUnitAddresses(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -1399,7 +1399,17 @@ func (u *UniterAPIV3) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	watch := machine.WatchAddresses()
+	application, err := unit.Application()
+	if err != nil {
+		return "", err
+	}
+	// The addresses reported to the unit by network-get depend on
+	// the application's endpoint bindings as well as on the machine's
+	// addresses, so changes to either are reported.
+	watch := common.NewMultiNotifyWatcher(
+		machine.WatchAddresses(),
+		application.WatchEndpointBindings(),
+	)
 	// Consume the initial event. Technically, API
 	// calls to Watch 'transmit' the initial event
 	// in the Watch response. But NotifyWatchers
@@ -176,7 +176,10 @@ func newStringsWatcher(context facade.Context) (facade.Facade, error) {
 	auth := context.Auth()
 	resources := context.Resources()
 
-	if !isAgent(auth) {
+	// Clients may watch the results of the actions they enqueue;
+	// resources are per-connection, so a client can only ever see
+	// the watchers it created itself.
+	if !isAgent(auth) && !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.StringsWatcher)
@@ -137,6 +137,24 @@ func (s *watcherSuite) TestMigrationStatusWatcherNotAgent(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
+func (s *watcherSuite) TestStringsWatcherClient(c *gc.C) {
+	ch := make(chan []string, 1)
+	id := s.resources.Register(&fakeStringsWatcher{ch: ch})
+	s.authorizer.Tag = names.NewUserTag("frogdog")
+
+	ch <- []string{"a", "b"}
+	facade := s.getFacade(c, "StringsWatcher", 1, id).(stringsWatcher)
+	result, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		Changes: []string{"a", "b"},
+	})
+}
+
+type stringsWatcher interface {
+	Next() (params.StringsWatchResult, error)
+}
+
 type machineStorageIdsWatcher interface {
 	Next() (params.MachineStorageIdsWatchResult, error)
 }
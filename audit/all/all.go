@@ -0,0 +1,10 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package all
+
+// Register all the available audit sinks.
+import (
+	_ "github.com/juju/juju/audit/syslog"
+	_ "github.com/juju/juju/audit/webhook"
+)
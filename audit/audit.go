@@ -78,3 +78,43 @@ func (e AuditEntry) Validate() error {
 
 	return nil
 }
+
+// Query describes which audit entries should be returned when
+// querying a store of audit entries. Empty fields are not used to
+// filter the entries.
+type Query struct {
+	// ModelUUID limits the entries to those recorded on the model
+	// with this ID.
+	ModelUUID string
+	// OriginType limits the entries to those triggered by this type
+	// of entity.
+	OriginType string
+	// OriginName limits the entries to those triggered by the origin
+	// with this name.
+	OriginName string
+	// Operation limits the entries to those recording this
+	// operation.
+	Operation string
+	// After limits the entries to those recorded at or after this
+	// time.
+	After time.Time
+	// Before limits the entries to those recorded before this time.
+	Before time.Time
+	// Limit, if non-zero, limits the number of entries returned to
+	// the most recent Limit entries.
+	Limit int
+}
+
+// Validate ensures that the query is self-consistent.
+func (q Query) Validate() error {
+	if q.ModelUUID != "" && !utils.IsValidUUIDString(q.ModelUUID) {
+		return errors.NotValidf("ModelUUID")
+	}
+	if !q.After.IsZero() && !q.Before.IsZero() && !q.Before.After(q.After) {
+		return errors.NewNotValid(nil, "Before must be later than After")
+	}
+	if q.Limit < 0 {
+		return errors.NotValidf("negative Limit")
+	}
+	return nil
+}
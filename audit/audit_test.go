@@ -114,3 +114,26 @@ func validEntry() audit.AuditEntry {
 		Operation:         ".",
 	}
 }
+
+func (s *auditSuite) TestQueryValidate_Empty(c *gc.C) {
+	c.Check(audit.Query{}.Validate(), jc.ErrorIsNil)
+}
+
+func (s *auditSuite) TestQueryValidate_InvalidModelUUIDErrors(c *gc.C) {
+	err := audit.Query{ModelUUID: "."}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "ModelUUID not valid")
+}
+
+func (s *auditSuite) TestQueryValidate_BeforeNotAfterAfterErrors(c *gc.C) {
+	now := time.Now().UTC()
+	err := audit.Query{After: now, Before: now.Add(-time.Minute)}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "Before must be later than After")
+}
+
+func (s *auditSuite) TestQueryValidate_NegativeLimitErrors(c *gc.C) {
+	err := audit.Query{Limit: -1}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "negative Limit not valid")
+}
@@ -0,0 +1,239 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package queue
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// bufferedEntry is a serialized audit entry held in a diskBuffer.
+type bufferedEntry struct {
+	seq  int64
+	data []byte
+}
+
+// diskBuffer is a bounded queue of serialized audit entries which is
+// mirrored to disk, so that entries which have not been delivered
+// survive a restart. Once the buffer is full, the oldest entries are
+// discarded to make room for new ones.
+//
+// Entries are appended to the buffer file, one per line, prefixed with
+// their sequence number. Removing entries only records the sequence
+// number of the last one removed, in a separate small file; the buffer
+// file is compacted once it holds as many removed entries as the
+// buffer's capacity, so each entry is rewritten at most once on
+// average.
+type diskBuffer struct {
+	path string
+	max  int
+
+	mu      sync.Mutex
+	file    *os.File
+	entries []bufferedEntry
+	nextSeq int64
+	removed int64
+	stale   int
+}
+
+// openDiskBuffer returns a diskBuffer holding up to max entries,
+// backed by the file at the given path. Any entries already recorded
+// in the file, and not since removed, are loaded.
+func openDiskBuffer(path string, max int) (*diskBuffer, error) {
+	b := &diskBuffer{path: path, max: max, removed: -1}
+	if err := b.readRemoved(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := b.readEntries(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if b.stale > 0 {
+		if err := b.compact(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if excess := len(b.entries) - b.max; excess > 0 {
+		if err := b.removeThrough(b.entries[excess-1].seq); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := b.openFile(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return b, nil
+}
+
+func (b *diskBuffer) removedPath() string {
+	return b.path + ".removed"
+}
+
+func (b *diskBuffer) readRemoved() error {
+	data, err := ioutil.ReadFile(b.removedPath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	removed, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return errors.Annotatef(err, "reading %s", b.removedPath())
+	}
+	b.removed = removed
+	b.nextSeq = removed + 1
+	return nil
+}
+
+func (b *diskBuffer) readEntries() error {
+	data, err := ioutil.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	if last := lines[len(lines)-1]; len(last) > 0 {
+		// The last entry was only partially written; it is
+		// dropped when the file is compacted below.
+		logger.Warningf("discarding partially written audit entry in %s", b.path)
+		b.stale++
+	}
+	for _, line := range lines[:len(lines)-1] {
+		fields := bytes.SplitN(line, []byte(" "), 2)
+		seq, err := strconv.ParseInt(string(fields[0]), 10, 64)
+		if err != nil || len(fields) != 2 {
+			return errors.Errorf("malformed line in %s", b.path)
+		}
+		if seq >= b.nextSeq {
+			b.nextSeq = seq + 1
+		}
+		if seq <= b.removed {
+			b.stale++
+			continue
+		}
+		b.entries = append(b.entries, bufferedEntry{seq: seq, data: fields[1]})
+	}
+	return nil
+}
+
+func (b *diskBuffer) openFile() error {
+	f, err := os.OpenFile(b.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b.file = f
+	return nil
+}
+
+// Len returns the number of entries in the buffer.
+func (b *diskBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.entries)
+}
+
+// Push adds the serialized entry to the end of the buffer, returning
+// the number of older entries which were discarded to make room.
+func (b *diskBuffer) Push(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return 0, errors.New("buffer closed")
+	}
+	seq := b.nextSeq
+	if _, err := fmt.Fprintf(b.file, "%d %s\n", seq, data); err != nil {
+		return 0, errors.Trace(err)
+	}
+	b.nextSeq++
+	b.entries = append(b.entries, bufferedEntry{seq: seq, data: data})
+	excess := len(b.entries) - b.max
+	if excess <= 0 {
+		return 0, nil
+	}
+	return excess, errors.Trace(b.removeThrough(b.entries[excess-1].seq))
+}
+
+// Peek returns up to n entries from the front of the buffer, without
+// removing them.
+func (b *diskBuffer) Peek(n int) []bufferedEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n > len(b.entries) {
+		n = len(b.entries)
+	}
+	result := make([]bufferedEntry, n)
+	copy(result, b.entries[:n])
+	return result
+}
+
+// RemoveThrough removes all of the entries up to and including the one
+// with the given sequence number. Entries which have already been
+// discarded are ignored.
+func (b *diskBuffer) RemoveThrough(seq int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return errors.Trace(b.removeThrough(seq))
+}
+
+// removeThrough implements RemoveThrough. It must be called with
+// b.mu held.
+func (b *diskBuffer) removeThrough(seq int64) error {
+	i := 0
+	for i < len(b.entries) && b.entries[i].seq <= seq {
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+	b.entries = b.entries[i:]
+	b.stale += i
+	b.removed = seq
+	data := []byte(strconv.FormatInt(seq, 10) + "\n")
+	if err := utils.AtomicWriteFile(b.removedPath(), data, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	if b.stale < b.max {
+		return nil
+	}
+	return errors.Trace(b.compact())
+}
+
+// compact replaces the buffer file with one holding only the entries
+// still in the buffer. It must be called with b.mu held.
+func (b *diskBuffer) compact() error {
+	var buf bytes.Buffer
+	for _, entry := range b.entries {
+		fmt.Fprintf(&buf, "%d %s\n", entry.seq, entry.data)
+	}
+	if err := utils.AtomicWriteFile(b.path, buf.Bytes(), 0600); err != nil {
+		return errors.Trace(err)
+	}
+	b.stale = 0
+	if b.file == nil {
+		return nil
+	}
+	// The open file refers to the replaced buffer file.
+	b.file.Close()
+	return errors.Trace(b.openFile())
+}
+
+// Close closes the buffer file. Entries which remain in the buffer
+// are loaded again when it is next opened.
+func (b *diskBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.file == nil {
+		return nil
+	}
+	err := b.file.Close()
+	b.file = nil
+	return errors.Trace(err)
+}
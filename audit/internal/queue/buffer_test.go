@@ -0,0 +1,122 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package queue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type bufferSuite struct {
+	testing.IsolationSuite
+	path string
+}
+
+var _ = gc.Suite(&bufferSuite{})
+
+func (s *bufferSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.path = filepath.Join(c.MkDir(), "buffer")
+}
+
+func (s *bufferSuite) open(c *gc.C, max int) *diskBuffer {
+	b, err := openDiskBuffer(s.path, max)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { b.Close() })
+	return b
+}
+
+func (s *bufferSuite) push(c *gc.C, b *diskBuffer, entries ...string) {
+	for _, entry := range entries {
+		_, err := b.Push([]byte(entry))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *bufferSuite) assertEntries(c *gc.C, b *diskBuffer, expected ...string) {
+	var entries []string
+	for _, entry := range b.Peek(b.Len()) {
+		entries = append(entries, string(entry.data))
+	}
+	c.Assert(entries, jc.DeepEquals, expected)
+}
+
+func (s *bufferSuite) assertFile(c *gc.C, expected string) {
+	data, err := ioutil.ReadFile(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expected)
+}
+
+func (s *bufferSuite) TestPushAppends(c *gc.C) {
+	b := s.open(c, 10)
+	s.push(c, b, "one", "two")
+	s.assertEntries(c, b, "one", "two")
+	s.assertFile(c, "0 one\n1 two\n")
+}
+
+func (s *bufferSuite) TestRemoveThroughKeepsFile(c *gc.C) {
+	b := s.open(c, 10)
+	s.push(c, b, "one", "two", "three")
+	err := b.RemoveThrough(b.Peek(2)[1].seq)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEntries(c, b, "three")
+	s.assertFile(c, "0 one\n1 two\n2 three\n")
+
+	c.Assert(b.Close(), jc.ErrorIsNil)
+	b = s.open(c, 10)
+	s.assertEntries(c, b, "three")
+	s.push(c, b, "four")
+	s.assertEntries(c, b, "three", "four")
+}
+
+func (s *bufferSuite) TestCompact(c *gc.C) {
+	b := s.open(c, 2)
+	s.push(c, b, "one", "two")
+	err := b.RemoveThrough(b.Peek(1)[0].seq)
+	c.Assert(err, jc.ErrorIsNil)
+	s.push(c, b, "three")
+	s.assertFile(c, "0 one\n1 two\n2 three\n")
+
+	err = b.RemoveThrough(b.Peek(1)[0].seq)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFile(c, "2 three\n")
+	s.push(c, b, "four")
+	s.assertFile(c, "2 three\n3 four\n")
+	s.assertEntries(c, b, "three", "four")
+}
+
+func (s *bufferSuite) TestPushDiscardsOldest(c *gc.C) {
+	b := s.open(c, 2)
+	s.push(c, b, "one", "two")
+	dropped, err := b.Push([]byte("three"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dropped, gc.Equals, 1)
+	s.assertEntries(c, b, "two", "three")
+
+	c.Assert(b.Close(), jc.ErrorIsNil)
+	b = s.open(c, 2)
+	s.assertEntries(c, b, "two", "three")
+}
+
+func (s *bufferSuite) TestOpenDiscardsPartialEntry(c *gc.C) {
+	err := ioutil.WriteFile(s.path, []byte("0 one\n1 tw"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	b := s.open(c, 10)
+	s.assertEntries(c, b, "one")
+	s.push(c, b, "two")
+	s.assertFile(c, "0 one\n1 two\n")
+}
+
+func (s *bufferSuite) TestFileMode(c *gc.C) {
+	b := s.open(c, 10)
+	s.push(c, b, "one")
+	info, err := os.Stat(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
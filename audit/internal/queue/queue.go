@@ -0,0 +1,196 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package queue provides the persistent queue through which audit
+// sinks deliver serialized audit entries, so that sending an entry
+// never waits on the sink's destination.
+package queue
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+)
+
+var logger = loggo.GetLogger("juju.audit.queue")
+
+// Config holds the configuration for a Queue.
+type Config struct {
+	// Target describes where entries are delivered, for logging.
+	Target string
+
+	// Deliver delivers a batch of serialized entries, oldest first.
+	// If it returns an error, the batch is retried.
+	Deliver func([][]byte) error
+
+	// BatchSize is the maximum number of entries delivered in a
+	// single call to Deliver.
+	BatchSize int
+
+	// BufferSize is the maximum number of undelivered entries held
+	// by the queue. Once it is reached, the oldest entries are
+	// discarded.
+	BufferSize int
+
+	// BufferPath is the file in which undelivered entries are kept.
+	BufferPath string
+
+	// RetryDelay is the delay before retrying a failed delivery. It
+	// doubles after each consecutive failure, up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	// Clock is used to time retries.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is not valid.
+func (config Config) Validate() error {
+	if config.Deliver == nil {
+		return errors.NotValidf("nil Deliver")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.BufferSize < config.BatchSize {
+		return errors.NotValidf("BufferSize smaller than BatchSize")
+	}
+	if config.BufferPath == "" {
+		return errors.NotValidf("empty BufferPath")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay smaller than RetryDelay")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Queue delivers serialized audit entries in the background. Entries
+// are first appended to a bounded on-disk buffer, and are removed from
+// it once they have been delivered; failed deliveries are retried with
+// an exponential backoff.
+type Queue struct {
+	tomb   tomb.Tomb
+	config Config
+	buffer *diskBuffer
+	wake   chan struct{}
+}
+
+// New returns a new Queue with the given configuration. Any entries
+// left undelivered by a previous queue using the same buffer will be
+// delivered.
+func New(config Config) (*Queue, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	buffer, err := openDiskBuffer(config.BufferPath, config.BufferSize)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening audit buffer")
+	}
+	q := &Queue{
+		config: config,
+		buffer: buffer,
+		wake:   make(chan struct{}, 1),
+	}
+	go func() {
+		defer q.tomb.Done()
+		defer buffer.Close()
+		q.tomb.Kill(q.loop())
+	}()
+	return q, nil
+}
+
+// Push adds the serialized entry to the queue, to be delivered
+// asynchronously.
+func (q *Queue) Push(data []byte) error {
+	dropped, err := q.buffer.Push(data)
+	if dropped > 0 {
+		logger.Warningf("audit buffer for %s full, discarded %d undelivered entries", q.config.Target, dropped)
+	}
+	if err != nil {
+		return errors.Annotate(err, "buffering audit entry")
+	}
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops the queue. Entries which have not yet been delivered
+// remain in the buffer.
+func (q *Queue) Close() error {
+	q.tomb.Kill(nil)
+	return q.tomb.Wait()
+}
+
+// immediately is a closed channel, used to deliver buffered entries
+// without delay.
+var immediately = func() <-chan time.Time {
+	ch := make(chan time.Time)
+	close(ch)
+	return ch
+}()
+
+func (q *Queue) loop() error {
+	var delay time.Duration
+	var ready <-chan time.Time
+	for {
+		if ready == nil && q.buffer.Len() > 0 {
+			if delay == 0 {
+				ready = immediately
+			} else {
+				ready = q.config.Clock.After(delay)
+			}
+		}
+		select {
+		case <-q.tomb.Dying():
+			return tomb.ErrDying
+		case <-q.wake:
+		case <-ready:
+			ready = nil
+			if err := q.deliverBatch(); err != nil {
+				delay = q.nextDelay(delay)
+				logger.Errorf("cannot deliver audit entries to %s (retrying in %s): %v", q.config.Target, delay, err)
+				continue
+			}
+			delay = 0
+		}
+	}
+}
+
+func (q *Queue) nextDelay(delay time.Duration) time.Duration {
+	if delay == 0 {
+		return q.config.RetryDelay
+	}
+	delay *= 2
+	if delay > q.config.MaxRetryDelay {
+		delay = q.config.MaxRetryDelay
+	}
+	return delay
+}
+
+// deliverBatch delivers the oldest buffered entries, and removes them
+// from the buffer once they have been delivered.
+func (q *Queue) deliverBatch() error {
+	batch := q.buffer.Peek(q.config.BatchSize)
+	if len(batch) == 0 {
+		return nil
+	}
+	data := make([][]byte, len(batch))
+	for i, entry := range batch {
+		data[i] = entry.data
+	}
+	if err := q.config.Deliver(data); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(q.buffer.RemoveThrough(batch[len(batch)-1].seq))
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/controller"
+)
+
+// Sink is a destination for audit entries which holds resources that
+// must be released once it is no longer needed.
+type Sink interface {
+	// Send sends the audit entry to the sink.
+	Send(AuditEntry) error
+
+	// Close releases the resources held by the sink, flushing any
+	// buffered entries where possible.
+	Close() error
+}
+
+// SinkParams holds the parameters used to open a Sink.
+type SinkParams struct {
+	// ControllerConfig holds the configuration of the controller
+	// which is recording the audit entries.
+	ControllerConfig controller.Config
+
+	// AgentTag is the tag of the agent recording the audit entries.
+	AgentTag names.Tag
+
+	// DataDir is a directory in which the sink may keep its
+	// own state.
+	DataDir string
+
+	// Clock is used by the sink for any timing it requires.
+	Clock clock.Clock
+}
+
+// SinkOpener opens a Sink from the supplied parameters.
+type SinkOpener func(SinkParams) (Sink, error)
+
+var (
+	sinksMu sync.Mutex
+	sinks   = make(map[string]SinkOpener)
+)
+
+// RegisterSink makes an audit sink available under the given name, for
+// use in the audit-log-sinks controller configuration.
+func RegisterSink(name string, opener SinkOpener) error {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	if _, ok := sinks[name]; ok {
+		return errors.AlreadyExistsf("audit sink %q", name)
+	}
+	sinks[name] = opener
+	return nil
+}
+
+// RegisteredSinks returns the names of the registered audit sinks,
+// in sorted order.
+func RegisteredSinks() []string {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenSinks opens the named audit sinks. Sinks which are unknown, or
+// which cannot be opened, are logged and skipped so that a broken sink
+// does not prevent the others, or the caller, from working.
+func OpenSinks(sinkNames []string, params SinkParams) []Sink {
+	opened := make([]Sink, 0, len(sinkNames))
+	for _, name := range sinkNames {
+		sinksMu.Lock()
+		opener, ok := sinks[name]
+		sinksMu.Unlock()
+		if !ok {
+			logger.Errorf("cannot open audit sink %q: not registered", name)
+			continue
+		}
+		sink, err := opener(params)
+		if err != nil {
+			logger.Errorf("cannot open audit sink %q: %v", name, err)
+			continue
+		}
+		opened = append(opened, sink)
+	}
+	return opened
+}
+
+// CloseSinks closes all of the supplied sinks, logging any errors.
+func CloseSinks(sinks []Sink) {
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			logger.Errorf("closing audit sink: %v", err)
+		}
+	}
+}
+
+// serializedEntry is the JSON representation of an AuditEntry sent to
+// external sinks.
+type serializedEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         string                 `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// SerializeEntry returns the JSON representation of the audit entry
+// which is sent to external sinks.
+func SerializeEntry(entry AuditEntry) ([]byte, error) {
+	data, err := json.Marshal(serializedEntry{
+		JujuServerVersion: entry.JujuServerVersion.String(),
+		ModelUUID:         entry.ModelUUID,
+		Timestamp:         entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		RemoteAddress:     entry.RemoteAddress,
+		OriginType:        entry.OriginType,
+		OriginName:        entry.OriginName,
+		Operation:         entry.Operation,
+		Data:              entry.Data,
+	})
+	return data, errors.Trace(err)
+}
+
+// DeserializeEntry returns the audit entry from its JSON
+// representation, as returned by SerializeEntry.
+func DeserializeEntry(data []byte) (AuditEntry, error) {
+	var serialized serializedEntry
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return AuditEntry{}, errors.Trace(err)
+	}
+	jujuVersion, err := version.Parse(serialized.JujuServerVersion)
+	if err != nil {
+		return AuditEntry{}, errors.Annotate(err, "parsing juju server version")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, serialized.Timestamp)
+	if err != nil {
+		return AuditEntry{}, errors.Annotate(err, "parsing timestamp")
+	}
+	return AuditEntry{
+		JujuServerVersion: jujuVersion,
+		ModelUUID:         serialized.ModelUUID,
+		Timestamp:         timestamp,
+		RemoteAddress:     serialized.RemoteAddress,
+		OriginType:        serialized.OriginType,
+		OriginName:        serialized.OriginName,
+		Operation:         serialized.Operation,
+		Data:              serialized.Data,
+	}, nil
+}
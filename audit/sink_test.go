@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type sinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sinkSuite{})
+
+type stubSink struct {
+	*testing.Stub
+	name string
+}
+
+func (s stubSink) Send(entry audit.AuditEntry) error {
+	s.MethodCall(s, "Send", entry)
+	return s.NextErr()
+}
+
+func (s stubSink) Close() error {
+	s.MethodCall(s, "Close", s.name)
+	return s.NextErr()
+}
+
+func (s *sinkSuite) TestRegisterSinkTwice(c *gc.C) {
+	opener := func(audit.SinkParams) (audit.Sink, error) { return nil, nil }
+	err := audit.RegisterSink("test-twice", opener)
+	c.Assert(err, jc.ErrorIsNil)
+	err = audit.RegisterSink("test-twice", opener)
+	c.Assert(err, gc.ErrorMatches, `audit sink "test-twice" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(audit.RegisteredSinks(), jc.Contains, "test-twice")
+}
+
+func (s *sinkSuite) TestOpenSinks(c *gc.C) {
+	stub := &testing.Stub{}
+	var params []audit.SinkParams
+	opener := func(name string) audit.SinkOpener {
+		return func(p audit.SinkParams) (audit.Sink, error) {
+			params = append(params, p)
+			return stubSink{stub, name}, nil
+		}
+	}
+	err := audit.RegisterSink("test-open-a", opener("a"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = audit.RegisterSink("test-open-b", opener("b"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	sinks := audit.OpenSinks([]string{"test-open-a", "test-open-b"}, audit.SinkParams{DataDir: "/var/lib/juju"})
+	c.Assert(sinks, jc.DeepEquals, []audit.Sink{stubSink{stub, "a"}, stubSink{stub, "b"}})
+	c.Assert(params, gc.HasLen, 2)
+	c.Assert(params[0].DataDir, gc.Equals, "/var/lib/juju")
+}
+
+func (s *sinkSuite) TestOpenSinksSkipsUnknown(c *gc.C) {
+	stub := &testing.Stub{}
+	err := audit.RegisterSink("test-known", func(audit.SinkParams) (audit.Sink, error) {
+		return stubSink{stub, "known"}, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sinks := audit.OpenSinks([]string{"test-unknown", "test-known"}, audit.SinkParams{})
+	c.Assert(sinks, jc.DeepEquals, []audit.Sink{stubSink{stub, "known"}})
+	c.Assert(c.GetTestLog(), jc.Contains, `cannot open audit sink "test-unknown": not registered`)
+}
+
+func (s *sinkSuite) TestOpenSinksSkipsErrors(c *gc.C) {
+	stub := &testing.Stub{}
+	err := audit.RegisterSink("test-error-a", func(audit.SinkParams) (audit.Sink, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = audit.RegisterSink("test-error-b", func(audit.SinkParams) (audit.Sink, error) {
+		return stubSink{stub, "b"}, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sinks := audit.OpenSinks([]string{"test-error-a", "test-error-b"}, audit.SinkParams{})
+	c.Assert(sinks, jc.DeepEquals, []audit.Sink{stubSink{stub, "b"}})
+	c.Assert(c.GetTestLog(), jc.Contains, `cannot open audit sink "test-error-a": boom`)
+	stub.CheckNoCalls(c)
+}
+
+func (s *sinkSuite) TestSerializeEntry(c *gc.C) {
+	data, err := audit.SerializeEntry(audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Timestamp:         time.Date(2016, 10, 16, 12, 30, 0, 5, time.UTC),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "ModelManager.DestroyModels",
+		Data:              map[string]interface{}{"foo": "bar"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `{"juju-server-version":"2.0.1",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"timestamp":"2016-10-16T12:30:00.000000005Z","remote-address":"10.0.0.1",`+
+		`"origin-type":"API request","origin-name":"user-admin",`+
+		`"operation":"ModelManager.DestroyModels","data":{"foo":"bar"}}`)
+}
+
+func (s *sinkSuite) TestDeserializeEntry(c *gc.C) {
+	entry := audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Timestamp:         time.Date(2016, 10, 16, 12, 30, 0, 5, time.UTC),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "ModelManager.DestroyModels",
+		Data:              map[string]interface{}{"foo": "bar"},
+	}
+	data, err := audit.SerializeEntry(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := audit.DeserializeEntry(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entry)
+}
@@ -0,0 +1,282 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package syslog provides an audit sink which forwards audit entries
+// to a remote syslog (RFC 5424) host over TLS.
+package syslog
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/audit/internal/queue"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+var logger = loggo.GetLogger("juju.audit.syslog")
+
+// SinkName is the name under which the syslog audit sink is registered.
+const SinkName = "syslog"
+
+const (
+	// bufferFilename is the name of the file, within the agent's
+	// data directory, in which undelivered entries are kept.
+	bufferFilename = "audit-syslog.buffer"
+
+	defaultBatchSize     = 100
+	defaultBufferSize    = 10000
+	defaultRetryDelay    = time.Second
+	defaultMaxRetryDelay = 5 * time.Minute
+)
+
+func init() {
+	if err := audit.RegisterSink(SinkName, openSink); err != nil {
+		panic(err)
+	}
+}
+
+// Sender sends log records to a syslog host.
+type Sender interface {
+	Send([]logfwd.Record) error
+	Close() error
+}
+
+// Config holds the configuration for a syslog audit sink.
+type Config struct {
+	// RawConfig holds the details of the syslog host to which
+	// audit entries are sent.
+	RawConfig syslog.RawConfig
+
+	// ControllerUUID is the UUID of the controller recording the
+	// audit entries.
+	ControllerUUID string
+
+	// AgentTag is the tag of the machine agent recording the audit
+	// entries.
+	AgentTag names.MachineTag
+
+	// Open connects to the syslog host.
+	Open func(syslog.RawConfig) (Sender, error)
+
+	// BatchSize is the maximum number of entries sent to the
+	// syslog host at once.
+	BatchSize int
+
+	// BufferSize is the maximum number of undelivered entries held
+	// by the sink. Once it is reached, the oldest entries are
+	// discarded.
+	BufferSize int
+
+	// BufferPath is the file in which undelivered entries are kept.
+	BufferPath string
+
+	// RetryDelay is the delay before retrying a failed send. It
+	// doubles after each consecutive failure, up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	// Clock is used to time retries.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is not valid.
+func (cfg Config) Validate() error {
+	if !cfg.RawConfig.Enabled {
+		return errors.NotValidf("disabled RawConfig")
+	}
+	if err := cfg.RawConfig.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidModel(cfg.ControllerUUID) {
+		return errors.NotValidf("ControllerUUID %q", cfg.ControllerUUID)
+	}
+	if cfg.AgentTag.Id() == "" {
+		return errors.NotValidf("empty AgentTag")
+	}
+	if cfg.Open == nil {
+		return errors.NotValidf("nil Open")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if cfg.BufferSize < cfg.BatchSize {
+		return errors.NotValidf("BufferSize smaller than BatchSize")
+	}
+	if cfg.BufferPath == "" {
+		return errors.NotValidf("empty BufferPath")
+	}
+	if cfg.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if cfg.MaxRetryDelay < cfg.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay smaller than RetryDelay")
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+func openSink(params audit.SinkParams) (audit.Sink, error) {
+	tag, ok := params.AgentTag.(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected machine agent tag, got %v", params.AgentTag)
+	}
+	controllerConfig := params.ControllerConfig
+	sink, err := NewSink(Config{
+		RawConfig: syslog.RawConfig{
+			Enabled:    true,
+			Host:       controllerConfig.AuditSyslogHost(),
+			CACert:     controllerConfig.AuditSyslogCACert(),
+			ClientCert: controllerConfig.AuditSyslogClientCert(),
+			ClientKey:  controllerConfig.AuditSyslogClientKey(),
+		},
+		ControllerUUID: controllerConfig.ControllerUUID(),
+		AgentTag:       tag,
+		Open:           open,
+		BatchSize:      defaultBatchSize,
+		BufferSize:     defaultBufferSize,
+		BufferPath:     filepath.Join(params.DataDir, bufferFilename),
+		RetryDelay:     defaultRetryDelay,
+		MaxRetryDelay:  defaultMaxRetryDelay,
+		Clock:          params.Clock,
+	})
+	return sink, errors.Trace(err)
+}
+
+func open(cfg syslog.RawConfig) (Sender, error) {
+	client, err := syslog.Open(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+// Sink is an audit sink which sends audit entries to a syslog host.
+// Entries are queued in a bounded on-disk buffer and sent in the
+// background. The connection is opened when the first entry is sent,
+// and reopened after any failure.
+type Sink struct {
+	config Config
+	queue  *queue.Queue
+
+	mu     sync.Mutex
+	sender Sender
+}
+
+// NewSink returns a new syslog audit sink with the given configuration.
+// Any entries left undelivered by a previous sink using the same buffer
+// will be sent.
+func NewSink(config Config) (*Sink, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &Sink{config: config}
+	q, err := queue.New(queue.Config{
+		Target:        config.RawConfig.Host,
+		Deliver:       s.send,
+		BatchSize:     config.BatchSize,
+		BufferSize:    config.BufferSize,
+		BufferPath:    config.BufferPath,
+		RetryDelay:    config.RetryDelay,
+		MaxRetryDelay: config.MaxRetryDelay,
+		Clock:         config.Clock,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.queue = q
+	return s, nil
+}
+
+// Send is part of the audit.Sink interface. The entry is buffered, and
+// will be sent asynchronously.
+func (s *Sink) Send(entry audit.AuditEntry) error {
+	data, err := audit.SerializeEntry(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.queue.Push(data))
+}
+
+// send sends a batch of serialized entries to the syslog host.
+func (s *Sink) send(batch [][]byte) error {
+	records := make([]logfwd.Record, 0, len(batch))
+	for _, data := range batch {
+		record, err := s.record(data)
+		if err != nil {
+			// The entry can never be sent, so retrying
+			// would block the entries behind it.
+			logger.Errorf("discarding audit entry: %v", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sender == nil {
+		sender, err := s.config.Open(s.config.RawConfig)
+		if err != nil {
+			return errors.Annotate(err, "connecting to syslog host")
+		}
+		s.sender = sender
+	}
+	if err := s.sender.Send(records); err != nil {
+		// Drop the connection so that the entries are resent
+		// over a fresh one.
+		s.sender.Close()
+		s.sender = nil
+		return errors.Annotate(err, "sending audit entries to syslog host")
+	}
+	return nil
+}
+
+func (s *Sink) record(message []byte) (logfwd.Record, error) {
+	entry, err := audit.DeserializeEntry(message)
+	if err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	record := logfwd.Record{
+		Origin: logfwd.OriginForMachineAgent(
+			s.config.AgentTag,
+			s.config.ControllerUUID,
+			entry.ModelUUID,
+			entry.JujuServerVersion,
+		),
+		Timestamp: entry.Timestamp,
+		Level:     loggo.INFO,
+		Message:   string(message),
+	}
+	if err := record.Validate(); err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	return record, nil
+}
+
+// Close is part of the audit.Sink interface. Entries which have not
+// yet been sent remain in the buffer.
+func (s *Sink) Close() error {
+	if err := s.queue.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sender == nil {
+		return nil
+	}
+	err := s.sender.Close()
+	s.sender = nil
+	return errors.Trace(err)
+}
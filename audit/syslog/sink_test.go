@@ -0,0 +1,218 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	auditsyslog "github.com/juju/juju/audit/syslog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type sinkSuite struct {
+	testing.IsolationSuite
+	stub   *testing.Stub
+	calls  chan string
+	clock  *testing.Clock
+	config auditsyslog.Config
+	entry  audit.AuditEntry
+}
+
+var _ = gc.Suite(&sinkSuite{})
+
+func (s *sinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.calls = make(chan string, 10)
+	s.clock = testing.NewClock(time.Time{})
+	s.config = auditsyslog.Config{
+		RawConfig: syslog.RawConfig{
+			Enabled:    true,
+			Host:       "a.b.c:9876",
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		AgentTag:       names.NewMachineTag("0"),
+		Open: func(cfg syslog.RawConfig) (auditsyslog.Sender, error) {
+			s.stub.AddCall("Open", cfg.Host)
+			err := s.stub.NextErr()
+			s.calls <- "Open"
+			if err != nil {
+				return nil, err
+			}
+			return &stubSender{s.stub, s.calls}, nil
+		},
+		BatchSize:     10,
+		BufferSize:    10,
+		BufferPath:    filepath.Join(c.MkDir(), "buffer"),
+		RetryDelay:    time.Second,
+		MaxRetryDelay: time.Minute,
+		Clock:         s.clock,
+	}
+	s.entry = audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "ModelManager.DestroyModels",
+	}
+}
+
+func (s *sinkSuite) TestRegistered(c *gc.C) {
+	c.Assert(audit.RegisteredSinks(), jc.Contains, auditsyslog.SinkName)
+}
+
+func (s *sinkSuite) TestValidate(c *gc.C) {
+	s.config.Open = nil
+	_, err := auditsyslog.NewSink(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Open not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *sinkSuite) newSink(c *gc.C) *auditsyslog.Sink {
+	sink, err := auditsyslog.NewSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return sink
+}
+
+func (s *sinkSuite) waitCalls(c *gc.C, names ...string) {
+	for _, name := range names {
+		select {
+		case call := <-s.calls:
+			c.Assert(call, gc.Equals, name)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+
+func (s *sinkSuite) assertNoCall(c *gc.C) {
+	select {
+	case call := <-s.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *sinkSuite) TestSend(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+	s.assertNoCall(c)
+
+	err := sink.Send(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open", "Send")
+
+	message, err := audit.SerializeEntry(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCall(c, 1, "Send", []logfwd.Record{{
+		Origin: logfwd.OriginForMachineAgent(
+			names.NewMachineTag("0"),
+			coretesting.ControllerTag.Id(),
+			coretesting.ModelTag.Id(),
+			version.MustParse("2.0.1"),
+		),
+		Timestamp: s.entry.Timestamp,
+		Level:     loggo.INFO,
+		Message:   string(message),
+	}})
+
+	err = sink.Send(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Send")
+}
+
+func (s *sinkSuite) TestSendReconnectsAfterFailure(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	s.stub.SetErrors(nil, errors.New("connection reset"))
+	err := sink.Send(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open", "Send", "Close")
+	s.assertNoCall(c)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open", "Send")
+	s.stub.CheckCallNames(c, "Open", "Send", "Close", "Open", "Send")
+	c.Assert(s.stub.Calls()[1].Args, jc.DeepEquals, s.stub.Calls()[4].Args)
+}
+
+func (s *sinkSuite) TestSendOpenError(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	s.stub.SetErrors(errors.New("no route to host"))
+	err := sink.Send(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open")
+	s.assertNoCall(c)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open", "Send")
+}
+
+func (s *sinkSuite) TestUndeliveredEntriesPersist(c *gc.C) {
+	sink := s.newSink(c)
+	s.stub.SetErrors(errors.New("no route to host"))
+	err := sink.Send(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open")
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+
+	sink = s.newSink(c)
+	defer sink.Close()
+	s.waitCalls(c, "Open", "Send")
+}
+
+func (s *sinkSuite) TestClose(c *gc.C) {
+	sink := s.newSink(c)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	s.stub.CheckNoCalls(c)
+
+	sink = s.newSink(c)
+	err := sink.Send(s.entry)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "Open", "Send")
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	s.waitCalls(c, "Close")
+	s.stub.CheckCallNames(c, "Open", "Send", "Close")
+}
+
+type stubSender struct {
+	stub  *testing.Stub
+	calls chan<- string
+}
+
+func (s *stubSender) Send(records []logfwd.Record) error {
+	s.stub.AddCall("Send", records)
+	err := s.stub.NextErr()
+	s.calls <- "Send"
+	return err
+}
+
+func (s *stubSender) Close() error {
+	s.stub.AddCall("Close")
+	err := s.stub.NextErr()
+	s.calls <- "Close"
+	return err
+}
@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package webhook provides an audit sink which posts batches of audit
+// entries, as JSON, to an HTTP endpoint.
+package webhook
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/audit/internal/queue"
+)
+
+// SinkName is the name under which the webhook audit sink is registered.
+const SinkName = "webhook"
+
+const (
+	// bufferFilename is the name of the file, within the agent's
+	// data directory, in which undelivered entries are kept.
+	bufferFilename = "audit-webhook.buffer"
+
+	defaultRetryDelay    = time.Second
+	defaultMaxRetryDelay = 5 * time.Minute
+	defaultHTTPTimeout   = 30 * time.Second
+)
+
+func init() {
+	if err := audit.RegisterSink(SinkName, openSink); err != nil {
+		panic(err)
+	}
+}
+
+// HTTPClient is the subset of *http.Client used by the sink.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Config holds the configuration for a webhook audit sink.
+type Config struct {
+	// URL is the endpoint to which batches of entries are posted.
+	URL string
+
+	// BatchSize is the maximum number of entries posted in a
+	// single request.
+	BatchSize int
+
+	// BufferSize is the maximum number of undelivered entries held
+	// by the sink. Once it is reached, the oldest entries are
+	// discarded.
+	BufferSize int
+
+	// BufferPath is the file in which undelivered entries are kept.
+	BufferPath string
+
+	// RetryDelay is the delay before retrying a failed request. It
+	// doubles after each consecutive failure, up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	// Client is used to make the HTTP requests.
+	Client HTTPClient
+
+	// Clock is used to time retries.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is not valid.
+func (config Config) Validate() error {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL %q", config.URL)
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.BufferSize < config.BatchSize {
+		return errors.NotValidf("BufferSize smaller than BatchSize")
+	}
+	if config.BufferPath == "" {
+		return errors.NotValidf("empty BufferPath")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay smaller than RetryDelay")
+	}
+	if config.Client == nil {
+		return errors.NotValidf("nil Client")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+func openSink(params audit.SinkParams) (audit.Sink, error) {
+	controllerConfig := params.ControllerConfig
+	sink, err := NewSink(Config{
+		URL:           controllerConfig.AuditWebhookURL(),
+		BatchSize:     controllerConfig.AuditWebhookBatchSize(),
+		BufferSize:    controllerConfig.AuditWebhookBufferSize(),
+		BufferPath:    filepath.Join(params.DataDir, bufferFilename),
+		RetryDelay:    defaultRetryDelay,
+		MaxRetryDelay: defaultMaxRetryDelay,
+		Client:        &http.Client{Timeout: defaultHTTPTimeout},
+		Clock:         params.Clock,
+	})
+	return sink, errors.Trace(err)
+}
+
+// Sink is an audit sink which posts batches of audit entries to an
+// HTTP endpoint. Entries are queued in a bounded on-disk buffer, and
+// are removed from it once they have been accepted by the endpoint;
+// failed requests are retried with an exponential backoff.
+type Sink struct {
+	config Config
+	queue  *queue.Queue
+}
+
+// NewSink returns a new webhook audit sink with the given configuration.
+// Any entries left undelivered by a previous sink using the same buffer
+// will be sent.
+func NewSink(config Config) (*Sink, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &Sink{config: config}
+	q, err := queue.New(queue.Config{
+		Target:        config.URL,
+		Deliver:       s.post,
+		BatchSize:     config.BatchSize,
+		BufferSize:    config.BufferSize,
+		BufferPath:    config.BufferPath,
+		RetryDelay:    config.RetryDelay,
+		MaxRetryDelay: config.MaxRetryDelay,
+		Clock:         config.Clock,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.queue = q
+	return s, nil
+}
+
+// Send is part of the audit.Sink interface. The entry is buffered, and
+// will be delivered asynchronously.
+func (s *Sink) Send(entry audit.AuditEntry) error {
+	data, err := audit.SerializeEntry(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.queue.Push(data))
+}
+
+// Close is part of the audit.Sink interface. Entries which have not
+// yet been delivered remain in the buffer.
+func (s *Sink) Close() error {
+	return errors.Trace(s.queue.Close())
+}
+
+// post posts a batch of serialized entries to the endpoint as a JSON
+// array.
+func (s *Sink) post(batch [][]byte) error {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, entry := range batch {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(entry)
+	}
+	body.WriteByte(']')
+
+	req, err := http.NewRequest("POST", s.config.URL, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected response %q", resp.Status)
+	}
+	return nil
+}
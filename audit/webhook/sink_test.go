@@ -0,0 +1,201 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/audit/webhook"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type sinkSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	server   *httptest.Server
+	requests chan []string
+	config   webhook.Config
+
+	mu     sync.Mutex
+	status int
+}
+
+var _ = gc.Suite(&sinkSuite{})
+
+func (s *sinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.status = http.StatusOK
+	s.requests = make(chan []string, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var entries []map[string]interface{}
+		err := json.NewDecoder(req.Body).Decode(&entries)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		operations := make([]string, len(entries))
+		for i, entry := range entries {
+			operations[i], _ = entry["operation"].(string)
+		}
+		s.mu.Lock()
+		status := s.status
+		s.mu.Unlock()
+		w.WriteHeader(status)
+		s.requests <- operations
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.config = webhook.Config{
+		URL:           s.server.URL,
+		BatchSize:     2,
+		BufferSize:    10,
+		BufferPath:    filepath.Join(c.MkDir(), "buffer"),
+		RetryDelay:    time.Second,
+		MaxRetryDelay: time.Minute,
+		Client:        http.DefaultClient,
+		Clock:         s.clock,
+	}
+}
+
+func (s *sinkSuite) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *sinkSuite) entry(operation string) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         operation,
+	}
+}
+
+func (s *sinkSuite) newSink(c *gc.C) *webhook.Sink {
+	sink, err := webhook.NewSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return sink
+}
+
+func (s *sinkSuite) assertRequest(c *gc.C, expected ...string) {
+	select {
+	case operations := <-s.requests:
+		c.Assert(operations, jc.DeepEquals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request")
+	}
+}
+
+func (s *sinkSuite) assertNoRequest(c *gc.C) {
+	select {
+	case operations := <-s.requests:
+		c.Fatalf("unexpected request %v", operations)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// drainRequests discards the requests already received, which must
+// only be called once the sink making them has been closed.
+func (s *sinkSuite) drainRequests() {
+	for {
+		select {
+		case <-s.requests:
+		default:
+			return
+		}
+	}
+}
+
+func (s *sinkSuite) TestRegistered(c *gc.C) {
+	c.Assert(audit.RegisteredSinks(), jc.Contains, webhook.SinkName)
+}
+
+func (s *sinkSuite) TestValidate(c *gc.C) {
+	s.config.BufferSize = 1
+	_, err := webhook.NewSink(s.config)
+	c.Assert(err, gc.ErrorMatches, "BufferSize smaller than BatchSize not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *sinkSuite) TestSend(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	err := sink.Send(s.entry("Client.FullStatus"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRequest(c, "Client.FullStatus")
+}
+
+func (s *sinkSuite) TestRetryBatches(c *gc.C) {
+	s.setStatus(http.StatusServiceUnavailable)
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	err := sink.Send(s.entry("one"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRequest(c, "one")
+
+	err = sink.Send(s.entry("two"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Send(s.entry("three"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoRequest(c)
+
+	s.setStatus(http.StatusOK)
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRequest(c, "one", "two")
+	s.assertRequest(c, "three")
+}
+
+func (s *sinkSuite) TestUndeliveredEntriesPersist(c *gc.C) {
+	s.setStatus(http.StatusInternalServerError)
+	sink := s.newSink(c)
+	err := sink.Send(s.entry("one"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRequest(c, "one")
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setStatus(http.StatusOK)
+	sink = s.newSink(c)
+	defer sink.Close()
+	s.assertRequest(c, "one")
+}
+
+func (s *sinkSuite) TestBufferDiscardsOldest(c *gc.C) {
+	s.config.BatchSize = 1
+	s.config.BufferSize = 2
+	s.setStatus(http.StatusInternalServerError)
+	sink := s.newSink(c)
+
+	for _, operation := range []string{"one", "two", "three"} {
+		err := sink.Send(s.entry(operation))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	s.drainRequests()
+
+	s.setStatus(http.StatusOK)
+	sink = s.newSink(c)
+	defer sink.Close()
+	s.assertRequest(c, "two")
+	s.assertRequest(c, "three")
+	s.assertNoRequest(c)
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -38,8 +40,9 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel attempts to cancel queued up Actions, given by tag, from
+	// running.
+	Cancel(params.Entities) (params.ActionResults, error)
 
 	// ApplicationCharmActions is a single query which uses ApplicationsCharmsActions to
 	// get the charm.Actions for a single Service by tag.
@@ -56,6 +59,20 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// WatchActionResults returns a watcher that notifies of the IDs of
+	// the receiver's Actions as they finish running.
+	WatchActionResults(names.Tag) (watcher.StringsWatcher, error)
+
+	// AddSchedules adds schedules for queueing Actions on all the
+	// units of applications.
+	AddSchedules(params.ActionSchedules) (params.ActionScheduleResults, error)
+
+	// ListSchedules returns all the action schedules in the model.
+	ListSchedules() (params.ActionSchedules, error)
+
+	// RemoveSchedules removes the action schedules with the given ids.
+	RemoveSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
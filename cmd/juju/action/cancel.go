@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewCancelCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{})
+}
+
+// cancelCommand cancels pending Actions by ID.
+type cancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel pending actions matching the given IDs or partial ID prefixes, so
+that they will not be run. Actions which have already started running,
+or which have finished, cannot be cancelled.
+
+Examples:
+
+    juju cancel-action 0be5c5ac
+    juju cancel-action 0be5c5ac 3a4e3d2f
+
+See also:
+    run-action
+    show-action-status
+`
+
+// Set up the output.
+func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+func (c *cancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-action",
+		Args:    "<action ID|action ID prefix> ...",
+		Purpose: "Cancel pending actions.",
+		Doc:     cancelDoc,
+	}
+}
+
+// Init validates the action IDs.
+func (c *cancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+// Run cancels the actions matching the requested IDs.
+func (c *cancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	entities := make([]params.Entity, len(c.requestedIds))
+	for i, id := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+
+	results, err := api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(entities) {
+		return errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+	return c.out.Write(ctx, resultsToMap(results.Results))
+}
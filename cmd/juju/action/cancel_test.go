@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectIds   []string
+		expectError string
+	}{{
+		expectError: "no action ID specified",
+	}, {
+		args:      []string{"deadbeef"},
+		expectIds: []string{"deadbeef"},
+	}, {
+		args:      []string{"deadbeef", "feedface"},
+		expectIds: []string{"deadbeef", "feedface"},
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		command, cancelCmd := action.NewCancelCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := testing.InitCommand(command, args)
+		if t.expectError != "" {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cancelCmd.RequestedIds(), jc.DeepEquals, t.expectIds)
+	}
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	fakeid := "deadbeef-0000-4000-8000-feedfacebeef"
+	fakeid2 := "feedface-0001-4000-8000-feedfacebeef"
+	results := []params.ActionResult{{
+		Action: &params.Action{Tag: "action-" + fakeid, Receiver: "unit-mysql-0"},
+		Status: params.ActionCancelled,
+	}, {
+		Action: &params.Action{Tag: "action-" + fakeid2, Receiver: "unit-mysql-1"},
+		Error:  &params.Error{Message: "action is running"},
+	}}
+	client := &fakeAPIClient{
+		actionTagMatches: params.FindTagsResults{
+			Matches: map[string][]params.Entity{
+				"deadbeef": {{Tag: "action-" + fakeid}},
+				"feedface": {{Tag: "action-" + fakeid2}},
+			},
+		},
+		actionResults: results,
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	command, _ := action.NewCancelCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, command, "-m", "admin", "deadbeef", "feedface")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.cancelledActions, jc.DeepEquals, params.Entities{
+		Entities: []params.Entity{
+			{Tag: "action-" + fakeid},
+			{Tag: "action-" + fakeid2},
+		},
+	})
+
+	out := &bytes.Buffer{}
+	err = cmd.FormatYaml(out, action.ActionResultsToMap(results))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, out.String())
+}
+
+func (s *CancelSuite) TestRunNoMatch(c *gc.C) {
+	client := &fakeAPIClient{}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	command, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, command, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "deadbeef" not found`)
+	c.Assert(client.cancelledActions.Entities, gc.HasLen, 0)
+}
+
+func (s *CancelSuite) TestRunAPIError(c *gc.C) {
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("deadbeef", "action-deadbeef-0000-4000-8000-feedfacebeef"),
+		apiErr:           errors.New("boom"),
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	command, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, command, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"gopkg.in/juju/names.v2"
 
@@ -25,6 +27,14 @@ type StatusCommand struct {
 	*statusCommand
 }
 
+type CancelCommand struct {
+	*cancelCommand
+}
+
+func (c *CancelCommand) RequestedIds() []string {
+	return c.requestedIds
+}
+
 type RunCommand struct {
 	*runCommand
 }
@@ -45,6 +55,14 @@ func (c *RunCommand) ParamsYAML() cmd.FileVar {
 	return c.paramsYAML
 }
 
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *RunCommand) Wait() (bool, time.Duration) {
+	return c.wait.wait, c.wait.timeout
+}
+
 func (c *RunCommand) Args() [][]string {
 	return c.args
 }
@@ -61,6 +79,34 @@ func (c *ListCommand) FullSchema() bool {
 	return c.fullSchema
 }
 
+type ScheduleCommand struct {
+	*scheduleCommand
+}
+
+func (c *ScheduleCommand) ApplicationTag() names.ApplicationTag {
+	return c.applicationTag
+}
+
+func (c *ScheduleCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *ScheduleCommand) Schedule() string {
+	return c.schedule
+}
+
+func (c *ScheduleCommand) Args() [][]string {
+	return c.args
+}
+
+type RemoveScheduleCommand struct {
+	*removeScheduleCommand
+}
+
+func (c *RemoveScheduleCommand) Ids() []string {
+	return c.ids
+}
+
 func NewShowOutputCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ShowOutputCommand) {
 	c := &showOutputCommand{}
 	c.SetClientStore(store)
@@ -73,6 +119,12 @@ func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Status
 	return modelcmd.Wrap(c), &StatusCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &CancelCommand{c}
+}
+
 func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListCommand) {
 	c := &listCommand{}
 	c.SetClientStore(store)
@@ -85,6 +137,24 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ScheduleCommand) {
+	c := &scheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &ScheduleCommand{c}
+}
+
+func NewSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &schedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RemoveScheduleCommand) {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RemoveScheduleCommand{c}
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
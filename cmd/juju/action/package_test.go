@@ -13,12 +13,14 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
 )
 
 const (
@@ -123,10 +125,18 @@ type fakeAPIClient struct {
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
 	enqueuedActions    params.Actions
+	cancelledActions   params.Entities
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	finishedActions    [][]string
+	watchedReceivers   []names.Tag
+	addedSchedules     params.ActionSchedules
+	scheduleResults    []params.ActionScheduleResult
+	schedules          []params.ActionSchedule
+	removedSchedules   params.ActionScheduleIds
+	errorResults       []params.ErrorResult
 	apiErr             error
 }
 
@@ -165,7 +175,8 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelledActions = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -209,3 +220,42 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+// WatchActionResults returns a watcher which delivers the events in
+// finishedActions, and then blocks.
+func (c *fakeAPIClient) WatchActionResults(receiver names.Tag) (watcher.StringsWatcher, error) {
+	c.watchedReceivers = append(c.watchedReceivers, receiver)
+	changes := make(chan []string, len(c.finishedActions))
+	for _, ids := range c.finishedActions {
+		changes <- ids
+	}
+	return &fakeStringsWatcher{changes: changes}, nil
+}
+
+func (c *fakeAPIClient) AddSchedules(args params.ActionSchedules) (params.ActionScheduleResults, error) {
+	c.addedSchedules = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() (params.ActionSchedules, error) {
+	return params.ActionSchedules{Schedules: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+type fakeStringsWatcher struct {
+	changes chan []string
+}
+
+func (w *fakeStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (w *fakeStringsWatcher) Kill() {}
+
+func (w *fakeStringsWatcher) Wait() error {
+	return nil
+}
@@ -6,6 +6,7 @@ package action
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -33,6 +34,8 @@ type runCommand struct {
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
+	wait         waitFlag
 	out          cmd.Output
 	args         [][]string
 }
@@ -54,6 +57,17 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is given, the action is stopped and marked as failed if it is
+still running once the timeout has elapsed.  A queued action which has not
+yet started can be cancelled with 'juju cancel-action <ID>'.
+
+If --wait is given, the command blocks until the action has finished, and
+then shows its results as 'juju show-action-output' would.  The command
+fails with a non-zero exit code if the action did not complete
+successfully.  A maximum time to wait may be given as in --wait=10m; the
+command fails if the action has not finished by then, but the action itself
+is left to run.
+
 Examples:
 
 $ juju run-action mysql/3 backup 
@@ -104,6 +118,17 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/3 backup --timeout 30m
+...
+The backup will fail if it has not finished within 30 minutes.
+
+$ juju run-action mysql/3 backup --wait=1h
+id: <ID>
+results:
+  ...
+status: completed
+...
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -115,6 +140,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "Fail the action if it runs for longer than this")
+	f.Var(&c.wait, "wait", "Wait for the action to finish, optionally with a maximum time to wait, as in --wait=5m")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -128,6 +155,9 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
@@ -149,54 +179,59 @@ func (c *runCommand) Init(args []string) error {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
-		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
-			thisArg := strings.SplitN(arg, "=", 2)
-			if len(thisArg) != 2 {
-				return errors.Errorf("argument %q must be of the form key...=value", arg)
-			}
-			keySlice := strings.Split(thisArg[0], ".")
-			// check each key for validity
-			for _, key := range keySlice {
-				if valid := keyRule.MatchString(key); !valid {
-					return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-				}
-			}
-			// c.args={..., [key, key, key, key, value]}
-			c.args = append(c.args, append(keySlice, thisArg[1]))
-		}
-		return nil
+		var err error
+		c.args, err = parseParamArgs(args[2:])
+		return err
 	}
 }
 
-func (c *runCommand) Run(ctx *cmd.Context) error {
-	api, err := c.NewActionAPIClient()
-	if err != nil {
-		return err
+// parseParamArgs parses CLI args of the form key.key.key...=value into
+// slices of the form [key, key, key, ..., value].
+func parseParamArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := keyRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
 	}
-	defer api.Close()
+	return result, nil
+}
 
+// buildParams returns the action params read from the given params
+// file, if any, overridden by the given parsed key.key.key...=value
+// args. Values in args are parsed as YAML unless parseStrings is set.
+func buildParams(ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
 	actionParams := map[string]interface{}{}
 
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = yaml.Unmarshal(b, &actionParams)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		conformantParams, err := common.ConformYAML(actionParams)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		betterParams, ok := conformantParams.(map[string]interface{})
 		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
+			return nil, errors.New("params must contain a YAML map with string keys")
 		}
 
 		actionParams = betterParams
@@ -204,15 +239,15 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 
 	// If we had explicit args {..., [key, key, key, key, value], ...}
 	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
+	for _, argSlice := range args {
 		valueIndex := len(argSlice) - 1
 		keys := argSlice[:valueIndex]
 		value := argSlice[valueIndex]
 		cleansedValue := interface{}(value)
-		if !c.parseStrings {
+		if !parseStrings {
 			err := yaml.Unmarshal([]byte(value), &cleansedValue)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 		// Insert the value in the map.
@@ -221,12 +256,26 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 
 	conformantParams, err := common.ConformYAML(actionParams)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	typedConformantParams, ok := conformantParams.(map[string]interface{})
 	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
+
+func (c *runCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := buildParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
 	}
 
 	actionParam := params.Actions{
@@ -234,6 +283,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -260,6 +310,97 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
-	output := map[string]string{"Action queued with id": tag.Id()}
-	return c.out.Write(ctx, output)
+	if !c.wait.wait {
+		output := map[string]string{"Action queued with id": tag.Id()}
+		return c.out.Write(ctx, output)
+	}
+
+	result, err = c.waitForResult(api, tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output := FormatActionResult(result)
+	output["id"] = tag.Id()
+	if err := c.out.Write(ctx, output); err != nil {
+		return err
+	}
+	if result.Status != params.ActionCompleted {
+		return errors.Errorf("action %s %s", tag.Id(), result.Status)
+	}
+	return nil
+}
+
+// waitForResult blocks until the action with the given tag has finished
+// running, or until the wait timeout (if any) has elapsed, and returns
+// the action's result.
+func (c *runCommand) waitForResult(api APIClient, tag names.ActionTag) (params.ActionResult, error) {
+	w, err := api.WatchActionResults(c.unitTag)
+	if err != nil {
+		return params.ActionResult{}, errors.Annotatef(err, "cannot watch action %s", tag.Id())
+	}
+	defer w.Kill()
+
+	var timeout <-chan time.Time
+	if c.wait.timeout > 0 {
+		timeout = time.After(c.wait.timeout)
+	}
+	for {
+		select {
+		case ids, ok := <-w.Changes():
+			if !ok {
+				return params.ActionResult{}, errors.Errorf("watcher for action %s stopped: %v", tag.Id(), w.Wait())
+			}
+			for _, id := range ids {
+				if id == tag.Id() {
+					return fetchResult(api, tag.Id())
+				}
+			}
+		case <-timeout:
+			return params.ActionResult{}, errors.Errorf("timed out waiting for action %s", tag.Id())
+		}
+	}
+}
+
+// waitFlag holds the value of the --wait flag, which may be given on
+// its own to wait indefinitely, or with a maximum time to wait.
+type waitFlag struct {
+	wait    bool
+	timeout time.Duration
+}
+
+// IsBoolFlag allows the flag to be given without a value.
+func (f *waitFlag) IsBoolFlag() bool {
+	return true
+}
+
+// Set implements gnuflag.Value.
+func (f *waitFlag) Set(value string) error {
+	switch value {
+	case "true":
+		f.wait, f.timeout = true, 0
+		return nil
+	case "false":
+		f.wait, f.timeout = false, 0
+		return nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return errors.Errorf("invalid wait time %q", value)
+	}
+	if timeout <= 0 {
+		return errors.Errorf("wait time must be positive, got %v", timeout)
+	}
+	f.wait, f.timeout = true, timeout
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (f *waitFlag) String() string {
+	switch {
+	case !f.wait:
+		return "false"
+	case f.timeout == 0:
+		return "true"
+	}
+	return f.timeout.String()
 }
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	jc "github.com/juju/testing/checkers"
@@ -64,6 +65,9 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectParamsYamlPath string
 		expectParseStrings   bool
 		expectKVArgs         [][]string
+		expectTimeout        time.Duration
+		expectWait           bool
+		expectWaitTimeout    time.Duration
 		expectOutput         string
 		expectError          string
 	}{{
@@ -106,6 +110,37 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnit:         names.NewUnitTag(validUnitId),
 		expectAction:       "valid-action-name",
 		expectParseStrings: true,
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout", "5m"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 5 * time.Minute,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout=-5m"},
+		expectError: "timeout must not be negative",
+	}, {
+		should:       "handle --wait",
+		args:         []string{validUnitId, "valid-action-name", "--wait"},
+		expectUnit:   names.NewUnitTag(validUnitId),
+		expectAction: "valid-action-name",
+		expectWait:   true,
+	}, {
+		should:            "handle --wait with a timeout",
+		args:              []string{validUnitId, "valid-action-name", "--wait=5m"},
+		expectUnit:        names.NewUnitTag(validUnitId),
+		expectAction:      "valid-action-name",
+		expectWait:        true,
+		expectWaitTimeout: 5 * time.Minute,
+	}, {
+		should:      "fail with invalid --wait timeout",
+		args:        []string{validUnitId, "valid-action-name", "--wait=soon"},
+		expectError: `.*invalid wait time "soon"`,
+	}, {
+		should:      "fail with non-positive --wait timeout",
+		args:        []string{validUnitId, "valid-action-name", "--wait=0s"},
+		expectError: `.*wait time must be positive, got 0s`,
 	}, {
 		// cf. worker/uniter/runner/jujuc/action-set_test.go per @fwereade
 		should:       "work with multiple '=' signs",
@@ -173,6 +208,10 @@ func (s *RunSuite) TestInit(c *gc.C) {
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
+				c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
+				wait, waitTimeout := command.Wait()
+				c.Check(wait, gc.Equals, t.expectWait)
+				c.Check(waitTimeout, gc.Equals, t.expectWaitTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -399,3 +438,77 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunWait(c *gc.C) {
+	for i, t := range []struct {
+		should       string
+		args         []string
+		finished     [][]string
+		status       string
+		message      string
+		expectErr    string
+		expectOutput map[string]interface{}
+	}{{
+		should:   "show the results of a completed action",
+		args:     []string{"--wait"},
+		finished: [][]string{{"deadbeef-0000-4000-8000-feedfacebeef"}, {validActionId}},
+		status:   params.ActionCompleted,
+		expectOutput: map[string]interface{}{
+			"id":      validActionId,
+			"status":  "completed",
+			"results": map[interface{}]interface{}{"outfile": "foo.bz2"},
+		},
+	}, {
+		should:    "fail for a failed action",
+		args:      []string{"--wait=1h"},
+		finished:  [][]string{{validActionId}},
+		status:    params.ActionFailed,
+		message:   "oops",
+		expectErr: "action " + validActionId + " failed",
+		expectOutput: map[string]interface{}{
+			"id":      validActionId,
+			"status":  "failed",
+			"message": "oops",
+			"results": map[interface{}]interface{}{"outfile": "foo.bz2"},
+		},
+	}, {
+		should:    "time out if the action does not finish",
+		args:      []string{"--wait=10ms"},
+		finished:  [][]string{{"deadbeef-0000-4000-8000-feedfacebeef"}},
+		expectErr: "timed out waiting for action " + validActionId,
+	}} {
+		c.Logf("test %d: should %s", i, t.should)
+		fakeClient := &fakeAPIClient{
+			delay:   time.NewTimer(0),
+			timeout: time.NewTimer(testing.LongWait),
+			actionResults: []params.ActionResult{{
+				Action:  &params.Action{Tag: validActionTagString},
+				Status:  t.status,
+				Message: t.message,
+				Output:  map[string]interface{}{"outfile": "foo.bz2"},
+			}},
+			actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+			finishedActions:  t.finished,
+		}
+		restore := s.patchAPIClient(fakeClient)
+
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+		args := append([]string{"-m", "admin", validUnitId, "some-action"}, t.args...)
+		ctx, err := testing.RunCommand(c, wrappedCommand, args...)
+		restore()
+		if t.expectErr != "" {
+			c.Check(err, gc.ErrorMatches, t.expectErr)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+		}
+		c.Check(fakeClient.watchedReceivers, jc.DeepEquals, []names.Tag{names.NewUnitTag(validUnitId)})
+		if t.expectOutput == nil {
+			c.Check(testing.Stdout(ctx), gc.Equals, "")
+			continue
+		}
+		var output map[string]interface{}
+		err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(output, jc.DeepEquals, t.expectOutput)
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+// scheduleCommand adds a schedule for running an Action on all the
+// units of an application.
+type scheduleCommand struct {
+	ActionCommandBase
+	applicationTag names.ApplicationTag
+	actionName     string
+	schedule       string
+	paramsYAML     cmd.FileVar
+	parseStrings   bool
+	out            cmd.Output
+	args           [][]string
+}
+
+const scheduleDoc = `
+Schedule an Action to be queued for execution on every unit of an
+application at regular times, with a given set of params.  Params are
+given as for 'juju run-action'.
+
+The schedule is either an interval of at least a minute, as in "@every 6h";
+one of "@hourly", "@daily", "@weekly" or "@monthly"; or a cron expression
+with the five fields "minute hour day-of-month month day-of-week".  Times
+are in UTC.
+
+Actions queued by a schedule are shown by 'juju show-action-status', and
+may be selected with its --schedule option.  Schedules can be listed with
+'juju schedules', and removed with 'juju remove-schedule'.
+
+Examples:
+
+    juju schedule-action mysql backup "@daily"
+    juju schedule-action mysql backup "30 2 * * 1-5" out=out.tar.bz2
+    juju schedule-action sleeper pause "@every 90m" --params p.yml
+
+See also:
+    run-action
+    schedules
+    remove-schedule
+    show-action-status
+`
+
+// SetFlags offers an option for YAML output.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+func (c *scheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<application name> <action name> <schedule> [key.key.key...=value]",
+		Purpose: "Queue an action for execution at regular times.",
+		Doc:     scheduleDoc,
+	}
+}
+
+// Init validates the application and action names, and the params.
+func (c *scheduleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no action specified")
+	case 2:
+		return errors.New("no schedule specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	if !ActionNameRule.MatchString(args[1]) {
+		return errors.Errorf("invalid action name %q", args[1])
+	}
+	c.applicationTag = names.NewApplicationTag(args[0])
+	c.actionName = args[1]
+	c.schedule = args[2]
+	var err error
+	c.args, err = parseParamArgs(args[3:])
+	return err
+}
+
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := buildParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
+	}
+	results, err := api.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			ApplicationTag: c.applicationTag.String(),
+			Name:           c.actionName,
+			Parameters:     actionParams,
+			Schedule:       c.schedule,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.Schedule == nil {
+		return errors.New("action failed to schedule")
+	}
+	return c.out.Write(ctx, map[string]string{
+		"Action scheduled with id": result.Schedule.Id,
+		"next run":                 formatScheduleTime(result.Schedule.NextRun),
+	})
+}
+
+func NewSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&schedulesCommand{})
+}
+
+// schedulesCommand lists the action schedules in a model.
+type schedulesCommand struct {
+	ActionCommandBase
+	application string
+	out         cmd.Output
+}
+
+const schedulesDoc = `
+List the schedules on which actions are queued, as added by
+'juju schedule-action'.  The schedules of a single application can be
+shown with --application.
+`
+
+// SetFlags sets up the output and filter flags.
+func (c *schedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+	f.StringVar(&c.application, "application", "", "Only show the schedules of this application")
+}
+
+func (c *schedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "schedules",
+		Purpose: "List action schedules.",
+		Doc:     schedulesDoc,
+		Aliases: []string{"list-schedules"},
+	}
+}
+
+// Init validates the application name filter.
+func (c *schedulesCommand) Init(args []string) error {
+	if c.application != "" && !names.IsValidApplication(c.application) {
+		return errors.Errorf("invalid application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *schedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	result, err := api.ListSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var schedules []params.ActionSchedule
+	for _, schedule := range result.Schedules {
+		if c.application != "" && schedule.ApplicationTag != names.NewApplicationTag(c.application).String() {
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules to display.")
+		return nil
+	}
+	return c.out.Write(ctx, schedulesToMap(schedules))
+}
+
+// scheduleOutput holds the details of an action schedule for output.
+type scheduleOutput struct {
+	Application string                 `yaml:"application" json:"application"`
+	Action      string                 `yaml:"action" json:"action"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule    string                 `yaml:"schedule" json:"schedule"`
+	NextRun     string                 `yaml:"next-run" json:"next-run"`
+	LastRun     string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+}
+
+// schedulesToMap converts action schedules for output, keyed by id.
+func schedulesToMap(schedules []params.ActionSchedule) map[string]scheduleOutput {
+	result := make(map[string]scheduleOutput)
+	for _, schedule := range schedules {
+		application := schedule.ApplicationTag
+		if tag, err := names.ParseApplicationTag(schedule.ApplicationTag); err == nil {
+			application = tag.Id()
+		}
+		result[schedule.Id] = scheduleOutput{
+			Application: application,
+			Action:      schedule.Name,
+			Parameters:  schedule.Parameters,
+			Schedule:    schedule.Schedule,
+			NextRun:     formatScheduleTime(schedule.NextRun),
+			LastRun:     formatScheduleTime(schedule.LastRun),
+		}
+	}
+	return result
+}
+
+// formatScheduleTime formats a schedule time for output, or returns the
+// empty string for the zero time.
+func formatScheduleTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// printSchedulesTabular prints action schedules in tabular format.
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	var ids []string
+	for id := range schedules {
+		ids = append(ids, id)
+	}
+	utils.SortStringsNaturally(ids)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", "Id", "Application", "Action", "Schedule", "Next run", "Last run")
+	for _, id := range ids {
+		s := schedules[id]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", id, s.Application, s.Action, s.Schedule, s.NextRun, s.LastRun)
+	}
+	tw.Flush()
+	return nil
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules by id.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const removeScheduleDoc = `
+Remove the action schedules with the given ids, as shown by
+'juju schedules'.  Actions which the schedules have already queued are
+not affected.
+
+Examples:
+
+    juju remove-schedule 3
+    juju remove-schedule 3 4
+`
+
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-schedule",
+		Args:    "<schedule id> ...",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+// Init validates the schedule ids.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule id specified")
+	}
+	c.ids = args
+	return nil
+}
+
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSchedules(params.ActionScheduleIds{Ids: c.ids})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(c.ids) {
+		return errors.Errorf("expected %d results, got %d", len(c.ids), len(results.Results))
+	}
+	var failed bool
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot remove schedule %s: %v", c.ids[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args           []string
+		expectApp      string
+		expectAction   string
+		expectSchedule string
+		expectArgs     [][]string
+		expectError    string
+	}{{
+		expectError: "no application name specified",
+	}, {
+		args:        []string{"mysql"},
+		expectError: "no action specified",
+	}, {
+		args:        []string{"mysql", "backup"},
+		expectError: "no schedule specified",
+	}, {
+		args:        []string{invalidServiceId, "backup", "@daily"},
+		expectError: `invalid application name "something-strange-"`,
+	}, {
+		args:        []string{"mysql", "Backup", "@daily"},
+		expectError: `invalid action name "Backup"`,
+	}, {
+		args:        []string{"mysql", "backup", "@daily", "out"},
+		expectError: `argument "out" must be of the form key...=value`,
+	}, {
+		args:           []string{"mysql", "backup", "@daily"},
+		expectApp:      "mysql",
+		expectAction:   "backup",
+		expectSchedule: "@daily",
+		expectArgs:     [][]string{},
+	}, {
+		args:           []string{"mysql", "backup", "30 2 * * 1-5", "out=out.tar.bz2", "file.kind=xz"},
+		expectApp:      "mysql",
+		expectAction:   "backup",
+		expectSchedule: "30 2 * * 1-5",
+		expectArgs:     [][]string{{"out", "out.tar.bz2"}, {"file", "kind", "xz"}},
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		command, scheduleCmd := action.NewScheduleCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := testing.InitCommand(command, args)
+		if t.expectError != "" {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(scheduleCmd.ApplicationTag().Id(), gc.Equals, t.expectApp)
+		c.Check(scheduleCmd.ActionName(), gc.Equals, t.expectAction)
+		c.Check(scheduleCmd.Schedule(), gc.Equals, t.expectSchedule)
+		c.Check(scheduleCmd.Args(), jc.DeepEquals, t.expectArgs)
+	}
+}
+
+func (s *ScheduleSuite) TestRun(c *gc.C) {
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{
+				Id:      "3",
+				NextRun: time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC),
+			},
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	command, _ := action.NewScheduleCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, command, "-m", "admin", "mysql", "backup", "@daily", "out=out.tar.bz2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			ApplicationTag: "application-mysql",
+			Name:           "backup",
+			Parameters:     map[string]interface{}{"out": "out.tar.bz2"},
+			Schedule:       "@daily",
+		}},
+	})
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+Action scheduled with id: "3"
+next run: 2016-11-11T00:00:00Z
+`[1:])
+}
+
+func (s *ScheduleSuite) TestRunError(c *gc.C) {
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: `schedule "whenever" not valid`},
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	command, _ := action.NewScheduleCommandForTest(s.store)
+	_, err := testing.RunCommand(c, command, "-m", "admin", "mysql", "backup", "whenever")
+	c.Assert(err, gc.ErrorMatches, `schedule "whenever" not valid`)
+}
+
+func (s *ScheduleSuite) TestSchedules(c *gc.C) {
+	client := &fakeAPIClient{
+		schedules: []params.ActionSchedule{{
+			Id:             "1",
+			ApplicationTag: "application-mysql",
+			Name:           "backup",
+			Schedule:       "@daily",
+			NextRun:        time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC),
+			LastRun:        time.Date(2016, 11, 10, 0, 0, 0, 0, time.UTC),
+		}, {
+			Id:             "2",
+			ApplicationTag: "application-wordpress",
+			Name:           "snapshot",
+			Schedule:       "@every 6h",
+			NextRun:        time.Date(2016, 11, 10, 15, 30, 0, 0, time.UTC),
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+Id  Application  Action    Schedule   Next run              Last run
+1   mysql        backup    @daily     2016-11-11T00:00:00Z  2016-11-10T00:00:00Z
+2   wordpress    snapshot  @every 6h  2016-11-10T15:30:00Z  
+`[1:])
+
+	ctx, err = testing.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin", "--application", "wordpress", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+"2":
+  application: wordpress
+  action: snapshot
+  schedule: '@every 6h'
+  next-run: 2016-11-10T15:30:00Z
+`[1:])
+}
+
+func (s *ScheduleSuite) TestSchedulesNone(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, "No action schedules to display.\n")
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleInit(c *gc.C) {
+	command, removeCmd := action.NewRemoveScheduleCommandForTest(s.store)
+	err := testing.InitCommand(command, []string{"-m", "admin"})
+	c.Check(err, gc.ErrorMatches, "no schedule id specified")
+
+	command, removeCmd = action.NewRemoveScheduleCommandForTest(s.store)
+	err = testing.InitCommand(command, []string{"-m", "admin", "3", "4"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(removeCmd.Ids(), jc.DeepEquals, []string{"3", "4"})
+}
+
+func (s *ScheduleSuite) TestRemoveSchedule(c *gc.C) {
+	client := &fakeAPIClient{
+		errorResults: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `action schedule "4" not found`}},
+		},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	command, _ := action.NewRemoveScheduleCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, command, "-m", "admin", "3", "4")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(client.removedSchedules, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"3", "4"}})
+	c.Check(testing.Stderr(ctx), gc.Equals, `cannot remove schedule 4: action schedule "4" not found`+"\n")
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleAPIError(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{apiErr: errors.New("boom")})
+	defer restore()
+
+	command, _ := action.NewRemoveScheduleCommandForTest(s.store)
+	_, err := testing.RunCommand(c, command, "-m", "admin", "3")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -24,11 +24,13 @@ type statusCommand struct {
 	out         cmd.Output
 	requestedId string
 	name        string
+	schedule    string
 }
 
 const statusDoc = `
 Show the status of Actions matching given ID, partial ID prefix, or all Actions if no ID is supplied.
 If --name <name> is provided the search will be done by name rather than by ID.
+If --schedule <id> is provided only Actions queued by that schedule are shown.
 `
 
 // Set up the output.
@@ -36,6 +38,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.name, "name", "", "Action name")
+	f.StringVar(&c.schedule, "schedule", "", "Only show actions queued by the action schedule with this id")
 }
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -72,7 +75,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		return c.out.Write(ctx, resultsToMap(actions))
+		return c.out.Write(ctx, resultsToMap(c.filterSchedule(actions)))
 	}
 
 	actionTags, err := getActionTagsByPrefix(api, c.requestedId)
@@ -102,7 +105,22 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("identifier %q matched action(s) %v, but found no results", c.requestedId, actionTags)
 	}
 
-	return c.out.Write(ctx, resultsToMap(actions.Results))
+	return c.out.Write(ctx, resultsToMap(c.filterSchedule(actions.Results)))
+}
+
+// filterSchedule returns the results for Actions queued by the requested
+// action schedule, or all the results if no schedule was requested.
+func (c *statusCommand) filterSchedule(results []params.ActionResult) []params.ActionResult {
+	if c.schedule == "" {
+		return results
+	}
+	filtered := []params.ActionResult{}
+	for _, result := range results {
+		if result.Action != nil && result.Action.Schedule == c.schedule {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
 }
 
 // resultsToMap is a helper function that takes in a []params.ActionResult
@@ -136,6 +154,9 @@ func resultToMap(result params.ActionResult) map[string]interface{} {
 			item["unit"] = rtag.Id()
 		}
 
+		if result.Action.Schedule != "" {
+			item["schedule"] = result.Action.Schedule
+		}
 	}
 	item["status"] = result.Status
 	return item
@@ -90,6 +90,39 @@ func (s *StatusSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *StatusSuite) TestRunSchedule(c *gc.C) {
+	fakeid := "deadbeef-0000-4000-8000-feedfacebeef"
+	fakeid2 := "deadbeef-0001-4000-8000-feedfacebeef"
+	results := []params.ActionResult{{
+		Action: &params.Action{Tag: "action-" + fakeid, Receiver: "unit-mysql-0", Schedule: "3"},
+		Status: params.ActionCompleted,
+	}, {
+		Action: &params.Action{Tag: "action-" + fakeid2, Receiver: "unit-mysql-0"},
+		Status: params.ActionCompleted,
+	}}
+	fakeClient := makeFakeClient(
+		0*time.Second, // No API delay
+		5*time.Second, // 5 second test timeout
+		tagsForIdPrefix("", "action-"+fakeid, "action-"+fakeid2),
+		results,
+		params.ActionsByNames{},
+		"", // No API error
+	)
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	s.subcommand, _ = action.NewStatusCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, s.subcommand, "-m", "admin", "--schedule", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+actions:
+- id: `+fakeid+`
+  schedule: "3"
+  status: completed
+  unit: mysql/0
+`[1:])
+}
+
 func (s *StatusSuite) runTestCase(c *gc.C, tc statusTestCase) {
 	for _, modelFlag := range s.modelFlags {
 		fakeClient := makeFakeClient(
@@ -4,16 +4,33 @@
 package application
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/applicationoffers"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
 )
 
+var usageAddRelationDetails = `
+Either application may be given as the URL of an application offered by
+another model, in which case the offer is first consumed into this model.
+
+Examples:
+    juju add-relation wordpress mysql
+    juju add-relation wordpress:db admin/prod.db
+
+See also:
+    consume
+    offer
+    remove-relation`[1:]
+
 // NewAddRelationCommand returns a command to add a relation between 2 services.
 func NewAddRelationCommand() cmd.Command {
 	cmd := &addRelationCommand{}
@@ -25,14 +42,22 @@ func NewAddRelationCommand() cmd.Command {
 		return application.NewClient(root), nil
 
 	}
+	cmd.newConsumeAPIFunc = func() (ApplicationConsumeAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return applicationoffers.NewClient(root), nil
+	}
 	return modelcmd.Wrap(cmd)
 }
 
 // addRelationCommand adds a relation between two application endpoints.
 type addRelationCommand struct {
 	modelcmd.ModelCommandBase
-	Endpoints  []string
-	newAPIFunc func() (ApplicationAddRelationAPI, error)
+	Endpoints         []string
+	newAPIFunc        func() (ApplicationAddRelationAPI, error)
+	newConsumeAPIFunc func() (ApplicationConsumeAPI, error)
 }
 
 func (c *addRelationCommand) Info() *cmd.Info {
@@ -41,6 +66,7 @@ func (c *addRelationCommand) Info() *cmd.Info {
 		Aliases: []string{"relate"},
 		Args:    "<application1>[:<relation name1>] <application2>[:<relation name2>]",
 		Purpose: "Add a relation between two applications.",
+		Doc:     usageAddRelationDetails,
 	}
 }
 
@@ -59,14 +85,53 @@ type ApplicationAddRelationAPI interface {
 }
 
 func (c *addRelationCommand) Run(ctx *cmd.Context) error {
+	endpoints, err := c.consumeOffers()
+	if params.IsCodeUnauthorized(err) {
+		common.PermissionsMessage(ctx.Stderr, "consume an application offer")
+	}
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	client, err := c.newAPIFunc()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
-	_, err = client.AddRelation(c.Endpoints...)
+	_, err = client.AddRelation(endpoints...)
 	if params.IsCodeUnauthorized(err) {
 		common.PermissionsMessage(ctx.Stderr, "add a relation")
 	}
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
+
+// consumeOffers consumes any application offers given by URL in place
+// of an application, and returns the endpoints with each such URL
+// replaced by the name of the consumed remote application.
+func (c *addRelationCommand) consumeOffers() ([]string, error) {
+	endpoints := make([]string, len(c.Endpoints))
+	var client ApplicationConsumeAPI
+	for i, endpoint := range c.Endpoints {
+		endpoints[i] = endpoint
+		if !crossmodel.IsApplicationURL(endpoint) {
+			continue
+		}
+		url, relationName := endpoint, ""
+		if colon := strings.Index(endpoint, ":"); colon != -1 {
+			url, relationName = endpoint[:colon], endpoint[colon:]
+		}
+		if client == nil {
+			var err error
+			client, err = c.newConsumeAPIFunc()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			defer client.Close()
+		}
+		localName, err := client.Consume(url, "")
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot consume %q", url)
+		}
+		endpoints[i] = localName + relationName
+	}
+	return endpoints, nil
+}
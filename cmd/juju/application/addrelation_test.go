@@ -34,7 +34,7 @@ func (s *AddRelationSuite) SetUpTest(c *gc.C) {
 var _ = gc.Suite(&AddRelationSuite{})
 
 func (s *AddRelationSuite) runAddRelation(c *gc.C, args ...string) error {
-	_, err := coretesting.RunCommand(c, NewAddRelationCommandForTest(s.mockAPI), args...)
+	_, err := coretesting.RunCommand(c, NewAddRelationCommandForTest(s.mockAPI, s.mockAPI), args...)
 	return err
 }
 
@@ -76,12 +76,30 @@ func (s *AddRelationSuite) TestAddRelationBlocked(c *gc.C) {
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *AddRelationSuite) TestAddRelationConsumesOffer(c *gc.C) {
+	err := s.runAddRelation(c, "wordpress:db", "admin/prod.db:server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"Consume", []interface{}{"admin/prod.db", ""}},
+		{"Close", nil},
+		{"AddRelation", []interface{}{[]string{"wordpress:db", "db:server"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AddRelationSuite) TestAddRelationConsumeFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runAddRelation(c, "wordpress", "admin/prod.db")
+	c.Assert(err, gc.ErrorMatches, `cannot consume "admin/prod.db": boom`)
+	s.mockAPI.CheckCallNames(c, "Consume", "Close")
+}
+
 func (s *AddRelationSuite) TestAddRelationUnauthorizedMentionsJujuGrant(c *gc.C) {
 	s.mockAPI.SetErrors(&params.Error{
 		Message: "permission denied",
 		Code:    params.CodeUnauthorized,
 	})
-	ctx, _ := coretesting.RunCommand(c, NewAddRelationCommandForTest(s.mockAPI), "application1", "application2")
+	ctx, _ := coretesting.RunCommand(c, NewAddRelationCommandForTest(s.mockAPI, s.mockAPI), "application1", "application2")
 	errString := strings.Replace(coretesting.Stderr(ctx), "\n", " ", -1)
 	c.Assert(errString, gc.Matches, `.*juju grant.*`)
 }
@@ -96,6 +114,14 @@ func (s mockAddAPI) Close() error {
 	return s.NextErr()
 }
 
+func (s mockAddAPI) Consume(url, alias string) (string, error) {
+	s.MethodCall(s, "Consume", url, alias)
+	if err := s.NextErr(); err != nil {
+		return "", err
+	}
+	return url[strings.LastIndex(url, ".")+1:], nil
+}
+
 func (s mockAddAPI) AddRelation(endpoints ...string) (*params.AddRelationResults, error) {
 	s.MethodCall(s, "AddRelation", endpoints)
 	return s.addRelationFunc(endpoints...)
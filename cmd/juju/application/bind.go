@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Binds application endpoints to spaces.`[1:]
+
+var usageBindDetails = `
+Changes the spaces that the given endpoints of a deployed application are
+bound to, leaving the bindings of any other endpoints unchanged. Each space
+must already exist in the model.
+
+The application's units are notified of the change and run their
+config-changed hook, so that they may use network-get to discover their
+new addresses.
+
+Examples:
+    juju bind mysql db=internal
+    juju bind wordpress db=internal website=public
+
+See also:
+    deploy
+    spaces`[1:]
+
+// NewBindCommand returns a command to bind application endpoints
+// to spaces.
+func NewBindCommand() cmd.Command {
+	cmd := &bindCommand{}
+	cmd.newAPIFunc = func() (ApplicationBindAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// bindCommand changes the endpoint bindings of an application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Bindings        map[string]string
+	newAPIFunc      func() (ApplicationBindAPI, error)
+}
+
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application name> <endpoint>=<space> [...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName = args[0]
+	if !names.IsValidApplication(c.ApplicationName) {
+		return errors.NotValidf("application name %q", c.ApplicationName)
+	}
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	c.Bindings = make(map[string]string)
+	for _, arg := range args[1:] {
+		parts := strings.Split(arg, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("binding %q not valid, expected <endpoint>=<space>", arg)
+		}
+		endpoint, space := parts[0], parts[1]
+		if !names.IsValidSpace(space) {
+			return errors.NotValidf("space name %q", space)
+		}
+		if _, ok := c.Bindings[endpoint]; ok {
+			return errors.Errorf("endpoint %q bound more than once", endpoint)
+		}
+		c.Bindings[endpoint] = space
+	}
+	return nil
+}
+
+// ApplicationBindAPI defines the API methods that the bind command uses.
+type ApplicationBindAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetEndpointBindings(application string, bindings map[string]string) error
+}
+
+func (c *bindCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 3 {
+		return errors.New("binding endpoints of deployed applications is not supported by this controller")
+	}
+	err = client.SetEndpointBindings(c.ApplicationName, c.Bindings)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeBindAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+type fakeBindAPI struct {
+	jujutesting.Stub
+	version int
+}
+
+func (f *fakeBindAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeBindAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeBindAPI) SetEndpointBindings(application string, bindings map[string]string) error {
+	f.MethodCall(f, "SetEndpointBindings", application, bindings)
+	return f.NextErr()
+}
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeBindAPI{version: 3}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/default", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/default"
+}
+
+func (s *BindSuite) runBind(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &bindCommand{
+		newAPIFunc: func() (ApplicationBindAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"my_sql", "db=internal"},
+		err:  `application name "my_sql" not valid`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql", "internal"},
+		err:  `binding "internal" not valid, expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "=internal"},
+		err:  `binding "=internal" not valid, expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "db=internal=public"},
+		err:  `binding "db=internal=public" not valid, expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "db=Internal"},
+		err:  `space name "Internal" not valid`,
+	}, {
+		args: []string{"mysql", "db=internal", "db=public"},
+		err:  `endpoint "db" bound more than once`,
+	}} {
+		_, err := s.runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := s.runBind(c, "mysql", "db=internal", "cluster=replication")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEndpointBindings", []interface{}{"mysql", map[string]string{
+			"db":      "internal",
+			"cluster": "replication",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *BindSuite) TestBindError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runBind(c, "mysql", "db=internal")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "SetEndpointBindings", "Close")
+}
+
+func (s *BindSuite) TestBindNotSupported(c *gc.C) {
+	s.api.version = 2
+	_, err := s.runBind(c, "mysql", "db=internal")
+	c.Assert(err, gc.ErrorMatches, "binding endpoints of deployed applications is not supported by this controller")
+	s.api.CheckCallNames(c, "Close")
+}
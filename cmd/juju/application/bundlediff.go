@@ -0,0 +1,419 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+const (
+	// missingFromBundle is reported for entities which exist in the
+	// model but are not described by the bundle.
+	missingFromBundle = "bundle"
+
+	// missingFromModel is reported for entities which are described
+	// by the bundle but do not exist in the model.
+	missingFromModel = "model"
+)
+
+// bundleDiff describes the differences between a bundle and a model.
+// Machines are keyed by their id in the bundle; ModelMachines lists the
+// model machines which do not correspond to any machine in the bundle.
+type bundleDiff struct {
+	Applications  map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines      map[string]*machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	ModelMachines []string                    `yaml:"model-machines,omitempty" json:"model-machines,omitempty"`
+	Series        *stringDiff                 `yaml:"series,omitempty" json:"series,omitempty"`
+	Relations     *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// Empty returns whether the bundle and model are equivalent.
+func (d *bundleDiff) Empty() bool {
+	return len(d.Applications) == 0 &&
+		len(d.Machines) == 0 &&
+		len(d.ModelMachines) == 0 &&
+		d.Series == nil &&
+		d.Relations == nil
+}
+
+// applicationDiff describes the differences between an application in
+// the bundle and the same application in the model.
+type applicationDiff struct {
+	Missing     string                 `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *stringDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series      *stringDiff            `yaml:"series,omitempty" json:"series,omitempty"`
+	NumUnits    *intDiff               `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Placement   *stringsDiff           `yaml:"to,omitempty" json:"to,omitempty"`
+	Expose      *boolDiff              `yaml:"expose,omitempty" json:"expose,omitempty"`
+	Constraints *stringDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Options     map[string]*optionDiff `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		d.Series == nil &&
+		d.NumUnits == nil &&
+		d.Placement == nil &&
+		d.Expose == nil &&
+		d.Constraints == nil &&
+		len(d.Options) == 0
+}
+
+// machineDiff describes the differences between a machine in the bundle
+// and the model machine which hosts the units placed on it.
+type machineDiff struct {
+	Missing      string      `yaml:"missing,omitempty" json:"missing,omitempty"`
+	ModelMachine string      `yaml:"model-machine,omitempty" json:"model-machine,omitempty"`
+	Series       *stringDiff `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints  *stringDiff `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+func (d *machineDiff) empty() bool {
+	return d.Missing == "" && d.Series == nil && d.Constraints == nil
+}
+
+// relationsDiff holds the relations which only appear on one side.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+type stringsDiff struct {
+	Bundle []string `yaml:"bundle" json:"bundle"`
+	Model  []string `yaml:"model" json:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+func diffStrings(bundle, model string) *stringDiff {
+	if bundle == model {
+		return nil
+	}
+	return &stringDiff{Bundle: bundle, Model: model}
+}
+
+// diffBundle compares the bundle data read from a file against the bundle
+// data exported from the model. Machine ids in a bundle are unrelated to
+// those in the model, so each bundle machine is compared with the model
+// machine which hosts the units the bundle places on it.
+func diffBundle(bundle, model *charm.BundleData) *bundleDiff {
+	result := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+		Machines:     make(map[string]*machineDiff),
+	}
+	if bundle.Series != "" {
+		result.Series = diffStrings(bundle.Series, model.Series)
+	}
+
+	machines := mapBundleMachines(bundle, model)
+	for name, bundleApp := range bundle.Applications {
+		modelApp, found := model.Applications[name]
+		if !found {
+			result.Applications[name] = &applicationDiff{Missing: missingFromModel}
+			continue
+		}
+		diff := diffApplication(bundleApp, modelApp, bundle.Series)
+		if len(bundleApp.To) > 0 && !placementsMatch(bundle, model, name, machines.modelIds) {
+			diff.Placement = &stringsDiff{Bundle: bundleApp.To, Model: modelApp.To}
+		}
+		if !diff.empty() {
+			result.Applications[name] = diff
+		}
+	}
+	for name := range model.Applications {
+		if _, found := bundle.Applications[name]; !found {
+			result.Applications[name] = &applicationDiff{Missing: missingFromBundle}
+		}
+	}
+
+	for id, bundleMachine := range bundle.Machines {
+		modelId, found := machines.modelIds[id]
+		if !found {
+			result.Machines[id] = &machineDiff{Missing: missingFromModel}
+			continue
+		}
+		modelMachine := model.Machines[modelId]
+		if modelMachine == nil {
+			modelMachine = &charm.MachineSpec{}
+		}
+		if bundleMachine == nil {
+			bundleMachine = &charm.MachineSpec{}
+		}
+		diff := &machineDiff{
+			Constraints: diffStrings(bundleMachine.Constraints, modelMachine.Constraints),
+		}
+		if bundleMachine.Series != "" {
+			diff.Series = diffStrings(bundleMachine.Series, modelMachine.Series)
+		}
+		if !diff.empty() {
+			if modelId != id {
+				diff.ModelMachine = modelId
+			}
+			result.Machines[id] = diff
+		}
+	}
+	for id := range model.Machines {
+		if !machines.accounted[id] {
+			result.ModelMachines = append(result.ModelMachines, id)
+		}
+	}
+	sort.Strings(result.ModelMachines)
+
+	result.Relations = diffRelations(bundle.Relations, model.Relations)
+	return result
+}
+
+// unitLocation records where a unit is placed: the top level machine
+// (or "new" for a new machine), and the type of the container it is
+// in, if any.
+type unitLocation struct {
+	machine   string
+	container string
+}
+
+// maxPlacementDepth limits how many unit placements are followed to
+// locate a unit, to guard against placement cycles.
+const maxPlacementDepth = 10
+
+// locateUnit returns where the numbered unit of the named application is
+// placed by the bundle data. It returns false if the placement cannot be
+// determined.
+func locateUnit(data *charm.BundleData, application string, unit, depth int) (unitLocation, bool) {
+	spec, ok := data.Applications[application]
+	if !ok || spec == nil || unit < 0 || unit >= spec.NumUnits || depth > maxPlacementDepth {
+		return unitLocation{}, false
+	}
+	// Units beyond the placement directives given reuse the last
+	// directive; with no directives, units go on new machines.
+	if len(spec.To) == 0 {
+		return unitLocation{machine: "new"}, true
+	}
+	directive := spec.To[len(spec.To)-1]
+	if unit < len(spec.To) {
+		directive = spec.To[unit]
+	}
+	placement, err := charm.ParsePlacement(directive)
+	if err != nil {
+		return unitLocation{}, false
+	}
+	if placement.Application == "" {
+		return unitLocation{machine: placement.Machine, container: placement.ContainerType}, true
+	}
+	if placement.Unit < 0 {
+		return unitLocation{}, false
+	}
+	location, ok := locateUnit(data, placement.Application, placement.Unit, depth+1)
+	if ok && placement.ContainerType != "" {
+		location.container = placement.ContainerType
+	}
+	return location, ok
+}
+
+// machineMapping relates the machines of a bundle to those of a model.
+type machineMapping struct {
+	// modelIds maps bundle machine ids to model machine ids.
+	modelIds map[string]string
+
+	// accounted holds the ids of the model machines which host
+	// units that the bundle places on one of its machines, or on a
+	// new machine.
+	accounted map[string]bool
+}
+
+// mapBundleMachines maps each bundle machine to the model machine which
+// hosts the first unit the bundle places on it. Units of an application
+// are matched in order of their number in the bundle and in the model.
+func mapBundleMachines(bundle, model *charm.BundleData) machineMapping {
+	mapping := machineMapping{
+		modelIds:  make(map[string]string),
+		accounted: make(map[string]bool),
+	}
+	var names []string
+	for name := range bundle.Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bundleApp, modelApp := bundle.Applications[name], model.Applications[name]
+		if bundleApp == nil || modelApp == nil {
+			continue
+		}
+		for i := 0; i < bundleApp.NumUnits && i < modelApp.NumUnits; i++ {
+			bundleLocation, ok := locateUnit(bundle, name, i, 0)
+			if !ok {
+				continue
+			}
+			modelLocation, ok := locateUnit(model, name, i, 0)
+			if !ok {
+				continue
+			}
+			if bundleLocation.machine == "new" {
+				mapping.accounted[modelLocation.machine] = true
+				continue
+			}
+			if _, ok := mapping.modelIds[bundleLocation.machine]; !ok {
+				mapping.modelIds[bundleLocation.machine] = modelLocation.machine
+				mapping.accounted[modelLocation.machine] = true
+			}
+		}
+	}
+	return mapping
+}
+
+// placementsMatch reports whether the units of the named application
+// are placed in the model as the bundle describes, once bundle machine
+// ids are mapped to model machine ids.
+func placementsMatch(bundle, model *charm.BundleData, application string, modelIds map[string]string) bool {
+	bundleApp, modelApp := bundle.Applications[application], model.Applications[application]
+	for i := 0; i < bundleApp.NumUnits && i < modelApp.NumUnits; i++ {
+		bundleLocation, ok := locateUnit(bundle, application, i, 0)
+		if !ok {
+			continue
+		}
+		modelLocation, ok := locateUnit(model, application, i, 0)
+		if !ok {
+			return false
+		}
+		if bundleLocation.container != modelLocation.container {
+			return false
+		}
+		if bundleLocation.machine != "new" && modelIds[bundleLocation.machine] != modelLocation.machine {
+			return false
+		}
+	}
+	return true
+}
+
+func diffApplication(bundle, model *charm.ApplicationSpec, defaultSeries string) *applicationDiff {
+	result := &applicationDiff{
+		Charm:       diffStrings(bundle.Charm, model.Charm),
+		Constraints: diffStrings(bundle.Constraints, model.Constraints),
+	}
+	// The series of an application in the bundle falls back on the
+	// bundle's default series, or the one implied by the charm URL.
+	series := bundle.Series
+	if series == "" {
+		series = defaultSeries
+	}
+	if series == "" {
+		if curl, err := charm.ParseURL(bundle.Charm); err == nil {
+			series = curl.Series
+		}
+	}
+	if series != "" {
+		result.Series = diffStrings(series, model.Series)
+	}
+	if bundle.NumUnits != model.NumUnits {
+		result.NumUnits = &intDiff{Bundle: bundle.NumUnits, Model: model.NumUnits}
+	}
+	if bundle.Expose != model.Expose {
+		result.Expose = &boolDiff{Bundle: bundle.Expose, Model: model.Expose}
+	}
+
+	options := make(map[string]*optionDiff)
+	for key, bundleValue := range bundle.Options {
+		modelValue, found := model.Options[key]
+		if !found || !reflect.DeepEqual(bundleValue, modelValue) {
+			options[key] = &optionDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range model.Options {
+		if _, found := bundle.Options[key]; !found {
+			options[key] = &optionDiff{Model: modelValue}
+		}
+	}
+	if len(options) > 0 {
+		result.Options = options
+	}
+	return result
+}
+
+// diffRelations compares the relations in the bundle and model. Bundle
+// relations may omit the endpoint name, in which case any endpoint of
+// the application is considered a match.
+func diffRelations(bundle, model [][]string) *relationsDiff {
+	var result relationsDiff
+	for _, relation := range bundle {
+		if !containsRelation(model, relation) {
+			result.BundleAdditions = append(result.BundleAdditions, relation)
+		}
+	}
+	for _, relation := range model {
+		if !containsRelation(bundle, relation) {
+			result.ModelAdditions = append(result.ModelAdditions, relation)
+		}
+	}
+	if len(result.BundleAdditions) == 0 && len(result.ModelAdditions) == 0 {
+		return nil
+	}
+	sort.Sort(relationsByEndpoints(result.BundleAdditions))
+	sort.Sort(relationsByEndpoints(result.ModelAdditions))
+	return &result
+}
+
+func containsRelation(relations [][]string, relation []string) bool {
+	if len(relation) != 2 {
+		return false
+	}
+	for _, candidate := range relations {
+		if len(candidate) != 2 {
+			continue
+		}
+		if endpointsMatch(candidate[0], relation[0]) && endpointsMatch(candidate[1], relation[1]) {
+			return true
+		}
+		if endpointsMatch(candidate[0], relation[1]) && endpointsMatch(candidate[1], relation[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func endpointsMatch(a, b string) bool {
+	appA, relA := splitEndpoint(a)
+	appB, relB := splitEndpoint(b)
+	if appA != appB {
+		return false
+	}
+	return relA == "" || relB == "" || relA == relB
+}
+
+func splitEndpoint(endpoint string) (string, string) {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+var usageConsumeSummary = `
+Adds a remote application to the model from an offer in another model.`[1:]
+
+var usageConsumeDetails = `
+A remote application stands in for the offered application, so that
+applications in this model may be related to the offered endpoints. The
+remote application is named after the offer unless an alias is given.
+
+Offers may also be consumed implicitly, by passing an offer URL to
+add-relation.
+
+Examples:
+    juju consume admin/prod.db
+    juju consume admin/prod.db proddb
+
+See also:
+    offer
+    add-relation`[1:]
+
+// NewConsumeCommand returns a command to consume application offers.
+func NewConsumeCommand() cmd.Command {
+	cmd := &consumeCommand{}
+	cmd.newAPIFunc = func() (ApplicationConsumeAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return applicationoffers.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// consumeCommand adds a remote application to the model.
+type consumeCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationURL   string
+	ApplicationAlias string
+	newAPIFunc       func() (ApplicationConsumeAPI, error)
+}
+
+func (c *consumeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "consume",
+		Args:    "<offer URL> [<application alias>]",
+		Purpose: usageConsumeSummary,
+		Doc:     usageConsumeDetails,
+	}
+}
+
+func (c *consumeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer URL specified")
+	}
+	if _, err := crossmodel.ParseApplicationURL(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.ApplicationURL = args[0]
+	if len(args) > 1 {
+		c.ApplicationAlias = args[1]
+		if !names.IsValidApplication(c.ApplicationAlias) {
+			return errors.NotValidf("application alias %q", c.ApplicationAlias)
+		}
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ApplicationConsumeAPI defines the API methods that the consume and
+// add-relation commands use to consume application offers.
+type ApplicationConsumeAPI interface {
+	Close() error
+	Consume(url, alias string) (string, error)
+}
+
+func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	localName, err := client.Consume(c.ApplicationURL, c.ApplicationAlias)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintf(ctx.Stdout, "Added %s as %s\n", c.ApplicationURL, localName)
+	return nil
+}
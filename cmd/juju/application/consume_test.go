@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ConsumeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeConsumeAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ConsumeSuite{})
+
+type fakeConsumeAPI struct {
+	jujutesting.Stub
+	localName string
+}
+
+func (f *fakeConsumeAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeConsumeAPI) Consume(url, alias string) (string, error) {
+	f.MethodCall(f, "Consume", url, alias)
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return f.localName, nil
+}
+
+func (s *ConsumeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeConsumeAPI{localName: "db"}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/default", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/default"
+}
+
+func (s *ConsumeSuite) runConsume(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &consumeCommand{
+		newAPIFunc: func() (ApplicationConsumeAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *ConsumeSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no offer URL specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `application URL "mysql": missing user not valid`,
+	}, {
+		args: []string{"admin/prod.db", "my_db"},
+		err:  `application alias "my_db" not valid`,
+	}, {
+		args: []string{"admin/prod.db", "db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := s.runConsume(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ConsumeSuite) TestConsume(c *gc.C) {
+	ctx, err := s.runConsume(c, "admin/prod.db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Consume", []interface{}{"admin/prod.db", ""}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, "Added admin/prod.db as db\n")
+}
+
+func (s *ConsumeSuite) TestConsumeWithAlias(c *gc.C) {
+	s.api.localName = "proddb"
+	ctx, err := s.runConsume(c, "admin/prod.db", "proddb")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Consume", []interface{}{"admin/prod.db", "proddb"}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, "Added admin/prod.db as proddb\n")
+}
+
+func (s *ConsumeSuite) TestConsumeError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runConsume(c, "admin/prod.db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "Consume", "Close")
+}
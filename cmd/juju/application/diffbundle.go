@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/storage"
+)
+
+var usageDiffBundleSummary = `
+Compares a bundle with a model and reports any differences.`[1:]
+
+var usageDiffBundleDetails = `
+Compares the applications, units placement, relations, configuration
+options, constraints and machines described by a local bundle with the
+current state of the model, and reports what differs.
+
+Each difference records the value from the bundle alongside the value
+found in the model. Applications, machines and relations which are
+present on only one side are reported as missing from the other.
+
+Machine ids in a bundle need not match those in the model. Each bundle
+machine is compared with the model machine hosting the units that the
+bundle places on it; model machines which correspond to no bundle
+machine are listed under "model-machines".
+
+Examples:
+    juju diff-bundle ./mediawiki.yaml
+    juju diff-bundle ./mediawiki.yaml --format json
+
+See also:
+    deploy
+    export-bundle`[1:]
+
+// NewDiffBundleCommand returns a command to compare a bundle against
+// the current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand reports the differences between a bundle and a model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	bundleFile string
+	api        DiffBundleAPI
+}
+
+// DiffBundleAPI specifies the used function calls of the Bundle facade.
+type DiffBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: usageDiffBundleSummary,
+		Doc:     usageDiffBundleDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundleFile = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	bundleData, err := c.readBundle(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	exported, err := client.ExportBundle()
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	var modelData charm.BundleData
+	if err := yaml.Unmarshal([]byte(exported), &modelData); err != nil {
+		return errors.Annotate(err, "cannot read exported model")
+	}
+
+	diff := diffBundle(bundleData, &modelData)
+	if diff.Empty() {
+		fmt.Fprintln(ctx.Stdout, "No differences between the bundle and the model.")
+		return nil
+	}
+	return c.out.Write(ctx, diff)
+}
+
+// readBundle reads the bundle data from a local bundle file, archive or
+// directory, and verifies it in the same way that the Bundle facade does
+// when computing the changes required to deploy it.
+func (c *diffBundleCommand) readBundle(ctx *cmd.Context) (*charm.BundleData, error) {
+	bundleFile := ctx.AbsPath(c.bundleFile)
+	bundleDir := filepath.Dir(bundleFile)
+	data, err := charmrepo.ReadBundleFile(bundleFile)
+	if err != nil {
+		localBundle, _, pathErr := charmrepo.NewBundleAtPath(bundleFile)
+		if pathErr != nil {
+			return nil, errors.Annotatef(pathErr, "cannot read bundle %q", c.bundleFile)
+		}
+		data = localBundle.Data()
+		bundleDir = bundleFile
+	}
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	if err := data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage); err != nil {
+		if verr, ok := err.(*charm.VerificationError); ok {
+			errs := make([]string, len(verr.Errors))
+			for i, err := range verr.Errors {
+				errs[i] = err.Error()
+			}
+			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+		}
+		return nil, errors.Annotate(err, "cannot verify bundle")
+	}
+	return data, nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type DiffBundleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeDiffBundleAPI
+	store *jujuclienttesting.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+const diffBundleModel = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    series: xenial
+    num_units: 1
+    to:
+    - "0"
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    series: xenial
+    num_units: 2
+    to:
+    - "0"
+    - lxd:1
+    expose: true
+    options:
+      blog-title: engineering
+machines:
+  "0":
+    series: xenial
+  "1":
+    series: xenial
+relations:
+- - wordpress:db
+  - mysql:server
+`
+
+type fakeDiffBundleAPI struct {
+	jujutesting.Stub
+	exported string
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) ExportBundle() (string, error) {
+	f.MethodCall(f, "ExportBundle")
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return f.exported, nil
+}
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeDiffBundleAPI{exported: diffBundleModel}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+	s.dir = c.MkDir()
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, bundle string, args ...string) (*cmd.Context, error) {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(bundle), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := &diffBundleCommand{api: s.api}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), append([]string{path}, args...)...)
+}
+
+func (s *DiffBundleSuite) TestNoDifferences(c *gc.C) {
+	ctx, err := s.runDiffBundle(c, diffBundleModel)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "ExportBundle", "Close")
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No differences between the bundle and the model.\n")
+}
+
+func (s *DiffBundleSuite) TestMachinesMatchedByUnits(c *gc.C) {
+	// The bundle's machine ids differ from the model's, but its units
+	// are placed in the same way.
+	ctx, err := s.runDiffBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    to: ["5"]
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    to: ["mysql/0", "lxd:7"]
+    expose: true
+    options:
+      blog-title: engineering
+machines:
+  "5":
+    series: xenial
+  "7":
+    series: trusty
+relations:
+- ["wordpress:db", "mysql:server"]
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+machines:
+  "7":
+    model-machine: "1"
+    series:
+      bundle: trusty
+      model: xenial
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestPlacementDifferences(c *gc.C) {
+	ctx, err := s.runDiffBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    to: ["0"]
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    to: ["0", "0"]
+    expose: true
+    options:
+      blog-title: engineering
+machines:
+  "0":
+    series: xenial
+relations:
+- ["wordpress:db", "mysql:server"]
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+applications:
+  wordpress:
+    to:
+      bundle:
+      - "0"
+      - "0"
+      model:
+      - "0"
+      - lxd:1
+model-machines:
+- "1"
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestDifferences(c *gc.C) {
+	ctx, err := s.runDiffBundle(c, `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-6
+    num_units: 3
+    constraints: mem=4G
+    options:
+      blog-title: marketing
+  haproxy:
+    charm: cs:xenial/haproxy-1
+    num_units: 1
+relations:
+- - wordpress
+  - haproxy
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  mysql:
+    missing: bundle
+  wordpress:
+    charm:
+      bundle: cs:xenial/wordpress-6
+      model: cs:xenial/wordpress-5
+    num_units:
+      bundle: 3
+      model: 2
+    expose:
+      bundle: false
+      model: true
+    constraints:
+      bundle: mem=4G
+      model: ""
+    options:
+      blog-title:
+        bundle: marketing
+        model: engineering
+relations:
+  bundle-additions:
+  - - wordpress
+    - haproxy
+  model-additions:
+  - - wordpress:db
+    - mysql:server
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestJSONOutput(c *gc.C) {
+	ctx, err := s.runDiffBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    to: ["0"]
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    to: ["0", "lxd:1"]
+    expose: true
+    options:
+      blog-title: engineering
+machines:
+  "0":
+  "1":
+    series: trusty
+relations:
+- ["wordpress", "mysql"]
+`, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals,
+		`{"machines":{"1":{"series":{"bundle":"trusty","model":"xenial"}}}}`+"\n")
+}
+
+func (s *DiffBundleSuite) TestInvalidBundle(c *gc.C) {
+	_, err := s.runDiffBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: -1
+`)
+	c.Assert(err, gc.ErrorMatches, `(?s)the provided bundle has the following errors:.*negative number of units.*`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *DiffBundleSuite) TestExportError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runDiffBundle(c, diffBundleModel)
+	c.Assert(err, gc.ErrorMatches, "cannot export model: boom")
+	s.api.CheckCallNames(c, "ExportBundle", "Close")
+}
+
+func (s *DiffBundleSuite) TestNoBundle(c *gc.C) {
+	command := &diffBundleCommand{api: s.api}
+	command.SetClientStore(s.store)
+	_, err := testing.RunCommand(c, modelcmd.Wrap(command))
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
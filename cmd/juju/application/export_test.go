@@ -53,11 +53,16 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
-// NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
-func NewAddRelationCommandForTest(api ApplicationAddRelationAPI) cmd.Command {
-	cmd := &addRelationCommand{newAPIFunc: func() (ApplicationAddRelationAPI, error) {
-		return api, nil
-	}}
+// NewAddRelationCommandForTest returns an AddRelationCommand with the apis provided as specified.
+func NewAddRelationCommandForTest(api ApplicationAddRelationAPI, consumeAPI ApplicationConsumeAPI) cmd.Command {
+	cmd := &addRelationCommand{
+		newAPIFunc: func() (ApplicationAddRelationAPI, error) {
+			return api, nil
+		},
+		newConsumeAPIFunc: func() (ApplicationConsumeAPI, error) {
+			return consumeAPI, nil
+		},
+	}
 	return modelcmd.Wrap(cmd)
 }
 
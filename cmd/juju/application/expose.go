@@ -4,8 +4,12 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +23,14 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access may be restricted to a set of source networks with --to-cidrs.
+Exposing an application again replaces any previous restriction. If the
+cloud's firewall cannot restrict the sources of traffic, the application's
+ports are left closed rather than opened to all.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.1.0/24
 
 See also: 
     unexpose`[1:]
@@ -34,6 +44,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToCIDRs         []string
+	toCIDRs         string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +57,33 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-separated list of CIDRs allowed to access the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	if c.toCIDRs != "" {
+		for _, cidr := range strings.Split(c.toCIDRs, ",") {
+			cidr = strings.TrimSpace(cidr)
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+			c.ToCIDRs = append(c.ToCIDRs, cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
+	BestAPIVersion() int
 	Expose(serviceName string) error
+	ExposeToCIDRs(serviceName string, cidrs []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +103,15 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.ToCIDRs) > 0 {
+		// Older controllers ignore the CIDRs, and would expose the
+		// application to all sources.
+		if client.BestAPIVersion() < 3 {
+			return errors.New("exposing applications to specific CIDRs is not supported by this controller")
+		}
+		err = client.ExposeToCIDRs(c.ApplicationName, c.ToCIDRs)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/jujuclient"
+)
+
+var usageOfferSummary = `
+Offers application endpoints for use in other models.`[1:]
+
+var usageOfferDetails = `
+The named endpoints of the application are made available to other models
+hosted by the same controller, at a URL made up of the model owner, the
+model name and the offer name. The offer name defaults to the name of the
+application.
+
+Users with read access to the offering model may consume the offer, and
+relate their own applications to it.
+
+Examples:
+    juju offer mysql:db
+    juju offer mysql:db,log prod-db
+
+See also:
+    consume
+    add-relation`[1:]
+
+// NewOfferCommand returns a command to offer application endpoints.
+func NewOfferCommand() cmd.Command {
+	cmd := &offerCommand{}
+	cmd.newAPIFunc = func() (ApplicationOfferAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return applicationoffers.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// offerCommand offers application endpoints to other models.
+type offerCommand struct {
+	modelcmd.ModelCommandBase
+	Application string
+	Endpoints   []string
+	OfferName   string
+	newAPIFunc  func() (ApplicationOfferAPI, error)
+}
+
+func (c *offerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer",
+		Args:    "<application>:<endpoint>[,<endpoint>...] [<offer name>]",
+		Purpose: usageOfferSummary,
+		Doc:     usageOfferDetails,
+	}
+}
+
+func (c *offerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application endpoints specified")
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("endpoints must be specified as <application>:<endpoint>[,<endpoint>...]")
+	}
+	c.Application = parts[0]
+	if !names.IsValidApplication(c.Application) {
+		return errors.NotValidf("application name %q", c.Application)
+	}
+	c.Endpoints = strings.Split(parts[1], ",")
+	if len(args) > 1 {
+		c.OfferName = args[1]
+		if !names.IsValidApplication(c.OfferName) {
+			return errors.NotValidf("offer name %q", c.OfferName)
+		}
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ApplicationOfferAPI defines the API methods that the offer command uses.
+type ApplicationOfferAPI interface {
+	Close() error
+	Offer(application string, endpoints []string, offerName string) error
+}
+
+func (c *offerCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.Offer(c.Application, c.Endpoints, c.OfferName); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	url, err := c.offerURL()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "Application %q endpoints %v available at %q\n", c.Application, c.Endpoints, url)
+	return nil
+}
+
+// offerURL returns the URL at which the offer may be consumed.
+func (c *offerCommand) offerURL() (string, error) {
+	modelName := c.ModelName()
+	var owner string
+	if jujuclient.IsQualifiedModelName(modelName) {
+		unqualified, ownerTag, err := jujuclient.SplitModelName(modelName)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		modelName, owner = unqualified, ownerTag.Id()
+	} else {
+		details, err := c.ClientStore().AccountDetails(c.ControllerName())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		owner = details.User
+	}
+	offerName := c.OfferName
+	if offerName == "" {
+		offerName = c.Application
+	}
+	url := crossmodel.ApplicationURL{
+		User:      owner,
+		ModelName: modelName,
+		OfferName: offerName,
+	}
+	return url.String(), nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type OfferSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeOfferAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&OfferSuite{})
+
+type fakeOfferAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeOfferAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeOfferAPI) Offer(application string, endpoints []string, offerName string) error {
+	f.MethodCall(f, "Offer", application, endpoints, offerName)
+	return f.NextErr()
+}
+
+func (s *OfferSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeOfferAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/prod", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/prod"
+}
+
+func (s *OfferSuite) runOffer(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &offerCommand{
+		newAPIFunc: func() (ApplicationOfferAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *OfferSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application endpoints specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `endpoints must be specified as <application>:<endpoint>\[,<endpoint>...\]`,
+	}, {
+		args: []string{"mysql:"},
+		err:  `endpoints must be specified as <application>:<endpoint>\[,<endpoint>...\]`,
+	}, {
+		args: []string{"my_sql:db"},
+		err:  `application name "my_sql" not valid`,
+	}, {
+		args: []string{"mysql:db", "prod.db"},
+		err:  `offer name "prod.db" not valid`,
+	}, {
+		args: []string{"mysql:db", "db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := s.runOffer(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *OfferSuite) TestOffer(c *gc.C) {
+	ctx, err := s.runOffer(c, "mysql:db,log")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Offer", []interface{}{"mysql", []string{"db", "log"}, ""}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `Application "mysql" endpoints [db log] available at "admin/prod.mysql"`+"\n")
+}
+
+func (s *OfferSuite) TestOfferWithName(c *gc.C) {
+	ctx, err := s.runOffer(c, "mysql:db", "proddb")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Offer", []interface{}{"mysql", []string{"db"}, "proddb"}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `Application "mysql" endpoints [db] available at "admin/prod.proddb"`+"\n")
+}
+
+func (s *OfferSuite) TestOfferError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runOffer(c, "mysql:db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "Offer", "Close")
+}
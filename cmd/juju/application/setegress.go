@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var usageSetEgressSummary = `
+Restricts the outbound traffic of an application's machines.`[1:]
+
+var usageSetEgressDetails = `
+Sets the egress rules of an application, replacing any previous rules.
+Each rule allows outbound traffic to a destination CIDR on a port range,
+in the form <cidr>:<port range>. The port range defaults to tcp, as in
+open-port.
+
+The machines hosting units of an application with egress rules may only
+send traffic allowed by the rules of the applications they host, and to
+the controllers. Traffic needed by the charms, such as to package
+archives or DNS servers, must be allowed explicitly.
+
+Running the command without rules clears the application's egress rules,
+allowing all outbound traffic again.
+
+Egress rules are only supported in the "instance" firewall mode, and not
+by every cloud. Setting rules fails in models that cannot enforce them,
+such as OpenStack models, or AWS models outside a VPC.
+
+Examples:
+    juju set-egress mysql 10.0.0.0/8:443/tcp 0.0.0.0/0:53/udp
+    juju set-egress mysql
+
+See also:
+    expose`[1:]
+
+// NewSetEgressCommand returns a command to set the egress rules of an
+// application.
+func NewSetEgressCommand() cmd.Command {
+	cmd := &setEgressCommand{}
+	cmd.newAPIFunc = func() (ApplicationSetEgressAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// setEgressCommand sets the egress rules of an application.
+type setEgressCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Rules           []network.EgressRule
+	newAPIFunc      func() (ApplicationSetEgressAPI, error)
+}
+
+func (c *setEgressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-egress",
+		Args:    "<application name> [<cidr>:<port range> ...]",
+		Purpose: usageSetEgressSummary,
+		Doc:     usageSetEgressDetails,
+	}
+}
+
+func (c *setEgressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName = args[0]
+	if !names.IsValidApplication(c.ApplicationName) {
+		return errors.NotValidf("application name %q", c.ApplicationName)
+	}
+	for _, arg := range args[1:] {
+		rule, err := network.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.Rules = append(c.Rules, rule)
+	}
+	return nil
+}
+
+// ApplicationSetEgressAPI defines the API methods that the set-egress
+// command uses.
+type ApplicationSetEgressAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetEgressRules(application string, rules []network.EgressRule) error
+}
+
+func (c *setEgressCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 3 {
+		return errors.New("setting egress rules is not supported by this controller")
+	}
+	err = client.SetEgressRules(c.ApplicationName, c.Rules)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
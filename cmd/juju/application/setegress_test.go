@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type SetEgressSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeSetEgressAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&SetEgressSuite{})
+
+type fakeSetEgressAPI struct {
+	jujutesting.Stub
+	version int
+}
+
+func (f *fakeSetEgressAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeSetEgressAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSetEgressAPI) SetEgressRules(application string, rules []network.EgressRule) error {
+	f.MethodCall(f, "SetEgressRules", application, rules)
+	return f.NextErr()
+}
+
+func (s *SetEgressSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSetEgressAPI{version: 3}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/default", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/default"
+}
+
+func (s *SetEgressSuite) runSetEgress(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &setEgressCommand{
+		newAPIFunc: func() (ApplicationSetEgressAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *SetEgressSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"my_sql", "10.0.0.0/8:443"},
+		err:  `application name "my_sql" not valid`,
+	}, {
+		args: []string{"mysql", "10.0.0.0/8"},
+		err:  `invalid egress rule "10.0.0.0/8", expected <cidr>:<port range>`,
+	}, {
+		args: []string{"mysql", "10.0.0.1:443"},
+		err:  `invalid destination CIDR "10.0.0.1"`,
+	}} {
+		_, err := s.runSetEgress(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *SetEgressSuite) TestSetEgress(c *gc.C) {
+	_, err := s.runSetEgress(c, "mysql", "10.0.0.0/8:443/tcp", "0.0.0.0/0:53/udp")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEgressRules", []interface{}{"mysql", []network.EgressRule{
+			network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+			network.NewEgressRule(network.PortRange{53, 53, "udp"}, "0.0.0.0/0"),
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressSuite) TestClearEgress(c *gc.C) {
+	_, err := s.runSetEgress(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEgressRules", []interface{}{"mysql", []network.EgressRule(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressSuite) TestSetEgressError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runSetEgress(c, "mysql", "10.0.0.0/8:443")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "SetEgressRules", "Close")
+}
+
+func (s *SetEgressSuite) TestSetEgressNotSupported(c *gc.C) {
+	s.api.version = 2
+	_, err := s.runSetEgress(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "setting egress rules is not supported by this controller")
+	s.api.CheckCallNames(c, "Close")
+}
@@ -4,9 +4,11 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/juju/ansiterm"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 	"github.com/mattn/go-isatty"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -24,6 +27,16 @@ import (
 // display, from the end of the consolidated log.
 const defaultLineCount = 10
 
+const (
+	// formatText is the default output format, writing one
+	// human readable line per log message.
+	formatText = "text"
+
+	// formatJSON writes each log message as a JSON object on a
+	// line of its own.
+	formatJSON = "json"
+)
+
 var usageDebugLogSummary = `
 Displays log messages for a model.`[1:]
 
@@ -44,17 +57,38 @@ identified by prefixing 'unit-' to its corresponding unit name and replacing
 the slash with a dash. A machine entity is identified by prefixing 'machine-'
 to its corresponding machine id.
 
+The '--include-application', '--include-machine' and '--include-unit'
+options, and their '--exclude-' counterparts, filter by entity using the
+names shown by ` + "`juju status`" + `. An application matches the agents
+of all of its units, and a machine matches its own agent as well as the
+agents of any containers it hosts.
+
 The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--match' option only shows messages matching a regular expression.
+
+The '--since' and '--until' options restrict the messages shown to a time
+range. Each takes either an RFC3339 timestamp, such as
+2016-11-02T09:00:00Z, or a duration before the current time, such as 90m.
+Setting '--since' shows every matching message logged since that time,
+as if '--replay' had been given. Setting '--until' stops once the
+existing messages have been shown.
+
 The filtering options combine as follows:
-* All --include options are logically ORed together.
-* All --exclude options are logically ORed together.
+* All --include options, including those for applications, machines and
+  units, are logically ORed together.
+* All --exclude options, including those for applications, machines and
+  units, are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --match, --since and --until selections are logically ANDed to form the
+  complete filter.
+
+With '--format json' each message is written as a JSON object on a line of
+its own, suitable for processing by other tools.
 
 Examples:
 
@@ -93,6 +127,18 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show the messages from the units of the mysql application, or from
+machine 2 and its containers, which were logged in the last hour and
+mention a failure, as JSON:
+
+    juju debug-log --since 1h --include-application mysql \
+        --include-machine 2 --match 'fail(ed|ure)' --format json
+
+Show the messages logged during a given morning, and then stop:
+
+    juju debug-log --since 2016-11-02T09:00:00Z \
+        --until 2016-11-02T12:00:00Z
+
 See also: 
     status
     ssh`
@@ -117,6 +163,9 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
+	output string
 	params api.DebugLogParams
 
 	utc      bool
@@ -140,6 +189,15 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeApplication), "include-application", "Only show log messages for the units of these applications")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeApplication), "exclude-application", "Do not show log messages for the units of these applications")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMachine), "include-machine", "Only show log messages for these machines and their containers")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMachine), "exclude-machine", "Do not show log messages for these machines and their containers")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeUnit), "include-unit", "Only show log messages for these units")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeUnit), "exclude-unit", "Do not show log messages for these units")
+	f.StringVar(&c.params.MessageRegex, "match", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged after this time (RFC3339 timestamp or duration ago)")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged before this time (RFC3339 timestamp or duration ago)")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -157,6 +215,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.output, "format", formatText, "Output format, one of [text, json]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -171,6 +230,12 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if err := c.initFilters(time.Now()); err != nil {
+		return errors.Trace(err)
+	}
+	if c.output != formatText && c.output != formatJSON {
+		return errors.Errorf("format value %q is not one of %q, %q", c.output, formatText, formatJSON)
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -185,6 +250,70 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// initFilters validates the filtering options, and converts the
+// --since and --until values into times relative to now.
+func (c *debugLogCommand) initFilters(now time.Time) error {
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		if c.tail {
+			return errors.NotValidf("setting --tail and --until")
+		}
+		until, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		if !c.params.StartTime.IsZero() && !until.After(c.params.StartTime) {
+			return errors.New("--until must be later than --since")
+		}
+		c.params.EndTime = until
+	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Annotate(err, "invalid --match value")
+		}
+	}
+	checks := []struct {
+		flag   string
+		values []string
+		valid  func(string) bool
+	}{
+		{"include-application", c.params.IncludeApplication, names.IsValidApplication},
+		{"exclude-application", c.params.ExcludeApplication, names.IsValidApplication},
+		{"include-machine", c.params.IncludeMachine, names.IsValidMachine},
+		{"exclude-machine", c.params.ExcludeMachine, names.IsValidMachine},
+		{"include-unit", c.params.IncludeUnit, names.IsValidUnit},
+		{"exclude-unit", c.params.ExcludeUnit, names.IsValidUnit},
+	}
+	for _, check := range checks {
+		for _, value := range check.values {
+			if !check.valid(value) {
+				return errors.Errorf("--%s value %q is not valid", check.flag, value)
+			}
+		}
+	}
+	return nil
+}
+
+// parseLogTime parses either an RFC3339 timestamp, or a duration
+// which is subtracted from now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%q is neither an RFC3339 timestamp nor a positive duration", value)
+	}
+	return now.Add(-d), nil
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params api.DebugLogParams) (<-chan api.LogMessage, error)
 	Close() error
@@ -206,7 +335,7 @@ func isTerminal(out io.Writer) bool {
 func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if c.tail {
 		c.params.NoTail = false
-	} else if c.notail {
+	} else if c.notail || !c.params.EndTime.IsZero() {
 		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
@@ -223,6 +352,9 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.output == formatJSON {
+		return c.writeJSONRecords(ctx.Stdout, messages)
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
@@ -238,6 +370,37 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// jsonLogRecord is the structure written for each log message when
+// the json output format is selected.
+type jsonLogRecord struct {
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Severity  string    `json:"severity"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Message   string    `json:"message"`
+}
+
+// writeJSONRecords writes each message as a JSON object on a line of
+// its own, until the messages channel is closed.
+func (c *debugLogCommand) writeJSONRecords(w io.Writer, messages <-chan api.LogMessage) error {
+	encoder := json.NewEncoder(w)
+	for msg := range messages {
+		err := encoder.Encode(jsonLogRecord{
+			Entity:    msg.Entity,
+			Timestamp: msg.Timestamp.In(c.tz),
+			Severity:  msg.Severity,
+			Module:    msg.Module,
+			Location:  msg.Location,
+			Message:   msg.Message,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
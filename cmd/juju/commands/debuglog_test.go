@@ -86,6 +86,56 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--since", "2016-11-02T09:00:00Z", "--until", "2016-11-02T10:00:00Z"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2016, 11, 2, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is neither an RFC3339 timestamp nor a positive duration`,
+		}, {
+			args:     []string{"--since", "2016-11-02T10:00:00Z", "--until", "2016-11-02T09:00:00Z"},
+			errMatch: `--until must be later than --since`,
+		}, {
+			args:     []string{"--tail", "--until", "2016-11-02T10:00:00Z"},
+			errMatch: `setting --tail and --until not valid`,
+		}, {
+			args: []string{"--match", "fail(ed|ure)"},
+			expected: api.DebugLogParams{
+				Backlog:      10,
+				MessageRegex: "fail(ed|ure)",
+			},
+		}, {
+			args:     []string{"--match", "fail("},
+			errMatch: `invalid --match value: .*`,
+		}, {
+			args: []string{
+				"--include-application", "mysql", "--exclude-application", "wordpress",
+				"--include-machine", "0", "--exclude-machine", "1/lxd/0",
+				"--include-unit", "mysql/0", "--exclude-unit", "wordpress/1",
+			},
+			expected: api.DebugLogParams{
+				Backlog:            10,
+				IncludeApplication: []string{"mysql"},
+				ExcludeApplication: []string{"wordpress"},
+				IncludeMachine:     []string{"0"},
+				ExcludeMachine:     []string{"1/lxd/0"},
+				IncludeUnit:        []string{"mysql/0"},
+				ExcludeUnit:        []string{"wordpress/1"},
+			},
+		}, {
+			args:     []string{"--include-unit", "mysql"},
+			errMatch: `--include-unit value "mysql" is not valid`,
+		}, {
+			args:     []string{"--exclude-machine", "machine-0"},
+			errMatch: `--exclude-machine value "machine-0" is not valid`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -100,6 +150,27 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestSinceDuration(c *gc.C) {
+	command := &debugLogCommand{since: "90m", until: "30m"}
+	now := time.Date(2016, 11, 2, 12, 0, 0, 0, time.UTC)
+	err := command.initFilters(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.params.StartTime, gc.Equals, time.Date(2016, 11, 2, 10, 30, 0, 0, time.UTC))
+	c.Assert(command.params.EndTime, gc.Equals, time.Date(2016, 11, 2, 11, 30, 0, 0, time.UTC))
+	c.Assert(command.params.Replay, jc.IsTrue)
+}
+
+func (s *DebugLogSuite) TestUntilStopsTailing(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := testing.RunCommand(c, newDebugLogCommand(), "--until", "2016-11-02T10:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params.NoTail, jc.IsTrue)
+	c.Assert(fake.params.EndTime, gc.Equals, time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC))
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -163,6 +234,14 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	checkOutput(
 		"--location",
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
+	checkOutput(
+		"--format", "json", "--utc",
+		`{"entity":"machine-0","timestamp":"2016-10-09T08:15:23.345Z","severity":"INFO",`+
+			`"module":"test.module","location":"somefile.go:123","message":"this is the log output"}`+"\n")
+	checkOutput(
+		"--format", "json",
+		`{"entity":"machine-0","timestamp":"2016-10-09T14:15:23.345+06:00","severity":"INFO",`+
+			`"module":"test.module","location":"somefile.go:123","message":"this is the log output"}`+"\n")
 }
 
 type fakeDebugLogAPI struct {
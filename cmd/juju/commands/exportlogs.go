@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/logexport"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var usageExportLogsSummary = `
+Exports the logs of a model to a compressed archive.`[1:]
+
+var usageExportLogsDetails = `
+Fetches the logs recorded by the controller for a model, and writes them
+to a gzip-compressed file holding one JSON encoded log record per line.
+The archive can be loaded into another controller with "juju import-logs".
+
+The --since and --until options restrict the export to log messages
+recorded within a time range. Each accepts either an RFC3339 timestamp,
+or a duration which is taken relative to the current time.
+
+If --filename is not specified, the logs are written to a file named
+after the model in the current directory.
+
+Exporting logs requires admin access to the model.
+
+Examples:
+
+    juju export-logs
+    juju export-logs -m mymodel --since 2h
+    juju export-logs --since 2016-11-01T00:00:00Z --until 2016-11-02T00:00:00Z
+    juju export-logs --filename logs.jsonl.gz
+
+See also:
+    debug-log
+    import-logs`[1:]
+
+func newExportLogsCommand() cmd.Command {
+	return modelcmd.Wrap(&exportLogsCommand{})
+}
+
+type exportLogsCommand struct {
+	modelcmd.ModelCommandBase
+
+	since    string
+	until    string
+	filename string
+	start    time.Time
+	end      time.Time
+}
+
+// Info implements Command.
+func (c *exportLogsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-logs",
+		Purpose: usageExportLogsSummary,
+		Doc:     usageExportLogsDetails,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportLogsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.since, "since", "", "Only export log messages recorded after this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only export log messages recorded before this time or duration ago")
+	f.StringVar(&c.filename, "filename", "", "The file to write the exported logs to")
+}
+
+// Init implements Command.
+func (c *exportLogsCommand) Init(args []string) error {
+	now := time.Now()
+	if c.since != "" {
+		start, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.start = start
+	}
+	if c.until != "" {
+		end, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.end = end
+	}
+	if !c.start.IsZero() && !c.end.IsZero() && !c.end.After(c.start) {
+		return errors.New("--until must be later than --since")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// ExportLogsAPI defines the API methods used by the export-logs command.
+type ExportLogsAPI interface {
+	Export(start, end time.Time) (io.ReadCloser, error)
+	Close() error
+}
+
+var getExportLogsAPI = func(c *exportLogsCommand) (ExportLogsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return logexport.NewClient(root)
+}
+
+// Run implements Command.
+func (c *exportLogsCommand) Run(ctx *cmd.Context) error {
+	filename := c.filename
+	if filename == "" {
+		modelName := c.ModelName()
+		if name, _, err := jujuclient.SplitModelName(modelName); err == nil {
+			modelName = name
+		}
+		filename = fmt.Sprintf("%s-logs.jsonl.gz", modelName)
+	}
+	filename = ctx.AbsPath(filename)
+
+	client, err := getExportLogsAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	archive, err := client.Export(c.start, c.end)
+	if err != nil {
+		return errors.Annotate(err, "cannot export logs")
+	}
+	defer archive.Close()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, archive); err != nil {
+		return errors.Annotate(err, "while writing log archive")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Annotate(err, "while writing log archive")
+	}
+	fmt.Fprintf(ctx.Stdout, "Logs successfully exported to %s\n", filename)
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type logArchiveSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclienttesting.MemStore
+}
+
+func (s *logArchiveSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+type ExportLogsSuite struct {
+	logArchiveSuite
+	fake *fakeExportLogsAPI
+}
+
+var _ = gc.Suite(&ExportLogsSuite{})
+
+type fakeExportLogsAPI struct {
+	gitjujutesting.Stub
+	archive string
+}
+
+func (f *fakeExportLogsAPI) Export(start, end time.Time) (io.ReadCloser, error) {
+	f.MethodCall(f, "Export", start, end)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader(f.archive)), nil
+}
+
+func (f *fakeExportLogsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (s *ExportLogsSuite) SetUpTest(c *gc.C) {
+	s.logArchiveSuite.SetUpTest(c)
+	s.fake = &fakeExportLogsAPI{archive: "<compressed logs>"}
+	s.PatchValue(&getExportLogsAPI, func(*exportLogsCommand) (ExportLogsAPI, error) {
+		return s.fake, nil
+	})
+}
+
+func (s *ExportLogsSuite) runExportLogs(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &exportLogsCommand{}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *ExportLogsSuite) TestExportLogs(c *gc.C) {
+	ctx, err := s.runExportLogs(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Export", []interface{}{time.Time{}, time.Time{}}},
+		{"Close", nil},
+	})
+
+	filename := filepath.Join(ctx.Dir, "mymodel-logs.jsonl.gz")
+	c.Assert(testing.Stdout(ctx), gc.Equals, "Logs successfully exported to "+filename+"\n")
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "<compressed logs>")
+}
+
+func (s *ExportLogsSuite) TestExportLogsToFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "logs.gz")
+	ctx, err := s.runExportLogs(c,
+		"--filename", filename,
+		"--since", "2016-11-02T09:00:00Z",
+		"--until", "2016-11-02T10:00:00Z",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Export", []interface{}{
+			time.Date(2016, 11, 2, 9, 0, 0, 0, time.UTC),
+			time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC),
+		}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, "Logs successfully exported to "+filename+"\n")
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "<compressed logs>")
+}
+
+func (s *ExportLogsSuite) TestExportLogsError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.runExportLogs(c)
+	c.Assert(err, gc.ErrorMatches, "cannot export logs: boom")
+	s.fake.CheckCallNames(c, "Export", "Close")
+}
+
+func (s *ExportLogsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		args:     []string{"foo"},
+		errMatch: `unrecognized args: \["foo"\]`,
+	}, {
+		args:     []string{"--since", "yesterday"},
+		errMatch: `invalid --since value: "yesterday" is neither an RFC3339 timestamp nor a positive duration`,
+	}, {
+		args:     []string{"--until", "-1h"},
+		errMatch: `invalid --until value: "-1h" is neither an RFC3339 timestamp nor a positive duration`,
+	}, {
+		args:     []string{"--since", "1h", "--until", "2h"},
+		errMatch: `--until must be later than --since`,
+	}} {
+		c.Logf("test %d", i)
+		command := &exportLogsCommand{}
+		command.SetClientStore(s.store)
+		err := testing.InitCommand(modelcmd.Wrap(command), test.args)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+	s.fake.CheckNoCalls(c)
+}
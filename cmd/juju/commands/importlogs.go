@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/logexport"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageImportLogsSummary = `
+Imports a log archive into a model.`[1:]
+
+var usageImportLogsDetails = `
+Loads the log records from an archive written by "juju export-logs" into
+the logs of a model, where they can be viewed with "juju debug-log". The
+entity, version and timestamp of each record are preserved.
+
+This is intended for examining the logs of another controller after the
+fact, and requires superuser access to the controller.
+
+Examples:
+
+    juju import-logs mymodel-logs.jsonl.gz
+    juju import-logs -m postmortem mymodel-logs.jsonl.gz
+
+See also:
+    debug-log
+    export-logs`[1:]
+
+func newImportLogsCommand() cmd.Command {
+	return modelcmd.Wrap(&importLogsCommand{})
+}
+
+type importLogsCommand struct {
+	modelcmd.ModelCommandBase
+
+	filename string
+}
+
+// Info implements Command.
+func (c *importLogsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-logs",
+		Args:    "<archive>",
+		Purpose: usageImportLogsSummary,
+		Doc:     usageImportLogsDetails,
+	}
+}
+
+// Init implements Command.
+func (c *importLogsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no log archive specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ImportLogsAPI defines the API methods used by the import-logs command.
+type ImportLogsAPI interface {
+	Import(archive io.ReadSeeker) (int, error)
+	Close() error
+}
+
+var getImportLogsAPI = func(c *importLogsCommand) (ImportLogsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return logexport.NewClient(root)
+}
+
+// Run implements Command.
+func (c *importLogsCommand) Run(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+
+	client, err := getImportLogsAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	count, err := client.Import(f)
+	if err != nil {
+		return errors.Annotate(err, "cannot import logs")
+	}
+	fmt.Fprintf(ctx.Stdout, "Imported %d log records\n", count)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type ImportLogsSuite struct {
+	logArchiveSuite
+	fake    *fakeImportLogsAPI
+	archive string
+}
+
+var _ = gc.Suite(&ImportLogsSuite{})
+
+type fakeImportLogsAPI struct {
+	gitjujutesting.Stub
+	imported string
+}
+
+func (f *fakeImportLogsAPI) Import(archive io.ReadSeeker) (int, error) {
+	f.MethodCall(f, "Import")
+	if err := f.NextErr(); err != nil {
+		return 0, err
+	}
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return 0, err
+	}
+	f.imported = string(data)
+	return 42, nil
+}
+
+func (f *fakeImportLogsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (s *ImportLogsSuite) SetUpTest(c *gc.C) {
+	s.logArchiveSuite.SetUpTest(c)
+	s.fake = &fakeImportLogsAPI{}
+	s.PatchValue(&getImportLogsAPI, func(*importLogsCommand) (ImportLogsAPI, error) {
+		return s.fake, nil
+	})
+	s.archive = filepath.Join(c.MkDir(), "logs.jsonl.gz")
+	err := ioutil.WriteFile(s.archive, []byte("<compressed logs>"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportLogsSuite) runImportLogs(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &importLogsCommand{}
+	command.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *ImportLogsSuite) TestImportLogs(c *gc.C) {
+	ctx, err := s.runImportLogs(c, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "Import", "Close")
+	c.Assert(s.fake.imported, gc.Equals, "<compressed logs>")
+	c.Assert(testing.Stdout(ctx), gc.Equals, "Imported 42 log records\n")
+}
+
+func (s *ImportLogsSuite) TestImportLogsError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.runImportLogs(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, "cannot import logs: boom")
+	s.fake.CheckCallNames(c, "Import", "Close")
+}
+
+func (s *ImportLogsSuite) TestImportLogsMissingFile(c *gc.C) {
+	_, err := s.runImportLogs(c, filepath.Join(c.MkDir(), "missing.gz"))
+	c.Assert(err, gc.ErrorMatches, "open .*missing.gz: no such file or directory")
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ImportLogsSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runImportLogs(c)
+	c.Assert(err, gc.ErrorMatches, "no log archive specified")
+	_, err = s.runImportLogs(c, "a.gz", "b.gz")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.gz"\]`)
+}
@@ -233,6 +233,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Creation commands.
 	r.Register(newBootstrapCommand())
 	r.Register(application.NewAddRelationCommand())
+	r.Register(application.NewConsumeCommand())
+	r.Register(application.NewOfferCommand())
 
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
@@ -250,6 +252,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSSHCommand())
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
+	r.Register(newExportLogsCommand())
+	r.Register(newImportLogsCommand())
 	r.Register(newDebugHooksCommand())
 
 	// Configuration commands.
@@ -308,9 +312,13 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportBundleCommand())
 
 	if featureflag.Enabled(feature.Migration) {
 		r.Register(newMigrateCommand())
+		r.Register(model.NewShowMigrationCommand())
+		r.Register(model.NewExportModelCommand())
+		r.Register(model.NewImportModelCommand())
 	}
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -322,18 +330,25 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
+	r.Register(action.NewCancelCommand())
+	r.Register(action.NewScheduleCommand())
+	r.Register(action.NewSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
+	r.Register(application.NewBindCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDefaultDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewSetEgressCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -342,9 +357,19 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage storage
 	r.Register(storage.NewAddCommand())
+	r.Register(storage.NewAttachStorageCommand())
+	r.Register(storage.NewCreateSnapshotCommand())
+	r.Register(storage.NewDetachStorageCommand())
+	r.Register(storage.NewImportFilesystemCommand())
 	r.Register(storage.NewListCommand())
+	r.Register(storage.NewListSnapshotsCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewPoolRemoveCommand())
+	r.Register(storage.NewPoolUpdateCommand())
+	r.Register(storage.NewRemoveStorageCommand())
+	r.Register(storage.NewResizeStorageCommand())
+	r.Register(storage.NewRestoreSnapshotCommand())
 	r.Register(storage.NewShowCommand())
 
 	// Manage spaces
@@ -375,6 +400,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -400,20 +400,26 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"clouds",
 	"config",
 	"collect-metrics",
+	"consume",
 	"controllers",
 	"create-backup",
 	"create-budget",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"credentials",
 	"controller-config",
 	"debug-hooks",
@@ -422,6 +428,8 @@ var commandNames = []string{
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -431,12 +439,16 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-user",
 	"expose",
+	"export-bundle",
+	"export-logs",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
 	"gui",
 	"help",
 	"help-tool",
+	"import-filesystem",
+	"import-logs",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -451,11 +463,13 @@ var commandNames = []string{
 	"list-machines",
 	"list-models",
 	"list-plans",
+	"list-schedules",
 	"list-regions",
 	"list-ssh-keys",
 	"list-spaces",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -465,6 +479,7 @@ var commandNames = []string{
 	"model-config",
 	"model-defaults",
 	"models",
+	"offer",
 	"plans",
 	"regions",
 	"register",
@@ -476,19 +491,27 @@ var commandNames = []string{
 	"remove-credential",
 	"remove-machine",
 	"remove-relation",
+	"remove-schedule",
 	"remove-ssh-key",
+	"remove-storage",
+	"remove-storage-pool",
 	"remove-unit",
+	"resize-storage",
 	"resolved",
 	"restore-backup",
+	"restore-storage-snapshot",
 	"retry-provisioning",
 	"revoke",
 	"run",
 	"run-action",
+	"schedule-action",
+	"schedules",
 	"scp",
 	"set-budget",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-egress",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -510,6 +533,7 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"switch",
 	"sync-tools",
@@ -519,6 +543,7 @@ var commandNames = []string{
 	"unregister",
 	"update-clouds",
 	"update-credential",
+	"update-storage-pool",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
@@ -532,7 +557,10 @@ var devFeatures = []string{feature.Migration}
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
+	"export-model",
+	"import-model",
 	"migrate",
+	"show-migration",
 )
 
 func (s *MainSuite) TestHelpCommands(c *gc.C) {
@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
@@ -28,10 +31,13 @@ type migrateCommand struct {
 	api              migrateAPI
 	model            string
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
+	BestAPIVersion() int
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationPrechecks(spec controller.MigrationSpec) (controller.MigrationBlockers, error)
 }
 
 const migrateDoc = `
@@ -57,6 +63,14 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the checks made by the source and target controllers
+before a migration starts are run, and any problems that would prevent
+the migration are reported, without starting it. These include
+mismatched agent versions, charms that are unavailable, machines and
+units that are not healthy, missing permissions on the target
+controller and unusable cloud credentials. The command fails if any
+problems are found.
+
 See also:
     login
     controllers
@@ -73,6 +87,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report any problems preventing the migration without starting it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -139,6 +159,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.reportBlockers(ctx, api, spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -147,6 +170,34 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// reportBlockers writes the problems that would prevent the migration
+// to the context's stdout, failing if there are any.
+func (c *migrateCommand) reportBlockers(ctx *cmd.Context, api migrateAPI, spec *controller.MigrationSpec) error {
+	if api.BestAPIVersion() < 4 {
+		return errors.New("dry runs of migrations are not supported by this controller")
+	}
+	blockers, err := api.MigrationPrechecks(*spec)
+	if err != nil {
+		return err
+	}
+	if len(blockers.Source) == 0 && len(blockers.Target) == 0 {
+		ctx.Infof("No problems found, model %q can be migrated to %q", c.model, c.targetController)
+		return nil
+	}
+	writeBlockers := func(heading string, blockers []string) {
+		if len(blockers) == 0 {
+			return
+		}
+		fmt.Fprintln(ctx.Stdout, heading)
+		for _, blocker := range blockers {
+			fmt.Fprintf(ctx.Stdout, "  - %s\n", blocker)
+		}
+	}
+	writeBlockers("Source controller:", blockers.Source)
+	writeBlockers("Target controller:", blockers.Target)
+	return errors.Errorf("migration of model %q to %q is blocked", c.model, c.targetController)
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -80,7 +80,7 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.api = &fakeMigrateAPI{}
+	s.api = &fakeMigrateAPI{version: 4}
 
 	mac0, err := macaroon.New([]byte("secret0"), "id0", "location0")
 	c.Assert(err, jc.ErrorIsNil)
@@ -169,6 +169,49 @@ func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "No problems found, model \"model\" can be migrated to \"target\"\n")
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+	c.Check(s.api.precheckSpecSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "target",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunBlockers(c *gc.C) {
+	s.api.blockers = controller.MigrationBlockers{
+		Source: []string{"machine 0 is dying", "cleanup needed"},
+		Target: []string{"upgrade in progress"},
+	}
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `migration of model "model" to "target" is blocked`)
+
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+Source controller:
+  - machine 0 is dying
+  - cleanup needed
+Target controller:
+  - upgrade in progress
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunNotSupported(c *gc.C) {
+	s.api.version = 3
+	_, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "dry runs of migrations are not supported by this controller")
+	c.Check(s.api.precheckSpecSeen, gc.IsNil)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	cmd := s.makeCommand()
 	cmd.SetModelAPI(&fakeModelAPI{})
@@ -211,7 +254,14 @@ func (s *MigrateSuite) run(c *gc.C, cmd *migrateCommand, args ...string) (*cmd.C
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	version          int
+	specSeen         *controller.MigrationSpec
+	precheckSpecSeen *controller.MigrationSpec
+	blockers         controller.MigrationBlockers
+}
+
+func (a *fakeMigrateAPI) BestAPIVersion() int {
+	return a.version
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -219,6 +269,11 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationPrechecks(spec controller.MigrationSpec) (controller.MigrationBlockers, error) {
+	a.precheckSpecSeen = &spec
+	return a.blockers, nil
+}
+
 type fakeModelAPI struct {
 	model string
 }
@@ -0,0 +1,251 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewAuditLogCommand returns a command to query the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// auditLogCommand queries the audit log of the current controller.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api AuditLogAPI
+
+	model      string
+	originType string
+	originName string
+	operation  string
+	after      string
+	before     string
+	limit      int
+
+	// now is used to resolve relative times. It is
+	// overridden in tests.
+	now func() time.Time
+}
+
+// AuditLogAPI defines the methods on the AuditLog API that the
+// audit-log command calls.
+type AuditLogAPI interface {
+	Close() error
+	Query(audit.Query) ([]audit.AuditEntry, error)
+}
+
+const auditLogDoc = `
+Shows the operations recorded in the audit log of a controller, oldest
+first. Only controller superusers may query the audit log.
+
+Entries may be filtered by the model they were recorded against, the
+type and name of the origin which triggered them (typically the user
+making an API request), the operation performed and the time window in
+which they were recorded. Times may be given in RFC3339 format, or as a
+duration relative to the current time (e.g. 24h).
+
+By default the 100 most recent matching entries are shown; use --limit 0
+to show all of them.
+
+Examples:
+
+    juju audit-log
+    juju audit-log -m admin/default --operation ModelManager.DestroyModels
+    juju audit-log --origin-name user-bob --after 24h
+    juju audit-log --after 2016-10-01T00:00:00Z --before 2016-10-02T00:00:00Z --format json
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the audit log of a controller.",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.model, "m", "", "Only show entries for the named model, or model UUID")
+	f.StringVar(&c.model, "model", "", "")
+	f.StringVar(&c.originType, "origin-type", "", "Only show entries with the given origin type")
+	f.StringVar(&c.originName, "origin-name", "", "Only show entries with the given origin name")
+	f.StringVar(&c.operation, "operation", "", "Only show entries for the given operation")
+	f.StringVar(&c.after, "after", "", "Only show entries recorded after this time")
+	f.StringVar(&c.before, "before", "", "Only show entries recorded before this time")
+	f.IntVar(&c.limit, "limit", 100, "The maximum number of entries to show")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseTime parses a time given either in RFC3339 format, or as a
+// duration before the current time.
+func (c *auditLogCommand) parseTime(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("invalid --%s value %q, expected RFC3339 time or duration", flag, value)
+	}
+	return c.now().Add(-d).UTC(), nil
+}
+
+func (c *auditLogCommand) modelUUID() (string, error) {
+	if c.model == "" || utils.IsValidUUIDString(c.model) {
+		return c.model, nil
+	}
+	modelName := c.model
+	if !jujuclient.IsQualifiedModelName(modelName) {
+		accountDetails, err := c.ClientStore().AccountDetails(c.ControllerName())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		modelName = jujuclient.JoinOwnerModelName(names.NewUserTag(accountDetails.User), modelName)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return uuids[0], nil
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// AuditLogEntry holds the formatted details of an audit log entry.
+type AuditLogEntry struct {
+	Timestamp         time.Time              `yaml:"timestamp" json:"timestamp"`
+	ModelUUID         string                 `yaml:"model-uuid" json:"model-uuid"`
+	OriginType        string                 `yaml:"origin-type" json:"origin-type"`
+	OriginName        string                 `yaml:"origin-name" json:"origin-name"`
+	RemoteAddress     string                 `yaml:"remote-address" json:"remote-address"`
+	Operation         string                 `yaml:"operation" json:"operation"`
+	JujuServerVersion string                 `yaml:"juju-server-version" json:"juju-server-version"`
+	Data              map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	after, err := c.parseTime("after", c.after)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	before, err := c.parseTime("before", c.before)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelUUID, err := c.modelUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := audit.Query{
+		ModelUUID:  modelUUID,
+		OriginType: c.originType,
+		OriginName: c.originName,
+		Operation:  c.operation,
+		After:      after,
+		Before:     before,
+		Limit:      c.limit,
+	}
+	if err := query.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching audit log entries.")
+		return nil
+	}
+	formatted := make([]AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		formatted[i] = AuditLogEntry{
+			Timestamp:         entry.Timestamp,
+			ModelUUID:         entry.ModelUUID,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			RemoteAddress:     entry.RemoteAddress,
+			Operation:         entry.Operation,
+			JujuServerVersion: entry.JujuServerVersion.String(),
+			Data:              entry.Data,
+		}
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]AuditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Model", "Origin type", "Origin", "Remote address", "Operation")
+	for _, entry := range entries {
+		w.Println(
+			entry.Timestamp.Format(time.RFC3339),
+			entry.ModelUUID,
+			entry.OriginType,
+			entry.OriginName,
+			entry.RemoteAddress,
+			entry.Operation,
+		)
+	}
+	return tw.Flush()
+}
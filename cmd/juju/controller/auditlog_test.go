@@ -0,0 +1,168 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeAuditLogAPI
+	store *jujuclienttesting.MemStore
+	now   time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+type fakeAuditLogAPI struct {
+	gitjujutesting.Stub
+	entries []audit.AuditEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) Query(query audit.Query) ([]audit.AuditEntry, error) {
+	f.MethodCall(f, "Query", query)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.now = time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeAuditLogAPI{
+		entries: []audit.AuditEntry{{
+			JujuServerVersion: version.MustParse("2.0.1"),
+			ModelUUID:         testing.ModelTag.Id(),
+			Timestamp:         s.now.Add(-time.Hour),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob",
+			Operation:         "ModelManager.DestroyModels",
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, func() time.Time { return s.now })
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Query", []interface{}{audit.Query{Limit: 100}}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Time                  Model                                 Origin type  Origin    Remote address  Operation\n"+
+		"2016-10-16T11:00:00Z  deadbeef-0bad-400d-8000-4b1d0d06f00d  API request  user-bob  10.0.0.1        ModelManager.DestroyModels\n")
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"timestamp":"2016-10-16T11:00:00Z",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","origin-type":"API request",`+
+		`"origin-name":"user-bob","remote-address":"10.0.0.1",`+
+		`"operation":"ModelManager.DestroyModels","juju-server-version":"2.0.1"}]`+"\n")
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No matching audit log entries.\n")
+}
+
+func (s *AuditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.run(c,
+		"-m", "mymodel",
+		"--origin-type", "API request",
+		"--origin-name", "user-bob",
+		"--operation", "ModelManager.DestroyModels",
+		"--after", "24h",
+		"--before", "2016-10-16T11:30:00Z",
+		"--limit", "0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Query", []interface{}{audit.Query{
+			ModelUUID:  testing.ModelTag.Id(),
+			OriginType: "API request",
+			OriginName: "user-bob",
+			Operation:  "ModelManager.DestroyModels",
+			After:      s.now.Add(-24 * time.Hour),
+			Before:     time.Date(2016, 10, 16, 11, 30, 0, 0, time.UTC),
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestModelUUID(c *gc.C) {
+	_, err := s.run(c, "--model", "deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Query", []interface{}{audit.Query{
+			ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+			Limit:     100,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestInvalidTime(c *gc.C) {
+	_, err := s.run(c, "--after", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `invalid --after value "yesterday", expected RFC3339 time or duration`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *AuditLogSuite) TestInvalidWindow(c *gc.C) {
+	_, err := s.run(c, "--after", "1h", "--before", "2h")
+	c.Assert(err, gc.ErrorMatches, "Before must be later than After")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *AuditLogSuite) TestNegativeLimit(c *gc.C) {
+	_, err := s.run(c, "--limit", "-1")
+	c.Assert(err, gc.ErrorMatches, "--limit must not be negative")
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.api.CheckCallNames(c, "Query", "Close")
+}
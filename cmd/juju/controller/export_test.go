@@ -167,3 +167,14 @@ func FmtModelStatus(data ModelData) string {
 func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, error) {
 	return newData(api, ctrUUID)
 }
+
+// NewAuditLogCommandForTest returns an audit-log command with the API,
+// client store and clock provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore, now func() time.Time) cmd.Command {
+	c := &auditLogCommand{
+		api: api,
+		now: now,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
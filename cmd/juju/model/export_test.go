@@ -56,6 +56,42 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.WrapController(cmd)
 }
 
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowMigrationCommandForTest returns a ShowMigrationCommand with
+// the api provided as specified.
+func NewShowMigrationCommandForTest(api ShowMigrationAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showMigrationCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewExportModelCommandForTest returns an ExportModelCommand with the
+// api provided as specified.
+func NewExportModelCommandForTest(api ExportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportModelCommandForTest returns an ImportModelCommand with the
+// apis provided as specified.
+func NewImportModelCommandForTest(api ImportModelAPI, uploaderAPI ModelBinariesUploaderAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importModelCommand{
+		api: api,
+		newUploaderAPI: func(string) (ModelBinariesUploaderAPI, error) {
+			return uploaderAPI, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ExportBundleCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeExportBundleClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ExportBundleCommandSuite{})
+
+const exportedBundle = `
+applications:
+  mysql:
+    charm: cs:trusty/mysql-57
+    num_units: 1
+    to:
+    - "0"
+machines:
+  "0":
+    series: trusty
+`[1:]
+
+type fakeExportBundleClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeExportBundleClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportBundleClient) ExportBundle() (string, error) {
+	f.MethodCall(f, "ExportBundle")
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return exportedBundle, nil
+}
+
+func (s *ExportBundleCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportBundleClient{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleToStdout(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+	c.Assert(testing.Stdout(ctx), gc.Equals, exportedBundle)
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleToFile(c *gc.C) {
+	dir := c.MkDir()
+	ctx, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store), "--filename", filepath.Join(dir, "bundle.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+
+	filename := filepath.Join(dir, "bundle.yaml")
+	c.Assert(testing.Stdout(ctx), gc.Equals, "Bundle successfully exported to "+filename+"\n")
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, exportedBundle)
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleUnexpectedArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// NewExportModelCommand returns a fully constructed export-model
+// command.
+func NewExportModelCommand() cmd.Command {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	api ExportModelAPI
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes the current model (or the model specified with -m), along with
+the charms and tools it uses, to an archive file. The archive can be
+carried to a controller which the source controller cannot reach, and
+imported into it with "juju import-model".
+
+The model keeps running on the source controller. Its agents are not
+redirected to the controller the archive is imported into.
+
+The archive includes the model's secrets, such as its cloud credential
+and agent passwords, so it is only readable by the user who created it,
+and exporting a model requires controller admin access.
+
+Models which would lose some of their contents are not exported. These
+are models with resources, cross-model relations, or encrypted volumes.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "Exports a model and its binaries to an archive file.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ExportModelAPI specifies the used function calls of the ModelManager
+// facade and the model's API client.
+type ExportModelAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ExportModel(names.ModelTag) (coremigration.SerializedModel, error)
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenURI(uri string, query url.Values) (io.ReadCloser, error)
+}
+
+// exportModelClient combines the controller connection used to export
+// the model with the model connection used to download its binaries.
+type exportModelClient struct {
+	modelManager *modelmanager.Client
+	modelClient  *api.Client
+}
+
+// Close implements ExportModelAPI.
+func (c *exportModelClient) Close() error {
+	c.modelClient.Close()
+	return c.modelManager.Close()
+}
+
+// BestAPIVersion implements ExportModelAPI.
+func (c *exportModelClient) BestAPIVersion() int {
+	return c.modelManager.BestAPIVersion()
+}
+
+// ExportModel implements ExportModelAPI.
+func (c *exportModelClient) ExportModel(model names.ModelTag) (coremigration.SerializedModel, error) {
+	return c.modelManager.ExportModel(model)
+}
+
+// OpenCharm implements ExportModelAPI.
+func (c *exportModelClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return c.modelClient.OpenCharm(curl)
+}
+
+// OpenURI implements ExportModelAPI.
+func (c *exportModelClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return c.modelClient.OpenURI(uri, query)
+}
+
+func (c *exportModelCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	controllerRoot, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening controller API connection")
+	}
+	modelRoot, err := c.NewAPIRoot()
+	if err != nil {
+		controllerRoot.Close()
+		return nil, errors.Annotate(err, "opening model API connection")
+	}
+	return &exportModelClient{
+		modelManager: modelmanager.NewClient(controllerRoot),
+		modelClient:  modelRoot.Client(),
+	}, nil
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	modelDetails, err := c.ClientStore().ModelByName(c.ControllerName(), c.ModelName())
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 3 {
+		return errors.New("exporting models is not supported by this controller")
+	}
+
+	serialized, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+
+	filename := ctx.AbsPath(c.filename)
+	// The archive holds the model's secrets, such as its cloud
+	// credential and agent passwords, so only the user may read it.
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = coremigration.WriteArchive(f, serialized, client)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return errors.Annotate(err, "writing model archive")
+	}
+	fmt.Fprintf(ctx.Stdout, "Model %q exported to %s\n", c.ModelName(), filename)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeExportModelClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+type fakeExportModelClient struct {
+	gitjujutesting.Stub
+	version    int
+	serialized coremigration.SerializedModel
+}
+
+func (f *fakeExportModelClient) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelClient) ExportModel(model names.ModelTag) (coremigration.SerializedModel, error) {
+	f.MethodCall(f, "ExportModel", model)
+	if err := f.NextErr(); err != nil {
+		return coremigration.SerializedModel{}, err
+	}
+	return f.serialized, nil
+}
+
+func (f *fakeExportModelClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl.String())
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader("charm " + curl.String())), nil
+}
+
+func (f *fakeExportModelClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader("tools " + uri)), nil
+}
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportModelClient{
+		version: 3,
+		serialized: coremigration.SerializedModel{
+			Bytes:  []byte("model data"),
+			Charms: []string{"cs:trusty/mysql-3"},
+			Tools: map[version.Binary]string{
+				version.MustParseBinary("2.0.0-trusty-amd64"): "/tools/2.0.0-trusty-amd64",
+			},
+		},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportModelCommandSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, model.NewExportModelCommandForTest(s.fake, s.store), args...)
+	return err
+}
+
+func (s *ExportModelCommandSuite) TestExport(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	err := s.run(c, filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"OpenCharm", []interface{}{"cs:trusty/mysql-3"}},
+		{"OpenURI", []interface{}{"/tools/2.0.0-trusty-amd64"}},
+		{"Close", nil},
+	})
+
+	f, err := os.Open(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := coremigration.ReadArchive(f, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(archive.Model), gc.Equals, "model data")
+	c.Check(archive.Charms, gc.HasLen, 1)
+	c.Check(archive.Tools, gc.HasLen, 1)
+}
+
+func (s *ExportModelCommandSuite) TestExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	err := s.run(c, filepath.Join(c.MkDir(), "mymodel.tar.gz"))
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+}
+
+func (s *ExportModelCommandSuite) TestExportNotSupported(c *gc.C) {
+	s.fake.version = 2
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	err := s.run(c, filename)
+	c.Assert(err, gc.ErrorMatches, "exporting models is not supported by this controller")
+	s.fake.CheckCallNames(c, "Close")
+	_, err = os.Stat(filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ExportModelCommandSuite) TestDownloadErrorRemovesFile(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	err := s.run(c, filename)
+	c.Assert(err, gc.ErrorMatches, "writing model archive: cannot open charm cs:trusty/mysql-3: boom")
+	_, err = os.Stat(filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ExportModelCommandSuite) TestInit(c *gc.C) {
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+	err = s.run(c, "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
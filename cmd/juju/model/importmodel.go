@@ -0,0 +1,290 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a fully constructed import-model
+// command.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api            ImportModelAPI
+	newUploaderAPI func(modelName string) (ModelBinariesUploaderAPI, error)
+
+	filename  string
+	modelName string
+	activate  bool
+}
+
+const importModelHelpDoc = `
+Imports a model archive written by "juju export-model" into the
+current controller (or the controller specified with -c), uploading
+the charms and tools it contains. The model keeps its name, owner and
+UUID, so it must not already exist in the controller.
+
+The imported model is not activated: the controller does not manage
+it until it is explicitly handed over with --activate. The model's
+agents are not redirected to the controller the model is imported
+into, and the source model keeps managing the same machines, so the
+model must only be activated once it has been destroyed on the source
+controller, or its agents have been stopped.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+    juju import-model --activate bob/mymodel
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Imports a model archive into a controller.",
+		Doc:     importModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.activate, "activate", false, "Activate a previously imported model, named instead of the archive file")
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		if c.activate {
+			return errors.New("no model specified")
+		}
+		return errors.New("no archive file specified")
+	}
+	if c.activate {
+		c.modelName = args[0]
+	} else {
+		c.filename = args[0]
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Import([]byte) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+}
+
+// ModelBinariesUploaderAPI specifies the used function calls of the
+// imported model's API client.
+type ModelBinariesUploaderAPI interface {
+	Close() error
+	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
+	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+}
+
+// migrationTargetClient adds the Close method of the API connection
+// to the MigrationTarget facade client.
+type migrationTargetClient struct {
+	*migrationtarget.Client
+	conn api.Connection
+}
+
+// Close implements ImportModelAPI.
+func (c *migrationTargetClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return &migrationTargetClient{
+		Client: migrationtarget.NewClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *importModelCommand) getUploaderAPI(modelName string) (ModelBinariesUploaderAPI, error) {
+	if c.newUploaderAPI != nil {
+		return c.newUploaderAPI(modelName)
+	}
+	conn, err := c.NewModelAPIRoot(modelName)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening model API connection")
+	}
+	return conn.Client(), nil
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	if c.activate {
+		return c.activateModel(ctx)
+	}
+	dir, err := ioutil.TempDir("", "juju-import-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	archive, err := c.readArchive(ctx.AbsPath(c.filename), dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	model, err := description.Deserialize(archive.Model)
+	if err != nil {
+		return errors.Annotate(err, "reading model from archive")
+	}
+	modelUUID := model.Tag().Id()
+	modelName, _ := model.Config()["name"].(string)
+	if modelName == "" {
+		return errors.New("model in archive has no name")
+	}
+	qualifiedName := jujuclient.JoinOwnerModelName(model.Owner(), modelName)
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Import(archive.Model); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+
+	// The model is recorded in the client store so that a connection
+	// can be opened to it to upload its binaries.
+	store := c.ClientStore()
+	err = store.UpdateModel(c.ControllerName(), qualifiedName, jujuclient.ModelDetails{ModelUUID: modelUUID})
+	if err == nil {
+		err = c.uploadBinaries(qualifiedName, archive)
+	}
+	if err != nil {
+		if abortErr := client.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("cannot abort import of model %q: %v", qualifiedName, abortErr)
+		}
+		if removeErr := store.RemoveModel(c.ControllerName(), qualifiedName); removeErr != nil && !errors.IsNotFound(removeErr) {
+			logger.Errorf("cannot remove model %q from client store: %v", qualifiedName, removeErr)
+		}
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "Model %q imported into controller %q but not activated.\n", qualifiedName, c.ControllerName())
+	fmt.Fprintf(ctx.Stdout, "Once the model has been destroyed on the source controller, hand it over with:\n")
+	fmt.Fprintf(ctx.Stdout, "    juju import-model -c %s --activate %s\n", c.ControllerName(), qualifiedName)
+	return nil
+}
+
+// activateModel hands a previously imported model over to the
+// controller, which starts managing it.
+func (c *importModelCommand) activateModel(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	accountDetails, err := store.AccountDetails(c.ControllerName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelName := c.modelName
+	if !jujuclient.IsQualifiedModelName(modelName) {
+		modelName = jujuclient.JoinOwnerModelName(names.NewUserTag(accountDetails.User), modelName)
+	}
+	details, err := store.ModelByName(c.ControllerName(), modelName)
+	if err != nil {
+		return errors.Annotatef(err, "getting details of model %q", modelName)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Activate(details.ModelUUID); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	fmt.Fprintf(ctx.Stdout, "Model %q activated in controller %q\n", modelName, c.ControllerName())
+	return nil
+}
+
+func (c *importModelCommand) readArchive(filename, dir string) (*coremigration.Archive, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	return coremigration.ReadArchive(f, dir)
+}
+
+func (c *importModelCommand) uploadBinaries(modelName string, archive *coremigration.Archive) error {
+	uploader, err := c.getUploaderAPI(modelName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer uploader.Close()
+
+	for charmURL, path := range archive.Charms {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = uploadFile(path, func(r io.ReadSeeker) error {
+			_, err := uploader.UploadCharm(curl, r)
+			return err
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload charm %s", curl)
+		}
+	}
+	for v, path := range archive.Tools {
+		err := uploadFile(path, func(r io.ReadSeeker) error {
+			_, err := uploader.UploadTools(r, v)
+			return err
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload tools %s", v)
+		}
+	}
+	return nil
+}
+
+func uploadFile(path string, upload func(io.ReadSeeker) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	return errors.Trace(upload(f))
+}
@@ -0,0 +1,232 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake     *fakeImportModelClient
+	store    *jujuclienttesting.MemStore
+	filename string
+	tools    version.Binary
+}
+
+var _ = gc.Suite(&ImportModelCommandSuite{})
+
+type fakeImportModelClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeImportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.MethodCall(f, "UploadCharm", curl.String(), string(content))
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadTools(r io.ReadSeeker, v version.Binary, series ...string) (tools.List, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.MethodCall(f, "UploadTools", v, string(content))
+	return nil, f.NextErr()
+}
+
+// archiveSource serves the binaries written to the test model archive.
+type archiveSource struct{}
+
+func (archiveSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("charm")), nil
+}
+
+func (archiveSource) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("tools")), nil
+}
+
+func (s *ImportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeImportModelClient{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	bytes, err := description.Serialize(description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"name": "mymodel",
+			"uuid": testing.ModelTag.Id(),
+		},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.tools = version.MustParseBinary("2.0.0-trusty-amd64")
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	f, err := os.Create(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = coremigration.WriteArchive(f, coremigration.SerializedModel{
+		Bytes:  bytes,
+		Charms: []string{"cs:trusty/mysql-3"},
+		Tools:  map[version.Binary]string{s.tools: "/tools/2.0.0-trusty-amd64"},
+	}, archiveSource{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportModelCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	command := model.NewImportModelCommandForTest(s.fake, s.fake, s.store)
+	ctx, err := testing.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), nil
+}
+
+func (s *ImportModelCommandSuite) TestImport(c *gc.C) {
+	out, err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, `
+Model "bob/mymodel" imported into controller "testing" but not activated.
+Once the model has been destroyed on the source controller, hand it over with:
+    juju import-model -c testing --activate bob/mymodel
+`[1:])
+	// The model must not be activated while the source model is
+	// still managing its machines.
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Import", nil},
+		{"UploadCharm", []interface{}{"cs:trusty/mysql-3", "charm"}},
+		{"UploadTools", []interface{}{s.tools, "tools"}},
+		{"Close", nil},
+		{"Close", nil},
+	})
+
+	details, err := s.store.ModelByName("testing", "bob/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.ModelUUID, gc.Equals, testing.ModelTag.Id())
+}
+
+func (s *ImportModelCommandSuite) TestImportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "importing model: boom")
+	s.fake.CheckCallNames(c, "Import", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestUploadErrorAborts(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "cannot upload charm cs:trusty/mysql-3: boom")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Import", nil},
+		{"UploadCharm", []interface{}{"cs:trusty/mysql-3", "charm"}},
+		{"Close", nil},
+		{"Abort", []interface{}{testing.ModelTag.Id()}},
+		{"Close", nil},
+	})
+
+	_, err = s.store.ModelByName("testing", "bob/mymodel")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ImportModelCommandSuite) TestBadArchive(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "junk")
+	err := ioutil.WriteFile(filename, []byte("junk"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, filename)
+	c.Assert(err, gc.ErrorMatches, "reading model archive: .*")
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ImportModelCommandSuite) TestActivate(c *gc.C) {
+	err := s.store.UpdateModel("testing", "bob/mymodel", jujuclient.ModelDetails{ModelUUID: testing.ModelTag.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := s.run(c, "--activate", "bob/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, "Model \"bob/mymodel\" activated in controller \"testing\"\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Activate", []interface{}{testing.ModelTag.Id()}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportModelCommandSuite) TestActivateUnqualifiedName(c *gc.C) {
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{ModelUUID: testing.ModelTag.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := s.run(c, "--activate", "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, "Model \"admin/mymodel\" activated in controller \"testing\"\n")
+	s.fake.CheckCall(c, 0, "Activate", testing.ModelTag.Id())
+}
+
+func (s *ImportModelCommandSuite) TestActivateUnknownModel(c *gc.C) {
+	_, err := s.run(c, "--activate", "bob/mymodel")
+	c.Assert(err, gc.ErrorMatches, `getting details of model "bob/mymodel": .*not found`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ImportModelCommandSuite) TestActivateError(c *gc.C) {
+	err := s.store.UpdateModel("testing", "bob/mymodel", jujuclient.ModelDetails{ModelUUID: testing.ModelTag.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.SetErrors(errors.New("boom"))
+	_, err = s.run(c, "--activate", "bob/mymodel")
+	c.Assert(err, gc.ErrorMatches, "activating model: boom")
+	s.fake.CheckCallNames(c, "Activate", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+	_, err = s.run(c, "--activate")
+	c.Assert(err, gc.ErrorMatches, "no model specified")
+	_, err = s.run(c, "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
@@ -0,0 +1,225 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationmaster"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// NewShowMigrationCommand returns a fully constructed show-migration
+// command.
+func NewShowMigrationCommand() cmd.Command {
+	return modelcmd.Wrap(&showMigrationCommand{})
+}
+
+type showMigrationCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ShowMigrationAPI
+
+	isoTime bool
+}
+
+const showMigrationHelpDoc = `
+Shows the progress of the latest migration of the current model (or
+the model specified with -m): when the migration entered each phase,
+how many of the model's agents have reported success or failure for
+the current phase, and how many bytes of charms, tools and resources
+have been sent to the target controller.
+
+Examples:
+
+    juju show-migration
+    juju show-migration -m mymodel --format json
+
+See also:
+    migrate
+`
+
+// Info implements Command.
+func (c *showMigrationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-migration",
+		Purpose: "Shows the progress of a model migration.",
+		Doc:     showMigrationHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *showMigrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationTabular,
+	})
+}
+
+// Init implements Command.
+func (c *showMigrationCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ShowMigrationAPI specifies the used function calls of the
+// MigrationMaster facade.
+type ShowMigrationAPI interface {
+	Close() error
+	MigrationProgress() (coremigration.MigrationProgress, error)
+}
+
+// migrationMasterClient adds the Close method of the API connection
+// to the MigrationMaster facade client.
+type migrationMasterClient struct {
+	*migrationmaster.Client
+	conn api.Connection
+}
+
+// Close implements ShowMigrationAPI.
+func (c *migrationMasterClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *showMigrationCommand) getAPI() (ShowMigrationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return &migrationMasterClient{
+		Client: migrationmaster.NewClient(conn, nil),
+		conn:   conn,
+	}, nil
+}
+
+// Run implements Command.
+func (c *showMigrationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	progress, err := client.MigrationProgress()
+	if params.IsCodeNotFound(err) {
+		return errors.Errorf("model %q has no migrations", c.ModelName())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, c.formatProgress(progress))
+}
+
+// migrationProgress is the serialisation structure for the output of
+// show-migration.
+type migrationProgress struct {
+	MigrationId      string                  `yaml:"migration-id" json:"migration-id"`
+	TargetController string                  `yaml:"target-controller" json:"target-controller"`
+	Phase            string                  `yaml:"phase" json:"phase"`
+	Message          string                  `yaml:"message,omitempty" json:"message,omitempty"`
+	Phases           []migrationPhaseTime    `yaml:"phases" json:"phases"`
+	MinionReports    []migrationMinionCounts `yaml:"minion-reports,omitempty" json:"minion-reports,omitempty"`
+	Transferred      map[string]int64        `yaml:"transferred-bytes" json:"transferred-bytes"`
+}
+
+type migrationPhaseTime struct {
+	Phase   string `yaml:"phase" json:"phase"`
+	Entered string `yaml:"entered" json:"entered"`
+}
+
+type migrationMinionCounts struct {
+	Kind      string `yaml:"kind" json:"kind"`
+	Succeeded int    `yaml:"succeeded" json:"succeeded"`
+	Failed    int    `yaml:"failed" json:"failed"`
+	Pending   int    `yaml:"pending" json:"pending"`
+}
+
+// transferKinds holds the kinds of binary reported by show-migration,
+// in display order. They're always shown, even if nothing of that
+// kind has been sent yet.
+var transferKinds = []string{
+	coremigration.TransferCharms,
+	coremigration.TransferTools,
+	coremigration.TransferResources,
+}
+
+func (c *showMigrationCommand) formatProgress(in coremigration.MigrationProgress) migrationProgress {
+	out := migrationProgress{
+		MigrationId:      in.MigrationId,
+		TargetController: in.TargetControllerTag.Id(),
+		Phase:            in.Phase.String(),
+		Message:          in.StatusMessage,
+		Phases:           make([]migrationPhaseTime, len(in.PhaseTimes)),
+		Transferred:      make(map[string]int64),
+	}
+	for i, pt := range in.PhaseTimes {
+		t := pt.Time
+		out.Phases[i] = migrationPhaseTime{
+			Phase:   pt.Phase.String(),
+			Entered: common.FormatTime(&t, c.isoTime),
+		}
+	}
+	for _, counts := range in.MinionCounts {
+		out.MinionReports = append(out.MinionReports, migrationMinionCounts{
+			Kind:      counts.Kind,
+			Succeeded: counts.Succeeded,
+			Failed:    counts.Failed,
+			Pending:   counts.Pending,
+		})
+	}
+	for _, kind := range transferKinds {
+		out.Transferred[kind] = 0
+	}
+	for kind, count := range in.TransferredBytes {
+		out.Transferred[kind] = count
+	}
+	return out
+}
+
+func formatMigrationTabular(writer io.Writer, value interface{}) error {
+	progress, ok := value.(migrationProgress)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", progress, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Migration", "Target controller", "Phase", "Message")
+	w.Println(progress.MigrationId, progress.TargetController, progress.Phase, progress.Message)
+
+	w.Println()
+	w.Println("Phase", "Entered")
+	for _, pt := range progress.Phases {
+		w.Println(pt.Phase, pt.Entered)
+	}
+
+	if len(progress.MinionReports) > 0 {
+		w.Println()
+		w.Println("Agents", "Succeeded", "Failed", "Pending")
+		for _, counts := range progress.MinionReports {
+			w.Println(counts.Kind, counts.Succeeded, counts.Failed, counts.Pending)
+		}
+	}
+
+	w.Println()
+	w.Println("Transferred", "Bytes")
+	for _, kind := range transferKinds {
+		w.Println(kind, progress.Transferred[kind])
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ShowMigrationCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeShowMigrationClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ShowMigrationCommandSuite{})
+
+type fakeShowMigrationClient struct {
+	gitjujutesting.Stub
+	progress coremigration.MigrationProgress
+}
+
+func (f *fakeShowMigrationClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeShowMigrationClient) MigrationProgress() (coremigration.MigrationProgress, error) {
+	f.MethodCall(f, "MigrationProgress")
+	if err := f.NextErr(); err != nil {
+		return coremigration.MigrationProgress{}, err
+	}
+	return f.progress, nil
+}
+
+func (s *ShowMigrationCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	t0 := time.Date(2016, 6, 22, 16, 0, 0, 0, time.UTC)
+	s.fake = &fakeShowMigrationClient{
+		progress: coremigration.MigrationProgress{
+			MigrationId:         "id",
+			TargetControllerTag: testing.ControllerTag,
+			Phase:               coremigration.IMPORT,
+			StatusMessage:       "importing",
+			PhaseTimes: []coremigration.PhaseTime{
+				{Phase: coremigration.QUIESCE, Time: t0},
+				{Phase: coremigration.IMPORT, Time: t0.Add(time.Minute)},
+			},
+			MinionCounts: []coremigration.MinionCounts{
+				{Kind: "machine", Succeeded: 2, Pending: 1},
+				{Kind: "unit", Succeeded: 1, Failed: 1, Pending: 1},
+			},
+			TransferredBytes: map[string]int64{
+				"charms": 2048,
+				"tools":  4096,
+			},
+		},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ShowMigrationCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, model.NewShowMigrationCommandForTest(s.fake, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), nil
+}
+
+func (s *ShowMigrationCommandSuite) TestTabular(c *gc.C) {
+	out, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "MigrationProgress", "Close")
+	c.Assert(out, gc.Equals, `
+Migration  Target controller                     Phase   Message
+id         deadbeef-1bad-500d-9000-4b1d0d06f00d  IMPORT  importing
+
+Phase    Entered
+QUIESCE  2016-06-22 16:00:00Z
+IMPORT   2016-06-22 16:01:00Z
+
+Agents   Succeeded  Failed  Pending
+machine  2          0       1
+unit     1          1       1
+
+Transferred  Bytes
+charms       2048
+tools        4096
+resources    0
+`[1:])
+}
+
+func (s *ShowMigrationCommandSuite) TestYAML(c *gc.C) {
+	out, err := s.run(c, "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+migration-id: id
+target-controller: deadbeef-1bad-500d-9000-4b1d0d06f00d
+phase: IMPORT
+message: importing
+phases:
+- phase: QUIESCE
+  entered: 2016-06-22 16:00:00Z
+- phase: IMPORT
+  entered: 2016-06-22 16:01:00Z
+minion-reports:
+- kind: machine
+  succeeded: 2
+  failed: 0
+  pending: 1
+- kind: unit
+  succeeded: 1
+  failed: 1
+  pending: 1
+transferred-bytes:
+  charms: 2048
+  resources: 0
+  tools: 4096
+`[1:])
+}
+
+func (s *ShowMigrationCommandSuite) TestNoMigration(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Code: params.CodeNotFound, Message: "migration not found"})
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, `model "admin/mymodel" has no migrations`)
+	s.fake.CheckCallNames(c, "MigrationProgress", "Close")
+}
+
+func (s *ShowMigrationCommandSuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ShowMigrationCommandSuite) TestUnexpectedArgs(c *gc.C) {
+	_, err := s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
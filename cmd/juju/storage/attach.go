@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAttachStorageCommand returns a command used to attach existing
+// storage to a unit.
+func NewAttachStorageCommand() cmd.Command {
+	cmd := &attachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageAttachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	attachStorageCommandDoc = `
+Attaches existing storage to a unit. Specify a unit and one or more
+storage IDs to attach to it. The storage must not be attached to any
+other unit, and must be compatible with the storage declared by the
+unit's charm.
+
+Examples:
+    juju attach-storage postgresql/1 pgdata/0
+`
+	attachStorageCommandArgs = `<unit> <storage> [<storage> ...]`
+)
+
+// attachStorageCommand attaches existing storage instances to a unit.
+type attachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageAttachAPI, error)
+	unitId     string
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *attachStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("attach-storage requires a unit ID and at least one storage ID")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	for _, id := range args[1:] {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.unitId = args[0]
+	c.storageIds = args[1:]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *attachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "attach-storage",
+		Purpose: "Attaches existing storage to a unit.",
+		Doc:     attachStorageCommandDoc,
+		Args:    attachStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *attachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Attach(c.unitId, c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "attach storage")
+		}
+		return err
+	}
+	return storageResultsError("attach", c.storageIds, results)
+}
+
+// StorageAttachAPI defines the API methods that the attach-storage
+// command uses.
+type StorageAttachAPI interface {
+	Close() error
+	Attach(unitId string, storageIds []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type AttachStorageSuite struct {
+	SubStorageSuite
+	mockAPI *mockAttachAPI
+}
+
+var _ = gc.Suite(&AttachStorageSuite{})
+
+func (s *AttachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockAttachAPI{
+		attach: func(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+			return make([]params.ErrorResult, len(storageIds)), nil
+		},
+	}
+}
+
+func (s *AttachStorageSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        nil,
+		expectedErr: "attach-storage requires a unit ID and at least one storage ID",
+	}, {
+		args:        []string{"foo/0"},
+		expectedErr: "attach-storage requires a unit ID and at least one storage ID",
+	}, {
+		args:        []string{"foo-0", "bar/1"},
+		expectedErr: `unit name "foo-0" not valid`,
+	}, {
+		args:        []string{"foo/0", "bar-1"},
+		expectedErr: `storage ID "bar-1" not valid`,
+	}} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *AttachStorageSuite) TestAttach(c *gc.C) {
+	var unit string
+	var attached []string
+	s.mockAPI.attach = func(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+		unit = unitId
+		attached = storageIds
+		return make([]params.ErrorResult, len(storageIds)), nil
+	}
+	_, err := s.run(c, "foo/0", "bar/1", "baz/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit, gc.Equals, "foo/0")
+	c.Assert(attached, jc.DeepEquals, []string{"bar/1", "baz/2"})
+}
+
+func (s *AttachStorageSuite) TestAttachError(c *gc.C) {
+	s.mockAPI.attach = func(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := s.run(c, "foo/0", "bar/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *AttachStorageSuite) TestAttachFailures(c *gc.C) {
+	s.mockAPI.attach = func(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+		return []params.ErrorResult{
+			{Error: &params.Error{Message: "foo"}},
+			{},
+		}, nil
+	}
+	_, err := s.run(c, "foo/0", "bar/1", "baz/2")
+	c.Assert(err, gc.ErrorMatches, "failed to attach storage bar/1: foo")
+}
+
+func (s *AttachStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewAttachStorageCommandForTest(s.mockAPI, s.store), args...)
+}
+
+type mockAttachAPI struct {
+	attach func(string, []string) ([]params.ErrorResult, error)
+}
+
+func (*mockAttachAPI) Close() error {
+	return nil
+}
+
+func (m *mockAttachAPI) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	return m.attach(unitId, storageIds)
+}
@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDetachStorageCommand returns a command used to detach storage
+// from the units it is attached to.
+func NewDetachStorageCommand() cmd.Command {
+	cmd := &detachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageDetachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	detachStorageCommandDoc = `
+Detaches storage from units. Specify one or more storage IDs, as output by
+"juju storage". The storage will remain in the model until it is removed
+by an operator, and may be attached to another unit with attach-storage.
+
+Storage that is attached to a machine, and which cannot be detached from
+that machine (such as loop devices), cannot be detached from its unit.
+Detaching storage will fail if the unit's charm requires the storage.
+
+Examples:
+    juju detach-storage pgdata/0
+`
+	detachStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// detachStorageCommand detaches storage instances from their units.
+type detachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageDetachAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *detachStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("detach-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *detachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Purpose: "Detaches storage from units.",
+		Doc:     detachStorageCommandDoc,
+		Args:    detachStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *detachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Detach(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "detach storage")
+		}
+		return err
+	}
+	return storageResultsError("detach", c.storageIds, results)
+}
+
+// StorageDetachAPI defines the API methods that the detach-storage
+// command uses.
+type StorageDetachAPI interface {
+	Close() error
+	Detach(storageIds []string) ([]params.ErrorResult, error)
+}
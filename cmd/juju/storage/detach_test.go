@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type DetachStorageSuite struct {
+	SubStorageSuite
+	mockAPI *mockDetachAPI
+}
+
+var _ = gc.Suite(&DetachStorageSuite{})
+
+func (s *DetachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockDetachAPI{
+		detach: func(storageIds []string) ([]params.ErrorResult, error) {
+			return make([]params.ErrorResult, len(storageIds)), nil
+		},
+	}
+}
+
+func (s *DetachStorageSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        nil,
+		expectedErr: "detach-storage requires at least one storage ID",
+	}, {
+		args:        []string{"foo/bar"},
+		expectedErr: `storage ID "foo/bar" not valid`,
+	}} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *DetachStorageSuite) TestDetach(c *gc.C) {
+	var detached []string
+	s.mockAPI.detach = func(storageIds []string) ([]params.ErrorResult, error) {
+		detached = storageIds
+		return make([]params.ErrorResult, len(storageIds)), nil
+	}
+	_, err := s.run(c, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(detached, jc.DeepEquals, []string{"foo/0", "bar/1"})
+}
+
+func (s *DetachStorageSuite) TestDetachError(c *gc.C) {
+	s.mockAPI.detach = func(storageIds []string) ([]params.ErrorResult, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := s.run(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DetachStorageSuite) TestDetachFailures(c *gc.C) {
+	s.mockAPI.detach = func(storageIds []string) ([]params.ErrorResult, error) {
+		return []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "foo"}},
+			{Error: &params.Error{Message: "bar"}},
+		}, nil
+	}
+	_, err := s.run(c, "baz/0", "qux/1", "quux/2")
+	c.Assert(err, gc.ErrorMatches, "failed to detach storage qux/1: foo\nfailed to detach storage quux/2: bar")
+}
+
+func (s *DetachStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachStorageCommandForTest(s.mockAPI, s.store), args...)
+}
+
+type mockDetachAPI struct {
+	detach func([]string) ([]params.ErrorResult, error)
+}
+
+func (*mockDetachAPI) Close() error {
+	return nil
+}
+
+func (m *mockDetachAPI) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	return m.detach(storageIds)
+}
@@ -54,3 +54,83 @@ func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.C
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewAttachStorageCommandForTest(api StorageAttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &attachStorageCommand{newAPIFunc: func() (StorageAttachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewDetachStorageCommandForTest(api StorageDetachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachStorageCommand{newAPIFunc: func() (StorageDetachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveStorageCommandForTest(api StorageRemoveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeStorageCommand{newAPIFunc: func() (StorageRemoveAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewImportFilesystemCommandForTest(api StorageImporter, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importFilesystemCommand{newAPIFunc: func() (StorageImporter, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewCreateSnapshotCommandForTest(api StorageCreateSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createSnapshotCommand{newAPIFunc: func() (StorageCreateSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListSnapshotsCommandForTest(api StorageListSnapshotsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listSnapshotsCommand{newAPIFunc: func() (StorageListSnapshotsAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRestoreSnapshotCommandForTest(api StorageRestoreSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &restoreSnapshotCommand{newAPIFunc: func() (StorageRestoreSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeStorageCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewPoolUpdateCommandForTest(api PoolUpdateAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &poolUpdateCommand{newAPIFunc: func() (PoolUpdateAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewPoolRemoveCommandForTest(api PoolRemoveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &poolRemoveCommand{newAPIFunc: func() (PoolRemoveAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
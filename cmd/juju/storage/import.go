@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+// NewImportFilesystemCommand returns a command used to import a
+// filesystem into the model.
+func NewImportFilesystemCommand() cmd.Command {
+	cmd := &importFilesystemCommand{}
+	cmd.newAPIFunc = func() (StorageImporter, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	importFilesystemCommandDoc = `
+Import an existing filesystem into the model. This will lead to the model
+taking ownership of the storage, so you must take care not to import storage
+that is in use by another Juju model.
+
+To import a filesystem, you must specify three things:
+
+ - the storage pool which identifies the storage provider
+   which manages the storage, and with which the storage
+   will be associated
+ - the storage provider ID for the filesystem, or
+   volume that backs the filesystem
+ - the storage name to assign to the filesystem,
+   corresponding to the storage name used by a charm
+
+Once a filesystem is imported, Juju will create an associated storage
+instance using the given storage name. The storage instance is detached,
+and may be attached to a unit with "juju attach-storage".
+
+Examples:
+    # Import an existing EBS volume, which contains a filesystem,
+    # as storage named "pgdata".
+    juju import-filesystem ebs vol-123456 pgdata
+`
+	importFilesystemCommandArgs = `<storage-pool> <storage-provider-id> <storage-name>`
+)
+
+// importFilesystemCommand imports filesystems into the model.
+type importFilesystemCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageImporter, error)
+
+	storagePool       string
+	storageProviderId string
+	storageName       string
+}
+
+// Init implements Command.Init.
+func (c *importFilesystemCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("import-filesystem requires a storage pool, provider ID, and storage name")
+	}
+	c.storagePool = args[0]
+	c.storageProviderId = args[1]
+	c.storageName = args[2]
+
+	// Storage instance IDs are formed from the storage
+	// name and a sequence number.
+	if !names.IsValidStorage(c.storageName + "/0") {
+		return errors.NotValidf("storage name %q", c.storageName)
+	}
+	return cmd.CheckEmpty(args[3:])
+}
+
+// Info implements Command.Info.
+func (c *importFilesystemCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-filesystem",
+		Purpose: "Imports a filesystem into the model.",
+		Doc:     importFilesystemCommandDoc,
+		Args:    importFilesystemCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *importFilesystemCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ctx.Infof(
+		"importing %q from storage pool %q as storage %q",
+		c.storageProviderId, c.storagePool, c.storageName,
+	)
+	storageTag, err := api.Import(
+		storage.StorageKindFilesystem,
+		c.storagePool,
+		c.storageProviderId,
+		c.storageName,
+	)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "import filesystem")
+		}
+		return err
+	}
+	ctx.Infof("imported storage %s", storageTag.Id())
+	return nil
+}
+
+// StorageImporter defines the API methods that the import-filesystem
+// command uses.
+type StorageImporter interface {
+	Close() error
+	Import(
+		kind storage.StorageKind,
+		storagePool string,
+		storageProviderId string,
+		storageName string,
+	) (names.StorageTag, error)
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ImportFilesystemSuite struct {
+	SubStorageSuite
+	importer *mockStorageImporter
+}
+
+var _ = gc.Suite(&ImportFilesystemSuite{})
+
+func (s *ImportFilesystemSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.importer = &mockStorageImporter{
+		importStorage: func(kind jujustorage.StorageKind, pool, providerId, storageName string) (names.StorageTag, error) {
+			return names.NewStorageTag(storageName + "/0"), nil
+		},
+	}
+}
+
+func (s *ImportFilesystemSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        []string{"foo", "bar"},
+		expectedErr: "import-filesystem requires a storage pool, provider ID, and storage name",
+	}, {
+		args:        []string{"foo", "bar", "123"},
+		expectedErr: `storage name "123" not valid`,
+	}, {
+		args:        []string{"foo", "bar", "baz", "qux"},
+		expectedErr: `unrecognized args: \["qux"\]`,
+	}} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *ImportFilesystemSuite) TestImportSuccess(c *gc.C) {
+	var args []interface{}
+	s.importer.importStorage = func(kind jujustorage.StorageKind, pool, providerId, storageName string) (names.StorageTag, error) {
+		args = []interface{}{kind, pool, providerId, storageName}
+		return names.NewStorageTag("pgdata/0"), nil
+	}
+	ctx, err := s.run(c, "foo", "bar", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args, jc.DeepEquals, []interface{}{jujustorage.StorageKindFilesystem, "foo", "bar", "pgdata"})
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+importing "bar" from storage pool "foo" as storage "pgdata"
+imported storage pgdata/0
+`[1:])
+}
+
+func (s *ImportFilesystemSuite) TestImportError(c *gc.C) {
+	s.importer.importStorage = func(kind jujustorage.StorageKind, pool, providerId, storageName string) (names.StorageTag, error) {
+		return names.StorageTag{}, errors.New("nope")
+	}
+	ctx, err := s.run(c, "foo", "bar", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+importing "bar" from storage pool "foo" as storage "pgdata"
+`[1:])
+}
+
+func (s *ImportFilesystemSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(s.importer, s.store), args...)
+}
+
+type mockStorageImporter struct {
+	importStorage func(jujustorage.StorageKind, string, string, string) (names.StorageTag, error)
+}
+
+func (*mockStorageImporter) Close() error {
+	return nil
+}
+
+func (m *mockStorageImporter) Import(
+	kind jujustorage.StorageKind,
+	storagePool, storageProviderId, storageName string,
+) (names.StorageTag, error) {
+	return m.importStorage(kind, storagePool, storageProviderId, storageName)
+}
@@ -72,6 +72,9 @@ storage:
       current: attached
       since: .*
     persistent: true
+    usage:
+      capacity: 1073741824
+      used: 268435456
     attachments:
       units:
         postgresql/0:
@@ -171,6 +174,7 @@ func (s mockListAPI) ListStorageDetails() ([]params.StorageDetails, error) {
 			Since:  &epoch,
 		},
 		Persistent: true,
+		Usage:      &params.StorageUsage{Capacity: 1073741824, Used: 268435456},
 		Attachments: map[string]params.StorageAttachmentDetails{
 			"unit-postgresql-0": params.StorageAttachmentDetails{
 				Location: "hither",
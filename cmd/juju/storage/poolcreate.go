@@ -36,6 +36,14 @@ into named resources that can be used during deployment.
 Pools defined at the model level are easily reused across applications.
 Pool creation requires a pool name, the provider type and attributes for
 configuration as space-separated pairs, e.g. tags, size, path, etc.
+
+Volumes created in a pool of the "loop" provider may be encrypted at rest
+by setting the "encrypted" attribute to true. Encryption keys are held by
+the controller, and delivered only to the machine to which a volume is
+attached.
+
+Examples:
+    juju create-storage-pool secure loop encrypted=true
 `
 
 // NewPoolCreateCommand returns a command that creates or defines a storage pool
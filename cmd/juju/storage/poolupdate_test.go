@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type PoolUpdateSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolUpdateAPI
+}
+
+var _ = gc.Suite(&PoolUpdateSuite{})
+
+func (s *PoolUpdateSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockPoolUpdateAPI{}
+}
+
+func (s *PoolUpdateSuite) runPoolUpdate(c *gc.C, args []string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewPoolUpdateCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateNoArgs(c *gc.C) {
+	_, err := s.runPoolUpdate(c, nil)
+	c.Check(err, gc.ErrorMatches, "pool update requires a pool name")
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateAttrMissingKey(c *gc.C) {
+	_, err := s.runPoolUpdate(c, []string{"sunshine", "=too"})
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "=too"`)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdate(c *gc.C) {
+	_, err := s.runPoolUpdate(c, []string{"sunshine", "something=too", "another=one"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.name, gc.Equals, "sunshine")
+	c.Assert(s.mockAPI.attrs, jc.DeepEquals, map[string]interface{}{
+		"something": "too",
+		"another":   "one",
+	})
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateNoAttrs(c *gc.C) {
+	_, err := s.runPoolUpdate(c, []string{"sunshine"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.name, gc.Equals, "sunshine")
+	c.Assert(s.mockAPI.attrs, gc.HasLen, 0)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateError(c *gc.C) {
+	s.mockAPI.err = errors.New("validating storage provider config: no good")
+	_, err := s.runPoolUpdate(c, []string{"sunshine", "something=too"})
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+}
+
+type mockPoolUpdateAPI struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+}
+
+func (*mockPoolUpdateAPI) Close() error {
+	return nil
+}
+
+func (m *mockPoolUpdateAPI) UpdatePool(pname string, pconfig map[string]interface{}) error {
+	m.name = pname
+	m.attrs = pconfig
+	return m.err
+}
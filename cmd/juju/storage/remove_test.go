@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveStorageSuite struct {
+	SubStorageSuite
+	mockAPI *mockRemoveAPI
+}
+
+var _ = gc.Suite(&RemoveStorageSuite{})
+
+func (s *RemoveStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockRemoveAPI{
+		remove: func(storageIds []string, release bool) ([]params.ErrorResult, error) {
+			return make([]params.ErrorResult, len(storageIds)), nil
+		},
+	}
+}
+
+func (s *RemoveStorageSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        nil,
+		expectedErr: "remove-storage requires at least one storage ID",
+	}, {
+		args:        []string{"foo"},
+		expectedErr: `storage ID "foo" not valid`,
+	}, {
+		args:        []string{"--destroy", "--release", "foo/0"},
+		expectedErr: "--destroy and --release cannot both be specified",
+	}} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *RemoveStorageSuite) TestRemove(c *gc.C) {
+	s.assertRemove(c, []string{"foo/0", "bar/1"}, false)
+	s.assertRemove(c, []string{"--destroy", "foo/0", "bar/1"}, false)
+	s.assertRemove(c, []string{"--release", "foo/0", "bar/1"}, true)
+}
+
+func (s *RemoveStorageSuite) assertRemove(c *gc.C, args []string, expectRelease bool) {
+	var removed []string
+	var released bool
+	s.mockAPI.remove = func(storageIds []string, release bool) ([]params.ErrorResult, error) {
+		removed = storageIds
+		released = release
+		return make([]params.ErrorResult, len(storageIds)), nil
+	}
+	_, err := s.run(c, args...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, jc.DeepEquals, []string{"foo/0", "bar/1"})
+	c.Assert(released, gc.Equals, expectRelease)
+}
+
+func (s *RemoveStorageSuite) TestRemoveError(c *gc.C) {
+	s.mockAPI.remove = func(storageIds []string, release bool) ([]params.ErrorResult, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := s.run(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *RemoveStorageSuite) TestRemoveFailures(c *gc.C) {
+	s.mockAPI.remove = func(storageIds []string, release bool) ([]params.ErrorResult, error) {
+		return []params.ErrorResult{
+			{Error: &params.Error{Message: "storage is still attached"}},
+		}, nil
+	}
+	_, err := s.run(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "failed to remove storage foo/0: storage is still attached")
+}
+
+func (s *RemoveStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewRemoveStorageCommandForTest(s.mockAPI, s.store), args...)
+}
+
+type mockRemoveAPI struct {
+	remove func([]string, bool) ([]params.ErrorResult, error)
+}
+
+func (*mockRemoveAPI) Close() error {
+	return nil
+}
+
+func (m *mockRemoveAPI) Remove(storageIds []string, release bool) ([]params.ErrorResult, error) {
+	return m.remove(storageIds, release)
+}
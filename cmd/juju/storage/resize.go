@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommand returns a command used to grow the volume
+// underlying a storage instance.
+func NewResizeStorageCommand() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows the volume underlying a storage instance to the specified size.
+Volumes may only be grown, never shrunk.
+
+The size is a number with an optional unit suffix of M, G, T, P or E
+(MiB by default). The resize is performed by the storage provisioner
+while the storage remains attached; once it has completed, the new size
+is reported by "juju show-storage" and by the storage-get hook tool, and
+the charm may then grow its filesystem.
+
+Not all storage providers support resizing volumes.
+
+Examples:
+    juju resize-storage pgdata/0 100G
+`
+	resizeStorageCommandArgs = `<storage> <size>`
+)
+
+// resizeStorageCommand grows the volume underlying a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse size %q", args[1])
+	}
+	if size == 0 {
+		return errors.NotValidf("size %q", args[1])
+	}
+	c.storageId = args[0]
+	c.size = size
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows the volume underlying a storage instance.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.Resize(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return errors.Annotatef(err, "failed to resize storage %s", c.storageId)
+	}
+	ctx.Infof("resizing storage %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	Resize(storageId string, size uint64) error
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeStorageSuite struct {
+	SubStorageSuite
+	api *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockResizeAPI{
+		resize: func(string, uint64) error {
+			return nil
+		},
+	}
+}
+
+func (s *ResizeStorageSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"foo/0"},
+		expect: "resize-storage requires a storage ID and a size",
+	}, {
+		args:   []string{"foo", "10G"},
+		expect: `storage ID "foo" not valid`,
+	}, {
+		args:   []string{"foo/0", "lots"},
+		expect: `cannot parse size "lots": .*`,
+	}, {
+		args:   []string{"foo/0", "0"},
+		expect: `size "0" not valid`,
+	}, {
+		args:   []string{"foo/0", "10G", "20G"},
+		expect: `unrecognized args: \["20G"\]`,
+	}} {
+		c.Logf("test %d: %q", i, t.args)
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expect)
+	}
+}
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	var storageId string
+	var size uint64
+	s.api.resize = func(id string, sz uint64) error {
+		storageId, size = id, sz
+		return nil
+	}
+	ctx, err := s.run(c, "foo/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageId, gc.Equals, "foo/0")
+	c.Assert(size, gc.Equals, uint64(10*1024))
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "resizing storage foo/0 to 10240MiB\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	s.api.resize = func(string, uint64) error {
+		return errors.New("resizing storage with storage provider \"tmpfs\" not supported")
+	}
+	_, err := s.run(c, "foo/0", "10G")
+	c.Assert(err, gc.ErrorMatches, `failed to resize storage foo/0: resizing storage with storage provider "tmpfs" not supported`)
+}
+
+func (s *ResizeStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), args...)
+}
+
+type mockResizeAPI struct {
+	resize func(string, uint64) error
+}
+
+func (*mockResizeAPI) Close() error {
+	return nil
+}
+
+func (m *mockResizeAPI) Resize(storageId string, size uint64) error {
+	return m.resize(storageId, size)
+}
@@ -76,6 +76,33 @@ shared-fs/0:
 	)
 }
 
+func (s *ShowSuite) TestShowUsage(c *gc.C) {
+	s.mockAPI.usage = &params.StorageUsage{Capacity: 1073741824, Used: 536870912}
+	s.assertValidShow(
+		c,
+		[]string{"shared-fs/0"},
+		`
+shared-fs/0:
+  kind: filesystem
+  status:
+    current: attached
+    since: 01 Jan 1970 08:00:00\+08:00
+  persistent: true
+  usage:
+    capacity: 1073741824
+    used: 536870912
+  attachments:
+    units:
+      transcode/0:
+        machine: \"1\"
+        location: a location
+      transcode/1:
+        machine: \"2\"
+        location: b location
+`[1:],
+	)
+}
+
 func (s *ShowSuite) TestShowInvalidId(c *gc.C) {
 	_, err := s.runShow(c, []string{"foo"})
 	c.Assert(err, gc.ErrorMatches, ".*invalid storage id foo.*")
@@ -132,6 +159,7 @@ func (s *ShowSuite) assertValidShow(c *gc.C, args []string, expected string) {
 
 type mockShowAPI struct {
 	noMatch bool
+	usage   *params.StorageUsage
 }
 
 func (s mockShowAPI) Close() error {
@@ -154,6 +182,7 @@ func (s mockShowAPI) StorageDetails(tags []names.StorageTag) ([]params.StorageDe
 					Since:  &epoch,
 				},
 				Persistent: true,
+				Usage:      s.usage,
 				Attachments: map[string]params.StorageAttachmentDetails{
 					"unit-transcode-0": params.StorageAttachmentDetails{
 						MachineTag: "machine-1",
@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotSuite struct {
+	SubStorageSuite
+	api     *mockSnapshotAPI
+	created time.Time
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+func (s *SnapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.created = time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC)
+	s.api = &mockSnapshotAPI{
+		createSnapshots: func(storageIds []string) ([]params.CreateStorageSnapshotResult, error) {
+			results := make([]params.CreateStorageSnapshotResult, len(storageIds))
+			for i, id := range storageIds {
+				results[i].Result = &params.StorageSnapshot{
+					SnapshotId: "snap-" + id,
+					Size:       1024,
+					Created:    s.created,
+				}
+			}
+			return results, nil
+		},
+		listSnapshots: func(storageId string) ([]params.StorageSnapshot, error) {
+			return []params.StorageSnapshot{{
+				SnapshotId: "snap-0",
+				Size:       1024,
+				Created:    s.created,
+			}, {
+				SnapshotId: "snap-1",
+				Size:       2048,
+				Created:    s.created.Add(time.Hour),
+			}}, nil
+		},
+		restoreSnapshot: func(storageId, snapshotId string) error {
+			return nil
+		},
+	}
+}
+
+func (s *SnapshotSuite) TestCreateInitErrors(c *gc.C) {
+	_, err := s.runCreate(c)
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+	_, err = s.runCreate(c, "foo/0", "bar")
+	c.Assert(err, gc.ErrorMatches, `storage ID "bar" not valid`)
+}
+
+func (s *SnapshotSuite) TestCreate(c *gc.C) {
+	ctx, err := s.runCreate(c, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+created snapshot snap-foo/0 of storage foo/0
+created snapshot snap-bar/1 of storage bar/1
+`[1:])
+}
+
+func (s *SnapshotSuite) TestCreatePending(c *gc.C) {
+	s.api.createSnapshots = func(storageIds []string) ([]params.CreateStorageSnapshotResult, error) {
+		return []params.CreateStorageSnapshotResult{
+			{Result: &params.StorageSnapshot{SnapshotId: "snap-0"}},
+			{Pending: true},
+		}, nil
+	}
+	ctx, err := s.runCreate(c, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+created snapshot snap-0 of storage foo/0
+requested snapshot of storage bar/1
+`[1:])
+}
+
+func (s *SnapshotSuite) TestCreateErrors(c *gc.C) {
+	s.api.createSnapshots = func(storageIds []string) ([]params.CreateStorageSnapshotResult, error) {
+		return []params.CreateStorageSnapshotResult{
+			{Result: &params.StorageSnapshot{SnapshotId: "snap-0"}},
+			{Error: &params.Error{Message: "snapshots not supported"}},
+		}, nil
+	}
+	ctx, err := s.runCreate(c, "foo/0", "bar/1")
+	c.Assert(err, gc.ErrorMatches, "failed to snapshot storage bar/1: snapshots not supported")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "created snapshot snap-0 of storage foo/0\n")
+
+	s.api.createSnapshots = func([]string) ([]params.CreateStorageSnapshotResult, error) {
+		return nil, errors.New("nope")
+	}
+	_, err = s.runCreate(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+func (s *SnapshotSuite) TestListInitErrors(c *gc.C) {
+	_, err := s.runList(c)
+	c.Assert(err, gc.ErrorMatches, "storage-snapshots requires a storage ID")
+	_, err = s.runList(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+	_, err = s.runList(c, "foo/0", "bar/1")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar/1"\]`)
+}
+
+func (s *SnapshotSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c, "foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+Snapshot  Size    Created
+snap-0    1.0GiB  2016-10-16 12:00:00Z
+snap-1    2.0GiB  2016-10-16 13:00:00Z
+
+`[1:])
+}
+
+func (s *SnapshotSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "foo/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- id: snap-0
+  size: 1024
+  created: 2016-10-16T12:00:00Z
+- id: snap-1
+  size: 2048
+  created: 2016-10-16T13:00:00Z
+`[1:])
+}
+
+func (s *SnapshotSuite) TestListEmpty(c *gc.C) {
+	s.api.listSnapshots = func(string) ([]params.StorageSnapshot, error) {
+		return nil, nil
+	}
+	ctx, err := s.runList(c, "foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No snapshots of storage foo/0 to display.\n")
+}
+
+func (s *SnapshotSuite) TestRestoreInitErrors(c *gc.C) {
+	_, err := s.runRestore(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "restore-storage-snapshot requires a storage ID and a snapshot ID")
+	_, err = s.runRestore(c, "foo", "snap-0")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+	_, err = s.runRestore(c, "foo/0", "snap-0", "snap-1")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["snap-1"\]`)
+}
+
+func (s *SnapshotSuite) TestRestore(c *gc.C) {
+	var args []string
+	s.api.restoreSnapshot = func(storageId, snapshotId string) error {
+		args = []string{storageId, snapshotId}
+		return nil
+	}
+	ctx, err := s.runRestore(c, "foo/0", "snap-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args, jc.DeepEquals, []string{"foo/0", "snap-0"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "restored storage foo/0 from snapshot snap-0\n")
+}
+
+func (s *SnapshotSuite) TestRestoreError(c *gc.C) {
+	s.api.restoreSnapshot = func(storageId, snapshotId string) error {
+		return errors.New("storage foo/0 is attached to machine 0")
+	}
+	_, err := s.runRestore(c, "foo/0", "snap-0")
+	c.Assert(err, gc.ErrorMatches, "failed to restore storage foo/0: storage foo/0 is attached to machine 0")
+}
+
+func (s *SnapshotSuite) runCreate(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store), args...)
+}
+
+func (s *SnapshotSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), args...)
+}
+
+func (s *SnapshotSuite) runRestore(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewRestoreSnapshotCommandForTest(s.api, s.store), args...)
+}
+
+type mockSnapshotAPI struct {
+	createSnapshots func([]string) ([]params.CreateStorageSnapshotResult, error)
+	listSnapshots   func(string) ([]params.StorageSnapshot, error)
+	restoreSnapshot func(string, string) error
+}
+
+func (*mockSnapshotAPI) Close() error {
+	return nil
+}
+
+func (m *mockSnapshotAPI) CreateSnapshots(storageIds []string) ([]params.CreateStorageSnapshotResult, error) {
+	return m.createSnapshots(storageIds)
+}
+
+func (m *mockSnapshotAPI) ListSnapshots(storageId string) ([]params.StorageSnapshot, error) {
+	return m.listSnapshots(storageId)
+}
+
+func (m *mockSnapshotAPI) RestoreSnapshot(storageId, snapshotId string) error {
+	return m.restoreSnapshot(storageId, snapshotId)
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCreateSnapshotCommand returns a command used to take snapshots
+// of storage.
+func NewCreateSnapshotCommand() cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageCreateSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	createSnapshotCommandDoc = `
+Takes a snapshot of storage. Specify one or more storage IDs, as output by
+"juju storage". A snapshot captures the contents of the volume underlying
+the storage, so that the storage can later be restored to that point with
+"juju restore-storage-snapshot"; for example, before upgrading a charm.
+
+Snapshots are supported by storage providers that manage volumes from the
+controller, such as EBS, and by the loop provider. Snapshots of loop volumes
+are taken by the agent on the volume's machine, and are listed by
+"juju storage-snapshots" once they have been taken. Filesystem storage may
+only be snapshotted if the filesystem is backed by a volume.
+
+Examples:
+    juju create-storage-snapshot pgdata/0
+`
+	createSnapshotCommandArgs = `<storage> [<storage> ...]`
+)
+
+// createSnapshotCommand takes snapshots of storage instances.
+type createSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageCreateSnapshotAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Takes snapshots of storage.",
+		Doc:     createSnapshotCommandDoc,
+		Args:    createSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	var failures []string
+	for i, result := range results {
+		if result.Error != nil {
+			failures = append(failures, fmt.Sprintf(
+				"failed to snapshot storage %s: %v",
+				c.storageIds[i], result.Error,
+			))
+			continue
+		}
+		if result.Pending {
+			ctx.Infof("requested snapshot of storage %s", c.storageIds[i])
+			continue
+		}
+		ctx.Infof("created snapshot %s of storage %s", result.Result.SnapshotId, c.storageIds[i])
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// StorageCreateSnapshotAPI defines the API methods that the
+// create-storage-snapshot command uses.
+type StorageCreateSnapshotAPI interface {
+	Close() error
+	CreateSnapshots(storageIds []string) ([]params.CreateStorageSnapshotResult, error)
+}
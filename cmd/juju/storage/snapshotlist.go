@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewListSnapshotsCommand returns a command used to list the
+// snapshots of storage.
+func NewListSnapshotsCommand() cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = func() (StorageListSnapshotsAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listSnapshotsCommandDoc = `
+Lists the snapshots taken of storage with "juju create-storage-snapshot",
+oldest first.
+
+Examples:
+    juju storage-snapshots pgdata/0
+`
+
+// listSnapshotsCommand lists the snapshots of a storage instance.
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageListSnapshotsAPI, error)
+	storageId  string
+	out        cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("storage-snapshots requires a storage ID")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageId = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists the snapshots of storage.",
+		Doc:     listSnapshotsCommandDoc,
+		Args:    "<storage>",
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	snapshots, err := api.ListSnapshots(c.storageId)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "list storage snapshots")
+		}
+		return err
+	}
+	if len(snapshots) == 0 {
+		ctx.Infof("No snapshots of storage %s to display.", c.storageId)
+		return nil
+	}
+	infos := make([]SnapshotInfo, len(snapshots))
+	for i, snapshot := range snapshots {
+		infos[i] = SnapshotInfo{
+			Id:      snapshot.SnapshotId,
+			Size:    snapshot.Size,
+			Created: snapshot.Created,
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// SnapshotInfo defines the serialization behaviour of a storage
+// snapshot.
+type SnapshotInfo struct {
+	Id      string    `yaml:"id" json:"id"`
+	Size    uint64    `yaml:"size" json:"size"`
+	Created time.Time `yaml:"created" json:"created"`
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage
+// snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.([]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Snapshot", "Size", "Created")
+	for _, snapshot := range snapshots {
+		print(
+			snapshot.Id,
+			humanize.IBytes(snapshot.Size*humanize.MiByte),
+			common.FormatTime(&snapshot.Created, true),
+		)
+	}
+	return tw.Flush()
+}
+
+// StorageListSnapshotsAPI defines the API methods that the
+// storage-snapshots command uses.
+type StorageListSnapshotsAPI interface {
+	Close() error
+	ListSnapshots(storageId string) ([]params.StorageSnapshot, error)
+}
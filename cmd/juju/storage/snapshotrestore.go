@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRestoreSnapshotCommand returns a command used to restore storage
+// from a snapshot.
+func NewRestoreSnapshotCommand() cmd.Command {
+	cmd := &restoreSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageRestoreSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	restoreSnapshotCommandDoc = `
+Restores storage from a snapshot, as output by "juju storage-snapshots".
+The current contents of the storage are replaced with the contents of the
+snapshot.
+
+The storage must be detached from its unit with "juju detach-storage" before
+it is restored, and may be attached again with "juju attach-storage" once
+the restore has completed. Storage that cannot be detached from its machine,
+such as loop storage, cannot be restored.
+
+Examples:
+    juju detach-storage pgdata/0
+    juju restore-storage-snapshot pgdata/0 snap-0123456789abcdef
+    juju attach-storage postgresql/0 pgdata/0
+`
+	restoreSnapshotCommandArgs = `<storage> <snapshot>`
+)
+
+// restoreSnapshotCommand restores a storage instance from a snapshot.
+type restoreSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageRestoreSnapshotAPI, error)
+	storageId  string
+	snapshotId string
+}
+
+// Init implements Command.Init.
+func (c *restoreSnapshotCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("restore-storage-snapshot requires a storage ID and a snapshot ID")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageId = args[0]
+	c.snapshotId = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *restoreSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-storage-snapshot",
+		Purpose: "Restores storage from a snapshot.",
+		Doc:     restoreSnapshotCommandDoc,
+		Args:    restoreSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *restoreSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.RestoreSnapshot(c.storageId, c.snapshotId); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "restore storage")
+		}
+		return errors.Annotatef(err, "failed to restore storage %s", c.storageId)
+	}
+	ctx.Infof("restored storage %s from snapshot %s", c.storageId, c.snapshotId)
+	return nil
+}
+
+// StorageRestoreSnapshotAPI defines the API methods that the
+// restore-storage-snapshot command uses.
+type StorageRestoreSnapshotAPI interface {
+	Close() error
+	RestoreSnapshot(storageId, snapshotId string) error
+}
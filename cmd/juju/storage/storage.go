@@ -7,6 +7,9 @@
 package storage
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -37,9 +40,20 @@ type StorageInfo struct {
 	Kind        string              `yaml:"kind" json:"kind"`
 	Status      EntityStatus        `yaml:"status" json:"status"`
 	Persistent  bool                `yaml:"persistent" json:"persistent"`
+	Usage       *StorageUsage       `yaml:"usage,omitempty" json:"usage,omitempty"`
 	Attachments *StorageAttachments `yaml:"attachments" json:"attachments"`
 }
 
+// StorageUsage contains the most recently reported usage of the volume
+// or filesystem underlying a storage instance.
+type StorageUsage struct {
+	// Capacity is the total capacity, in bytes.
+	Capacity uint64 `yaml:"capacity" json:"capacity"`
+
+	// Used is the amount of the capacity in use, in bytes.
+	Used uint64 `yaml:"used" json:"used"`
+}
+
 // StorageAttachments contains details about all attachments to a storage
 // instance.
 type StorageAttachments struct {
@@ -95,6 +109,13 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 		Persistent: details.Persistent,
 	}
 
+	if details.Usage != nil {
+		info.Usage = &StorageUsage{
+			Capacity: details.Usage.Capacity,
+			Used:     details.Usage.Used,
+		}
+	}
+
 	if len(details.Attachments) > 0 {
 		unitStorageAttachments := make(map[string]UnitStorageAttachment)
 		for unitTagString, attachmentDetails := range details.Attachments {
@@ -120,3 +141,21 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 
 	return storageTag, info, nil
 }
+
+// storageResultsError returns an error describing the failed results of
+// an operation on the specified storage instances, or nil if none failed.
+func storageResultsError(operation string, storageIds []string, results []params.ErrorResult) error {
+	var failures []string
+	for i, result := range results {
+		if result.Error != nil {
+			failures = append(failures, fmt.Sprintf(
+				"failed to %s storage %s: %v",
+				operation, storageIds[i], result.Error,
+			))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
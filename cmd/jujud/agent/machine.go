@@ -1098,6 +1098,18 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	var auditSinks []audit.Sink
+	if controllerConfig.AuditingEnabled() {
+		// Sinks which cannot be opened are logged and skipped, so
+		// that they do not stop the API server.
+		auditSinks = audit.OpenSinks(controllerConfig.AuditLogSinks(), audit.SinkParams{
+			ControllerConfig: controllerConfig,
+			AgentTag:         tag,
+			DataDir:          dataDir,
+			Clock:            clock.WallClock,
+		})
+	}
+
 	server, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
 		Clock:            clock.WallClock,
 		Cert:             cert,
@@ -1115,18 +1127,25 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 			clock.WallClock,
 			jujuversion.Current,
 			agentConfig.Model().Id(),
-			newAuditEntrySink(st, logDir),
+			newAuditEntrySink(st, logDir, auditSinks),
 			auditErrorHandler,
 		),
 	})
 	if err != nil {
+		audit.CloseSinks(auditSinks)
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
+	if len(auditSinks) > 0 {
+		go func() {
+			server.Wait()
+			audit.CloseSinks(auditSinks)
+		}()
+	}
 
 	return server, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
+func newAuditEntrySink(st *state.State, logDir string, sinks []audit.Sink) audit.AuditEntrySinkFn {
 	persistFn := st.PutAuditEntryFn()
 	fileSinkFn := audit.NewLogFileSink(logDir)
 	return func(entry audit.AuditEntry) error {
@@ -1138,6 +1157,14 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 		if strings.HasPrefix(entry.Operation, "Pinger:") {
 			return nil
 		}
+		// Failures to send to the configured sinks are logged rather
+		// than returned, so that one unavailable sink does not mask
+		// problems recording to the controller itself.
+		for _, sink := range sinks {
+			if err := sink.Send(entry); err != nil {
+				logger.Errorf("cannot send audit record to sink: %v", err)
+			}
+		}
 		persistErr := persistFn(entry)
 		sinkErr := fileSinkFn(entry)
 		if persistErr == nil {
@@ -449,6 +449,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-gelf",
+				OpenFn: sinks.OpenGELF,
+			}, {
+				Name:   "juju-log-forward-http",
+				OpenFn: sinks.OpenHTTPJSON,
 			}},
 		})),
 	}
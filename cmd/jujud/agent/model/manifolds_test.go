@@ -31,6 +31,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 	// NOTE: if this test failed, the cmd/jujud/agent tests will
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -49,6 +50,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"migration-master",
 		"not-alive-flag",
 		"not-dead-flag",
+		"remote-relations",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
@@ -61,6 +63,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 
 func (s *ManifoldsSuite) TestFlagDependencies(c *gc.C) {
 	exclusions := set.NewStrings(
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -19,6 +19,8 @@ import (
 	"github.com/juju/utils/exec"
 
 	"github.com/juju/juju/agent"
+	// Import the audit sinks.
+	_ "github.com/juju/juju/audit/all"
 	jujucmd "github.com/juju/juju/cmd"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
@@ -4,7 +4,9 @@
 package controller
 
 import (
+	"crypto/tls"
 	"net/url"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -25,6 +27,40 @@ const (
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogSinks holds a comma-separated list of the sinks, in
+	// addition to the local audit log file and database, to which
+	// audit entries are sent (e.g. "syslog,webhook").
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditSyslogHost is the host-port of the syslog server to which
+	// audit entries are sent by the syslog audit sink.
+	AuditSyslogHost = "audit-syslog-host"
+
+	// AuditSyslogCACert is the CA certificate used to verify the
+	// syslog server's certificate.
+	AuditSyslogCACert = "audit-syslog-ca-cert"
+
+	// AuditSyslogClientCert is the certificate presented to the
+	// syslog server.
+	AuditSyslogClientCert = "audit-syslog-client-cert"
+
+	// AuditSyslogClientKey is the key for the certificate presented
+	// to the syslog server.
+	AuditSyslogClientKey = "audit-syslog-client-key"
+
+	// AuditWebhookURL is the URL to which the webhook audit sink
+	// posts batches of audit entries.
+	AuditWebhookURL = "audit-webhook-url"
+
+	// AuditWebhookBatchSize is the maximum number of audit entries
+	// posted to the webhook in a single request.
+	AuditWebhookBatchSize = "audit-webhook-batch-size"
+
+	// AuditWebhookBufferSize is the maximum number of audit entries
+	// held on disk while waiting to be posted to the webhook. Once
+	// the buffer is full, the oldest entries are discarded.
+	AuditWebhookBufferSize = "audit-webhook-buffer-size"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -67,6 +103,14 @@ const (
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = false
 
+	// DefaultAuditWebhookBatchSize contains the default value for the
+	// AuditWebhookBatchSize config value.
+	DefaultAuditWebhookBatchSize = 100
+
+	// DefaultAuditWebhookBufferSize contains the default value for the
+	// AuditWebhookBufferSize config value.
+	DefaultAuditWebhookBufferSize = 10000
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -83,6 +127,14 @@ const (
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
 	APIPort,
+	AuditLogSinks,
+	AuditSyslogHost,
+	AuditSyslogCACert,
+	AuditSyslogClientCert,
+	AuditSyslogClientKey,
+	AuditWebhookURL,
+	AuditWebhookBatchSize,
+	AuditWebhookBufferSize,
 	AutocertDNSNameKey,
 	AutocertURLKey,
 	CACertKey,
@@ -181,6 +233,73 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogSinks returns the names of the sinks, other than the local
+// audit log file and database, to which audit entries are sent.
+func (c Config) AuditLogSinks() []string {
+	var sinks []string
+	for _, name := range strings.Split(c.asString(AuditLogSinks), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sinks = append(sinks, name)
+		}
+	}
+	return sinks
+}
+
+// AuditSyslogHost returns the host-port of the syslog server to which
+// audit entries are sent.
+func (c Config) AuditSyslogHost() string {
+	return c.asString(AuditSyslogHost)
+}
+
+// AuditSyslogCACert returns the CA certificate used to verify the
+// syslog server's certificate.
+func (c Config) AuditSyslogCACert() string {
+	return c.asString(AuditSyslogCACert)
+}
+
+// AuditSyslogClientCert returns the certificate presented to the
+// syslog server.
+func (c Config) AuditSyslogClientCert() string {
+	return c.asString(AuditSyslogClientCert)
+}
+
+// AuditSyslogClientKey returns the key for the certificate presented
+// to the syslog server.
+func (c Config) AuditSyslogClientKey() string {
+	return c.asString(AuditSyslogClientKey)
+}
+
+// AuditWebhookURL returns the URL to which batches of audit entries
+// are posted.
+func (c Config) AuditWebhookURL() string {
+	return c.asString(AuditWebhookURL)
+}
+
+// AuditWebhookBatchSize returns the maximum number of audit entries
+// posted to the webhook in a single request.
+func (c Config) AuditWebhookBatchSize() int {
+	return c.intOrDefault(AuditWebhookBatchSize, DefaultAuditWebhookBatchSize)
+}
+
+// AuditWebhookBufferSize returns the maximum number of audit entries
+// held on disk while waiting to be posted to the webhook.
+func (c Config) AuditWebhookBufferSize() int {
+	return c.intOrDefault(AuditWebhookBufferSize, DefaultAuditWebhookBufferSize)
+}
+
+// intOrDefault returns the named attribute as an integer, or the
+// supplied default if it is not set.
+func (c Config) intOrDefault(name string, defaultValue int) int {
+	// Values obtained over the api are encoded as float64.
+	switch value := c[name].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	}
+	return defaultValue
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -268,6 +387,25 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateAuditLogSinks(); err != nil {
+		return errors.Trace(err)
+	}
+	if v, ok := c[AuditWebhookURL].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit webhook URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("audit webhook URL %q must use http or https", v)
+		}
+	}
+	if c.AuditWebhookBatchSize() <= 0 {
+		return errors.Errorf("%s must be positive", AuditWebhookBatchSize)
+	}
+	if c.AuditWebhookBufferSize() < c.AuditWebhookBatchSize() {
+		return errors.Errorf("%s must not be less than %s", AuditWebhookBufferSize, AuditWebhookBatchSize)
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -283,6 +421,48 @@ func Validate(c Config) error {
 	return nil
 }
 
+// auditSinkRequiredKeys holds the names of the audit sinks which may
+// be named in AuditLogSinks, and the configuration each one requires.
+var auditSinkRequiredKeys = map[string][]string{
+	"syslog": {
+		AuditSyslogHost,
+		AuditSyslogCACert,
+		AuditSyslogClientCert,
+		AuditSyslogClientKey,
+	},
+	"webhook": {
+		AuditWebhookURL,
+	},
+}
+
+// validateAuditLogSinks checks that the audit sinks named in
+// AuditLogSinks exist, and that the settings they require are set.
+func (c Config) validateAuditLogSinks() error {
+	for _, name := range c.AuditLogSinks() {
+		required, ok := auditSinkRequiredKeys[name]
+		if !ok {
+			return errors.NotValidf("audit sink %q", name)
+		}
+		for _, key := range required {
+			if c.asString(key) == "" {
+				return errors.Errorf("audit sink %q requires %s", name, key)
+			}
+		}
+	}
+	if v := c.AuditSyslogCACert(); v != "" {
+		if _, err := cert.ParseCert(v); err != nil {
+			return errors.Annotate(err, "bad audit syslog CA certificate")
+		}
+	}
+	if c.AuditSyslogClientCert() != "" || c.AuditSyslogClientKey() != "" {
+		_, err := tls.X509KeyPair([]byte(c.AuditSyslogClientCert()), []byte(c.AuditSyslogClientKey()))
+		if err != nil {
+			return errors.Annotate(err, "bad audit syslog client key pair")
+		}
+	}
+	return nil
+}
+
 // GenerateControllerCertAndKey makes sure that the config has a CACert and
 // CAPrivateKey, generates and returns new certificate and key.
 func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string) (string, string, error) {
@@ -291,6 +471,14 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 
 var configChecker = schema.FieldMap(schema.Fields{
 	AuditingEnabled:         schema.Bool(),
+	AuditLogSinks:           schema.String(),
+	AuditSyslogHost:         schema.String(),
+	AuditSyslogCACert:       schema.String(),
+	AuditSyslogClientCert:   schema.String(),
+	AuditSyslogClientKey:    schema.String(),
+	AuditWebhookURL:         schema.String(),
+	AuditWebhookBatchSize:   schema.ForceInt(),
+	AuditWebhookBufferSize:  schema.ForceInt(),
 	APIPort:                 schema.ForceInt(),
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
@@ -302,6 +490,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
+	AuditLogSinks:           schema.Omit,
+	AuditSyslogHost:         schema.Omit,
+	AuditSyslogCACert:       schema.Omit,
+	AuditSyslogClientCert:   schema.Omit,
+	AuditSyslogClientKey:    schema.Omit,
+	AuditWebhookURL:         schema.Omit,
+	AuditWebhookBatchSize:   schema.Omit,
+	AuditWebhookBufferSize:  schema.Omit,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
@@ -120,6 +120,76 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "audit webhook URL OK",
+	config: controller.Config{
+		controller.AuditWebhookURL: "https://siem.example.com/juju",
+		controller.CACertKey:       testing.CACert,
+	},
+}, {
+	about: "invalid audit webhook URL scheme",
+	config: controller.Config{
+		controller.AuditWebhookURL: "ftp://siem.example.com/juju",
+		controller.CACertKey:       testing.CACert,
+	},
+	expectError: `audit webhook URL "ftp://siem.example.com/juju" must use http or https`,
+}, {
+	about: "non-positive audit webhook batch size",
+	config: controller.Config{
+		controller.AuditWebhookBatchSize: 0,
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `audit-webhook-batch-size must be positive`,
+}, {
+	about: "audit webhook buffer smaller than batch",
+	config: controller.Config{
+		controller.AuditWebhookBatchSize:  10,
+		controller.AuditWebhookBufferSize: 5,
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `audit-webhook-buffer-size must not be less than audit-webhook-batch-size`,
+}, {
+	about: "audit syslog sink OK",
+	config: controller.Config{
+		controller.AuditLogSinks:         "syslog",
+		controller.AuditSyslogHost:       "syslog.example.com:6514",
+		controller.AuditSyslogCACert:     testing.CACert,
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  testing.ServerKey,
+		controller.CACertKey:             testing.CACert,
+	},
+}, {
+	about: "unknown audit sink",
+	config: controller.Config{
+		controller.AuditLogSinks: "syslog,kafka",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `audit sink "kafka" not valid`,
+}, {
+	about: "audit syslog sink requires host",
+	config: controller.Config{
+		controller.AuditLogSinks:         "syslog",
+		controller.AuditSyslogCACert:     testing.CACert,
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  testing.ServerKey,
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `audit sink "syslog" requires audit-syslog-host`,
+}, {
+	about: "audit webhook sink requires URL",
+	config: controller.Config{
+		controller.AuditLogSinks: "webhook",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `audit sink "webhook" requires audit-webhook-url`,
+}, {
+	about: "invalid audit syslog client key pair",
+	config: controller.Config{
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  "xxx",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `bad audit syslog client key pair: .*`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -133,3 +203,31 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestAuditSettings(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogSinks:         "syslog, webhook,",
+		controller.AuditSyslogHost:       "syslog.example.com:6514",
+		controller.AuditSyslogCACert:     testing.CACert,
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  testing.ServerKey,
+		controller.AuditWebhookURL:       "https://siem.example.com/juju",
+		controller.AuditWebhookBatchSize: 20,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"syslog", "webhook"})
+	c.Assert(cfg.AuditWebhookURL(), gc.Equals, "https://siem.example.com/juju")
+	c.Assert(cfg.AuditWebhookBatchSize(), gc.Equals, 20)
+	c.Assert(cfg.AuditWebhookBufferSize(), gc.Equals, controller.DefaultAuditWebhookBufferSize)
+}
+
+func (s *ConfigSuite) TestAuditSettingsFromAPI(c *gc.C) {
+	// Numbers decoded from the API are float64s.
+	cfg := controller.Config{
+		controller.AuditWebhookBatchSize:  float64(50),
+		controller.AuditWebhookBufferSize: float64(500),
+	}
+	c.Assert(cfg.AuditLogSinks(), gc.HasLen, 0)
+	c.Assert(cfg.AuditWebhookBatchSize(), gc.Equals, 50)
+	c.Assert(cfg.AuditWebhookBufferSize(), gc.Equals, 500)
+}
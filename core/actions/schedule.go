@@ -0,0 +1,184 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule determines when a scheduled action is next to be run.
+type Schedule interface {
+	// Next returns the first time after t at which the action should
+	// run, or the zero time if it never will.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a schedule specification. This is either an
+// interval, as in "@every 6h"; one of "@hourly", "@daily", "@weekly"
+// or "@monthly"; or a cron expression holding the five fields "minute
+// hour day-of-month month day-of-week", as in "30 2 * * 1-5".
+//
+// Cron fields accept "*", single values, ranges such as "1-5", lists
+// such as "1,15" and steps such as "*/10" or "0-30/5". Days of the week
+// run from 0 (Sunday) to 6, and 7 is also accepted for Sunday.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every") {
+		interval, err := time.ParseDuration(strings.TrimSpace(spec[len("@every"):]))
+		if err != nil {
+			return nil, errors.NotValidf("schedule %q", spec)
+		}
+		if interval < time.Minute {
+			return nil, errors.Errorf("invalid schedule %q: interval must be at least a minute", spec)
+		}
+		return intervalSchedule(interval), nil
+	}
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q", spec)
+	}
+	var s cronSchedule
+	var err error
+	for i, f := range []struct {
+		field    *uint64
+		min, max uint
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.dayOfMonth, 1, 31},
+		{&s.month, 1, 12},
+		{&s.dayOfWeek, 0, 7},
+	} {
+		if *f.field, err = parseCronField(fields[i], f.min, f.max); err != nil {
+			return nil, errors.Annotatef(err, "invalid schedule %q", spec)
+		}
+	}
+	// Sunday may be given as either 0 or 7.
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+	s.anyDayOfMonth = fields[2] == "*"
+	s.anyDayOfWeek = fields[4] == "*"
+	return s, nil
+}
+
+// parseCronField returns a bit set holding the values in [min, max]
+// matched by the given cron field.
+func parseCronField(field string, min, max uint) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		start, end, step := min, max, uint(1)
+		rangeSpec := part
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, errors.Errorf("invalid step in %q", part)
+			}
+			step = uint(n)
+			rangeSpec = part[:i]
+		}
+		if rangeSpec != "*" {
+			bounds := strings.SplitN(rangeSpec, "-", 2)
+			n, err := strconv.ParseUint(bounds[0], 10, 8)
+			if err != nil {
+				return 0, errors.Errorf("invalid value in %q", part)
+			}
+			start = uint(n)
+			end = start
+			if len(bounds) == 2 {
+				n, err := strconv.ParseUint(bounds[1], 10, 8)
+				if err != nil {
+					return 0, errors.Errorf("invalid value in %q", part)
+				}
+				end = uint(n)
+			} else if step != 1 {
+				// "n/step" runs from n to the end of the range.
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, errors.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// intervalSchedule runs an action at a fixed interval.
+type intervalSchedule time.Duration
+
+// Next is part of the Schedule interface.
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s)).Truncate(time.Second)
+}
+
+// cronSchedule runs an action at the times matched by a cron
+// expression, as a bit set for each field.
+type cronSchedule struct {
+	minute        uint64
+	hour          uint64
+	dayOfMonth    uint64
+	month         uint64
+	dayOfWeek     uint64
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// maxCronSearch bounds the search for the next matching time, so that
+// expressions which can never match (such as "0 0 30 2 *") terminate.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// Next is part of the Schedule interface.
+func (s cronSchedule) Next(t time.Time) time.Time {
+	limit := t.Add(maxCronSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of t is matched. As with cron,
+// when both the day of the month and the day of the week are
+// restricted, a day matching either of them is matched.
+func (s cronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dow
+	case s.anyDayOfWeek:
+		return dom
+	}
+	return dom || dow
+}
@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type ScheduleSuite struct{}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+// Thursday.
+var t0 = time.Date(2016, 11, 10, 9, 30, 15, 0, time.UTC)
+
+func (s *ScheduleSuite) TestNext(c *gc.C) {
+	for i, t := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "@every 6h",
+		expect: time.Date(2016, 11, 10, 15, 30, 15, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2016, 11, 10, 10, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@daily",
+		expect: time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@weekly",
+		expect: time.Date(2016, 11, 13, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@monthly",
+		expect: time.Date(2016, 12, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "* * * * *",
+		expect: time.Date(2016, 11, 10, 9, 31, 0, 0, time.UTC),
+	}, {
+		spec:   "*/20 * * * *",
+		expect: time.Date(2016, 11, 10, 9, 40, 0, 0, time.UTC),
+	}, {
+		spec:   "15,45 9-17 * * *",
+		expect: time.Date(2016, 11, 10, 9, 45, 0, 0, time.UTC),
+	}, {
+		spec:   "30 2 * * 1-5",
+		expect: time.Date(2016, 11, 11, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "0 3 * * 6,7",
+		expect: time.Date(2016, 11, 12, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 1 1 *",
+		expect: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Either the day of the month or the day of the week.
+		spec:   "0 0 13 * 5",
+		expect: time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 29 2 *",
+		expect: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 30 2 *",
+	}} {
+		c.Logf("test %d: %q", i, t.spec)
+		schedule, err := actions.ParseSchedule(t.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(t0), gc.DeepEquals, t.expect)
+	}
+}
+
+func (s *ScheduleSuite) TestParseErrors(c *gc.C) {
+	for i, t := range []struct {
+		spec   string
+		expect string
+	}{{
+		spec:   "",
+		expect: `schedule "" not valid`,
+	}, {
+		spec:   "@yearly",
+		expect: `schedule "@yearly" not valid`,
+	}, {
+		spec:   "@every soon",
+		expect: `schedule "@every soon" not valid`,
+	}, {
+		spec:   "@every 10s",
+		expect: `invalid schedule "@every 10s": interval must be at least a minute`,
+	}, {
+		spec:   "* * * *",
+		expect: `schedule "\* \* \* \*" not valid`,
+	}, {
+		spec:   "60 * * * *",
+		expect: `invalid schedule "60 \* \* \* \*": "60" out of range 0-59`,
+	}, {
+		spec:   "* * 0 * *",
+		expect: `invalid schedule "\* \* 0 \* \*": "0" out of range 1-31`,
+	}, {
+		spec:   "5-1 * * * *",
+		expect: `invalid schedule "5-1 \* \* \* \*": "5-1" out of range 0-59`,
+	}, {
+		spec:   "*/0 * * * *",
+		expect: `invalid schedule "\*/0 \* \* \* \*": invalid step in "\*/0"`,
+	}, {
+		spec:   "* * * jan *",
+		expect: `invalid schedule "\* \* \* jan \*": invalid value in "jan"`,
+	}} {
+		c.Logf("test %d: %q", i, t.spec)
+		_, err := actions.ParseSchedule(t.spec)
+		c.Check(err, gc.ErrorMatches, t.expect)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// parallelKey is the actions.yaml key with which a charm declares that
+// an action may run in parallel with hooks and other actions.
+const parallelKey = "parallel"
+
+// Spec is a charm's definition of an action, along with the settings
+// juju reads from actions.yaml which charm.ActionSpec does not hold.
+type Spec struct {
+	charm.ActionSpec
+
+	// Parallel is true if the charm has declared that the action
+	// may run at the same time as hooks and other actions.
+	Parallel bool
+}
+
+// NewSpec returns the Spec for the given charm action definition.
+//
+// The charm package keeps any top-level action settings it does not
+// know about in the action's params schema, so the settings held by
+// Spec are moved from there into its fields, leaving only the params
+// schema itself.
+func NewSpec(spec charm.ActionSpec) Spec {
+	result := Spec{ActionSpec: spec}
+	if _, ok := spec.Params[parallelKey]; !ok {
+		return result
+	}
+	result.Parallel, _ = spec.Params[parallelKey].(bool)
+	result.Params = make(map[string]interface{}, len(spec.Params)-1)
+	for key, value := range spec.Params {
+		if key != parallelKey {
+			result.Params[key] = value
+		}
+	}
+	return result
+}
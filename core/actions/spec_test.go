@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type specSuite struct{}
+
+var _ = gc.Suite(&specSuite{})
+
+func (*specSuite) TestNewSpec(c *gc.C) {
+	for i, t := range []struct {
+		params         map[string]interface{}
+		expectParams   map[string]interface{}
+		expectParallel bool
+	}{{
+		params:       nil,
+		expectParams: nil,
+	}, {
+		params:       map[string]interface{}{"type": "object"},
+		expectParams: map[string]interface{}{"type": "object"},
+	}, {
+		params:       map[string]interface{}{"type": "object", "parallel": false},
+		expectParams: map[string]interface{}{"type": "object"},
+	}, {
+		params:       map[string]interface{}{"type": "object", "parallel": "yes"},
+		expectParams: map[string]interface{}{"type": "object"},
+	}, {
+		params:         map[string]interface{}{"type": "object", "parallel": true},
+		expectParams:   map[string]interface{}{"type": "object"},
+		expectParallel: true,
+	}} {
+		c.Logf("test %d: %v", i, t.params)
+		spec := actions.NewSpec(charm.ActionSpec{
+			Description: "an action",
+			Params:      t.params,
+		})
+		c.Check(spec.Description, gc.Equals, "an action")
+		c.Check(spec.Params, jc.DeepEquals, t.expectParams)
+		c.Check(spec.Parallel, gc.Equals, t.expectParallel)
+	}
+}
+
+func (*specSuite) TestNewSpecLeavesCharmSpec(c *gc.C) {
+	params := map[string]interface{}{"type": "object", "parallel": true}
+	actions.NewSpec(charm.ActionSpec{Params: params})
+	c.Assert(params, jc.DeepEquals, map[string]interface{}{"type": "object", "parallel": true})
+}
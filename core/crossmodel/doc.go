@@ -0,0 +1,8 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel contains types and functions shared by the
+// client and server components of cross-model relations, in which
+// an application in one model relates to an endpoint offered by an
+// application in another model hosted by the same controller.
+package crossmodel
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// ApplicationURL identifies an application offer. Its string form
+// is "<user>/<model>.<offer>", e.g. "admin/prod.mysql".
+type ApplicationURL struct {
+	// User is the name of the user that owns the model hosting
+	// the offer.
+	User string
+
+	// ModelName is the name of the model hosting the offer.
+	ModelName string
+
+	// OfferName is the name under which the application's
+	// endpoints are offered.
+	OfferName string
+}
+
+// String returns the string form of the URL.
+func (u ApplicationURL) String() string {
+	return fmt.Sprintf("%s/%s.%s", u.User, u.ModelName, u.OfferName)
+}
+
+// IsApplicationURL reports whether the supplied string looks like
+// an application offer URL, as opposed to an application name.
+func IsApplicationURL(s string) bool {
+	return strings.Contains(s, "/")
+}
+
+// ParseApplicationURL parses the string form of an application
+// offer URL.
+func ParseApplicationURL(s string) (*ApplicationURL, error) {
+	slash := strings.Index(s, "/")
+	if slash == -1 {
+		return nil, errors.NotValidf("application URL %q: missing user", s)
+	}
+	user, rest := s[:slash], s[slash+1:]
+	dot := strings.LastIndex(rest, ".")
+	if dot == -1 {
+		return nil, errors.NotValidf("application URL %q: missing offer name", s)
+	}
+	modelName, offerName := rest[:dot], rest[dot+1:]
+	if !names.IsValidUser(user) {
+		return nil, errors.NotValidf("application URL %q: user name %q", s, user)
+	}
+	if !names.IsValidModelName(modelName) {
+		return nil, errors.NotValidf("application URL %q: model name %q", s, modelName)
+	}
+	if !names.IsValidApplication(offerName) {
+		return nil, errors.NotValidf("application URL %q: offer name %q", s, offerName)
+	}
+	return &ApplicationURL{
+		User:      user,
+		ModelName: modelName,
+		OfferName: offerName,
+	}, nil
+}
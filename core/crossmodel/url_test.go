@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type URLSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&URLSuite{})
+
+func (*URLSuite) TestParseApplicationURL(c *gc.C) {
+	url, err := crossmodel.ParseApplicationURL("admin/prod.mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url, jc.DeepEquals, &crossmodel.ApplicationURL{
+		User:      "admin",
+		ModelName: "prod",
+		OfferName: "mysql",
+	})
+	c.Assert(url.String(), gc.Equals, "admin/prod.mysql")
+}
+
+func (*URLSuite) TestParseApplicationURLExternalUser(c *gc.C) {
+	url, err := crossmodel.ParseApplicationURL("fred@external/prod.db-offer")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url, jc.DeepEquals, &crossmodel.ApplicationURL{
+		User:      "fred@external",
+		ModelName: "prod",
+		OfferName: "db-offer",
+	})
+}
+
+func (*URLSuite) TestParseApplicationURLInvalid(c *gc.C) {
+	for i, test := range []struct {
+		url    string
+		expect string
+	}{{
+		url:    "mysql",
+		expect: `application URL "mysql": missing user not valid`,
+	}, {
+		url:    "admin/prod",
+		expect: `application URL "admin/prod": missing offer name not valid`,
+	}, {
+		url:    "admin/prod.",
+		expect: `application URL "admin/prod.": offer name "" not valid`,
+	}, {
+		url:    "/prod.mysql",
+		expect: `application URL "/prod.mysql": user name "" not valid`,
+	}, {
+		url:    "admin/pr_od.mysql",
+		expect: `application URL "admin/pr_od.mysql": model name "pr_od" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.url)
+		_, err := crossmodel.ParseApplicationURL(test.url)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (*URLSuite) TestIsApplicationURL(c *gc.C) {
+	c.Assert(crossmodel.IsApplicationURL("admin/prod.mysql"), jc.IsTrue)
+	c.Assert(crossmodel.IsApplicationURL("mysql"), jc.IsFalse)
+}
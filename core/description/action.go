@@ -28,6 +28,9 @@ type action struct {
 	Status_    string                 `yaml:"status"`
 	Message_   string                 `yaml:"message"`
 	Results_   map[string]interface{} `yaml:"results"`
+	Parallel_  bool                   `yaml:"parallel,omitempty"`
+	Timeout_   time.Duration          `yaml:"timeout,omitempty"`
+	Schedule_  string                 `yaml:"schedule,omitempty"`
 }
 
 // Id implements Action.
@@ -88,6 +91,21 @@ func (i *action) Results() map[string]interface{} {
 	return i.Results_
 }
 
+// Parallel implements Action.
+func (i *action) Parallel() bool {
+	return i.Parallel_
+}
+
+// Timeout implements Action.
+func (i *action) Timeout() time.Duration {
+	return i.Timeout_
+}
+
+// Schedule implements Action.
+func (i *action) Schedule() string {
+	return i.Schedule_
+}
+
 // ActionArgs is an argument struct used to create a
 // new internal action type that supports the Action interface.
 type ActionArgs struct {
@@ -101,6 +119,9 @@ type ActionArgs struct {
 	Status     string
 	Message    string
 	Results    map[string]interface{}
+	Parallel   bool
+	Timeout    time.Duration
+	Schedule   string
 }
 
 func newAction(args ActionArgs) *action {
@@ -113,6 +134,9 @@ func newAction(args ActionArgs) *action {
 		Message_:    args.Message,
 		Id_:         args.Id,
 		Results_:    args.Results,
+		Parallel_:   args.Parallel,
+		Timeout_:    args.Timeout,
+		Schedule_:   args.Schedule,
 	}
 	if !args.Started.IsZero() {
 		value := args.Started
@@ -176,11 +200,17 @@ func importActionV1(source map[string]interface{}) (*action, error) {
 		"message":    schema.String(),
 		"results":    schema.StringMap(schema.Any()),
 		"id":         schema.String(),
+		"parallel":   schema.Bool(),
+		"timeout":    schema.String(),
+		"schedule":   schema.String(),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"started":   time.Time{},
 		"completed": time.Time{},
+		"parallel":  false,
+		"timeout":   "",
+		"schedule":  "",
 	}
 	checker := schema.FieldMap(fields, defaults)
 
@@ -198,6 +228,14 @@ func importActionV1(source map[string]interface{}) (*action, error) {
 		Parameters_: valid["parameters"].(map[string]interface{}),
 		Enqueued_:   valid["enqueued"].(time.Time).UTC(),
 		Results_:    valid["results"].(map[string]interface{}),
+		Parallel_:   valid["parallel"].(bool),
+		Schedule_:   valid["schedule"].(string),
+	}
+	if timeout := valid["timeout"].(string); timeout != "" {
+		action.Timeout_, err = time.ParseDuration(timeout)
+		if err != nil {
+			return nil, errors.Annotatef(err, "action v1 timeout")
+		}
 	}
 
 	started := valid["started"].(time.Time)
@@ -41,6 +41,9 @@ func (s *ActionSerializationSuite) TestNewAction(c *gc.C) {
 		Status:     "happy",
 		Message:    "a message",
 		Results:    map[string]interface{}{"the": 3, "thing": "bam"},
+		Parallel:   true,
+		Timeout:    5 * time.Minute,
+		Schedule:   "2",
 	}
 	action := newAction(args)
 	c.Check(action.Id(), gc.Equals, args.Id)
@@ -53,6 +56,9 @@ func (s *ActionSerializationSuite) TestNewAction(c *gc.C) {
 	c.Check(action.Status(), gc.Equals, args.Status)
 	c.Check(action.Message(), gc.Equals, args.Message)
 	c.Check(action.Results(), jc.DeepEquals, args.Results)
+	c.Check(action.Parallel(), jc.IsTrue)
+	c.Check(action.Timeout(), gc.Equals, args.Timeout)
+	c.Check(action.Schedule(), gc.Equals, args.Schedule)
 }
 
 func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
@@ -70,6 +76,9 @@ func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
 				Status:     "happy",
 				Message:    "a message",
 				Results:    map[string]interface{}{"the": 3, "thing": "bam"},
+				Parallel:   true,
+				Timeout:    time.Minute,
+				Schedule:   "2",
 			}),
 			newAction(ActionArgs{
 				Name:       "bing",
@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type actionSchedules struct {
+	Version          int               `yaml:"version"`
+	ActionSchedules_ []*actionSchedule `yaml:"action-schedules"`
+}
+
+type actionSchedule struct {
+	Id_          string                 `yaml:"id"`
+	Application_ string                 `yaml:"application"`
+	Name_        string                 `yaml:"name"`
+	Parameters_  map[string]interface{} `yaml:"parameters"`
+	Schedule_    string                 `yaml:"schedule"`
+	Created_     time.Time              `yaml:"created"`
+	NextRun_     time.Time              `yaml:"next-run"`
+	// Can't use omitempty with time.Time, it just doesn't work
+	// (nothing is serialised), so use a pointer in the struct.
+	LastRun_ *time.Time `yaml:"last-run,omitempty"`
+}
+
+// Id implements ActionSchedule.
+func (i *actionSchedule) Id() string {
+	return i.Id_
+}
+
+// Application implements ActionSchedule.
+func (i *actionSchedule) Application() string {
+	return i.Application_
+}
+
+// Name implements ActionSchedule.
+func (i *actionSchedule) Name() string {
+	return i.Name_
+}
+
+// Parameters implements ActionSchedule.
+func (i *actionSchedule) Parameters() map[string]interface{} {
+	return i.Parameters_
+}
+
+// Schedule implements ActionSchedule.
+func (i *actionSchedule) Schedule() string {
+	return i.Schedule_
+}
+
+// Created implements ActionSchedule.
+func (i *actionSchedule) Created() time.Time {
+	return i.Created_
+}
+
+// NextRun implements ActionSchedule.
+func (i *actionSchedule) NextRun() time.Time {
+	return i.NextRun_
+}
+
+// LastRun implements ActionSchedule.
+func (i *actionSchedule) LastRun() time.Time {
+	var zero time.Time
+	if i.LastRun_ == nil {
+		return zero
+	}
+	return *i.LastRun_
+}
+
+// ActionScheduleArgs is an argument struct used to create a
+// new internal actionSchedule type that supports the ActionSchedule
+// interface.
+type ActionScheduleArgs struct {
+	Id          string
+	Application string
+	Name        string
+	Parameters  map[string]interface{}
+	Schedule    string
+	Created     time.Time
+	NextRun     time.Time
+	LastRun     time.Time
+}
+
+func newActionSchedule(args ActionScheduleArgs) *actionSchedule {
+	schedule := &actionSchedule{
+		Id_:          args.Id,
+		Application_: args.Application,
+		Name_:        args.Name,
+		Parameters_:  args.Parameters,
+		Schedule_:    args.Schedule,
+		Created_:     args.Created,
+		NextRun_:     args.NextRun,
+	}
+	if !args.LastRun.IsZero() {
+		value := args.LastRun
+		schedule.LastRun_ = &value
+	}
+	return schedule
+}
+
+func importActionSchedules(source map[string]interface{}) ([]*actionSchedule, error) {
+	checker := versionedChecker("action-schedules")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "action schedules version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := actionScheduleDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["action-schedules"].([]interface{})
+	return importActionScheduleList(sourceList, importFunc)
+}
+
+func importActionScheduleList(sourceList []interface{}, importFunc actionScheduleDeserializationFunc) ([]*actionSchedule, error) {
+	result := make([]*actionSchedule, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for action schedule %d, %T", i, value)
+		}
+		schedule, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "action schedule %d", i)
+		}
+		result = append(result, schedule)
+	}
+	return result, nil
+}
+
+type actionScheduleDeserializationFunc func(map[string]interface{}) (*actionSchedule, error)
+
+var actionScheduleDeserializationFuncs = map[int]actionScheduleDeserializationFunc{
+	1: importActionScheduleV1,
+}
+
+func importActionScheduleV1(source map[string]interface{}) (*actionSchedule, error) {
+	fields := schema.Fields{
+		"id":          schema.String(),
+		"application": schema.String(),
+		"name":        schema.String(),
+		"parameters":  schema.StringMap(schema.Any()),
+		"schedule":    schema.String(),
+		"created":     schema.Time(),
+		"next-run":    schema.Time(),
+		"last-run":    schema.Time(),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"last-run": time.Time{},
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "action schedule v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	schedule := &actionSchedule{
+		Id_:          valid["id"].(string),
+		Application_: valid["application"].(string),
+		Name_:        valid["name"].(string),
+		Parameters_:  valid["parameters"].(map[string]interface{}),
+		Schedule_:    valid["schedule"].(string),
+		Created_:     valid["created"].(time.Time).UTC(),
+		NextRun_:     valid["next-run"].(time.Time).UTC(),
+	}
+	lastRun := valid["last-run"].(time.Time)
+	if !lastRun.IsZero() {
+		lastRun = lastRun.UTC()
+		schedule.LastRun_ = &lastRun
+	}
+	return schedule, nil
+}
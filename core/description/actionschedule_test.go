@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ActionScheduleSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ActionScheduleSerializationSuite{})
+
+func (s *ActionScheduleSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "action schedules"
+	s.sliceName = "action-schedules"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importActionSchedules(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["action-schedules"] = []interface{}{}
+	}
+}
+
+func (s *ActionScheduleSerializationSuite) TestNewActionSchedule(c *gc.C) {
+	args := ActionScheduleArgs{
+		Id:          "0",
+		Application: "mysql",
+		Name:        "backup",
+		Parameters:  map[string]interface{}{"outfile": "out.tgz"},
+		Schedule:    "@daily",
+		Created:     time.Now(),
+		NextRun:     time.Now(),
+		LastRun:     time.Now(),
+	}
+	schedule := newActionSchedule(args)
+	c.Check(schedule.Id(), gc.Equals, args.Id)
+	c.Check(schedule.Application(), gc.Equals, args.Application)
+	c.Check(schedule.Name(), gc.Equals, args.Name)
+	c.Check(schedule.Parameters(), jc.DeepEquals, args.Parameters)
+	c.Check(schedule.Schedule(), gc.Equals, args.Schedule)
+	c.Check(schedule.Created(), gc.Equals, args.Created)
+	c.Check(schedule.NextRun(), gc.Equals, args.NextRun)
+	c.Check(schedule.LastRun(), gc.Equals, args.LastRun)
+}
+
+func (s *ActionScheduleSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := actionSchedules{
+		Version: 1,
+		ActionSchedules_: []*actionSchedule{
+			newActionSchedule(ActionScheduleArgs{
+				Id:          "0",
+				Application: "mysql",
+				Name:        "backup",
+				Parameters:  map[string]interface{}{"outfile": "out.tgz"},
+				Schedule:    "@daily",
+				Created:     time.Now().UTC(),
+				NextRun:     time.Now().UTC(),
+				LastRun:     time.Now().UTC(),
+			}),
+			newActionSchedule(ActionScheduleArgs{
+				Id:          "1",
+				Application: "wordpress",
+				Name:        "snapshot",
+				Parameters:  map[string]interface{}{},
+				Schedule:    "@every 1h",
+				Created:     time.Now().UTC(),
+				NextRun:     time.Now().UTC(),
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, err := importActionSchedules(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(schedules, jc.DeepEquals, initial.ActionSchedules_)
+}
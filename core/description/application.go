@@ -28,9 +28,13 @@ type application struct {
 
 	// ForceCharm is true if an upgrade charm is forced.
 	// It means upgrade even if the charm is in an error state.
-	ForceCharm_ bool `yaml:"force-charm,omitempty"`
-	Exposed_    bool `yaml:"exposed,omitempty"`
-	MinUnits_   int  `yaml:"min-units,omitempty"`
+	ForceCharm_   bool     `yaml:"force-charm,omitempty"`
+	Exposed_      bool     `yaml:"exposed,omitempty"`
+	ExposedCIDRs_ []string `yaml:"exposed-cidrs,omitempty"`
+	EgressRules_  []string `yaml:"egress-rules,omitempty"`
+	MinUnits_     int      `yaml:"min-units,omitempty"`
+
+	EndpointBindings_ map[string]string `yaml:"endpoint-bindings,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -61,7 +65,10 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedCIDRs         []string
+	EgressRules          []string
 	MinUnits             int
+	EndpointBindings     map[string]string
 	Settings             map[string]interface{}
 	Leader               string
 	LeadershipSettings   map[string]interface{}
@@ -80,7 +87,10 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedCIDRs_:         args.ExposedCIDRs,
+		EgressRules_:          args.EgressRules,
 		MinUnits_:             args.MinUnits,
+		EndpointBindings_:     args.EndpointBindings,
 		Settings_:             args.Settings,
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
@@ -142,11 +152,26 @@ func (s *application) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedCIDRs implements Application.
+func (s *application) ExposedCIDRs() []string {
+	return s.ExposedCIDRs_
+}
+
+// EgressRules implements Application.
+func (s *application) EgressRules() []string {
+	return s.EgressRules_
+}
+
 // MinUnits implements Application.
 func (s *application) MinUnits() int {
 	return s.MinUnits_
 }
 
+// EndpointBindings implements Application.
+func (s *application) EndpointBindings() map[string]string {
+	return s.EndpointBindings_
+}
+
 // Settings implements Application.
 func (s *application) Settings() map[string]interface{} {
 	return s.Settings_
@@ -314,7 +339,10 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-cidrs":       schema.List(schema.String()),
+		"egress-rules":        schema.List(schema.String()),
 		"min-units":           schema.Int(),
+		"endpoint-bindings":   schema.StringMap(schema.String()),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
 		"leader":              schema.String(),
@@ -328,7 +356,10 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"subordinate":         false,
 		"force-charm":         false,
 		"exposed":             false,
+		"exposed-cidrs":       schema.Omit,
+		"egress-rules":        schema.Omit,
 		"min-units":           int64(0),
+		"endpoint-bindings":   schema.Omit,
 		"leader":              "",
 		"metrics-creds":       "",
 		"storage-constraints": schema.Omit,
@@ -361,6 +392,16 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		StatusHistory_:        newStatusHistory(),
 	}
 	result.importAnnotations(valid)
+
+	if bindings, ok := valid["endpoint-bindings"]; ok {
+		result.EndpointBindings_ = convertToStringMap(bindings)
+	}
+	if cidrs, ok := valid["exposed-cidrs"]; ok {
+		result.ExposedCIDRs_ = convertToStringSlice(cidrs)
+	}
+	if rules, ok := valid["egress-rules"]; ok {
+		result.EgressRules_ = convertToStringSlice(rules)
+	}
 	if err := result.importStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
 	}
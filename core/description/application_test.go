@@ -112,6 +112,9 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 		ForceCharm:           true,
 		Exposed:              true,
 		MinUnits:             42, // no judgement is made by the migration code
+		EndpointBindings: map[string]string{
+			"rel-name": "some-space",
+		},
 		Settings: map[string]interface{}{
 			"key": "value",
 		},
@@ -133,6 +136,7 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.ForceCharm(), jc.IsTrue)
 	c.Assert(application.Exposed(), jc.IsTrue)
 	c.Assert(application.MinUnits(), gc.Equals, 42)
+	c.Assert(application.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
 	c.Assert(application.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(application.Leader(), gc.Equals, "magic/1")
 	c.Assert(application.LeadershipSettings(), jc.DeepEquals, args.LeadershipSettings)
@@ -229,6 +233,38 @@ func (s *ApplicationSerializationSuite) TestStorageConstraints(c *gc.C) {
 	c.Check(second.Count(), gc.Equals, uint64(7))
 }
 
+func (s *ApplicationSerializationSuite) TestEndpointBindings(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.EndpointBindings = map[string]string{
+		"db":      "internal",
+		"website": "public",
+	}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
+}
+
+func (s *ApplicationSerializationSuite) TestExposedCIDRs(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.Exposed = true
+	args.ExposedCIDRs = []string{"10.0.0.0/8", "192.168.1.0/24"}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, args.ExposedCIDRs)
+}
+
+func (s *ApplicationSerializationSuite) TestEgressRules(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.EgressRules = []string{"10.0.0.0/8:443/tcp", "192.168.1.0/24:53/udp"}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.EgressRules(), jc.DeepEquals, args.EgressRules)
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
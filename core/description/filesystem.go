@@ -24,6 +24,7 @@ type filesystem struct {
 	Size_         uint64 `yaml:"size"`
 	Pool_         string `yaml:"pool,omitempty"`
 	FilesystemID_ string `yaml:"filesystem-id,omitempty"`
+	Releasing_    bool   `yaml:"releasing,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -53,6 +54,7 @@ type FilesystemArgs struct {
 	Size         uint64
 	Pool         string
 	FilesystemID string
+	Releasing    bool
 }
 
 func newFilesystem(args FilesystemArgs) *filesystem {
@@ -64,6 +66,7 @@ func newFilesystem(args FilesystemArgs) *filesystem {
 		Size_:          args.Size,
 		Pool_:          args.Pool,
 		FilesystemID_:  args.FilesystemID,
+		Releasing_:     args.Releasing,
 		StatusHistory_: newStatusHistory(),
 	}
 	if args.Binding != nil {
@@ -126,6 +129,11 @@ func (f *filesystem) FilesystemID() string {
 	return f.FilesystemID_
 }
 
+// Releasing implements Filesystem.
+func (f *filesystem) Releasing() bool {
+	return f.Releasing_
+}
+
 // Status implements Filesystem.
 func (f *filesystem) Status() Status {
 	// To avoid typed nils check nil here.
@@ -229,6 +237,7 @@ func importFilesystemV1(source map[string]interface{}) (*filesystem, error) {
 		"size":          schema.ForceUint(),
 		"pool":          schema.String(),
 		"filesystem-id": schema.String(),
+		"releasing":     schema.Bool(),
 		"status":        schema.StringMap(schema.Any()),
 		"attachments":   schema.StringMap(schema.Any()),
 	}
@@ -239,6 +248,7 @@ func importFilesystemV1(source map[string]interface{}) (*filesystem, error) {
 		"binding":       "",
 		"pool":          "",
 		"filesystem-id": "",
+		"releasing":     false,
 		"attachments":   schema.Omit,
 	}
 	addStatusHistorySchema(fields)
@@ -260,6 +270,7 @@ func importFilesystemV1(source map[string]interface{}) (*filesystem, error) {
 		Size_:          valid["size"].(uint64),
 		Pool_:          valid["pool"].(string),
 		FilesystemID_:  valid["filesystem-id"].(string),
+		Releasing_:     valid["releasing"].(bool),
 		StatusHistory_: newStatusHistory(),
 	}
 	if err := result.importStatusHistory(valid); err != nil {
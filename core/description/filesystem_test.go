@@ -46,6 +46,7 @@ func testFilesystemMap() map[interface{}]interface{} {
 		"size":           int(20 * gig),
 		"pool":           "swimming",
 		"filesystem-id":  "some filesystem id",
+		"releasing":      true,
 		"status":         minimalStatusMap(),
 		"status-history": emptyStatusHistoryMap(),
 		"attachments": map[interface{}]interface{}{
@@ -71,6 +72,7 @@ func testFilesystemArgs() FilesystemArgs {
 		Size:         20 * gig,
 		Pool:         "swimming",
 		FilesystemID: "some filesystem id",
+		Releasing:    true,
 	}
 }
 
@@ -87,6 +89,7 @@ func (s *FilesystemSerializationSuite) TestNewFilesystem(c *gc.C) {
 	c.Check(filesystem.Size(), gc.Equals, 20*gig)
 	c.Check(filesystem.Pool(), gc.Equals, "swimming")
 	c.Check(filesystem.FilesystemID(), gc.Equals, "some filesystem id")
+	c.Check(filesystem.Releasing(), jc.IsTrue)
 
 	c.Check(filesystem.Attachments(), gc.HasLen, 0)
 }
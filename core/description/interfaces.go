@@ -91,6 +91,9 @@ type Model interface {
 	Actions() []Action
 	AddAction(ActionArgs) Action
 
+	ActionSchedules() []ActionSchedule
+	AddActionSchedule(ActionScheduleArgs) ActionSchedule
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -257,7 +260,10 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedCIDRs() []string
+	EgressRules() []string
 	MinUnits() int
+	EndpointBindings() map[string]string
 
 	Settings() map[string]interface{}
 
@@ -427,6 +433,22 @@ type Action interface {
 	Results() map[string]interface{}
 	Status() string
 	Message() string
+	Parallel() bool
+	Timeout() time.Duration
+	Schedule() string
+}
+
+// ActionSchedule represents an action which is enqueued on all the
+// units of an application at the times given by a schedule.
+type ActionSchedule interface {
+	Id() string
+	Application() string
+	Name() string
+	Parameters() map[string]interface{}
+	Schedule() string
+	Created() time.Time
+	NextRun() time.Time
+	LastRun() time.Time
 }
 
 // Volume represents a volume (disk, logical volume, etc.) in the model.
@@ -447,6 +469,8 @@ type Volume interface {
 	HardwareID() string
 	VolumeID() string
 	Persistent() bool
+	Releasing() bool
+	Encrypted() bool
 
 	Attachments() []VolumeAttachment
 	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
@@ -478,6 +502,7 @@ type Filesystem interface {
 	Pool() string
 
 	FilesystemID() string
+	Releasing() bool
 
 	Attachments() []FilesystemAttachment
 	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
@@ -497,7 +522,7 @@ type Storage interface {
 	Tag() names.StorageTag
 	Kind() string
 	// Owner returns the tag of the application or unit that owns this storage
-	// instance.
+	// instance, or nil if the storage has been detached and has no owner.
 	Owner() (names.Tag, error)
 	Name() string
 
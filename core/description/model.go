@@ -52,6 +52,7 @@ func NewModel(args ModelArgs) Model {
 	m.setSSHHostKeys(nil)
 	m.setCloudImageMetadatas(nil)
 	m.setActions(nil)
+	m.setActionSchedules(nil)
 	m.setVolumes(nil)
 	m.setFilesystems(nil)
 	m.setStorages(nil)
@@ -129,7 +130,8 @@ type model struct {
 
 	CloudImageMetadata_ cloudimagemetadataset `yaml:"cloud-image-metadata"`
 
-	Actions_ actions `yaml:"actions"`
+	Actions_         actions         `yaml:"actions"`
+	ActionSchedules_ actionSchedules `yaml:"action-schedules"`
 
 	SSHHostKeys_ sshHostKeys `yaml:"ssh-host-keys"`
 
@@ -456,6 +458,29 @@ func (m *model) setActions(actionsList []*action) {
 	}
 }
 
+// ActionSchedules implements Model.
+func (m *model) ActionSchedules() []ActionSchedule {
+	var result []ActionSchedule
+	for _, schedule := range m.ActionSchedules_.ActionSchedules_ {
+		result = append(result, schedule)
+	}
+	return result
+}
+
+// AddActionSchedule implements Model.
+func (m *model) AddActionSchedule(args ActionScheduleArgs) ActionSchedule {
+	schedule := newActionSchedule(args)
+	m.ActionSchedules_.ActionSchedules_ = append(m.ActionSchedules_.ActionSchedules_, schedule)
+	return schedule
+}
+
+func (m *model) setActionSchedules(scheduleList []*actionSchedule) {
+	m.ActionSchedules_ = actionSchedules{
+		Version:          1,
+		ActionSchedules_: scheduleList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -673,9 +698,8 @@ func (m *model) validateStorage(allMachineIDs, allApplications, allUnits set.Str
 		if err != nil {
 			return errors.Wrap(err, errors.NotValidf("storage[%d] owner (%s)", i, owner))
 		}
-		ownerID := owner.Id()
-		if !appsAndUnits.Contains(ownerID) {
-			return errors.NotValidf("storage[%d] owner (%s)", i, ownerID)
+		if owner != nil && !appsAndUnits.Contains(owner.Id()) {
+			return errors.NotValidf("storage[%d] owner (%s)", i, owner.Id())
 		}
 		for _, unit := range storage.Attachments() {
 			if !allUnits.Contains(unit.Id()) {
@@ -906,6 +930,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"ssh-host-keys":        schema.StringMap(schema.Any()),
 		"cloud-image-metadata": schema.StringMap(schema.Any()),
 		"actions":              schema.StringMap(schema.Any()),
+		"action-schedules":     schema.StringMap(schema.Any()),
 		"ip-addresses":         schema.StringMap(schema.Any()),
 		"spaces":               schema.StringMap(schema.Any()),
 		"subnets":              schema.StringMap(schema.Any()),
@@ -921,6 +946,9 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
 		"cloud-region": schema.Omit,
+		// Models serialized before action schedules were
+		// migrated have none.
+		"action-schedules": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -1049,6 +1077,16 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setActions(actions)
 
+	if schedulesMap, ok := valid["action-schedules"]; ok {
+		schedules, err := importActionSchedules(schedulesMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "action-schedules")
+		}
+		result.setActionSchedules(schedules)
+	} else {
+		result.setActionSchedules(nil)
+	}
+
 	volumes, err := importVolumes(valid["volumes"].(map[string]interface{}))
 	if err != nil {
 		return nil, errors.Annotate(err, "volumes")
@@ -701,6 +701,47 @@ func (s *ModelSerializationSuite) TestAction(c *gc.C) {
 	c.Assert(model.Actions(), jc.DeepEquals, actions)
 }
 
+func (s *ModelSerializationSuite) TestActionSchedule(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	created := time.Now().UTC()
+	schedule := initial.AddActionSchedule(ActionScheduleArgs{
+		Id:          "0",
+		Application: "mysql",
+		Name:        "backup",
+		Parameters:  map[string]interface{}{},
+		Schedule:    "@daily",
+		Created:     created,
+		NextRun:     created.Add(time.Hour),
+	})
+	c.Assert(schedule.Name(), gc.Equals, "backup")
+	c.Assert(schedule.Created(), gc.Equals, created)
+	schedules := initial.ActionSchedules()
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0], jc.DeepEquals, schedule)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ActionSchedules(), jc.DeepEquals, schedules)
+}
+
+func (s *ModelSerializationSuite) TestActionSchedulesOptional(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	delete(source, "action-schedules")
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ActionSchedules(), gc.HasLen, 0)
+}
+
 func (s *ModelSerializationSuite) TestVolumeValidation(c *gc.C) {
 	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddVolume(testVolumeArgs())
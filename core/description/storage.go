@@ -88,10 +88,8 @@ func (s *storage) Validate() error {
 	if s.ID_ == "" {
 		return errors.NotValidf("storage missing id")
 	}
-	if s.Owner_ == "" {
-		return errors.NotValidf("storage %q missing owner", s.ID_)
-	}
-	// Also check that the owner and attachments are valid.
+	// Storage that has been detached from its unit has no owner, but
+	// the owner must be valid if there is one.
 	if _, err := s.Owner(); err != nil {
 		return errors.Wrap(err, errors.NotValidf("storage %q invalid owner", s.ID_))
 	}
@@ -87,6 +87,17 @@ func (s *StorageSerializationSuite) TestStorageValidMissingID(c *gc.C) {
 	c.Check(err, jc.Satisfies, errors.IsNotValid)
 }
 
+func (s *StorageSerializationSuite) TestStorageValidDetached(c *gc.C) {
+	args := testStorageArgs()
+	args.Owner = nil
+	args.Attachments = nil
+	storage := newStorage(args)
+	c.Assert(storage.Validate(), jc.ErrorIsNil)
+	owner, err := storage.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.IsNil)
+}
+
 func (s *StorageSerializationSuite) TestStorageMatches(c *gc.C) {
 	bytes, err := yaml.Marshal(testStorage())
 	c.Assert(err, jc.ErrorIsNil)
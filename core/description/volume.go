@@ -24,6 +24,8 @@ type volume struct {
 	HardwareID_  string `yaml:"hardware-id,omitempty"`
 	VolumeID_    string `yaml:"volume-id,omitempty"`
 	Persistent_  bool   `yaml:"persistent"`
+	Releasing_   bool   `yaml:"releasing,omitempty"`
+	Encrypted_   bool   `yaml:"encrypted,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -56,6 +58,8 @@ type VolumeArgs struct {
 	HardwareID  string
 	VolumeID    string
 	Persistent  bool
+	Releasing   bool
+	Encrypted   bool
 }
 
 func newVolume(args VolumeArgs) *volume {
@@ -68,6 +72,8 @@ func newVolume(args VolumeArgs) *volume {
 		HardwareID_:    args.HardwareID,
 		VolumeID_:      args.VolumeID,
 		Persistent_:    args.Persistent,
+		Releasing_:     args.Releasing,
+		Encrypted_:     args.Encrypted,
 		StatusHistory_: newStatusHistory(),
 	}
 	if args.Binding != nil {
@@ -132,6 +138,16 @@ func (v *volume) Persistent() bool {
 	return v.Persistent_
 }
 
+// Releasing implements Volume.
+func (v *volume) Releasing() bool {
+	return v.Releasing_
+}
+
+// Encrypted implements Volume.
+func (v *volume) Encrypted() bool {
+	return v.Encrypted_
+}
+
 // Status implements Volume.
 func (v *volume) Status() Status {
 	// To avoid typed nils check nil here.
@@ -236,6 +252,8 @@ func importVolumeV1(source map[string]interface{}) (*volume, error) {
 		"hardware-id": schema.String(),
 		"volume-id":   schema.String(),
 		"persistent":  schema.Bool(),
+		"releasing":   schema.Bool(),
+		"encrypted":   schema.Bool(),
 		"status":      schema.StringMap(schema.Any()),
 		"attachments": schema.StringMap(schema.Any()),
 	}
@@ -246,6 +264,8 @@ func importVolumeV1(source map[string]interface{}) (*volume, error) {
 		"pool":        "",
 		"hardware-id": "",
 		"volume-id":   "",
+		"releasing":   false,
+		"encrypted":   false,
 		"attachments": schema.Omit,
 	}
 	addStatusHistorySchema(fields)
@@ -268,6 +288,8 @@ func importVolumeV1(source map[string]interface{}) (*volume, error) {
 		HardwareID_:    valid["hardware-id"].(string),
 		VolumeID_:      valid["volume-id"].(string),
 		Persistent_:    valid["persistent"].(bool),
+		Releasing_:     valid["releasing"].(bool),
+		Encrypted_:     valid["encrypted"].(bool),
 		StatusHistory_: newStatusHistory(),
 	}
 	if err := result.importStatusHistory(valid); err != nil {
@@ -47,6 +47,7 @@ func testVolumeMap() map[interface{}]interface{} {
 		"hardware-id":    "a hardware id",
 		"volume-id":      "some volume id",
 		"persistent":     true,
+		"releasing":      true,
 		"status":         minimalStatusMap(),
 		"status-history": emptyStatusHistoryMap(),
 		"attachments": map[interface{}]interface{}{
@@ -73,6 +74,7 @@ func testVolumeArgs() VolumeArgs {
 		HardwareID:  "a hardware id",
 		VolumeID:    "some volume id",
 		Persistent:  true,
+		Releasing:   true,
 	}
 }
 
@@ -90,6 +92,7 @@ func (s *VolumeSerializationSuite) TestNewVolume(c *gc.C) {
 	c.Check(volume.HardwareID(), gc.Equals, "a hardware id")
 	c.Check(volume.VolumeID(), gc.Equals, "some volume id")
 	c.Check(volume.Persistent(), jc.IsTrue)
+	c.Check(volume.Releasing(), jc.IsTrue)
 
 	c.Check(volume.Attachments(), gc.HasLen, 0)
 }
@@ -185,6 +188,16 @@ func (s *VolumeSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(volume, jc.DeepEquals, original)
 }
 
+func (s *VolumeSerializationSuite) TestParsingSerializedDataEncrypted(c *gc.C) {
+	args := testVolumeArgs()
+	args.Encrypted = true
+	original := newVolume(args)
+	original.SetStatus(minimalStatusArgs())
+	volume := s.exportImport(c, original)
+	c.Assert(volume, jc.DeepEquals, original)
+	c.Assert(volume.Encrypted(), jc.IsTrue)
+}
+
 type VolumeAttachmentSerializationSuite struct {
 	SliceSerializationSuite
 }
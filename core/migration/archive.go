@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// A model archive is a gzipped tarball holding a serialized model in
+// archiveModelFile, and the charms and tools it uses in the
+// archiveCharmsDir and archiveToolsDir directories. Charm archives are
+// named by their query-escaped charm URL; tools by their version.
+const (
+	archiveModelFile = "model.yaml"
+	archiveCharmsDir = "charms"
+	archiveToolsDir  = "tools"
+	archiveToolsExt  = ".tgz"
+)
+
+// maxArchiveModelSize limits the size of the serialized model, which
+// is read into memory when a model archive is read.
+const maxArchiveModelSize = 256 * 1024 * 1024
+
+// ArchiveSource defines the methods used to read the binaries used by
+// a model when writing a model archive.
+type ArchiveSource interface {
+	// OpenCharm returns the charm archive for the given URL.
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+
+	// OpenURI returns the tools with the given URI, as listed in
+	// SerializedModel.Tools.
+	OpenURI(uri string, query url.Values) (io.ReadCloser, error)
+}
+
+// WriteArchive writes a model archive containing the serialized model
+// and the charms and tools it uses, read from source, to w.
+func WriteArchive(w io.Writer, model SerializedModel, source ArchiveSource) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	err := tw.WriteHeader(&tar.Header{
+		Name: archiveModelFile,
+		Mode: 0644,
+		Size: int64(len(model.Bytes)),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tw.Write(model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, charmURL := range model.Charms {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := source.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open charm %s", curl)
+		}
+		name := path.Join(archiveCharmsDir, url.QueryEscape(curl.String()))
+		err = writeArchiveEntry(tw, name, reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %s", curl)
+		}
+	}
+
+	for v, uri := range model.Tools {
+		reader, err := source.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open tools %s", v)
+		}
+		name := path.Join(archiveToolsDir, v.String()+archiveToolsExt)
+		err = writeArchiveEntry(tw, name, reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive tools %s", v)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// writeArchiveEntry writes the content of r to the tar archive under
+// the given name. The content is staged in a temporary file because
+// the size of each entry must be known before it is written.
+func writeArchiveEntry(tw *tar.Writer, name string, r io.Reader) error {
+	tempFile, err := ioutil.TempFile("", "juju-model-archive")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+	size, err := io.Copy(tempFile, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tempFile.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: size,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, tempFile)
+	return errors.Trace(err)
+}
+
+// Archive holds the contents of a model archive which has been
+// extracted by ReadArchive.
+type Archive struct {
+	// Model holds the serialized model.
+	Model []byte
+
+	// Charms maps the URL of each charm used by the model to the
+	// path of its extracted charm archive.
+	Charms map[string]string
+
+	// Tools maps each tools version used by the model to the path of
+	// its extracted tools tarball.
+	Tools map[version.Binary]string
+}
+
+// ReadArchive reads a model archive written by WriteArchive from r,
+// extracting the binaries it contains into dir.
+func ReadArchive(r io.Reader, dir string) (*Archive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model archive")
+	}
+	defer gzr.Close()
+
+	for _, subdir := range []string{archiveCharmsDir, archiveToolsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, subdir), 0755); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	archive := &Archive{
+		Charms: make(map[string]string),
+		Tools:  make(map[version.Binary]string),
+	}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "reading model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			return nil, errors.Errorf("unexpected entry %q in model archive", hdr.Name)
+		}
+
+		if hdr.Name == archiveModelFile {
+			if hdr.Size > maxArchiveModelSize {
+				return nil, errors.Errorf("model in archive is too large (%d bytes)", hdr.Size)
+			}
+			archive.Model, err = ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading model")
+			}
+			continue
+		}
+
+		subdir, name := path.Split(hdr.Name)
+		subdir = strings.TrimSuffix(subdir, "/")
+		if name == "" || name == "." || name == ".." {
+			return nil, errors.Errorf("unexpected entry %q in model archive", hdr.Name)
+		}
+		target := filepath.Join(dir, subdir, name)
+		switch subdir {
+		case archiveCharmsDir:
+			charmURL, err := url.QueryUnescape(name)
+			if err != nil {
+				return nil, errors.Errorf("unexpected entry %q in model archive", hdr.Name)
+			}
+			curl, err := charm.ParseURL(charmURL)
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid charm in model archive")
+			}
+			archive.Charms[curl.String()] = target
+		case archiveToolsDir:
+			v, err := version.ParseBinary(strings.TrimSuffix(name, archiveToolsExt))
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid tools in model archive")
+			}
+			archive.Tools[v] = target
+		default:
+			return nil, errors.Errorf("unexpected entry %q in model archive", hdr.Name)
+		}
+		if err := extractArchiveEntry(tr, target); err != nil {
+			return nil, errors.Annotatef(err, "extracting %q", hdr.Name)
+		}
+	}
+	if archive.Model == nil {
+		return nil, errors.New("model archive does not contain a model")
+	}
+	return archive, nil
+}
+
+func extractArchiveEntry(r io.Reader, target string) error {
+	f, err := os.Create(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/migration"
+)
+
+type ArchiveSuite struct{}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+type fakeArchiveSource struct {
+	charms map[string]string
+	uris   map[string]string
+}
+
+func (s *fakeArchiveSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	content, ok := s.charms[curl.String()]
+	if !ok {
+		return nil, errors.NotFoundf("charm %s", curl)
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
+func (s *fakeArchiveSource) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	content, ok := s.uris[uri]
+	if !ok {
+		return nil, errors.NotFoundf("uri %s", uri)
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	tools := version.MustParseBinary("2.0.0-xenial-amd64")
+	model := migration.SerializedModel{
+		Bytes:  []byte("model data"),
+		Charms: []string{"cs:trusty/mysql-3", "local:xenial/my-charm-1"},
+		Tools:  map[version.Binary]string{tools: "/tools/2.0.0-xenial-amd64"},
+	}
+	source := &fakeArchiveSource{
+		charms: map[string]string{
+			"cs:trusty/mysql-3":       "mysql charm",
+			"local:xenial/my-charm-1": "my charm",
+		},
+		uris: map[string]string{
+			"/tools/2.0.0-xenial-amd64": "tools tarball",
+		},
+	}
+	var buf bytes.Buffer
+	err := migration.WriteArchive(&buf, model, source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archive, err := migration.ReadArchive(&buf, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(archive.Model), gc.Equals, "model data")
+	c.Assert(archive.Charms, gc.HasLen, 2)
+	for curl, content := range source.charms {
+		c.Check(readFile(c, archive.Charms[curl]), gc.Equals, content)
+	}
+	c.Assert(archive.Tools, gc.HasLen, 1)
+	c.Check(readFile(c, archive.Tools[tools]), gc.Equals, "tools tarball")
+}
+
+func (s *ArchiveSuite) TestWriteArchiveCharmError(c *gc.C) {
+	model := migration.SerializedModel{
+		Bytes:  []byte("model data"),
+		Charms: []string{"cs:trusty/mysql-3"},
+	}
+	err := migration.WriteArchive(ioutil.Discard, model, &fakeArchiveSource{})
+	c.Assert(err, gc.ErrorMatches, "cannot open charm cs:trusty/mysql-3: charm cs:trusty/mysql-3 not found")
+}
+
+func (s *ArchiveSuite) TestReadArchiveNoModel(c *gc.C) {
+	archive := makeArchive(c, map[string]string{
+		"charms/cs%3Atrusty%2Fmysql-3": "mysql charm",
+	})
+	_, err := migration.ReadArchive(archive, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "model archive does not contain a model")
+}
+
+func (s *ArchiveSuite) TestReadArchiveUnexpectedEntry(c *gc.C) {
+	for _, name := range []string{
+		"other.yaml",
+		"charms/nested/cs%3Atrusty%2Fmysql-3",
+		"tools/..",
+	} {
+		c.Logf("entry %q", name)
+		archive := makeArchive(c, map[string]string{
+			"model.yaml": "model data",
+			name:         "content",
+		})
+		_, err := migration.ReadArchive(archive, c.MkDir())
+		c.Check(err, gc.ErrorMatches, `unexpected entry ".*" in model archive`)
+	}
+}
+
+func (s *ArchiveSuite) TestReadArchiveInvalidTools(c *gc.C) {
+	archive := makeArchive(c, map[string]string{
+		"model.yaml":    "model data",
+		"tools/foo.tgz": "tools tarball",
+	})
+	_, err := migration.ReadArchive(archive, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "invalid tools in model archive: .*")
+}
+
+func (s *ArchiveSuite) TestReadArchiveNotGzipped(c *gc.C) {
+	_, err := migration.ReadArchive(strings.NewReader("junk"), c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "reading model archive: .*")
+}
+
+func makeArchive(c *gc.C, entries map[string]string) io.Reader {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range entries {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(content)),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+func readFile(c *gc.C, path string) string {
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+)
+
+// The kinds of binary which are sent to the target controller during
+// a migration, as used to record the number of bytes transferred.
+const (
+	TransferCharms    = "charms"
+	TransferTools     = "tools"
+	TransferResources = "resources"
+)
+
+// MigrationProgress holds the detailed progress of a model migration
+// for display to the end user.
+type MigrationProgress struct {
+	// MigrationId holds the unique id for the migration.
+	MigrationId string
+
+	// TargetControllerTag identifies the controller the model is
+	// being migrated to.
+	TargetControllerTag names.ControllerTag
+
+	// Phase indicates the current migration phase.
+	Phase Phase
+
+	// StatusMessage holds the latest human readable status message
+	// for the migration.
+	StatusMessage string
+
+	// PhaseTimes holds the time at which the migration entered each
+	// phase so far, in the order they were entered.
+	PhaseTimes []PhaseTime
+
+	// MinionCounts holds the numbers of agents which have reported
+	// (or not) for the current phase, grouped by the kind of agent.
+	MinionCounts []MinionCounts
+
+	// TransferredBytes holds the number of bytes sent to the target
+	// controller, keyed by the kind of binary (e.g. TransferCharms).
+	TransferredBytes map[string]int64
+}
+
+// PhaseTime records when a migration entered a phase.
+type PhaseTime struct {
+	Phase Phase
+	Time  time.Time
+}
+
+// MinionCounts holds the number of agents of one kind (e.g. "machine"
+// or "unit") which have succeeded or failed to complete the current
+// migration phase, or are yet to report on it.
+type MinionCounts struct {
+	Kind      string
+	Succeeded int
+	Failed    int
+	Pending   int
+}
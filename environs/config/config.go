@@ -22,6 +22,8 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -99,6 +101,10 @@ const (
 	// The default block storage source.
 	StorageDefaultBlockSourceKey = "storage-default-block-source"
 
+	// StorageUsageWarningThresholdKey is the percentage of a volume or
+	// filesystem's capacity above which its status carries a warning.
+	StorageUsageWarningThresholdKey = "storage-usage-warning-threshold"
+
 	// ResourceTagsKey is an optional list or space-separated string
 	// of k=v pairs, defining the tags for ResourceTags.
 	ResourceTagsKey = "resource-tags"
@@ -121,6 +127,18 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdGELFHost sets the hostname:port of the GELF TCP input to
+	// which logs are forwarded.
+	LogFwdGELFHost = "gelf-host"
+
+	// LogFwdHTTPURL sets the URL to which batches of logs are posted
+	// as JSON.
+	LogFwdHTTPURL = "http-log-url"
+
+	// LogFwdHTTPBatchSize sets the maximum number of log records
+	// posted to LogFwdHTTPURL in a single request.
+	LogFwdHTTPBatchSize = "http-log-batch-size"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -436,9 +454,15 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if lfCfg, ok := cfg.LogFwdConfig(); ok {
 		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := cfg.defined[StorageUsageWarningThresholdKey].(int); ok {
+		if v < 0 || v > 100 {
+			return errors.Errorf("%s: expected percentage between 0 and 100, got %d", StorageUsageWarningThresholdKey, v)
 		}
 	}
 
@@ -682,6 +706,90 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdGELF returns the GELF forwarding config.
+func (c *Config) LogFwdGELF() (*gelf.RawConfig, bool) {
+	host, _ := c.defined[LogFwdGELFHost].(string)
+	if host == "" {
+		return nil, false
+	}
+	enabled, _ := c.defined[LogForwardEnabled].(bool)
+	return &gelf.RawConfig{
+		Enabled: enabled,
+		Host:    host,
+	}, true
+}
+
+// LogFwdHTTP returns the HTTP JSON forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	url, _ := c.defined[LogFwdHTTPURL].(string)
+	if url == "" {
+		return nil, false
+	}
+	enabled, _ := c.defined[LogForwardEnabled].(bool)
+	batchSize, _ := c.defined[LogFwdHTTPBatchSize].(int)
+	return &httpjson.RawConfig{
+		Enabled:   enabled,
+		URL:       url,
+		BatchSize: batchSize,
+	}, true
+}
+
+// LogFwdConfig holds the configuration of each of the log forwarding
+// sinks. Sinks which have not been configured are nil.
+type LogFwdConfig struct {
+	Syslog *syslog.RawConfig
+	GELF   *gelf.RawConfig
+	HTTP   *httpjson.RawConfig
+}
+
+// Enabled returns whether log forwarding is enabled.
+func (cfg LogFwdConfig) Enabled() bool {
+	switch {
+	case cfg.Syslog != nil:
+		return cfg.Syslog.Enabled
+	case cfg.GELF != nil:
+		return cfg.GELF.Enabled
+	case cfg.HTTP != nil:
+		return cfg.HTTP.Enabled
+	}
+	return false
+}
+
+// Validate ensures that the config of each configured sink is valid.
+func (cfg LogFwdConfig) Validate() error {
+	// Log forwarding may be enabled without a syslog host, as long
+	// as some other sink has been configured.
+	syslogOptional := cfg.GELF != nil || cfg.HTTP != nil
+	if cfg.Syslog != nil && (cfg.Syslog.Host != "" || !syslogOptional) {
+		if err := cfg.Syslog.Validate(); err != nil {
+			return errors.Annotate(err, "invalid syslog forwarding config")
+		}
+	}
+	if cfg.GELF != nil {
+		if err := cfg.GELF.Validate(); err != nil {
+			return errors.Annotate(err, "invalid GELF forwarding config")
+		}
+	}
+	if cfg.HTTP != nil {
+		if err := cfg.HTTP.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP forwarding config")
+		}
+	}
+	return nil
+}
+
+// LogFwdConfig returns the config of all the log forwarding sinks.
+func (c *Config) LogFwdConfig() (*LogFwdConfig, bool) {
+	var lfCfg LogFwdConfig
+	lfCfg.Syslog, _ = c.LogFwdSyslog()
+	lfCfg.GELF, _ = c.LogFwdGELF()
+	lfCfg.HTTP, _ = c.LogFwdHTTP()
+	if lfCfg.Syslog == nil && lfCfg.GELF == nil && lfCfg.HTTP == nil {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -845,6 +953,20 @@ func (c *Config) StorageDefaultBlockSource() (string, bool) {
 	return bs, bs != ""
 }
 
+// DefaultStorageUsageWarningThreshold is the storage usage warning
+// threshold used when none has been configured.
+const DefaultStorageUsageWarningThreshold = 90
+
+// StorageUsageWarningThreshold returns the percentage of a volume or
+// filesystem's capacity above which a warning is reported in its status.
+// A threshold of zero disables the warning.
+func (c *Config) StorageUsageWarningThreshold() int {
+	if v, ok := c.defined[StorageUsageWarningThresholdKey].(int); ok {
+		return v
+	}
+	return DefaultStorageUsageWarningThreshold
+}
+
 // ResourceTags returns a set of tags to set on environment resources
 // that Juju creates and manages, if the provider supports them. These
 // tags have no special meaning to Juju, but may be used for existing
@@ -939,11 +1061,16 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdGELFHost:         schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPBatchSize:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
 
+	StorageUsageWarningThresholdKey: schema.Omit,
+
 	"firewall-mode":              schema.Omit,
 	"logging-config":             schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
@@ -1263,7 +1390,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1287,6 +1414,21 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdGELFHost: {
+		Description: `The hostname:port of the GELF TCP input to forward logs to.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which batches of logs are posted as JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBatchSize: {
+		Description: `The maximum number of logs posted to http-log-url in a single request.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1297,6 +1439,11 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	StorageUsageWarningThresholdKey: {
+		Description: `The percentage of a volume or filesystem's capacity in use above which its status reports a warning (default 90, 0 to disable)`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"test-mode": {
 		Description: `Whether the model is intended for testing.
 If true, accessing the charm store does not affect statistical
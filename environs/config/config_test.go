@@ -544,6 +544,43 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid GELF and HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"gelf-host":           "graylog.example.com:12201",
+			"http-log-url":        "https://logs.example.com/juju",
+			"http-log-batch-size": 50,
+		}),
+	}, {
+		about:       "Log forwarding enabled without any sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+		}),
+		err: `invalid syslog forwarding config: Host "" not valid`,
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"http-log-url":       "ftp://logs.example.com",
+		}),
+		err: `invalid HTTP forwarding config: URL scheme "ftp" not valid`,
+	}, {
+		about:       "Valid storage usage warning threshold",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"storage-usage-warning-threshold": 75,
+		}),
+	}, {
+		about:       "Invalid storage usage warning threshold",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"storage-usage-warning-threshold": 101,
+		}),
+		err: `storage-usage-warning-threshold: expected percentage between 0 and 100, got 101`,
 	},
 }
 
@@ -646,6 +683,23 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	gelfCfg, hasGELFCfg := cfg.LogFwdGELF()
+	if v, ok := test.attrs["gelf-host"].(string); ok {
+		c.Assert(hasGELFCfg, jc.IsTrue)
+		c.Assert(gelfCfg.Host, gc.Equals, v)
+	} else {
+		c.Assert(hasGELFCfg, jc.IsFalse)
+	}
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if v, ok := test.attrs["http-log-url"].(string); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+		batchSize, _ := test.attrs["http-log-batch-size"].(int)
+		c.Assert(httpCfg.BatchSize, gc.Equals, batchSize)
+	} else {
+		c.Assert(hasHTTPCfg, jc.IsFalse)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -713,6 +767,13 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(resourceTags, gc.HasLen, 0)
 	}
 
+	threshold := cfg.StorageUsageWarningThreshold()
+	if v, ok := test.attrs["storage-usage-warning-threshold"].(int); ok {
+		c.Check(threshold, gc.Equals, v)
+	} else {
+		c.Check(threshold, gc.Equals, config.DefaultStorageUsageWarningThreshold)
+	}
+
 	xmit := cfg.TransmitVendorMetrics()
 	expectedXmit, xmitAsserted := test.attrs["transmit-vendor-metrics"]
 	if xmitAsserted {
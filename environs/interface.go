@@ -315,6 +315,47 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// IngressRuleFirewaller is an optional interface, implemented by
+// Environs whose global firewall can restrict the source addresses
+// allowed to reach opened ports. Its methods must only be used if the
+// environment was setup with the FwGlobal firewall mode.
+type IngressRuleFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment, sorted by network.SortIngressRules().
+	IngressRules() ([]network.IngressRule, error)
+}
+
+// EgressFirewaller is an optional interface, implemented by Environs
+// that can restrict the outbound traffic of individual machines. Its
+// methods must only be used if the environment was setup with the
+// FwInstance firewall mode. They may return an error satisfying
+// errors.IsNotSupported if the environment's firewall cannot restrict
+// outbound traffic after all.
+type EgressFirewaller interface {
+	// SupportsEgressRules reports whether the environment's firewall
+	// can restrict the outbound traffic of machines.
+	SupportsEgressRules() (bool, error)
+
+	// SetEgressRules restricts the outbound traffic of the machine
+	// with the given id to the given rules, replacing any previously
+	// set. If no rules are given, all outbound traffic is allowed.
+	SetEgressRules(machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the rules to which the outbound traffic of
+	// the machine with the given id is restricted, sorted by
+	// network.SortEgressRules(). If none are returned, all outbound
+	// traffic is allowed.
+	EgressRules(machineId string) ([]network.EgressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 )
@@ -83,3 +84,17 @@ func SupportsSpaces(env Environ) bool {
 	}
 	return ok
 }
+
+// SupportsEgressRules reports whether the environment can restrict the
+// outbound traffic of machines, which requires the FwInstance firewall
+// mode and an environment firewall implementing EgressFirewaller.
+func SupportsEgressRules(env Environ) (bool, error) {
+	if env.Config().FirewallMode() != config.FwInstance {
+		return false, nil
+	}
+	egressEnv, ok := env.(EgressFirewaller)
+	if !ok {
+		return false, nil
+	}
+	return egressEnv.SupportsEgressRules()
+}
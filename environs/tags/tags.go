@@ -35,6 +35,10 @@ const (
 	// the service or unit that owns the Juju storage instance
 	// that an IaaS storage resource is assigned to.
 	JujuStorageOwner = JujuTagPrefix + "storage-owner"
+
+	// JujuStorageVolume is the tag name used for identifying
+	// the Juju volume that an IaaS volume snapshot was taken of.
+	JujuStorageVolume = JujuTagPrefix + "storage-volume"
 )
 
 // ResourceTagger is an interface that can provide resource tags.
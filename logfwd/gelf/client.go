@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// gelfVersion is the version of the GELF specification implemented.
+const gelfVersion = "1.1"
+
+// dialTimeout is how long to wait when connecting to the GELF host.
+const dialTimeout = 30 * time.Second
+
+// DialFunc connects to the given TCP address.
+type DialFunc func(network, address string) (net.Conn, error)
+
+func dial(network, address string) (net.Conn, error) {
+	conn, err := net.DialTimeout(network, address, dialTimeout)
+	return conn, errors.Trace(err)
+}
+
+// Client is the wrapper around a GELF TCP connection.
+type Client struct {
+	// Conn is the connection over which messages are written.
+	Conn io.WriteCloser
+}
+
+// Open connects to a remote GELF host and wraps that connection in
+// a new client.
+func Open(cfg RawConfig) (*Client, error) {
+	client, err := OpenForDialer(cfg, dial)
+	return client, errors.Trace(err)
+}
+
+// OpenForDialer connects to a remote GELF host using the given dial
+// function and wraps that connection in a new client.
+func OpenForDialer(cfg RawConfig, dial DialFunc) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	conn, err := dial("tcp", cfg.address())
+	if err != nil {
+		return nil, errors.Annotate(err, "opening client connection")
+	}
+	return &Client{Conn: conn}, nil
+}
+
+// Close closes the client's connection.
+func (client Client) Close() error {
+	err := client.Conn.Close()
+	return errors.Trace(err)
+}
+
+// Send sends the records to the remote GELF host. Each message is
+// terminated by a null byte, as required for GELF over TCP.
+func (client Client) Send(records []logfwd.Record) error {
+	for _, rec := range records {
+		msg, err := messageFromRecord(rec)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := client.Conn.Write(append(data, 0)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Message is a single GELF message. Fields prefixed with an underscore
+// are additional fields, which GELF servers index by name.
+type Message struct {
+	Version         string  `json:"version"`
+	Host            string  `json:"host"`
+	ShortMessage    string  `json:"short_message"`
+	Timestamp       float64 `json:"timestamp"`
+	Level           int     `json:"level"`
+	RecordID        int64   `json:"_record_id"`
+	ControllerUUID  string  `json:"_controller_uuid"`
+	ModelUUID       string  `json:"_model_uuid"`
+	OriginType      string  `json:"_origin_type"`
+	OriginName      string  `json:"_origin_name"`
+	Software        string  `json:"_software"`
+	SoftwareVersion string  `json:"_software_version"`
+	Module          string  `json:"_module"`
+	Source          string  `json:"_source"`
+}
+
+func messageFromRecord(rec logfwd.Record) (Message, error) {
+	msg := Message{
+		Version:         gelfVersion,
+		Host:            rec.Origin.Hostname,
+		ShortMessage:    rec.Message,
+		Timestamp:       float64(rec.Timestamp.UnixNano()) / float64(time.Second),
+		RecordID:        rec.ID,
+		ControllerUUID:  rec.Origin.ControllerUUID,
+		ModelUUID:       rec.Origin.ModelUUID,
+		OriginType:      rec.Origin.Type.String(),
+		OriginName:      rec.Origin.Name,
+		Software:        rec.Origin.Software.Name,
+		SoftwareVersion: rec.Origin.Software.Version.String(),
+		Module:          rec.Location.Module,
+		Source:          fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line),
+	}
+	// GELF requires a non-empty short message.
+	if msg.ShortMessage == "" {
+		msg.ShortMessage = "-"
+	}
+
+	// GELF uses the syslog severity levels.
+	switch rec.Level {
+	case loggo.CRITICAL:
+		msg.Level = 2
+	case loggo.ERROR:
+		msg.Level = 3
+	case loggo.WARNING:
+		msg.Level = 4
+	case loggo.INFO:
+		msg.Level = 6
+	case loggo.DEBUG, loggo.TRACE:
+		msg.Level = 7
+	default:
+		return msg, errors.Errorf("unsupported log level %q", rec.Level)
+	}
+	return msg, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	conn *stubConn
+	rec  logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.conn = &stubConn{stub: s.stub}
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, version.MustParse("1.2.3")),
+		Timestamp: time.Unix(12345, 500000000),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) TestOpen(c *gc.C) {
+	var conn net.Conn = &net.TCPConn{}
+	dial := func(network, address string) (net.Conn, error) {
+		s.stub.AddCall("Dial", network, address)
+		return conn, s.stub.NextErr()
+	}
+
+	client, err := gelf.OpenForDialer(gelf.RawConfig{Enabled: true, Host: "a.b.c"}, dial)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []testing.StubCall{{"Dial", []interface{}{"tcp", "a.b.c:12201"}}})
+	c.Check(client.Conn, gc.Equals, conn)
+
+	s.stub.ResetCalls()
+	_, err = gelf.OpenForDialer(gelf.RawConfig{Enabled: true, Host: "a.b.c:1234"}, dial)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []testing.StubCall{{"Dial", []interface{}{"tcp", "a.b.c:1234"}}})
+}
+
+func (s *ClientSuite) TestOpenError(c *gc.C) {
+	dial := func(network, address string) (net.Conn, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := gelf.OpenForDialer(gelf.RawConfig{Enabled: true, Host: "a.b.c"}, dial)
+	c.Assert(err, gc.ErrorMatches, "opening client connection: boom")
+}
+
+func (s *ClientSuite) TestClose(c *gc.C) {
+	client := gelf.Client{Conn: s.conn}
+
+	err := client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Close")
+}
+
+func (s *ClientSuite) TestSendLogFull(c *gc.C) {
+	client := gelf.Client{Conn: s.conn}
+
+	err := client.Send([]logfwd.Record{s.rec, s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write", "Write")
+	messages := bytes.Split(s.conn.buf.Bytes(), []byte{0})
+	c.Assert(messages, gc.HasLen, 3)
+	c.Assert(messages[2], gc.HasLen, 0)
+
+	var msg gelf.Message
+	err = json.Unmarshal(messages[0], &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg, jc.DeepEquals, gelf.Message{
+		Version:         "1.1",
+		Host:            "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		ShortMessage:    "(╯°□°)╯︵ ┻━┻",
+		Timestamp:       12345.5,
+		Level:           3,
+		RecordID:        10,
+		ControllerUUID:  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		OriginType:      "machine",
+		OriginName:      "99",
+		Software:        "jujud-machine-agent",
+		SoftwareVersion: "1.2.3",
+		Module:          "juju.x.y",
+		Source:          "x/y/spam.go:42",
+	})
+}
+
+func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
+	client := gelf.Client{Conn: s.conn}
+
+	levels := map[loggo.Level]int{
+		loggo.CRITICAL: 2,
+		loggo.ERROR:    3,
+		loggo.WARNING:  4,
+		loggo.INFO:     6,
+		loggo.DEBUG:    7,
+		loggo.TRACE:    7,
+	}
+	for level, expected := range levels {
+		c.Logf("trying %s -> %d", level, expected)
+		s.conn.buf.Reset()
+		s.rec.Level = level
+
+		err := client.Send([]logfwd.Record{s.rec})
+		c.Assert(err, jc.ErrorIsNil)
+
+		var msg gelf.Message
+		err = json.Unmarshal(bytes.TrimRight(s.conn.buf.Bytes(), "\x00"), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg.Level, gc.Equals, expected)
+	}
+}
+
+func (s *ClientSuite) TestSendEmptyMessage(c *gc.C) {
+	client := gelf.Client{Conn: s.conn}
+	s.rec.Message = ""
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var msg gelf.Message
+	err = json.Unmarshal(bytes.TrimRight(s.conn.buf.Bytes(), "\x00"), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.ShortMessage, gc.Equals, "-")
+}
+
+func (s *ClientSuite) TestSendWriteError(c *gc.C) {
+	client := gelf.Client{Conn: s.conn}
+	s.stub.SetErrors(errors.New("boom"))
+
+	err := client.Send([]logfwd.Record{s.rec, s.rec})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stub.CheckCallNames(c, "Write")
+}
+
+type stubConn struct {
+	stub *testing.Stub
+	buf  bytes.Buffer
+}
+
+func (s *stubConn) Write(data []byte) (int, error) {
+	s.stub.AddCall("Write", data)
+	if err := s.stub.NextErr(); err != nil {
+		return 0, err
+	}
+	return s.buf.Write(data)
+}
+
+func (s *stubConn) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+)
+
+// DefaultPort is the port used when the configured host does not
+// specify one.
+const DefaultPort = "12201"
+
+// RawConfig holds the raw configuration data for a connection to a
+// GELF forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Host is the host-port of the GELF TCP input. The format is:
+	//
+	//   [domain-or-ip-addr] or [domain-or-ip-addr][:port]
+	//
+	// If the port is not set then DefaultPort will be used.
+	Host string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	host, _, err := net.SplitHostPort(cfg.Host)
+	if err != nil {
+		host = cfg.Host
+	}
+	if host == "" && cfg.Enabled {
+		return errors.NotValidf("Host %q", cfg.Host)
+	}
+	return nil
+}
+
+// address returns the host-port to connect to, adding the default
+// port if none was configured.
+func (cfg RawConfig) address() string {
+	if _, _, err := net.SplitHostPort(cfg.Host); err == nil {
+		return cfg.Host
+	}
+	return net.JoinHostPort(cfg.Host, DefaultPort)
+}
@@ -0,0 +1,7 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The gelf package holds the tools needed to perform log forwarding
+// from Juju to a remote host accepting the Graylog Extended Log Format
+// (GELF) over TCP.
+package gelf
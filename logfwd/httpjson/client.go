@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// requestTimeout is how long to wait for the endpoint to respond to
+// each batch of records.
+const requestTimeout = 30 * time.Second
+
+// HTTPClient exposes the underlying functionality needed by Client.
+type HTTPClient interface {
+	// Do sends an HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client posts batches of log records to an HTTP endpoint.
+type Client struct {
+	// URL is the endpoint to which records are posted.
+	URL string
+
+	// BatchSize is the maximum number of records posted in a
+	// single request.
+	BatchSize int
+
+	// HTTPClient is used to make the requests.
+	HTTPClient HTTPClient
+}
+
+// Open returns a new client for the configured endpoint.
+func Open(cfg RawConfig) (*Client, error) {
+	client, err := OpenForClient(cfg, &http.Client{Timeout: requestTimeout})
+	return client, errors.Trace(err)
+}
+
+// OpenForClient returns a new client for the configured endpoint,
+// which makes requests using the given HTTP client.
+func OpenForClient(cfg RawConfig, httpClient HTTPClient) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.URL == "" {
+		return nil, errors.NotValidf("empty URL")
+	}
+	return &Client{
+		URL:        cfg.URL,
+		BatchSize:  cfg.batchSize(),
+		HTTPClient: httpClient,
+	}, nil
+}
+
+// Close implements io.Closer. There is no connection to close, since
+// each batch is posted in a request of its own.
+func (client Client) Close() error {
+	return nil
+}
+
+// Send posts the records to the endpoint, in batches of at most
+// BatchSize records.
+func (client Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := client.BatchSize
+		if n <= 0 || n > len(records) {
+			n = len(records)
+		}
+		if err := client.post(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client Client) post(records []logfwd.Record) error {
+	batch := make([]Record, len(records))
+	for i, rec := range records {
+		batch[i] = recordFromLogfwd(rec)
+	}
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", client.URL, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "posting log records")
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection may be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("posting log records: unexpected response %q", resp.Status)
+	}
+	return nil
+}
+
+// Record is the JSON representation of a single log record.
+type Record struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name"`
+	Software        string    `json:"software"`
+	SoftwareVersion string    `json:"software-version"`
+	Module          string    `json:"module"`
+	Source          string    `json:"source"`
+	Message         string    `json:"message"`
+}
+
+func recordFromLogfwd(rec logfwd.Record) Record {
+	return Record{
+		ID:              rec.ID,
+		Timestamp:       rec.Timestamp.UTC(),
+		Level:           rec.Level.String(),
+		ControllerUUID:  rec.Origin.ControllerUUID,
+		ModelUUID:       rec.Origin.ModelUUID,
+		Hostname:        rec.Origin.Hostname,
+		OriginType:      rec.Origin.Type.String(),
+		OriginName:      rec.Origin.Name,
+		Software:        rec.Origin.Software.Name,
+		SoftwareVersion: rec.Origin.Software.Version.String(),
+		Module:          rec.Location.Module,
+		Source:          fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line),
+		Message:         rec.Message,
+	}
+}
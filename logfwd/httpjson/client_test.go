@@ -0,0 +1,146 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	status   int
+	requests [][]httpjson.Record
+	rec      logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.status = http.StatusOK
+	s.requests = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		var batch []httpjson.Record
+		err := json.NewDecoder(r.Body).Decode(&batch)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, batch)
+		w.WriteHeader(s.status)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	tag := names.NewUnitTag("mysql/1")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForUnitAgent(tag, cID, mID, version.MustParse("1.2.3")),
+		Timestamp: time.Date(2016, 11, 2, 9, 0, 0, 0, time.UTC),
+		Level:     loggo.WARNING,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "disk nearly full",
+	}
+}
+
+func (s *ClientSuite) open(c *gc.C, batchSize int) *httpjson.Client {
+	client, err := httpjson.Open(httpjson.RawConfig{
+		Enabled:   true,
+		URL:       s.server.URL,
+		BatchSize: batchSize,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenDefaultBatchSize(c *gc.C) {
+	client := s.open(c, 0)
+	c.Check(client.URL, gc.Equals, s.server.URL)
+	c.Check(client.BatchSize, gc.Equals, httpjson.DefaultBatchSize)
+}
+
+func (s *ClientSuite) TestOpenInvalid(c *gc.C) {
+	_, err := httpjson.Open(httpjson.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c, 0)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, jc.DeepEquals, [][]httpjson.Record{{{
+		ID:              10,
+		Timestamp:       time.Date(2016, 11, 2, 9, 0, 0, 0, time.UTC),
+		Level:           "WARNING",
+		ControllerUUID:  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:        "unit-mysql-1.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		OriginType:      "unit",
+		OriginName:      "mysql/1",
+		Software:        "jujud-unit-agent",
+		SoftwareVersion: "1.2.3",
+		Module:          "juju.x.y",
+		Source:          "x/y/spam.go:42",
+		Message:         "disk nearly full",
+	}}})
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, 2)
+	records := make([]logfwd.Record, 5)
+	for i := range records {
+		records[i] = s.rec
+		records[i].ID = int64(i)
+	}
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 3)
+	var ids [][]int64
+	for _, batch := range s.requests {
+		var batchIDs []int64
+		for _, rec := range batch {
+			batchIDs = append(batchIDs, rec.ID)
+		}
+		ids = append(ids, batchIDs)
+	}
+	c.Assert(ids, jc.DeepEquals, [][]int64{{0, 1}, {2, 3}, {4}})
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.status = http.StatusServiceUnavailable
+	client := s.open(c, 0)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `posting log records: unexpected response "503 Service Unavailable"`)
+}
+
+func (s *ClientSuite) TestClose(c *gc.C) {
+	client := s.open(c, 0)
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
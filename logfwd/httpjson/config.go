@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+)
+
+// DefaultBatchSize is the maximum number of records posted in a single
+// request when the batch size is not configured.
+const DefaultBatchSize = 100
+
+// RawConfig holds the raw configuration data for forwarding logs to
+// an HTTP endpoint.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the http or https URL to which batches of records
+	// are posted.
+	URL string
+
+	// BatchSize is the maximum number of records posted in a single
+	// request. If it is zero then DefaultBatchSize will be used.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+	} else {
+		u, err := url.Parse(cfg.URL)
+		if err != nil {
+			return errors.NotValidf("URL %q", cfg.URL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("URL scheme %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return errors.NotValidf("URL %q without host", cfg.URL)
+		}
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	return nil
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidate(c *gc.C) {
+	for _, cfg := range []httpjson.RawConfig{
+		{},
+		{Enabled: true, URL: "https://logs.example.com/juju"},
+		{Enabled: true, URL: "http://10.0.0.1:8080", BatchSize: 10},
+	} {
+		c.Check(cfg.Validate(), jc.ErrorIsNil)
+	}
+}
+
+func (s *ConfigSuite) TestRawValidateErrors(c *gc.C) {
+	for _, test := range []struct {
+		cfg httpjson.RawConfig
+		err string
+	}{{
+		cfg: httpjson.RawConfig{Enabled: true},
+		err: "empty URL not valid",
+	}, {
+		cfg: httpjson.RawConfig{URL: "ftp://logs.example.com"},
+		err: `URL scheme "ftp" not valid`,
+	}, {
+		cfg: httpjson.RawConfig{URL: "http:///juju"},
+		err: `URL "http:///juju" without host not valid`,
+	}, {
+		cfg: httpjson.RawConfig{URL: "http://logs.example.com", BatchSize: -1},
+		err: "negative BatchSize not valid",
+	}} {
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/tools"
@@ -107,6 +108,11 @@ type UploadBinariesConfig struct {
 	Tools           map[version.Binary]string
 	ToolsDownloader ToolsDownloader
 	ToolsUploader   ToolsUploader
+
+	// ReportTransfer, if set, is called with the kind (e.g.
+	// migration.TransferCharms) and size of each binary once it
+	// has been sent to the target controller.
+	ReportTransfer func(kind string, size int64)
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -141,19 +147,25 @@ func UploadBinaries(config UploadBinariesConfig) error {
 	return nil
 }
 
-func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
+func (c *UploadBinariesConfig) reportTransfer(kind string, size int64) {
+	if c.ReportTransfer != nil {
+		c.ReportTransfer(kind, size)
+	}
+}
+
+func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, size int64, cleanup func(), err error) {
 	tempFile, err := ioutil.TempFile("", "juju-migrate-binary")
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, 0, nil, errors.Trace(err)
 	}
 	defer func() {
 		if err != nil {
 			os.Remove(tempFile.Name())
 		}
 	}()
-	_, err = io.Copy(tempFile, r)
+	size, err = io.Copy(tempFile, r)
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, 0, nil, errors.Trace(err)
 	}
 	tempFile.Seek(0, 0)
 	rmTempFile := func() {
@@ -162,7 +174,7 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 		os.Remove(filename)
 	}
 
-	return tempFile, rmTempFile, nil
+	return tempFile, size, rmTempFile, nil
 }
 
 func uploadCharms(config UploadBinariesConfig) error {
@@ -180,7 +192,7 @@ func uploadCharms(config UploadBinariesConfig) error {
 		}
 		defer reader.Close()
 
-		content, cleanup, err := streamThroughTempFile(reader)
+		content, size, cleanup, err := streamThroughTempFile(reader)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -189,6 +201,7 @@ func uploadCharms(config UploadBinariesConfig) error {
 		if _, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
 			return errors.Annotate(err, "cannot upload charm")
 		}
+		config.reportTransfer(coremigration.TransferCharms, size)
 	}
 	return nil
 }
@@ -203,7 +216,7 @@ func uploadTools(config UploadBinariesConfig) error {
 		}
 		defer reader.Close()
 
-		content, cleanup, err := streamThroughTempFile(reader)
+		content, size, cleanup, err := streamThroughTempFile(reader)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -212,6 +225,7 @@ func uploadTools(config UploadBinariesConfig) error {
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload tools")
 		}
+		config.reportTransfer(coremigration.TransferTools, size)
 	}
 	return nil
 }
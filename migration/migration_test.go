@@ -18,6 +18,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
@@ -117,6 +118,10 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 		ToolsDownloader: downloader,
 		ToolsUploader:   uploader,
 	}
+	transferred := make(map[string]int64)
+	config.ReportTransfer = func(kind string, size int64) {
+		transferred[kind] += size
+	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -133,6 +138,13 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 		"/tools/1",
 	})
 	c.Assert(uploader.tools, jc.DeepEquals, toolsMap)
+
+	// The fake binaries' content is their charm URL or tools URI,
+	// suffixed with " content" for charms.
+	c.Assert(transferred, jc.DeepEquals, map[string]int64{
+		coremigration.TransferCharms: int64(len("local:trusty/magic content") + len("cs:trusty/postgresql-42 content")),
+		coremigration.TransferTools:  int64(len("/tools/0") + len("/tools/1")),
+	})
 }
 
 type fakeDownloader struct {
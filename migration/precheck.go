@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -29,6 +31,12 @@ type PrecheckBackend interface {
 	IsMigrationActive(string) (bool, error)
 	AllMachines() ([]PrecheckMachine, error)
 	AllApplications() ([]PrecheckApplication, error)
+	AllApplicationOffers() ([]PrecheckApplicationOffer, error)
+	AllRemoteApplications() ([]PrecheckRemoteApplication, error)
+	ApplicationsWithResources() ([]string, error)
+	VolumesWithEncryptionKeys() ([]names.VolumeTag, error)
+	Charm(*charm.URL) (PrecheckCharm, error)
+	CloudCredential(names.CloudCredentialTag) (cloud.Credential, error)
 	ControllerBackend() (PrecheckBackend, error)
 }
 
@@ -40,6 +48,7 @@ type PrecheckModel interface {
 	Owner() names.UserTag
 	Life() state.Life
 	MigrationMode() state.MigrationMode
+	CloudCredential() (names.CloudCredentialTag, bool)
 }
 
 // PrecheckMachine describes the state interface for a machine needed
@@ -64,6 +73,24 @@ type PrecheckApplication interface {
 	MinUnits() int
 }
 
+// PrecheckApplicationOffer describes the state interface for an
+// application offer needed by migration prechecks.
+type PrecheckApplicationOffer interface {
+	Name() string
+}
+
+// PrecheckRemoteApplication describes the state interface for a
+// remote application needed by migration prechecks.
+type PrecheckRemoteApplication interface {
+	Name() string
+}
+
+// PrecheckCharm describes the state interface for a charm needed by
+// migration prechecks.
+type PrecheckCharm interface {
+	IsUploaded() bool
+}
+
 // PrecheckUnit describes state interface for a unit needed by
 // migration prechecks.
 type PrecheckUnit interface {
@@ -76,29 +103,85 @@ type PrecheckUnit interface {
 	AgentPresence() (bool, error)
 }
 
+// precheck is a group of related migration prechecks, returning an
+// error describing the first problem found.
+type precheck func(PrecheckBackend) error
+
+// sourcePrechecks holds the prechecks run against the source
+// controller, in order.
+var sourcePrechecks = []precheck{
+	checkModel,
+	checkCredential,
+	checkMachines,
+	checkApplications,
+	checkCrossModelRelations,
+	checkEncryptedVolumes,
+	checkResources,
+	checkCharms,
+	checkCleanups,
+	checkSourceController,
+}
+
+// exportPrechecks holds the prechecks run before exporting a model to
+// an archive, in order. They check for the parts of a model which are
+// not exported.
+var exportPrechecks = []precheck{
+	checkCrossModelRelations,
+	checkEncryptedVolumes,
+	checkResources,
+}
+
 // SourcePrecheck checks the state of the source controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
 func SourcePrecheck(backend PrecheckBackend) error {
-	if err := checkModel(backend); err != nil {
-		return errors.Trace(err)
-	}
+	return runPrechecks(backend, sourcePrechecks)
+}
 
-	if err := checkMachines(backend); err != nil {
-		return errors.Trace(err)
+// SourcePrecheckReport runs the same checks as SourcePrecheck, but
+// rather than stopping at the first problem found, it returns the
+// problems found by each group of checks. No problems means the model
+// may be migrated.
+func SourcePrecheckReport(backend PrecheckBackend) []string {
+	return reportPrechecks(backend, sourcePrechecks)
+}
+
+// ExportPrecheck checks that the model contains nothing that would be
+// lost by exporting it to an archive. The backend provided must be for
+// the model to be exported.
+func ExportPrecheck(backend PrecheckBackend) error {
+	return runPrechecks(backend, exportPrechecks)
+}
+
+func runPrechecks(backend PrecheckBackend, prechecks []precheck) error {
+	for _, check := range prechecks {
+		if err := check(backend); err != nil {
+			return errors.Trace(err)
+		}
 	}
+	return nil
+}
 
-	if err := checkApplications(backend); err != nil {
-		return errors.Trace(err)
+func reportPrechecks(backend PrecheckBackend, prechecks []precheck) []string {
+	var blockers []string
+	for _, check := range prechecks {
+		if err := check(backend); err != nil {
+			blockers = append(blockers, err.Error())
+		}
 	}
+	return blockers
+}
 
+func checkCleanups(backend PrecheckBackend) error {
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
 		return errors.New("cleanup needed")
 	}
+	return nil
+}
 
-	// Check the source controller.
+func checkSourceController(backend PrecheckBackend) error {
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
 		return errors.Trace(err)
@@ -123,6 +206,29 @@ func checkModel(backend PrecheckBackend) error {
 	return nil
 }
 
+// checkCredential checks that the cloud credential of the model, if
+// it has one, may still be used.
+func checkCredential(backend PrecheckBackend) error {
+	model, err := backend.Model()
+	if err != nil {
+		return errors.Annotate(err, "retrieving model")
+	}
+	tag, ok := model.CloudCredential()
+	if !ok {
+		return nil
+	}
+	credential, err := backend.CloudCredential(tag)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("cloud credential %s not found", tag.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving cloud credential %s", tag.Id())
+	}
+	if credential.Revoked {
+		return errors.Errorf("cloud credential %s is revoked", tag.Id())
+	}
+	return nil
+}
+
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
@@ -130,7 +236,38 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	return runPrechecks(backend, targetPrechecks(modelInfo))
+}
+
+// TargetPrecheckReport runs the same checks as TargetPrecheck, but
+// rather than stopping at the first problem found, it returns the
+// problems found by each group of checks. No problems means the
+// target controller may accept the model.
+func TargetPrecheckReport(backend PrecheckBackend, modelInfo coremigration.ModelInfo) ([]string, error) {
+	if err := modelInfo.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reportPrechecks(backend, targetPrechecks(modelInfo)), nil
+}
+
+// targetPrechecks returns the prechecks run against the target
+// controller for the described model, in order.
+func targetPrechecks(modelInfo coremigration.ModelInfo) []precheck {
+	return []precheck{
+		func(backend PrecheckBackend) error {
+			return checkNotMigrating(backend, modelInfo)
+		},
+		func(backend PrecheckBackend) error {
+			return checkTargetVersion(backend, modelInfo)
+		},
+		checkController,
+		func(backend PrecheckBackend) error {
+			return checkModelConflicts(backend, modelInfo)
+		},
+	}
+}
 
+func checkNotMigrating(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
 	// documents have been deleted but the migration isn't quite done
@@ -143,7 +280,10 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	} else if migrating {
 		return errors.New("model is being migrated out of target controller")
 	}
+	return nil
+}
 
+func checkTargetVersion(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
 	controllerVersion, err := backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
@@ -153,12 +293,10 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 		return errors.Errorf("model has higher version than target controller (%s > %s)",
 			modelInfo.AgentVersion, controllerVersion)
 	}
+	return nil
+}
 
-	if err := checkController(backend); err != nil {
-		return errors.Trace(err)
-	}
-
-	// Check for conflicts with existing models
+func checkModelConflicts(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
 	models, err := backend.AllModels()
 	if err != nil {
 		return errors.Annotate(err, "retrieving models")
@@ -259,6 +397,94 @@ func checkApplications(backend PrecheckBackend) error {
 	return nil
 }
 
+// checkCrossModelRelations checks that the model neither offers nor
+// consumes applications across models. The offers, remote applications
+// and their relations' networks are not exported, so the relations
+// would be broken by the migration.
+func checkCrossModelRelations(backend PrecheckBackend) error {
+	offers, err := backend.AllApplicationOffers()
+	if err != nil {
+		return errors.Annotate(err, "retrieving application offers")
+	}
+	if len(offers) > 0 {
+		offerNames := make([]string, len(offers))
+		for i, offer := range offers {
+			offerNames[i] = offer.Name()
+		}
+		return errors.Errorf("model has application offers (%s), which cannot be migrated",
+			strings.Join(offerNames, ", "))
+	}
+	remoteApps, err := backend.AllRemoteApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving remote applications")
+	}
+	if len(remoteApps) > 0 {
+		appNames := make([]string, len(remoteApps))
+		for i, app := range remoteApps {
+			appNames[i] = app.Name()
+		}
+		return errors.Errorf("model has remote applications (%s), which cannot be migrated",
+			strings.Join(appNames, ", "))
+	}
+	return nil
+}
+
+// checkEncryptedVolumes checks that the model has no volumes with
+// generated encryption keys. The keys are not exported, so the contents
+// of the volumes would be unreadable after the migration.
+func checkEncryptedVolumes(backend PrecheckBackend) error {
+	tags, err := backend.VolumesWithEncryptionKeys()
+	if err != nil {
+		return errors.Annotate(err, "retrieving encrypted volumes")
+	}
+	if len(tags) > 0 {
+		ids := make([]string, len(tags))
+		for i, tag := range tags {
+			ids[i] = tag.Id()
+		}
+		return errors.Errorf("model has encrypted volumes (%s), which cannot be migrated",
+			strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+// checkResources checks that no application in the model has resources
+// with uploaded or fetched content. The resource blobs are not exported,
+// so the applications would be left without them.
+func checkResources(backend PrecheckBackend) error {
+	appNames, err := backend.ApplicationsWithResources()
+	if err != nil {
+		return errors.Annotate(err, "retrieving resources")
+	}
+	if len(appNames) > 0 {
+		return errors.Errorf("model has applications with resources (%s), which cannot be migrated",
+			strings.Join(appNames, ", "))
+	}
+	return nil
+}
+
+// checkCharms checks that the charms of all applications are available
+// for export.
+func checkCharms(backend PrecheckBackend) error {
+	apps, err := backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		curl, _ := app.CharmURL()
+		ch, err := backend.Charm(curl)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("charm %s of application %s not found", curl, app.Name())
+		} else if err != nil {
+			return errors.Annotatef(err, "retrieving charm %s", curl)
+		}
+		if !ch.IsUploaded() {
+			return errors.Errorf("charm %s of application %s not uploaded", curl, app.Name())
+		}
+	}
+	return nil
+}
+
 func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
 	units, err := app.AllUnits()
 	if err != nil {
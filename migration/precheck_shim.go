@@ -6,6 +6,7 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/state"
 )
@@ -30,6 +31,35 @@ func (s *precheckShim) Model() (PrecheckModel, error) {
 	return model, nil
 }
 
+// ApplicationsWithResources implements PrecheckBackend. Placeholder
+// resources, whose content has not been fetched yet, are ignored.
+func (s *precheckShim) ApplicationsWithResources() ([]string, error) {
+	resources, err := s.State.Resources()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var appNames []string
+	for _, app := range apps {
+		appResources, err := resources.ListResources(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, res := range appResources.Resources {
+			if !res.IsPlaceholder() {
+				appNames = append(appNames, app.Name())
+				break
+			}
+		}
+	}
+	return appNames, nil
+}
+
 // AllModels implements PrecheckBackend.
 func (s *precheckShim) AllModels() ([]PrecheckModel, error) {
 	models, err := s.State.AllModels()
@@ -87,6 +117,41 @@ func (s *precheckShim) AllApplications() ([]PrecheckApplication, error) {
 	return out, nil
 }
 
+// AllApplicationOffers implements PrecheckBackend.
+func (s *precheckShim) AllApplicationOffers() ([]PrecheckApplicationOffer, error) {
+	offers, err := s.State.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckApplicationOffer, 0, len(offers))
+	for _, offer := range offers {
+		out = append(out, offer)
+	}
+	return out, nil
+}
+
+// AllRemoteApplications implements PrecheckBackend.
+func (s *precheckShim) AllRemoteApplications() ([]PrecheckRemoteApplication, error) {
+	apps, err := s.State.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckRemoteApplication, 0, len(apps))
+	for _, app := range apps {
+		out = append(out, app)
+	}
+	return out, nil
+}
+
+// Charm implements PrecheckBackend.
+func (s *precheckShim) Charm(curl *charm.URL) (PrecheckCharm, error) {
+	ch, err := s.State.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	model, err := s.State.ControllerModel()
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
@@ -68,6 +69,96 @@ func (*SourcePrecheckSuite) TestCharmUpgrades(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "unit spanner/1 is upgrading")
 }
 
+func (*SourcePrecheckSuite) TestCharmNotUploaded(c *gc.C) {
+	backend := newHappyBackend()
+	backend.pendingCharm = "cs:foo-1"
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 of application foo not uploaded")
+}
+
+func (*SourcePrecheckSuite) TestCharmNotFound(c *gc.C) {
+	backend := newHappyBackend()
+	backend.charmErr = errors.NotFoundf("charm")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 of application foo not found")
+}
+
+func (*SourcePrecheckSuite) TestApplicationOffers(c *gc.C) {
+	backend := newHappyBackend()
+	backend.offers = []migration.PrecheckApplicationOffer{
+		&fakeNamed{name: "hosted-mysql"},
+		&fakeNamed{name: "hosted-db2"},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has application offers \(hosted-mysql, hosted-db2\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestRemoteApplications(c *gc.C) {
+	backend := newHappyBackend()
+	backend.remoteApps = []migration.PrecheckRemoteApplication{
+		&fakeNamed{name: "mysql"},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has remote applications \(mysql\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestEncryptedVolumes(c *gc.C) {
+	backend := newHappyBackend()
+	backend.encryptedVolumes = []names.VolumeTag{
+		names.NewVolumeTag("0"),
+		names.NewVolumeTag("1/2"),
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has encrypted volumes \(0, 1/2\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestResources(c *gc.C) {
+	backend := newHappyBackend()
+	backend.resourceApps = []string{"mysql", "wordpress"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has applications with resources \(mysql, wordpress\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestCredentialRevoked(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.credential = "dummy/owner/secret"
+	backend.credential.Revoked = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "cloud credential dummy/owner/secret is revoked")
+}
+
+func (*SourcePrecheckSuite) TestCredentialNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.credential = "dummy/owner/secret"
+	backend.credentialErr = errors.NotFoundf("credential")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "cloud credential dummy/owner/secret not found")
+}
+
+func (*SourcePrecheckSuite) TestReportSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	c.Assert(migration.SourcePrecheckReport(backend), gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestReportBlockers(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:  "foo",
+			units: []migration.PrecheckUnit{&fakeUnit{name: "foo/0", agentStatus: status.Executing}},
+		},
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+	c.Assert(migration.SourcePrecheckReport(backend), jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"unit foo/0 not idle (executing)",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
 func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.migrationMode = state.MigrationModeImporting
@@ -282,6 +373,37 @@ func (s *SourcePrecheckSuite) TestProvisioningControllerMachine(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "controller: machine 0 not running (allocating)")
 }
 
+type ExportPrecheckSuite struct {
+	precheckBaseSuite
+}
+
+var _ = gc.Suite(&ExportPrecheckSuite{})
+
+func (*ExportPrecheckSuite) TestSuccess(c *gc.C) {
+	err := migration.ExportPrecheck(newHappyBackend())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*ExportPrecheckSuite) TestIgnoresAgents(c *gc.C) {
+	// An offline export does not need the model's agents.
+	err := migration.ExportPrecheck(newBackendWithDownMachineAgent())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*ExportPrecheckSuite) TestResources(c *gc.C) {
+	backend := newHappyBackend()
+	backend.resourceApps = []string{"mysql"}
+	err := migration.ExportPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has applications with resources \(mysql\), which cannot be migrated`)
+}
+
+func (*ExportPrecheckSuite) TestEncryptedVolumes(c *gc.C) {
+	backend := newHappyBackend()
+	backend.encryptedVolumes = []names.VolumeTag{names.NewVolumeTag("0")}
+	err := migration.ExportPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has encrypted volumes \(0\), which cannot be migrated`)
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -416,6 +538,33 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExists(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "model with same UUID already exists (model-uuid)")
 }
 
+func (s *TargetPrecheckSuite) TestReportSuccess(c *gc.C) {
+	blockers, err := migration.TargetPrecheckReport(newHappyBackend(), s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, gc.HasLen, 0)
+}
+
+func (s *TargetPrecheckSuite) TestReportBlockers(c *gc.C) {
+	backend := newBackendWithRebootingMachine()
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: modelUUID},
+	}
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+	blockers, err := migration.TargetPrecheckReport(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"machine 0 is scheduled to reboot",
+		"model with same UUID already exists (model-uuid)",
+	})
+}
+
+func (s *TargetPrecheckSuite) TestReportInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	_, err := migration.TargetPrecheckReport(newHappyBackend(), s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "empty UUID not valid")
+}
+
 func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	backend := newFakeBackend()
 	backend.models = []migration.PrecheckModel{
@@ -554,6 +703,18 @@ type fakeBackend struct {
 	apps       []migration.PrecheckApplication
 	allAppsErr error
 
+	offers     []migration.PrecheckApplicationOffer
+	remoteApps []migration.PrecheckRemoteApplication
+
+	encryptedVolumes []names.VolumeTag
+	resourceApps     []string
+
+	pendingCharm string
+	charmErr     error
+
+	credential    cloud.Credential
+	credentialErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -590,6 +751,33 @@ func (b *fakeBackend) AllApplications() ([]migration.PrecheckApplication, error)
 
 }
 
+func (b *fakeBackend) AllApplicationOffers() ([]migration.PrecheckApplicationOffer, error) {
+	return b.offers, nil
+}
+
+func (b *fakeBackend) AllRemoteApplications() ([]migration.PrecheckRemoteApplication, error) {
+	return b.remoteApps, nil
+}
+
+func (b *fakeBackend) VolumesWithEncryptionKeys() ([]names.VolumeTag, error) {
+	return b.encryptedVolumes, nil
+}
+
+func (b *fakeBackend) ApplicationsWithResources() ([]string, error) {
+	return b.resourceApps, nil
+}
+
+func (b *fakeBackend) Charm(curl *charm.URL) (migration.PrecheckCharm, error) {
+	if b.charmErr != nil {
+		return nil, b.charmErr
+	}
+	return &fakeCharm{uploaded: curl.String() != b.pendingCharm}, nil
+}
+
+func (b *fakeBackend) CloudCredential(names.CloudCredentialTag) (cloud.Credential, error) {
+	return b.credential, b.credentialErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -603,6 +791,7 @@ type fakeModel struct {
 	owner         names.UserTag
 	life          state.Life
 	migrationMode state.MigrationMode
+	credential    string
 }
 
 func (m *fakeModel) UUID() string {
@@ -625,6 +814,21 @@ func (m *fakeModel) MigrationMode() state.MigrationMode {
 	return m.migrationMode
 }
 
+func (m *fakeModel) CloudCredential() (names.CloudCredentialTag, bool) {
+	if m.credential == "" {
+		return names.CloudCredentialTag{}, false
+	}
+	return names.NewCloudCredentialTag(m.credential), true
+}
+
+type fakeCharm struct {
+	uploaded bool
+}
+
+func (ch *fakeCharm) IsUploaded() bool {
+	return ch.uploaded
+}
+
 type fakeMachine struct {
 	id             string
 	version        version.Binary
@@ -716,6 +920,14 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+type fakeNamed struct {
+	name string
+}
+
+func (n *fakeNamed) Name() string {
+	return n.name
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// EgressRule represents a range of ports, and the destination CIDR to
+// which outbound traffic on those ports is allowed.
+type EgressRule struct {
+	PortRange
+
+	// DestinationCIDR holds the CIDR to which outbound traffic on
+	// the port range is allowed.
+	DestinationCIDR string
+}
+
+// NewEgressRule returns an EgressRule allowing outbound traffic on the
+// given port range to the given destination CIDR.
+func NewEgressRule(portRange PortRange, destinationCIDR string) EgressRule {
+	return EgressRule{
+		PortRange:       portRange,
+		DestinationCIDR: destinationCIDR,
+	}
+}
+
+// ParseEgressRule builds an EgressRule from a string of the form
+// "<cidr>:<port range>", where the port range is parsed as by
+// ParsePortRange. Validate() gets called on the result before
+// returning.
+// Example strings: "10.0.0.0/8:443/tcp", "0.0.0.0/0:53/udp".
+func ParseEgressRule(inRule string) (EgressRule, error) {
+	// CIDRs may contain colons, but port ranges may not.
+	i := strings.LastIndex(inRule, ":")
+	if i < 0 {
+		return EgressRule{}, errors.Errorf("invalid egress rule %q, expected <cidr>:<port range>", inRule)
+	}
+	portRange, err := ParsePortRange(inRule[i+1:])
+	if err != nil {
+		return EgressRule{}, errors.Annotatef(err, "invalid egress rule %q", inRule)
+	}
+	rule := NewEgressRule(portRange, inRule[:i])
+	return rule, rule.Validate()
+}
+
+// Validate returns an error if the rule's port range or destination
+// CIDR is not valid.
+func (r EgressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, _, err := net.ParseCIDR(r.DestinationCIDR); err != nil {
+		return errors.Errorf("invalid destination CIDR %q", r.DestinationCIDR)
+	}
+	return nil
+}
+
+// String returns the rule in the form accepted by ParseEgressRule.
+func (r EgressRule) String() string {
+	return fmt.Sprintf("%s:%s", r.DestinationCIDR, r.PortRange)
+}
+
+func (r EgressRule) GoString() string {
+	return r.String()
+}
+
+type egressRuleSlice []EgressRule
+
+func (s egressRuleSlice) Len() int      { return len(s) }
+func (s egressRuleSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s egressRuleSlice) Less(i, j int) bool {
+	p1 := s[i].PortRange
+	p2 := s[j].PortRange
+	if p1.Protocol != p2.Protocol {
+		return p1.Protocol < p2.Protocol
+	}
+	if p1.FromPort != p2.FromPort {
+		return p1.FromPort < p2.FromPort
+	}
+	if p1.ToPort != p2.ToPort {
+		return p1.ToPort < p2.ToPort
+	}
+	return s[i].DestinationCIDR < s[j].DestinationCIDR
+}
+
+// SortEgressRules sorts the given rules, first by port range, then
+// by destination CIDR.
+func SortEgressRules(rules []EgressRule) {
+	sort.Sort(egressRuleSlice(rules))
+}
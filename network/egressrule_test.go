@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type EgressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&EgressRuleSuite{})
+
+func (*EgressRuleSuite) TestParseEgressRule(c *gc.C) {
+	for _, test := range []struct {
+		in     string
+		expect network.EgressRule
+	}{{
+		in:     "10.0.0.0/8:443/tcp",
+		expect: network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+	}, {
+		in:     "0.0.0.0/0:53/udp",
+		expect: network.NewEgressRule(network.MustParsePortRange("53/udp"), "0.0.0.0/0"),
+	}, {
+		in:     "192.168.1.0/24:8000-8080",
+		expect: network.NewEgressRule(network.MustParsePortRange("8000-8080/tcp"), "192.168.1.0/24"),
+	}, {
+		in:     "2001:db8::/32:443/tcp",
+		expect: network.NewEgressRule(network.MustParsePortRange("443/tcp"), "2001:db8::/32"),
+	}} {
+		c.Logf("parsing %q", test.in)
+		rule, err := network.ParseEgressRule(test.in)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, jc.DeepEquals, test.expect)
+	}
+}
+
+func (*EgressRuleSuite) TestParseEgressRuleErrors(c *gc.C) {
+	for _, test := range []struct {
+		in  string
+		err string
+	}{{
+		in:  "10.0.0.0/8",
+		err: `invalid egress rule "10.0.0.0/8", expected <cidr>:<port range>`,
+	}, {
+		in:  "10.0.0.0/8:http",
+		err: `invalid egress rule "10.0.0.0/8:http": invalid port "http": .*`,
+	}, {
+		in:  "10.0.0.0/8:80/icmp",
+		err: `invalid egress rule "10.0.0.0/8:80/icmp": invalid protocol "icmp", expected "tcp" or "udp"`,
+	}, {
+		in:  "10.0.0.1:443",
+		err: `invalid destination CIDR "10.0.0.1"`,
+	}} {
+		c.Logf("parsing %q", test.in)
+		_, err := network.ParseEgressRule(test.in)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*EgressRuleSuite) TestString(c *gc.C) {
+	rule := network.NewEgressRule(network.MustParsePortRange("8000-8080/udp"), "10.0.0.0/8")
+	c.Assert(rule.String(), gc.Equals, "10.0.0.0/8:8000-8080/udp")
+
+	parsed, err := network.ParseEgressRule(rule.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, rule)
+}
+
+func (*EgressRuleSuite) TestSortEgressRules(c *gc.C) {
+	rules := []network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "192.168.1.0/24"),
+		network.NewEgressRule(network.MustParsePortRange("53/udp"), "10.0.0.0/8"),
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+		network.NewEgressRule(network.MustParsePortRange("22/tcp"), "10.0.0.0/8"),
+	}
+	network.SortEgressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("22/tcp"), "10.0.0.0/8"),
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "192.168.1.0/24"),
+		network.NewEgressRule(network.MustParsePortRange("53/udp"), "10.0.0.0/8"),
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// DefaultSourceCIDR is the source CIDR of ingress rules that allow
+// traffic from anywhere.
+const DefaultSourceCIDR = "0.0.0.0/0"
+
+// IngressRule represents a range of ports, and the source CIDRs from
+// which traffic to those ports is allowed.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs holds the sorted CIDRs from which traffic to the
+	// port range is allowed.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule allowing traffic to the given
+// port range from the given source CIDRs, or from anywhere if none are
+// given.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{DefaultSourceCIDR}
+	}
+	return IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: set.NewStrings(sourceCIDRs...).SortedValues(),
+	}
+}
+
+// Validate returns an error if the rule's port range or any of its
+// source CIDRs is not valid.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(r.SourceCIDRs) == 0 {
+		return errors.Errorf("invalid ingress rule %v: no source CIDRs", r.PortRange)
+	}
+	for _, cidr := range r.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid source CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// AllowsAll returns whether the rule allows traffic from anywhere.
+func (r IngressRule) AllowsAll() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if cidr == DefaultSourceCIDR {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IngressRule) String() string {
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (s ingressRuleSlice) Len() int      { return len(s) }
+func (s ingressRuleSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ingressRuleSlice) Less(i, j int) bool {
+	p1 := s[i].PortRange
+	p2 := s[j].PortRange
+	if p1.Protocol != p2.Protocol {
+		return p1.Protocol < p2.Protocol
+	}
+	if p1.FromPort != p2.FromPort {
+		return p1.FromPort < p2.FromPort
+	}
+	if p1.ToPort != p2.ToPort {
+		return p1.ToPort < p2.ToPort
+	}
+	return strings.Join(s[i].SourceCIDRs, ",") < strings.Join(s[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by port range, then
+// by source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
+
+// IngressRulesFromPortRanges returns rules allowing traffic to each of
+// the given port ranges from anywhere.
+func IngressRulesFromPortRanges(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = NewIngressRule(portRange)
+	}
+	return rules
+}
+
+// PortRangesFromIngressRules returns the distinct port ranges of the
+// given rules, sorted by SortPortRanges.
+func PortRangesFromIngressRules(rules []IngressRule) []PortRange {
+	seen := make(map[PortRange]bool)
+	var portRanges []PortRange
+	for _, rule := range rules {
+		if seen[rule.PortRange] {
+			continue
+		}
+		seen[rule.PortRange] = true
+		portRanges = append(portRanges, rule.PortRange)
+	}
+	SortPortRanges(portRanges)
+	return portRanges
+}
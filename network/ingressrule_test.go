@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	rule := network.NewIngressRule(network.MustParsePortRange("80/tcp"))
+	c.Check(rule.SourceCIDRs, jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Check(rule.AllowsAll(), jc.IsTrue)
+	c.Check(rule.String(), gc.Equals, "80/tcp from 0.0.0.0/0")
+
+	rule = network.NewIngressRule(
+		network.MustParsePortRange("8000-8080/udp"),
+		"192.168.1.0/24", "10.0.0.0/8", "192.168.1.0/24",
+	)
+	c.Check(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Check(rule.AllowsAll(), jc.IsFalse)
+	c.Check(rule.String(), gc.Equals, "8000-8080/udp from 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*IngressRuleSuite) TestValidate(c *gc.C) {
+	rule := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8")
+	c.Check(rule.Validate(), jc.ErrorIsNil)
+
+	rule = network.NewIngressRule(network.PortRange{80, 80, "icmp"})
+	c.Check(rule.Validate(), gc.ErrorMatches, `invalid protocol "icmp", expected "tcp" or "udp"`)
+
+	rule = network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Check(rule.Validate(), gc.ErrorMatches, `invalid source CIDR "10.0.0.0"`)
+
+	rule = network.IngressRule{PortRange: network.MustParsePortRange("80/tcp")}
+	c.Check(rule.Validate(), gc.ErrorMatches, `invalid ingress rule 80/tcp: no source CIDRs`)
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("22/tcp")),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
+
+func (*IngressRuleSuite) TestPortRangeConversions(c *gc.C) {
+	portRanges := []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	}
+	rules := network.IngressRulesFromPortRanges(portRanges)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+
+	rules = append(rules, network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"))
+	c.Assert(network.PortRangesFromIngressRules(rules), jc.DeepEquals, []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	})
+}
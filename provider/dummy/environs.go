@@ -37,6 +37,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
@@ -194,6 +195,7 @@ type OpOpenPorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpClosePorts struct {
@@ -201,6 +203,7 @@ type OpClosePorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpPutFile struct {
@@ -237,7 +240,8 @@ type environState struct {
 	maxId          int // maximum instance id allocated so far.
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
-	globalPorts    map[network.PortRange]bool
+	globalRules    ingressRules
+	egressRules    map[string][]network.EgressRule
 	bootstrapped   bool
 	apiListener    net.Listener
 	apiServer      *apiserver.Server
@@ -414,7 +418,8 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		ops:            ops,
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
-		globalPorts:    make(map[network.PortRange]bool),
+		globalRules:    make(ingressRules),
+		egressRules:    make(map[string][]network.EgressRule),
 		creator:        string(buf),
 	}
 	return s
@@ -716,7 +721,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	i := &dummyInstance{
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(ingressRules),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -979,7 +984,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	i := &dummyInstance{
 		id:           instance.Id(idString),
 		addresses:    addrs,
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(ingressRules),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1371,6 +1376,24 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.PortRangesFromIngressRules(rules), nil
+}
+
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
@@ -1380,13 +1403,13 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		estate.globalPorts[p] = true
-	}
+	estate.globalRules.open(rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
@@ -1396,13 +1419,13 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		delete(estate.globalPorts, p)
-	}
+	estate.globalRules.close(rules)
 	return nil
 }
 
-func (e *environ) Ports() (ports []network.PortRange, err error) {
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
 	}
@@ -1412,11 +1435,65 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for p := range estate.globalPorts {
-		ports = append(ports, p)
+	return estate.globalRules.list(), nil
+}
+
+// SupportsEgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *environ) SupportsEgressRules() (bool, error) {
+	if err := e.checkBroken("SupportsEgressRules"); err != nil {
+		return false, err
 	}
-	network.SortPortRanges(ports)
-	return
+	return true, nil
+}
+
+// SetEgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *environ) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	if err := e.checkBroken("SetEgressRules"); err != nil {
+		return err
+	}
+	if mode := e.ecfg().FirewallMode(); mode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for setting egress rules on machine", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	if len(rules) == 0 {
+		delete(estate.egressRules, machineId)
+		return nil
+	}
+	sorted := make([]network.EgressRule, len(rules))
+	copy(sorted, rules)
+	network.SortEgressRules(sorted)
+	estate.egressRules[machineId] = sorted
+	return nil
+}
+
+// EgressRules is specified in the environs.EgressFirewaller interface.
+func (e *environ) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if err := e.checkBroken("EgressRules"); err != nil {
+		return nil, err
+	}
+	if mode := e.ecfg().FirewallMode(); mode != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from machine", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	rules := estate.egressRules[machineId]
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	result := make([]network.EgressRule, len(rules))
+	copy(result, rules)
+	return result, nil
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1425,7 +1502,7 @@ func (*environ) Provider() environs.EnvironProvider {
 
 type dummyInstance struct {
 	state        *environState
-	ports        map[network.PortRange]bool
+	rules        ingressRules
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1508,77 +1585,135 @@ func (inst *dummyInstance) Addresses() ([]network.Address, error) {
 }
 
 func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.OpenIngressRules(machineId, network.IngressRulesFromPortRanges(ports))
+}
+
+func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.CloseIngressRules(machineId, network.IngressRulesFromPortRanges(ports))
+}
+
+func (inst *dummyInstance) Ports(machineId string) ([]network.PortRange, error) {
+	rules, err := inst.IngressRules(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.PortRangesFromIngressRules(rules), nil
+}
+
+// OpenIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
 	defer delay()
-	logger.Infof("openPorts %s, %#v", machineId, ports)
+	logger.Infof("openIngressRules %s, %#v", machineId, rules)
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("OpenPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("OpenIngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("OpenPorts"); err != nil {
+	if err := inst.checkBroken("OpenIngressRules"); err != nil {
 		return err
 	}
 	inst.state.ops <- OpOpenPorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
-	}
-	for _, p := range ports {
-		inst.ports[p] = true
+		Ports:      network.PortRangesFromIngressRules(rules),
+		Rules:      rules,
 	}
+	inst.rules.open(rules)
 	return nil
 }
 
-func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+// CloseIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("ClosePorts with mismatched machine id, expected %s got %s", inst.machineId, machineId))
+		panic(fmt.Errorf("CloseIngressRules with mismatched machine id, expected %s got %s", inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("ClosePorts"); err != nil {
+	if err := inst.checkBroken("CloseIngressRules"); err != nil {
 		return err
 	}
 	inst.state.ops <- OpClosePorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
-	}
-	for _, p := range ports {
-		delete(inst.ports, p)
+		Ports:      network.PortRangesFromIngressRules(rules),
+		Rules:      rules,
 	}
+	inst.rules.close(rules)
 	return nil
 }
 
-func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, err error) {
+// IngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("Ports with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("IngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("Ports"); err != nil {
+	if err := inst.checkBroken("IngressRules"); err != nil {
 		return nil, err
 	}
-	for p := range inst.ports {
-		ports = append(ports, p)
+	return inst.rules.list(), nil
+}
+
+// ingressRules holds the source CIDRs allowed to access each open port
+// range. As with real providers, the rules opened for a port range are
+// merged into one.
+type ingressRules map[network.PortRange]set.Strings
+
+func (r ingressRules) open(rules []network.IngressRule) {
+	for _, rule := range rules {
+		cidrs, ok := r[rule.PortRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			r[rule.PortRange] = cidrs
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			cidrs.Add(cidr)
+		}
 	}
-	network.SortPortRanges(ports)
-	return
+}
+
+func (r ingressRules) close(rules []network.IngressRule) {
+	for _, rule := range rules {
+		cidrs, ok := r[rule.PortRange]
+		if !ok {
+			continue
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			cidrs.Remove(cidr)
+		}
+		if cidrs.IsEmpty() {
+			delete(r, rule.PortRange)
+		}
+	}
+}
+
+func (r ingressRules) list() []network.IngressRule {
+	var rules []network.IngressRule
+	for portRange, cidrs := range r {
+		rules = append(rules, network.NewIngressRule(portRange, cidrs.Values()...))
+	}
+	network.SortIngressRules(rules)
+	return rules
 }
 
 // providerDelay controls the delay before dummy responds.
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/tags"
@@ -838,6 +840,177 @@ func detachVolumes(client *ec2.EC2, attachParams []storage.VolumeAttachmentParam
 
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
+var _ storage.VolumeSnapshotRestorer = (*ebsVolumeSource)(nil)
+
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, "juju snapshot of "+p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Tag the snapshot with the model UUID, so that it is clear which
+	// model it belongs to; snapshots outlive the volumes they are
+	// taken of, so they are not destroyed with the model. Restoring
+	// a snapshot replaces the EBS volume, so the snapshot is also
+	// tagged with the Juju volume it was taken of.
+	if err := tagResources(v.env.ec2, map[string]string{
+		tags.JujuModel:         v.modelUUID,
+		tags.JujuStorageVolume: p.Tag.String(),
+	}, resp.Id); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return ebsVolumeSnapshot(resp.Snapshot)
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListVolumeSnapshots(p storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	filter := ec2.NewFilter()
+	filter.Add("tag:"+tags.JujuModel, v.modelUUID)
+	filter.Add("tag:"+tags.JujuStorageVolume, p.Tag.String())
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying snapshots")
+	}
+	snapshots := make([]storage.VolumeSnapshot, len(resp.Snapshots))
+	for i, snap := range resp.Snapshots {
+		snapshot, err := ebsVolumeSnapshot(snap)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots[i] = *snapshot
+	}
+	return snapshots, nil
+}
+
+func ebsVolumeSnapshot(snap ec2.Snapshot) (*storage.VolumeSnapshot, error) {
+	sizeInGib, err := strconv.ParseUint(snap.VolumeSize, 10, 64)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing size of snapshot %q", snap.Id)
+	}
+	created, err := time.Parse(time.RFC3339, snap.StartTime)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing start time of snapshot %q", snap.Id)
+	}
+	return &storage.VolumeSnapshot{
+		SnapshotId: snap.Id,
+		VolumeId:   snap.VolumeId,
+		Size:       gibToMib(sizeInGib),
+		Created:    created,
+	}, nil
+}
+
+// RestoreVolumeSnapshots is specified on the storage.VolumeSnapshotRestorer interface.
+//
+// EBS snapshots cannot be restored in place, so a new volume is created
+// from each snapshot in the availability zone of the original volume,
+// and the original volume is then destroyed.
+func (v *ebsVolumeSource) RestoreVolumeSnapshots(params []storage.RestoreVolumeSnapshotParams) ([]storage.RestoreVolumeSnapshotsResult, error) {
+	results := make([]storage.RestoreVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		info, err := v.restoreVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "restoring %q from %q", p.VolumeId, p.SnapshotId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) restoreVolumeSnapshot(p storage.RestoreVolumeSnapshotParams) (_ *storage.VolumeInfo, err error) {
+	volume, err := describeVolume(v.env.ec2, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(volume.Attachments) > 0 {
+		return nil, errors.Errorf("volume is attached to instance %q", volume.Attachments[0].InstanceId)
+	}
+	resp, err := v.env.ec2.Snapshots([]string{p.SnapshotId}, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying snapshot")
+	}
+	if len(resp.Snapshots) != 1 {
+		return nil, errors.NotFoundf("snapshot %q", p.SnapshotId)
+	}
+	if !snapshotOfVolume(resp.Snapshots[0], p.Tag, v.modelUUID) {
+		return nil, errors.Errorf("snapshot %q was not taken of %s", p.SnapshotId, names.ReadableString(p.Tag))
+	}
+
+	vol := ec2.CreateVolume{
+		SnapshotId: p.SnapshotId,
+		AvailZone:  volume.AvailZone,
+		VolumeType: volume.VolumeType,
+	}
+	if volume.VolumeType == volumeTypeIO1 {
+		// IOPS may only be specified for provisioned IOPS volumes.
+		vol.IOPS = volume.IOPS
+	}
+	createResp, err := v.env.ec2.CreateVolume(vol)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeId := createResp.Id
+	defer func() {
+		if err == nil {
+			return
+		}
+		if _, err := v.env.ec2.DeleteVolume(volumeId); err != nil {
+			logger.Errorf("error cleaning up volume %v: %v", volumeId, err)
+		}
+	}()
+
+	// The restored volume replaces the original, so give it the
+	// same tags.
+	resourceTags := make(map[string]string)
+	for _, tag := range volume.Tags {
+		resourceTags[tag.Key] = tag.Value
+	}
+	if err := tagResources(v.env.ec2, resourceTags, volumeId); err != nil {
+		return nil, errors.Annotate(err, "tagging volume")
+	}
+
+	// The original volume is no longer referred to by Juju once the
+	// restored volume is recorded, so destroy it now rather than
+	// leaking it. If it cannot be destroyed, the restored volume is
+	// cleaned up instead so the restore can be retried.
+	if _, err := v.env.ec2.DeleteVolume(p.VolumeId); err != nil {
+		return nil, errors.Annotatef(err, "destroying original volume %q", p.VolumeId)
+	}
+	return &storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(createResp.Size)),
+		Persistent: true,
+	}, nil
+}
+
+// snapshotOfVolume reports whether the snapshot was taken by Juju of
+// the volume with the specified tag in the specified model.
+func snapshotOfVolume(snap ec2.Snapshot, tag names.VolumeTag, modelUUID string) bool {
+	var modelMatches, volumeMatches bool
+	for _, t := range snap.Tags {
+		switch t.Key {
+		case tags.JujuModel:
+			modelMatches = t.Value == modelUUID
+		case tags.JujuStorageVolume:
+			volumeMatches = t.Value == tag.String()
+		}
+	}
+	return modelMatches && volumeMatches
+}
+
 // blockDeviceNamer returns a function that cycles through block device names.
 //
 // The returned function returns the device name that should be used in
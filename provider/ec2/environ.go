@@ -55,6 +55,8 @@ var (
 	aliveInstanceStates = []string{"pending", "running"}
 )
 
+var _ environs.EgressFirewaller = (*environ)(nil)
+
 type environ struct {
 	name  string
 	cloud environs.CloudSpec
@@ -1111,37 +1113,40 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 	return listVolumes(e.ec2, filter)
 }
 
-func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.Protocol,
+			FromPort:  r.FromPort,
+			ToPort:    r.ToPort,
+			SourceIPs: r.SourceCIDRs,
+		}
+		if len(ipPerms[i].SourceIPs) == 0 {
+			ipPerms[i].SourceIPs = []string{network.DefaultSourceCIDR}
 		}
 	}
 	return ipPerms
 }
 
-func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) openIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the rules' sources to access the given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2.AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(rules) == 1 {
 			return nil
 		}
-		// If there's more than one port and we get a duplicate error,
-		// then we go through authorizing each port individually,
-		// otherwise the ports that were *not* duplicates will have
+		// If there's more than one rule and we get a duplicate error,
+		// then we go through authorizing each rule individually,
+		// otherwise the rules that were *not* duplicates will have
 		// been ignored
 		for i := range ipPerms {
 			_, err := e.ec2.AuthorizeSecurityGroup(g, ipPerms[i:i+1])
@@ -1157,71 +1162,236 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 	return nil
 }
 
-func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) closeIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
+	// Revoke permissions for the rules' sources to access the given ports.
 	// Note that ec2 allows the revocation of permissions that aren't
 	// granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2.RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2.RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
-func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
+func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
-			logger.Errorf("expected exactly one IP permission, found: %v", p)
+		if len(p.SourceIPs) == 0 {
+			logger.Errorf("expected at least one IP permission, found: %v", p)
 			continue
 		}
-		ports = append(ports, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
 			ToPort:   p.ToPort,
-		})
+		}
+		rules = append(rules, network.NewIngressRule(portRange, p.SourceIPs...))
 	}
-	network.SortPortRanges(ports)
-	return ports, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.PortRangesFromIngressRules(rules), nil
+}
+
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
 	}
-	if err := e.openPortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.openIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ingress rules in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
 	}
-	if err := e.closePortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.closeIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ingress rules in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) Ports() ([]network.PortRange, error) {
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
 	}
-	return e.portsInGroup(e.globalGroupName())
+	return e.ingressRulesInGroup(e.globalGroupName())
+}
+
+// allowAllEgress is the egress permission that security groups in a VPC
+// are created with, allowing all outbound traffic.
+var allowAllEgress = ec2.IPPerm{
+	Protocol:  "-1",
+	SourceIPs: []string{"0.0.0.0/0"},
+}
+
+func rulesToEgressIPPerms(rules []network.EgressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
+		ipPerms[i] = ec2.IPPerm{
+			Protocol:  r.Protocol,
+			FromPort:  r.FromPort,
+			ToPort:    r.ToPort,
+			SourceIPs: []string{r.DestinationCIDR},
+		}
+	}
+	return ipPerms
+}
+
+// checkEgressSupported returns an error if the egress rules of machines
+// cannot be set. Only security groups in a VPC filter outbound traffic.
+func (e *environ) checkEgressSupported() error {
+	if e.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for egress rules", e.Config().FirewallMode())
+	}
+	if isVPCIDSet(e.ecfg().vpcID()) {
+		return nil
+	}
+	hasDefaultVPC, err := e.hasDefaultVPC()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !hasDefaultVPC {
+		return errors.NotSupportedf("egress rules outside a VPC")
+	}
+	return nil
+}
+
+// restrictJujuGroupEgress replaces the allow-all egress permission of
+// the model's juju group, which every machine is in, with permissions
+// allowing traffic to the group's members only. The outbound traffic
+// of unrestricted machines remains allowed by their machine groups.
+func (e *environ) restrictJujuGroupEgress() error {
+	info, err := e.groupInfoByName(e.jujuGroupName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	g := info.SecurityGroup
+	have := newPermSetForGroup(info.IPPermsEgress, g)
+	want := newPermSetForGroup([]ec2.IPPerm{
+		{Protocol: "tcp", FromPort: 0, ToPort: 65535},
+		{Protocol: "udp", FromPort: 0, ToPort: 65535},
+		{Protocol: "icmp", FromPort: -1, ToPort: -1},
+	}, g)
+	return errors.Trace(e.changeGroupEgress(g, have, want))
+}
+
+// changeGroupEgress revokes the egress permissions of the group that
+// are in have but not in want, and authorizes those in want but not
+// in have.
+func (e *environ) changeGroupEgress(g ec2.SecurityGroup, have, want permSet) error {
+	if revoke := have.difference(want); len(revoke) > 0 {
+		if _, err := e.ec2.RevokeSecurityGroupEgress(g, revoke.ipPerms()); err != nil {
+			return errors.Annotatef(err, "revoking egress of security group %q", g.Id)
+		}
+	}
+	if add := want.difference(have); len(add) > 0 {
+		if _, err := e.ec2.AuthorizeSecurityGroupEgress(g, add.ipPerms()); err != nil {
+			return errors.Annotatef(err, "authorizing egress of security group %q", g.Id)
+		}
+	}
+	return nil
+}
+
+// SupportsEgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *environ) SupportsEgressRules() (bool, error) {
+	err := e.checkEgressSupported()
+	if errors.IsNotSupported(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// SetEgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *environ) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	if err := e.checkEgressSupported(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.restrictJujuGroupEgress(); err != nil {
+		return errors.Trace(err)
+	}
+	info, err := e.groupInfoByName(e.machineGroupName(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	perms := []ec2.IPPerm{allowAllEgress}
+	if len(rules) > 0 {
+		perms = rulesToEgressIPPerms(rules)
+	}
+	have := newPermSetForGroup(info.IPPermsEgress, info.SecurityGroup)
+	want := newPermSetForGroup(perms, info.SecurityGroup)
+	if err := e.changeGroupEgress(info.SecurityGroup, have, want); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("set egress rules for machine %q: %v", machineId, rules)
+	return nil
+}
+
+// EgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *environ) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if err := e.checkEgressSupported(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := e.groupInfoByName(e.machineGroupName(machineId))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.EgressRule
+	for _, p := range info.IPPermsEgress {
+		if p.Protocol == allowAllEgress.Protocol {
+			// All outbound traffic is allowed.
+			return nil, nil
+		}
+		portRange := network.PortRange{
+			Protocol: p.Protocol,
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+		}
+		for _, ip := range p.SourceIPs {
+			rules = append(rules, network.NewEgressRule(portRange, ip))
+		}
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1633,6 +1803,17 @@ func newPermSetForGroup(ps []ec2.IPPerm, group ec2.SecurityGroup) permSet {
 	return m
 }
 
+// difference returns the permissions in m that are not in other.
+func (m permSet) difference(other permSet) permSet {
+	result := make(permSet)
+	for p := range m {
+		if !other[p] {
+			result[p] = true
+		}
+	}
+	return result
+}
+
 // ipPerms returns m as a slice of permissions usable
 // with the ec2 package.
 func (m permSet) ipPerms() (ps []ec2.IPPerm) {
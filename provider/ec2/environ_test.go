@@ -106,11 +106,12 @@ func pInt(i uint64) *uint64 {
 	return &i
 }
 
-func (*Suite) TestPortsToIPPerms(c *gc.C) {
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
 	testCases := []struct {
-		about    string
-		ports    []network.PortRange
-		expected []amzec2.IPPerm
+		about       string
+		ports       []network.PortRange
+		sourceCIDRs []string
+		expected    []amzec2.IPPerm
 	}{{
 		about: "single port",
 		ports: []network.PortRange{{
@@ -159,11 +160,47 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 			ToPort:    120,
 			SourceIPs: []string{"0.0.0.0/0"},
 		}},
+	}, {
+		about: "source CIDRs",
+		ports: []network.PortRange{{
+			FromPort: 80,
+			ToPort:   80,
+			Protocol: "tcp",
+		}},
+		sourceCIDRs: []string{"192.168.1.0/24", "10.0.0.0/8"},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "tcp",
+			FromPort:  80,
+			ToPort:    80,
+			SourceIPs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+		}},
 	}}
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		ipperms := portsToIPPerms(t.ports)
+		rules := make([]network.IngressRule, len(t.ports))
+		for j, portRange := range t.ports {
+			rules[j] = network.NewIngressRule(portRange, t.sourceCIDRs...)
+		}
+		ipperms := rulesToIPPerms(rules)
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestRulesToEgressIPPerms(c *gc.C) {
+	rules := []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}, "192.168.1.0/24"),
+	}
+	c.Assert(rulesToEgressIPPerms(rules), gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  443,
+		ToPort:    443,
+		SourceIPs: []string{"10.0.0.0/8"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  53,
+		ToPort:    53,
+		SourceIPs: []string{"192.168.1.0/24"},
+	}})
+}
@@ -33,6 +33,10 @@ type gceConnection interface {
 	OpenPorts(fwname string, ports ...network.PortRange) error
 	ClosePorts(fwname string, ports ...network.PortRange) error
 
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenIngressRules(fwname string, rules ...network.IngressRule) error
+	CloseIngressRules(fwname string, rules ...network.IngressRule) error
+
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
 	// Storage related methods.
@@ -210,13 +214,15 @@ func (env *environ) BootstrapMessage() string {
 // Destroy shuts down all known machines and destroys the rest of the
 // known environment.
 func (env *environ) Destroy() error {
-	ports, err := env.Ports()
+	// Closing the ingress rules also removes the firewalls
+	// restricted to particular sources.
+	rules, err := env.IngressRules()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	if len(ports) > 0 {
-		if err := env.ClosePorts(ports); err != nil {
+	if len(rules) > 0 {
+		if err := env.CloseIngressRules(rules); err != nil {
 			return errors.Trace(err)
 		}
 	}
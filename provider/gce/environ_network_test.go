@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 )
 
@@ -75,3 +76,28 @@ func (s *environNetSuite) TestPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
 }
+
+func (s *environNetSuite) TestOpenIngressRulesAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+	}
+	err := s.Env.OpenIngressRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenIngressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, rules)
+}
+
+func (s *environNetSuite) TestIngressRules(c *gc.C) {
+	s.FakeConn.Rules = []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+	}
+
+	rules, err := s.Env.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, s.FakeConn.Rules)
+}
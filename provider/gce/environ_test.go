@@ -139,7 +139,7 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "IngressRules")
 	fwname := common.EnvFullName(s.Env.Config().UUID())
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
 	s.FakeCommon.CheckCalls(c, []gce.FakeCall{{
@@ -149,3 +149,20 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 		},
 	}})
 }
+
+func (s *environSuite) TestDestroyClosesIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}),
+		network.NewIngressRule(network.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"}, "10.0.0.0/8"),
+	}
+	s.FakeConn.Rules = rules
+
+	err := s.Env.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	fwname := common.EnvFullName(s.Env.Config().UUID())
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "CloseIngressRules")
+	c.Check(s.FakeConn.Calls[1].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[1].Rules, jc.DeepEquals, rules)
+}
@@ -35,6 +35,9 @@ type rawConnectionWrapper interface {
 	// the named firewall and returns it. If the firewall is not found,
 	// errors.NotFound is returned.
 	GetFirewall(projectID, name string) (*compute.Firewall, error)
+	// ListFirewalls sends an API request to GCE for the information
+	// about the firewalls whose names start with the given prefix.
+	ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error)
 	// AddFirewall requests GCE to add a firewall with the provided info.
 	// If the firewall already exists then an error will be returned.
 	// The call blocks until the firewall is added or the request fails.
@@ -144,6 +144,17 @@ func (s *connSuite) TestConnectionAddInstanceGetFailed(c *gc.C) {
 	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 }
 
+func (s *connSuite) TestConnectionRemoveInstanceListFirewallsFailed(c *gc.C) {
+	failure := errors.New("<unknown>")
+	s.FakeConn.Err = failure
+	s.FakeConn.FailOnCall = 2
+
+	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+}
+
 func (s *connSuite) TestConnectionInstance(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
@@ -209,7 +220,7 @@ func (s *connSuite) TestConnectionRemoveInstanceAPI(c *gc.C) {
 	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
@@ -217,6 +228,25 @@ func (s *connSuite) TestConnectionRemoveInstanceAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
 	c.Check(s.FakeConn.Calls[1].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[2].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].Prefix, gc.Equals, "spam-src-")
+}
+
+func (s *connSuite) TestConnectionRemoveInstanceSourceFirewalls(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{
+		{Name: "spam-src-0123456789"},
+		{Name: "spam-src-abcdef0123"},
+	}
+	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 5)
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam-src-0123456789")
+	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[4].Name, gc.Equals, "spam-src-abcdef0123")
 }
 
 func (s *connSuite) TestConnectionRemoveInstanceFailed(c *gc.C) {
@@ -253,12 +283,14 @@ func (s *connSuite) TestConnectionRemoveInstancesAPI(c *gc.C) {
 	err := s.Conn.RemoveInstances("sp", "spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
 	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[3].Prefix, gc.Equals, "spam-src-")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesMultiple(c *gc.C) {
@@ -273,16 +305,20 @@ func (s *connSuite) TestConnectionRemoveInstancesMultiple(c *gc.C) {
 	err := s.Conn.RemoveInstances("", "spam", "special")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 5)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 7)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
 	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveInstance")
-	c.Check(s.FakeConn.Calls[3].ID, gc.Equals, "special")
-	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[4].Name, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[3].Prefix, gc.Equals, "spam-src-")
+	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveInstance")
+	c.Check(s.FakeConn.Calls[4].ID, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[5].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[5].Name, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[6].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[6].Prefix, gc.Equals, "special-src-")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesPartialMatch(c *gc.C) {
@@ -297,7 +333,7 @@ func (s *connSuite) TestConnectionRemoveInstancesPartialMatch(c *gc.C) {
 	err := s.Conn.RemoveInstances("", "spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
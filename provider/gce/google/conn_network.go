@@ -4,11 +4,21 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
 
+// sourceFirewallInfix separates the name of the instances targeted by a
+// source-restricted firewall from the hash of its source CIDRs.
+const sourceFirewallInfix = "-src-"
+
 // Ports build a list of all open port ranges for a given firewall name
 // (within the Connection's project) and returns it. If the firewall
 // does not exist then the list will be empty and no error is returned.
@@ -21,6 +31,11 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 		return nil, errors.Annotate(err, "while getting ports from GCE")
 	}
 
+	return firewallPorts(firewall)
+}
+
+// firewallPorts returns the port ranges opened by the given firewall.
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
 	var ports []network.PortRange
 	for _, allowed := range firewall.Allowed {
 		for _, portRangeStr := range allowed.Ports {
@@ -111,3 +126,174 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 	return nil
 }
+
+// sourceFirewallName returns the name of the firewall that allows
+// traffic from the given sorted source CIDRs to instances tagged with
+// fwname. Traffic from anywhere is allowed by the firewall named fwname
+// itself, so that it is shared with OpenPorts and ClosePorts.
+func sourceFirewallName(fwname string, sourceCIDRs []string) string {
+	if len(sourceCIDRs) == 1 && sourceCIDRs[0] == network.DefaultSourceCIDR {
+		return fwname
+	}
+	hash := sha256.Sum256([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s%s%x", fwname, sourceFirewallInfix, hash[:5])
+}
+
+// sourcePorts holds the port ranges opened to a set of source CIDRs.
+type sourcePorts struct {
+	sourceCIDRs []string
+	ports       []network.PortRange
+}
+
+// groupRulesBySources returns the port ranges of the given ingress
+// rules, grouped by the rules' source CIDRs.
+func groupRulesBySources(rules []network.IngressRule) []*sourcePorts {
+	var groups []*sourcePorts
+	byKey := make(map[string]*sourcePorts)
+	for _, rule := range rules {
+		sourceCIDRs := rule.SourceCIDRs
+		if len(sourceCIDRs) == 0 {
+			sourceCIDRs = []string{network.DefaultSourceCIDR}
+		}
+		sourceCIDRs = append([]string(nil), sourceCIDRs...)
+		sort.Strings(sourceCIDRs)
+		key := strings.Join(sourceCIDRs, ",")
+		group, ok := byKey[key]
+		if !ok {
+			group = &sourcePorts{sourceCIDRs: sourceCIDRs}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.ports = append(group.ports, rule.PortRange)
+	}
+	return groups
+}
+
+// IngressRules returns the ingress rules of the named firewall and of
+// the source-restricted firewalls targeting the same instances (within
+// the Connection's project).
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	ports, err := gce.Ports(fwname)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := network.IngressRulesFromPortRanges(ports)
+
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, fwname+sourceFirewallInfix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+	for _, firewall := range firewalls {
+		ports, err := firewallPorts(firewall)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, portRange := range ports {
+			rules = append(rules, network.NewIngressRule(portRange, firewall.SourceRanges...))
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// removeSourceFirewalls sends requests to the GCE API to remove the
+// source-restricted firewalls targeting the instances tagged with
+// fwname (within the Connection's project).
+func (gce Connection) removeSourceFirewalls(fwname string) error {
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, fwname+sourceFirewallInfix)
+	if err != nil {
+		return errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+	for _, firewall := range firewalls {
+		err := gce.raw.RemoveFirewall(gce.projectID, firewall.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing firewall %q", firewall.Name)
+		}
+	}
+	return nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules for the instances tagged with fwname. Rules allowing
+// traffic from anywhere are opened on the named firewall, as by
+// OpenPorts; other rules are opened on a firewall for their source
+// CIDRs, which is created if it does not exist yet.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	for _, group := range groupRulesBySources(rules) {
+		name := sourceFirewallName(fwname, group.sourceCIDRs)
+		if name == fwname {
+			if err := gce.OpenPorts(fwname, group.ports...); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+
+		inputPortsSet := network.NewPortSet(group.ports...)
+		firewall, err := gce.raw.GetFirewall(gce.projectID, name)
+		if errors.IsNotFound(err) {
+			// Create a new firewall.
+			firewall := sourceFirewallSpec(name, fwname, group.sourceCIDRs, inputPortsSet)
+			if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
+				return errors.Annotatef(err, "opening port(s) %+v from %v", group.ports, group.sourceCIDRs)
+			}
+			continue
+		}
+		if err != nil {
+			return errors.Annotate(err, "while getting ingress rules from GCE")
+		}
+
+		// Update an existing firewall.
+		currentPorts, err := firewallPorts(firewall)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newPortsSet := network.NewPortSet(currentPorts...).Union(inputPortsSet)
+		firewall = sourceFirewallSpec(name, fwname, group.sourceCIDRs, newPortsSet)
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
+			return errors.Annotatef(err, "opening port(s) %+v from %v", group.ports, group.sourceCIDRs)
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules sends requests to the GCE API to close the provided
+// ingress rules for the instances tagged with fwname. Source-restricted
+// firewalls left with no ports are removed.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	for _, group := range groupRulesBySources(rules) {
+		name := sourceFirewallName(fwname, group.sourceCIDRs)
+		if name == fwname {
+			if err := gce.ClosePorts(fwname, group.ports...); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+
+		firewall, err := gce.raw.GetFirewall(gce.projectID, name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Annotate(err, "while getting ingress rules from GCE")
+		}
+		currentPorts, err := firewallPorts(firewall)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newPortsSet := network.NewPortSet(currentPorts...).Difference(network.NewPortSet(group.ports...))
+		if newPortsSet.IsEmpty() {
+			// Delete the firewall.
+			if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
+				return errors.Annotatef(err, "closing port(s) %+v from %v", group.ports, group.sourceCIDRs)
+			}
+			continue
+		}
+
+		// Update the existing firewall.
+		firewall = sourceFirewallSpec(name, fwname, group.sourceCIDRs, newPortsSet)
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
+			return errors.Annotatef(err, "closing port(s) %+v from %v", group.ports, group.sourceCIDRs)
+		}
+	}
+	return nil
+}
@@ -173,3 +173,79 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewall = &compute.Firewall{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam-src-0123456789",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8", "192.168.1.0/24"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.NewIngressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8", "192.168.1.0/24"),
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[1].Prefix, gc.Equals, "spam-src-")
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesAdd(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	rule := network.NewIngressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8")
+	err := s.Conn.OpenIngressRules("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Matches, "spam-src-[0-9a-f]{10}")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         s.FakeConn.Calls[0].Name,
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionCloseIngressRulesRemove(c *gc.C) {
+	s.FakeConn.Firewall = &compute.Firewall{
+		Name:         "spam-src-0123456789",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}
+
+	rule := network.NewIngressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8")
+	err := s.Conn.CloseIngressRules("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, s.FakeConn.Calls[0].Name)
+}
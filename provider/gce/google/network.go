@@ -78,6 +78,16 @@ func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
 	return &firewall
 }
 
+// sourceFirewallSpec returns a compute.Firewall for the provided name
+// that allows traffic from the given source CIDRs to the port range set
+// on instances tagged with target.
+func sourceFirewallSpec(name, target string, sourceCIDRs []string, ps network.PortSet) *compute.Firewall {
+	firewall := firewallSpec(name, ps)
+	firewall.TargetTags = []string{target}
+	firewall.SourceRanges = sourceCIDRs
+	return firewall
+}
+
 func extractAddresses(interfaces ...*compute.NetworkInterface) []network.Address {
 	var addresses []network.Address
 
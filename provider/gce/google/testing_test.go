@@ -167,6 +167,7 @@ type fakeConn struct {
 	Instance      *compute.Instance
 	Instances     []*compute.Instance
 	Firewall      *compute.Firewall
+	Firewalls     []*compute.Firewall
 	Zones         []*compute.Zone
 	Err           error
 	FailOnCall    int
@@ -269,6 +270,21 @@ func (rc *fakeConn) GetFirewall(projectID, name string) (*compute.Firewall, erro
 	return rc.Firewall, err
 }
 
+func (rc *fakeConn) ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error) {
+	call := fakeCall{
+		FuncName:  "ListFirewalls",
+		ProjectID: projectID,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Firewalls, err
+}
+
 func (rc *fakeConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := fakeCall{
 		FuncName:  "AddFirewall",
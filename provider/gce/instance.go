@@ -101,3 +101,36 @@ func (inst *environInstance) Ports(machineID string) ([]network.PortRange, error
 	ports, err := inst.env.gce.Ports(name)
 	return ports, errors.Trace(err)
 }
+
+// OpenIngressRules opens the given ingress rules on the instance,
+// which should have been started with the given machine id.
+func (inst *environInstance) OpenIngressRules(machineID string, rules []network.IngressRule) error {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = inst.env.gce.OpenIngressRules(name, rules...)
+	return errors.Trace(err)
+}
+
+// CloseIngressRules closes the given ingress rules on the instance,
+// which should have been started with the given machine id.
+func (inst *environInstance) CloseIngressRules(machineID string, rules []network.IngressRule) error {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = inst.env.gce.CloseIngressRules(name, rules...)
+	return errors.Trace(err)
+}
+
+// IngressRules returns the ingress rules opened on the instance, which
+// should have been started with the given machine id.
+func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules, err := inst.env.gce.IngressRules(name)
+	return rules, errors.Trace(err)
+}
@@ -52,3 +52,24 @@ func (env *environ) Ports() ([]network.PortRange, error) {
 	}
 	return ports, errors.Trace(err)
 }
+
+// OpenIngressRules opens the given ingress rules for the whole
+// environment. The LXD provider does not firewall its instances yet,
+// so this does nothing.
+func (env *environ) OpenIngressRules(rules []network.IngressRule) error {
+	return nil
+}
+
+// CloseIngressRules closes the given ingress rules for the whole
+// environment. The LXD provider does not firewall its instances yet,
+// so this does nothing.
+func (env *environ) CloseIngressRules(rules []network.IngressRule) error {
+	return nil
+}
+
+// IngressRules returns the ingress rules opened for the whole
+// environment. The LXD provider does not firewall its instances yet,
+// so there are none.
+func (env *environ) IngressRules() ([]network.IngressRule, error) {
+	return nil, nil
+}
@@ -107,3 +107,24 @@ func (inst *environInstance) Ports(machineID string) ([]network.PortRange, error
 	}
 	return ports, errors.Trace(err)
 }
+
+// OpenIngressRules opens the given ingress rules on the instance.
+// The LXD provider does not firewall its instances yet, so this does
+// nothing.
+func (inst *environInstance) OpenIngressRules(machineID string, rules []network.IngressRule) error {
+	return nil
+}
+
+// CloseIngressRules closes the given ingress rules on the instance.
+// The LXD provider does not firewall its instances yet, so this does
+// nothing.
+func (inst *environInstance) CloseIngressRules(machineID string, rules []network.IngressRule) error {
+	return nil
+}
+
+// IngressRules returns the ingress rules opened on the instance.
+// The LXD provider does not firewall its instances yet, so there are
+// none.
+func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
+	return nil, nil
+}
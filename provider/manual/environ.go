@@ -351,6 +351,18 @@ func (e *manualEnviron) Ports() ([]network.PortRange, error) {
 	return nil, nil
 }
 
+func (e *manualEnviron) OpenIngressRules(rules []network.IngressRule) error {
+	return nil
+}
+
+func (e *manualEnviron) CloseIngressRules(rules []network.IngressRule) error {
+	return nil
+}
+
+func (e *manualEnviron) IngressRules() ([]network.IngressRule, error) {
+	return nil, nil
+}
+
 func (*manualEnviron) Provider() environs.EnvironProvider {
 	return manualProvider{}
 }
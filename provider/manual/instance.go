@@ -49,3 +49,15 @@ func (manualBootstrapInstance) ClosePorts(machineId string, ports []network.Port
 func (manualBootstrapInstance) Ports(machineId string) ([]network.PortRange, error) {
 	return nil, nil
 }
+
+func (manualBootstrapInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return nil
+}
+
+func (manualBootstrapInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return nil
+}
+
+func (manualBootstrapInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return nil, nil
+}
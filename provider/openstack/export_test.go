@@ -497,7 +497,7 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 	return e.(*Environ).resolveNetwork(networkName)
 }
 
-var PortsToRuleInfo = portsToRuleInfo
+var RulesToRuleInfo = rulesToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
 
 var MakeServiceURL = &makeServiceURL
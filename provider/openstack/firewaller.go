@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/nova"
 
@@ -63,6 +64,30 @@ type Firewaller interface {
 
 	// InstancePorts returns the port ranges opened for the specified  instance.
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
+
+	// OpenIngressRules opens the given ingress rules for the whole environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole environment.
+	IngressRules() ([]network.IngressRule, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for the specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+
+	// SetEgressRules sets the egress rules of the specified machine.
+	SetEgressRules(machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules of the specified machine.
+	EgressRules(machineId string) ([]network.EgressRule, error)
 }
 
 type firewallerFactory struct {
@@ -308,79 +333,129 @@ func deleteSecurityGroup(novaclient *nova.Client, name, id string) {
 
 // OpenPorts implements Firewaller interface.
 func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
+	return c.OpenIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+// ClosePorts implements Firewaller interface.
+func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+	return c.CloseIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+// Ports implements Firewaller interface.
+func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+	rules, err := c.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.PortRangesFromIngressRules(rules), nil
+}
+
+// OpenInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.OpenInstanceIngressRules(inst, machineId, network.IngressRulesFromPortRanges(ports))
+}
+
+// CloseInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.CloseInstanceIngressRules(inst, machineId, network.IngressRulesFromPortRanges(ports))
+}
+
+// InstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+	rules, err := c.InstanceIngressRules(inst, machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.PortRangesFromIngressRules(rules), nil
+}
+
+// OpenIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.openPortsInGroup(c.globalGroupRegexp(), ports); err != nil {
+	if err := c.openIngressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ingress rules in global group: %v", rules)
 	return nil
 }
 
-// ClosePorts implements Firewaller interface.
-func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.closePortsInGroup(c.globalGroupRegexp(), ports); err != nil {
+	if err := c.closeIngressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ingress rules in global group: %v", rules)
 	return nil
 }
 
-// Ports implements Firewaller interface.
-func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+// IngressRules implements Firewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			c.environ.Config().FirewallMode())
 	}
-	return c.portsInGroup(c.globalGroupRegexp())
+	return c.ingressRulesInGroup(c.globalGroupRegexp())
 }
 
-// OpenInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// OpenInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := c.openPortsInGroup(nameRegexp, ports); err != nil {
+	if err := c.openIngressRulesInGroup(nameRegexp, rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, ports)
+	logger.Infof("opened ingress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
 	return nil
 }
 
-// CloseInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := c.closePortsInGroup(nameRegexp, ports); err != nil {
+	if err := c.closeIngressRulesInGroup(nameRegexp, rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, ports)
+	logger.Infof("closed ingress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
 	return nil
 }
 
-// InstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+// InstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	portRanges, err := c.portsInGroup(nameRegexp)
+	rules, err := c.ingressRulesInGroup(nameRegexp)
 	if err != nil {
 		return nil, err
 	}
-	return portRanges, nil
+	return rules, nil
+}
+
+// SetEgressRules is not supported, as nova security groups only filter
+// inbound traffic.
+func (c *defaultFirewaller) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("SetEgressRules")
+}
+
+// EgressRules is not supported, as nova security groups only filter
+// inbound traffic.
+func (c *defaultFirewaller) EgressRules(machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("EgressRules")
 }
 
 func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
@@ -405,14 +480,14 @@ func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup
 	return matchingGroups[0], nil
 }
 
-func (c *defaultFirewaller) openPortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
+func (c *defaultFirewaller) openIngressRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
 	group, err := c.matchingGroup(nameRegExp)
 	if err != nil {
 		return err
 	}
 	novaclient := c.environ.nova()
-	rules := portsToRuleInfo(group.Id, portRanges)
-	for _, rule := range rules {
+	ruleInfo := rulesToRuleInfo(group.Id, rules)
+	for _, rule := range ruleInfo {
 		_, err := novaclient.CreateSecurityGroupRule(rule)
 		if err != nil {
 			// TODO: if err is not rule already exists, raise?
@@ -432,8 +507,17 @@ func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRan
 		*rule.ToPort == portRange.ToPort
 }
 
-func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+// ruleSourceCIDR returns the source CIDR of the supplied nova security
+// group rule. Rules without one allow traffic from anywhere.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.DefaultSourceCIDR
+}
+
+func (c *defaultFirewaller) closeIngressRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
 	group, err := c.matchingGroup(nameRegExp)
@@ -442,35 +526,50 @@ func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	}
 	novaclient := c.environ.nova()
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
+	for _, rule := range rules {
+		sourceCIDRs := set.NewStrings(rule.SourceCIDRs...)
+		if sourceCIDRs.IsEmpty() {
+			sourceCIDRs.Add(network.DefaultSourceCIDR)
+		}
 		for _, p := range group.Rules {
-			if !ruleMatchesPortRange(p, portRange) {
+			if !ruleMatchesPortRange(p, rule.PortRange) || !sourceCIDRs.Contains(ruleSourceCIDR(p)) {
 				continue
 			}
 			err := novaclient.DeleteSecurityGroupRule(p.Id)
 			if err != nil {
 				return err
 			}
-			break
 		}
 	}
 	return nil
 }
 
-func (c *defaultFirewaller) portsInGroup(nameRegexp string) (portRanges []network.PortRange, err error) {
+func (c *defaultFirewaller) ingressRulesInGroup(nameRegexp string) ([]network.IngressRule, error) {
 	group, err := c.matchingGroup(nameRegexp)
 	if err != nil {
 		return nil, err
 	}
+	// Nova holds a rule for each source CIDR, so merge the
+	// rules for each port range.
+	var portRanges []network.PortRange
+	sourceCIDRs := make(map[network.PortRange][]string)
 	for _, p := range group.Rules {
-		portRanges = append(portRanges, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: *p.IPProtocol,
 			FromPort: *p.FromPort,
 			ToPort:   *p.ToPort,
-		})
+		}
+		if _, ok := sourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		sourceCIDRs[portRange] = append(sourceCIDRs[portRange], ruleSourceCIDR(p))
+	}
+	rules := make([]network.IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = network.NewIngressRule(portRange, sourceCIDRs[portRange]...)
 	}
-	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (c *defaultFirewaller) globalGroupName(controllerUUID string) string {
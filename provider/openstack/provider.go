@@ -182,6 +182,8 @@ var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.IngressRuleFirewaller = (*Environ)(nil)
+var _ environs.EgressFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -199,6 +201,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ instance.IngressRuleFirewaller = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -345,6 +348,24 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstanceIngressRules(inst, machineId, rules)
+}
+
+// CloseIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstanceIngressRules(inst, machineId, rules)
+}
+
+// IngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1308,19 +1329,26 @@ func jujuMachineFilter() *nova.Filter {
 	return filter
 }
 
-// portsToRuleInfo maps port ranges to nova rules
-func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
-		rules[i] = nova.RuleInfo{
-			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+// rulesToRuleInfo maps ingress rules to nova rules, one for each of the
+// rules' source CIDRs.
+func rulesToRuleInfo(groupId string, rules []network.IngressRule) []nova.RuleInfo {
+	var result []nova.RuleInfo
+	for _, r := range rules {
+		sourceCIDRs := r.SourceCIDRs
+		if len(sourceCIDRs) == 0 {
+			sourceCIDRs = []string{network.DefaultSourceCIDR}
+		}
+		for _, cidr := range sourceCIDRs {
+			result = append(result, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      r.FromPort,
+				ToPort:        r.ToPort,
+				IPProtocol:    r.Protocol,
+				Cidr:          cidr,
+			})
 		}
 	}
-	return rules
+	return result
 }
 
 func (e *Environ) OpenPorts(ports []network.PortRange) error {
@@ -1335,6 +1363,43 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.OpenIngressRules(rules)
+}
+
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.CloseIngressRules(rules)
+}
+
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules()
+}
+
+// SupportsEgressRules is specified in the environs.EgressFirewaller
+// interface. The provider manages its security groups with the nova
+// API, whose rules only filter inbound traffic.
+func (e *Environ) SupportsEgressRules() (bool, error) {
+	return false, nil
+}
+
+// SetEgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *Environ) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	return e.firewaller.SetEgressRules(machineId, rules)
+}
+
+// EgressRules is specified in the environs.EgressFirewaller
+// interface.
+func (e *Environ) EgressRules(machineId string) ([]network.EgressRule, error) {
+	return e.firewaller.EgressRules(machineId)
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
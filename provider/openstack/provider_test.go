@@ -186,12 +186,13 @@ func (t *localTests) TestGetServerAddresses(c *gc.C) {
 	}
 }
 
-func (*localTests) TestPortsToRuleInfo(c *gc.C) {
+func (*localTests) TestRulesToRuleInfo(c *gc.C) {
 	groupId := "groupid"
 	testCases := []struct {
-		about    string
-		ports    []network.PortRange
-		expected []nova.RuleInfo
+		about       string
+		ports       []network.PortRange
+		sourceCIDRs []string
+		expected    []nova.RuleInfo
 	}{{
 		about: "single port",
 		ports: []network.PortRange{{
@@ -244,11 +245,36 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			Cidr:          "0.0.0.0/0",
 			ParentGroupId: groupId,
 		}},
+	}, {
+		about: "source CIDRs",
+		ports: []network.PortRange{{
+			FromPort: 80,
+			ToPort:   80,
+			Protocol: "tcp",
+		}},
+		sourceCIDRs: []string{"192.168.1.0/24", "10.0.0.0/8"},
+		expected: []nova.RuleInfo{{
+			IPProtocol:    "tcp",
+			FromPort:      80,
+			ToPort:        80,
+			Cidr:          "10.0.0.0/8",
+			ParentGroupId: groupId,
+		}, {
+			IPProtocol:    "tcp",
+			FromPort:      80,
+			ToPort:        80,
+			Cidr:          "192.168.1.0/24",
+			ParentGroupId: groupId,
+		}},
 	}}
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		rules := PortsToRuleInfo(groupId, t.ports)
+		ingressRules := make([]network.IngressRule, len(t.ports))
+		for j, portRange := range t.ports {
+			ingressRules[j] = network.NewIngressRule(portRange, t.sourceCIDRs...)
+		}
+		rules := RulesToRuleInfo(groupId, ingressRules)
 		c.Check(len(rules), gc.Equals, len(t.expected))
 		c.Check(rules, gc.DeepEquals, t.expected)
 	}
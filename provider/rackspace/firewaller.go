@@ -94,6 +94,54 @@ func (c *rackspaceFirewaller) InstancePorts(inst instance.Instance, machineId st
 	return configurator.FindOpenPorts()
 }
 
+// OpenIngressRules is not supported.
+func (c *rackspaceFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenIngressRules")
+}
+
+// CloseIngressRules is not supported.
+func (c *rackspaceFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseIngressRules")
+}
+
+// IngressRules is not supported.
+func (c *rackspaceFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("IngressRules")
+}
+
+// OpenInstanceIngressRules is not supported, as the instance firewall
+// cannot restrict the sources of traffic; callers should fall back to
+// OpenInstancePorts.
+func (c *rackspaceFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenInstanceIngressRules")
+}
+
+// CloseInstanceIngressRules is not supported, as the instance firewall
+// cannot restrict the sources of traffic; callers should fall back to
+// CloseInstancePorts.
+func (c *rackspaceFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseInstanceIngressRules")
+}
+
+// InstanceIngressRules is not supported, as the instance firewall
+// cannot restrict the sources of traffic; callers should fall back to
+// InstancePorts.
+func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("InstanceIngressRules")
+}
+
+// SetEgressRules is not supported, as the instance firewall only
+// filters inbound traffic.
+func (c *rackspaceFirewaller) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("SetEgressRules")
+}
+
+// EgressRules is not supported, as the instance firewall only filters
+// inbound traffic.
+func (c *rackspaceFirewaller) EgressRules(machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("EgressRules")
+}
+
 func (c *rackspaceFirewaller) changePorts(inst instance.Instance, insert bool, ports []network.PortRange) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
@@ -104,6 +104,18 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Parallel records whether the charm allows the action to run
+	// alongside other actions and hooks, outside the machine lock.
+	Parallel bool `bson:"parallel,omitempty"`
+
+	// Timeout is the time the action is allowed to run before it is
+	// stopped and marked as failed; zero means no timeout.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// Schedule holds the id of the action schedule which enqueued
+	// the action, if any.
+	Schedule string `bson:"schedule,omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -136,6 +148,24 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// Parallel returns whether the action may be run in parallel with
+// other actions.
+func (a *action) Parallel() bool {
+	return a.doc.Parallel
+}
+
+// Timeout returns the time the action is allowed to run for, or zero
+// if it may run indefinitely.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
+// Schedule returns the id of the action schedule which enqueued the
+// action, or "" if it was not enqueued by a schedule.
+func (a *action) Schedule() string {
+	return a.doc.Schedule
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -247,7 +277,7 @@ func newAction(st *State, adoc actionDoc) Action {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, opts actionOptions) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -263,6 +293,9 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Parameters: parameters,
 			Enqueued:   st.NowToTheSecond(),
 			Status:     ActionPending,
+			Parallel:   opts.parallel,
+			Timeout:    opts.timeout,
+			Schedule:   opts.schedule,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
 			ModelUUID: modelUUID,
@@ -354,8 +387,20 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 	return results, errors.Trace(iter.Close())
 }
 
-// EnqueueAction
+// actionOptions holds the optional settings of an action to be enqueued.
+type actionOptions struct {
+	parallel bool
+	timeout  time.Duration
+	schedule string
+}
+
+// EnqueueAction queues an action with the given name and payload for
+// the supplied receiver.
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, actionOptions{})
+}
+
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, opts actionOptions) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -365,7 +410,11 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	if opts.timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout %v", opts.timeout)
+	}
+
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -301,6 +302,51 @@ func (s *ActionSuite) TestEnqueueActionRequiresName(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "action name required")
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	action, err := s.unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 5*time.Minute)
+	c.Assert(action.Parallel(), jc.IsFalse)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 5*time.Minute)
+
+	action, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ActionSuite) TestAddActionWithNegativeTimeout(c *gc.C) {
+	_, err := s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative action timeout -1s not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionSuite) TestAddActionParallel(c *gc.C) {
+	ch := s.AddActionsCharm(c, "dummy", `
+backup:
+  description: Back up the database.
+  parallel: true
+  params:
+    outfile:
+      type: string
+snapshot:
+  description: Take a snapshot of the database.
+`, 2)
+	svc := s.AddTestingService(c, "parallel-actions", ch)
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := unit.AddAction("backup", map[string]interface{}{"outfile": "out.tar.bz2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Parallel(), jc.IsTrue)
+
+	action, err = unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Parallel(), jc.IsFalse)
+}
+
 func (s *ActionSuite) TestAddActionAcceptsDuplicateNames(c *gc.C) {
 	name := "snapshot"
 	params1 := map[string]interface{}{"outfile": "outfile.tar.bz2"}
@@ -878,6 +924,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
@@ -0,0 +1,331 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// actionScheduleDoc records an action which is to be enqueued on all
+// the units of an application, at the times given by a schedule.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	Id        string `bson:"id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Application is the name of the application on whose units
+	// the action is enqueued.
+	Application string `bson:"application"`
+
+	// Name is the name of the action to enqueue.
+	Name string `bson:"name"`
+
+	// Parameters holds the parameters the action is enqueued with.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Schedule is the schedule specification, as accepted by
+	// actions.ParseSchedule.
+	Schedule string `bson:"schedule"`
+
+	// Created is the time the schedule was added.
+	Created time.Time `bson:"created"`
+
+	// NextRun is the time at which the action is next to be enqueued.
+	NextRun time.Time `bson:"next-run"`
+
+	// LastRun is the time at which the action was last enqueued, or
+	// the zero time if it has not yet been.
+	LastRun time.Time `bson:"last-run"`
+}
+
+// ActionSchedule represents an action which is enqueued on all the
+// units of an application at regular times.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Application returns the name of the application on whose units the
+// action is enqueued.
+func (s *ActionSchedule) Application() string {
+	return s.doc.Application
+}
+
+// Name returns the name of the scheduled action.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters the action is enqueued with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the schedule specification.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns the time at which the action is next to be enqueued.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// LastRun returns the time at which the action was last enqueued, or
+// the zero time if it has not yet been.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// Run enqueues the scheduled action on each of the application's alive
+// units, and advances the schedule's next run time, if the schedule is
+// due to run at the given time. It returns the actions enqueued; if the
+// schedule is not due, or has already been run by someone else, no
+// actions are returned.
+func (s *ActionSchedule) Run(now time.Time) ([]Action, error) {
+	if s.doc.NextRun.After(now) {
+		return nil, nil
+	}
+	schedule, err := actions.ParseSchedule(s.doc.Schedule)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now = now.Round(time.Second).UTC()
+	nextRun := schedule.Next(now)
+
+	// Claim the run before enqueuing anything, so that concurrent
+	// runs of the same schedule cannot both enqueue actions.
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"next-run", s.doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{
+			{"next-run", nextRun},
+			{"last-run", now},
+		}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		if _, err := s.st.ActionSchedule(s.doc.Id); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot run action schedule %q", s.doc.Id)
+	}
+	s.doc.NextRun = nextRun
+	s.doc.LastRun = now
+
+	application, err := s.st.Application(s.doc.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var enqueued []Action
+	for _, unit := range units {
+		if unit.Life() != Alive {
+			continue
+		}
+		action, err := unit.addAction(s.doc.Name, s.doc.Parameters, actionOptions{schedule: s.doc.Id})
+		if err != nil {
+			logger.Warningf("cannot enqueue scheduled action %q on unit %q: %v", s.doc.Name, unit.Name(), err)
+			continue
+		}
+		enqueued = append(enqueued, action)
+	}
+	return enqueued, nil
+}
+
+// AddActionScheduleArgs holds the arguments to AddActionSchedule.
+type AddActionScheduleArgs struct {
+	// Application is the name of the application on whose units
+	// the action is to be enqueued.
+	Application string
+
+	// Name is the name of the action to enqueue.
+	Name string
+
+	// Parameters holds the parameters to enqueue the action with.
+	Parameters map[string]interface{}
+
+	// Schedule is the schedule specification, as accepted by
+	// actions.ParseSchedule.
+	Schedule string
+}
+
+// AddActionSchedule adds a schedule for enqueuing an action on all the
+// units of an application.
+func (st *State) AddActionSchedule(args AddActionScheduleArgs) (_ *ActionSchedule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add schedule for action %q on application %q", args.Name, args.Application)
+
+	schedule, err := actions.ParseSchedule(args.Schedule)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	application, err := st.Application(args.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, ok := actions.PredefinedActionsSpec[args.Name]
+	if !ok {
+		ch, _, err := application.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ch.Actions() != nil {
+			spec, ok = ch.Actions().ActionSpecs[args.Name]
+		}
+		if !ok {
+			return nil, errors.NotFoundf("action %q", args.Name)
+		}
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	now := st.NowToTheSecond()
+	nextRun := schedule.Next(now)
+	if nextRun.IsZero() {
+		return nil, errors.Errorf("schedule %q never runs", args.Schedule)
+	}
+	seq, err := st.sequence("actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	doc := actionScheduleDoc{
+		DocId:       st.docID(id),
+		Id:          id,
+		ModelUUID:   st.ModelUUID(),
+		Application: args.Application,
+		Name:        args.Name,
+		Parameters:  args.Parameters,
+		Schedule:    args.Schedule,
+		Created:     now,
+		NextRun:     nextRun,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := application.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if application.Life() != Alive {
+			return nil, errors.New("application is not alive")
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     application.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (st *State) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedules returns all the action schedules in the model, in
+// the order they were added.
+func (st *State) ActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	results := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		results[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions it has already enqueued are not affected.
+func (st *State) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	}
+	return errors.Annotatef(err, "cannot remove action schedule %q", id)
+}
+
+// WatchActionSchedules returns a NotifyWatcher which triggers whenever
+// an action schedule is added, removed or run.
+func (st *State) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionSchedulesC, isLocalID(st))
+}
+
+// removeActionSchedulesOps returns the operations required to remove
+// all the action schedules of the named application.
+func removeActionSchedulesOps(st *State, application string) ([]txn.Op, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	err := schedules.Find(bson.D{{"application", application}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedules for application %q", application)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	clock       *jujutesting.Clock
+	application *state.Application
+	unit        *state.Unit
+	unit2       *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2016, 11, 10, 9, 30, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.application = s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.unit, err = s.application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, schedule string) *state.ActionSchedule {
+	as, err := s.State.AddActionSchedule(state.AddActionScheduleArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		Parameters:  map[string]interface{}{"outfile": "out.bz2"},
+		Schedule:    schedule,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return as
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	as := s.addSchedule(c, "@daily")
+	c.Check(as.Id(), gc.Equals, "1")
+	c.Check(as.Application(), gc.Equals, "dummy")
+	c.Check(as.Name(), gc.Equals, "snapshot")
+	c.Check(as.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Check(as.Schedule(), gc.Equals, "@daily")
+	c.Check(as.Created(), gc.Equals, s.clock.Now())
+	c.Check(as.NextRun(), gc.Equals, time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC))
+	c.Check(as.LastRun().IsZero(), jc.IsTrue)
+
+	as2 := s.addSchedule(c, "@every 6h")
+	c.Check(as2.Id(), gc.Equals, "2")
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Check(schedules[0].Id(), gc.Equals, "1")
+	c.Check(schedules[1].Id(), gc.Equals, "2")
+	c.Check(schedules[1].NextRun().UTC(), gc.Equals, time.Date(2016, 11, 10, 15, 30, 0, 0, time.UTC))
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, t := range []struct {
+		args   state.AddActionScheduleArgs
+		expect string
+	}{{
+		args: state.AddActionScheduleArgs{
+			Application: "dummy", Name: "snapshot", Schedule: "whenever",
+		},
+		expect: `.*schedule "whenever" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{
+			Application: "dummy", Name: "snapshot", Schedule: "0 0 30 2 *",
+		},
+		expect: `.*schedule "0 0 30 2 \*" never runs`,
+	}, {
+		args: state.AddActionScheduleArgs{
+			Application: "missing", Name: "snapshot", Schedule: "@daily",
+		},
+		expect: `.*application "missing" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{
+			Application: "dummy", Name: "missing", Schedule: "@daily",
+		},
+		expect: `.*action "missing" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{
+			Application: "dummy",
+			Name:        "snapshot",
+			Parameters:  map[string]interface{}{"outfile": 5.0},
+			Schedule:    "@daily",
+		},
+		expect: `.*validation failed: .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddActionSchedule(t.args)
+		c.Check(err, gc.ErrorMatches, `cannot add schedule for action ".*" on application ".*": `+t.expect)
+	}
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleDyingApplication(c *gc.C) {
+	_, err := s.application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddActionSchedule(state.AddActionScheduleArgs{
+		Application: "dummy", Name: "snapshot", Schedule: "@daily",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add schedule for action "snapshot" on application "dummy": application is not alive`)
+}
+
+func (s *ActionScheduleSuite) TestRun(c *gc.C) {
+	as := s.addSchedule(c, "@every 1h")
+	err := s.unit2.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Not yet due.
+	enqueued, err := as.Run(s.clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 0)
+
+	s.clock.Advance(time.Hour)
+	now := s.clock.Now()
+	enqueued, err = as.Run(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 1)
+	c.Check(enqueued[0].Receiver(), gc.Equals, s.unit.Name())
+	c.Check(enqueued[0].Name(), gc.Equals, "snapshot")
+	c.Check(enqueued[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Check(enqueued[0].Schedule(), gc.Equals, as.Id())
+	c.Check(as.LastRun(), gc.Equals, now)
+	c.Check(as.NextRun(), gc.Equals, now.Add(time.Hour))
+
+	// A stale copy of the schedule does not run it again.
+	stale, err := s.State.ActionSchedule(as.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveActionSchedule(as.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stale.Run(now.Add(time.Hour))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	actions, err := s.unit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunConcurrently(c *gc.C) {
+	as := s.addSchedule(c, "@every 1h")
+	other, err := s.State.ActionSchedule(as.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Hour)
+	enqueued, err := as.Run(s.clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 2)
+
+	enqueued, err = other.Run(s.clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	as := s.addSchedule(c, "@daily")
+	err := s.State.RemoveActionSchedule(as.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule(as.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule "1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveActionSchedule(as.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule "1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestApplicationRemovalRemovesSchedules(c *gc.C) {
+	s.addSchedule(c, "@daily")
+	for _, unit := range []*state.Unit{s.unit, s.unit2} {
+		err := unit.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.State.WatchActionSchedules()
+	defer workertest.CleanKill(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	as := s.addSchedule(c, "@every 1h")
+	wc.AssertOneChange()
+
+	s.clock.Advance(time.Hour)
+	_, err := as.Run(s.clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveActionSchedule(as.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -601,8 +601,30 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Attach existing filesystems and volumes, such as those belonging
+	// to storage that has been detached from another unit.
+	for tag, params := range args.filesystemAttachments {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, incMachineStorageAttachmentCountOp(filesystemsC, tag.Id()))
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, names.NewStorageTag(f.doc.StorageId), params,
+		})
+		if f.doc.VolumeId != "" {
+			// The filesystem is backed by a volume, which must
+			// be attached to the machine too.
+			volumeOps = append(volumeOps, incMachineStorageAttachmentCountOp(volumesC, f.doc.VolumeId))
+			volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+				names.NewVolumeTag(f.doc.VolumeId), VolumeAttachmentParams{},
+			})
+		}
+	}
+	for tag, params := range args.volumeAttachments {
+		volumeOps = append(volumeOps, incMachineStorageAttachmentCountOp(volumesC, tag.Id()))
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{tag, params})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -618,6 +640,17 @@ func (st *State) machineStorageOps(
 	return ops, volumeAttachments, fsAttachments, nil
 }
 
+// incMachineStorageAttachmentCountOp returns a txn.Op that increments the
+// attachment count of an existing, Alive volume or filesystem.
+func incMachineStorageAttachmentCountOp(collection, id string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}
+}
+
 // addMachineStorageAttachmentsOps returns txn.Ops for adding the IDs of
 // attached volumes and filesystems to an existing machine. Filesystem
 // mount points are checked against existing filesystem attachments for
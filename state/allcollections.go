@@ -269,6 +269,18 @@ func allCollections() collectionSchema {
 		},
 		relationScopesC: {},
 
+		// relationNetworksC holds the networks from which a relation's
+		// counterpart in another model connects, used to derive
+		// firewall ingress rules for cross-model relations.
+		relationNetworksC: {},
+
+		// These collections hold information associated with
+		// cross-model relations: the application endpoints offered
+		// to other models, and the proxies for applications in
+		// other models that take part in relations in this one.
+		applicationOffersC:  {},
+		remoteApplicationsC: {},
+
 		// -----
 
 		// These collections hold information associated with machines.
@@ -335,6 +347,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
 
 		// -----
 
@@ -394,6 +411,11 @@ func allCollections() collectionSchema {
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "time"},
+			}, {
+				Key: []string{"time"},
+			}},
 		},
 	}
 }
@@ -405,8 +427,10 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	applicationOffersC       = "applicationOffers"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	auditingC                = "audit.log"
@@ -448,6 +472,8 @@ const (
 	permissionsC             = "permissions"
 	providerIDsC             = "providerIDs"
 	rebootC                  = "reboot"
+	remoteApplicationsC      = "remoteApplications"
+	relationNetworksC        = "relationNetworks"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -22,6 +24,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -47,6 +50,8 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
+	EgressRules          []string   `bson:"egress-rules,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
@@ -285,6 +290,26 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	ops = append(ops, charmOps...)
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
 
+	scheduleOps, err := removeActionSchedulesOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, scheduleOps...)
+
+	// All of the units have been removed, so there are no attachments
+	// to the application's shared storage; it can be removed too.
+	storageOps, err := removeStorageInstancesOps(a.st, a.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, storageOps...)
+
+	offerOps, err := removeApplicationOffersOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, offerOps...)
+
 	globalKey := a.globalKey()
 	ops = append(ops,
 		removeEndpointBindingsOp(globalKey),
@@ -304,29 +329,116 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedCIDRs returns the CIDRs from which the explicitly open ports
+// of an exposed application may be accessed. If none are returned, the
+// ports may be accessed from anywhere. See SetExposedCIDRs.
+func (a *Application) ExposedCIDRs() []string {
+	if len(a.doc.ExposedCIDRs) == 0 {
+		return nil
+	}
+	cidrs := make([]string, len(a.doc.ExposedCIDRs))
+	copy(cidrs, a.doc.ExposedCIDRs)
+	return cidrs
+}
+
+// SetExposed marks the application as exposed, allowing access to its
+// open ports from anywhere.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, nil)
+}
+
+// SetExposedCIDRs marks the application as exposed, allowing access to
+// its open ports only from the given CIDRs. If no CIDRs are given, the
+// ports may be accessed from anywhere, as with SetExposed.
+func (a *Application) SetExposedCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return a.setExposed(true, set.NewStrings(cidrs...).SortedValues())
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, nil)
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) setExposed(exposed bool, cidrs []string) (err error) {
+	var update bson.D
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{{"exposed", exposed}, {"exposed-cidrs", cidrs}}}}
+	} else {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-cidrs", nil}}},
+		}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedCIDRs = cidrs
+	return nil
+}
+
+// EgressRules returns the rules allowing outbound traffic from the
+// machines hosting the application's units. If none are returned, all
+// outbound traffic is allowed. See SetEgressRules.
+func (a *Application) EgressRules() ([]network.EgressRule, error) {
+	if len(a.doc.EgressRules) == 0 {
+		return nil, nil
+	}
+	rules := make([]network.EgressRule, len(a.doc.EgressRules))
+	for i, value := range a.doc.EgressRules {
+		rule, err := network.ParseEgressRule(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", a)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// SetEgressRules restricts the outbound traffic of the machines hosting
+// the application's units to the given rules. If no rules are given,
+// the restrictions are removed and all outbound traffic is allowed.
+func (a *Application) SetEgressRules(rules []network.EgressRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set egress rules for application %q", a)
+	values := set.NewStrings()
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		values.Add(rule.String())
+	}
+	var update bson.D
+	if values.IsEmpty() {
+		update = bson.D{{"$unset", bson.D{{"egress-rules", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"egress-rules", values.SortedValues()}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	a.doc.EgressRules = nil
+	if !values.IsEmpty() {
+		a.doc.EgressRules = values.SortedValues()
+	}
 	return nil
 }
 
@@ -656,6 +768,10 @@ func (a *Application) changeCharmOps(
 			newStorageConstraintsKey, newStorageConstraints,
 		)
 	}
+	storagePoolOps, err := storageConstraintsPoolRefOps(a.st, newStorageConstraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Upgrade charm storage.
 	upgradeStorageOps, err := a.upgradeStorageOps(ch.Meta(), oldMeta, units, newStorageConstraints)
@@ -703,6 +819,7 @@ func (a *Application) changeCharmOps(
 			}}},
 		},
 	}...)
+	ops = append(ops, storagePoolOps...)
 	ops = append(ops, checkStorageOps...)
 	ops = append(ops, upgradeStorageOps...)
 
@@ -1114,6 +1231,19 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
+	sharedStorageOps, numSharedStorageAttachments, err := unitSharedStorageOps(
+		a.st,
+		unitTag,
+		charm.Meta(),
+		args.storageCons,
+		a.doc.Series,
+		machineAssignable,
+	)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	storageOps = append(storageOps, sharedStorageOps...)
+	numStorageAttachments += numSharedStorageAttachments
 
 	docID := a.st.docID(name)
 	globalKey := unitGlobalKey(name)
@@ -1529,6 +1659,42 @@ func (a *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// SetEndpointBindings merges the given bindings into the application's
+// existing endpoint bindings. Each endpoint must be defined by the
+// application's current charm, and each space must exist; otherwise an
+// error satisfying errors.IsNotValid is returned.
+func (a *Application) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", a)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Assert the charm is unchanged, as the bindings were
+		// validated against its metadata.
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"charmurl", a.doc.CharmURL}),
+		}, bindingsOp}, nil
+	}
+	return a.st.run(buildTxn)
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
@@ -1727,6 +1893,10 @@ func addApplicationOps(st *State, args addApplicationOpsArgs) ([]txn.Op, error)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	storagePoolOps, err := storageConstraintsPoolRefOps(st, args.storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	globalKey := svc.globalKey()
 	settingsKey := svc.settingsKey()
@@ -1742,6 +1912,7 @@ func addApplicationOps(st *State, args addApplicationOpsArgs) ([]txn.Op, error)
 		addModelServiceRefOp(st, svc.Name()),
 	}
 	ops = append(ops, charmRefOps...)
+	ops = append(ops, storagePoolOps...)
 	ops = append(ops, txn.Op{
 		C:      applicationsC,
 		Id:     svc.Name(),
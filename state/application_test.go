@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
@@ -1440,6 +1441,96 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestServiceExposedCIDRs(c *gc.C) {
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err := s.mysql.SetExposedCIDRs([]string{"192.168.1.0/24", "10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	// Exposing without CIDRs allows access from anywhere.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	// Unexposing clears the CIDRs.
+	err = s.mysql.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestServiceExposedCIDRsInvalid(c *gc.C) {
+	err := s.mysql.SetExposedCIDRs([]string{"10.0.0.0/8", "10.0.0.1"})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.1" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.mysql.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	dns := network.NewEgressRule(network.MustParsePortRange("53/udp"), "10.0.0.2/32")
+	https := network.NewEgressRule(network.MustParsePortRange("443/tcp"), "192.168.1.0/24")
+	err = s.mysql.SetEgressRules([]network.EgressRule{dns, https, dns})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{https, dns}
+	rules, err = s.mysql.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.mysql.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+
+	// Setting no rules allows all outbound traffic.
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.mysql.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.1"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": invalid destination CIDR "10.0.0.1"`)
+	rules, err := s.mysql.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql"`+notAliveErr)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -2642,3 +2733,83 @@ func (s *ApplicationSuite) TestSetCharmHandlesMissingBindingsAsDefaults(c *gc.C)
 
 	s.assertApplicationRemovedWithItsBindings(c, service)
 }
+
+func (s *ApplicationSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("ha", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, map[string]string{
+		"server": "db",
+	})
+
+	err = service.SetEndpointBindings(map[string]string{
+		"server":  "ha",
+		"cluster": "ha",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	setBindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings, jc.DeepEquals, map[string]string{
+		"server":  "ha",
+		"client":  "",
+		"cluster": "ha",
+	})
+
+	// Setting the same bindings again is a no-op.
+	err = service.SetEndpointBindings(map[string]string{"server": "ha"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsInvalid(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+
+	err = service.SetEndpointBindings(map[string]string{"server": "missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": unknown space "missing" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = service.SetEndpointBindings(map[string]string{"kludge": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": unknown endpoint "kludge" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	s.assertApplicationHasOnlyDefaultEndpointBindings(c, service)
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsNotAlive(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestWatchEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	w := s.mysql.WatchEndpointBindings()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Unrelated changes are not reported.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -0,0 +1,218 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationOffer represents some of an application's endpoints,
+// published under a name so that applications in other models hosted
+// by the same controller may relate to them.
+type ApplicationOffer struct {
+	st  *State
+	doc applicationOfferDoc
+}
+
+// applicationOfferDoc represents the internal state of an application
+// offer in MongoDB.
+type applicationOfferDoc struct {
+	DocID           string   `bson:"_id"`
+	ModelUUID       string   `bson:"model-uuid"`
+	OfferName       string   `bson:"offer-name"`
+	ApplicationName string   `bson:"application-name"`
+	Endpoints       []string `bson:"endpoints"`
+}
+
+func newApplicationOffer(st *State, doc *applicationOfferDoc) *ApplicationOffer {
+	return &ApplicationOffer{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// Name returns the name of the offer.
+func (o *ApplicationOffer) Name() string {
+	return o.doc.OfferName
+}
+
+// ApplicationName returns the name of the offered application.
+func (o *ApplicationOffer) ApplicationName() string {
+	return o.doc.ApplicationName
+}
+
+// EndpointNames returns the names of the offered application endpoints.
+func (o *ApplicationOffer) EndpointNames() []string {
+	return o.doc.Endpoints
+}
+
+// Endpoints returns the offered application endpoints.
+func (o *ApplicationOffer) Endpoints() ([]Endpoint, error) {
+	app, err := o.st.Application(o.doc.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps := make([]Endpoint, len(o.doc.Endpoints))
+	for i, name := range o.doc.Endpoints {
+		ep, err := app.Endpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		eps[i] = ep
+	}
+	return eps, nil
+}
+
+// AddApplicationOfferParams contains the parameters for offering
+// an application's endpoints to other models.
+type AddApplicationOfferParams struct {
+	// OfferName is the name under which the endpoints are offered.
+	OfferName string
+
+	// ApplicationName is the name of the application to offer.
+	ApplicationName string
+
+	// Endpoints are the names of the application endpoints to offer.
+	Endpoints []string
+}
+
+// AddApplicationOffer offers the specified endpoints of an application
+// under the supplied name, which must be unique within the model.
+func (st *State) AddApplicationOffer(args AddApplicationOfferParams) (_ *ApplicationOffer, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add application offer %q", args.OfferName)
+	if !names.IsValidApplication(args.OfferName) {
+		return nil, errors.NotValidf("offer name %q", args.OfferName)
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, errors.NotValidf("empty endpoints")
+	}
+	app, err := st.Application(args.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, name := range args.Endpoints {
+		ep, err := app.Endpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Role == charm.RolePeer {
+			return nil, errors.Errorf("cannot offer peer relation %q", name)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("cannot offer container scoped relation %q", name)
+		}
+	}
+	docID := st.docID(args.OfferName)
+	doc := &applicationOfferDoc{
+		DocID:           docID,
+		ModelUUID:       st.ModelUUID(),
+		OfferName:       args.OfferName,
+		ApplicationName: args.ApplicationName,
+		Endpoints:       args.Endpoints,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", app)
+		}
+		if _, err := st.ApplicationOffer(args.OfferName); err == nil {
+			return nil, errors.AlreadyExistsf("application offer %q", args.OfferName)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      applicationOffersC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newApplicationOffer(st, doc), nil
+}
+
+// ApplicationOffer returns the application offer with the supplied name.
+func (st *State) ApplicationOffer(offerName string) (*ApplicationOffer, error) {
+	applicationOffers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var doc applicationOfferDoc
+	err := applicationOffers.FindId(offerName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("application offer %q", offerName)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get application offer %q", offerName)
+	}
+	return newApplicationOffer(st, &doc), nil
+}
+
+// AllApplicationOffers returns all the application offers in the model.
+func (st *State) AllApplicationOffers() ([]*ApplicationOffer, error) {
+	applicationOffers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []applicationOfferDoc
+	if err := applicationOffers.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get application offers")
+	}
+	offers := make([]*ApplicationOffer, len(docs))
+	for i := range docs {
+		offers[i] = newApplicationOffer(st, &docs[i])
+	}
+	return offers, nil
+}
+
+// RemoveApplicationOffer removes the application offer with the
+// supplied name. Relations already established through the offer
+// are not affected.
+func (st *State) RemoveApplicationOffer(offerName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove application offer %q", offerName)
+	if _, err := st.ApplicationOffer(offerName); err != nil {
+		return errors.Trace(err)
+	}
+	return st.runTransaction([]txn.Op{{
+		C:      applicationOffersC,
+		Id:     st.docID(offerName),
+		Remove: true,
+	}})
+}
+
+// removeApplicationOffersOps returns the operations required to remove
+// all offers of the named application.
+func removeApplicationOffersOps(st *State, applicationName string) ([]txn.Op, error) {
+	applicationOffers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	sel := bson.D{{"application-name", applicationName}}
+	if err := applicationOffers.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get offers of application %q", applicationName)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      applicationOffersC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
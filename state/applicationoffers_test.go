@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationOfferSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&ApplicationOfferSuite{})
+
+func (s *ApplicationOfferSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *ApplicationOfferSuite) TestAddApplicationOffer(c *gc.C) {
+	offer, err := s.State.AddApplicationOffer(state.AddApplicationOfferParams{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Name(), gc.Equals, "db")
+	c.Assert(offer.ApplicationName(), gc.Equals, "mysql")
+	c.Assert(offer.EndpointNames(), jc.DeepEquals, []string{"server"})
+
+	offer, err = s.State.ApplicationOffer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := offer.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	ep, err := s.mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eps, jc.DeepEquals, []state.Endpoint{ep})
+
+	offers, err := s.State.AllApplicationOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 1)
+	c.Assert(offers[0].Name(), gc.Equals, "db")
+}
+
+func (s *ApplicationOfferSuite) TestAddApplicationOfferAlreadyExists(c *gc.C) {
+	args := state.AddApplicationOfferParams{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	}
+	_, err := s.State.AddApplicationOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddApplicationOffer(args)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ApplicationOfferSuite) TestAddApplicationOfferUnknownEndpoint(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.AddApplicationOfferParams{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"admin"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application offer "db": application "mysql" has no "admin" relation`)
+}
+
+func (s *ApplicationOfferSuite) TestRemoveApplicationOffer(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.AddApplicationOfferParams{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveApplicationOffer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("db")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationOfferSuite) TestApplicationRemovalRemovesOffers(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.AddApplicationOfferParams{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("db")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
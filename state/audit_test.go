@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+func (s *AuditSuite) putEntry(c *gc.C, operation string, when time.Time) audit.AuditEntry {
+	entry := audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.1"),
+		ModelUUID:         s.State.ModelUUID(),
+		Timestamp:         when.UTC(),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         operation,
+	}
+	err := s.State.PutAuditEntryFn()(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	return entry
+}
+
+func (s *AuditSuite) TestAuditEntries(c *gc.C) {
+	start := coretesting.NonZeroTime().Round(time.Second)
+	s.putEntry(c, "Client.FullStatus", start)
+	s.putEntry(c, "ModelManager.DestroyModels", start.Add(time.Minute))
+	s.putEntry(c, "Client.FullStatus", start.Add(2*time.Minute))
+
+	entries, err := s.State.AuditEntries(audit.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 3)
+	c.Check(entries[0].Timestamp, gc.Equals, start.UTC())
+	c.Check(entries[2].Timestamp, gc.Equals, start.Add(2*time.Minute).UTC())
+
+	entries, err = s.State.AuditEntries(audit.Query{
+		Operation: "ModelManager.DestroyModels",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].OriginName, gc.Equals, "user-admin")
+
+	entries, err = s.State.AuditEntries(audit.Query{
+		After:  start.Add(time.Minute),
+		Before: start.Add(2 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Operation, gc.Equals, "ModelManager.DestroyModels")
+
+	entries, err = s.State.AuditEntries(audit.Query{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Operation, gc.Equals, "ModelManager.DestroyModels")
+	c.Check(entries[1].Timestamp, gc.Equals, start.Add(2*time.Minute).UTC())
+}
+
+func (s *AuditSuite) TestAuditEntriesOtherModel(c *gc.C) {
+	s.putEntry(c, "Client.FullStatus", coretesting.NonZeroTime())
+
+	entries, err := s.State.AuditEntries(audit.Query{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
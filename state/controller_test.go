@@ -24,11 +24,19 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
+		controller.IdentityURL:            true,
+		controller.IdentityPublicKey:      true,
+		controller.AutocertURLKey:         true,
+		controller.AutocertDNSNameKey:     true,
+		controller.AllowModelAccessKey:    true,
+		controller.AuditLogSinks:          true,
+		controller.AuditSyslogHost:        true,
+		controller.AuditSyslogCACert:      true,
+		controller.AuditSyslogClientCert:  true,
+		controller.AuditSyslogClientKey:   true,
+		controller.AuditWebhookURL:        true,
+		controller.AuditWebhookBatchSize:  true,
+		controller.AuditWebhookBufferSize: true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
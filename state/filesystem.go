@@ -60,6 +60,21 @@ type Filesystem interface {
 	// if it needs to be provisioned. Params returns true if the returned
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
+
+	// Releasing reports whether the filesystem is to be released from
+	// the model when it is removed, rather than destroyed.
+	Releasing() bool
+
+	// Usage returns the filesystem's usage as last reported by a
+	// machine agent. Usage returns true if usage has been reported,
+	// otherwise false.
+	Usage() (StorageUsage, bool)
+
+	// PendingSize returns the size, in MiB, that the filesystem is
+	// to be grown to, after its backing volume has been resized.
+	// PendingSize returns true if there is a resize pending,
+	// otherwise false.
+	PendingSize() (uint64, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -111,6 +126,9 @@ type filesystemDoc struct {
 	Binding         string            `bson:"binding,omitempty"`
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
+	Releasing       bool              `bson:"releasing,omitempty"`
+	Usage           *StorageUsage     `bson:"usage,omitempty"`
+	PendingSize     uint64            `bson:"pendingsize,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -253,6 +271,24 @@ func (f *filesystem) Volume() (names.VolumeTag, error) {
 	return names.NewVolumeTag(f.doc.VolumeId), nil
 }
 
+// Releasing is required to implement Filesystem.
+func (f *filesystem) Releasing() bool {
+	return f.doc.Releasing
+}
+
+// Usage is required to implement Filesystem.
+func (f *filesystem) Usage() (StorageUsage, bool) {
+	if f.doc.Usage == nil {
+		return StorageUsage{}, false
+	}
+	return *f.doc.Usage, true
+}
+
+// PendingSize is required to implement Filesystem.
+func (f *filesystem) PendingSize() (uint64, bool) {
+	return f.doc.PendingSize, f.doc.PendingSize != 0
+}
+
 // Info is required to implement Filesystem.
 func (f *filesystem) Info() (FilesystemInfo, error) {
 	if f.doc.Info == nil {
@@ -269,6 +305,18 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// pool returns the name of the storage pool from which the filesystem
+// is, or is to be, provisioned.
+func (f *filesystem) pool() string {
+	if f.doc.Info != nil {
+		return f.doc.Info.Pool
+	}
+	if f.doc.Params != nil {
+		return f.doc.Params.Pool
+	}
+	return ""
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return f.st.FilesystemStatus(f.FilesystemTag())
@@ -737,13 +785,19 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if err != nil {
 		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
 	}
-	if !provider.Supports(storage.StorageKindFilesystem) {
+	if provider.Supports(storage.StorageKindFilesystem) {
+		// A backing volume records its own reference to the pool.
+		ops, err = storagePoolRefOps(st, params.Pool)
+		if err != nil {
+			return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
+		}
+	} else {
 		var volumeOps []txn.Op
 		volumeParams := VolumeParams{
-			params.storage,
-			filesystemTag, // volume is bound to filesystem
-			params.Pool,
-			params.Size,
+			storage: params.storage,
+			binding: filesystemTag, // volume is bound to filesystem
+			Pool:    params.Pool,
+			Size:    params.Size,
 		}
 		volumeOps, volumeTag, err = st.addVolumeOps(volumeParams, machineId)
 		if err != nil {
@@ -770,6 +824,37 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	return ops, filesystemTag, volumeTag, nil
 }
 
+// addSharedFilesystemOps returns txn.Ops to create a new model-scoped
+// filesystem for shared storage. Unlike addFilesystemOps, the filesystem
+// is created without any attachments; one is added for each machine that
+// a unit sharing the storage is assigned to.
+func (st *State) addSharedFilesystemOps(params FilesystemParams) ([]txn.Op, names.FilesystemTag, error) {
+	machineId, err := st.validateFilesystemParams(params, "")
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "validating filesystem params")
+	}
+	filesystemId, err := newFilesystemId(st, machineId)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	status := statusDoc{
+		Status:  status.Pending,
+		Updated: st.clock.Now().UnixNano(),
+	}
+	doc := filesystemDoc{
+		FilesystemId: filesystemId,
+		StorageId:    params.storage.Id(),
+		Binding:      params.binding.String(),
+		Params:       &params,
+	}
+	ops, err := storagePoolRefOps(st, params.Pool)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+	ops = append(ops, st.newFilesystemOps(doc, status)...)
+	return ops, names.NewFilesystemTag(filesystemId), nil
+}
+
 func (st *State) newFilesystemOps(doc filesystemDoc, status statusDoc) []txn.Op {
 	return []txn.Op{
 		createStatusOp(st, filesystemGlobalKey(doc.FilesystemId), status),
@@ -782,6 +867,138 @@ func (st *State) newFilesystemOps(doc filesystemDoc, status statusDoc) []txn.Op
 	}
 }
 
+// AddExistingFilesystem imports an existing, already-provisioned
+// filesystem into the model. The filesystem, and its backing volume
+// if it has one, are recorded as belonging to a new storage instance
+// with the given storage name and no owner, so that it may later be
+// attached to a unit. The tag of the new storage instance is returned.
+//
+// The filesystem and volume are given the status "detached". When the
+// storage instance is attached to a unit with AttachStorage, their
+// status becomes "attaching" until the storage provisioner has
+// attached them to the unit's machine.
+func (st *State) AddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem")
+	if err := st.validateExistingFilesystem(info, backingVolume, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	storageTag := names.NewStorageTag(storageId)
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	filesystemTag := names.NewFilesystemTag(filesystemId)
+
+	// Imported storage is not attached to anything.
+	detached := statusDoc{
+		Status:  status.Detached,
+		Updated: st.clock.Now().UnixNano(),
+	}
+	poolNames := []string{info.Pool}
+	if backingVolume != nil {
+		poolNames = append(poolNames, backingVolume.Pool)
+	}
+	ops, err := storagePoolRefOps(st, poolNames...)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	var volumeId string
+	if backingVolume != nil {
+		volumeId, err = newVolumeName(st, "")
+		if err != nil {
+			return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+		}
+		volumeInfo := *backingVolume
+		ops = append(ops, st.newVolumeOps(volumeDoc{
+			Name:      volumeId,
+			StorageId: storageId,
+			Binding:   filesystemTag.String(),
+			Info:      &volumeInfo,
+		}, detached)...)
+		// Filesystems managed by Juju on a volume are identified
+		// by the filesystem tag.
+		info.FilesystemId = filesystemTag.String()
+	}
+	ops = append(ops, st.newFilesystemOps(filesystemDoc{
+		FilesystemId: filesystemId,
+		VolumeId:     volumeId,
+		StorageId:    storageId,
+		Binding:      storageTag.String(),
+		Info:         &info,
+	}, detached)...)
+	ops = append(ops, txn.Op{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindFilesystem,
+			StorageName: storageName,
+		},
+	})
+	if backingVolume != nil {
+		// Record the provider ID of the imported volume, so that
+		// concurrent imports of the same volume cannot both succeed.
+		ops = append(ops, st.importedVolumeOp(backingVolume.VolumeId))
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if backingVolume != nil {
+			if err := st.checkVolumeNotInModel(backingVolume.VolumeId); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// validateExistingFilesystem returns an error if the filesystem described
+// cannot be imported into the model.
+func (st *State) validateExistingFilesystem(info FilesystemInfo, backingVolume *VolumeInfo, storageName string) error {
+	// Storage instance IDs are formed from the storage
+	// name and a sequence number.
+	if !names.IsValidStorage(storageName + "/0") {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if info.Pool == "" {
+		return errors.NotValidf("empty pool name")
+	}
+	_, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() == storage.ScopeMachine || !provider.Dynamic() {
+		return errors.NotSupportedf("importing storage from pool %q", info.Pool)
+	}
+	if backingVolume == nil {
+		if info.FilesystemId == "" {
+			return errors.NotValidf("empty filesystem ID")
+		}
+		return nil
+	}
+	if info.FilesystemId != "" {
+		return errors.NotValidf("filesystem ID with backing volume")
+	}
+	if backingVolume.VolumeId == "" {
+		return errors.NotValidf("empty backing volume ID")
+	}
+	if backingVolume.Pool != info.Pool {
+		return errors.NotValidf("backing volume pool %q", backingVolume.Pool)
+	}
+	return nil
+}
+
 func (st *State) filesystemParamsWithDefaults(params FilesystemParams) (FilesystemParams, error) {
 	if params.Pool != "" {
 		return params, nil
@@ -894,7 +1111,11 @@ func (st *State) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo)
 				return nil, err
 			}
 		}
-		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		// If the filesystem has grown to the size requested
+		// by a pending resize, the resize is complete.
+		pendingSize, resizing := fs.PendingSize()
+		unsetPendingSize := resizing && info.Size >= pendingSize
+		ops := setFilesystemInfoOps(tag, info, unsetParams, unsetPendingSize)
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -916,15 +1137,23 @@ func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	return nil
 }
 
-func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetParams bool) []txn.Op {
+func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetParams, unsetPendingSize bool) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if unsetPendingSize {
+		asserts = append(asserts, bson.DocElem{"pendingsize", bson.D{{"$lte", info.Size}}})
+		unset = append(unset, bson.DocElem{"pendingsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      filesystemsC,
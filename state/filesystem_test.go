@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 )
 
 type FilesystemStateSuite struct {
@@ -356,6 +357,54 @@ func (s *FilesystemStateSuite) TestWatchEnvironFilesystemAttachments(c *gc.C) {
 	// the means to progress Volume lifecycle.
 }
 
+func (s *FilesystemStateSuite) TestResizeVolumeFilesystem(c *gc.C) {
+	filesystemAttachment, _ := s.addUnitWithFilesystem(c, "loop", true)
+	filesystemTag := filesystemAttachment.Filesystem()
+	machineTag := filesystemAttachment.Machine()
+	volumeTag, err := s.filesystem(c, filesystemTag).Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volumeTag, state.VolumeAttachmentInfo{DeviceName: "loop0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{FilesystemId: "fs-id", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineFilesystemResizes(machineTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	// The filesystem is not grown until the volume has been.
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	_, ok := s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsFalse)
+
+	volumeInfo, err := s.volume(c, volumeTag).Info()
+	c.Assert(err, jc.ErrorIsNil)
+	volumeInfo.Size = 2048
+	err = s.State.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(filesystemTag.Id())
+	wc.AssertNoChange()
+	pendingSize, ok := s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(pendingSize, gc.Equals, uint64(2048))
+
+	// Completing the resize does not trigger the watcher.
+	filesystemInfo, err := s.filesystem(c, filesystemTag).Info()
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemInfo.Size = 2048
+	err = s.State.SetFilesystemInfo(filesystemTag, filesystemInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	_, ok = s.filesystem(c, filesystemTag).PendingSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *FilesystemStateSuite) TestWatchMachineFilesystems(c *gc.C) {
 	service := s.setupMixedScopeStorageService(c, "filesystem")
 	addUnit := func() {
@@ -1031,3 +1080,110 @@ func (s *FilesystemStateSuite) setupFilesystemAttachment(c *gc.C, pool string) (
 	assertMachineStorageRefs(c, s.State, machine.MachineTag())
 	return s.filesystem(c, attachments[0].Filesystem()), machine
 }
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystem(c *gc.C) {
+	fsInfo := state.FilesystemInfo{Pool: "persistent-block", Size: 123}
+	volInfo := state.VolumeInfo{
+		Pool:       "persistent-block",
+		Size:       123,
+		VolumeId:   "vol-ume",
+		Persistent: true,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindFilesystem)
+	c.Assert(si.StorageName(), gc.Equals, "pgdata")
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	fsInfo.FilesystemId = filesystem.FilesystemTag().String()
+	s.assertFilesystemInfo(c, filesystem.FilesystemTag(), fsInfo)
+	fsStatus, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fsStatus.Status, gc.Equals, status.Detached)
+
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeInfo(c, volumeTag, volInfo)
+
+	_, err = s.State.AddExistingFilesystem(state.FilesystemInfo{Pool: "persistent-block"}, &volInfo, "pgdata")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: volume "vol-ume" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemConcurrently(c *gc.C) {
+	fsInfo := state.FilesystemInfo{Pool: "persistent-block", Size: 123}
+	volInfo := state.VolumeInfo{Pool: "persistent-block", Size: 123, VolumeId: "vol-ume"}
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: volume "vol-ume" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+	all, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *FilesystemStateSuite) TestAttachExistingFilesystem(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "persistent-block")
+	fsInfo := state.FilesystemInfo{Pool: "persistent-block", Size: 123}
+	volInfo := state.VolumeInfo{Pool: "persistent-block", Size: 123, VolumeId: "vol-ume"}
+	storageTag, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	fsStatus, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fsStatus.Status, gc.Equals, status.Attaching)
+	volume := s.storageInstanceVolume(c, storageTag)
+	volStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volStatus.Status, gc.Equals, status.Attaching)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemInvalid(c *gc.C) {
+	volInfo := &state.VolumeInfo{Pool: "persistent-block", Size: 123, VolumeId: "vol-ume"}
+	for i, t := range []struct {
+		info          state.FilesystemInfo
+		backingVolume *state.VolumeInfo
+		storageName   string
+		expect        string
+	}{{
+		info:          state.FilesystemInfo{Pool: "persistent-block"},
+		backingVolume: volInfo,
+		storageName:   "0data",
+		expect:        `storage name "0data" not valid`,
+	}, {
+		info:          state.FilesystemInfo{Pool: "loop-pool"},
+		backingVolume: &state.VolumeInfo{Pool: "loop-pool", VolumeId: "loop0"},
+		storageName:   "pgdata",
+		expect:        `importing storage from pool "loop-pool" not supported`,
+	}, {
+		info:        state.FilesystemInfo{Pool: "persistent-block"},
+		storageName: "pgdata",
+		expect:      `empty filesystem ID not valid`,
+	}, {
+		info:          state.FilesystemInfo{Pool: "persistent-block", FilesystemId: "fs-123"},
+		backingVolume: volInfo,
+		storageName:   "pgdata",
+		expect:        `filesystem ID with backing volume not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddExistingFilesystem(t.info, t.backingVolume, t.storageName)
+		c.Check(err, gc.ErrorMatches, "cannot add existing filesystem: "+t.expect)
+	}
+	all, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
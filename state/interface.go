@@ -157,6 +157,12 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithTimeout queues an action as AddAction does, which
+	// is stopped and marked as failed if it runs for longer than the
+	// given timeout. A zero timeout allows the action to run
+	// indefinitely.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -221,6 +227,18 @@ type Action interface {
 	// Status returns the final state of the action.
 	Status() ActionStatus
 
+	// Parallel returns whether the action may be run in parallel with
+	// other actions.
+	Parallel() bool
+
+	// Timeout returns the time the action is allowed to run for, or
+	// zero if it may run indefinitely.
+	Timeout() time.Duration
+
+	// Schedule returns the id of the action schedule which enqueued
+	// the action, or "" if it was not enqueued by a schedule.
+	Schedule() string
+
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
@@ -4,11 +4,13 @@
 package audit
 
 import (
-	"github.com/juju/errors"
+	"time"
 
-	"github.com/juju/juju/audit"
+	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
 )
 
@@ -27,6 +29,10 @@ type auditEntryDoc struct {
 	// unmarshaled via time.Time::UnmarshalText.
 	Timestamp string `bson:"timestamp"`
 
+	// Time is the Timestamp in nanoseconds since the Unix epoch. It
+	// is stored so that entries can be queried and sorted by time.
+	Time int64 `bson:"time"`
+
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
 	RemoteAddress string `bson:"remote-address"`
@@ -76,6 +82,7 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
 		Timestamp:         string(timeAsBlob),
+		Time:              auditEntry.Timestamp.UnixNano(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
@@ -83,3 +90,80 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// FindDocsFn is the signature of a function which reads the documents
+// matching selector from the named collection into result, sorted by
+// the given field and limited to limit documents if non-zero.
+type FindDocsFn func(collectionName string, selector bson.D, sort string, limit int, result interface{}) error
+
+// AuditEntriesFn creates a closure which when passed a Query will
+// return the matching entries from the audit collection, oldest first.
+func AuditEntriesFn(
+	collectionName string,
+	findDocs FindDocsFn,
+) func(audit.Query) ([]audit.AuditEntry, error) {
+	return func(query audit.Query) ([]audit.AuditEntry, error) {
+		if err := query.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Read the most recent entries first so that the limit
+		// applies to them, then reverse them into time order.
+		var docs []auditEntryDoc
+		if err := findDocs(collectionName, querySelector(query), "-time", query.Limit, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, len(docs))
+		for i, doc := range docs {
+			entry, err := auditEntryFromAuditEntryDoc(doc)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			entries[len(docs)-1-i] = entry
+		}
+		return entries, nil
+	}
+}
+
+func querySelector(query audit.Query) bson.D {
+	var selector bson.D
+	if query.ModelUUID != "" {
+		selector = append(selector, bson.DocElem{"model-uuid", query.ModelUUID})
+	}
+	if query.OriginType != "" {
+		selector = append(selector, bson.DocElem{"origin-type", query.OriginType})
+	}
+	if query.OriginName != "" {
+		selector = append(selector, bson.DocElem{"origin-name", query.OriginName})
+	}
+	if query.Operation != "" {
+		selector = append(selector, bson.DocElem{"operation", query.Operation})
+	}
+	var timeRange bson.D
+	if !query.After.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", query.After.UnixNano()})
+	}
+	if !query.Before.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lt", query.Before.UnixNano()})
+	}
+	if len(timeRange) > 0 {
+		selector = append(selector, bson.DocElem{"time", timeRange})
+	}
+	return selector
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotate(err, "cannot parse audit entry timestamp")
+	}
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -58,6 +60,7 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
 			"timestamp":           string(requestedTimeBlob),
+			"time":                requested.Timestamp.UnixNano(),
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
@@ -111,3 +114,93 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestAuditEntries_RoundTrip(c *gc.C) {
+	stored := audit.AuditEntry{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         utils.MustNewUUID().String(),
+		Timestamp:         coretesting.NonZeroTime().UTC(),
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "user",
+		OriginName:        "bob",
+		Operation:         "status",
+		Data: map[string]interface{}{
+			"$a.b": "c",
+		},
+	}
+	var inserted []interface{}
+	insertDocs := func(_ string, docs ...interface{}) error {
+		inserted = append(inserted, docs...)
+		return nil
+	}
+	err := stateaudit.PutAuditEntryFn("audit.log", insertDocs)(stored)
+	c.Assert(err, jc.ErrorIsNil)
+
+	findDocs := func(collectionName string, selector bson.D, sort string, limit int, result interface{}) error {
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(selector, gc.HasLen, 0)
+		c.Check(sort, gc.Equals, "-time")
+		c.Check(limit, gc.Equals, 0)
+		data, err := bson.Marshal(bson.M{"docs": inserted})
+		c.Assert(err, jc.ErrorIsNil)
+		var raw struct {
+			Docs bson.Raw `bson:"docs"`
+		}
+		c.Assert(bson.Unmarshal(data, &raw), jc.ErrorIsNil)
+		return raw.Docs.Unmarshal(result)
+	}
+	entries, err := stateaudit.AuditEntriesFn("audit.log", findDocs)(audit.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Timestamp.Equal(stored.Timestamp), jc.IsTrue)
+	entries[0].Timestamp = stored.Timestamp
+	c.Check(entries[0], jc.DeepEquals, stored)
+}
+
+func (*AuditSuite) TestAuditEntries_Selector(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	after := coretesting.NonZeroTime().UTC()
+	before := after.Add(time.Hour)
+
+	var called bool
+	findDocs := func(_ string, selector bson.D, sort string, limit int, _ interface{}) error {
+		called = true
+		c.Check(selector, jc.DeepEquals, bson.D{
+			{"model-uuid", modelUUID},
+			{"origin-type", "API request"},
+			{"origin-name", "user-bob"},
+			{"operation", "ModelManager.DestroyModel"},
+			{"time", bson.D{
+				{"$gte", after.UnixNano()},
+				{"$lt", before.UnixNano()},
+			}},
+		})
+		c.Check(limit, gc.Equals, 10)
+		return nil
+	}
+	entries, err := stateaudit.AuditEntriesFn("audit.log", findDocs)(audit.Query{
+		ModelUUID:  modelUUID,
+		OriginType: "API request",
+		OriginName: "user-bob",
+		Operation:  "ModelManager.DestroyModel",
+		After:      after,
+		Before:     before,
+		Limit:      10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+	c.Check(called, jc.IsTrue)
+}
+
+func (*AuditSuite) TestAuditEntries_ValidateQuery(c *gc.C) {
+	_, err := stateaudit.AuditEntriesFn("audit.log", nil)(audit.Query{Limit: -1})
+	c.Check(err, gc.ErrorMatches, "negative Limit not valid")
+}
+
+func (*AuditSuite) TestAuditEntries_PropagatesReadError(c *gc.C) {
+	findDocs := func(string, bson.D, string, int, interface{}) error {
+		return errors.New("my error")
+	}
+	_, err := stateaudit.AuditEntriesFn("audit.log", findDocs)(audit.Query{})
+	c.Check(err, gc.ErrorMatches, "my error")
+}
@@ -250,6 +250,40 @@ func (logger *DbLogger) Close() {
 	}
 }
 
+// ImportLogs writes the supplied log records to the database, for the
+// model associated with st. The entity, version and timestamp of each
+// record are preserved; the record's ID and model UUID are ignored.
+func ImportLogs(st ModelSessioner, records []*LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	modelUUID := st.ModelUUID()
+	docs := make([]interface{}, len(records))
+	for i, rec := range records {
+		if rec.Entity == nil {
+			return errors.NotValidf("log record %d without entity", i)
+		}
+		docs[i] = &logDoc{
+			Id:        bson.NewObjectId(),
+			Time:      rec.Time.UnixNano(),
+			ModelUUID: modelUUID,
+			Entity:    rec.Entity.String(),
+			Version:   rec.Version.String(),
+			Module:    rec.Module,
+			Location:  rec.Location,
+			Level:     int(rec.Level),
+			Message:   rec.Message,
+		}
+	}
+	if err := logsColl.Insert(docs...); err != nil {
+		return errors.Annotate(err, "inserting log records")
+	}
+	return nil
+}
+
 // LogTailer allows for retrieval of Juju's logs from MongoDB. It
 // first returns any matching already recorded logs and then waits for
 // additional matching logs as they appear.
@@ -293,18 +327,57 @@ type LogRecord struct {
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
+//
+// The entity filters accept entity tags, optionally with '*' wildcards.
+// The label filters accept application names, machine ids (matching
+// the machine's containers too) and unit names, and select the agents
+// associated with them. Entity and label filters are logically ORed
+// together; all other filters are logically ANDed.
+//
+// If EndTime is set, the LogTailer stops once the matching logs which
+// have already been recorded have been returned, as for NoTail.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
-	MinLevel      loggo.Level
-	InitialLines  int
-	NoTail        bool
-	IncludeEntity []string
-	ExcludeEntity []string
-	IncludeModule []string
-	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
-	AllModels     bool
+	StartID            int64
+	StartTime          time.Time
+	EndTime            time.Time
+	MinLevel           loggo.Level
+	InitialLines       int
+	NoTail             bool
+	IncludeEntity      []string
+	ExcludeEntity      []string
+	IncludeModule      []string
+	ExcludeModule      []string
+	IncludeApplication []string
+	ExcludeApplication []string
+	IncludeMachine     []string
+	ExcludeMachine     []string
+	IncludeUnit        []string
+	ExcludeUnit        []string
+	MessageRegex       string
+	Oplog              *mgo.Collection // For testing only
+	AllModels          bool
+}
+
+func (params *LogTailerParams) validateLabels() error {
+	checks := []struct {
+		values [][]string
+		valid  func(string) bool
+		what   string
+	}{
+		{[][]string{params.IncludeApplication, params.ExcludeApplication}, names.IsValidApplication, "application name"},
+		{[][]string{params.IncludeMachine, params.ExcludeMachine}, names.IsValidMachine, "machine id"},
+		{[][]string{params.IncludeUnit, params.ExcludeUnit}, names.IsValidUnit, "unit name"},
+	}
+	for _, check := range checks {
+		for _, values := range check.values {
+			for _, value := range values {
+				if !check.valid(value) {
+					return errors.NotValidf("%s %q", check.what, value)
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -339,6 +412,14 @@ func NewLogTailer(st LogTailerState, params *LogTailerParams) (LogTailer, error)
 	if !st.IsController() && params.AllModels {
 		return nil, errors.NewNotValid(nil, "not allowed to tail logs from all models: not a controller")
 	}
+	if err := params.validateLabels(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if params.MessageRegex != "" {
+		if _, err := regexp.Compile(params.MessageRegex); err != nil {
+			return nil, errors.NewNotValid(err, "invalid message regex")
+		}
+	}
 
 	session := st.MongoSession().Copy()
 	t := &logTailer{
@@ -398,7 +479,7 @@ func (t *logTailer) loop() error {
 		return errors.Trace(err)
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
@@ -515,8 +596,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lt"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -524,13 +612,19 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
 	}
-	if len(params.IncludeEntity) > 0 {
+	includeEntity := append(makeEntityPatterns(params.IncludeEntity), makeLabelPatterns(
+		params.IncludeApplication, params.IncludeMachine, params.IncludeUnit,
+	)...)
+	if len(includeEntity) > 0 {
 		sel = append(sel,
-			bson.DocElem{"n", bson.RegEx{Pattern: makeEntityPattern(params.IncludeEntity)}})
+			bson.DocElem{"n", bson.RegEx{Pattern: joinPatterns(includeEntity)}})
 	}
-	if len(params.ExcludeEntity) > 0 {
+	excludeEntity := append(makeEntityPatterns(params.ExcludeEntity), makeLabelPatterns(
+		params.ExcludeApplication, params.ExcludeMachine, params.ExcludeUnit,
+	)...)
+	if len(excludeEntity) > 0 {
 		sel = append(sel,
-			bson.DocElem{"n", bson.M{"$not": bson.RegEx{Pattern: makeEntityPattern(params.ExcludeEntity)}}})
+			bson.DocElem{"n", bson.M{"$not": bson.RegEx{Pattern: joinPatterns(excludeEntity)}}})
 	}
 	if len(params.IncludeModule) > 0 {
 		sel = append(sel,
@@ -540,6 +634,9 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -548,13 +645,35 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 	return sel
 }
 
-func makeEntityPattern(entities []string) string {
+func makeEntityPatterns(entities []string) []string {
 	var patterns []string
 	for _, entity := range entities {
 		// Convert * wildcard to the regex equivalent. This is safe
 		// because * never appears in entity names.
 		patterns = append(patterns, strings.Replace(entity, "*", ".*", -1))
 	}
+	return patterns
+}
+
+// makeLabelPatterns returns patterns matching the entity tags of the
+// agents for the given applications, machines and units. The patterns
+// for machines also match the machines' containers.
+func makeLabelPatterns(applications, machines, units []string) []string {
+	var patterns []string
+	for _, application := range applications {
+		patterns = append(patterns, names.UnitTagKind+"-"+regexp.QuoteMeta(application)+`-[0-9]+`)
+	}
+	for _, machine := range machines {
+		tag := names.NewMachineTag(machine).String()
+		patterns = append(patterns, regexp.QuoteMeta(tag)+`(-.+)?`)
+	}
+	for _, unit := range units {
+		patterns = append(patterns, regexp.QuoteMeta(names.NewUnitTag(unit).String()))
+	}
+	return patterns
+}
+
+func joinPatterns(patterns []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)$`
 }
 
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -194,6 +195,56 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
 }
 
+func (s *LogsSuite) TestImportLogs(c *gc.C) {
+	t0 := coretesting.ZeroTime().Truncate(time.Millisecond)
+	err := state.ImportLogs(s.State, []*state.LogRecord{{
+		ID:        99,
+		Time:      t0,
+		ModelUUID: "some-other-model",
+		Entity:    names.NewUnitTag("mysql/0"),
+		Version:   version.MustParse("1.2.3"),
+		Level:     loggo.WARNING,
+		Module:    "some.where",
+		Location:  "foo.go:99",
+		Message:   "all is well",
+	}, {
+		Time:    t0.Add(time.Second),
+		Entity:  names.NewMachineTag("0"),
+		Level:   loggo.ERROR,
+		Module:  "else.where",
+		Message: "oh noes",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+
+	c.Assert(docs[0]["t"], gc.Equals, t0.UnixNano())
+	c.Assert(docs[0]["e"], gc.Equals, s.State.ModelUUID())
+	c.Assert(docs[0]["n"], gc.Equals, "unit-mysql-0")
+	c.Assert(docs[0]["r"], gc.Equals, "1.2.3")
+	c.Assert(docs[0]["m"], gc.Equals, "some.where")
+	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
+	c.Assert(docs[0]["v"], gc.Equals, int(loggo.WARNING))
+	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+
+	c.Assert(docs[1]["t"], gc.Equals, t0.Add(time.Second).UnixNano())
+	c.Assert(docs[1]["e"], gc.Equals, s.State.ModelUUID())
+	c.Assert(docs[1]["n"], gc.Equals, "machine-0")
+	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+}
+
+func (s *LogsSuite) TestImportLogsWithoutEntity(c *gc.C) {
+	err := state.ImportLogs(s.State, []*state.LogRecord{{
+		Time:    coretesting.ZeroTime(),
+		Message: "who said that?",
+	}})
+	c.Assert(err, gc.ErrorMatches, "log record 0 without entity not valid")
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 0)
+}
+
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"), jujuversion.Current)
 	defer dbLogger.Close()
@@ -659,6 +710,130 @@ func (s *LogTailerSuite) TestExcludeEntityWildcard(c *gc.C) {
 	}
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	s.writeLogsT(c,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT, threshT.Add(5*time.Second), 5, want)
+	s.writeLogsT(c,
+		threshT.Add(5*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(5 * time.Second),
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The tailer stops once the time range has been read.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestMessageRegex(c *gc.C) {
+	connected := logTemplate{Message: "connected to 10.0.0.1"}
+	failed := logTemplate{Message: "connection failed"}
+	writeLogs := func() {
+		s.writeLogs(c, 2, connected)
+		s.writeLogs(c, 3, failed)
+		s.writeLogs(c, 1, connected)
+	}
+	params := &state.LogTailerParams{
+		MessageRegex: `^connected to [0-9.]+$`,
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 3, connected)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeLabels(c *gc.C) {
+	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
+	machine1 := logTemplate{Entity: names.NewMachineTag("1")}
+	container := logTemplate{Entity: names.NewMachineTag("1/lxd/0")}
+	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
+	foobar0 := logTemplate{Entity: names.NewUnitTag("foo-bar/0")}
+	baz3 := logTemplate{Entity: names.NewUnitTag("baz/3")}
+	writeLogs := func() {
+		s.writeLogs(c, 1, machine0)
+		s.writeLogs(c, 2, foo0)
+		s.writeLogs(c, 1, foobar0)
+		s.writeLogs(c, 1, machine1)
+		s.writeLogs(c, 2, container)
+		s.writeLogs(c, 1, baz3)
+	}
+	params := &state.LogTailerParams{
+		IncludeApplication: []string{"foo"},
+		IncludeMachine:     []string{"1"},
+		IncludeUnit:        []string{"baz/3"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, foo0)
+		s.assertTailer(c, tailer, 1, machine1)
+		s.assertTailer(c, tailer, 2, container)
+		s.assertTailer(c, tailer, 1, baz3)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeLabels(c *gc.C) {
+	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
+	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
+	foo1 := logTemplate{Entity: names.NewUnitTag("foo/1")}
+	bar0 := logTemplate{Entity: names.NewUnitTag("bar/0")}
+	writeLogs := func() {
+		s.writeLogs(c, 3, machine0)
+		s.writeLogs(c, 2, foo0)
+		s.writeLogs(c, 1, foo1)
+		s.writeLogs(c, 2, bar0)
+	}
+	params := &state.LogTailerParams{
+		ExcludeMachine: []string{"0"},
+		ExcludeUnit:    []string{"foo/0"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, foo1)
+		s.assertTailer(c, tailer, 2, bar0)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInvalidFilters(c *gc.C) {
+	for _, test := range []struct {
+		params state.LogTailerParams
+		err    string
+	}{{
+		params: state.LogTailerParams{IncludeApplication: []string{"Foo"}},
+		err:    `application name "Foo" not valid`,
+	}, {
+		params: state.LogTailerParams{ExcludeMachine: []string{"machine-0"}},
+		err:    `machine id "machine-0" not valid`,
+	}, {
+		params: state.LogTailerParams{IncludeUnit: []string{"foo"}},
+		err:    `unit name "foo" not valid`,
+	}, {
+		params: state.LogTailerParams{MessageRegex: "("},
+		err:    `invalid message regex: error parsing regexp: .*`,
+	}} {
+		_, err := state.NewLogTailer(s.otherState, &test.params)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
 func (s *LogTailerSuite) TestIncludeModule(c *gc.C) {
 	mod0 := logTemplate{Module: "foo.bar"}
 	mod1 := logTemplate{Module: "juju.thing"}
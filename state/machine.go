@@ -1686,6 +1686,11 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return m.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout is part of the ActionReceiver interface.
+func (m *Machine) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
@@ -1700,7 +1705,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 	if err != nil {
 		return nil, err
 	}
-	return m.st.EnqueueAction(m.Tag(), name, payloadWithDefaults)
+	return m.st.enqueueAction(m.Tag(), name, payloadWithDefaults, actionOptions{timeout: timeout})
 }
 
 // CancelAction is part of the ActionReceiver interface.
@@ -48,6 +48,9 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.readAllConstraints(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.readAllEndpointBindings(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	modelConfig, found := export.modelSettings[modelGlobalKey]
 	if !found {
@@ -117,6 +120,10 @@ func (st *State) Export() (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if err := export.actionSchedules(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if err := export.cloudimagemetadata(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -138,6 +145,7 @@ type exporter struct {
 
 	annotations             map[string]annotatorDoc
 	constraints             map[string]bson.M
+	endpointBindings        map[string]bindingsMap
 	modelSettings           map[string]settingsDoc
 	modelStorageConstraints map[string]storageConstraintsDoc
 	status                  map[string]bson.M
@@ -574,6 +582,8 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedCIDRs:         application.doc.ExposedCIDRs,
+		EgressRules:          application.doc.EgressRules,
 		MinUnits:             application.doc.MinUnits,
 		Settings:             applicationSettingsDoc.Settings,
 		Leader:               ctx.leader,
@@ -583,9 +593,12 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	if constraints, found := e.modelStorageConstraints[storageConstraintsKey]; found {
 		args.StorageConstraints = e.storageConstraints(constraints)
 	}
+	globalKey := application.globalKey()
+	if bindings, found := e.endpointBindings[globalKey]; found {
+		args.EndpointBindings = bindings
+	}
 	exApplication := e.model.AddApplication(args)
 	// Find the current application status.
-	globalKey := application.globalKey()
 	statusArgs, err := e.statusArgs(globalKey)
 	if err != nil {
 		return errors.Annotatef(err, "status for application %s", appName)
@@ -892,6 +905,30 @@ func (e *exporter) actions() error {
 			Results:    results,
 			Message:    message,
 			Id:         action.Id(),
+			Parallel:   action.Parallel(),
+			Timeout:    action.Timeout(),
+			Schedule:   action.Schedule(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) actionSchedules() error {
+	schedules, err := e.st.ActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d action schedules", len(schedules))
+	for _, schedule := range schedules {
+		e.model.AddActionSchedule(description.ActionScheduleArgs{
+			Id:          schedule.Id(),
+			Application: schedule.Application(),
+			Name:        schedule.Name(),
+			Parameters:  schedule.Parameters(),
+			Schedule:    schedule.Schedule(),
+			Created:     schedule.Created(),
+			NextRun:     schedule.NextRun(),
+			LastRun:     schedule.LastRun(),
 		})
 	}
 	return nil
@@ -1010,6 +1047,23 @@ func (e *exporter) readAllConstraints() error {
 	return nil
 }
 
+func (e *exporter) readAllEndpointBindings() error {
+	bindings, closer := e.st.getCollection(endpointBindingsC)
+	defer closer()
+
+	var docs []endpointBindingsDoc
+	if err := bindings.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read endpoint bindings collection")
+	}
+
+	e.logger.Debugf("read %d endpoint bindings docs", len(docs))
+	e.endpointBindings = make(map[string]bindingsMap)
+	for _, doc := range docs {
+		e.endpointBindings[e.st.localID(doc.DocID)] = doc.Bindings
+	}
+	return nil
+}
+
 // getAnnotations doesn't really care if there are any there or not
 // for the key, but if they were there, they are removed so we can
 // check at the end of the export for anything we have forgotten.
@@ -1252,9 +1306,17 @@ func (e *exporter) volumes() error {
 }
 
 func (e *exporter) addVolume(vol *volume, volAttachments []volumeAttachmentDoc) error {
+	if vol.doc.EncryptionKey != "" {
+		// Encryption keys never leave the controller, so the
+		// contents of the volume could not be read after the
+		// model is imported elsewhere.
+		return errors.NotSupportedf("exporting volume %s with encrypted contents", vol.doc.Name)
+	}
 	args := description.VolumeArgs{
-		Tag:     vol.VolumeTag(),
-		Binding: vol.LifeBinding(),
+		Tag:       vol.VolumeTag(),
+		Binding:   vol.LifeBinding(),
+		Releasing: vol.Releasing(),
+		Encrypted: vol.encrypted(),
 	}
 	if tag, err := vol.StorageInstance(); err == nil {
 		// only returns an error when no storage tag.
@@ -1367,10 +1429,11 @@ func (e *exporter) addFilesystem(fs *filesystem, fsAttachments []filesystemAttac
 	storage, _ := fs.Storage()
 	volume, _ := fs.Volume()
 	args := description.FilesystemArgs{
-		Tag:     fs.FilesystemTag(),
-		Storage: storage,
-		Volume:  volume,
-		Binding: fs.LifeBinding(),
+		Tag:       fs.FilesystemTag(),
+		Storage:   storage,
+		Volume:    volume,
+		Binding:   fs.LifeBinding(),
+		Releasing: fs.Releasing(),
 	}
 	logger.Debugf("addFilesystem: %#v", fs.doc)
 	if info, err := fs.Info(); err == nil {
@@ -1469,10 +1532,12 @@ func (e *exporter) addStorage(instance *storageInstance, attachments []names.Uni
 	args := description.StorageArgs{
 		Tag:         instance.StorageTag(),
 		Kind:        instance.Kind().String(),
-		Owner:       instance.Owner(),
 		Name:        instance.StorageName(),
 		Attachments: attachments,
 	}
+	if owner, ok := instance.Owner(); ok {
+		args.Owner = owner
+	}
 	e.model.AddStorage(args)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -365,6 +366,10 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 	})
 	c.Assert(exported.MetricsCredentials(), jc.DeepEquals, []byte("sekrit"))
 
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exported.EndpointBindings(), jc.DeepEquals, bindings)
+
 	constraints := exported.Constraints()
 	c.Assert(constraints, gc.NotNil)
 	c.Assert(constraints.Architecture(), gc.Equals, *cons.Arch)
@@ -735,6 +740,32 @@ func (s *MigrationExportSuite) TestActions(c *gc.C) {
 	c.Check(action.Message(), gc.Equals, "")
 }
 
+func (s *MigrationExportSuite) TestActionSchedules(c *gc.C) {
+	state.AddTestingService(c, s.State, "dummy", state.AddTestingCharm(c, s.State, "dummy"))
+	added, err := s.State.AddActionSchedule(state.AddActionScheduleArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		Parameters:  map[string]interface{}{"outfile": "out.bz2"},
+		Schedule:    "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules := model.ActionSchedules()
+	c.Assert(schedules, gc.HasLen, 1)
+	schedule := schedules[0]
+	c.Check(schedule.Id(), gc.Equals, added.Id())
+	c.Check(schedule.Application(), gc.Equals, "dummy")
+	c.Check(schedule.Name(), gc.Equals, "snapshot")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Check(schedule.Schedule(), gc.Equals, "@daily")
+	c.Check(schedule.Created(), gc.Equals, added.Created())
+	c.Check(schedule.NextRun(), gc.Equals, added.NextRun())
+	c.Check(schedule.LastRun().IsZero(), jc.IsTrue)
+}
+
 type goodToken struct{}
 
 // Check implements leadership.Token
@@ -788,6 +819,7 @@ func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
 	c.Check(provisioned.HardwareID(), gc.Equals, "magic")
 	c.Check(provisioned.VolumeID(), gc.Equals, "volume id")
 	c.Check(provisioned.Persistent(), jc.IsTrue)
+	c.Check(provisioned.Encrypted(), jc.IsFalse)
 	attachments := provisioned.Attachments()
 	c.Assert(attachments, gc.HasLen, 1)
 	attachment := attachments[0]
@@ -808,6 +840,7 @@ func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
 	c.Check(notProvisioned.HardwareID(), gc.Equals, "")
 	c.Check(notProvisioned.VolumeID(), gc.Equals, "")
 	c.Check(notProvisioned.Persistent(), jc.IsFalse)
+	c.Check(notProvisioned.Encrypted(), jc.IsFalse)
 	attachments = notProvisioned.Attachments()
 	c.Assert(attachments, gc.HasLen, 1)
 	attachment = attachments[0]
@@ -823,6 +856,35 @@ func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
 	c.Check(status.Value(), gc.Equals, "pending")
 }
 
+func (s *MigrationExportSuite) TestVolumesEncrypted(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State), dummy.StorageProviders())
+	_, err := pm.Create("encrypted-loop", provider.LoopProviderType, map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "encrypted-loop", Size: 1234},
+		}},
+	})
+
+	// No key has been generated yet, so the volume
+	// can be created encrypted after migration.
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	c.Check(volumes[0].Encrypted(), jc.IsTrue)
+
+	// Once a key has been generated, the volume's contents
+	// cannot be read without it, and the key is never exported.
+	_, err = s.State.VolumeEncryptionKey(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, ".*exporting volume 0/0 with encrypted contents not supported")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MigrationExportSuite) TestFilesystems(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Filesystems: []state.MachineFilesystemParams{{
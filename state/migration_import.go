@@ -115,6 +115,9 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.applications(); err != nil {
 		return nil, nil, errors.Annotate(err, "applications")
 	}
+	if err := restore.actionSchedules(); err != nil {
+		return nil, nil, errors.Annotate(err, "action schedules")
+	}
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
@@ -822,6 +825,8 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
+		EgressRules:          s.EgressRules(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
@@ -1230,6 +1235,9 @@ func (i *importer) addAction(action description.Action) error {
 		Started:    action.Started(),
 		Completed:  action.Completed(),
 		Status:     ActionStatus(action.Status()),
+		Parallel:   action.Parallel(),
+		Timeout:    action.Timeout(),
+		Schedule:   action.Schedule(),
 	}
 	prefix := ensureActionMarker(action.Receiver())
 	notificationDoc := &actionNotificationDoc{
@@ -1254,6 +1262,39 @@ func (i *importer) addAction(action description.Action) error {
 	return nil
 }
 
+func (i *importer) actionSchedules() error {
+	i.logger.Debugf("importing action schedules")
+	var ops []txn.Op
+	for _, schedule := range i.model.ActionSchedules() {
+		docID := i.st.docID(schedule.Id())
+		ops = append(ops, txn.Op{
+			C:      actionSchedulesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &actionScheduleDoc{
+				DocId:       docID,
+				Id:          schedule.Id(),
+				ModelUUID:   i.st.ModelUUID(),
+				Application: schedule.Application(),
+				Name:        schedule.Name(),
+				Parameters:  schedule.Parameters(),
+				Schedule:    schedule.Schedule(),
+				Created:     schedule.Created(),
+				NextRun:     schedule.NextRun(),
+				LastRun:     schedule.LastRun(),
+			},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing action schedules succeeded")
+	return nil
+}
+
 func (i *importer) importStatusHistory(globalKey string, history []description.Status) error {
 	docs := make([]interface{}, len(history))
 	for i, statusVal := range history {
@@ -1364,7 +1405,6 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 	doc := &storageInstanceDoc{
 		Id:              storage.Tag().Id(),
 		Kind:            kind,
-		Owner:           owner.String(),
 		StorageName:     storage.Name(),
 		AttachmentCount: len(attachments),
 	}
@@ -1375,14 +1415,18 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 		Insert: doc,
 	})
 
-	refcounts, closer := i.st.getCollection(refcountsC)
-	defer closer()
-	storageRefcountKey := entityStorageRefcountKey(owner, storage.Name())
-	incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, 1)
-	if err != nil {
-		return errors.Trace(err)
+	// Detached storage has no owner, and so is not refcounted.
+	if owner != nil {
+		doc.Owner = owner.String()
+		refcounts, closer := i.st.getCollection(refcountsC)
+		defer closer()
+		storageRefcountKey := entityStorageRefcountKey(owner, storage.Name())
+		incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, 1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
 	}
-	ops = append(ops, incRefOp)
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -1424,11 +1468,13 @@ func (i *importer) addVolume(volume description.Volume) error {
 			Pool:       volume.Pool(),
 			VolumeId:   volume.VolumeID(),
 			Persistent: volume.Persistent(),
+			Encrypted:  volume.Encrypted(),
 		}
 	} else {
 		params = &VolumeParams{
-			Size: volume.Size(),
-			Pool: volume.Pool(),
+			Size:      volume.Size(),
+			Pool:      volume.Pool(),
+			Encrypted: volume.Encrypted(),
 		}
 	}
 	doc := volumeDoc{
@@ -1439,6 +1485,7 @@ func (i *importer) addVolume(volume description.Volume) error {
 		Params:          params,
 		Info:            info,
 		AttachmentCount: len(attachments),
+		Releasing:       volume.Releasing(),
 	}
 	status := i.makeStatusDoc(volume.Status())
 	ops := i.st.newVolumeOps(doc, status)
@@ -1535,6 +1582,7 @@ func (i *importer) addFilesystem(filesystem description.Filesystem) error {
 		Params:          params,
 		Info:            info,
 		AttachmentCount: len(attachments),
+		Releasing:       filesystem.Releasing(),
 	}
 	status := i.makeStatusDoc(filesystem.Status())
 	ops := i.st.newFilesystemOps(doc, status)
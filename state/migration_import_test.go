@@ -14,6 +14,8 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
@@ -327,7 +329,11 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the application.
-	c.Assert(application.SetExposed(), jc.ErrorIsNil)
+	c.Assert(application.SetExposedCIDRs([]string{"10.0.0.0/8"}), jc.ErrorIsNil)
+	err = application.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.Active, 5)
@@ -348,6 +354,12 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedCIDRs(), jc.DeepEquals, exported.ExposedCIDRs())
+	exportedEgress, err := exported.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	importedEgress, err := imported.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedEgress, jc.DeepEquals, exportedEgress)
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -767,6 +779,67 @@ func (s *MigrationImportSuite) TestAction(c *gc.C) {
 	c.Check(action.Status(), gc.Equals, state.ActionPending)
 }
 
+func (s *MigrationImportSuite) TestActionSchedules(c *gc.C) {
+	state.AddTestingService(c, s.State, "dummy", state.AddTestingCharm(c, s.State, "dummy"))
+	added, err := s.State.AddActionSchedule(state.AddActionScheduleArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		Parameters:  map[string]interface{}{"outfile": "out.bz2"},
+		Schedule:    "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer func() {
+		c.Assert(newSt.Close(), jc.ErrorIsNil)
+	}()
+
+	schedules, err := newSt.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	schedule := schedules[0]
+	c.Check(schedule.Id(), gc.Equals, added.Id())
+	c.Check(schedule.Application(), gc.Equals, "dummy")
+	c.Check(schedule.Name(), gc.Equals, "snapshot")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Check(schedule.Schedule(), gc.Equals, "@daily")
+	c.Check(schedule.NextRun().UTC(), gc.Equals, added.NextRun().UTC())
+
+	// The schedule sequence is migrated too, so new schedules
+	// do not reuse the imported ids.
+	next, err := newSt.AddActionSchedule(state.AddActionScheduleArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		Parameters:  map[string]interface{}{"outfile": "out.bz2"},
+		Schedule:    "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.Id(), gc.Not(gc.Equals), added.Id())
+}
+
+func (s *MigrationImportSuite) TestVolumeReleasing(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Size: 1234},
+		}},
+	})
+	volTag := names.NewVolumeTag("0/0")
+	// There is no way of releasing a volume that is not assigned
+	// to a storage instance, so mark it as releasing directly.
+	err := state.RunTransaction(s.State, []txn.Op{{
+		C:      state.VolumesC,
+		Id:     state.DocID(s.State, volTag.Id()),
+		Update: bson.D{{"$set", bson.D{{"releasing", true}}}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	volume, err := newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volume.Releasing(), jc.IsTrue)
+}
+
 func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Volumes: []state.MachineVolumeParams{{
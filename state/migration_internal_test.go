@@ -61,6 +61,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// actions
 		actionsC,
+		actionSchedulesC,
 
 		// storage
 		filesystemsC,
@@ -170,6 +171,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		"resources",
 		endpointBindingsC,
 
+		// cross-model relations
+		applicationOffersC,
+		remoteApplicationsC,
+		relationNetworksC,
+
 		// uncategorised
 		metricsManagerC, // should really be copied across
 		auditingC,
@@ -343,6 +349,8 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedCIDRs",
+		"EgressRules",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -651,15 +659,51 @@ func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 		"Results",
 		"Message",
 		"Status",
+		"Parallel",
+		"Timeout",
+		"Schedule",
 	)
 	s.AssertExportedFields(c, actionDoc{}, migrated.Union(ignored))
 }
 
+func (s *MigrationSuite) TestActionScheduleDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		// DocId is recreated from the Id.
+		"DocId",
+	)
+	migrated := set.NewStrings(
+		"Id",
+		"Application",
+		"Name",
+		"Parameters",
+		"Schedule",
+		"Created",
+		"NextRun",
+		"LastRun",
+	)
+	s.AssertExportedFields(c, actionScheduleDoc{}, migrated.Union(ignored))
+}
+
 func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
 		"DocID",
 		"Life",
+		// A pending resize is requested again by the user if
+		// it has not completed before the migration.
+		"PendingSize",
+		// Usage is reported again by machine agents after
+		// the migration.
+		"Usage",
+		// Encryption keys never leave the controller; volumes
+		// with keys are refused by the export.
+		"EncryptionKey",
+		// A pending snapshot is requested again by the user if
+		// it has not been taken before the migration, and the
+		// snapshots are recorded again when it is.
+		"PendingSnapshot",
+		"Snapshots",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -668,13 +712,14 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"Binding",
 		"Info",
 		"Params",
+		"Releasing",
 	)
 	s.AssertExportedFields(c, volumeDoc{}, migrated.Union(ignored))
 	// The info and params fields ar structs.
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
-		"HardwareId", "Size", "Pool", "VolumeId", "Persistent"))
+		"HardwareId", "Size", "Pool", "VolumeId", "Persistent", "Encrypted"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "Encrypted"))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -702,6 +747,12 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
+		// Usage is reported again by machine agents after
+		// the migration.
+		"Usage",
+		// A filesystem with a resize pending is grown when
+		// its backing volume is next resized.
+		"PendingSize",
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
@@ -711,6 +762,7 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"Binding",
 		"Info",
 		"Params",
+		"Releasing",
 	)
 	s.AssertExportedFields(c, filesystemDoc{}, migrated.Union(ignored))
 	// The info and params fields ar structs.
@@ -60,6 +60,15 @@ type ModelMigration interface {
 	// last changed.
 	PhaseChangedTime() time.Time
 
+	// PhaseTimes returns the time at which the migration entered
+	// each of the phases it has passed through so far.
+	PhaseTimes() map[migration.Phase]time.Time
+
+	// TransferredBytes returns the number of bytes of each kind of
+	// binary (e.g. "charms" or "tools") which have been sent to the
+	// target controller so far.
+	TransferredBytes() map[string]int64
+
 	// StatusMessage returns human readable text about the current
 	// progress of the migration.
 	StatusMessage() string
@@ -80,6 +89,10 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// AddTransferredBytes records that a further count bytes of the
+	// given kind of binary have been sent to the target controller.
+	AddTransferredBytes(kind string, count int64) error
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -195,6 +208,14 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// PhaseTimes holds the time that the migration entered each
+	// phase (stored as per UnixNano), keyed by phase name.
+	PhaseTimes map[string]int64 `bson:"phase-times,omitempty"`
+
+	// TransferredBytes holds the number of bytes sent to the target
+	// controller, keyed by the kind of binary transferred.
+	TransferredBytes map[string]int64 `bson:"transferred-bytes,omitempty"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -260,6 +281,31 @@ func (mig *modelMigration) PhaseChangedTime() time.Time {
 	return unixNanoToTime0(mig.statusDoc.PhaseChangedTime)
 }
 
+// PhaseTimes implements ModelMigration.
+func (mig *modelMigration) PhaseTimes() map[migration.Phase]time.Time {
+	result := make(map[migration.Phase]time.Time)
+	for name, t := range mig.statusDoc.PhaseTimes {
+		if phase, ok := migration.ParsePhase(name); ok {
+			result[phase] = unixNanoToTime0(t)
+		}
+	}
+	if _, ok := result[migration.QUIESCE]; !ok && mig.statusDoc.StartTime != 0 {
+		// Migrations created before phase times were recorded
+		// still know when they started.
+		result[migration.QUIESCE] = unixNanoToTime0(mig.statusDoc.StartTime)
+	}
+	return result
+}
+
+// TransferredBytes implements ModelMigration.
+func (mig *modelMigration) TransferredBytes() map[string]int64 {
+	result := make(map[string]int64)
+	for kind, count := range mig.statusDoc.TransferredBytes {
+		result[kind] = count
+	}
+	return result
+}
+
 // StatusMessage implements ModelMigration.
 func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
@@ -309,9 +355,15 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	nextDoc := mig.statusDoc
 	nextDoc.Phase = nextPhase.String()
 	nextDoc.PhaseChangedTime = now
+	nextDoc.PhaseTimes = make(map[string]int64)
+	for name, t := range mig.statusDoc.PhaseTimes {
+		nextDoc.PhaseTimes[name] = t
+	}
+	nextDoc.PhaseTimes[nextDoc.Phase] = now
 	update := bson.M{
-		"phase":              nextDoc.Phase,
-		"phase-changed-time": now,
+		"phase":                        nextDoc.Phase,
+		"phase-changed-time":           now,
+		"phase-times." + nextDoc.Phase: now,
 	}
 	if nextPhase == migration.SUCCESS {
 		nextDoc.SuccessTime = now
@@ -377,6 +429,27 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// AddTransferredBytes implements ModelMigration.
+func (mig *modelMigration) AddTransferredBytes(kind string, count int64) error {
+	if kind == "" || strings.ContainsAny(kind, ".$") {
+		return errors.NotValidf("transfer kind %q", kind)
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$inc": bson.M{"transferred-bytes." + kind: count}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to record transferred bytes")
+	}
+	if mig.statusDoc.TransferredBytes == nil {
+		mig.statusDoc.TransferredBytes = make(map[string]int64)
+	}
+	mig.statusDoc.TransferredBytes[kind] += count
+	return nil
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -636,6 +709,9 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			Phase:            migration.QUIESCE.String(),
 			PhaseChangedTime: now,
 			StatusMessage:    "starting",
+			PhaseTimes: map[string]int64{
+				migration.QUIESCE.String(): now,
+			},
 		}
 		return []txn.Op{{
 			C:      migrationsC,
@@ -409,6 +409,33 @@ func (s *MigrationSuite) TestSuccessfulPhaseTransitions(c *gc.C) {
 	s.assertMigrationCleanedUp(c, mig)
 }
 
+func (s *MigrationSuite) TestPhaseTimes(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	startTime := s.clock.Now()
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, map[migration.Phase]time.Time{
+		migration.QUIESCE: startTime,
+	})
+
+	s.clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	importTime := s.clock.Now()
+	s.clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	abortTime := s.clock.Now()
+
+	expected := map[migration.Phase]time.Time{
+		migration.QUIESCE: startTime,
+		migration.IMPORT:  importTime,
+		migration.ABORT:   abortTime,
+	}
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, expected)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.PhaseTimes(), jc.DeepEquals, expected)
+}
+
 func (s *MigrationSuite) TestABORTCleanup(c *gc.C) {
 	mig, err := s.State2.CreateMigration(s.stdSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -501,6 +528,35 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestTransferredBytes(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.TransferredBytes(), gc.HasLen, 0)
+
+	c.Assert(mig.AddTransferredBytes("charms", 100), jc.ErrorIsNil)
+	c.Assert(mig.AddTransferredBytes("tools", 2000), jc.ErrorIsNil)
+	c.Assert(mig.AddTransferredBytes("charms", 50), jc.ErrorIsNil)
+
+	expected := map[string]int64{
+		"charms": 150,
+		"tools":  2000,
+	}
+	c.Check(mig.TransferredBytes(), jc.DeepEquals, expected)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.TransferredBytes(), jc.DeepEquals, expected)
+}
+
+func (s *MigrationSuite) TestTransferredBytesInvalidKind(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.AddTransferredBytes("char.ms", 100)
+	c.Check(err, gc.ErrorMatches, `transfer kind "char.ms" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
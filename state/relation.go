@@ -144,7 +144,7 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 		return nil, false, errAlreadyDying
 	}
 	if r.doc.UnitCount == 0 {
-		removeOps, err := r.removeOps(ignoreService, nil)
+		removeOps, err := r.removeOps(ignoreService, "")
 		if err != nil {
 			return nil, false, err
 		}
@@ -160,16 +160,16 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 
 // removeOps returns the operations necessary to remove the relation. If
 // ignoreService is not empty, no operations affecting that service will be
-// included; if departingUnit is not nil, this implies that the relation's
-// services may be Dying and otherwise unreferenced, and may thus require
-// removal themselves.
-func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.Op, error) {
+// included; if departingUnitName is not empty, this implies that the
+// relation's services may be Dying and otherwise unreferenced, and may thus
+// require removal themselves.
+func (r *Relation) removeOps(ignoreService string, departingUnitName string) ([]txn.Op, error) {
 	relOp := txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Remove: true,
 	}
-	if departingUnit != nil {
+	if departingUnitName != "" {
 		relOp.Assert = bson.D{{"life", Dying}, {"unitcount", 1}}
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
@@ -179,14 +179,24 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 		if ep.ApplicationName == ignoreService {
 			continue
 		}
+		if isRemote, err := isRemoteApplication(r.st, ep.ApplicationName); err != nil {
+			return nil, errors.Trace(err)
+		} else if isRemote {
+			remoteOps, err := r.removeRemoteEndpointOps(ep, departingUnitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, remoteOps...)
+			continue
+		}
 		var asserts bson.D
 		hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
-		if departingUnit == nil {
+		if departingUnitName == "" {
 			// We're constructing a destroy operation, either of the relation
 			// or one of its services, and can therefore be assured that both
 			// services are Alive.
 			asserts = append(hasRelation, isAliveDoc...)
-		} else if ep.ApplicationName == departingUnit.ApplicationName() {
+		} else if ep.ApplicationName == unitApplicationName(departingUnitName) {
 			// This service must have at least one unit -- the one that's
 			// departing the relation -- so it cannot be ready for removal.
 			cannotDieYet := bson.D{{"unitcount", bson.D{{"$gt", 0}}}}
@@ -224,10 +234,54 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 			Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
 		})
 	}
+	ops = append(ops, removeIngressNetworksOp(r.st, r.doc.Key))
 	cleanupOp := newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
 	return append(ops, cleanupOp), nil
 }
 
+// removeRemoteEndpointOps returns the operations necessary to release
+// the supplied remote application endpoint when removing the relation.
+// A remote application has no units of its own, so it may require
+// removal whenever it is Dying and this is its last relation.
+func (r *Relation) removeRemoteEndpointOps(ep Endpoint, departingUnitName string) ([]txn.Op, error) {
+	hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
+	if departingUnitName == "" {
+		// As above, both applications can be assumed to be Alive.
+		return []txn.Op{{
+			C:      remoteApplicationsC,
+			Id:     r.st.docID(ep.ApplicationName),
+			Assert: append(hasRelation, isAliveDoc...),
+			Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+		}}, nil
+	}
+	remoteApplications, closer := r.st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	app := &RemoteApplication{st: r.st}
+	hasLastRef := bson.D{{"life", Dying}, {"relationcount", 1}}
+	removable := append(bson.D{{"_id", ep.ApplicationName}}, hasLastRef...)
+	if err := remoteApplications.Find(removable).One(&app.doc); err == nil {
+		return app.removeOps(hasLastRef), nil
+	} else if err != mgo.ErrNotFound {
+		return nil, err
+	}
+	return []txn.Op{{
+		C:  remoteApplicationsC,
+		Id: r.st.docID(ep.ApplicationName),
+		Assert: bson.D{{"$or", []bson.D{
+			{{"life", Alive}},
+			{{"relationcount", bson.D{{"$gt", 1}}}},
+		}}},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}, nil
+}
+
+// unitApplicationName returns the name of the application of the
+// named unit, which may be a unit of a remote application.
+func unitApplicationName(unitName string) string {
+	return strings.Split(unitName, "/")[0]
+}
+
 // Id returns the integer internal relation key. This is exposed
 // because the unit agent needs to expose a value derived from this
 // (as JUJU_RELATION_ID) to allow relation hooks to differentiate
@@ -291,11 +345,69 @@ func (r *Relation) Unit(u *Unit) (*RelationUnit, error) {
 		st:       r.st,
 		relation: r,
 		unit:     u,
+		unitName: u.doc.Name,
 		endpoint: ep,
 		scope:    strings.Join(scope, "#"),
 	}, nil
 }
 
+// RemoteUnit returns a RelationUnit for the named unit of a remote
+// application, through which the unit's counterpart in another model
+// can be represented in the relation.
+func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("remote unit name %q", unitName)
+	}
+	applicationName := unitApplicationName(unitName)
+	if isRemote, err := isRemoteApplication(r.st, applicationName); err != nil {
+		return nil, errors.Trace(err)
+	} else if !isRemote {
+		return nil, errors.NotFoundf("remote application %q", applicationName)
+	}
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, err
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.Errorf("remote unit %q cannot take part in container scoped relation", unitName)
+	}
+	return &RelationUnit{
+		st:       r.st,
+		relation: r,
+		unitName: unitName,
+		endpoint: ep,
+		scope:    "r#" + strconv.Itoa(r.doc.Id),
+	}, nil
+}
+
+// UnitsInScope returns the names of the units of the named application
+// that are currently in scope in the relation, and have not prepared
+// to leave it.
+func (r *Relation) UnitsInScope(applicationName string) ([]string, error) {
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := fmt.Sprintf("^r#%d#(.+#)?%s#%s/", r.doc.Id, ep.Role, applicationName)
+	sel := bson.D{
+		{"key", bson.D{{"$regex", prefix}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get units in scope of relation %q", r)
+	}
+	unitNames := make([]string, len(docs))
+	for i, doc := range docs {
+		unitNames[i] = doc.unitName()
+	}
+	sort.Strings(unitNames)
+	return unitNames, nil
+}
+
 // relationSettingsCleanupChange removes the settings doc.
 type relationSettingsCleanupChange struct {
 	Prefix string
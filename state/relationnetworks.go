@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// relationNetworksDoc records the networks, in CIDR notation, from which
+// the units of a relation's counterpart in another model connect.
+type relationNetworksDoc struct {
+	DocID       string   `bson:"_id"`
+	ModelUUID   string   `bson:"model-uuid"`
+	RelationKey string   `bson:"relation-key"`
+	CIDRs       []string `bson:"cidrs"`
+}
+
+// SetIngressNetworks records the networks, in CIDR notation, from which
+// the units of the relation's counterpart in another model connect. The
+// firewall must admit traffic from these networks to the ports opened
+// by the relation's local units.
+func (r *Relation) SetIngressNetworks(cidrs []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set ingress networks for relation %q", r)
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	docID := r.st.docID(r.doc.Key)
+	rel := &Relation{r.st, r.doc}
+	buildTxn := func(int) ([]txn.Op, error) {
+		if err := rel.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if rel.doc.Life != Alive {
+			return nil, errors.New("relation is not alive")
+		}
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     rel.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		existing, err := rel.IngressNetworks()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      relationNetworksC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: relationNetworksDoc{
+					RelationKey: rel.doc.Key,
+					CIDRs:       cidrs,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      relationNetworksC,
+			Id:     docID,
+			Assert: bson.D{{"cidrs", existing}},
+			Update: bson.D{{"$set", bson.D{{"cidrs", cidrs}}}},
+		}), nil
+	}
+	return rel.st.run(buildTxn)
+}
+
+// IngressNetworks returns the networks, in CIDR notation, from which
+// the units of the relation's counterpart in another model connect.
+// If none have been recorded, an error satisfying errors.IsNotFound
+// is returned.
+func (r *Relation) IngressNetworks() ([]string, error) {
+	relationNetworks, closer := r.st.getCollection(relationNetworksC)
+	defer closer()
+
+	var doc relationNetworksDoc
+	err := relationNetworks.FindId(r.doc.Key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("ingress networks for relation %q", r)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get ingress networks for relation %q", r)
+	}
+	return doc.CIDRs, nil
+}
+
+// removeIngressNetworksOp returns an operation that removes the
+// ingress networks recorded for the relation with the supplied key,
+// if there are any.
+func removeIngressNetworksOp(st *State, relationKey string) txn.Op {
+	return txn.Op{
+		C:      relationNetworksC,
+		Id:     st.docID(relationKey),
+		Remove: true,
+	}
+}
@@ -21,10 +21,13 @@ import (
 
 // RelationUnit holds information about a single unit in a relation, and
 // allows clients to conveniently access unit-specific functionality.
+// The unit may belong to a remote application, in which case it has
+// no corresponding Unit in this model.
 type RelationUnit struct {
 	st       *State
 	relation *Relation
 	unit     *Unit
+	unitName string
 	endpoint Endpoint
 	scope    string
 }
@@ -42,9 +45,18 @@ func (ru *RelationUnit) Endpoint() Endpoint {
 
 // PrivateAddress returns the private address of the unit.
 func (ru *RelationUnit) PrivateAddress() (network.Address, error) {
+	if !ru.isLocalUnit() {
+		return network.Address{}, errors.NotSupportedf("private address of remote unit %q", ru.unitName)
+	}
 	return ru.unit.PrivateAddress()
 }
 
+// isLocalUnit returns whether the relation unit corresponds to a unit
+// in this model, rather than to a unit of a remote application.
+func (ru *RelationUnit) isLocalUnit() bool {
+	return ru.unit != nil
+}
+
 // ErrCannotEnterScope indicates that a relation unit failed to enter its scope
 // due to either the unit or the relation not being Alive.
 var ErrCannotEnterScope = stderrors.New("cannot enter scope: unit or relation is not alive")
@@ -86,13 +98,21 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	}
 
 	// Collect the operations necessary to enter scope, as follows:
-	// * Check unit and relation state, and incref the relation.
+	// * Check unit and relation state, and incref the relation. A remote
+	//   unit has no document of its own, so we check the life of its
+	//   remote application instead.
 	// * TODO(fwereade): check unit status == params.StatusActive (this
 	//   breaks a bunch of tests in a boring but noisy-to-fix way, and is
 	//   being saved for a followup).
-	unitDocID, relationDocID := ru.unit.doc.DocID, ru.relation.doc.DocID
+	var unitColl, unitDocID string
+	if ru.isLocalUnit() {
+		unitColl, unitDocID = unitsC, ru.unit.doc.DocID
+	} else {
+		unitColl, unitDocID = remoteApplicationsC, ru.st.docID(ru.endpoint.ApplicationName)
+	}
+	relationDocID := ru.relation.doc.DocID
 	ops := []txn.Op{{
-		C:      unitsC,
+		C:      unitColl,
 		Id:     unitDocID,
 		Assert: isAliveDoc,
 	}, {
@@ -156,13 +176,15 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	defer closer()
 	relations, closer := db.GetCollection(relationsC)
 	defer closer()
+	unitOrApplications, closer := db.GetCollection(unitColl)
+	defer closer()
 
 	// The relation or unit might no longer be Alive. (Note that there is no
 	// need for additional checks if we're trying to create a subordinate
 	// unit: this could fail due to the subordinate service's not being Alive,
 	// but this case will always be caught by the check for the relation's
 	// life (because a relation cannot be Alive if its services are not).)
-	if alive, err := isAliveWithSession(units, unitDocID); err != nil {
+	if alive, err := isAliveWithSession(unitOrApplications, unitDocID); err != nil {
 		return err
 	} else if !alive {
 		return ErrCannotEnterScope
@@ -187,7 +209,7 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// has changed under our feet, preventing us from clearing it properly; if
 	// that is the case, something is seriously wrong (nobody else should be
 	// touching that doc under our feet) and we should bail out.
-	prefix := fmt.Sprintf("cannot enter scope for unit %q in relation %q: ", ru.unit, ru.relation)
+	prefix := fmt.Sprintf("cannot enter scope for unit %q in relation %q: ", ru.unitName, ru.relation)
 	if changed, err := settingsChanged(); err != nil {
 		return err
 	} else if changed {
@@ -207,7 +229,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	units, closer := ru.st.getCollection(unitsC)
 	defer closer()
 
-	if !ru.unit.IsPrincipal() || ru.endpoint.Scope != charm.ScopeContainer {
+	if !ru.isLocalUnit() || !ru.unit.IsPrincipal() || ru.endpoint.Scope != charm.ScopeContainer {
 		return nil, "", nil
 	}
 	related, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName)
@@ -292,7 +314,7 @@ func (ru *RelationUnit) LeaveScope() error {
 	// to have a Dying relation with a smaller-than-real unit count, because
 	// Destroy changes the Life attribute in memory (units could join before
 	// the database is actually changed).
-	desc := fmt.Sprintf("unit %q in relation %q", ru.unit, ru.relation)
+	desc := fmt.Sprintf("unit %q in relation %q", ru.unitName, ru.relation)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := ru.relation.Refresh(); errors.IsNotFound(err) {
@@ -328,7 +350,7 @@ func (ru *RelationUnit) LeaveScope() error {
 				Update: bson.D{{"$inc", bson.D{{"unitcount", -1}}}},
 			})
 		} else {
-			relOps, err := ru.relation.removeOps("", ru.unit)
+			relOps, err := ru.relation.removeOps("", ru.unitName)
 			if err != nil {
 				return nil, err
 			}
@@ -372,7 +394,7 @@ func (ru *RelationUnit) inScope(sel bson.D) (bool, error) {
 func (ru *RelationUnit) WatchScope() *RelationScopeWatcher {
 	role := counterpartRole(ru.endpoint.Role)
 	scope := ru.scope + "#" + string(role)
-	return newRelationScopeWatcher(ru.st, scope, ru.unit.Name())
+	return newRelationScopeWatcher(ru.st, scope, ru.unitName)
 }
 
 // Settings returns a Settings which allows access to the unit's settings
@@ -421,7 +443,7 @@ func (ru *RelationUnit) unitKey(uname string) (string, error) {
 // which is used as a key for that unit within this relation in the settings,
 // presence, and relationScopes collections.
 func (ru *RelationUnit) key() string {
-	return ru._key(string(ru.endpoint.Role), ru.unit.Name())
+	return ru._key(string(ru.endpoint.Role), ru.unitName)
 }
 
 func (ru *RelationUnit) _key(role, unitname string) string {
@@ -0,0 +1,462 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RemoteApplication represents the state of an application hosted
+// in another model, which takes part in relations in this model.
+// A remote application has no charm or units of its own; its units
+// enter and leave relation scopes on behalf of the units of the
+// application it stands for.
+type RemoteApplication struct {
+	st  *State
+	doc remoteApplicationDoc
+}
+
+// remoteApplicationDoc represents the internal state of a remote
+// application in MongoDB.
+type remoteApplicationDoc struct {
+	DocID           string              `bson:"_id"`
+	Name            string              `bson:"name"`
+	ModelUUID       string              `bson:"model-uuid"`
+	URL             string              `bson:"url,omitempty"`
+	SourceModelUUID string              `bson:"source-model-uuid"`
+	OfferName       string              `bson:"offer-name,omitempty"`
+	Endpoints       []remoteEndpointDoc `bson:"endpoints"`
+	Life            Life                `bson:"life"`
+	RelationCount   int                 `bson:"relationcount"`
+	IsConsumerProxy bool                `bson:"is-consumer-proxy"`
+	TxnRevno        int64               `bson:"txn-revno"`
+}
+
+// remoteEndpointDoc represents the internal state of a remote
+// application endpoint in MongoDB.
+type remoteEndpointDoc struct {
+	Name      string              `bson:"name"`
+	Role      charm.RelationRole  `bson:"role"`
+	Interface string              `bson:"interface"`
+	Limit     int                 `bson:"limit"`
+	Scope     charm.RelationScope `bson:"scope"`
+}
+
+func newRemoteApplication(st *State, doc *remoteApplicationDoc) *RemoteApplication {
+	return &RemoteApplication{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// Name returns the name of the remote application.
+func (s *RemoteApplication) Name() string {
+	return s.doc.Name
+}
+
+// Tag returns a name identifying the remote application.
+func (s *RemoteApplication) Tag() names.Tag {
+	return names.NewApplicationTag(s.doc.Name)
+}
+
+// String returns the name of the remote application.
+func (s *RemoteApplication) String() string {
+	return s.doc.Name
+}
+
+// URL returns the URL of the offer that the remote application was
+// consumed from. It is empty for a consumer proxy.
+func (s *RemoteApplication) URL() string {
+	return s.doc.URL
+}
+
+// SourceModel returns the tag of the model hosting the application
+// that the remote application stands for.
+func (s *RemoteApplication) SourceModel() names.ModelTag {
+	return names.NewModelTag(s.doc.SourceModelUUID)
+}
+
+// OfferName returns the name of the offer that the remote application
+// was consumed from. It is empty for a consumer proxy.
+func (s *RemoteApplication) OfferName() string {
+	return s.doc.OfferName
+}
+
+// IsConsumerProxy returns whether the remote application stands for
+// an application in another model that consumes an offer in this one,
+// rather than for an offered application in another model.
+func (s *RemoteApplication) IsConsumerProxy() bool {
+	return s.doc.IsConsumerProxy
+}
+
+// Life returns whether the remote application is Alive, Dying or Dead.
+func (s *RemoteApplication) Life() Life {
+	return s.doc.Life
+}
+
+// Endpoints returns the remote application's currently available
+// relation endpoints.
+func (s *RemoteApplication) Endpoints() ([]Endpoint, error) {
+	eps := make([]Endpoint, len(s.doc.Endpoints))
+	for i, ep := range s.doc.Endpoints {
+		eps[i] = Endpoint{
+			ApplicationName: s.doc.Name,
+			Relation: charm.Relation{
+				Name:      ep.Name,
+				Role:      ep.Role,
+				Interface: ep.Interface,
+				Limit:     ep.Limit,
+				Scope:     ep.Scope,
+			},
+		}
+	}
+	sort.Sort(epSlice(eps))
+	return eps, nil
+}
+
+// Endpoint returns the relation endpoint with the supplied name, if
+// it exists.
+func (s *RemoteApplication) Endpoint(relationName string) (Endpoint, error) {
+	eps, err := s.Endpoints()
+	if err != nil {
+		return Endpoint{}, err
+	}
+	for _, ep := range eps {
+		if ep.Name == relationName {
+			return ep, nil
+		}
+	}
+	return Endpoint{}, errors.Errorf("remote application %q has no %q relation", s, relationName)
+}
+
+// Relations returns a Relation for every relation the remote
+// application is in.
+func (s *RemoteApplication) Relations() (relations []*Relation, err error) {
+	return applicationRelations(s.st, s.doc.Name)
+}
+
+// Refresh refreshes the contents of the remote application from the
+// underlying state. It returns an error that satisfies
+// errors.IsNotFound if the remote application has been removed.
+func (s *RemoteApplication) Refresh() error {
+	remoteApplications, closer := s.st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	err := remoteApplications.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("remote application %q", s)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh remote application %q", s)
+	}
+	return nil
+}
+
+// Destroy ensures that this remote application reference and all its
+// relations will be removed at some point; if no relation involving the
+// application has any units in scope, they are all removed immediately.
+func (s *RemoteApplication) Destroy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy remote application %q", s)
+	defer func() {
+		if err == nil {
+			// This is a white lie; the document might actually be removed.
+			s.doc.Life = Dying
+		}
+	}()
+	app := &RemoteApplication{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		switch ops, err := app.destroyOps(); err {
+		case errRefresh:
+		case errAlreadyDying:
+			return nil, jujutxn.ErrNoOperations
+		case nil:
+			return ops, nil
+		default:
+			return nil, err
+		}
+		return nil, jujutxn.ErrTransientFailure
+	}
+	return app.st.run(buildTxn)
+}
+
+// destroyOps returns the operations required to destroy the remote
+// application. If it returns errRefresh, the application should be
+// refreshed and the destruction operations recalculated.
+func (s *RemoteApplication) destroyOps() ([]txn.Op, error) {
+	if s.doc.Life == Dying {
+		return nil, errAlreadyDying
+	}
+	rels, err := s.Relations()
+	if err != nil {
+		return nil, err
+	}
+	if len(rels) != s.doc.RelationCount {
+		// This is just an early bail out. The relations obtained may still
+		// be wrong, but that situation will be caught by a combination of
+		// asserts on relationcount and on each known relation, below.
+		return nil, errRefresh
+	}
+	var ops []txn.Op
+	removeCount := 0
+	for _, rel := range rels {
+		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
+		if err == errAlreadyDying {
+			relOps = []txn.Op{{
+				C:      relationsC,
+				Id:     rel.doc.DocID,
+				Assert: bson.D{{"life", Dying}},
+			}}
+		} else if err != nil {
+			return nil, err
+		}
+		if isRemove {
+			removeCount++
+		}
+		ops = append(ops, relOps...)
+	}
+	// If all of the remote application's known relations will be
+	// removed, the application can also be removed.
+	if s.doc.RelationCount == removeCount {
+		hasLastRefs := bson.D{{"life", Alive}, {"relationcount", removeCount}}
+		return append(ops, s.removeOps(hasLastRefs)...), nil
+	}
+	// In all other cases, remote application removal will be handled
+	// as a consequence of the removal of the last relation referencing
+	// it. If any relations have been removed, they'll be caught by the
+	// operations collected above; but if any has been added, we need
+	// to abort and add a destroy op for that relation too.
+	notLastRefs := bson.D{
+		{"life", Alive},
+		{"relationcount", s.doc.RelationCount},
+	}
+	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	if removeCount != 0 {
+		decref := bson.D{{"$inc", bson.D{{"relationcount", -removeCount}}}}
+		update = append(update, decref...)
+	}
+	return append(ops, txn.Op{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: notLastRefs,
+		Update: update,
+	}), nil
+}
+
+// removeOps returns the operations required to remove the remote
+// application. Supplied asserts will be included in the operation on
+// the remote application document.
+func (s *RemoteApplication) removeOps(asserts bson.D) []txn.Op {
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: asserts,
+		Remove: true,
+	}}
+}
+
+// addRelationOps returns the operations required to add a relation
+// to the remote application via the supplied endpoint.
+func (s *RemoteApplication) addRelationOps(ep Endpoint) ([]txn.Op, error) {
+	if s.doc.Life != Alive {
+		return nil, errors.Errorf("remote application %q is not alive", s)
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.Errorf("remote application %q cannot take part in container scoped relation", s)
+	}
+	remoteEp, err := s.Endpoint(ep.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if remoteEp.Interface != ep.Interface || remoteEp.Role != ep.Role {
+		return nil, errors.Errorf("%q does not implement %q", s, ep)
+	}
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
+	}}, nil
+}
+
+// AddRemoteApplicationParams contains the parameters for adding a
+// remote application to the model.
+type AddRemoteApplicationParams struct {
+	// Name is the name to give the remote application.
+	Name string
+
+	// URL is the URL of the offer that the remote application is
+	// consumed from. It is empty for a consumer proxy.
+	URL string
+
+	// SourceModel is the tag of the model hosting the application
+	// that the remote application stands for.
+	SourceModel names.ModelTag
+
+	// OfferName is the name of the offer that the remote application
+	// is consumed from. It is empty for a consumer proxy.
+	OfferName string
+
+	// Endpoints are the endpoints through which the remote application
+	// may take part in relations.
+	Endpoints []charm.Relation
+
+	// IsConsumerProxy is true if the remote application stands for an
+	// application in another model consuming an offer in this one.
+	IsConsumerProxy bool
+}
+
+// Validate returns an error if the parameters are not valid.
+func (p AddRemoteApplicationParams) Validate() error {
+	if !names.IsValidApplication(p.Name) {
+		return errors.NotValidf("name %q", p.Name)
+	}
+	if p.SourceModel == (names.ModelTag{}) {
+		return errors.NotValidf("empty source model tag")
+	}
+	if len(p.Endpoints) == 0 {
+		return errors.NotValidf("empty endpoints")
+	}
+	for _, ep := range p.Endpoints {
+		if ep.Role == charm.RolePeer {
+			return errors.NotValidf("peer endpoint %q", ep.Name)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return errors.NotValidf("container scoped endpoint %q", ep.Name)
+		}
+	}
+	return nil
+}
+
+// AddRemoteApplication creates a new remote application record,
+// having the supplied relation endpoints, with the supplied name
+// (which must be unique across all applications, local and remote).
+func (st *State) AddRemoteApplication(args AddRemoteApplicationParams) (_ *RemoteApplication, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote application %q", args.Name)
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkModelActive(st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps := make([]remoteEndpointDoc, len(args.Endpoints))
+	for i, ep := range args.Endpoints {
+		eps[i] = remoteEndpointDoc{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		}
+	}
+	applicationID := st.docID(args.Name)
+	doc := &remoteApplicationDoc{
+		DocID:           applicationID,
+		Name:            args.Name,
+		ModelUUID:       st.ModelUUID(),
+		URL:             args.URL,
+		SourceModelUUID: args.SourceModel.Id(),
+		OfferName:       args.OfferName,
+		Endpoints:       eps,
+		Life:            Alive,
+		IsConsumerProxy: args.IsConsumerProxy,
+	}
+	app := newRemoteApplication(st, doc)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := checkModelActive(st); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if exists, err := isNotDead(st, remoteApplicationsC, args.Name); err != nil {
+			return nil, errors.Trace(err)
+		} else if exists {
+			return nil, errors.Errorf("remote application already exists")
+		}
+		if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
+			return nil, errors.Trace(err)
+		} else if exists {
+			return nil, errors.Errorf("local application with same name already exists")
+		}
+		return []txn.Op{
+			assertModelActiveOp(st.ModelUUID()),
+			{
+				C:      applicationsC,
+				Id:     applicationID,
+				Assert: txn.DocMissing,
+			}, {
+				C:      remoteApplicationsC,
+				Id:     applicationID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			},
+		}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
+// RemoteApplication returns a remote application state by name.
+func (st *State) RemoteApplication(name string) (_ *RemoteApplication, err error) {
+	if !names.IsValidApplication(name) {
+		return nil, errors.NotValidf("remote application name %q", name)
+	}
+	remoteApplications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	doc := &remoteApplicationDoc{}
+	err = remoteApplications.FindId(name).One(doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get remote application %q", name)
+	}
+	return newRemoteApplication(st, doc), nil
+}
+
+// AllRemoteApplications returns all the remote applications in the model.
+func (st *State) AllRemoteApplications() (applications []*RemoteApplication, err error) {
+	remoteApplications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	docs := []remoteApplicationDoc{}
+	err = remoteApplications.Find(bson.D{}).All(&docs)
+	if err != nil {
+		return nil, errors.Errorf("cannot get all remote applications")
+	}
+	for _, v := range docs {
+		applications = append(applications, newRemoteApplication(st, &v))
+	}
+	return applications, nil
+}
+
+// isRemoteApplication returns whether the named application is a
+// remote application.
+func isRemoteApplication(st *State, name string) (bool, error) {
+	remoteApplications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	n, err := remoteApplications.FindId(name).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return n > 0, nil
+}
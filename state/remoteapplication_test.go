@@ -0,0 +1,220 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type RemoteApplicationSuite struct {
+	ConnSuite
+	wordpress   *state.Application
+	mysql       *state.RemoteApplication
+	sourceModel names.ModelTag
+}
+
+var _ = gc.Suite(&RemoteApplicationSuite{})
+
+func (s *RemoteApplicationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.sourceModel = names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	var err error
+	s.mysql, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		URL:         "admin/prod.mysql",
+		SourceModel: s.sourceModel,
+		OfferName:   "mysql",
+		Endpoints: []charm.Relation{{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteApplicationSuite) addRelation(c *gc.C) *state.Relation {
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *RemoteApplicationSuite) TestRemoteApplication(c *gc.C) {
+	app, err := s.State.RemoteApplication("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Name(), gc.Equals, "mysql")
+	c.Assert(app.URL(), gc.Equals, "admin/prod.mysql")
+	c.Assert(app.OfferName(), gc.Equals, "mysql")
+	c.Assert(app.SourceModel(), gc.Equals, s.sourceModel)
+	c.Assert(app.IsConsumerProxy(), jc.IsFalse)
+	c.Assert(app.Life(), gc.Equals, state.Alive)
+	eps, err := app.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eps, jc.DeepEquals, []state.Endpoint{{
+		ApplicationName: "mysql",
+		Relation: charm.Relation{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		},
+	}})
+
+	all, err := s.State.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Name(), gc.Equals, "mysql")
+}
+
+func (s *RemoteApplicationSuite) TestRemoteApplicationNotFound(c *gc.C) {
+	_, err := s.State.RemoteApplication("mediawiki")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplicationNameClash(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "wordpress",
+		SourceModel: s.sourceModel,
+		Endpoints: []charm.Relation{{
+			Name: "db", Role: charm.RoleRequirer, Interface: "mysql",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add remote application "wordpress": local application with same name already exists`)
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "mysql",
+		Charm: s.AddTestingCharm(c, "mysql"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "mysql": remote application with same name already exists`)
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplicationInvalid(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "riak",
+		SourceModel: s.sourceModel,
+		Endpoints: []charm.Relation{{
+			Name: "ring", Role: charm.RolePeer, Interface: "riak",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add remote application "riak": peer endpoint "ring" not valid`)
+}
+
+func (s *RemoteApplicationSuite) TestAddRelation(c *gc.C) {
+	rel := s.addRelation(c)
+	c.Assert(rel.String(), gc.Equals, "wordpress:db mysql:server")
+
+	rels, err := s.mysql.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].Id(), gc.Equals, rel.Id())
+}
+
+func (s *RemoteApplicationSuite) TestDestroyRemovesRelations(c *gc.C) {
+	rel := s.addRelation(c)
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.RemoteApplication("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitEnterAndLeaveScope(c *gc.C) {
+	rel := s.addRelation(c)
+	ru, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"private-address": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	inScope, err := rel.UnitsInScope("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.DeepEquals, []string{"mysql/0"})
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{"private-address": "10.0.0.1"})
+
+	// Destroying the remote application leaves the relation
+	// dying while the remote unit remains in scope.
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Life(), gc.Equals, state.Dying)
+
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.RemoteApplication("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitNotRemote(c *gc.C) {
+	rel := s.addRelation(c)
+	_, err := rel.RemoteUnit("wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `remote application "wordpress" not found`)
+}
+
+func (s *RemoteApplicationSuite) TestIngressNetworks(c *gc.C) {
+	rel := s.addRelation(c)
+	_, err := rel.IngressNetworks()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = rel.SetIngressNetworks([]string{"10.0.0.1/32"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetIngressNetworks([]string{"10.0.0.1/32", "10.0.0.2/32"})
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err := rel.IngressNetworks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.1/32", "10.0.0.2/32"})
+
+	err = rel.SetIngressNetworks([]string{"10.0.0.1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set ingress networks for relation "wordpress:db mysql:server": CIDR "10.0.0.1" not valid`)
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = rel.IngressNetworks()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestWatchRelationIngressNetworks(c *gc.C) {
+	rel := s.addRelation(c)
+	w := s.State.WatchRelationIngressNetworks()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := rel.SetIngressNetworks([]string{"10.0.0.1/32"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *RemoteApplicationSuite) TestWatchRemoteApplications(c *gc.C) {
+	w := s.State.WatchRemoteApplications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+}
@@ -410,6 +410,19 @@ func replaceSettingsOp(st *State, collection, key string, values map[string]inte
 	return op, assertFailed, nil
 }
 
+// replaceSettings replaces the contents of the settings document for key
+// with the supplied values.
+func replaceSettings(st *State, collection, key string, values map[string]interface{}) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		op, _, err := replaceSettingsOp(st, collection, key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{op}, nil
+	}
+	return st.run(buildTxn)
+}
+
 func (s *Settings) assertUnchangedOp() txn.Op {
 	return txn.Op{
 		C:      s.collection,
@@ -477,6 +490,11 @@ func (s *StateSettings) ReadSettings(key string) (map[string]interface{}, error)
 	}
 }
 
+// ReplaceSettings exposes replaceSettings on state for use outside the state package.
+func (s *StateSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	return replaceSettings(s.st, s.collection, key, settings)
+}
+
 // RemoveSettings exposes removeSettings on state for use outside the state package.
 func (s *StateSettings) RemoveSettings(key string) error {
 	return removeSettings(s.st, s.collection, key)
@@ -995,6 +995,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	} else if exists {
 		return nil, errors.Errorf("application already exists")
 	}
+	if exists, err := isNotDead(st, remoteApplicationsC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote application with same name already exists")
+	}
 	if err := checkModelActive(st); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1136,6 +1141,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	ops := []txn.Op{
 		assertModelActiveOp(st.ModelUUID()),
 		endpointBindingsOp,
+		{
+			C:      remoteApplicationsC,
+			Id:     applicationID,
+			Assert: txn.DocMissing,
+		},
 	}
 	addOps, err := addApplicationOps(st, addApplicationOpsArgs{
 		applicationDoc: svcDoc,
@@ -1173,6 +1183,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	}
 
 	// Collect unit-adding operations.
+	unitTags := make([]names.UnitTag, args.NumUnits)
 	for x := 0; x < args.NumUnits; x++ {
 		unitName, unitOps, err := svc.addServiceUnitOps(applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
 		if err != nil {
@@ -1184,7 +1195,18 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			placement = *args.Placement[x]
 		}
 		ops = append(ops, assignUnitOps(unitName, placement)...)
+		unitTags[x] = names.NewUnitTag(unitName)
 	}
+
+	// Collect shared storage operations. These must come after the
+	// unit-adding operations, as the storage is attached to each unit.
+	sharedStorageOps, err := createSharedStorageOps(
+		st, svc.ApplicationTag(), args.Charm.Meta(), args.Storage, unitTags,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, sharedStorageOps...)
 	// At the last moment before inserting the service, prime status history.
 	probablyUpdateStatusHistory(st, svc.globalKey(), statusDoc)
 
@@ -1536,7 +1558,15 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	} else {
 		return nil, errors.Errorf("invalid endpoint %q", name)
 	}
+	var svc endpointer
 	svc, err := st.Application(svcName)
+	if errors.IsNotFound(err) {
+		// The endpoints may belong to a remote application.
+		svc, err = st.RemoteApplication(svcName)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("application %q", svcName)
+		}
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1562,6 +1592,13 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	return final, nil
 }
 
+// endpointer is implemented by both local and remote applications,
+// providing access to the endpoints they may relate through.
+type endpointer interface {
+	Endpoint(relationName string) (Endpoint, error)
+	Endpoints() ([]Endpoint, error)
+}
+
 // AddRelation creates a new relation with the given endpoints.
 func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	key := relationKey(eps)
@@ -1604,6 +1641,17 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		var subordinateCount int
 		series := map[string]bool{}
 		for _, ep := range eps {
+			remoteApp, err := st.RemoteApplication(ep.ApplicationName)
+			if err == nil {
+				remoteOps, err := remoteApp.addRelationOps(ep)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, remoteOps...)
+				continue
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
 			svc, err := st.Application(ep.ApplicationName)
 			if errors.IsNotFound(err) {
 				return nil, errors.Errorf("application %q does not exist", ep.ApplicationName)
@@ -2056,6 +2104,23 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the audit entries persisted to the database
+// which match the given query, oldest first.
+func (st *State) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, selector bson.D, sort string, limit int, result interface{}) error {
+		collection, closeCollection := st.getCollection(collectionName)
+		defer closeCollection()
+
+		q := collection.Find(selector).Sort(sort)
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return errors.Trace(q.All(result))
+	}
+	entries, err := stateaudit.AuditEntriesFn(auditingC, find)(query)
+	return entries, errors.Trace(err)
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
@@ -34,8 +35,10 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the application or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean reporting whether there is an owner. Storage
+	// that has been detached from its unit has no owner until it is attached
+	// to another unit.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -114,14 +117,17 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
-		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// This should be impossible; the owner tag is
+		// only ever set to a valid tag or cleared.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -264,7 +270,8 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 		// remove the storage instance immediately.
 		hasNoAttachments := bson.D{{"attachmentcount", 0}}
 		assert := append(hasNoAttachments, isAliveDoc...)
-		return removeStorageInstanceOps(st, s.Owner(), s.StorageTag(), assert)
+		owner, _ := s.Owner()
+		return removeStorageInstanceOps(st, owner, s.StorageTag(), assert)
 	}
 	// There are still attachments: the storage instance will be removed
 	// when the last attachment is removed. We schedule a cleanup to destroy
@@ -286,8 +293,77 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 	return ops, nil
 }
 
+// ReleaseStorageInstance ensures that the storage instance and all its
+// attachments will be removed at some point, as with DestroyStorageInstance,
+// except that the storage instance's volume or filesystem will be released
+// from the model rather than destroyed, leaving the underlying storage
+// intact.
+func (st *State) ReleaseStorageInstance(tag names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot release storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		releaseOps, err := st.releaseStorageInstanceOps(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch ops, err := st.destroyStorageInstanceOps(s); err {
+		case errAlreadyDying:
+			return nil, jujutxn.ErrNoOperations
+		case nil:
+			return append(releaseOps, ops...), nil
+		default:
+			return nil, errors.Trace(err)
+		}
+	}
+	return st.run(buildTxn)
+}
+
+// releaseStorageInstanceOps returns txn.Ops to mark the volume and/or
+// filesystem assigned to the storage instance as releasing, so that they
+// are removed from the model without being destroyed.
+func (st *State) releaseStorageInstanceOps(tag names.StorageTag) ([]txn.Op, error) {
+	releaseOp := func(c string, id string) txn.Op {
+		return txn.Op{
+			C:      c,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"releasing", true}}}},
+		}
+	}
+	f, err := st.storageInstanceFilesystem(tag)
+	if err == nil {
+		if err := checkStorageDetachable(st, f.pool(), f.FilesystemTag()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{releaseOp(filesystemsC, f.doc.FilesystemId)}
+		if f.doc.VolumeId != "" {
+			ops = append(ops, releaseOp(volumesC, f.doc.VolumeId))
+		}
+		return ops, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	v, err := st.storageInstanceVolume(tag)
+	if errors.IsNotFound(err) {
+		// There is nothing provisioned to release.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkStorageDetachable(st, v.pool(), v.VolumeTag()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{releaseOp(volumesC, v.doc.Name)}, nil
+}
+
 // removeStorageInstanceOps removes the storage instance with the given
-// tag from state, if the specified assertions hold true.
+// tag from state, if the specified assertions hold true. The owner may be
+// nil if the storage instance has been detached.
 func removeStorageInstanceOps(
 	st *State,
 	owner names.Tag,
@@ -337,7 +413,11 @@ func removeStorageInstanceOps(
 		return nil, errors.Trace(err)
 	}
 
-	// Decrement the charm storage reference count.
+	// Decrement the charm storage reference count. Detached storage
+	// instances are not counted against any entity.
+	if owner == nil {
+		return ops, nil
+	}
 	refcounts, closer := st.getCollection(refcountsC)
 	defer closer()
 	storageName, err := names.StorageName(tag.Id())
@@ -501,9 +581,6 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the service.
-	//
 	// TODO(axw) prevent creation of shared storage after service
 	// creation, because the only sane time to add storage attachments
 	// is when units are added to said service.
@@ -578,6 +655,141 @@ func createStorageAttachmentOp(storage names.StorageTag, unit names.UnitTag) txn
 	}
 }
 
+// createSharedStorageOps returns txn.Ops for creating the shared storage
+// instances for a newly created application, along with their filesystems
+// and attachments to each of the application's initial units.
+//
+// The units' documents must be inserted earlier in the same transaction,
+// as the returned operations update their storage attachment counts.
+func createSharedStorageOps(
+	st *State,
+	applicationTag names.ApplicationTag,
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	units []names.UnitTag,
+) ([]txn.Op, error) {
+	// Create storage instances in order of name, to simplify testing.
+	storageNames := set.NewStrings()
+	for name := range cons {
+		storageNames.Add(name)
+	}
+
+	refcounts, closer := st.getCollection(refcountsC)
+	defer closer()
+
+	var ops []txn.Op
+	for _, store := range storageNames.SortedValues() {
+		cons := cons[store]
+		charmStorage, ok := charmMeta.Storage[store]
+		if !ok {
+			return nil, errors.NotFoundf("charm storage %q", store)
+		}
+		if !charmStorage.Shared || cons.Count == 0 {
+			continue
+		}
+		storageRefcountKey := entityStorageRefcountKey(applicationTag, store)
+		incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, int(cons.Count))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
+
+		for i := uint64(0); i < cons.Count; i++ {
+			id, err := newStorageInstanceId(st, store)
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot generate storage instance name")
+			}
+			storageTag := names.NewStorageTag(id)
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &storageInstanceDoc{
+					Id:              id,
+					Kind:            StorageKindFilesystem,
+					Owner:           applicationTag.String(),
+					StorageName:     store,
+					AttachmentCount: len(units),
+				},
+			})
+			filesystemOps, _, err := st.addSharedFilesystemOps(FilesystemParams{
+				storage: storageTag,
+				binding: storageTag,
+				Pool:    cons.Pool,
+				Size:    cons.Size,
+			})
+			if err != nil {
+				return nil, errors.Annotatef(err, "creating filesystem for storage %s", id)
+			}
+			ops = append(ops, filesystemOps...)
+			for _, unitTag := range units {
+				ops = append(ops, createStorageAttachmentOp(storageTag, unitTag), txn.Op{
+					C:      unitsC,
+					Id:     unitTag.Id(),
+					Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+				})
+			}
+		}
+	}
+	return ops, nil
+}
+
+// unitSharedStorageOps returns txn.Ops for attaching a newly created unit
+// to each of the shared storage instances owned by its application, along
+// with the number of storage attachments created.
+//
+// maybeMachineAssignable may be nil, or an machineAssignable which
+// describes the unit's machine assignment. If the unit is assigned
+// to a machine, then the shared filesystems will be attached to it.
+func unitSharedStorageOps(
+	st *State,
+	unitTag names.UnitTag,
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	series string,
+	maybeMachineAssignable machineAssignable,
+) ([]txn.Op, int, error) {
+	applicationName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	query := bson.D{
+		{"owner", names.NewApplicationTag(applicationName).String()},
+		{"life", Alive},
+	}
+	if err := coll.Find(query).Sort("id").All(&docs); err != nil {
+		return nil, -1, errors.Annotatef(err, "cannot get shared storage for %s", unitTag.Id())
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		storageTag := names.NewStorageTag(doc.Id)
+		ops = append(ops, createStorageAttachmentOp(storageTag, unitTag), txn.Op{
+			C:      storageInstancesC,
+			Id:     doc.Id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		if maybeMachineAssignable != nil {
+			machineOps, err := unitAssignedMachineStorageOps(
+				st, unitTag, charmMeta, cons, series,
+				&storageInstance{st, doc},
+				maybeMachineAssignable,
+			)
+			if err != nil {
+				return nil, -1, errors.Annotatef(
+					err, "creating machine storage for storage %s", doc.Id,
+				)
+			}
+			ops = append(ops, machineOps...)
+		}
+	}
+	return ops, len(docs), nil
+}
+
 // StorageAttachments returns the StorageAttachments for the specified storage
 // instance.
 func (st *State) StorageAttachments(storage names.StorageTag) ([]StorageAttachment, error) {
@@ -691,6 +903,304 @@ func destroyStorageAttachmentOps(storage names.StorageTag, unit names.UnitTag) [
 	return ops
 }
 
+// DetachStorage ensures that the storage attachment will be removed at
+// some point, leaving the storage instance in the model without an owner
+// so that it may later be attached to another unit. The storage instance
+// must be owned by the unit, and its volume or filesystem must be able to
+// be detached from the unit's machine.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.detachStorageOps(si, u)
+	}
+	return st.run(buildTxn)
+}
+
+func (st *State) detachStorageOps(si *storageInstance, u *Unit) ([]txn.Op, error) {
+	if si.doc.Life != Alive {
+		return nil, errors.New("storage instance is not alive")
+	}
+	if owner, ok := si.Owner(); !ok || owner != u.Tag() {
+		return nil, errors.New("storage instance is not owned by the unit")
+	}
+	charmMeta, ops, err := st.unitCharmMetaOps(u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Make sure that the unit is not left with fewer instances
+	// of the named storage than its charm requires.
+	countOp, count, err := st.countEntityStorageInstances(u.Tag(), si.doc.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if charmStorage, ok := charmMeta.Storage[si.doc.StorageName]; ok && count <= charmStorage.CountMin {
+		return nil, errors.Errorf(
+			"charm requires at least %d %q storage instance(s)",
+			charmStorage.CountMin, si.doc.StorageName,
+		)
+	}
+	refcounts, closer := st.getCollection(refcountsC)
+	defer closer()
+	storageRefcountKey := entityStorageRefcountKey(u.Tag(), si.doc.StorageName)
+	decRefOp, _, err := nsRefcounts.DyingDecRefOp(refcounts, storageRefcountKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, countOp, decRefOp, txn.Op{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: bson.D{{"life", Alive}, {"owner", si.doc.Owner}},
+		Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+	})
+	ops = append(ops, destroyStorageAttachmentOps(si.StorageTag(), u.UnitTag())...)
+
+	machineOps, err := st.detachStorageMachineOps(si.StorageTag(), u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, machineOps...), nil
+}
+
+// detachStorageMachineOps returns txn.Ops to detach the volume or filesystem
+// of the specified storage instance from the machine that the unit is
+// assigned to, if any. An error is returned if the volume or filesystem
+// cannot be detached from a machine.
+func (st *State) detachStorageMachineOps(storage names.StorageTag, u *Unit) ([]txn.Op, error) {
+	var machineTag names.MachineTag
+	machineId, err := u.AssignedMachineId()
+	if err == nil {
+		machineTag = names.NewMachineTag(machineId)
+	} else if !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	// Make sure that the unit is not assigned to a
+	// machine during the transaction.
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: bson.D{{"machineid", u.doc.MachineId}},
+	}}
+
+	// A filesystem backed by a volume will have its volume detached
+	// when the filesystem attachment is removed.
+	f, err := st.storageInstanceFilesystem(storage)
+	if err == nil {
+		if err := checkStorageDetachable(st, f.pool(), f.FilesystemTag()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineId == "" {
+			return ops, nil
+		}
+		attachment, err := st.FilesystemAttachment(machineTag, f.FilesystemTag())
+		if err == nil && attachment.Life() == Alive {
+			ops = append(ops, detachFilesystemOps(machineTag, f.FilesystemTag())...)
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	v, err := st.storageInstanceVolume(storage)
+	if errors.IsNotFound(err) {
+		// The storage has no volume or filesystem yet.
+		return ops, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkStorageDetachable(st, v.pool(), v.VolumeTag()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machineId == "" {
+		return ops, nil
+	}
+	attachment, err := st.VolumeAttachment(machineTag, v.VolumeTag())
+	if err == nil && attachment.Life() == Alive {
+		ops = append(ops, detachVolumeOps(machineTag, v.VolumeTag())...)
+	} else if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	return ops, nil
+}
+
+// checkStorageDetachable returns an error if the specified volume or
+// filesystem, provisioned from the named storage pool, cannot be detached
+// from one machine and attached to another.
+func checkStorageDetachable(st *State, pool string, tag names.Tag) error {
+	_, provider, err := poolStorageProvider(st, pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() == storage.ScopeMachine || !provider.Dynamic() {
+		return errors.Errorf("%s cannot be detached from its machine", names.ReadableString(tag))
+	}
+	return nil
+}
+
+// AttachStorage attaches the specified storage instance, which must have
+// been detached from its previous owner, to the specified unit. If the unit
+// is assigned to a machine, then the storage instance's volume or filesystem
+// will be attached to that machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.attachStorageOps(si, u)
+	}
+	return st.run(buildTxn)
+}
+
+func (st *State) attachStorageOps(si *storageInstance, u *Unit) ([]txn.Op, error) {
+	if si.doc.Life != Alive {
+		return nil, errors.New("storage instance is not alive")
+	}
+	if owner, ok := si.Owner(); ok {
+		return nil, errors.Errorf("storage instance is owned by %s", names.ReadableString(owner))
+	}
+	if si.doc.AttachmentCount > 0 {
+		return nil, errors.New("storage instance is still being detached")
+	}
+	if u.Life() != Alive {
+		return nil, unitNotAliveErr
+	}
+	charmMeta, ops, err := st.unitCharmMetaOps(u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageName := si.doc.StorageName
+	charmStorage, ok := charmMeta.Storage[storageName]
+	if !ok {
+		return nil, errors.NotFoundf("charm storage %q", storageName)
+	}
+	if (charmStorage.Type == charm.StorageBlock) != (si.doc.Kind == StorageKindBlock) {
+		return nil, errors.Errorf(
+			"%s storage cannot be attached to charm storage %q of type %s",
+			si.doc.Kind, storageName, charmStorage.Type,
+		)
+	}
+
+	// Make sure that the unit is not left with more instances
+	// of the named storage than its charm allows.
+	countOp, count, err := st.countEntityStorageInstances(u.Tag(), storageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if charmStorage.CountMax >= 0 && count >= charmStorage.CountMax {
+		return nil, errors.Errorf(
+			"charm allows at most %d %q storage instance(s)",
+			charmStorage.CountMax, storageName,
+		)
+	}
+	refcounts, closer := st.getCollection(refcountsC)
+	defer closer()
+	storageRefcountKey := entityStorageRefcountKey(u.Tag(), storageName)
+	incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, countOp, incRefOp, txn.Op{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: bson.D{{"life", Alive}, {"owner", ""}, {"attachmentcount", 0}},
+		Update: bson.D{
+			{"$set", bson.D{{"owner", u.Tag().String()}}},
+			{"$inc", bson.D{{"attachmentcount", 1}}},
+		},
+	}, createStorageAttachmentOp(si.StorageTag(), u.UnitTag()), txn.Op{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+	})
+
+	// Attach the storage instance's volume or filesystem to the unit's
+	// machine; if the unit is not yet assigned, this will happen when
+	// it is.
+	cons, err := u.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attached := &storageInstance{st, si.doc}
+	attached.doc.Owner = u.Tag().String()
+	machineOps, err := unitAssignedMachineStorageOps(
+		st, u.UnitTag(), charmMeta, cons, u.Series(), attached, u,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statusOps, err := st.attachingStorageStatusOps(si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, machineOps...)
+	return append(ops, statusOps...), nil
+}
+
+// attachingStorageStatusOps returns txn.Ops to set the status of the
+// storage instance's filesystem and volume to "attaching", if they are
+// detached. The storage provisioner sets their status to "attached"
+// once they are attached to the unit's machine.
+func (st *State) attachingStorageStatusOps(tag names.StorageTag) ([]txn.Op, error) {
+	var globalKeys []string
+	f, err := st.storageInstanceFilesystem(tag)
+	if err == nil {
+		globalKeys = append(globalKeys, f.globalKey())
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	v, err := st.storageInstanceVolume(tag)
+	if err == nil {
+		globalKeys = append(globalKeys, v.globalKey())
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, globalKey := range globalKeys {
+		current, err := getStatus(st, globalKey, "storage")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current.Status != status.Detached {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      statusesC,
+			Id:     st.docID(globalKey),
+			Assert: bson.D{{"status", status.Detached}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", status.Attaching},
+				{"statusinfo", ""},
+				{"updated", st.clock.Now().UnixNano()},
+			}}},
+		})
+	}
+	return ops, nil
+}
+
 // Remove removes the storage attachment from state, and may remove its storage
 // instance as well, if the storage instance is Dying and no other references to
 // it exist. It will fail if the storage attachment is not Dying.
@@ -750,8 +1260,9 @@ func removeStorageAttachmentOps(
 			// Either the storage instance is dying, or its owner
 			// is a unit; in either case, no more attachments can
 			// be added to the instance, so it can be removed.
+			owner, _ := si.Owner()
 			siOps, err := removeStorageInstanceOps(
-				st, si.Owner(), si.StorageTag(), hasLastRef,
+				st, owner, si.StorageTag(), hasLastRef,
 			)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -760,6 +1271,20 @@ func removeStorageAttachmentOps(
 			return ops, nil
 		}
 	}
+	if owner, ok := si.Owner(); ok && owner.Kind() == names.ApplicationTagKind {
+		// The storage is shared with the application's other units,
+		// and will remain attached to their machines; detach it from
+		// the machine that this unit is assigned to.
+		u, err := st.Unit(s.doc.Unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		detachOps, err := st.detachStorageMachineOps(si.StorageTag(), u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	decrefOp := txn.Op{
 		C:      storageInstancesC,
 		Id:     si.doc.Id,
@@ -910,11 +1435,10 @@ func validateStorageConstraintsAgainstCharm(
 		if !ok {
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
-		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
+		if charmStorage.Shared && charmStorage.Type != charm.StorageFilesystem {
 			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
+				"charm %q store %q: shared %s storage not supported",
+				charmMeta.Name, name, charmStorage.Type,
 			)
 		}
 		if cons.Count < uint64(charmStorage.CountMin) {
@@ -941,6 +1465,35 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateStoragePool(st, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if charmStorage.Shared && cons.Count > 0 {
+			if err := validateSharedStoragePool(st, cons.Pool); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSharedStoragePool validates that the storage pool can be used
+// to provision shared storage. Shared storage is attached to the machines
+// of all of an application's units, so it must be a filesystem that is
+// provisioned dynamically and independently of any one machine.
+func validateSharedStoragePool(st *State, poolName string) error {
+	providerType, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron || !provider.Dynamic() {
+		return errors.Errorf(
+			"%q provider does not support shared storage: storage must be dynamically provisioned and model-scoped",
+			providerType,
+		)
+	}
+	if !provider.Supports(storage.StorageKindFilesystem) {
+		return errors.Errorf(
+			"%q provider does not support shared storage: provider does not support filesystems",
+			providerType,
+		)
 	}
 	return nil
 }
@@ -1025,6 +1578,130 @@ func poolStorageProvider(st *State, poolName string) (storage.ProviderType, stor
 	return providerType, provider, nil
 }
 
+// storagePoolRefOps returns txn.Ops that record new references to the
+// named storage pools. Only pools defined by the user, which have
+// settings, are referenced; the version of each pool's settings is
+// incremented so that a concurrent RemoveStoragePool will abort.
+func storagePoolRefOps(st *State, poolNames ...string) ([]txn.Op, error) {
+	var ops []txn.Op
+	seen := set.NewStrings()
+	for _, poolName := range poolNames {
+		if poolName == "" || seen.Contains(poolName) {
+			continue
+		}
+		seen.Add(poolName)
+		key := poolmanager.SettingsKey(poolName)
+		if _, err := readSettings(st, settingsC, key); errors.IsNotFound(err) {
+			// The pool is a provider type, or a default
+			// pool, neither of which can be removed.
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading pool %q", poolName)
+		}
+		ops = append(ops, txn.Op{
+			C:      settingsC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$inc", bson.D{{"version", 1}}}},
+		})
+	}
+	return ops, nil
+}
+
+// storageConstraintsPoolRefOps returns txn.Ops that record references
+// to the storage pools named in the specified storage constraints.
+func storageConstraintsPoolRefOps(st *State, allCons map[string]StorageConstraints) ([]txn.Op, error) {
+	poolNames := make([]string, 0, len(allCons))
+	for _, cons := range allCons {
+		poolNames = append(poolNames, cons.Pool)
+	}
+	return storagePoolRefOps(st, poolNames...)
+}
+
+// RemoveStoragePool removes the storage pool with the specified name.
+// A pool may not be removed while any volume or filesystem is in the
+// pool, or while any application's storage constraints name the pool.
+func (st *State) RemoveStoragePool(poolName string) error {
+	key := poolmanager.SettingsKey(poolName)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		settings, err := readSettings(st, settingsC, key)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("pool %q", poolName)
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading pool %q", poolName)
+		}
+		inUse, err := storagePoolInUse(st, poolName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inUse {
+			return nil, errors.Errorf("storage pool %q in use", poolName)
+		}
+		// Anything that comes to use the pool increments the
+		// version of its settings, so asserting that they are
+		// unchanged ensures that the pool is still unused.
+		op := settings.assertUnchangedOp()
+		op.Remove = true
+		return []txn.Op{op}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// storagePoolInUse reports whether or not any volume or filesystem in
+// the model was created in, or is to be created in, the named pool, or
+// any storage constraints name the pool.
+func storagePoolInUse(st *State, poolName string) (bool, error) {
+	inPool := bson.D{{"$or", []bson.D{
+		{{"params.pool", poolName}},
+		{{"info.pool", poolName}},
+	}}}
+	for _, collName := range []string{volumesC, filesystemsC} {
+		coll, closer := st.getCollection(collName)
+		n, err := coll.Find(inPool).Count()
+		closer()
+		if err != nil {
+			return false, errors.Annotatef(err, "querying %s", collName)
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	coll, closer := st.getCollection(storageConstraintsC)
+	defer closer()
+	var docs []storageConstraintsDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return false, errors.Annotate(err, "querying storage constraints")
+	}
+	for _, doc := range docs {
+		for _, cons := range doc.Constraints {
+			if cons.Pool == poolName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// storagePoolEncrypted reports whether or not the named storage pool
+// requests that its storage be encrypted.
+func storagePoolEncrypted(st *State, poolName string) (bool, error) {
+	registry, err := st.storageProviderRegistry()
+	if err != nil {
+		return false, errors.Annotate(err, "getting storage provider registry")
+	}
+	poolManager := poolmanager.New(NewStateSettings(st), registry)
+	pool, err := poolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		// The pool is a provider type, which has no attributes.
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	encrypted, err := storage.Encrypted(pool.Attrs())
+	return encrypted, errors.Trace(err)
+}
+
 // ErrNoDefaultStoragePool is returned when a storage pool is required but none
 // is specified nor available as a default.
 var ErrNoDefaultStoragePool = fmt.Errorf("no storage pool specifed and no default available")
@@ -1041,6 +1718,11 @@ func addDefaultStorageConstraints(st *State, allCons map[string]StorageConstrain
 		cons, ok := allCons[name]
 		if !ok {
 			if charmStorage.Shared {
+				if charmStorage.CountMin == 0 {
+					// The shared storage is optional, so
+					// none will be created by default.
+					continue
+				}
 				// TODO(axw) get the model's default shared storage
 				// pool, and create constraints here.
 				return errors.Errorf(
@@ -1171,31 +1853,11 @@ func (st *State) addStorageForUnitOps(
 
 	// Storage addition is based on the charm metadata, so make sure that
 	// the charm URL for the unit or application does not change during
-	// the transaction. If the unit does not have a charm URL set yet,
-	// then we use the application's charm URL.
-	ops := []txn.Op{{
-		C:      unitsC,
-		Id:     u.doc.Name,
-		Assert: bson.D{{"charmurl", u.doc.CharmURL}},
-	}}
-	curl, ok := u.CharmURL()
-	if !ok {
-		a, err := u.Application()
-		if err != nil {
-			return nil, errors.Annotatef(err, "getting application for unit %v", u.doc.Name)
-		}
-		curl = a.doc.CharmURL
-		ops = append(ops, txn.Op{
-			C:      applicationsC,
-			Id:     a.doc.Name,
-			Assert: bson.D{{"charmurl", curl}},
-		})
-	}
-	ch, err := st.Charm(curl)
+	// the transaction.
+	charmMeta, ops, err := st.unitCharmMetaOps(u)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	charmMeta := ch.Meta()
 	charmStorageMeta, ok := charmMeta.Storage[storageName]
 	if !ok {
 		return nil, errors.NotFoundf("charm storage %q", storageName)
@@ -1231,6 +1893,36 @@ func (st *State) addStorageForUnitOps(
 	return ops, nil
 }
 
+// unitCharmMetaOps returns the metadata of the charm that the unit is
+// running, along with txn.Ops that ensure the charm URL of the unit does
+// not change. If the unit does not have a charm URL set yet, then we use
+// the application's charm URL.
+func (st *State) unitCharmMetaOps(u *Unit) (*charm.Meta, []txn.Op, error) {
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.Name,
+		Assert: bson.D{{"charmurl", u.doc.CharmURL}},
+	}}
+	curl, ok := u.CharmURL()
+	if !ok {
+		a, err := u.Application()
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "getting application for unit %v", u.doc.Name)
+		}
+		curl = a.doc.CharmURL
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.Name,
+			Assert: bson.D{{"charmurl", curl}},
+		})
+	}
+	ch, err := st.Charm(curl)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return ch.Meta(), ops, nil
+}
+
 // addUnitStorageOps returns transaction ops to create storage for the given
 // unit. If countMin is non-negative, the Count field of the constraints will
 // be ignored, and as many storage instances as necessary to make up the
@@ -546,7 +546,9 @@ func (s *StorageStateSuite) TestAllStorageInstances(c *gc.C) {
 	for _, one := range all {
 		c.Assert(one.Kind(), gc.DeepEquals, state.StorageKindBlock)
 		c.Assert(nameSet.Contains(one.StorageName()), jc.IsTrue)
-		c.Assert(ownerSet.Contains(one.Owner().String()), jc.IsTrue)
+		owner, ok := one.Owner()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ownerSet.Contains(owner.String()), jc.IsTrue)
 	}
 }
 
@@ -795,6 +797,156 @@ func (s *StorageStateSuite) TestDestroyUnitStorageAttachments(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// setupDetachableStorage adds a unit of the storage-block charm, with its
+// optional "allecto" storage provisioned from a dynamic, model-scoped pool,
+// and assigns it to a new machine. The unit and the tag of its "allecto"
+// storage instance are returned.
+func (s *StorageStateSuite) setupDetachableStorage(c *gc.C) (*state.Application, *state.Unit, names.StorageTag) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop-pool", 1024, 1),
+		"allecto": makeStorageCons("persistent-block", 1024, 1),
+	})
+	u := s.addAssignedUnit(c, app)
+	return app, u, s.unitStorageTag(c, u.UnitTag(), "allecto")
+}
+
+func (s *StorageStateSuite) addAssignedUnit(c *gc.C, app *state.Application) *state.Unit {
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	return u
+}
+
+func (s *StorageStateSuite) unitStorageTag(c *gc.C, unit names.UnitTag, storageName string) names.StorageTag {
+	attachments, err := s.State.UnitStorageAttachments(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, a := range attachments {
+		si, err := s.State.StorageInstance(a.StorageInstance())
+		c.Assert(err, jc.ErrorIsNil)
+		if si.StorageName() == storageName {
+			return si.StorageTag()
+		}
+	}
+	c.Fatalf("unit %s has no %q storage", unit.Id(), storageName)
+	panic("unreachable")
+}
+
+func (s *StorageStateSuite) unitMachineTag(c *gc.C, u *state.Unit) names.MachineTag {
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return names.NewMachineTag(machineId)
+}
+
+func (s *StorageStateSuite) TestDetachStorage(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c)
+	volume := s.storageInstanceVolume(c, storageTag)
+	machineTag := s.unitMachineTag(c, u)
+
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	attachment, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	c.Assert(s.volumeAttachment(c, machineTag, volume.VolumeTag()).Life(), gc.Equals, state.Dying)
+	c.Assert(s.volume(c, volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+
+	// Detaching again is a no-op.
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsTrue)
+}
+
+func (s *StorageStateSuite) TestDetachStorageRequiredByCharm(c *gc.C) {
+	_, u, _ := s.setupDetachableStorage(c)
+	storageTag := s.unitStorageTag(c, u.UnitTag(), "data")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage .* from unit storage-block/0: charm requires at least 1 "data" storage instance\(s\)`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageMachineScoped(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop-pool", 1024, 1),
+		"allecto": makeStorageCons("loop-pool", 1024, 1),
+	})
+	u := s.addAssignedUnit(c, app)
+	storageTag := s.unitStorageTag(c, u.UnitTag(), "allecto")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage .*: volume .* cannot be detached from its machine`)
+}
+
+func (s *StorageStateSuite) TestAttachStorage(c *gc.C) {
+	app, u, storageTag := s.setupDetachableStorage(c)
+	volume := s.storageInstanceVolume(c, storageTag)
+	u2 := s.addAssignedUnit(c, app)
+
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-block/1: storage instance is still being detached`)
+
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-block/1: charm allows at most 1 "allecto" storage instance\(s\)`)
+
+	// Detach the unit's own "allecto" storage to make room.
+	err = s.State.DetachStorage(s.unitStorageTag(c, u2.UnitTag(), "allecto"), u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+	attachment, err := s.State.StorageAttachment(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	// The existing volume is attached to the new unit's machine.
+	machineTag := s.unitMachineTag(c, u2)
+	c.Assert(s.volumeAttachment(c, machineTag, volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+	assertMachineStorageRefs(c, s.State, machineTag)
+}
+
+func (s *StorageStateSuite) TestAttachStorageOwned(c *gc.C) {
+	app, _, storageTag := s.setupDetachableStorage(c)
+	u2 := s.addAssignedUnit(c, app)
+	err := s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-block/1: storage instance is owned by unit storage-block/0`)
+}
+
+func (s *StorageStateSuite) TestReleaseStorageInstance(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ReleaseStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsFalse)
+
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Releasing(), jc.IsTrue)
+}
+
 func (s *StorageStateSuite) TestStorageLocationConflictIdentical(c *gc.C) {
 	s.testStorageLocationConflict(
 		c, "/srv", "/srv",
@@ -923,6 +1075,66 @@ func (s *StorageStateSuite) TestNewModelDefaultPools(c *gc.C) {
 	c.Assert(listed, jc.DeepEquals, []*storage.Config{blackPool, radiancePool})
 }
 
+func (s *StorageStateSuite) addVolumeMachine(c *gc.C, pool string) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: pool, Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestRemoveStoragePool(c *gc.C) {
+	err := s.State.RemoveStoragePool("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+
+	pm := poolmanager.New(state.NewStateSettings(s.State), dummy.StorageProviders())
+	_, err = pm.Get("loop-pool")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageStateSuite) TestRemoveStoragePoolNotFound(c *gc.C) {
+	err := s.State.RemoveStoragePool("missing")
+	c.Assert(err, gc.ErrorMatches, `pool "missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageStateSuite) TestRemoveStoragePoolInUseByVolume(c *gc.C) {
+	s.addVolumeMachine(c, "loop-pool")
+	err := s.State.RemoveStoragePool("loop-pool")
+	c.Assert(err, gc.ErrorMatches, `storage pool "loop-pool" in use`)
+}
+
+func (s *StorageStateSuite) TestRemoveStoragePoolInUseByStorageConstraints(c *gc.C) {
+	// The application has no units, and so no volumes, but
+	// units added later would have volumes in the pool.
+	ch := s.AddTestingCharm(c, "storage-block")
+	s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	})
+	err := s.State.RemoveStoragePool("loop-pool")
+	c.Assert(err, gc.ErrorMatches, `storage pool "loop-pool" in use`)
+}
+
+func (s *StorageStateSuite) TestRemoveStoragePoolConcurrentUse(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		s.addVolumeMachine(c, "loop-pool")
+	}).Check()
+	err := s.State.RemoveStoragePool("loop-pool")
+	c.Assert(err, gc.ErrorMatches, `storage pool "loop-pool" in use`)
+}
+
+func (s *StorageStateSuite) TestRemoveStoragePoolConcurrentRemove(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.RemoveStoragePool("loop-pool")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	err := s.State.RemoveStoragePool("loop-pool")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type byStorageConfigName []*storage.Config
 
 func (c byStorageConfigName) Len() int {
@@ -937,9 +1149,179 @@ func (c byStorageConfigName) Swap(a, b int) {
 	c[a], c[b] = c[b], c[a]
 }
 
+func (s *StorageStateSuite) addSharedStorageService(c *gc.C, numUnits int) *state.Application {
+	ch := s.createStorageCharm(c, "shared-storage", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	service, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "shared-storage",
+		Charm:    ch,
+		NumUnits: numUnits,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("environscoped", 1024, 1),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return service
+}
+
+func (s *StorageStateSuite) TestAddServiceSharedStorage(c *gc.C) {
+	service := s.addSharedStorageService(c, 2)
+	storageTag := names.NewStorageTag("data/0")
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindFilesystem)
+	owner, ok := storageInstance.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, service.Tag())
+
+	storageAttachments, err := s.State.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageAttachments, gc.HasLen, 2)
+	units := make(set.Strings)
+	for _, att := range storageAttachments {
+		units.Add(att.Unit().Id())
+	}
+	c.Assert(units.SortedValues(), jc.DeepEquals, []string{"shared-storage/0", "shared-storage/1"})
+
+	// The filesystem is model-scoped, and is not attached to any
+	// machine until the units are assigned.
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag("0"))
+	attachments, err := s.State.FilesystemAttachments(filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 0)
+}
+
+func (s *StorageStateSuite) TestAddServiceSharedStorageValidation(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-block", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageBlock,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared-block",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("environscoped", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "shared-block": charm "shared-block" store "data": shared block storage not supported`)
+
+	ch = s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	for _, pool := range []string{"machinescoped", "environscoped-block"} {
+		_, err = s.State.AddApplication(state.AddApplicationArgs{
+			Name:  "shared-filesystem",
+			Charm: ch,
+			Storage: map[string]state.StorageConstraints{
+				"data": makeStorageCons(pool, 1024, 1),
+			},
+		})
+		c.Check(err, gc.ErrorMatches, `cannot add application "shared-filesystem": charm "shared-filesystem" store "data": ".*" provider does not support shared storage: .*`)
+	}
+	_, err = s.State.AddApplication(state.AddApplicationArgs{Name: "shared-filesystem", Charm: ch})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "shared-filesystem": no constraints specified for shared charm storage "data"`)
+}
+
+func (s *StorageStateSuite) TestAddServiceSharedStorageSharedfsPool(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State), dummy.StorageProviders())
+	_, err := pm.Create("shared-dir", provider.SharedfsProviderType, map[string]interface{}{
+		"path": "/srv/shared",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.createStorageCharm(c, "shared-filesystem", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared-filesystem",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("shared-dir", 1024, 1),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, names.NewStorageTag("data/0"))
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag("0"))
+	params, ok := filesystem.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "shared-dir")
+}
+
+func (s *StorageStateSuite) TestAddUnitSharedStorage(c *gc.C) {
+	service := s.addSharedStorageService(c, 0)
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	storageAttachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageAttachments, gc.HasLen, 1)
+	storageTag := storageAttachments[0].StorageInstance()
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+
+	// Assigning the unit to a machine attaches the shared
+	// filesystem to the machine.
+	err = u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	attachment := s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	// Removing the unit's storage attachment detaches the filesystem
+	// from the unit's machine, but leaves the shared storage intact.
+	err = s.State.DestroyUnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment = s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsTrue)
+}
+
+func (s *StorageStateSuite) TestAddUnitSharedStorageDying(c *gc.C) {
+	service := s.addSharedStorageService(c, 1)
+	err := s.State.DestroyStorageInstance(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Dying shared storage is not attached to new units.
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	storageAttachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageAttachments, gc.HasLen, 0)
+}
+
+func (s *StorageStateSuite) TestRemoveServiceRemovesSharedStorage(c *gc.C) {
+	service := s.addSharedStorageService(c, 0)
+	storageTag := names.NewStorageTag("data/0")
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	err := service.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsFalse)
+	c.Assert(s.filesystem(c, filesystemTag).Life(), gc.Equals, state.Dead)
+}
+
 // TODO(axw) the following require shared storage support to test:
-// - StorageAttachments can't be added to Dying StorageInstance
-// - StorageInstance without attachments is removed by Destroy
 // - concurrent add-unit and StorageAttachment removal does not
 //   remove storage instance.
 
@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// StorageUsage describes how much of a volume or filesystem's capacity
+// is in use, as reported by the agent of a machine it is attached to.
+type StorageUsage struct {
+	// Capacity is the total capacity, in bytes.
+	Capacity uint64 `bson:"capacity"`
+
+	// Used is the amount of the capacity in use, in bytes.
+	Used uint64 `bson:"used"`
+}
+
+// Percent returns the percentage of the capacity that is in use,
+// rounded down.
+func (u StorageUsage) Percent() int {
+	if u.Capacity == 0 {
+		return 0
+	}
+	return int(u.Used * 100 / u.Capacity)
+}
+
+// SetFilesystemUsage records the usage of the specified filesystem. If
+// the usage crosses the model's storage usage warning threshold, the
+// filesystem's status message is updated accordingly.
+func (st *State) SetFilesystemUsage(tag names.FilesystemTag, usage StorageUsage) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage of filesystem %q", tag.Id())
+	f, err := st.filesystemByTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.setStorageUsage(filesystemsC, tag.Id(), usage); err != nil {
+		return errors.Trace(err)
+	}
+	return st.updateStorageUsageStatus(filesystemGlobalKey(tag.Id()), "filesystem", f.doc.Usage, usage)
+}
+
+// SetVolumeUsage records the usage of the specified volume. If the
+// usage crosses the model's storage usage warning threshold, the
+// volume's status message is updated accordingly.
+func (st *State) SetVolumeUsage(tag names.VolumeTag, usage StorageUsage) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage of volume %q", tag.Id())
+	v, err := st.volumeByTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.setStorageUsage(volumesC, tag.Id(), usage); err != nil {
+		return errors.Trace(err)
+	}
+	return st.updateStorageUsageStatus(volumeGlobalKey(tag.Id()), "volume", v.doc.Usage, usage)
+}
+
+func (st *State) setStorageUsage(collection, id string, usage StorageUsage) error {
+	if usage.Used > usage.Capacity {
+		return errors.Errorf(
+			"used %d bytes exceeds capacity %d bytes",
+			usage.Used, usage.Capacity,
+		)
+	}
+	ops := []txn.Op{{
+		C:      collection,
+		Id:     id,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"usage", usage}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("storage is dead")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// updateStorageUsageStatus sets or clears a warning in the status message
+// of an attached volume or filesystem, when its usage crosses the model's
+// storage usage warning threshold.
+func (st *State) updateStorageUsageStatus(globalKey, badge string, oldUsage *StorageUsage, usage StorageUsage) error {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	threshold := cfg.StorageUsageWarningThreshold()
+	if threshold == 0 {
+		return nil
+	}
+	wasOver := oldUsage != nil && oldUsage.Percent() >= threshold
+	isOver := usage.Percent() >= threshold
+	switch {
+	case !wasOver && !isOver:
+		return nil
+	case wasOver && isOver && oldUsage.Percent() == usage.Percent():
+		return nil
+	}
+
+	// Only attached storage carries a usage warning, so as not
+	// to mask any other status set by the storage provisioner.
+	current, err := getStatus(st, globalKey, badge)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != status.Attached {
+		return nil
+	}
+	var message string
+	if isOver {
+		message = fmt.Sprintf(
+			"%d%% of capacity used, exceeding warning threshold of %d%%",
+			usage.Percent(), threshold,
+		)
+	}
+	now := st.clock.Now()
+	return setStatus(st, setStatusParams{
+		badge:     badge,
+		globalKey: globalKey,
+		status:    status.Attached,
+		message:   message,
+		updated:   &now,
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type StorageUsageSuite struct {
+	StorageStateSuiteBase
+	filesystemTag names.FilesystemTag
+	volumeTag     names.VolumeTag
+}
+
+var _ = gc.Suite(&StorageUsageSuite{})
+
+func (s *StorageUsageSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{
+				Pool: "environscoped", Size: 1024,
+			},
+		}},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{
+				Pool: "environscoped", Size: 1024,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystemAttachments, err := s.State.MachineFilesystemAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystemAttachments, gc.HasLen, 1)
+	s.filesystemTag = filesystemAttachments[0].Filesystem()
+
+	volumeAttachments, err := s.State.MachineVolumeAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeAttachments, gc.HasLen, 1)
+	s.volumeTag = volumeAttachments[0].Volume()
+}
+
+func (s *StorageUsageSuite) TestSetFilesystemUsage(c *gc.C) {
+	_, ok := s.filesystem(c, s.filesystemTag).Usage()
+	c.Assert(ok, jc.IsFalse)
+
+	usage := state.StorageUsage{Capacity: 1000, Used: 250}
+	err := s.State.SetFilesystemUsage(s.filesystemTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, ok := s.filesystem(c, s.filesystemTag).Usage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(stored, jc.DeepEquals, usage)
+	c.Assert(stored.Percent(), gc.Equals, 25)
+}
+
+func (s *StorageUsageSuite) TestSetVolumeUsage(c *gc.C) {
+	_, ok := s.volume(c, s.volumeTag).Usage()
+	c.Assert(ok, jc.IsFalse)
+
+	usage := state.StorageUsage{Capacity: 2000, Used: 500}
+	err := s.State.SetVolumeUsage(s.volumeTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, ok := s.volume(c, s.volumeTag).Usage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(stored, jc.DeepEquals, usage)
+}
+
+func (s *StorageUsageSuite) TestSetFilesystemUsageExceedsCapacity(c *gc.C) {
+	err := s.State.SetFilesystemUsage(s.filesystemTag, state.StorageUsage{Capacity: 1000, Used: 2000})
+	c.Assert(err, gc.ErrorMatches, `cannot set usage of filesystem "0": used 2000 bytes exceeds capacity 1000 bytes`)
+}
+
+func (s *StorageUsageSuite) TestSetFilesystemUsageNotFound(c *gc.C) {
+	err := s.State.SetFilesystemUsage(names.NewFilesystemTag("42"), state.StorageUsage{Capacity: 1000})
+	c.Assert(err, gc.ErrorMatches, `cannot set usage of filesystem "42": filesystem "42" not found`)
+}
+
+func (s *StorageUsageSuite) TestSetFilesystemUsageWarning(c *gc.C) {
+	err := s.State.SetFilesystemStatus(s.filesystemTag, status.Attached, "", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setFilesystemUsage(c, 950)
+	s.assertFilesystemStatus(c, status.Attached, "95% of capacity used, exceeding warning threshold of 90%")
+	s.setFilesystemUsage(c, 980)
+	s.assertFilesystemStatus(c, status.Attached, "98% of capacity used, exceeding warning threshold of 90%")
+	s.setFilesystemUsage(c, 500)
+	s.assertFilesystemStatus(c, status.Attached, "")
+}
+
+func (s *StorageUsageSuite) TestSetFilesystemUsageWarningThreshold(c *gc.C) {
+	err := s.State.SetFilesystemStatus(s.filesystemTag, status.Attached, "", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"storage-usage-warning-threshold": 50,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setFilesystemUsage(c, 600)
+	s.assertFilesystemStatus(c, status.Attached, "60% of capacity used, exceeding warning threshold of 50%")
+
+	err = s.State.SetFilesystemStatus(s.filesystemTag, status.Attached, "", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"storage-usage-warning-threshold": 0,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setFilesystemUsage(c, 990)
+	s.assertFilesystemStatus(c, status.Attached, "")
+}
+
+func (s *StorageUsageSuite) TestSetFilesystemUsageWarningNotAttached(c *gc.C) {
+	s.setFilesystemUsage(c, 950)
+	s.assertFilesystemStatus(c, status.Pending, "")
+}
+
+func (s *StorageUsageSuite) TestSetVolumeUsageWarning(c *gc.C) {
+	err := s.State.SetVolumeStatus(s.volumeTag, status.Attached, "", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeUsage(s.volumeTag, state.StorageUsage{Capacity: 1000, Used: 900})
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err := s.volume(c, s.volumeTag).Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Attached)
+	c.Assert(statusInfo.Message, gc.Equals, "90% of capacity used, exceeding warning threshold of 90%")
+}
+
+func (s *StorageUsageSuite) setFilesystemUsage(c *gc.C, used uint64) {
+	err := s.State.SetFilesystemUsage(s.filesystemTag, state.StorageUsage{Capacity: 1000, Used: used})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageUsageSuite) assertFilesystemStatus(c *gc.C, expect status.Status, message string) {
+	statusInfo, err := s.filesystem(c, s.filesystemTag).Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, expect)
+	c.Assert(statusInfo.Message, gc.Equals, message)
+}
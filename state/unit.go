@@ -1830,7 +1830,14 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
+		volume, err := st.storageInstanceVolume(storage.StorageTag())
+		if err == nil {
+			// The storage instance already has a volume, either
+			// because it is shared by the application's units or
+			// because it has been detached from another unit; we
+			// will just add an attachment.
+			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
+		} else if owner, _ := storage.Owner(); errors.IsNotFound(err) && owner == unit {
 			// The storage instance is owned by the unit, so we'll need
 			// to create a volume.
 			cons := allCons[storage.StorageName()]
@@ -1844,14 +1851,7 @@ func machineStorageParamsForStorageInstance(
 				volumeParams, volumeAttachmentParams,
 			})
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) volume already, for which we will
-			// just add an attachment.
-			volume, err := st.StorageInstanceVolume(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
-			}
-			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
+			return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
 		}
 	case StorageKindFilesystem:
 		location, err := filesystemMountPoint(charmStorage, storage.StorageTag(), series)
@@ -1866,7 +1866,14 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
+		filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+		if err == nil {
+			// The storage instance already has a filesystem, either
+			// because it is shared by the application's units or
+			// because it has been detached from another unit; we
+			// will just add an attachment.
+			filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
+		} else if owner, _ := storage.Owner(); errors.IsNotFound(err) && owner == unit {
 			// The storage instance is owned by the unit, so we'll need
 			// to create a filesystem.
 			cons := allCons[storage.StorageName()]
@@ -1880,14 +1887,7 @@ func machineStorageParamsForStorageInstance(
 				filesystemParams, filesystemAttachmentParams,
 			})
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) filesystem already, for which we will
-			// just add an attachment.
-			filesystem, err := st.StorageInstanceFilesystem(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
-			}
-			filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
+			return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storage.Kind())
@@ -2208,22 +2208,35 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout is part of the ActionReceiver interface.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
+	return u.addAction(name, payload, actionOptions{timeout: timeout})
+}
+
+// addAction validates the payload against the named action's spec, and
+// enqueues the action with its defaults filled in. Whether the action
+// may run in parallel is taken from the spec, not from opts.
+func (u *Unit) addAction(name string, payload map[string]interface{}, opts actionOptions) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
 
 	// If the action is predefined inside juju, get spec from map
-	spec, ok := actions.PredefinedActionsSpec[name]
+	charmSpec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		specs, err := u.ActionSpecs()
 		if err != nil {
 			return nil, err
 		}
-		spec, ok = specs[name]
+		charmSpec, ok = specs[name]
 		if !ok {
 			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
 		}
 	}
+	spec := actions.NewSpec(charmSpec)
 	// Reject bad payloads before attempting to insert defaults.
 	err := spec.ValidateParams(payload)
 	if err != nil {
@@ -2233,7 +2246,8 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	opts.parallel = spec.Parallel
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, opts)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
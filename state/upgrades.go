@@ -5,6 +5,7 @@ package state
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -141,6 +142,32 @@ func StripLocalUserDomain(st *State) error {
 	return st.runRawTransaction(ops)
 }
 
+// AddAuditEntryTimes sets the time field, used to query and sort audit
+// entries, on audit entries written before it was introduced.
+func AddAuditEntryTimes(st *State) error {
+	coll, closer := st.getRawCollection(auditingC)
+	defer closer()
+	upgradesLogger.Infof("adding time to audit entries")
+
+	iter := coll.Find(bson.D{{"time", bson.D{{"$exists", false}}}}).Select(bson.D{{"timestamp", 1}}).Iter()
+	defer iter.Close()
+	var doc struct {
+		Id        interface{} `bson:"_id"`
+		Timestamp string      `bson:"timestamp"`
+	}
+	for iter.Next(&doc) {
+		var timestamp time.Time
+		if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			return errors.Annotatef(err, "cannot parse timestamp of audit entry %v", doc.Id)
+		}
+		err := coll.UpdateId(doc.Id, bson.D{{"$set", bson.D{{"time", timestamp.UnixNano()}}}})
+		if err != nil {
+			return errors.Annotatef(err, "cannot update audit entry %v", doc.Id)
+		}
+	}
+	return errors.Trace(iter.Err())
+}
+
 func stripLocalFromFields(st *State, collName string, fields ...string) ([]txn.Op, error) {
 	coll, closer := st.getRawCollection(collName)
 	defer closer()
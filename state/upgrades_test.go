@@ -361,3 +361,36 @@ func (s *upgradesSuite) TestRenameAddModelPermission(c *gc.C) {
 	}}
 	s.assertUpgradedData(c, RenameAddModelPermission, coll, expected)
 }
+
+func (s *upgradesSuite) TestAddAuditEntryTimes(c *gc.C) {
+	coll, closer := s.state.getRawCollection(auditingC)
+	defer closer()
+
+	err := coll.Insert(
+		bson.M{
+			"_id":       "entry1",
+			"timestamp": "2016-10-01T12:00:00.5Z",
+			"operation": "deploy",
+		},
+		bson.M{
+			"_id":       "entry2",
+			"timestamp": "2016-10-02T12:00:00Z",
+			"time":      int64(1),
+			"operation": "deploy",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []bson.M{{
+		"_id":       "entry1",
+		"timestamp": "2016-10-01T12:00:00.5Z",
+		"time":      time.Date(2016, 10, 1, 12, 0, 0, 5e8, time.UTC).UnixNano(),
+		"operation": "deploy",
+	}, {
+		"_id":       "entry2",
+		"timestamp": "2016-10-02T12:00:00Z",
+		"time":      int64(1),
+		"operation": "deploy",
+	}}
+	s.assertUpgradedData(c, AddAuditEntryTimes, coll, expected)
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
@@ -46,6 +48,28 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// Releasing reports whether the volume is to be released from the
+	// model when it is removed, rather than destroyed.
+	Releasing() bool
+
+	// PendingSize returns the size, in MiB, that the volume has been
+	// requested to grow to. PendingSize returns true if there is a
+	// resize pending, otherwise false.
+	PendingSize() (uint64, bool)
+
+	// SnapshotPending reports whether a snapshot of the volume has
+	// been requested, and not yet taken by the storage provisioner.
+	SnapshotPending() bool
+
+	// Snapshots returns the snapshots of the volume, as last recorded
+	// by the storage provisioner responsible for it.
+	Snapshots() []VolumeSnapshot
+
+	// Usage returns the volume's usage as last reported by a machine
+	// agent. Usage returns true if usage has been reported, otherwise
+	// false.
+	Usage() (StorageUsage, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +115,42 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	Releasing       bool          `bson:"releasing,omitempty"`
+	PendingSize     uint64        `bson:"pendingsize,omitempty"`
+	EncryptionKey   string        `bson:"encryptionkey,omitempty"`
+	Usage           *StorageUsage `bson:"usage,omitempty"`
+
+	// PendingSnapshot holds the time, in nanoseconds since the
+	// epoch, at which a snapshot of the volume was last requested.
+	// It is unset once the snapshot has been taken.
+	PendingSnapshot int64               `bson:"pendingsnapshot,omitempty"`
+	Snapshots       []volumeSnapshotDoc `bson:"snapshots,omitempty"`
+}
+
+// volumeSnapshotDoc records information about a snapshot of a volume.
+type volumeSnapshotDoc struct {
+	SnapshotId string `bson:"snapshotid"`
+	VolumeId   string `bson:"volumeid"`
+	Size       uint64 `bson:"size"`
+	Created    int64  `bson:"created"`
+}
+
+// VolumeSnapshot describes a snapshot of a volume, as recorded by the
+// storage provisioner responsible for volumes that are managed from
+// the machine they are attached to.
+type VolumeSnapshot struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the storage provider's unique ID for the volume
+	// that the snapshot was taken of.
+	VolumeId string
+
+	// Size is the size of the snapshot, in MiB.
+	Size uint64
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -117,6 +177,11 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Encrypted records whether or not the volume's pool requested
+	// encryption when the volume was created. It is set by state,
+	// and is unaffected by later changes to the pool.
+	Encrypted bool `bson:"encrypted,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -126,6 +191,11 @@ type VolumeInfo struct {
 	Pool       string `bson:"pool"`
 	VolumeId   string `bson:"volumeid"`
 	Persistent bool   `bson:"persistent"`
+
+	// Encrypted records whether or not the volume's contents are
+	// encrypted. It is carried over from the volume's parameters
+	// by state, and cannot be changed.
+	Encrypted bool `bson:"encrypted,omitempty"`
 }
 
 // VolumeAttachmentInfo describes information about a volume attachment.
@@ -220,6 +290,43 @@ func (v *volume) StorageInstance() (names.StorageTag, error) {
 	return names.NewStorageTag(v.doc.StorageId), nil
 }
 
+// Releasing is required to implement Volume.
+func (v *volume) Releasing() bool {
+	return v.doc.Releasing
+}
+
+// PendingSize is required to implement Volume.
+func (v *volume) PendingSize() (uint64, bool) {
+	return v.doc.PendingSize, v.doc.PendingSize != 0
+}
+
+// SnapshotPending is required to implement Volume.
+func (v *volume) SnapshotPending() bool {
+	return v.doc.PendingSnapshot != 0
+}
+
+// Snapshots is required to implement Volume.
+func (v *volume) Snapshots() []VolumeSnapshot {
+	snapshots := make([]VolumeSnapshot, len(v.doc.Snapshots))
+	for i, doc := range v.doc.Snapshots {
+		snapshots[i] = VolumeSnapshot{
+			SnapshotId: doc.SnapshotId,
+			VolumeId:   doc.VolumeId,
+			Size:       doc.Size,
+			Created:    time.Unix(0, doc.Created).UTC(),
+		}
+	}
+	return snapshots
+}
+
+// Usage is required to implement Volume.
+func (v *volume) Usage() (StorageUsage, bool) {
+	if v.doc.Usage == nil {
+		return StorageUsage{}, false
+	}
+	return *v.doc.Usage, true
+}
+
 // Info is required to implement Volume.
 func (v *volume) Info() (VolumeInfo, error) {
 	if v.doc.Info == nil {
@@ -236,6 +343,18 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// pool returns the name of the storage pool from which the volume is,
+// or is to be, provisioned.
+func (v *volume) pool() string {
+	if v.doc.Info != nil {
+		return v.doc.Info.Pool
+	}
+	if v.doc.Params != nil {
+		return v.doc.Params.Pool
+	}
+	return ""
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -697,7 +816,7 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 		if volume.Life() != Dead {
 			return nil, errors.New("volume is not dead")
 		}
-		return []txn.Op{
+		ops := []txn.Op{
 			{
 				C:      volumesC,
 				Id:     tag.Id(),
@@ -705,11 +824,75 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 				Remove: true,
 			},
 			removeStatusOp(st, volumeGlobalKey(tag.Id())),
-		}, nil
+		}
+		if info, err := volume.Info(); err == nil {
+			imported, err := st.isImportedVolume(info.VolumeId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if imported {
+				ops = append(ops, txn.Op{
+					C:      providerIDsC,
+					Id:     st.importedVolumeKey(info.VolumeId),
+					Remove: true,
+				})
+			}
+		}
+		return ops, nil
 	}
 	return st.run(buildTxn)
 }
 
+// importedVolumeKey returns the ID of the document recording that the
+// volume with the given provider ID was imported into the model.
+func (st *State) importedVolumeKey(volumeId string) string {
+	return st.docID("volume:" + volumeId)
+}
+
+// importedVolumeOp returns the operation needed to record that the
+// volume with the given provider ID has been imported into the model.
+// The operation fails if the volume has already been imported.
+func (st *State) importedVolumeOp(volumeId string) txn.Op {
+	key := st.importedVolumeKey(volumeId)
+	return txn.Op{
+		C:      providerIDsC,
+		Id:     key,
+		Assert: txn.DocMissing,
+		Insert: providerIdDoc{ID: key},
+	}
+}
+
+// isImportedVolume reports whether the volume with the given provider
+// ID has been imported into the model.
+func (st *State) isImportedVolume(volumeId string) (bool, error) {
+	providerIDs, closer := st.getCollection(providerIDsC)
+	defer closer()
+	n, err := providerIDs.FindId(st.importedVolumeKey(volumeId)).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return n > 0, nil
+}
+
+// checkVolumeNotInModel returns an AlreadyExists error if the volume
+// with the given provider ID is already in the model.
+func (st *State) checkVolumeNotInModel(volumeId string) error {
+	imported, err := st.isImportedVolume(volumeId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumes, closer := st.getCollection(volumesC)
+	defer closer()
+	n, err := volumes.Find(bson.D{{"info.volumeid", volumeId}}).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if imported || n > 0 {
+		return errors.AlreadyExistsf("volume %q", volumeId)
+	}
+	return nil
+}
+
 // newVolumeName returns a unique volume name.
 // If the machine ID supplied is non-empty, the
 // volume ID will incorporate it as the volume's
@@ -738,6 +921,10 @@ func (st *State) addVolumeOps(params VolumeParams, machineId string) ([]txn.Op,
 	if err != nil {
 		return nil, names.VolumeTag{}, errors.Trace(err)
 	}
+	params.Encrypted, err = storagePoolEncrypted(st, params.Pool)
+	if err != nil {
+		return nil, names.VolumeTag{}, errors.Trace(err)
+	}
 	machineId, err = st.validateVolumeParams(params, machineId)
 	if err != nil {
 		return nil, names.VolumeTag{}, errors.Annotate(err, "validating volume params")
@@ -758,7 +945,12 @@ func (st *State) addVolumeOps(params VolumeParams, machineId string) ([]txn.Op,
 		// Every volume is created with one attachment.
 		AttachmentCount: 1,
 	}
-	return st.newVolumeOps(doc, status), names.NewVolumeTag(name), nil
+	ops, err := storagePoolRefOps(st, params.Pool)
+	if err != nil {
+		return nil, names.VolumeTag{}, errors.Trace(err)
+	}
+	ops = append(ops, st.newVolumeOps(doc, status)...)
+	return ops, names.NewVolumeTag(name), nil
 }
 
 func (st *State) newVolumeOps(doc volumeDoc, status statusDoc) []txn.Op {
@@ -967,6 +1159,7 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 		var ops []txn.Op
 		if params, ok := v.Params(); ok {
 			info.Pool = params.Pool
+			info.Encrypted = params.Encrypted
 			unsetParams = true
 		} else {
 			// Ensure immutable properties do not change.
@@ -974,16 +1167,266 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			if err != nil {
 				return nil, err
 			}
+			info.Encrypted = oldInfo.Encrypted
 			if err := validateVolumeInfoChange(info, oldInfo); err != nil {
 				return nil, err
 			}
 		}
-		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		// If the volume has grown to the size requested
+		// by a pending resize, the resize is complete.
+		pendingSize, resizing := v.PendingSize()
+		unsetPendingSize := resizing && info.Size >= pendingSize
+		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams, unsetPendingSize)...)
+		if unsetPendingSize {
+			// The filesystem on the volume, if any, must
+			// now be grown to fill the volume.
+			resizeOps, err := st.resizeVolumeFilesystemOps(tag, info.Size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, resizeOps...)
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
 }
 
+// resizeVolumeFilesystemOps returns the operations required to request
+// that the provisioned filesystem backed by the specified volume, if
+// any, be grown to the given size, in MiB.
+func (st *State) resizeVolumeFilesystemOps(tag names.VolumeTag, size uint64) ([]txn.Op, error) {
+	f, err := st.volumeFilesystem(tag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if f.Life() != Alive {
+		return nil, nil
+	}
+	info, err := f.Info()
+	if errors.IsNotProvisioned(err) {
+		// The filesystem will be created to fill the volume.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.doc.FilesystemId,
+		Assert: append(isAliveDoc, bson.DocElem{"info.size", info.Size}),
+		Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+	}}, nil
+}
+
+// RestoreVolumeInfo replaces the information of a volume that has been
+// restored from a snapshot. Unlike SetVolumeInfo, the volume ID may
+// change, as some providers restore a snapshot to a new volume. The
+// volume must be provisioned, alive, and not attached to any machine.
+func (st *State) RestoreVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore info for volume %q", tag.Id())
+	if info.VolumeId == "" {
+		return errors.New("volume ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		oldInfo, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.doc.AttachmentCount > 0 {
+			return nil, errors.New("volume is attached")
+		}
+		info.Pool = oldInfo.Pool
+		info.Encrypted = oldInfo.Encrypted
+		asserts := append(isAliveDoc, bson.DocElem{"attachmentcount", 0})
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: asserts,
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeVolume requests that the volume with the specified tag be
+// grown to the given size, in MiB. The volume must be provisioned
+// and alive, and the new size must be larger than the current size.
+// The storage provisioner responsible for the volume will resize it,
+// completing the request when it records the new size with
+// SetVolumeInfo.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if pendingSize, ok := v.PendingSize(); ok && pendingSize == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		asserts := append(isAliveDoc, bson.DocElem{"info.size", info.Size})
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: asserts,
+			Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RequestVolumeSnapshot requests that a snapshot be taken of the volume
+// with the specified tag. The volume must be provisioned and alive. The
+// storage provisioner responsible for the volume will take the snapshot,
+// completing the request when it records the volume's snapshots with
+// SetVolumeSnapshots. Requesting a snapshot while one is pending is a
+// no-op.
+func (st *State) RequestVolumeSnapshot(tag names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot request snapshot of volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		if _, err := v.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.SnapshotPending() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		asserts := append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}})
+		asserts = append(asserts, bson.DocElem{"pendingsnapshot", bson.D{{"$exists", false}}})
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: asserts,
+			Update: bson.D{{"$set", bson.D{
+				{"pendingsnapshot", st.clock.Now().UnixNano()},
+			}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetVolumeSnapshots records the snapshots of the volume with the
+// specified tag, completing any pending snapshot request.
+func (st *State) SetVolumeSnapshots(tag names.VolumeTag, snapshots []VolumeSnapshot) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set snapshots of volume %q", tag.Id())
+	docs := make([]volumeSnapshotDoc, len(snapshots))
+	for i, snapshot := range snapshots {
+		if snapshot.SnapshotId == "" {
+			return errors.New("snapshot ID not set")
+		}
+		docs[i] = volumeSnapshotDoc{
+			SnapshotId: snapshot.SnapshotId,
+			VolumeId:   snapshot.VolumeId,
+			Size:       snapshot.Size,
+			Created:    snapshot.Created.UnixNano(),
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.volumeByTag(tag); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: txn.DocExists,
+			Update: bson.D{
+				{"$set", bson.D{{"snapshots", docs}}},
+				{"$unset", bson.D{{"pendingsnapshot", nil}}},
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// VolumeEncryptionKey returns the key with which the contents of the
+// specified volume are encrypted. A key is generated and recorded the
+// first time it is requested, and is returned unchanged thereafter.
+// Keys never leave the controller except to be delivered to the agent
+// responsible for the volume. An error is returned if the volume was
+// not created encrypted.
+func (st *State) VolumeEncryptionKey(tag names.VolumeTag) (key string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot get encryption key for volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !v.encrypted() {
+			return nil, errors.New("volume is not encrypted")
+		}
+		if v.doc.EncryptionKey != "" {
+			key = v.doc.EncryptionKey
+			return nil, jujutxn.ErrNoOperations
+		}
+		if v.Life() == Dead {
+			return nil, errors.New("volume is dead")
+		}
+		key, err = newVolumeEncryptionKey()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"encryptionkey", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"encryptionkey", key}}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return "", errors.Trace(err)
+	}
+	return key, nil
+}
+
+// encrypted reports whether or not the volume's contents are
+// encrypted, as recorded when the volume was created.
+func (v *volume) encrypted() bool {
+	if v.doc.Info != nil {
+		return v.doc.Info.Encrypted
+	}
+	return v.doc.Params != nil && v.doc.Params.Encrypted
+}
+
+// newVolumeEncryptionKey returns a new random 256-bit key,
+// hex-encoded.
+func newVolumeEncryptionKey() (string, error) {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(key[:]), nil
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -1000,15 +1443,23 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams, unsetPendingSize bool) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if unsetPendingSize {
+		asserts = append(asserts, bson.DocElem{"pendingsize", bson.D{{"$lte", info.Size}}})
+		unset = append(unset, bson.DocElem{"pendingsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
@@ -1027,6 +1478,21 @@ func (st *State) AllVolumes() ([]Volume, error) {
 	return volumesToInterfaces(volumes), nil
 }
 
+// VolumesWithEncryptionKeys returns the tags of the volumes in the
+// model for which encryption keys have been generated. The keys never
+// leave the controller, so these volumes cannot be exported.
+func (st *State) VolumesWithEncryptionKeys() ([]names.VolumeTag, error) {
+	volumes, err := st.volumes(bson.D{{"encryptionkey", bson.D{{"$gt", ""}}}})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volumes")
+	}
+	tags := make([]names.VolumeTag, len(volumes))
+	for i, v := range volumes {
+		tags[i] = v.VolumeTag()
+	}
+	return tags, nil
+}
+
 func volumeGlobalKey(name string) string {
 	return "v#" + name
 }
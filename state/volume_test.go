@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
@@ -237,6 +239,289 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestRestoreVolumeInfo(c *gc.C) {
+	volInfo := state.VolumeInfo{Pool: "persistent-block", Size: 123, VolumeId: "vol-ume"}
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "persistent-block", Size: 123}, &volInfo, "pgdata",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag, err := s.storageInstanceFilesystem(c, storageTag).Volume()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The pool may not be changed, but the volume ID may.
+	restoredInfo := state.VolumeInfo{Size: 456, VolumeId: "vol-restored", Persistent: true}
+	err = s.State.RestoreVolumeInfo(volumeTag, restoredInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	restoredInfo.Pool = "persistent-block"
+	s.assertVolumeInfo(c, volumeTag, restoredInfo)
+
+	err = s.State.RestoreVolumeInfo(volumeTag, state.VolumeInfo{Size: 456})
+	c.Assert(err, gc.ErrorMatches, `cannot restore info for volume ".*": volume ID not set`)
+}
+
+func (s *VolumeStateSuite) TestRestoreVolumeInfoAttached(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.State.RestoreVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume"})
+	c.Assert(err, gc.ErrorMatches, `cannot restore info for volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RestoreVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-restored"})
+	c.Assert(err, gc.ErrorMatches, `cannot restore info for volume "0/0": volume is attached`)
+}
+
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(volumeTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": new size 1024MiB must be larger than current size 1024MiB`)
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	pendingSize, ok := s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(pendingSize, gc.Equals, uint64(2048))
+
+	// Recording a size smaller than requested leaves
+	// the resize pending.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Pool: "loop-pool", Size: 1536})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Pool: "loop-pool", Size: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Pool: "loop-pool", Size: 2048})
+}
+
+func (s *VolumeStateSuite) TestRequestVolumeSnapshot(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot request snapshot of volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).SnapshotPending(), jc.IsFalse)
+
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).SnapshotPending(), jc.IsTrue)
+
+	// Requesting a snapshot while one is pending is a no-op.
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).SnapshotPending(), jc.IsTrue)
+}
+
+func (s *VolumeStateSuite) TestSetVolumeSnapshots(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	created := time.Date(2017, 1, 2, 3, 4, 5, 6, time.UTC)
+	snapshots := []state.VolumeSnapshot{{
+		SnapshotId: "vol-ume@20170102T030405.000000006Z",
+		VolumeId:   "vol-ume",
+		Size:       1024,
+		Created:    created,
+	}}
+	err = s.State.SetVolumeSnapshots(volumeTag, snapshots)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.volume(c, volumeTag)
+	c.Assert(volume.SnapshotPending(), jc.IsFalse)
+	c.Assert(volume.Snapshots(), jc.DeepEquals, snapshots)
+
+	err = s.State.SetVolumeSnapshots(volumeTag, []state.VolumeSnapshot{{}})
+	c.Assert(err, gc.ErrorMatches, `cannot set snapshots of volume "0/0": snapshot ID not set`)
+}
+
+func (s *VolumeStateSuite) TestVolumeEncryptionKey(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State), dummy.StorageProviders())
+	_, err := pm.Create("encrypted-loop", provider.LoopProviderType, map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, u, storageTag := s.setupSingleStorage(c, "block", "encrypted-loop")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams.Encrypted, jc.IsTrue)
+
+	// Later changes to the pool do not affect the volume.
+	_, err = pm.Replace("encrypted-loop", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	key, err := s.State.VolumeEncryptionKey(volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Matches, "[0-9a-f]{64}")
+
+	// The same key is returned each time it is requested.
+	again, err := s.State.VolumeEncryptionKey(volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, gc.Equals, key)
+
+	// The encryption flag is carried over to the volume's info.
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volume.VolumeTag())
+	volumeInfo, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeInfo.Encrypted, jc.IsTrue)
+
+	_, err = s.State.VolumeEncryptionKey(names.NewVolumeTag("42"))
+	c.Assert(err, gc.ErrorMatches, `cannot get encryption key for volume "42": volume "42" not found`)
+}
+
+func (s *VolumeStateSuite) TestVolumesWithEncryptionKeys(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State), dummy.StorageProviders())
+	_, err := pm.Create("encrypted-loop", provider.LoopProviderType, map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, u, storageTag := s.setupSingleStorage(c, "block", "encrypted-loop")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	// The volume is encrypted, but no key has been generated yet.
+	tags, err := s.State.VolumesWithEncryptionKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.HasLen, 0)
+
+	_, err = s.State.VolumeEncryptionKey(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err = s.State.VolumesWithEncryptionKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.VolumeTag{volumeTag})
+}
+
+func (s *VolumeStateSuite) TestVolumeEncryptionKeyNotEncrypted(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	_, err = s.State.VolumeEncryptionKey(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot get encryption key for volume "0/0": volume is not encrypted`)
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	service := s.setupMixedScopeStorageService(c, "block")
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := names.NewVolumeTag("0/1")
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.volume(c, volumeTag).Info()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/1")
+	wc.AssertNoChange()
+
+	// Requesting the same size again is a no-op.
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Completing the resize does not trigger the watcher.
+	info.Size = 2048
+	err = s.State.SetVolumeInfo(volumeTag, info)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Model-scoped volumes are not of interest.
+	err = s.State.SetVolumeInfo(names.NewVolumeTag("0"), state.VolumeInfo{VolumeId: "vol-model", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(names.NewVolumeTag("0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeSnapshotRequests(c *gc.C) {
+	service := s.setupMixedScopeStorageService(c, "block")
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := names.NewVolumeTag("0/1")
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-ume", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineVolumeSnapshotRequests(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/1")
+	wc.AssertNoChange()
+
+	// Requesting a snapshot while one is pending is a no-op.
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Completing the request does not trigger the watcher.
+	err = s.State.SetVolumeSnapshots(volumeTag, []state.VolumeSnapshot{{
+		SnapshotId: "vol-ume@1", VolumeId: "vol-ume", Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// A new request after the last one completed does.
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/1")
+	wc.AssertNoChange()
+
+	// Model-scoped volumes are not of interest.
+	err = s.State.SetVolumeInfo(names.NewVolumeTag("0"), state.VolumeInfo{VolumeId: "vol-model", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RequestVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
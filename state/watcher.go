@@ -214,7 +214,35 @@ func (st *State) WatchModelFilesystems() StringsWatcher {
 	return st.watchModelMachinestorage(filesystemsC)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// requests to resize model-scoped volumes.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	members, filter := st.modelMachinestorageMembers()
+	return newResizeWatcher(st, volumesC, members, filter)
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies
+// of requests to resize model-scoped filesystems.
+func (st *State) WatchModelFilesystemResizes() StringsWatcher {
+	members, filter := st.modelMachinestorageMembers()
+	return newResizeWatcher(st, filesystemsC, members, filter)
+}
+
+// WatchModelVolumeSnapshotRequests returns a StringsWatcher that
+// notifies of requests to snapshot model-scoped volumes.
+func (st *State) WatchModelVolumeSnapshotRequests() StringsWatcher {
+	members, filter := st.modelMachinestorageMembers()
+	return newSnapshotRequestWatcher(st, volumesC, members, filter)
+}
+
 func (st *State) watchModelMachinestorage(collection string) StringsWatcher {
+	members, filter := st.modelMachinestorageMembers()
+	return newLifecycleWatcher(st, collection, members, filter, nil)
+}
+
+// modelMachinestorageMembers returns the query and watcher filter
+// selecting model-scoped volumes or filesystems.
+func (st *State) modelMachinestorageMembers() (bson.D, func(interface{}) bool) {
 	pattern := fmt.Sprintf("^%s$", st.docID(names.NumberSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
 	filter := func(id interface{}) bool {
@@ -224,7 +252,7 @@ func (st *State) watchModelMachinestorage(collection string) StringsWatcher {
 		}
 		return !strings.Contains(k, "/")
 	}
-	return newLifecycleWatcher(st, collection, members, filter, nil)
+	return members, filter
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
@@ -239,7 +267,36 @@ func (st *State) WatchMachineFilesystems(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorage(m, filesystemsC)
 }
 
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// requests to resize volumes scoped to the specified machine.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	members, filter := st.machineStorageMembers(m)
+	return newResizeWatcher(st, volumesC, members, filter)
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies
+// of requests to resize filesystems scoped to the specified machine.
+func (st *State) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	members, filter := st.machineStorageMembers(m)
+	return newResizeWatcher(st, filesystemsC, members, filter)
+}
+
+// WatchMachineVolumeSnapshotRequests returns a StringsWatcher that
+// notifies of requests to snapshot volumes scoped to the specified
+// machine.
+func (st *State) WatchMachineVolumeSnapshotRequests(m names.MachineTag) StringsWatcher {
+	members, filter := st.machineStorageMembers(m)
+	return newSnapshotRequestWatcher(st, volumesC, members, filter)
+}
+
 func (st *State) watchMachineStorage(m names.MachineTag, collection string) StringsWatcher {
+	members, filter := st.machineStorageMembers(m)
+	return newLifecycleWatcher(st, collection, members, filter, nil)
+}
+
+// machineStorageMembers returns the query and watcher filter
+// selecting volumes or filesystems scoped to the specified machine.
+func (st *State) machineStorageMembers(m names.MachineTag) (bson.D, func(interface{}) bool) {
 	pattern := fmt.Sprintf("^%s/%s$", st.docID(m.Id()), names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
 	prefix := m.Id() + "/"
@@ -250,7 +307,180 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 		}
 		return strings.HasPrefix(k, prefix)
 	}
-	return newLifecycleWatcher(st, collection, members, filter, nil)
+	return members, filter
+}
+
+// requestWatcher notifies of requests to resize or snapshot volumes
+// or filesystems. Each request is recorded as a non-zero value in a
+// field of the entity's document, which is unset once the request has
+// been completed. The first event contains the IDs of all entities
+// with a request pending; subsequent events contain the IDs of
+// entities for which a new request has been made.
+type requestWatcher struct {
+	commonWatcher
+	out chan []string
+
+	// collection is the name of the collection to watch.
+	collection string
+	// field is the name of the document field recording requests.
+	field string
+	// value returns the value of the request field in a document.
+	value func(pendingRequestDoc) int64
+	// members is used to select the initial set of interesting entities.
+	members bson.D
+	// filter is used to exclude events not affecting interesting entities.
+	filter func(interface{}) bool
+	// pending holds the values of the most recent requests for
+	// entities with a request pending.
+	pending map[string]int64
+}
+
+var _ Watcher = (*requestWatcher)(nil)
+
+func newResizeWatcher(st *State, collection string, members bson.D, filter func(interface{}) bool) StringsWatcher {
+	value := func(doc pendingRequestDoc) int64 {
+		return int64(doc.PendingSize)
+	}
+	return newRequestWatcher(st, collection, "pendingsize", value, members, filter)
+}
+
+func newSnapshotRequestWatcher(st *State, collection string, members bson.D, filter func(interface{}) bool) StringsWatcher {
+	value := func(doc pendingRequestDoc) int64 {
+		return doc.PendingSnapshot
+	}
+	return newRequestWatcher(st, collection, "pendingsnapshot", value, members, filter)
+}
+
+func newRequestWatcher(
+	st *State,
+	collection, field string,
+	value func(pendingRequestDoc) int64,
+	members bson.D,
+	filter func(interface{}) bool,
+) StringsWatcher {
+	w := &requestWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan []string),
+		collection:    collection,
+		field:         field,
+		value:         value,
+		members:       members,
+		filter:        filter,
+		pending:       make(map[string]int64),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+type pendingRequestDoc struct {
+	Id              string `bson:"_id"`
+	PendingSize     uint64 `bson:"pendingsize"`
+	PendingSnapshot int64  `bson:"pendingsnapshot"`
+}
+
+func (w *requestWatcher) fields() bson.D {
+	return bson.D{{"_id", 1}, {w.field, 1}}
+}
+
+// Changes returns the event channel for the requestWatcher.
+func (w *requestWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *requestWatcher) initial() (set.Strings, error) {
+	coll, closer := w.st.getCollection(w.collection)
+	defer closer()
+
+	ids := make(set.Strings)
+	query := append(bson.D{{w.field, bson.D{{"$gt", 0}}}}, w.members...)
+	iter := coll.Find(query).Select(w.fields()).Iter()
+	var doc pendingRequestDoc
+	for iter.Next(&doc) {
+		id := w.st.localID(doc.Id)
+		ids.Add(id)
+		w.pending[id] = w.value(doc)
+	}
+	return ids, iter.Close()
+}
+
+func (w *requestWatcher) merge(ids set.Strings, updates map[interface{}]bool) error {
+	coll, closer := w.st.getCollection(w.collection)
+	defer closer()
+
+	var changed []string
+	for key, exists := range updates {
+		docID, ok := key.(string)
+		if !ok {
+			return errors.Errorf("id is not of type string, got %T", key)
+		}
+		if exists {
+			changed = append(changed, docID)
+		} else {
+			delete(w.pending, w.st.localID(docID))
+		}
+	}
+
+	latest := make(map[string]int64)
+	iter := coll.Find(bson.D{{"_id", bson.D{{"$in", changed}}}}).Select(w.fields()).Iter()
+	var doc pendingRequestDoc
+	for iter.Next(&doc) {
+		latest[w.st.localID(doc.Id)] = w.value(doc)
+		doc = pendingRequestDoc{}
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	// Add to ids any entities for which a new request has been made.
+	for id, value := range latest {
+		if value == 0 {
+			delete(w.pending, id)
+			continue
+		}
+		if w.pending[id] == value {
+			continue
+		}
+		w.pending[id] = value
+		ids.Add(id)
+	}
+	return nil
+}
+
+func (w *requestWatcher) loop() error {
+	in := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(w.collection, in, w.filter)
+	defer w.watcher.UnwatchCollection(w.collection, in)
+	ids, err := w.initial()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			updates, ok := collect(ch, in, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			if err := w.merge(ids, updates); err != nil {
+				return err
+			}
+			if !ids.IsEmpty() {
+				out = w.out
+			}
+		case out <- ids.Values():
+			ids = make(set.Strings)
+			out = nil
+		}
+	}
 }
 
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
@@ -318,6 +548,12 @@ func (st *State) WatchServices() StringsWatcher {
 	return newLifecycleWatcher(st, applicationsC, nil, isLocalID(st), nil)
 }
 
+// WatchRemoteApplications returns a StringsWatcher that notifies of
+// changes to the lifecycles of the remote applications in the model.
+func (st *State) WatchRemoteApplications() StringsWatcher {
+	return newLifecycleWatcher(st, remoteApplicationsC, nil, isLocalID(st), nil)
+}
+
 // WatchStorageAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all storage instances attached to the
 // specified unit.
@@ -356,10 +592,20 @@ func (s *Application) WatchUnits() StringsWatcher {
 // WatchRelations returns a StringsWatcher that notifies of changes to the
 // lifecycles of relations involving s.
 func (s *Application) WatchRelations() StringsWatcher {
-	prefix := s.doc.Name + ":"
+	return watchApplicationRelations(s.st, s.doc.Name)
+}
+
+// WatchRelations returns a StringsWatcher that notifies of changes to the
+// lifecycles of relations involving s.
+func (s *RemoteApplication) WatchRelations() StringsWatcher {
+	return watchApplicationRelations(s.st, s.doc.Name)
+}
+
+func watchApplicationRelations(st *State, applicationName string) StringsWatcher {
+	prefix := applicationName + ":"
 	infix := " " + prefix
 	filter := func(id interface{}) bool {
-		k, err := s.st.strictLocalID(id.(string))
+		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
@@ -367,8 +613,8 @@ func (s *Application) WatchRelations() StringsWatcher {
 		return out
 	}
 
-	members := bson.D{{"endpoints.applicationname", s.doc.Name}}
-	return newLifecycleWatcher(s.st, relationsC, members, filter, nil)
+	members := bson.D{{"endpoints.applicationname", applicationName}}
+	return newLifecycleWatcher(st, relationsC, members, filter, nil)
 }
 
 // WatchModelMachines returns a StringsWatcher that notifies of changes to
@@ -1272,6 +1518,12 @@ func (s *Application) Watch() NotifyWatcher {
 	return newEntityWatcher(s.st, applicationsC, s.doc.DocID)
 }
 
+// WatchEndpointBindings returns a watcher for observing changes to an
+// application's endpoint bindings.
+func (s *Application) WatchEndpointBindings() NotifyWatcher {
+	return newEntityWatcher(s.st, endpointBindingsC, s.st.docID(s.globalKey()))
+}
+
 // WatchLeaderSettings returns a watcher for observing changed to a service's
 // leader settings.
 func (s *Application) WatchLeaderSettings() NotifyWatcher {
@@ -1326,6 +1578,17 @@ func (st *State) WatchStorageAttachment(s names.StorageTag, u names.UnitTag) Not
 	return newEntityWatcher(st, storageAttachmentsC, st.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (st *State) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(st, volumesC, st.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (st *State) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) NotifyWatcher {
@@ -2491,6 +2754,12 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchRelationIngressNetworks returns a NotifyWatcher which triggers
+// whenever the ingress networks of any of the model's relations change.
+func (st *State) WatchRelationIngressNetworks() NotifyWatcher {
+	return newNotifyCollWatcher(st, relationNetworksC, isLocalID(st))
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
@@ -19,6 +19,12 @@ const (
 	// should not be relied upon until a storage source is
 	// constructed.
 	ConfigStorageDir = "storage-dir"
+
+	// ConfigEncrypted is the name of the boolean storage pool
+	// attribute that requests that storage be encrypted at rest.
+	// Storage providers that cannot encrypt storage should reject
+	// configurations that set it.
+	ConfigEncrypted = "encrypted"
 )
 
 // Config defines the configuration for a storage source.
@@ -76,3 +82,17 @@ func (c *Config) ValueString(name string) (string, bool) {
 	v, ok := c.attrs[name].(string)
 	return v, ok
 }
+
+// Encrypted reports whether the given storage configuration
+// attributes request that storage be encrypted at rest.
+func Encrypted(attrs map[string]interface{}) (bool, error) {
+	v, ok := attrs[ConfigEncrypted]
+	if !ok {
+		return false, nil
+	}
+	encrypted, err := schema.Bool().Coerce(v, []string{ConfigEncrypted})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return encrypted.(bool), nil
+}
@@ -4,6 +4,8 @@
 package storage
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/instance"
@@ -127,6 +129,44 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an interface that may be implemented by a
+// VolumeSource that is able to take point-in-time snapshots of its
+// volumes.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates a snapshot of each of the volumes
+	// specified in the parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots returns the snapshots taken of the volume
+	// with the specified tag. Because restoring a volume may change
+	// its provider volume ID, this includes snapshots taken of the
+	// volume before it was last restored.
+	ListVolumeSnapshots(params VolumeSnapshotParams) ([]VolumeSnapshot, error)
+}
+
+// VolumeSnapshotRestorer is an interface that may be implemented by a
+// VolumeSnapshotter that is able to restore volumes from their
+// snapshots.
+type VolumeSnapshotRestorer interface {
+	VolumeSnapshotter
+
+	// RestoreVolumeSnapshots restores volumes from snapshots. The
+	// volumes must not be attached to any machine. The restored
+	// volume may have a different provider volume ID to the original,
+	// in which case the original volume is destroyed, so callers must
+	// record the returned volume information.
+	RestoreVolumeSnapshots(params []RestoreVolumeSnapshotParams) ([]RestoreVolumeSnapshotsResult, error)
+}
+
+// VolumeResizer is an interface that may be implemented by a
+// VolumeSource that is able to grow its volumes in place, while
+// they are attached and in use.
+type VolumeResizer interface {
+	// ResizeVolumes grows volumes to the sizes specified in the
+	// parameters. Volumes can only be grown, never shrunk.
+	ResizeVolumes(params []ResizeVolumeParams) ([]ResizeVolumesResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -160,6 +200,15 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer is an interface that may be implemented by a
+// FilesystemSource that is able to grow its filesystems in place,
+// while they are attached and in use.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows filesystems to the sizes specified in
+	// the parameters. Filesystems can only be grown, never shrunk.
+	ResizeFilesystems(params []ResizeFilesystemParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -194,6 +243,10 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// EncryptionKey, if non-empty, is the key with which the storage
+	// provider should encrypt the volume's contents at rest.
+	EncryptionKey string
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -208,6 +261,10 @@ type VolumeAttachmentParams struct {
 	// VolumeId is the unique provider-supplied ID for the volume that
 	// should be attached/detached.
 	VolumeId string
+
+	// EncryptionKey, if non-empty, is the key required to unlock the
+	// encrypted volume once it has been attached.
+	EncryptionKey string
 }
 
 // AttachmentParams describes the parameters for attaching a volume or
@@ -319,3 +376,101 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider volume ID of the volume from which
+	// the snapshot was taken.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time
+}
+
+// VolumeSnapshotParams holds the parameters for creating or listing
+// snapshots of a volume.
+type VolumeSnapshotParams struct {
+	// Tag is the tag of the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider volume ID of the volume.
+	VolumeId string
+}
+
+// RestoreVolumeSnapshotParams holds the parameters for restoring a
+// volume from a snapshot.
+type RestoreVolumeSnapshotParams struct {
+	// Tag is the tag of the volume to restore.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider volume ID of the volume to restore.
+	VolumeId string
+
+	// SnapshotId is the provider ID of the snapshot to restore the
+	// volume from. The snapshot must have been taken of the volume
+	// with the specified tag.
+	SnapshotId string
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
+// RestoreVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.RestoreVolumeSnapshots call for one volume.
+// VolumeInfo should only be used if Error is nil.
+type RestoreVolumeSnapshotsResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
+// ResizeVolumeParams holds the parameters for resizing a volume.
+type ResizeVolumeParams struct {
+	// Tag is the tag of the volume to resize.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider volume ID of the volume to resize.
+	VolumeId string
+
+	// Size is the new size of the volume, in MiB.
+	Size uint64
+}
+
+// ResizeVolumesResult contains the result of a
+// VolumeResizer.ResizeVolumes call for one volume.
+// VolumeInfo should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
+// ResizeFilesystemParams holds the parameters for resizing a filesystem.
+type ResizeFilesystemParams struct {
+	// Tag is the tag of the filesystem to resize.
+	Tag names.FilesystemTag
+
+	// FilesystemId is the provider filesystem ID of the filesystem
+	// to resize.
+	FilesystemId string
+
+	// Size is the new size of the filesystem, in MiB.
+	Size uint64
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// FilesystemInfo should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	FilesystemInfo *FilesystemInfo
+	Error          error
+}
@@ -39,6 +39,12 @@ func globalKey(name string) string {
 	return globalKeyPrefix + name
 }
 
+// SettingsKey returns the key of the settings document that holds the
+// configuration of the pool with the specified name.
+func SettingsKey(name string) string {
+	return globalKey(name)
+}
+
 // Create is defined on PoolManager interface.
 func (pm *poolManager) Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
 	if name == "" {
@@ -69,6 +75,41 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	return cfg, nil
 }
 
+// Replace is defined on PoolManager interface.
+func (pm *poolManager) Replace(name string, attrs map[string]interface{}) (*storage.Config, error) {
+	if name == "" {
+		return nil, MissingNameError
+	}
+	existing, err := pm.Get(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providerType := existing.Provider()
+
+	cfg, err := storage.NewConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p, err := pm.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := provider.ValidateConfig(p, cfg); err != nil {
+		return nil, errors.Annotate(err, "validating storage provider config")
+	}
+
+	poolAttrs := cfg.Attrs()
+	poolAttrs[Name] = name
+	poolAttrs[Type] = string(providerType)
+	if err := pm.settings.ReplaceSettings(globalKey(name), poolAttrs); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("pool %q", name)
+		}
+		return nil, errors.Annotatef(err, "replacing pool %q", name)
+	}
+	return cfg, nil
+}
+
 // Delete is defined on PoolManager interface.
 func (pm *poolManager) Delete(name string) error {
 	err := pm.settings.RemoveSettings(globalKey(name))
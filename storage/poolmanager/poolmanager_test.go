@@ -130,6 +130,46 @@ func (s *poolSuite) TestCreateInvalidConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
 }
 
+func (s *poolSuite) TestReplace(c *gc.C) {
+	s.createSettings(c)
+	replaced, err := s.poolManager.Replace("testpool", map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replaced, gc.DeepEquals, p)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"baz": "qux"})
+	c.Assert(p.Name(), gc.Equals, "testpool")
+	c.Assert(p.Provider(), gc.Equals, storage.ProviderType("loop"))
+}
+
+func (s *poolSuite) TestReplaceMissingName(c *gc.C) {
+	_, err := s.poolManager.Replace("", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, "pool name is missing")
+}
+
+func (s *poolSuite) TestReplaceNotFound(c *gc.C) {
+	_, err := s.poolManager.Replace("testpool", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `pool "testpool" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *poolSuite) TestReplaceInvalidConfig(c *gc.C) {
+	s.createSettings(c)
+	s.registry.Providers["loop"] = &dummystorage.StorageProvider{
+		ValidateConfigFunc: func(cfg *storage.Config) error {
+			if _, ok := cfg.Attrs()["bad"]; ok {
+				return errors.New("no good")
+			}
+			return nil
+		},
+	}
+	_, err := s.poolManager.Replace("testpool", map[string]interface{}{"bad": "attr"})
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
 func (s *poolSuite) TestDelete(c *gc.C) {
 	s.createSettings(c)
 	err := s.poolManager.Delete("testpool")
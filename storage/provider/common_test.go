@@ -32,6 +32,7 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
 		provider.RootfsProviderType,
+		provider.SharedfsProviderType,
 		provider.TmpfsProviderType,
 	})
 }
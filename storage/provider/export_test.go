@@ -129,6 +129,14 @@ func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider
 	return &tmpfsProvider{run}
 }
 
+func SharedfsFilesystemSource(path string) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{nil},
+		set.NewStrings(),
+	}
+	return &sharedfsFilesystemSource{d, path}, d
+}
+
 // MountedDirs returns all the Dirs which have been created during any CreateFilesystem calls
 // on the specified filesystem source..
 func MountedDirs(fsSource storage.FilesystemSource) set.Strings {
@@ -5,10 +5,14 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -31,8 +35,12 @@ type loopProvider struct {
 var _ storage.Provider = (*loopProvider)(nil)
 
 // ValidateConfig is defined on the Provider interface.
-func (*loopProvider) ValidateConfig(*storage.Config) error {
-	// Loop provider has no configuration.
+func (*loopProvider) ValidateConfig(cfg *storage.Config) error {
+	// The only configuration the loop provider accepts is
+	// whether or not its volumes are encrypted.
+	if _, err := storage.Encrypted(cfg.Attrs()); err != nil {
+		return errors.Annotate(err, "validating loop storage config")
+	}
 	return nil
 }
 
@@ -121,6 +129,12 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
+	if params.EncryptionKey != "" {
+		if err := lvs.formatEncrypted(params.Tag, params.EncryptionKey); err != nil {
+			os.Remove(loopFilePath)
+			return storage.Volume{}, errors.Annotate(err, "could not encrypt block file")
+		}
+	}
 	return storage.Volume{
 		params.Tag,
 		storage.VolumeInfo{
@@ -134,6 +148,51 @@ func (lvs *loopVolumeSource) volumeFilePath(tag names.VolumeTag) string {
 	return filepath.Join(lvs.storageDir, tag.String())
 }
 
+// keyFilePath returns the path of the file that temporarily holds
+// the encryption key for the volume while cryptsetup is run.
+func (lvs *loopVolumeSource) keyFilePath(tag names.VolumeTag) string {
+	return filepath.Join(lvs.storageDir, tag.String()+".key")
+}
+
+// withKeyFile writes the encryption key for the volume to a file
+// readable only by the machine agent, calls f with the path of the
+// file, and then removes the file. The key is never persisted on
+// the machine.
+func (lvs *loopVolumeSource) withKeyFile(tag names.VolumeTag, key string, f func(keyFile string) error) error {
+	keyFile := lvs.keyFilePath(tag)
+	if err := ioutil.WriteFile(keyFile, []byte(key), 0600); err != nil {
+		return errors.Annotate(err, "writing key file")
+	}
+	defer os.Remove(keyFile)
+	return f(keyFile)
+}
+
+// formatEncrypted initialises a LUKS header on the volume's backing
+// file, so that its contents are encrypted with the given key.
+func (lvs *loopVolumeSource) formatEncrypted(tag names.VolumeTag, key string) error {
+	return lvs.withKeyFile(tag, key, func(keyFile string) error {
+		_, err := lvs.run(
+			"cryptsetup", "luksFormat", "--batch-mode",
+			"--key-file", keyFile, lvs.volumeFilePath(tag),
+		)
+		return errors.Annotate(err, "formatting LUKS volume")
+	})
+}
+
+// cryptDeviceName returns the name of the device mapper device
+// through which the decrypted contents of the volume are accessed.
+func cryptDeviceName(tag names.VolumeTag) string {
+	return "juju-" + tag.String()
+}
+
+// cryptDeviceActive reports whether the named device mapper
+// device has been set up.
+func cryptDeviceActive(run runCommandFunc, name string) bool {
+	// cryptsetup status exits non-zero if the device is inactive.
+	_, err := run("cryptsetup", "status", name)
+	return err == nil
+}
+
 // ListVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) ListVolumes() ([]string, error) {
 	// TODO(axw) implement this when we need it.
@@ -199,6 +258,20 @@ func (lvs *loopVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*
 		os.Remove(loopFilePath)
 		return nil, errors.Annotate(err, "attaching loop device")
 	}
+	if arg.EncryptionKey != "" {
+		deviceLink, err := lvs.openEncrypted(arg, deviceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &storage.VolumeAttachment{
+			arg.Volume,
+			arg.Machine,
+			storage.VolumeAttachmentInfo{
+				DeviceLink: deviceLink,
+				ReadOnly:   arg.ReadOnly,
+			},
+		}, nil
+	}
 	return &storage.VolumeAttachment{
 		arg.Volume,
 		arg.Machine,
@@ -209,19 +282,51 @@ func (lvs *loopVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*
 	}, nil
 }
 
+// openEncrypted unlocks the encrypted volume attached to the named
+// loop device, and returns the path of the device mapper link through
+// which the decrypted contents may be accessed.
+func (lvs *loopVolumeSource) openEncrypted(arg storage.VolumeAttachmentParams, loopDeviceName string) (string, error) {
+	name := cryptDeviceName(arg.Volume)
+	if !cryptDeviceActive(lvs.run, name) {
+		err := lvs.withKeyFile(arg.Volume, arg.EncryptionKey, func(keyFile string) error {
+			args := []string{"luksOpen", "--key-file", keyFile}
+			if arg.ReadOnly {
+				args = append(args, "--readonly")
+			}
+			args = append(args, path.Join("/dev", loopDeviceName), name)
+			_, err := lvs.run("cryptsetup", args...)
+			return err
+		})
+		if err != nil {
+			return "", errors.Annotate(err, "opening LUKS volume")
+		}
+	}
+	return path.Join("/dev/mapper", name), nil
+}
+
 // DetachVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
 	results := make([]error, len(args))
 	for i, arg := range args {
-		if err := lvs.detachVolume(arg.Volume); err != nil {
+		if err := lvs.detachVolume(arg); err != nil {
 			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
 		}
 	}
 	return results, nil
 }
 
-func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
-	loopFilePath := lvs.volumeFilePath(tag)
+func (lvs *loopVolumeSource) detachVolume(arg storage.VolumeAttachmentParams) error {
+	if arg.EncryptionKey != "" {
+		// The decrypted device must be closed before
+		// the loop device can be detached.
+		name := cryptDeviceName(arg.Volume)
+		if cryptDeviceActive(lvs.run, name) {
+			if _, err := lvs.run("cryptsetup", "luksClose", name); err != nil {
+				return errors.Annotatef(err, "closing LUKS volume %q", name)
+			}
+		}
+	}
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
 	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
 	if err != nil {
 		return errors.Annotate(err, "locating loop device")
@@ -237,6 +342,175 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		info, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing %q", arg.VolumeId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.ResizeVolumeParams) (*storage.VolumeInfo, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	size, err := blockFileSize(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if arg.Size < size {
+		return nil, errors.Errorf("cannot shrink volume from %dMiB to %dMiB", size, arg.Size)
+	}
+	if arg.Size > size {
+		// fallocate extends the existing file, leaving
+		// its current contents untouched.
+		if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		// Have the loop driver pick up the new size
+		// of the backing file.
+		if _, err := lvs.run("losetup", "-c", path.Join("/dev", deviceName)); err != nil {
+			return nil, errors.Annotatef(err, "updating size of loop device %q", deviceName)
+		}
+	}
+	if name := cryptDeviceName(arg.Tag); cryptDeviceActive(lvs.run, name) {
+		// Grow the decrypted device to fill the loop device.
+		if _, err := lvs.run("cryptsetup", "resize", name); err != nil {
+			return nil, errors.Annotatef(err, "resizing LUKS volume %q", name)
+		}
+	}
+	return &storage.VolumeInfo{
+		VolumeId: arg.VolumeId,
+		Size:     arg.Size,
+	}, nil
+}
+
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+
+// loopSnapshotTimeFormat is the format of the creation time that forms
+// part of a loop volume snapshot ID. Snapshot IDs of the same volume
+// sort in the order the snapshots were taken.
+const loopSnapshotTimeFormat = "20060102T150405.000000000Z"
+
+// snapshotDir returns the directory in which snapshots of the loop
+// volume backing files are stored.
+func (lvs *loopVolumeSource) snapshotDir() string {
+	return filepath.Join(lvs.storageDir, "snapshots")
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting %q", arg.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	if err := lvs.dirFuncs.mkDirAll(lvs.snapshotDir(), 0755); err != nil {
+		return nil, errors.Annotate(err, "creating snapshot directory")
+	}
+	created := time.Now().UTC()
+	snapshotId := arg.Tag.String() + "@" + created.Format(loopSnapshotTimeFormat)
+	snapshotFilePath := filepath.Join(lvs.snapshotDir(), snapshotId)
+	if _, err := lvs.run("cp", "--sparse=always", lvs.volumeFilePath(arg.Tag), snapshotFilePath); err != nil {
+		return nil, errors.Annotate(err, "copying loop backing file")
+	}
+	size, err := blockFileSize(lvs.run, snapshotFilePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		SnapshotId: snapshotId,
+		VolumeId:   arg.VolumeId,
+		Size:       size,
+		Created:    created,
+	}, nil
+}
+
+// ListVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListVolumeSnapshots(arg storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	if _, err := lvs.dirFuncs.lstat(lvs.snapshotDir()); os.IsNotExist(err) {
+		// No snapshots have been taken.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	prefix := arg.Tag.String() + "@"
+	out, err := lvs.run(
+		"find", lvs.snapshotDir(),
+		"-maxdepth", "1",
+		"-name", prefix+"*",
+		"-printf", `%f %s\n`,
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	sort.Strings(lines)
+	var snapshots []storage.VolumeSnapshot
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		created, err := time.Parse(loopSnapshotTimeFormat, strings.TrimPrefix(fields[0], prefix))
+		if err != nil {
+			logger.Warningf("ignoring unexpected snapshot file %q", fields[0])
+			continue
+		}
+		size, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing size of snapshot %q", fields[0])
+		}
+		snapshots = append(snapshots, storage.VolumeSnapshot{
+			SnapshotId: fields[0],
+			VolumeId:   arg.VolumeId,
+			Size:       bytesToMiB(size),
+			Created:    created,
+		})
+	}
+	return snapshots, nil
+}
+
+// blockFileSize returns the apparent size of the file at the specified
+// path, in MiB, rounding up.
+func blockFileSize(run runCommandFunc, filePath string) (uint64, error) {
+	out, err := run("stat", "--format=%s", filePath)
+	if err != nil {
+		return 0, errors.Annotate(err, "getting file size")
+	}
+	size, err := strconv.ParseUint(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return 0, errors.Annotate(err, "parsing file size")
+	}
+	return bytesToMiB(size), nil
+}
+
+// bytesToMiB converts a size in bytes to MiB, rounding up.
+func bytesToMiB(size uint64) uint64 {
+	const mib = 1024 * 1024
+	return (size + mib - 1) / mib
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
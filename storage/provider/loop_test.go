@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -67,6 +68,23 @@ func (s *loopSuite) TestValidateConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestValidateConfigEncrypted(c *gc.C) {
+	p := s.loopProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LoopProviderType, map[string]interface{}{
+		"encrypted": "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.LoopProviderType, map[string]interface{}{
+		"encrypted": "maybe",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating loop storage config: encrypted: expected bool, got string\("maybe"\)`)
+}
+
 func (s *loopSuite) TestSupports(c *gc.C) {
 	p := s.loopProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
@@ -114,6 +132,27 @@ func (s *loopSuite) TestCreateVolumes(c *gc.C) {
 	})
 }
 
+func (s *loopSuite) TestCreateVolumesEncrypted(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	keyFile := filepath.Join(s.storageDir, "volume-0.key")
+	s.commands.expect("fallocate", "-l", "2MiB", fileName)
+	s.commands.expect("cryptsetup", "luksFormat", "--batch-mode", "--key-file", keyFile, fileName)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:           names.NewVolumeTag("0"),
+		Size:          2,
+		EncryptionKey: "sekrit",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	// The key must not be left on disk.
+	_, err = os.Stat(keyFile)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	s.commands.expect("fallocate", "-l", "2MiB", filepath.Join(s.storageDir, "volume-0"))
@@ -217,6 +256,59 @@ func (s *loopSuite) TestAttachVolumes(c *gc.C) {
 	}})
 }
 
+func (s *loopSuite) TestAttachVolumesEncrypted(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0"))
+	cmd.respond("", nil) // no existing attachment
+	cmd = s.commands.expect("losetup", "-f", "--show", filepath.Join(s.storageDir, "volume-0"))
+	cmd.respond("/dev/loop98", nil)
+	cmd = s.commands.expect("cryptsetup", "status", "juju-volume-0")
+	cmd.respond("", errors.New("inactive"))
+	s.commands.expect(
+		"cryptsetup", "luksOpen", "--key-file", filepath.Join(s.storageDir, "volume-0.key"),
+		"/dev/loop98", "juju-volume-0",
+	)
+	cmd = s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-1"))
+	cmd.respond("/dev/loop99: foo\n", nil) // existing attachment
+	s.commands.expect("cryptsetup", "status", "juju-volume-1")
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:        names.NewVolumeTag("0"),
+		VolumeId:      "vol-ume0",
+		EncryptionKey: "sekrit",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}, {
+		Volume:        names.NewVolumeTag("1"),
+		VolumeId:      "vol-ume1",
+		EncryptionKey: "sekrit",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/mapper/juju-volume-0",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/mapper/juju-volume-1",
+			},
+		},
+	}})
+	_, err = os.Stat(filepath.Join(s.storageDir, "volume-0.key"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -245,6 +337,29 @@ func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestDetachVolumesEncrypted(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("cryptsetup", "status", "juju-volume-0")
+	s.commands.expect("cryptsetup", "luksClose", "juju-volume-0")
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-d", "/dev/loop0")
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:        names.NewVolumeTag("0"),
+		VolumeId:      "vol-ume0",
+		EncryptionKey: "sekrit",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+}
+
 func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -272,3 +387,128 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	// The snapshot file name includes the time at which it was
+	// taken, so record the commands rather than expecting them.
+	s.commands = &mockRunCommand{c: c}
+	var commands [][]string
+	source, dirFuncs := provider.LoopVolumeSource(s.storageDir, func(cmd string, args ...string) (string, error) {
+		commands = append(commands, append([]string{cmd}, args...))
+		return "2097152\n", nil
+	})
+	snapshotter := source.(storage.VolumeSnapshotter)
+
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	snapshot := results[0].Snapshot
+	c.Assert(snapshot.SnapshotId, gc.Matches, `volume-0@\d{8}T\d{6}\.\d{9}Z`)
+	c.Assert(snapshot.VolumeId, gc.Equals, "volume-0")
+	c.Assert(snapshot.Size, gc.Equals, uint64(2))
+	c.Assert(snapshot.Created.IsZero(), jc.IsFalse)
+
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	snapshotFile := filepath.Join(snapshotDir, snapshot.SnapshotId)
+	c.Assert(commands, jc.DeepEquals, [][]string{
+		{"cp", "--sparse=always", filepath.Join(s.storageDir, "volume-0"), snapshotFile},
+		{"stat", "--format=%s", snapshotFile},
+	})
+	c.Assert(dirFuncs.Dirs.Contains(snapshotDir), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsCopyFails(c *gc.C) {
+	source, _ := provider.LoopVolumeSource(s.storageDir, func(cmd string, args ...string) (string, error) {
+		return "", errors.New("no space left on device")
+	})
+	snapshotter := source.(storage.VolumeSnapshotter)
+
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `snapshotting "volume-0": copying loop backing file: no space left on device`)
+}
+
+func (s *loopSuite) TestListVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	arg := storage.VolumeSnapshotParams{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}
+
+	// No snapshots have been taken.
+	snapshots, err := snapshotter.ListVolumeSnapshots(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	dirFuncs.Dirs.Add(snapshotDir)
+	cmd := s.commands.expect("find", snapshotDir, "-maxdepth", "1", "-name", "volume-0@*", "-printf", `%f %s\n`)
+	cmd.respond(
+		"volume-0@20161016T120000.000000000Z 1048577\n"+
+			"volume-0@20161015T120000.000000000Z 1048576\n", nil,
+	)
+	snapshots, err = snapshotter.ListVolumeSnapshots(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "volume-0@20161015T120000.000000000Z",
+		VolumeId:   "volume-0",
+		Size:       1,
+		Created:    time.Date(2016, 10, 15, 12, 0, 0, 0, time.UTC),
+	}, {
+		SnapshotId: "volume-0@20161016T120000.000000000Z",
+		VolumeId:   "volume-0",
+		Size:       2,
+		Created:    time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *loopSuite) TestLoopVolumeSourceNotRestorer(c *gc.C) {
+	// Loop volumes cannot be detached from their machines,
+	// so they cannot be restored from their snapshots.
+	source, _ := s.loopVolumeSource(c)
+	_, ok := source.(storage.VolumeSnapshotRestorer)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	resizer := source.(storage.VolumeResizer)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	cmd := s.commands.expect("stat", "--format=%s", fileName)
+	cmd.respond("1048576\n", nil)
+	s.commands.expect("fallocate", "-l", "2MiB", fileName)
+	cmd = s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+	s.commands.expect("cryptsetup", "status", "juju-volume-0")
+	s.commands.expect("cryptsetup", "resize", "juju-volume-0")
+	cmd = s.commands.expect("stat", "--format=%s", filepath.Join(s.storageDir, "volume-1"))
+	cmd.respond("3145728\n", nil)
+
+	results, err := resizer.ResizeVolumes([]storage.ResizeVolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "volume-0",
+		Size:     2,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing "volume-1": cannot shrink volume from 3MiB to 2MiB`)
+}
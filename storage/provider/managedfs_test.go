@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -112,6 +113,92 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// sda1 is grown to fill sda, and the filesystem grown
+	// to fill the partition.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// xvdf1 has no partition to grow.
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+	resizer := source.(storage.FilesystemResizer)
+	results, err := resizer.ResizeFilesystems([]storage.ResizeFilesystemParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		Size:         6,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{
+		FilesystemInfo: &storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Size:         4,
+		},
+	}, {
+		FilesystemInfo: &storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-1",
+			Size:         6,
+		},
+	}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionAlreadyGrown(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 could only be grown by 0", errors.New("exit status 1"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	resizer := source.(storage.FilesystemResizer)
+	results, err := resizer.ResizeFilesystems([]storage.ResizeFilesystemParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsNotProvisioned(c *gc.C) {
+	source := s.initSource(c)
+	resizer := source.(storage.FilesystemResizer)
+	results, err := resizer.ResizeFilesystems([]storage.ResizeFilesystemParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem 0/0 is not yet provisioned")
+}
+
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
 	s.testAttachFilesystems(c, false, false)
 }
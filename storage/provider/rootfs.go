@@ -29,7 +29,16 @@ var (
 
 // ValidateConfig is defined on the Provider interface.
 func (p *rootfsProvider) ValidateConfig(cfg *storage.Config) error {
-	// Rootfs provider has no configuration.
+	// Rootfs provider has no configuration. Filesystems are bind
+	// mounted from the root filesystem, and so cannot be encrypted
+	// independently of it.
+	encrypted, err := storage.Encrypted(cfg.Attrs())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if encrypted {
+		return errors.NotSupportedf("encrypted rootfs storage (use a loop pool instead)")
+	}
 	return nil
 }
 
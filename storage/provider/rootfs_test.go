@@ -70,6 +70,16 @@ func (s *rootfsSuite) TestValidateConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *rootfsSuite) TestValidateConfigEncrypted(c *gc.C) {
+	p := s.rootfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.RootfsProviderType, map[string]interface{}{
+		"encrypted": "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `encrypted rootfs storage \(use a loop pool instead\) not supported`)
+}
+
 func (s *rootfsSuite) TestSupports(c *gc.C) {
 	p := s.rootfsProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
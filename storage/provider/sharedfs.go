@@ -0,0 +1,168 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	SharedfsProviderType = storage.ProviderType("sharedfs")
+
+	// SharedfsPath is the pool config attribute that names the
+	// directory holding the shared filesystems. The directory
+	// must be mounted at the same location on every machine,
+	// including the controller machines (for example, from an
+	// NFS export), before storage from the pool is created.
+	// Each filesystem is a subdirectory named after its ID.
+	SharedfsPath = "path"
+)
+
+// sharedfsProvider creates storage sources which provide access to
+// model-scoped filesystems in a directory shared by all machines.
+//
+// The provider is run by the controller's storage provisioner, so it
+// does not mount anything itself: creating a filesystem creates a
+// subdirectory of the shared directory, and attaching a filesystem
+// reports that subdirectory as the filesystem's location.
+type sharedfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider = (*sharedfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *sharedfsProvider) ValidateConfig(cfg *storage.Config) error {
+	path, _ := cfg.ValueString(SharedfsPath)
+	if path == "" {
+		return errors.Errorf("%q must be specified", SharedfsPath)
+	}
+	if !filepath.IsAbs(path) {
+		return errors.Errorf("%q must be an absolute path, got %q", SharedfsPath, path)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *sharedfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *sharedfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// path is validated by ValidateConfig.
+	path, _ := sourceConfig.ValueString(SharedfsPath)
+	return &sharedfsFilesystemSource{
+		&osDirFuncs{p.run},
+		filepath.Clean(path),
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*sharedfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*sharedfsProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*sharedfsProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*sharedfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+type sharedfsFilesystemSource struct {
+	dirFuncs dirFuncs
+	path     string
+}
+
+var _ storage.FilesystemSource = (*sharedfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *sharedfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// The size of the shared directory is managed outside of Juju,
+	// so there is nothing to check.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *sharedfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		if err := s.ValidateFilesystemParams(arg); err != nil {
+			results[i].Error = err
+			continue
+		}
+		if err := s.dirFuncs.mkDirAll(s.filesystemPath(arg.Tag), 0755); err != nil {
+			results[i].Error = errors.Annotate(err, "creating filesystem directory")
+			continue
+		}
+		results[i].Filesystem = &storage.Filesystem{
+			arg.Tag,
+			arg.Volume,
+			storage.FilesystemInfo{
+				FilesystemId: arg.Tag.String(),
+				Size:         arg.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// filesystemPath returns the subdirectory of the shared directory
+// holding the contents of the specified filesystem.
+func (s *sharedfsFilesystemSource) filesystemPath(tag names.FilesystemTag) string {
+	return filepath.Join(s.path, tag.Id())
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *sharedfsFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; the contents of the shared
+	// directory are owned by whoever manages the directory.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *sharedfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		// The shared directory is already mounted on the
+		// machine, so attaching reports where the filesystem's
+		// subdirectory is.
+		results[i].FilesystemAttachment = &storage.FilesystemAttachment{
+			arg.Filesystem,
+			arg.Machine,
+			storage.FilesystemAttachmentInfo{
+				Path:     s.filesystemPath(arg.Filesystem),
+				ReadOnly: arg.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *sharedfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	// DetachFilesystems is a no-op; the shared directory stays
+	// mounted for the other filesystems using it.
+	return make([]error, len(args)), nil
+}
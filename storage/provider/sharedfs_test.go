@@ -0,0 +1,152 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&sharedfsSuite{})
+
+type sharedfsSuite struct {
+	testing.BaseSuite
+}
+
+func (s *sharedfsSuite) sharedfsProvider(c *gc.C) storage.Provider {
+	p, err := provider.CommonStorageProviders().StorageProvider(provider.SharedfsProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	return p
+}
+
+func (s *sharedfsSuite) sharedfsFilesystemSource(c *gc.C) (storage.FilesystemSource, *provider.MockDirFuncs) {
+	return provider.SharedfsFilesystemSource("/srv/shared")
+}
+
+func (s *sharedfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.sharedfsProvider(c)
+	for _, attrs := range []map[string]interface{}{
+		{},
+		{"path": ""},
+	} {
+		cfg, err := storage.NewConfig("name", provider.SharedfsProviderType, attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		c.Check(err, gc.ErrorMatches, `"path" must be specified`)
+	}
+	cfg, err := storage.NewConfig("name", provider.SharedfsProviderType, map[string]interface{}{
+		"path": "srv/shared",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `"path" must be an absolute path, got "srv/shared"`)
+
+	cfg, err = storage.NewConfig("name", provider.SharedfsProviderType, map[string]interface{}{
+		"path": "/srv/shared",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *sharedfsSuite) TestFilesystemSource(c *gc.C) {
+	p := s.sharedfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.SharedfsProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, `"path" must be specified`)
+
+	cfg, err = storage.NewConfig("name", provider.SharedfsProviderType, map[string]interface{}{
+		"path": "/srv/shared/",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *sharedfsSuite) TestVolumeSource(c *gc.C) {
+	p := s.sharedfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.SharedfsProviderType, map[string]interface{}{
+		"path": "/srv/shared",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+}
+
+func (s *sharedfsSuite) TestSupports(c *gc.C) {
+	p := s.sharedfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *sharedfsSuite) TestScope(c *gc.C) {
+	p := s.sharedfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *sharedfsSuite) TestCreateFilesystems(c *gc.C) {
+	source, dirFuncs := s.sharedfsFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "filesystem-6",
+				Size:         2,
+			},
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.SortedValues(), jc.DeepEquals, []string{"/srv/shared/6"})
+}
+
+func (s *sharedfsSuite) TestAttachFilesystems(c *gc.C) {
+	source, _ := s.sharedfsFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "filesystem-6",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-id",
+			ReadOnly:   true,
+		},
+		Path: "/var/lib/juju/storage/data/0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("6"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/srv/shared/6",
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *sharedfsSuite) TestDestroyAndDetachFilesystems(c *gc.C) {
+	source, _ := s.sharedfsFilesystemSource(c)
+	errs, err := source.DestroyFilesystems([]string{"filesystem-6"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	errs, err = source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "filesystem-6",
+		Path:         "/srv/shared/6",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
@@ -47,4 +47,8 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the volume or filesystem backing the
+	// storage attachment, in MiB.
+	Size uint64
 }
@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// stateStepsFor201 returns upgrade steps for Juju 2.0.1 that manipulate state directly.
+func stateStepsFor201() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "add time to audit entries",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return state.AddAuditEntryTimes(context.State())
+			},
+		},
+	}
+}
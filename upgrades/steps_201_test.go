@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+)
+
+var v201 = version.MustParse("2.0.1")
+
+type steps201Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps201Suite{})
+
+func (s *steps201Suite) TestAddAuditEntryTimes(c *gc.C) {
+	step := findStateStep(c, v201, "add time to audit entries")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		ClockName:     "clock",
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller", "clock"})
+}
+
+func (s *ManifoldSuite) TestStartMissingClock(c *gc.C) {
+	manifold := actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		ClockName:     "clock",
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"clock":      dependency.ErrMissing,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		ClockName:     "clock",
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+		"clock":      clock.WallClock,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	expectCaller := &fakeCaller{}
+	manifold := actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		ClockName:     "clock",
+		NewFacade: func(apiCaller base.APICaller) (actionscheduler.Facade, error) {
+			c.Check(apiCaller, gc.Equals, expectCaller)
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": expectCaller,
+		"clock":      clock.WallClock,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartWorker(c *gc.C) {
+	expectFacade := &fakeFacade{}
+	expectWorker := &fakeWorker{}
+	manifold := actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		ClockName:     "clock",
+		NewFacade: func(_ base.APICaller) (actionscheduler.Facade, error) {
+			return expectFacade, nil
+		},
+		NewWorker: func(config actionscheduler.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, expectFacade)
+			c.Check(config.Clock, gc.Equals, clock.WallClock)
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"clock":      clock.WallClock,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeFacade struct {
+	actionscheduler.Facade
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/worker"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return actionscheduler.NewAPI(
+		apiCaller,
+		watcher.NewNotifyWatcher,
+	), nil
+}
+
+// NewWorker returns a worker.Worker that runs the configured
+// action schedules. It's a sensible value for ManifoldConfig.NewWorker.
+func NewWorker(config Config) (worker.Worker, error) {
+	return New(config)
+}
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// Watch returns a NotifyWatcher that triggers when action
+	// schedules are added, removed or run.
+	Watch() (watcher.NotifyWatcher, error)
+
+	// Schedules returns the time at which each action schedule
+	// is next due to run, keyed by schedule id.
+	Schedules() (map[string]time.Time, error)
+
+	// Run enqueues the actions of the identified schedules, if
+	// they are due.
+	Run(ids []string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// minDelay bounds how often the worker will check schedules that the
+// controller did not consider due when the worker did, as can happen
+// when their clocks disagree.
+const minDelay = 10 * time.Second
+
+// New returns a worker that runs the model's action schedules as they
+// fall due.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker enqueues the actions of action schedules when they fall due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher, err := w.config.Facade.Watch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+		case <-timer:
+		}
+		timer, err = w.runDue()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// runDue runs any schedules which are due, and returns a channel which
+// will deliver a value when the next schedule falls due; or nil, if
+// there are no schedules.
+func (w *Worker) runDue() (<-chan time.Time, error) {
+	schedules, err := w.config.Facade.Schedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	var due []string
+	for id, nextRun := range schedules {
+		if !nextRun.After(now) {
+			due = append(due, id)
+		}
+	}
+	if len(due) > 0 {
+		sort.Strings(due)
+		if err := w.config.Facade.Run(due); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if schedules, err = w.config.Facade.Schedules(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		now = w.config.Clock.Now()
+	}
+
+	var next time.Time
+	for _, nextRun := range schedules {
+		if next.IsZero() || nextRun.Before(next) {
+			next = nextRun
+		}
+	}
+	if next.IsZero() {
+		return nil, nil
+	}
+	delay := next.Sub(now)
+	if delay < minDelay {
+		delay = minDelay
+	}
+	return w.config.Clock.After(delay), nil
+}
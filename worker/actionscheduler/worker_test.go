@@ -0,0 +1,204 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *stubFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2016, 11, 10, 9, 30, 0, 0, time.UTC))
+	s.facade = newStubFacade(s.clock.Now())
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) *actionscheduler.Worker {
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for clock.After call")
+	}
+}
+
+func (s *WorkerSuite) waitRun(c *gc.C) []string {
+	select {
+	case ids := <-s.facade.run:
+		return ids
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Run call")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := actionscheduler.New(actionscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = actionscheduler.New(actionscheduler.Config{Facade: s.facade})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestRunsDueSchedules(c *gc.C) {
+	s.facade.addSchedule("1", time.Hour)
+	s.facade.addSchedule("2", 90*time.Minute)
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(time.Hour)
+	c.Check(s.waitRun(c), jc.DeepEquals, []string{"1"})
+
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	c.Check(s.waitRun(c), jc.DeepEquals, []string{"2"})
+
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	c.Check(s.waitRun(c), jc.DeepEquals, []string{"1"})
+}
+
+func (s *WorkerSuite) TestRunsOverdueSchedulesAtStart(c *gc.C) {
+	s.facade.addSchedule("1", -time.Minute)
+	s.facade.addSchedule("2", -time.Hour)
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Check(s.waitRun(c), jc.DeepEquals, []string{"1", "2"})
+	s.waitAlarm(c)
+}
+
+func (s *WorkerSuite) TestReschedulesOnChange(c *gc.C) {
+	s.facade.addSchedule("1", time.Hour)
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitAlarm(c)
+
+	s.facade.addSchedule("2", time.Minute)
+	s.facade.changes <- struct{}{}
+	s.waitAlarm(c)
+
+	s.clock.Advance(time.Minute)
+	c.Check(s.waitRun(c), jc.DeepEquals, []string{"2"})
+}
+
+func (s *WorkerSuite) TestRunError(c *gc.C) {
+	s.facade.addSchedule("1", 0)
+	s.facade.runErr = errors.New("splat")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "splat")
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("blam")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+}
+
+// stubFacade implements actionscheduler.Facade, running each schedule
+// at a fixed interval of an hour.
+type stubFacade struct {
+	mu        sync.Mutex
+	now       time.Time
+	schedules map[string]time.Time
+	changes   chan struct{}
+	run       chan []string
+	watchErr  error
+	runErr    error
+}
+
+func newStubFacade(now time.Time) *stubFacade {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return &stubFacade{
+		now:       now,
+		schedules: make(map[string]time.Time),
+		changes:   changes,
+		run:       make(chan []string, 10),
+	}
+}
+
+func (f *stubFacade) addSchedule(id string, after time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedules[id] = f.now.Add(after)
+}
+
+func (f *stubFacade) Watch() (watcher.NotifyWatcher, error) {
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return &stubWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: f.changes,
+	}, nil
+}
+
+func (f *stubFacade) Schedules() (map[string]time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedules := make(map[string]time.Time)
+	for id, nextRun := range f.schedules {
+		schedules[id] = nextRun
+	}
+	return schedules, nil
+}
+
+func (f *stubFacade) Run(ids []string) error {
+	if f.runErr != nil {
+		return f.runErr
+	}
+	f.mu.Lock()
+	for _, id := range ids {
+		f.schedules[id] = f.schedules[id].Add(time.Hour)
+	}
+	f.mu.Unlock()
+	f.run <- ids
+	return nil
+}
+
+type stubWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+func (w *stubWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
@@ -26,8 +26,9 @@ var pairsRE = regexp.MustCompile(`([A-Z]+)=(?:"(.*?)")`)
 const (
 	// values for the TYPE column that we care about
 
-	typeDisk = "disk"
-	typeLoop = "loop"
+	typeDisk  = "disk"
+	typeLoop  = "loop"
+	typeCrypt = "crypt"
 )
 
 func init() {
@@ -93,10 +94,11 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 		}
 
 		// We may later want to expand this, e.g. to handle lvm,
-		// dmraid, crypt, etc., but this is enough to cover bases
-		// for now.
+		// dmraid, etc., but this is enough to cover bases for now.
+		// Crypt devices are reported so that encrypted loop volumes
+		// may be matched by their device mapper links.
 		switch deviceType {
-		case typeDisk, typeLoop:
+		case typeDisk, typeLoop, typeCrypt:
 		default:
 			logger.Tracef("ignoring %q type device: %+v", deviceType, dev)
 			continue
@@ -4,9 +4,12 @@
 package firewaller
 
 import (
+	"fmt"
+	"net"
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/firewaller"
@@ -39,8 +42,27 @@ type Firewaller struct {
 	applicationids  map[names.ApplicationTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalSourceRef map[ingressSource]int
 	machinePorts    map[names.MachineTag]machineRanges
+
+	// environRules is the environ's ingress rule firewaller, if it
+	// supports restricting the sources of traffic in global mode.
+	environRules environs.IngressRuleFirewaller
+
+	// environEgress is the environ's egress firewaller, if it supports
+	// restricting the outbound traffic of machines in instance mode.
+	// The controllers' API ports are always allowed as destinations of
+	// restricted machines, so their agents keep working.
+	environEgress       environs.EgressFirewaller
+	apiHostPortsWatcher watcher.NotifyWatcher
+	controllerEgress    []network.EgressRule
+	egressChange        chan *egressChange
+	machineChange       chan *machineData
+
+	// ingressNetworksWatcher reports changes to the networks from
+	// which the units related to the model's services from other
+	// models connect.
+	ingressNetworksWatcher watcher.NotifyWatcher
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -53,6 +75,8 @@ func NewFirewaller(st *firewaller.State) (worker.Worker, error) {
 		unitds:         make(map[names.UnitTag]*unitData),
 		applicationids: make(map[names.ApplicationTag]*serviceData),
 		exposedChange:  make(chan *exposedChange),
+		egressChange:   make(chan *egressChange),
+		machineChange:  make(chan *machineData),
 		machinePorts:   make(map[names.MachineTag]machineRanges),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
@@ -87,9 +111,11 @@ func (fw *Firewaller) setUp() error {
 	}
 	switch fw.environ.Config().FirewallMode() {
 	case config.FwInstance:
+		fw.environEgress, _ = fw.environ.(environs.EgressFirewaller)
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalSourceRef = make(map[ingressSource]int)
+		fw.environRules, _ = fw.environ.(environs.IngressRuleFirewaller)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -115,6 +141,27 @@ func (fw *Firewaller) setUp() error {
 	}
 
 	logger.Debugf("started watching opened port ranges for the environment")
+
+	fw.ingressNetworksWatcher, err = fw.st.WatchIngressNetworks()
+	if err != nil {
+		return errors.Annotatef(err, "failed to start ingress networks watcher")
+	}
+	if err := fw.catacomb.Add(fw.ingressNetworksWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	if fw.environEgress != nil {
+		fw.apiHostPortsWatcher, err = fw.st.WatchAPIHostPorts()
+		if err != nil {
+			return errors.Annotatef(err, "failed to start API host ports watcher")
+		}
+		if err := fw.catacomb.Add(fw.apiHostPortsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		if err := fw.updateControllerEgress(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -124,6 +171,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var apiHostPortsChange watcher.NotifyChannel
+	if fw.apiHostPortsWatcher != nil {
+		apiHostPortsChange = fw.apiHostPortsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -182,6 +233,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.exposedCIDRs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -189,6 +241,35 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case _, ok := <-fw.ingressNetworksWatcher.Changes():
+			if !ok {
+				return errors.New("ingress networks watcher closed")
+			}
+			if err := fw.ingressNetworksChanged(); err != nil {
+				return errors.Annotate(err, "cannot change firewall ports")
+			}
+		case change := <-fw.egressChange:
+			change.serviced.egressRules = change.rules
+			fw.checkEgressSupported(change.serviced)
+			if err := fw.flushServiceEgressRules(change.serviced); err != nil {
+				return errors.Annotate(err, "cannot change egress rules")
+			}
+		case machined := <-fw.machineChange:
+			if err := fw.flushEgressRules(machined); err != nil {
+				return errors.Annotate(err, "cannot change egress rules")
+			}
+		case _, ok := <-apiHostPortsChange:
+			if !ok {
+				return errors.New("API host ports watcher closed")
+			}
+			if err := fw.updateControllerEgress(); err != nil {
+				return errors.Trace(err)
+			}
+			for _, machined := range fw.machineds {
+				if err := fw.flushEgressRules(machined); err != nil {
+					return errors.Annotate(err, "cannot change egress rules")
+				}
+			}
 		}
 	}
 }
@@ -200,7 +281,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		ingressRules: make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -239,10 +320,25 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		}
 	}
 
+	// Machines are watched for being provisioned, so that any egress
+	// rules can be set as soon as possible.
+	var machinew watcher.NotifyWatcher
+	if fw.environEgress != nil {
+		machinew, err = m.Watch()
+		if err != nil {
+			delete(fw.machineds, tag)
+			return errors.Trace(err)
+		}
+		if err := fw.catacomb.Add(machinew); err != nil {
+			delete(fw.machineds, tag)
+			return errors.Trace(err)
+		}
+	}
+
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &machined.catacomb,
 		Work: func() error {
-			return machined.watchLoop(unitw)
+			return machined.watchLoop(unitw, machinew)
 		},
 	})
 	if err != nil {
@@ -313,16 +409,32 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	cidrs, err := service.ExposedCIDRs()
+	if err != nil {
+		return err
+	}
+	ingressCIDRs, err := service.IngressNetworks()
+	if err != nil {
+		return err
+	}
+	egressRules, err := service.EgressRules()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:           fw,
+		application:  service,
+		exposed:      exposed,
+		exposedCIDRs: cidrs,
+		ingressCIDRs: ingressCIDRs,
+		egressRules:  egressRules,
+		unitds:       make(map[names.UnitTag]*unitData),
 	}
+	fw.checkEgressSupported(serviced)
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, cidrs, egressRules)
 		},
 	})
 	if err != nil {
@@ -336,14 +448,14 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 }
 
 // reconcileGlobal compares the initially started watcher for machines,
-// units and services with the opened and closed ports globally and
-// opens and closes the appropriate ports for the whole environment.
+// units and services with the opened and closed ingress rules globally
+// and opens and closes the appropriate rules for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.environIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	wantedRules := []network.IngressRule{}
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -351,31 +463,25 @@ func (fw *Firewaller) reconcileGlobal() error {
 				delete(machined.unitds, unitTag)
 				continue
 			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
+			if rule, ok := fw.ingressRule(portRange, unitd.serviced); ok {
+				wantedRules = append(wantedRules, rule)
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
-	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openEnvironRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeEnvironRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
 	}
 	return nil
 }
@@ -410,37 +516,42 @@ func (fw *Firewaller) reconcileInstances() error {
 		if err != nil {
 			return err
 		}
-		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machined.tag.Id())
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
-		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
-				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
-				// TODO(mue) Add local retry logic.
-				return err
-			}
-			network.SortPortRanges(toOpen)
+		// Check which rules to open or to close.
+		toOpen := diffRules(machined.ingressRules, initialRules)
+		toClose := diffRules(initialRules, machined.ingressRules)
+		if err := changeInstanceRules(instances[0], machined.tag, toOpen, toClose); err != nil {
+			// TODO(mue) Add local retry logic.
+			return err
 		}
-		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
-				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
-				// TODO(mue) Add local retry logic.
-				return err
-			}
-			network.SortPortRanges(toClose)
+		if err := fw.reconcileEgressRules(machined); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// reconcileEgressRules compares the egress rules set in the environment
+// for the machine with those of the services of its units, and sets
+// the latter if they differ.
+func (fw *Firewaller) reconcileEgressRules(machined *machineData) error {
+	if fw.environEgress == nil {
+		return nil
+	}
+	initialRules, err := fw.environEgress.EgressRules(machined.tag.Id())
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	machined.egressRules = initialRules
+	return fw.flushEgressRules(machined)
+}
+
 // unitsChanged responds to changes to the assigned units.
 func (fw *Firewaller) unitsChanged(change *unitsChange) error {
 	changed := []*unitData{}
@@ -554,70 +665,158 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ingress rules for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	// Gather rules to open and close.
+	want := []network.IngressRule{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
 			delete(machined.unitds, unitTag)
 			continue
 		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
+		if rule, ok := fw.ingressRule(portRange, unitd.serviced); ok {
+			want = append(want, rule)
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := diffRules(want, machined.ingressRules)
+	toClose := diffRules(machined.ingressRules, want)
+	machined.ingressRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalRules(toOpen, toClose)
+	}
+	if err := fw.flushInstanceRules(machined, toOpen, toClose); err != nil {
+		return err
+	}
+	return fw.flushEgressRules(machined)
+}
+
+// ingressRule returns the ingress rule that allows access to the given
+// port range of a unit of the passed service, from the sources it is
+// exposed to and from the networks of the units related to it from
+// other models. If the port range must not be opened, false is
+// returned.
+func (fw *Firewaller) ingressRule(portRange network.PortRange, serviced *serviceData) (network.IngressRule, bool) {
+	if serviced.exposed && len(serviced.exposedCIDRs) == 0 {
+		return network.NewIngressRule(portRange), true
+	}
+	sources := set.NewStrings(serviced.ingressCIDRs...)
+	if serviced.exposed {
+		sources = sources.Union(set.NewStrings(serviced.exposedCIDRs...))
+	}
+	if sources.IsEmpty() {
+		return network.IngressRule{}, false
+	}
+	if fw.globalMode && fw.environRules == nil {
+		// The environment's global firewall cannot restrict the
+		// sources of traffic, and opening the port range to all
+		// would allow more than the service's owner asked for.
+		logger.Errorf(
+			"cannot open %v of %q to %v: restricting sources not supported by the global firewall; leaving it closed",
+			portRange, serviced.application.Name(), sources.SortedValues(),
+		)
+		return network.IngressRule{}, false
+	}
+	return network.NewIngressRule(portRange, sources.Values()...), true
+}
+
+// ingressNetworksChanged refreshes the ingress networks of the watched
+// services, and opens and closes the ports of the units of those whose
+// networks have changed.
+func (fw *Firewaller) ingressNetworksChanged() error {
+	for _, serviced := range fw.applicationids {
+		cidrs, err := serviced.application.IngressNetworks()
+		if params.IsCodeNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if sameCIDRs(cidrs, serviced.ingressCIDRs) {
+			continue
+		}
+		serviced.ingressCIDRs = cidrs
+		unitds := []*unitData{}
+		for _, unitd := range serviced.unitds {
+			unitds = append(unitds, unitd)
+		}
+		if err := fw.flushUnits(unitds); err != nil {
+			return err
+		}
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return nil
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushGlobalRules opens and closes global ingress rules in the
+// environment. It keeps a reference count for the rules' sources so that
+// only 0-to-1 and 1-to-0 events modify the environment.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which sources are really to open or close.
+	var openSources, closeSources []ingressSource
+	for _, source := range ingressSources(rawOpen) {
+		if fw.globalSourceRef[source] == 0 {
+			openSources = append(openSources, source)
 		}
-		fw.globalPortRef[portRange]++
+		fw.globalSourceRef[source]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	for _, source := range ingressSources(rawClose) {
+		fw.globalSourceRef[source]--
+		if fw.globalSourceRef[source] == 0 {
+			closeSources = append(closeSources, source)
+			delete(fw.globalSourceRef, source)
 		}
 	}
-	// Open and close the ports.
-	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+	// Open and close the rules.
+	if toOpen := ingressRulesFromSources(openSources); len(toOpen) > 0 {
+		if err := fw.openEnvironRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
-	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+	if toClose := ingressRulesFromSources(closeSources); len(toClose) > 0 {
+		if err := fw.closeEnvironRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// environIngressRules returns the ingress rules open in the environment's
+// global firewall.
+func (fw *Firewaller) environIngressRules() ([]network.IngressRule, error) {
+	if fw.environRules != nil {
+		return fw.environRules.IngressRules()
+	}
+	ports, err := fw.environ.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPortRanges(ports), nil
+}
+
+// openEnvironRules opens the given ingress rules in the environment's
+// global firewall.
+func (fw *Firewaller) openEnvironRules(rules []network.IngressRule) error {
+	if fw.environRules != nil {
+		return fw.environRules.OpenIngressRules(rules)
+	}
+	return fw.environ.OpenPorts(network.PortRangesFromIngressRules(rules))
+}
+
+// closeEnvironRules closes the given ingress rules in the environment's
+// global firewall.
+func (fw *Firewaller) closeEnvironRules(rules []network.IngressRule) error {
+	if fw.environRules != nil {
+		return fw.environRules.CloseIngressRules(rules)
+	}
+	return fw.environ.ClosePorts(network.PortRangesFromIngressRules(rules))
+}
+
+// flushInstanceRules opens and closes ingress rules on the machine's
+// instance.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -632,7 +831,6 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	machineId := machined.tag.Id()
 	instanceId, err := m.InstanceId()
 	if err != nil {
 		return err
@@ -641,26 +839,202 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	// TODO(mue) Add local retry logic.
+	return changeInstanceRules(instances[0], machined.tag, toOpen, toClose)
+}
+
+// instanceIngressRules returns the ingress rules open on the instance
+// of the machine with the given id.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if rf, ok := inst.(instance.IngressRuleFirewaller); ok {
+		rules, err := rf.IngressRules(machineId)
+		if !errors.IsNotSupported(err) {
+			return rules, err
+		}
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPortRanges(ports), nil
+}
+
+// changeInstanceIngressRules opens and closes the given ingress rules
+// with the instance's ingress rule firewaller.
+func changeInstanceIngressRules(rf instance.IngressRuleFirewaller, machineTag names.MachineTag, toOpen, toClose []network.IngressRule) error {
+	machineId := machineTag.Id()
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
-			// TODO(mue) Add local retry logic.
+		if err := rf.OpenIngressRules(machineId, toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machineTag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
-			// TODO(mue) Add local retry logic.
+		if err := rf.CloseIngressRules(machineId, toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		logger.Infof("closed ingress rules %v on %q", toClose, machineTag)
 	}
 	return nil
 }
 
+// changeInstanceRules opens and closes the given ingress rules on the
+// instance of the machine with the given tag. Instances that cannot
+// restrict the sources of traffic only have the port ranges of rules
+// allowing all sources opened; the port ranges of other rules are left
+// closed.
+func changeInstanceRules(inst instance.Instance, machineTag names.MachineTag, toOpen, toClose []network.IngressRule) error {
+	machineId := machineTag.Id()
+	if rf, ok := inst.(instance.IngressRuleFirewaller); ok {
+		err := changeInstanceIngressRules(rf, machineTag, toOpen, toClose)
+		if !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+
+	for _, rule := range toOpen {
+		if !rule.AllowsAll() {
+			logger.Errorf(
+				"cannot open %v on %q to %v: restricting sources not supported by the instance firewall; leaving it closed",
+				rule.PortRange, machineTag, rule.SourceCIDRs,
+			)
+		}
+	}
+	openPorts := network.PortRangesFromIngressRules(rulesAllowingAll(toOpen))
+	closePorts := network.PortRangesFromIngressRules(rulesAllowingAll(toClose))
+	openPorts, closePorts = diffRanges(openPorts, closePorts), diffRanges(closePorts, openPorts)
+	if len(openPorts) > 0 {
+		if err := inst.OpenPorts(machineId, openPorts); err != nil {
+			return err
+		}
+		logger.Infof("opened port ranges %v on %q", openPorts, machineTag)
+	}
+	if len(closePorts) > 0 {
+		if err := inst.ClosePorts(machineId, closePorts); err != nil {
+			return err
+		}
+		logger.Infof("closed port ranges %v on %q", closePorts, machineTag)
+	}
+	return nil
+}
+
+// rulesAllowingAll returns those of the given rules that allow traffic
+// from anywhere.
+func rulesAllowingAll(rules []network.IngressRule) []network.IngressRule {
+	var result []network.IngressRule
+	for _, rule := range rules {
+		if rule.AllowsAll() {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// checkEgressSupported warns if the passed service has egress rules
+// that cannot be enforced.
+func (fw *Firewaller) checkEgressSupported(serviced *serviceData) {
+	if fw.environEgress != nil || len(serviced.egressRules) == 0 {
+		return
+	}
+	reason := "not supported by the environment"
+	if fw.globalMode {
+		reason = "not supported in global firewall mode"
+	}
+	logger.Warningf(
+		"cannot restrict outbound traffic of %q to %v: %s",
+		serviced.application.Name(), serviced.egressRules, reason,
+	)
+}
+
+// updateControllerEgress updates the egress rules that allow restricted
+// machines to reach the controllers' API ports.
+func (fw *Firewaller) updateControllerEgress() error {
+	hostPorts, err := fw.st.APIHostPorts()
+	if err != nil {
+		return errors.Annotate(err, "cannot get API host ports")
+	}
+	fw.controllerEgress = controllerEgressRules(hostPorts)
+	return nil
+}
+
+// flushServiceEgressRules sets the egress rules of the machines hosting
+// units of the passed service.
+func (fw *Firewaller) flushServiceEgressRules(serviced *serviceData) error {
+	machineds := map[names.MachineTag]*machineData{}
+	for _, unitd := range serviced.unitds {
+		machineds[unitd.machined.tag] = unitd.machined
+	}
+	for _, machined := range machineds {
+		if err := fw.flushEgressRules(machined); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushEgressRules sets the egress rules of the passed machine, if they
+// have changed. Machines that are not yet provisioned are skipped; their
+// rules are set once they are.
+func (fw *Firewaller) flushEgressRules(machined *machineData) error {
+	if fw.environEgress == nil {
+		return nil
+	}
+	want := fw.egressRules(machined)
+	if sameEgressRules(want, machined.egressRules) {
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := m.InstanceId(); params.IsCodeNotProvisioned(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	err = fw.environEgress.SetEgressRules(machined.tag.Id(), want)
+	if errors.IsNotSupported(err) {
+		logger.Warningf("cannot restrict outbound traffic of %q: %v", machined.tag, err)
+	} else if err != nil {
+		// TODO(mue) Add local retry logic.
+		return err
+	} else if len(want) == 0 {
+		logger.Infof("allowed all outbound traffic of %q", machined.tag)
+	} else {
+		logger.Infof("restricted outbound traffic of %q to %v", machined.tag, want)
+	}
+	machined.egressRules = want
+	return nil
+}
+
+// egressRules returns the egress rules of the passed machine. If any of
+// the services of its units have egress rules, the machine's outbound
+// traffic is restricted to all of those rules, and to the controllers.
+// Otherwise all outbound traffic is allowed, and nil is returned.
+func (fw *Firewaller) egressRules(machined *machineData) []network.EgressRule {
+	wanted := make(map[network.EgressRule]bool)
+	for _, unitd := range machined.unitds {
+		for _, rule := range unitd.serviced.egressRules {
+			wanted[rule] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+	for _, rule := range fw.controllerEgress {
+		wanted[rule] = true
+	}
+	rules := make([]network.EgressRule, 0, len(wanted))
+	for rule := range wanted {
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -751,11 +1125,13 @@ type unitsChange struct {
 
 // machineData holds machine details and watches units added or removed.
 type machineData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	tag         names.MachineTag
-	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	tag          names.MachineTag
+	unitds       map[names.UnitTag]*unitData
+	ingressRules []network.IngressRule
+	// egress rules last set for this machine, nil if unrestricted
+	egressRules []network.EgressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -764,11 +1140,19 @@ func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.st.Machine(md.tag)
 }
 
-// watchLoop watches the machine for units added or removed.
-func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
+// watchLoop watches the machine for units added or removed, and, if
+// machinew is not nil, for changes to the machine itself.
+func (md *machineData) watchLoop(unitw watcher.StringsWatcher, machinew watcher.NotifyWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
+	var machineChanges watcher.NotifyChannel
+	if machinew != nil {
+		if err := md.catacomb.Add(machinew); err != nil {
+			return errors.Trace(err)
+		}
+		machineChanges = machinew.Changes()
+	}
 	for {
 		select {
 		case <-md.catacomb.Dying():
@@ -782,6 +1166,15 @@ func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 			case <-md.catacomb.Dying():
 				return md.catacomb.ErrDying()
 			}
+		case _, ok := <-machineChanges:
+			if !ok {
+				return errors.New("machine watcher closed")
+			}
+			select {
+			case md.fw.machineChange <- md:
+			case <-md.catacomb.Dying():
+				return md.catacomb.ErrDying()
+			}
 		}
 	}
 }
@@ -805,23 +1198,37 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and CIDRs for one
+// specific service.
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	cidrs    []string
+}
+
+// egressChange contains the changed egress rules for one specific
+// service.
+type egressChange struct {
+	serviced *serviceData
+	rules    []network.EgressRule
 }
 
 // serviceData holds service details and watches exposure changes.
 type serviceData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposed      bool
+	exposedCIDRs []string
+	// networks of the units related to the service from other models
+	ingressCIDRs []string
+	egressRules  []network.EgressRule
+	unitds       map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag, CIDRs and egress rules
+// for changes.
+func (sd *serviceData) watchLoop(exposed bool, cidrs []string, egressRules []network.EgressRule) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +1254,30 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changeCIDRs, err := sd.application.ExposedCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			changeEgress, err := sd.application.EgressRules()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !sameEgressRules(changeEgress, egressRules) {
+				egressRules = changeEgress
+				select {
+				case sd.fw.egressChange <- &egressChange{sd, changeEgress}:
+				case <-sd.catacomb.Dying():
+					return sd.catacomb.ErrDying()
+				}
+			}
+			if change == exposed && sameCIDRs(changeCIDRs, cidrs) {
 				continue
 			}
 
 			exposed = change
+			cidrs = changeCIDRs
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -871,6 +1295,106 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
+// ingressSource identifies a port range opened to a single source CIDR.
+// Ingress rules are compared and reference counted by their sources, as
+// providers may merge the rules for a port range into one.
+type ingressSource struct {
+	portRange network.PortRange
+	cidr      string
+}
+
+// ingressSources returns the sources of the given ingress rules.
+func ingressSources(rules []network.IngressRule) []ingressSource {
+	var sources []ingressSource
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			sources = append(sources, ingressSource{rule.PortRange, cidr})
+		}
+	}
+	return sources
+}
+
+// ingressRulesFromSources returns the ingress rules that allow traffic
+// from the given sources, with one rule per port range.
+func ingressRulesFromSources(sources []ingressSource) []network.IngressRule {
+	cidrs := make(map[network.PortRange][]string)
+	for _, source := range sources {
+		cidrs[source.portRange] = append(cidrs[source.portRange], source.cidr)
+	}
+	var rules []network.IngressRule
+	for portRange, portCIDRs := range cidrs {
+		rules = append(rules, network.NewIngressRule(portRange, portCIDRs...))
+	}
+	network.SortIngressRules(rules)
+	return rules
+}
+
+// sameCIDRs returns whether A and B hold the same CIDRs, ignoring order.
+func sameCIDRs(A, B []string) bool {
+	return set.NewStrings(A...).Difference(set.NewStrings(B...)).IsEmpty() &&
+		set.NewStrings(B...).Difference(set.NewStrings(A...)).IsEmpty()
+}
+
+// sameEgressRules returns whether A and B hold the same egress rules,
+// both being sorted by network.SortEgressRules.
+func sameEgressRules(A, B []network.EgressRule) bool {
+	if len(A) != len(B) {
+		return false
+	}
+	for i := range A {
+		if A[i] != B[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// controllerEgressRules returns the egress rules allowing traffic to
+// the given API host ports of the controllers. Host names are skipped,
+// as they cannot be expressed as CIDRs.
+func controllerEgressRules(hostPorts [][]network.HostPort) []network.EgressRule {
+	seen := make(map[network.EgressRule]bool)
+	var rules []network.EgressRule
+	for _, server := range hostPorts {
+		for _, hp := range server {
+			ip := net.ParseIP(hp.Value)
+			if ip == nil {
+				continue
+			}
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			rule := network.NewEgressRule(
+				network.PortRange{FromPort: hp.Port, ToPort: hp.Port, Protocol: "tcp"},
+				fmt.Sprintf("%s/%d", ip, bits),
+			)
+			if !seen[rule] {
+				seen[rule] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	network.SortEgressRules(rules)
+	return rules
+}
+
+// diffRules returns the ingress rules allowing all the sources that are
+// allowed by A but not by B.
+func diffRules(A, B []network.IngressRule) []network.IngressRule {
+	allowed := make(map[ingressSource]bool)
+	for _, source := range ingressSources(B) {
+		allowed[source] = true
+	}
+	var missing []ingressSource
+	for _, source := range ingressSources(A) {
+		if !allowed[source] {
+			missing = append(missing, source)
+		}
+	}
+	return ingressRulesFromSources(missing)
+}
+
 // diffRanges returns all the port rangess that exist in A but not B.
 func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
 next:
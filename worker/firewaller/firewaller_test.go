@@ -10,9 +10,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	apifirewaller "github.com/juju/juju/api/firewaller"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju"
@@ -114,6 +116,78 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Por
 	}
 }
 
+// assertIngressRules retrieves the ingress rules of the instance and
+// compares them to the expected.
+func (s *firewallerBaseSuite) assertIngressRules(c *gc.C, inst instance.Instance, machineId string, expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := inst.(instance.IngressRuleFirewaller).IngressRules(machineId)
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertEnvironIngressRules retrieves the ingress rules of environment
+// and compares them to the expected.
+func (s *firewallerBaseSuite) assertEnvironIngressRules(c *gc.C, expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := s.Environ.(environs.IngressRuleFirewaller).IngressRules()
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertEgressRules retrieves the egress rules of the machine and
+// compares them to the expected.
+func (s *firewallerBaseSuite) assertEgressRules(c *gc.C, machineId string, expected []network.EgressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := s.Environ.(environs.EgressFirewaller).EgressRules(machineId)
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortEgressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	units, err := juju.AddUnits(s.State, app, app.Name(), 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -403,6 +477,153 @@ func (s *InstanceModeSuite) TestSetClearExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposedServiceToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	err = app.SetExposedCIDRs([]string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Changing the CIDRs replaces the rules.
+	err = app.SetExposedCIDRs([]string{"172.16.0.0/12"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "172.16.0.0/12"),
+	})
+
+	// Exposing to all sources again opens the ports to everyone.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}),
+	})
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestIngressNetworks(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), nil)
+
+	// The ports of an unexposed service are opened to the networks
+	// of its relations in other models.
+	err = rel.SetIngressNetworks([]string{"10.0.0.1/32"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.1/32"),
+	})
+
+	// Exposing the service adds its CIDRs to the networks.
+	err = app.SetExposedCIDRs([]string{"192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.1/32", "192.168.1.0/24"),
+	})
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetIngressNetworks([]string{"10.0.0.2/32"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.2/32"),
+	})
+
+	// Removing the relation closes the ports again.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1", "controller.example.com"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	_, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+
+	// Restricting the application also allows the controllers.
+	err = app.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "192.168.1.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, m.Id(), []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "192.168.1.0/24"),
+		network.NewEgressRule(network.PortRange{17070, 17070, "tcp"}, "10.0.0.1/32"),
+	})
+
+	// Moving the controllers updates the rules.
+	err = s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.2"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, m.Id(), []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "192.168.1.0/24"),
+		network.NewEgressRule(network.PortRange{17070, 17070, "tcp"}, "10.0.0.2/32"),
+	})
+
+	// Clearing the rules allows all outbound traffic again.
+	err = app.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestEgressRulesBeforeProvisioning(c *gc.C) {
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	err = app.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, m := s.addUnit(c, app)
+
+	// The rules are set once the machine is provisioned.
+	s.startInstance(c, m)
+	s.assertEgressRules(c, m.Id(), []network.EgressRule{
+		network.NewEgressRule(network.PortRange{17070, 17070, "tcp"}, "10.0.0.1/32"),
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}, "10.0.0.0/8"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -584,7 +805,7 @@ func (s *InstanceModeSuite) TestStartWithStateOpenPortsBroken(c *gc.C) {
 
 	// Nothing open without firewaller.
 	s.assertPorts(c, inst, m.Id(), nil)
-	dummy.SetInstanceBroken(inst, "OpenPorts")
+	dummy.SetInstanceBroken(inst, "OpenIngressRules")
 
 	// Starting the firewaller should attempt to open the ports,
 	// and fail due to the method being broken.
@@ -597,7 +818,7 @@ func (s *InstanceModeSuite) TestStartWithStateOpenPortsBroken(c *gc.C) {
 	select {
 	case err := <-errc:
 		c.Assert(err, gc.ErrorMatches,
-			`cannot respond to units changes for "machine-1": dummyInstance.OpenPorts is broken`)
+			`cannot respond to units changes for "machine-1": dummyInstance.OpenIngressRules is broken`)
 	case <-time.After(coretesting.LongWait):
 		fw.Kill()
 		fw.Wait()
@@ -605,6 +826,57 @@ func (s *InstanceModeSuite) TestStartWithStateOpenPortsBroken(c *gc.C) {
 	}
 }
 
+type ChangeInstanceRulesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ChangeInstanceRulesSuite{})
+
+func (s *ChangeInstanceRulesSuite) TestRestrictedRulesLeftClosed(c *gc.C) {
+	inst := &portsInstance{}
+	machineTag := names.NewMachineTag("0")
+	err := firewaller.ChangeInstanceRules(inst, machineTag, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.NewIngressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inst.opened, jc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+
+	// Restricting the sources of an opened port range closes it.
+	err = firewaller.ChangeInstanceRules(inst, machineTag, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	}, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.NewIngressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inst.opened, gc.HasLen, 0)
+}
+
+// portsInstance is an instance whose firewall can only open port
+// ranges to all sources.
+type portsInstance struct {
+	instance.Instance
+	opened []network.PortRange
+}
+
+func (inst *portsInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	inst.opened = append(inst.opened, ports...)
+	return nil
+}
+
+func (inst *portsInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	for _, port := range ports {
+		for i, opened := range inst.opened {
+			if opened == port {
+				inst.opened = append(inst.opened[:i], inst.opened[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
 type GlobalModeSuite struct {
 	firewallerBaseSuite
 }
@@ -670,6 +942,43 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestGlobalModeExposedCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingService(c, "wordpress", s.charm)
+	err = app1.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, app1)
+	s.startInstance(c, m1)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app2 := s.AddTestingService(c, "moinmoin", s.charm)
+	err = app2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, app2)
+	s.startInstance(c, m2)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironIngressRules(c, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "0.0.0.0/0", "10.0.0.0/8"),
+	})
+
+	// Unexposing one service leaves the other's rule in place.
+	err = app2.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironIngressRules(c, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	})
+
+	err = u1.ClosePort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironIngressRules(c, nil)
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -40,11 +40,10 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
-// to a sender.
+// to a sender. Each LogForwarder reads its own log stream, tracking
+// the last record sent under its own name, so that several of them
+// may forward to different sinks independently.
 type LogForwarder struct {
 	catacomb  catacomb.Catacomb
 	args      OpenLogForwarderArgs
@@ -79,7 +78,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -99,7 +98,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
+	if !ok || !cfg.Enabled() {
 		logger.Infof("config change - log forwarding not enabled")
 		return nil, closeExisting()
 	}
@@ -123,6 +122,10 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		Caller:    lf.args.Caller,
 		OpenSink:  lf.args.OpenSink,
 	})
+	if errors.Cause(err) == ErrSinkNotConfigured {
+		logger.Infof("config change - log forwarding to %s not configured", lf.args.Name)
+		return nil, nil
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -149,7 +152,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
@@ -38,7 +39,12 @@ func (s *LogForwarderSuite) SetUpTest(c *gc.C) {
 
 	s.stream = newStubStream()
 	s.sender = newStubSender()
-	s.rec = logfwd.Record{
+	s.rec = newTestRecord()
+}
+
+// newTestRecord returns a log record to be forwarded in tests.
+func newTestRecord() logfwd.Record {
+	return logfwd.Record{
 		Origin: logfwd.Origin{
 			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
 			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
@@ -47,8 +53,8 @@ func (s *LogForwarderSuite) SetUpTest(c *gc.C) {
 			Name:           "99",
 			Software: logfwd.Software{
 				PrivateEnterpriseNumber: 28978,
-				Name:    "jujud-machine-agent",
-				Version: version.Current,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.Current,
 			},
 		},
 		ID:        10,
@@ -86,8 +92,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		LogForwardConfig: configAPI,
 		AllModels:        true,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -166,6 +172,23 @@ func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	s.sender.stub.CheckCallNames(c)
 }
 
+func (s *LogForwarderSuite) TestSinkNotConfigured(c *gc.C) {
+	s.stream.addRecords(c, s.rec)
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.OpenSink = func(*config.LogFwdConfig) (*logforwarder.LogSink, error) {
+		return nil, logforwarder.ErrSinkNotConfigured
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	// A forwarder whose sink is not configured is idle.
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 func (s *LogForwarderSuite) TestStreamError(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stream.stub.SetErrors(nil, failure)
@@ -249,13 +272,15 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
+func (c *mockLogForwardConfig) LogForwardConfig() (*config.LogFwdConfig, bool, error) {
+	return &config.LogFwdConfig{
+		Syslog: &syslog.RawConfig{
+			Enabled:    c.enabled,
+			Host:       c.host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
 	}, true, nil
 }
 
@@ -7,10 +7,15 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
 )
 
+// orchestrator runs a LogForwarder for each of the log sinks, so that
+// each sink is fed from its own stream and a slow or failing sink does
+// not hold back delivery to the others.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -38,22 +43,52 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	var forwarders []worker.Worker
+	for _, spec := range args.Sinks {
+		lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			AllModels:        true,
+			ControllerUUID:   args.ControllerUUID,
+			LogForwardConfig: args.LogForwardConfig,
+			Caller:           args.Caller,
+			Name:             spec.Name,
+			OpenSink:         spec.OpenFn,
+			OpenLogStream:    args.OpenLogStream,
+		})
+		if err != nil {
+			for _, w := range forwarders {
+				worker.Stop(w)
+			}
+			return nil, errors.Annotatef(err, "opening log forwarder %q", spec.Name)
+		}
+		forwarders = append(forwarders, lf)
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		AllModels:        true,
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+
+	o := &orchestrator{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	<-o.catacomb.Dying()
+	return o.catacomb.ErrDying()
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
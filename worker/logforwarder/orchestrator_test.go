@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type OrchestratorSuite struct {
+	testing.IsolationSuite
+
+	streams map[string]*stubStream
+	senders map[string]*stubSender
+	rec     logfwd.Record
+}
+
+var _ = gc.Suite(&OrchestratorSuite{})
+
+func (s *OrchestratorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.streams = map[string]*stubStream{
+		"sink-a": newStubStream(),
+		"sink-b": newStubStream(),
+	}
+	s.senders = map[string]*stubSender{
+		"sink-a": newStubSender(),
+		"sink-b": newStubSender(),
+	}
+	s.rec = newTestRecord()
+}
+
+func (s *OrchestratorSuite) orchestratorArgs(c *gc.C) logforwarder.OrchestratorArgs {
+	var specs []logforwarder.LogSinkSpec
+	for _, name := range []string{"sink-a", "sink-b"} {
+		sender := s.senders[name]
+		specs = append(specs, logforwarder.LogSinkSpec{
+			Name: name,
+			OpenFn: func(cfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+				sender.host = cfg.Syslog.Host
+				return &logforwarder.LogSink{sender}, nil
+			},
+		})
+	}
+	return logforwarder.OrchestratorArgs{
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		LogForwardConfig: &mockLogForwardConfig{
+			enabled: true,
+			host:    "10.0.0.1",
+		},
+		Caller: &mockCaller{},
+		Sinks:  specs,
+		OpenLogStream: func(_ base.APICaller, cfg params.LogStreamConfig, _ string) (logforwarder.LogStream, error) {
+			c.Check(cfg.AllModels, jc.IsTrue)
+			return s.streams[cfg.Sink], nil
+		},
+		OpenLogForwarder: logforwarder.NewLogForwarder,
+	}
+}
+
+func (s *OrchestratorSuite) TestSinksForwardIndependently(c *gc.C) {
+	o, err := logforwarder.NewOrchestratorForController(s.orchestratorArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, o)
+
+	// Only sink-b's stream has a record ready; sink-a waiting on its
+	// own stream must not stop sink-b from sending.
+	s.streams["sink-b"].addRecords(c, s.rec)
+	s.senders["sink-b"].waitForSend(c)
+
+	rec1 := s.rec
+	rec1.ID = 11
+	s.streams["sink-a"].addRecords(c, rec1)
+	s.senders["sink-a"].waitForSend(c)
+
+	workertest.CleanKill(c, o)
+
+	rec1.Message = "send to 10.0.0.1"
+	s.senders["sink-a"].stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+	s.senders["sink-b"].stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{s.rec}}},
+		{"Close", nil},
+	})
+}
+
+func (s *OrchestratorSuite) TestNoSinks(c *gc.C) {
+	args := s.orchestratorArgs(c)
+	args.Sinks = nil
+	o, err := logforwarder.NewOrchestratorForController(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(o, gc.IsNil)
+}
+
+func (s *OrchestratorSuite) TestOpenForwarderError(c *gc.C) {
+	args := s.orchestratorArgs(c)
+	var opened []*logforwarder.LogForwarder
+	args.OpenLogForwarder = func(lfArgs logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+		if lfArgs.Name == "sink-b" {
+			return nil, errors.New("boom")
+		}
+		lf, err := logforwarder.NewLogForwarder(lfArgs)
+		opened = append(opened, lf)
+		return lf, err
+	}
+	_, err := logforwarder.NewOrchestratorForController(args)
+	c.Assert(err, gc.ErrorMatches, `opening log forwarder "sink-b": boom`)
+	c.Assert(opened, gc.HasLen, 1)
+	workertest.CheckKilled(c, opened[0])
+}
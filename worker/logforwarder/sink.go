@@ -4,7 +4,9 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
 
@@ -15,9 +17,13 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*config.LogFwdConfig, bool, error)
 }
 
+// ErrSinkNotConfigured is returned by a LogSinkFn when the log forward
+// configuration holds no settings for its sink.
+var ErrSinkNotConfigured = errors.New("log sink not configured")
+
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
@@ -26,8 +32,9 @@ type LogSinkSpec struct {
 	OpenFn LogSinkFn
 }
 
-// LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+// LogSinkFn is a function that opens a log sink. It returns
+// ErrSinkNotConfigured if the config does not include its sink.
+type LogSinkFn func(cfg *config.LogFwdConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
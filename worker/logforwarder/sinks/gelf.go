@@ -0,0 +1,31 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenGELF returns a sink used to receive log messages to be forwarded
+// to a GELF TCP input.
+func OpenGELF(lfCfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+	cfg := lfCfg.GELF
+	if cfg == nil {
+		return nil, logforwarder.ErrSinkNotConfigured
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := gelf.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
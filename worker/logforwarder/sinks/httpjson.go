@@ -0,0 +1,31 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTPJSON returns a sink used to receive log messages to be
+// posted in JSON batches to an HTTP endpoint.
+func OpenHTTPJSON(lfCfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+	cfg := lfCfg.HTTP
+	if cfg == nil {
+		return nil, logforwarder.ErrSinkNotConfigured
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+var openers = map[string]logforwarder.LogSinkFn{
+	"syslog":   sinks.OpenSyslog,
+	"gelf":     sinks.OpenGELF,
+	"httpjson": sinks.OpenHTTPJSON,
+}
+
+func (s *SinksSuite) TestNotConfigured(c *gc.C) {
+	cfg := &config.LogFwdConfig{
+		Syslog: &syslog.RawConfig{Enabled: true},
+	}
+	for name, open := range openers {
+		c.Logf("opening %s", name)
+		_, err := open(cfg)
+		c.Check(err, gc.Equals, logforwarder.ErrSinkNotConfigured)
+	}
+}
+
+func (s *SinksSuite) TestNotEnabled(c *gc.C) {
+	cfg := &config.LogFwdConfig{
+		Syslog: &syslog.RawConfig{Host: "10.0.0.1"},
+		GELF:   &gelf.RawConfig{Host: "10.0.0.1"},
+		HTTP:   &httpjson.RawConfig{URL: "http://10.0.0.1"},
+	}
+	for name, open := range openers {
+		c.Logf("opening %s", name)
+		_, err := open(cfg)
+		c.Check(err, gc.ErrorMatches, "log forwarding not enabled")
+	}
+}
+
+func (s *SinksSuite) TestOpenHTTPJSON(c *gc.C) {
+	cfg := &config.LogFwdConfig{
+		HTTP: &httpjson.RawConfig{Enabled: true, URL: "http://10.0.0.1", BatchSize: 5},
+	}
+	sink, err := sinks.OpenHTTPJSON(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	client, ok := sink.SendCloser.(*httpjson.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.URL, gc.Equals, "http://10.0.0.1")
+	c.Check(client.BatchSize, gc.Equals, 5)
+}
@@ -6,13 +6,19 @@ package sinks
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
-// OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+// OpenSyslog returns a sink used to receive log messages to be forwarded
+// to a syslog host.
+func OpenSyslog(lfCfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+	cfg := lfCfg.Syslog
+	if cfg == nil || cfg.Host == "" {
+		return nil, logforwarder.ErrSinkNotConfigured
+	}
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
@@ -9,8 +9,8 @@ import (
 
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
@@ -19,7 +19,7 @@ type TrackingSinkArgs struct {
 	AllModels bool
 
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config *config.LogFwdConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -65,6 +65,11 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// AddTransferredBytes records that a further number of bytes of
+	// the given kind of binary have been sent to the target
+	// controller.
+	AddTransferredBytes(kind string, count int64) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -370,10 +375,20 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		Tools:           serialized.Tools,
 		ToolsDownloader: w.config.ToolsDownloader,
 		ToolsUploader:   targetModelClient,
+		ReportTransfer:  w.reportTransfer,
 	})
 	return errors.Annotate(err, "failed migration binaries")
 }
 
+// reportTransfer records the size of a binary sent to the target
+// controller, so that the migration's progress can be shown to
+// users. Failing to record it isn't a reason to abort the migration.
+func (w *Worker) reportTransfer(kind string, size int64) {
+	if err := w.config.Facade.AddTransferredBytes(kind, size); err != nil {
+		w.logger.Warningf("failed to record transfer of %d bytes of %s: %v", size, kind, err)
+	}
+}
+
 func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigration.Phase, error) {
 	// Wait for agents to complete their validation checks.
 	ok, err := w.waitForMinions(status, failFast, "validating")
@@ -207,6 +207,7 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 				},
 				fakeToolsDownloader,
 			}},
+			{"facade.AddTransferredBytes", []interface{}{"charms", int64(1024)}},
 			apiCloseCall, // for target model
 			apiCloseCall, // for target controller
 			{"facade.SetPhase", []interface{}{coremigration.VALIDATION}},
@@ -900,6 +901,11 @@ func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (f *stubMasterFacade) AddTransferredBytes(kind string, count int64) error {
+	f.stub.AddCall("facade.AddTransferredBytes", kind, count)
+	return nil
+}
+
 func (f *stubMasterFacade) Reap() error {
 	f.stub.AddCall("facade.Reap")
 	return nil
@@ -973,6 +979,7 @@ func makeStubUploadBinaries(stub *jujutesting.Stub) func(migration.UploadBinarie
 			config.Tools,
 			config.ToolsDownloader,
 		)
+		config.ReportTransfer(coremigration.TransferCharms, 1024)
 		return nil
 	}
 }
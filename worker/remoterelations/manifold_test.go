@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/remoterelations"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := remoterelations.Manifold(remoterelations.ManifoldConfig{
+		APICallerName: "api-caller",
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := remoterelations.Manifold(remoterelations.ManifoldConfig{
+		APICallerName: "api-caller",
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	expectCaller := &fakeCaller{}
+	manifold := remoterelations.Manifold(remoterelations.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade: func(apiCaller base.APICaller) (remoterelations.Facade, error) {
+			c.Check(apiCaller, gc.Equals, expectCaller)
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": expectCaller,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartWorker(c *gc.C) {
+	expectFacade := &fakeFacade{}
+	expectWorker := &fakeWorker{}
+	manifold := remoterelations.Manifold(remoterelations.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade: func(_ base.APICaller) (remoterelations.Facade, error) {
+			return expectFacade, nil
+		},
+		NewWorker: func(config remoterelations.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, expectFacade)
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeFacade struct {
+	remoterelations.Facade
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/worker"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return remoterelations.NewAPI(
+		apiCaller,
+		watcher.NewStringsWatcher,
+		watcher.NewNotifyWatcher,
+	), nil
+}
+
+// NewWorker returns a worker.Worker that relays the model's
+// cross-model relations. It's a sensible value for
+// ManifoldConfig.NewWorker.
+func NewWorker(config Config) (worker.Worker, error) {
+	return New(config)
+}
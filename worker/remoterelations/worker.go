@@ -0,0 +1,321 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoterelations")
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// WatchRemoteApplications returns a StringsWatcher reporting
+	// the names of remote applications whose lifecycles change.
+	WatchRemoteApplications() (watcher.StringsWatcher, error)
+
+	// WatchRemoteApplicationRelations returns a StringsWatcher
+	// reporting the keys of relations involving the named remote
+	// application whose lifecycles change.
+	WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error)
+
+	// WatchRemoteRelationUnits returns a NotifyWatcher reporting
+	// changes to the units in scope, and their settings, on both
+	// sides of the synchronised relation with the supplied key.
+	WatchRemoteRelationUnits(key string) (watcher.NotifyWatcher, error)
+
+	// SyncRemoteRelation relays the units and settings of the
+	// relation with the supplied key to and from the model hosting
+	// its remote application. It returns a NotFound error once the
+	// relation has been removed.
+	SyncRemoteRelation(key string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	return nil
+}
+
+// New returns a worker that relays the cross-model relations of the
+// model's remote applications.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:           config,
+		relationChanges:  make(chan []string),
+		relationWatchers: make(map[string]*relationsWorker),
+		unitChanges:      make(chan string),
+		unitWatchers:     make(map[string]*relationUnitsWorker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker relays the units and settings of the model's cross-model
+// relations to and from the models hosting their remote applications.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// relationChanges receives the keys of changed relations from
+	// the relationWatchers, which are keyed by remote application.
+	relationChanges  chan []string
+	relationWatchers map[string]*relationsWorker
+
+	// unitChanges receives the keys of relations whose units have
+	// changed from the unitWatchers, which are keyed by relation.
+	unitChanges  chan string
+	unitWatchers map[string]*relationUnitsWorker
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	applicationWatcher, err := w.config.Facade.WatchRemoteApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(applicationWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case names, ok := <-applicationWatcher.Changes():
+			if !ok {
+				return errors.New("remote application watcher closed")
+			}
+			for _, name := range names {
+				if err := w.watchApplication(name); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		case keys := <-w.relationChanges:
+			for _, key := range keys {
+				if err := w.sync(key); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		case key := <-w.unitChanges:
+			if err := w.sync(key); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// watchApplication ensures that the relations of the named remote
+// application are being watched, if the application still exists.
+func (w *Worker) watchApplication(name string) error {
+	if _, ok := w.relationWatchers[name]; ok {
+		return nil
+	}
+	relationWatcher, err := w.config.Facade.WatchRemoteApplicationRelations(name)
+	if params.IsCodeNotFound(err) {
+		// The application has been removed, along with
+		// all of its relations.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	rw, err := newRelationsWorker(relationWatcher, w.relationChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(rw); err != nil {
+		return errors.Trace(err)
+	}
+	w.relationWatchers[name] = rw
+	return nil
+}
+
+// sync synchronises the relation with the supplied key, and ensures
+// that changes to its units are being watched; or stops watching them
+// if the relation has been removed.
+func (w *Worker) sync(key string) error {
+	err := w.config.Facade.SyncRemoteRelation(key)
+	if params.IsCodeNotFound(err) {
+		logger.Debugf("relation %q removed", key)
+		return errors.Trace(w.unwatchRelationUnits(key))
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot sync relation %q", key)
+	}
+	return errors.Trace(w.watchRelationUnits(key))
+}
+
+// watchRelationUnits ensures that the units of the relation with the
+// supplied key are being watched, if the relation needs synchronising
+// from this model.
+func (w *Worker) watchRelationUnits(key string) error {
+	if _, ok := w.unitWatchers[key]; ok {
+		return nil
+	}
+	unitWatcher, err := w.config.Facade.WatchRemoteRelationUnits(key)
+	if params.IsCodeNotFound(err) || params.IsCodeNotSupported(err) {
+		// The relation has been torn down since it was synced,
+		// or it mirrors a relation in another model.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot watch units of relation %q", key)
+	}
+	uw, err := newRelationUnitsWorker(unitWatcher, key, w.unitChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(uw); err != nil {
+		return errors.Trace(err)
+	}
+	w.unitWatchers[key] = uw
+	return nil
+}
+
+// unwatchRelationUnits stops watching the units of the relation with
+// the supplied key, if they are being watched.
+func (w *Worker) unwatchRelationUnits(key string) error {
+	uw, ok := w.unitWatchers[key]
+	if !ok {
+		return nil
+	}
+	delete(w.unitWatchers, key)
+	return worker.Stop(uw)
+}
+
+// relationsWorker forwards the changes reported by a remote
+// application's relations watcher to the main worker.
+type relationsWorker struct {
+	catacomb catacomb.Catacomb
+	watcher  watcher.StringsWatcher
+	out      chan<- []string
+}
+
+func newRelationsWorker(relationWatcher watcher.StringsWatcher, out chan<- []string) (*relationsWorker, error) {
+	rw := &relationsWorker{
+		watcher: relationWatcher,
+		out:     out,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &rw.catacomb,
+		Work: rw.loop,
+		Init: []worker.Worker{relationWatcher},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rw, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (rw *relationsWorker) Kill() {
+	rw.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (rw *relationsWorker) Wait() error {
+	return rw.catacomb.Wait()
+}
+
+func (rw *relationsWorker) loop() error {
+	for {
+		select {
+		case <-rw.catacomb.Dying():
+			return rw.catacomb.ErrDying()
+		case keys, ok := <-rw.watcher.Changes():
+			if !ok {
+				return errors.New("relation watcher closed")
+			}
+			select {
+			case <-rw.catacomb.Dying():
+				return rw.catacomb.ErrDying()
+			case rw.out <- keys:
+			}
+		}
+	}
+}
+
+// relationUnitsWorker forwards the changes reported by a relation's
+// units watcher to the main worker, as the relation's key.
+type relationUnitsWorker struct {
+	catacomb catacomb.Catacomb
+	watcher  watcher.NotifyWatcher
+	key      string
+	out      chan<- string
+}
+
+func newRelationUnitsWorker(unitWatcher watcher.NotifyWatcher, key string, out chan<- string) (*relationUnitsWorker, error) {
+	uw := &relationUnitsWorker{
+		watcher: unitWatcher,
+		key:     key,
+		out:     out,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &uw.catacomb,
+		Work: uw.loop,
+		Init: []worker.Worker{unitWatcher},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return uw, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (uw *relationUnitsWorker) Kill() {
+	uw.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (uw *relationUnitsWorker) Wait() error {
+	return uw.catacomb.Wait()
+}
+
+func (uw *relationUnitsWorker) loop() error {
+	for {
+		select {
+		case <-uw.catacomb.Dying():
+			return uw.catacomb.ErrDying()
+		case _, ok := <-uw.watcher.Changes():
+			if !ok {
+				return errors.New("relation units watcher closed")
+			}
+			select {
+			case <-uw.catacomb.Dying():
+				return uw.catacomb.ErrDying()
+			case uw.out <- uw.key:
+			}
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	facade *stubFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = newStubFacade()
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) *remoterelations.Worker {
+	w, err := remoterelations.New(remoterelations.Config{
+		Facade: s.facade,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitSync(c *gc.C) string {
+	select {
+	case key := <-s.facade.synced:
+		return key
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for SyncRemoteRelation call")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoSync(c *gc.C) {
+	select {
+	case key := <-s.facade.synced:
+		c.Fatalf("unexpected sync of %q", key)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) waitUnitsWatcher(c *gc.C, key string) *stubNotifyWatcher {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if w := s.facade.unitsWatcher(key); w != nil {
+			return w
+		}
+	}
+	c.Fatalf("timed out waiting for units of %q to be watched", key)
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := remoterelations.New(remoterelations.Config{})
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestSyncsChangedRelations(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationChanges <- []string{"mysql"}
+	s.facade.relationChanges("mysql") <- []string{"wordpress:db mysql:server"}
+	c.Check(s.waitSync(c), gc.Equals, "wordpress:db mysql:server")
+
+	s.facade.relationChanges("mysql") <- []string{"mediawiki:db mysql:server"}
+	c.Check(s.waitSync(c), gc.Equals, "mediawiki:db mysql:server")
+	s.assertNoSync(c)
+}
+
+func (s *WorkerSuite) TestSyncsRelationsWithChangedUnits(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationChanges <- []string{"mysql"}
+	s.facade.relationChanges("mysql") <- []string{
+		"wordpress:db mysql:server", "mediawiki:db mysql:server",
+	}
+	c.Check(s.waitSync(c), gc.Equals, "wordpress:db mysql:server")
+	c.Check(s.waitSync(c), gc.Equals, "mediawiki:db mysql:server")
+
+	// Only relations whose units change are synced again.
+	s.waitUnitsWatcher(c, "mediawiki:db mysql:server").changes <- struct{}{}
+	c.Check(s.waitSync(c), gc.Equals, "mediawiki:db mysql:server")
+	s.assertNoSync(c)
+
+	// A relation's units are watched only once.
+	s.facade.relationChanges("mysql") <- []string{"wordpress:db mysql:server"}
+	c.Check(s.waitSync(c), gc.Equals, "wordpress:db mysql:server")
+	workertest.CheckAlive(c, w)
+	c.Check(s.facade.unitsWatchCount(), gc.Equals, 2)
+}
+
+func (s *WorkerSuite) TestForgetsRemovedRelations(c *gc.C) {
+	s.facade.setRemoved("wordpress:db mysql:server")
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationChanges <- []string{"mysql"}
+	s.facade.relationChanges("mysql") <- []string{
+		"wordpress:db mysql:server", "mediawiki:db mysql:server",
+	}
+	c.Check(s.waitSync(c), gc.Equals, "wordpress:db mysql:server")
+	c.Check(s.waitSync(c), gc.Equals, "mediawiki:db mysql:server")
+	unitsWatcher := s.waitUnitsWatcher(c, "mediawiki:db mysql:server")
+	c.Check(s.facade.unitsWatcher("wordpress:db mysql:server"), gc.IsNil)
+
+	// Once removed, a relation's units are no longer watched.
+	s.facade.setRemoved("mediawiki:db mysql:server")
+	unitsWatcher.changes <- struct{}{}
+	c.Check(s.waitSync(c), gc.Equals, "mediawiki:db mysql:server")
+	workertest.CheckKilled(c, unitsWatcher)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestIgnoresMirrorRelations(c *gc.C) {
+	// In the offering model, the worker sees the relations of consumer
+	// proxies; they are synced, and watched, from the consuming model.
+	s.facade.setMirror("wordpress:db mysql:server")
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationChanges <- []string{"wordpress"}
+	s.facade.relationChanges("wordpress") <- []string{"wordpress:db mysql:server"}
+	c.Check(s.waitSync(c), gc.Equals, "wordpress:db mysql:server")
+	workertest.CheckAlive(c, w)
+	c.Check(s.facade.unitsWatchCount(), gc.Equals, 0)
+}
+
+func (s *WorkerSuite) TestIgnoresRemovedApplications(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationChanges <- []string{"missing"}
+	workertest.CheckAlive(c, w)
+	s.assertNoSync(c)
+}
+
+func (s *WorkerSuite) TestSyncError(c *gc.C) {
+	s.facade.syncErr = errors.New("splat")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.facade.applicationChanges <- []string{"mysql"}
+	s.facade.relationChanges("mysql") <- []string{"wordpress:db mysql:server"}
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, `cannot sync relation "wordpress:db mysql:server": splat`)
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("blam")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+}
+
+func (s *WorkerSuite) TestWatchUnitsError(c *gc.C) {
+	s.facade.watchUnitsErr = errors.New("kaboom")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.facade.applicationChanges <- []string{"mysql"}
+	s.facade.relationChanges("mysql") <- []string{"wordpress:db mysql:server"}
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, `cannot watch units of relation "wordpress:db mysql:server": kaboom`)
+}
+
+// stubFacade implements remoterelations.Facade, recording the keys
+// of the relations it's asked to sync.
+type stubFacade struct {
+	mu                 sync.Mutex
+	applicationChanges chan []string
+	relations          map[string]chan []string
+	unitsWatchers      map[string]*stubNotifyWatcher
+	removed            map[string]bool
+	mirrors            map[string]bool
+	synced             chan string
+	watchErr           error
+	watchUnitsErr      error
+	syncErr            error
+}
+
+func newStubFacade() *stubFacade {
+	return &stubFacade{
+		applicationChanges: make(chan []string, 1),
+		relations:          make(map[string]chan []string),
+		unitsWatchers:      make(map[string]*stubNotifyWatcher),
+		removed:            make(map[string]bool),
+		mirrors:            make(map[string]bool),
+		synced:             make(chan string, 10),
+	}
+}
+
+func (f *stubFacade) setRemoved(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removed[key] = true
+}
+
+func (f *stubFacade) setMirror(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mirrors[key] = true
+}
+
+func (f *stubFacade) unitsWatcher(key string) *stubNotifyWatcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.unitsWatchers[key]
+}
+
+func (f *stubFacade) unitsWatchCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.unitsWatchers)
+}
+
+func (f *stubFacade) relationChanges(application string) chan []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch, ok := f.relations[application]
+	if !ok {
+		ch = make(chan []string, 1)
+		f.relations[application] = ch
+	}
+	return ch
+}
+
+func (f *stubFacade) WatchRemoteApplications() (watcher.StringsWatcher, error) {
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return &stubWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: f.applicationChanges,
+	}, nil
+}
+
+func (f *stubFacade) WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error) {
+	if application == "missing" {
+		return nil, &params.Error{Code: params.CodeNotFound, Message: "remote application not found"}
+	}
+	return &stubWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: f.relationChanges(application),
+	}, nil
+}
+
+func (f *stubFacade) WatchRemoteRelationUnits(key string) (watcher.NotifyWatcher, error) {
+	if f.watchUnitsErr != nil {
+		return nil, f.watchUnitsErr
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mirrors[key] {
+		return nil, &params.Error{Code: params.CodeNotSupported, Message: "watching mirror relation not supported"}
+	}
+	w := &stubNotifyWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan struct{}, 1),
+	}
+	f.unitsWatchers[key] = w
+	return w, nil
+}
+
+func (f *stubFacade) SyncRemoteRelation(key string) error {
+	if f.syncErr != nil {
+		return f.syncErr
+	}
+	f.synced <- key
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.removed[key] {
+		return &params.Error{Code: params.CodeNotFound, Message: "relation not found"}
+	}
+	return nil
+}
+
+type stubWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func (w *stubWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+type stubNotifyWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+func (w *stubNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
@@ -5,4 +5,5 @@ package storageprovisioner
 
 var (
 	NewManagedFilesystemSource = &newManagedFilesystemSource
+	DiskUsage                  = &diskUsage
 )
@@ -65,6 +65,41 @@ func filesystemsChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// filesystemResizesChanged is called when new sizes have been requested
+// for the filesystems with the provided IDs.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewFilesystemTag(change)
+	}
+	results, err := ctx.config.Filesystems.FilesystemResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize params")
+	}
+	ops := make([]scheduleOp, 0, len(results))
+	for i, result := range results {
+		if params.IsCodeNotFound(result.Error) {
+			// The resize has already been completed.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		op, err := resizeFilesystemOpFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // filesystemAttachmentsChanged is called when the lifecycle states of the filesystem
 // attachments with the provided IDs have been seen to have changed.
 func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -206,6 +241,11 @@ func processDeadFilesystems(ctx *context, tags []names.FilesystemTag, filesystem
 	var remove []names.Tag
 	for i, result := range filesystemResults {
 		tag := tags[i]
+		if result.Error == nil && result.Result.Releasing {
+			logger.Debugf("filesystem %s is being released, queuing for removal", tag.Id())
+			remove = append(remove, tag)
+			continue
+		}
 		if result.Error == nil {
 			logger.Debugf("filesystem %s is provisioned, queuing for deprovisioning", tag.Id())
 			filesystem, err := filesystemFromParams(result.Result)
@@ -465,6 +505,29 @@ func filesystemParamsFromParams(in params.FilesystemParams) (storage.FilesystemP
 	}, nil
 }
 
+func resizeFilesystemOpFromParams(in params.FilesystemResizeParams) (*resizeFilesystemOp, error) {
+	filesystemTag, err := names.ParseFilesystemTag(in.FilesystemTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	if in.VolumeTag != "" {
+		volumeTag, err = names.ParseVolumeTag(in.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &resizeFilesystemOp{
+		provider: storage.ProviderType(in.Provider),
+		volume:   volumeTag,
+		args: storage.ResizeFilesystemParams{
+			Tag:          filesystemTag,
+			FilesystemId: in.FilesystemId,
+			Size:         in.Size,
+		},
+	}, nil
+}
+
 func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams) (storage.FilesystemAttachmentParams, error) {
 	machineTag, err := names.ParseMachineTag(in.MachineTag)
 	if err != nil {
@@ -330,6 +330,85 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 	return nil
 }
 
+// resizeFilesystems grows filesystems to the sizes specified in the
+// operations.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	argsBySource := make(map[string][]storage.ResizeFilesystemParams)
+	filesystemSources := make(map[string]storage.FilesystemSource)
+	for _, op := range ops {
+		sourceName := string(op.provider)
+		if op.volume != (names.VolumeTag{}) {
+			// Volume-backed filesystems are managed by the
+			// machine storage provisioner, regardless of the
+			// provider of the backing volume.
+			sourceName = ""
+			filesystemSources[sourceName] = ctx.managedFilesystemSource
+		}
+		argsBySource[sourceName] = append(argsBySource[sourceName], op.args)
+	}
+	var reschedule []scheduleOp
+	var filesystems []storage.Filesystem
+	for sourceName, args := range argsBySource {
+		logger.Debugf("resizing filesystems from %q: %v", sourceName, args)
+		source, ok := filesystemSources[sourceName]
+		if !ok {
+			var err error
+			source, err = filesystemSource(
+				ctx.config.StorageDir, sourceName,
+				storage.ProviderType(sourceName), ctx.config.Registry,
+			)
+			if err != nil {
+				return errors.Annotate(err, "getting filesystem source")
+			}
+		}
+		resizer, ok := source.(storage.FilesystemResizer)
+		if !ok {
+			logger.Warningf("storage provider %q does not support resizing filesystems", sourceName)
+			continue
+		}
+		results, err := resizer.ResizeFilesystems(args)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[args[i].Tag])
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(args[i].Tag),
+					result.Error,
+				)
+				continue
+			}
+			filesystems = append(filesystems, storage.Filesystem{
+				Tag:            args[i].Tag,
+				Volume:         ops[args[i].Tag].volume,
+				FilesystemInfo: *result.FilesystemInfo,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		updateFilesystem(ctx, filesystems[i])
+	}
+	return nil
+}
+
 // filesystemParamsBySource separates the filesystem parameters by filesystem source.
 func filesystemParamsBySource(
 	baseStorageDir string,
@@ -536,3 +615,20 @@ func (op *detachFilesystemOp) key() interface{} {
 		AttachmentTag: op.args.Filesystem.String(),
 	}
 }
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	volume   names.VolumeTag
+	args     storage.ResizeFilesystemParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	// Resizing is keyed separately to the filesystem tag, so
+	// that it does not replace other filesystem operations.
+	return resizeFilesystemKey{op.args.Tag}
+}
+
+type resizeFilesystemKey struct {
+	tag names.FilesystemTag
+}
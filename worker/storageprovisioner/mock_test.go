@@ -90,6 +90,8 @@ func (w *mockAttachmentsWatcher) Changes() watcher.MachineStorageIdsChannel {
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
+	snapshotsWatcher       *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
@@ -99,6 +101,9 @@ type mockVolumeAccessor struct {
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	volumeResizeParams      func([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+	volumeSnapshotParams    func([]names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error)
+	setVolumeSnapshots      func([]params.VolumeSnapshots) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -116,6 +121,14 @@ func (w *mockVolumeAccessor) WatchVolumes() (watcher.StringsWatcher, error) {
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshotRequests() (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -211,6 +224,44 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if v.volumeResizeParams != nil {
+		return v.volumeResizeParams(volumes)
+	}
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		result = append(result, params.VolumeResizeParamsResult{Result: params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  "vol-" + tag.Id(),
+			Provider:  "dummy",
+			Size:      2048,
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(volumes []names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error) {
+	if v.volumeSnapshotParams != nil {
+		return v.volumeSnapshotParams(volumes)
+	}
+	var result []params.VolumeSnapshotParamsResult
+	for _, tag := range volumes {
+		result = append(result, params.VolumeSnapshotParamsResult{Result: params.VolumeSnapshotParams{
+			VolumeTag: tag.String(),
+			VolumeId:  "vol-" + tag.Id(),
+			Provider:  "dummy",
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshots(snapshots []params.VolumeSnapshots) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshots != nil {
+		return v.setVolumeSnapshots(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -228,6 +279,8 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
+		snapshotsWatcher:       newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
@@ -240,12 +293,15 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 type mockFilesystemAccessor struct {
 	filesystemsWatcher     *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
+	resizesWatcher         *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+	setStorageUsage             func([]params.MachineStorageUsage) ([]params.ErrorResult, error)
+	filesystemResizeParams      func([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
 }
 
 func (m *mockFilesystemAccessor) provisionFilesystem(tag names.FilesystemTag) params.Filesystem {
@@ -267,6 +323,10 @@ func (w *mockFilesystemAccessor) WatchFilesystemAttachments() (watcher.MachineSt
 	return w.attachmentsWatcher, nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (v *mockFilesystemAccessor) Filesystems(filesystems []names.FilesystemTag) ([]params.FilesystemResult, error) {
 	var result []params.FilesystemResult
 	for _, tag := range filesystems {
@@ -335,6 +395,26 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 	return result, nil
 }
 
+func (f *mockFilesystemAccessor) FilesystemResizeParams(filesystems []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	if f.filesystemResizeParams != nil {
+		return f.filesystemResizeParams(filesystems)
+	}
+	var result []params.FilesystemResizeParamsResult
+	for _, tag := range filesystems {
+		resizeParams := params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  "vol-" + tag.Id(),
+			Provider:      "dummy",
+			Size:          2048,
+		}
+		if _, ok := names.FilesystemMachine(tag); ok {
+			resizeParams.VolumeTag = names.NewVolumeTag(tag.Id()).String()
+		}
+		result = append(result, params.FilesystemResizeParamsResult{Result: resizeParams})
+	}
+	return result, nil
+}
+
 func (f *mockFilesystemAccessor) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	if f.setFilesystemInfo != nil {
 		return f.setFilesystemInfo(filesystems)
@@ -349,10 +429,18 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 	return make([]params.ErrorResult, len(filesystemAttachments)), nil
 }
 
+func (f *mockFilesystemAccessor) SetStorageUsage(usages []params.MachineStorageUsage) ([]params.ErrorResult, error) {
+	if f.setStorageUsage != nil {
+		return f.setStorageUsage(usages)
+	}
+	return make([]params.ErrorResult, len(usages)), nil
+}
+
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
@@ -431,6 +519,9 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	listVolumeSnapshotsFunc      func(storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -540,6 +631,47 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes resizes volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: p.VolumeId,
+			Size:     p.Size,
+		}
+	}
+	return results, nil
+}
+
+// CreateVolumeSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].Snapshot = &storage.VolumeSnapshot{
+			SnapshotId: p.VolumeId + "@snap",
+			VolumeId:   p.VolumeId,
+		}
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots lists the snapshots of a volume.
+func (s *dummyVolumeSource) ListVolumeSnapshots(params storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	if s.provider.listVolumeSnapshotsFunc != nil {
+		return s.provider.listVolumeSnapshotsFunc(params)
+	}
+	return []storage.VolumeSnapshot{{
+		SnapshotId: params.VolumeId + "@snap",
+		VolumeId:   params.VolumeId,
+	}}, nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -612,8 +744,9 @@ func (s *dummyFilesystemSource) DetachFilesystems(params []storage.FilesystemAtt
 }
 
 type mockManagedFilesystemSource struct {
-	blockDevices map[names.VolumeTag]storage.BlockDevice
-	filesystems  map[names.FilesystemTag]storage.Filesystem
+	blockDevices          map[names.VolumeTag]storage.BlockDevice
+	filesystems           map[names.FilesystemTag]storage.Filesystem
+	resizeFilesystemsFunc func([]storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error)
 }
 
 func (s *mockManagedFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
@@ -678,6 +811,13 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.Filesys
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error) {
+	if s.resizeFilesystemsFunc != nil {
+		return s.resizeFilesystemsFunc(args)
+	}
+	return nil, errors.NotImplementedf("ResizeFilesystems")
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
@@ -715,6 +855,7 @@ type mockClock struct {
 	onNow       func() time.Time
 	onAfter     func(time.Duration) <-chan time.Time
 	onAfterFunc func(time.Duration, func()) clock.Timer
+	onNewTimer  func(time.Duration) clock.Timer
 }
 
 func (c *mockClock) Now() time.Time {
@@ -739,6 +880,9 @@ func (c *mockClock) After(d time.Duration) <-chan time.Time {
 }
 
 func (c *mockClock) NewTimer(d time.Duration) clock.Timer {
+	if c.onNewTimer != nil {
+		return c.onNewTimer(d)
+	}
 	return mockTimer{time.NewTimer(0)}
 }
 
@@ -761,6 +905,24 @@ func (t mockTimer) Chan() <-chan time.Time {
 	return t.C
 }
 
+// manualTimer is a clock.Timer that fires only when
+// a value is sent on its channel.
+type manualTimer struct {
+	c chan time.Time
+}
+
+func (t manualTimer) Chan() <-chan time.Time {
+	return t.c
+}
+
+func (t manualTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t manualTimer) Stop() bool {
+	return true
+}
+
 type mockStatusSetter struct {
 	args      []params.EntityStatusArgs
 	setStatus func([]params.EntityStatusArgs) error
@@ -27,8 +27,11 @@
 package storageprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -60,6 +63,14 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for requests to resize volumes that
+	// this storage provisioner is responsible for.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshotRequests watches for requests to snapshot
+	// volumes that this storage provisioner is responsible for.
+	WatchVolumeSnapshotRequests() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -79,12 +90,24 @@ type VolumeAccessor interface {
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// VolumeSnapshotParams returns the parameters for snapshotting
+	// the volumes with the specified tags.
+	VolumeSnapshotParams([]names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error)
+
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshots records the snapshots of volumes, completing
+	// any pending snapshot requests.
+	SetVolumeSnapshots([]params.VolumeSnapshots) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -98,6 +121,10 @@ type FilesystemAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchFilesystemAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchFilesystemResizes watches for requests to resize filesystems
+	// that this storage provisioner is responsible for.
+	WatchFilesystemResizes() (watcher.StringsWatcher, error)
+
 	// Filesystems returns details of filesystems with the specified tags.
 	Filesystems([]names.FilesystemTag) ([]params.FilesystemResult, error)
 
@@ -113,12 +140,20 @@ type FilesystemAccessor interface {
 	// filesystem attachments with the specified tags.
 	FilesystemAttachmentParams([]params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error)
 
+	// FilesystemResizeParams returns the parameters for resizing the
+	// filesystems with the specified tags.
+	FilesystemResizeParams([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
+
 	// SetFilesystemInfo records the details of newly provisioned filesystems.
 	SetFilesystemInfo([]params.Filesystem) ([]params.ErrorResult, error)
 
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// SetStorageUsage records the usage of volumes and filesystems
+	// attached to machines.
+	SetStorageUsage([]params.MachineStorageUsage) ([]params.ErrorResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -198,15 +233,21 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
+		usageReportTimer             clock.Timer
+		usageReportChanges           <-chan time.Time
 	)
 	machineChanges := make(chan names.MachineTag)
 
 	// Machine-scoped provisioners need to watch block devices, to create
-	// volume-backed filesystems.
+	// volume-backed filesystems, and periodically report the usage of
+	// attached filesystems.
 	if machineTag, ok := w.config.Scope.(names.MachineTag); ok {
 		machineBlockDevicesWatcher, err := w.config.Volumes.WatchBlockDevices(machineTag)
 		if err != nil {
@@ -216,6 +257,10 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		machineBlockDevicesChanges = machineBlockDevicesWatcher.Changes()
+
+		usageReportTimer = w.config.Clock.NewTimer(usageReportInterval)
+		defer usageReportTimer.Stop()
+		usageReportChanges = usageReportTimer.Chan()
 	}
 
 	volumesWatcher, err := w.config.Volumes.WatchVolumes()
@@ -227,6 +272,24 @@ func (w *storageProvisioner) loop() error {
 	}
 	volumesChanges = volumesWatcher.Changes()
 
+	volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+	if err != nil {
+		return errors.Annotate(err, "watching volume resizes")
+	}
+	if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	volumeResizesChanges = volumeResizesWatcher.Changes()
+
+	volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshotRequests()
+	if err != nil {
+		return errors.Annotate(err, "watching volume snapshot requests")
+	}
+	if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems()
 	if err != nil {
 		return errors.Annotate(err, "watching filesystems")
@@ -236,6 +299,15 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemsChanges = filesystemsWatcher.Changes()
 
+	filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes()
+	if err != nil {
+		return errors.Annotate(err, "watching filesystem resizes")
+	}
+	if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	filesystemResizesChanges = filesystemResizesWatcher.Changes()
+
 	volumeAttachmentsWatcher, err := w.config.Volumes.WatchVolumeAttachments()
 	if err != nil {
 		return errors.Annotate(err, "watching volume attachments")
@@ -271,6 +343,7 @@ func (w *storageProvisioner) loop() error {
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
 		pendingVolumeBlockDevices:            make(set.Tags),
+		reportedUsage:                        make(map[string]params.StorageUsage),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
 		ctx.volumeBlockDevices, ctx.filesystems,
@@ -292,6 +365,20 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshot requests watcher closed")
+			}
+			if err := volumeSnapshotRequestsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -306,6 +393,13 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemAttachmentsChanges:
 			if !ok {
 				return errors.New("filesystem attachments watcher closed")
@@ -324,6 +418,14 @@ func (w *storageProvisioner) loop() error {
 			if err := refreshMachine(&ctx, machineTag); err != nil {
 				return errors.Trace(err)
 			}
+		case <-usageReportChanges:
+			if err := reportStorageUsage(&ctx); err != nil {
+				// Failing to report usage should not prevent the
+				// worker from provisioning storage; we'll try again
+				// at the next interval.
+				logger.Warningf("%v", err)
+			}
+			usageReportTimer.Reset(usageReportInterval)
 		case <-ctx.schedule.Next():
 			// Ready to pick something(s) off the pending queue.
 			if err := processSchedule(&ctx); err != nil {
@@ -340,10 +442,13 @@ func processSchedule(ctx *context) error {
 	destroyVolumeOps := make(map[names.VolumeTag]*destroyVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	snapshotVolumeOps := make(map[names.VolumeTag]*snapshotVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -356,6 +461,10 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
+		case *snapshotVolumeOp:
+			snapshotVolumeOps[op.args.Tag] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *destroyFilesystemOp:
@@ -364,6 +473,8 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
 		}
 	}
 	if len(destroyVolumeOps) > 0 {
@@ -386,6 +497,16 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(snapshotVolumeOps) > 0 {
+		if err := snapshotVolumes(ctx, snapshotVolumeOps); err != nil {
+			return errors.Annotate(err, "snapshotting volumes")
+		}
+	}
 	if len(destroyFilesystemOps) > 0 {
 		if err := destroyFilesystems(ctx, destroyFilesystemOps); err != nil {
 			return errors.Annotate(err, "destroying filesystems")
@@ -406,6 +527,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
 	return nil
 }
 
@@ -480,6 +606,11 @@ type context struct {
 	// block devices we wish to enquire.
 	pendingVolumeBlockDevices set.Tags
 
+	// reportedUsage contains the most recently reported usage of
+	// volumes and filesystems, keyed by tag. This is only used by
+	// the machine-scoped storage provisioner.
+	reportedUsage map[string]params.StorageUsage
+
 	// managedFilesystemSource is a storage.FilesystemSource that
 	// manages filesystems backed by volumes attached to the host
 	// machine.
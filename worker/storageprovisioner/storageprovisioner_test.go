@@ -26,6 +26,7 @@ type storageProvisionerSuite struct {
 	provider                *dummyProvider
 	registry                storage.ProviderRegistry
 	managedFilesystemSource *mockManagedFilesystemSource
+	resizeFilesystemsFunc   func([]storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error)
 }
 
 var _ = gc.Suite(&storageProvisionerSuite{})
@@ -40,6 +41,7 @@ func (s *storageProvisionerSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.managedFilesystemSource = nil
+	s.resizeFilesystemsFunc = nil
 	s.PatchValue(
 		storageprovisioner.NewManagedFilesystemSource,
 		func(
@@ -47,12 +49,16 @@ func (s *storageProvisionerSuite) SetUpTest(c *gc.C) {
 			filesystems map[names.FilesystemTag]storage.Filesystem,
 		) storage.FilesystemSource {
 			s.managedFilesystemSource = &mockManagedFilesystemSource{
-				blockDevices: blockDevices,
-				filesystems:  filesystems,
+				blockDevices:          blockDevices,
+				filesystems:           filesystems,
+				resizeFilesystemsFunc: s.resizeFilesystemsFunc,
 			}
 			return s.managedFilesystemSource
 		},
 	)
+	s.PatchValue(storageprovisioner.DiskUsage, func(string) (uint64, uint64) {
+		return 0, 0
+	})
 }
 
 func (s *storageProvisionerSuite) TestStartStop(c *gc.C) {
@@ -1055,6 +1061,113 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestReportStorageUsage(c *gc.C) {
+	infoSet := make(chan interface{})
+	usageSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(attachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		infoSet <- attachments
+		return nil, nil
+	}
+	var usageErr error
+	filesystemAccessor.setStorageUsage = func(usages []params.MachineStorageUsage) ([]params.ErrorResult, error) {
+		err := usageErr
+		usageSet <- usages
+		return make([]params.ErrorResult, len(usages)), err
+	}
+
+	var used uint64 = 250
+	s.PatchValue(storageprovisioner.DiskUsage, func(path string) (uint64, uint64) {
+		c.Check(path, gc.Equals, "/mnt/xvdf1")
+		return 1000, used
+	})
+	usageTimer := manualTimer{make(chan time.Time)}
+	clock := &mockClock{
+		onNewTimer: func(d time.Duration) clock.Timer {
+			return usageTimer
+		},
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+		clock:       clock,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         123,
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       123,
+	}
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag:    "machine-0",
+		AttachmentTag: "filesystem-0-0",
+	}}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+	waitChannel(c, infoSet, "waiting for filesystem attachment info to be set")
+
+	// The usage of the filesystem is reported for both the
+	// filesystem and its backing volume.
+	usageTimer.c <- time.Time{}
+	usages := waitChannel(c, usageSet, "waiting for storage usage to be set")
+	c.Assert(usages, jc.DeepEquals, []params.MachineStorageUsage{{
+		MachineTag:    "machine-0",
+		AttachmentTag: "filesystem-0-0",
+		Usage:         params.StorageUsage{Capacity: 1000, Used: 250},
+	}, {
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+		Usage:         params.StorageUsage{Capacity: 1000, Used: 250},
+	}})
+
+	// Unchanged usage is not reported again.
+	usageTimer.c <- time.Time{}
+	assertNoEvent(c, usageSet, "storage usage set")
+
+	used = 500
+	usageTimer.c <- time.Time{}
+	usages = waitChannel(c, usageSet, "waiting for storage usage to be set")
+	c.Assert(usages, gc.HasLen, 2)
+	c.Assert(usages.([]params.MachineStorageUsage)[0].Usage, jc.DeepEquals, params.StorageUsage{
+		Capacity: 1000, Used: 500,
+	})
+
+	// Changes smaller than the reporting threshold are not reported.
+	used = 505
+	usageTimer.c <- time.Time{}
+	assertNoEvent(c, usageSet, "storage usage set")
+
+	// Failing to report usage does not stop the worker; the
+	// usage is reported again at the next interval.
+	usageErr = errors.New("report failed")
+	used = 750
+	usageTimer.c <- time.Time{}
+	waitChannel(c, usageSet, "waiting for storage usage to be set")
+	usageErr = nil
+	usageTimer.c <- time.Time{}
+	usages = waitChannel(c, usageSet, "waiting for storage usage to be set")
+	c.Assert(usages, gc.HasLen, 2)
+	c.Assert(usages.([]params.MachineStorageUsage)[0].Usage, jc.DeepEquals, params.StorageUsage{
+		Capacity: 1000, Used: 750,
+	})
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
@@ -1532,6 +1645,299 @@ func (s *storageProvisionerSuite) TestDestroyVolumes(c *gc.C) {
 	assertNoEvent(c, removedChan, "volumes removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].VolumeInfo = &storage.VolumeInfo{
+				VolumeId: arg.VolumeId,
+				Size:     arg.Size,
+			}
+		}
+		return results, nil
+	}
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.ResizeVolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesCompleted(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeResizeParams = func(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+		return []params.VolumeResizeParamsResult{{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "not found"},
+		}}, nil
+	}
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return make([]storage.ResizeVolumesResult, len(args)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The resize has already been completed, so
+	// there is nothing for the provisioner to do.
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	assertNoEvent(c, resizedChan, "volumes resized")
+}
+
+func (s *storageProvisionerSuite) TestSnapshotVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+
+	created := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshottedChan := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		snapshottedChan <- args
+		results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+		for i, arg := range args {
+			results[i].Snapshot = &storage.VolumeSnapshot{
+				SnapshotId: arg.VolumeId + "@2",
+				VolumeId:   arg.VolumeId,
+			}
+		}
+		return results, nil
+	}
+	s.provider.listVolumeSnapshotsFunc = func(arg storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+		return []storage.VolumeSnapshot{{
+			SnapshotId: arg.VolumeId + "@1", VolumeId: arg.VolumeId, Size: 1024, Created: created,
+		}, {
+			SnapshotId: arg.VolumeId + "@2", VolumeId: arg.VolumeId, Size: 1024, Created: created,
+		}}, nil
+	}
+	snapshotsSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshots = func(snapshots []params.VolumeSnapshots) ([]params.ErrorResult, error) {
+		snapshotsSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1"}
+	snapshotted := waitChannel(c, snapshottedChan, "waiting for volume to be snapshotted")
+	c.Assert(snapshotted, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+	}})
+	snapshots := waitChannel(c, snapshotsSet, "waiting for volume snapshots to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshots{{
+		VolumeTag: "volume-1",
+		Snapshots: []params.VolumeSnapshot{{
+			SnapshotId: "vol-1@1", VolumeId: "vol-1", Size: 1024, Created: created,
+		}, {
+			SnapshotId: "vol-1@2", VolumeId: "vol-1", Size: 1024, Created: created,
+		}},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestSnapshotVolumesRetry(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+
+	var calls int
+	snapshottedChan := make(chan interface{}, 2)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		snapshottedChan <- args
+		calls++
+		results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+		for i, arg := range args {
+			if calls == 1 {
+				results[i].Error = errors.New("badness")
+				continue
+			}
+			results[i].Snapshot = &storage.VolumeSnapshot{
+				SnapshotId: arg.VolumeId + "@snap",
+				VolumeId:   arg.VolumeId,
+			}
+		}
+		return results, nil
+	}
+	snapshotsSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshots = func(snapshots []params.VolumeSnapshots) ([]params.ErrorResult, error) {
+		snapshotsSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	clock := &mockClock{}
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry, clock: clock}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1"}
+	waitChannel(c, snapshottedChan, "waiting for volume to be snapshotted")
+
+	// The failed snapshot is retried, and the volume's
+	// snapshots are only recorded once it succeeds.
+	waitChannel(c, snapshottedChan, "waiting for volume snapshot to be retried")
+	snapshots := waitChannel(c, snapshotsSet, "waiting for volume snapshots to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshots{{
+		VolumeTag: "volume-1",
+		Snapshots: []params.VolumeSnapshot{{
+			SnapshotId: "vol-1@snap", VolumeId: "vol-1",
+		}},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeBackedFilesystems(c *gc.C) {
+	resizedChan := make(chan interface{}, 1)
+	s.resizeFilesystemsFunc = func(args []storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeFilesystemsResult, len(args))
+		for i, arg := range args {
+			results[i].FilesystemInfo = &storage.FilesystemInfo{
+				FilesystemId: arg.FilesystemId,
+				Size:         arg.Size,
+			}
+		}
+		return results, nil
+	}
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemInfoSet := make(chan interface{}, 1)
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"0/0"}
+	resized := waitChannel(c, resizedChan, "waiting for filesystem to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.ResizeFilesystemParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "vol-0/0",
+		Size:         2048,
+	}})
+	filesystems := waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+	c.Assert(filesystems, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "vol-0/0",
+			Size:         2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystemsCompleted(c *gc.C) {
+	resizedChan := make(chan interface{}, 1)
+	s.resizeFilesystemsFunc = func(args []storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error) {
+		resizedChan <- args
+		return make([]storage.ResizeFilesystemsResult, len(args)), nil
+	}
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.filesystemResizeParams = func(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+		return []params.FilesystemResizeParamsResult{{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "not found"},
+		}}, nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The resize has already been completed, so
+	// there is nothing for the provisioner to do.
+	filesystemAccessor.resizesWatcher.changes <- []string{"0/0"}
+	assertNoEvent(c, resizedChan, "filesystems resized")
+}
+
+func (s *storageProvisionerSuite) TestReleaseVolumes(c *gc.C) {
+	releasedVolume := names.NewVolumeTag("1")
+
+	volumeAccessor := newMockVolumeAccessor()
+	v := volumeAccessor.provisionVolume(releasedVolume)
+	v.Releasing = true
+	volumeAccessor.provisionedVolumes[releasedVolume.String()] = v
+
+	life := func(tags []names.Tag) ([]params.LifeResult, error) {
+		results := make([]params.LifeResult, len(tags))
+		for i := range results {
+			results[i].Life = params.Dead
+		}
+		return results, nil
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumesFunc = func(volumeIds []string) ([]error, error) {
+		destroyedChan <- volumeIds
+		return make([]error, len(volumeIds)), nil
+	}
+
+	removedChan := make(chan interface{}, 1)
+	remove := func(tags []names.Tag) ([]params.ErrorResult, error) {
+		removedChan <- tags
+		return make([]params.ErrorResult, len(tags)), nil
+	}
+
+	args := &workerArgs{
+		volumes: volumeAccessor,
+		life: &mockLifecycleManager{
+			life:   life,
+			remove: remove,
+		},
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{releasedVolume.Id()}
+
+	// The released volume should be removed from state
+	// without being deprovisioned.
+	removed := waitChannel(c, removedChan, "waiting for volume to be removed")
+	c.Assert(removed, jc.DeepEquals, []names.Tag{releasedVolume})
+	assertNoEvent(c, destroyedChan, "volumes deprovisioned")
+}
+
 func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	volume := names.NewVolumeTag("1")
 	volumeAccessor := newMockVolumeAccessor()
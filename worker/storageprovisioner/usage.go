@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/du"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// usageReportInterval is the interval at which a machine-scoped
+// storage provisioner reports the usage of attached filesystems.
+const usageReportInterval = 5 * time.Minute
+
+// usageReportThreshold is the change in the used bytes of a
+// filesystem, as a percentage of its capacity, that must be seen
+// before the new usage is reported.
+const usageReportThreshold = 1
+
+// diskUsage returns the capacity and used bytes of the filesystem
+// mounted at the specified path.
+var diskUsage = func(path string) (capacity, used uint64) {
+	usage := du.NewDiskUsage(path)
+	return usage.Size(), usage.Used()
+}
+
+// reportStorageUsage measures the usage of each filesystem attached to
+// the scoped machine, and reports any that has changed significantly
+// since it was last reported. The usage of a volume-backed filesystem is also reported as
+// the usage of its backing volume.
+func reportStorageUsage(ctx *context) error {
+	var usages []params.MachineStorageUsage
+	for id, attachment := range ctx.filesystemAttachments {
+		if attachment.Path == "" {
+			continue
+		}
+		capacity, used := diskUsage(attachment.Path)
+		if capacity == 0 {
+			// The filesystem could not be measured,
+			// e.g. because it is not yet mounted.
+			continue
+		}
+		usage := params.StorageUsage{Capacity: capacity, Used: used}
+		tags := []names.Tag{attachment.Filesystem}
+		if filesystem, ok := ctx.filesystems[attachment.Filesystem]; ok {
+			if filesystem.Volume != (names.VolumeTag{}) {
+				tags = append(tags, filesystem.Volume)
+			}
+		}
+		for _, tag := range tags {
+			if reported, ok := ctx.reportedUsage[tag.String()]; ok && !usageChanged(reported, usage) {
+				continue
+			}
+			usages = append(usages, params.MachineStorageUsage{
+				MachineTag:    id.MachineTag,
+				AttachmentTag: tag.String(),
+				Usage:         usage,
+			})
+		}
+	}
+	if len(usages) == 0 {
+		return nil
+	}
+	sort.Sort(byAttachmentTag(usages))
+	results, err := ctx.config.Filesystems.SetStorageUsage(usages)
+	if err != nil {
+		return errors.Annotate(err, "reporting storage usage")
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			// Failing to report usage should not prevent the
+			// worker from provisioning storage; we'll try again
+			// at the next interval.
+			logger.Warningf(
+				"failed to report usage of %s: %v",
+				usages[i].AttachmentTag, result.Error,
+			)
+			continue
+		}
+		ctx.reportedUsage[usages[i].AttachmentTag] = usages[i].Usage
+	}
+	return nil
+}
+
+// usageChanged reports whether the measured usage differs enough from
+// the reported usage to be worth reporting again.
+func usageChanged(reported, measured params.StorageUsage) bool {
+	if reported.Capacity != measured.Capacity {
+		return true
+	}
+	delta := measured.Used - reported.Used
+	if reported.Used > measured.Used {
+		delta = reported.Used - measured.Used
+	}
+	return delta*100 >= measured.Capacity*usageReportThreshold
+}
+
+type byAttachmentTag []params.MachineStorageUsage
+
+func (u byAttachmentTag) Len() int {
+	return len(u)
+}
+
+func (u byAttachmentTag) Less(i, j int) bool {
+	return u[i].AttachmentTag < u[j].AttachmentTag
+}
+
+func (u byAttachmentTag) Swap(i, j int) {
+	u[i], u[j] = u[j], u[i]
+}
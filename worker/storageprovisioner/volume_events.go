@@ -54,6 +54,76 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when new sizes have been requested
+// for the volumes with the provided IDs.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	ops := make([]scheduleOp, 0, len(results))
+	for i, result := range results {
+		if params.IsCodeNotFound(result.Error) {
+			// The resize has already been completed.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		op, err := resizeVolumeOpFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// volumeSnapshotRequestsChanged is called when snapshots have been
+// requested of the volumes with the provided IDs.
+func volumeSnapshotRequestsChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeSnapshotParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	ops := make([]scheduleOp, 0, len(results))
+	for i, result := range results {
+		if params.IsCodeNotFound(result.Error) {
+			// The snapshot has already been taken.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting snapshot params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		op, err := snapshotVolumeOpFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -173,6 +243,11 @@ func processDeadVolumes(ctx *context, tags []names.VolumeTag, volumeResults []pa
 	var remove []names.Tag
 	for i, result := range volumeResults {
 		tag := tags[i]
+		if result.Error == nil && result.Result.Releasing {
+			logger.Debugf("volume %s is being released, queuing for removal", tag.Id())
+			remove = append(remove, tag)
+			continue
+		}
 		if result.Error == nil {
 			logger.Debugf("volume %s is provisioned, queuing for deprovisioning", tag.Id())
 			volume, err := volumeFromParams(result.Result)
@@ -398,6 +473,22 @@ func volumesFromStorage(in []storage.Volume) []params.Volume {
 	return out
 }
 
+func volumeSnapshotsFromStorage(tag names.VolumeTag, in []storage.VolumeSnapshot) params.VolumeSnapshots {
+	out := params.VolumeSnapshots{
+		VolumeTag: tag.String(),
+		Snapshots: make([]params.VolumeSnapshot, len(in)),
+	}
+	for i, snapshot := range in {
+		out.Snapshots[i] = params.VolumeSnapshot{
+			SnapshotId: snapshot.SnapshotId,
+			VolumeId:   snapshot.VolumeId,
+			Size:       snapshot.Size,
+			Created:    snapshot.Created,
+		}
+	}
+	return out
+}
+
 func volumeAttachmentsFromStorage(in []storage.VolumeAttachment) []params.VolumeAttachment {
 	out := make([]params.VolumeAttachment, len(in))
 	for i, v := range in {
@@ -465,7 +556,8 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 				InstanceId: instance.Id(in.Attachment.InstanceId),
 				ReadOnly:   in.Attachment.ReadOnly,
 			},
-			Volume: volumeTag,
+			Volume:        volumeTag,
+			EncryptionKey: in.Attachment.EncryptionKey,
 		}
 	}
 	return storage.VolumeParams{
@@ -475,6 +567,36 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.EncryptionKey,
+	}, nil
+}
+
+func resizeVolumeOpFromParams(in params.VolumeResizeParams) (*resizeVolumeOp, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resizeVolumeOp{
+		provider: storage.ProviderType(in.Provider),
+		args: storage.ResizeVolumeParams{
+			Tag:      volumeTag,
+			VolumeId: in.VolumeId,
+			Size:     in.Size,
+		},
+	}, nil
+}
+
+func snapshotVolumeOpFromParams(in params.VolumeSnapshotParams) (*snapshotVolumeOp, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &snapshotVolumeOp{
+		provider: storage.ProviderType(in.Provider),
+		args: storage.VolumeSnapshotParams{
+			Tag:      volumeTag,
+			VolumeId: in.VolumeId,
+		},
 	}, nil
 }
 
@@ -494,7 +616,8 @@ func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage
 			InstanceId: instance.Id(in.InstanceId),
 			ReadOnly:   in.ReadOnly,
 		},
-		Volume:   volumeTag,
-		VolumeId: in.VolumeId,
+		Volume:        volumeTag,
+		VolumeId:      in.VolumeId,
+		EncryptionKey: in.EncryptionKey,
 	}, nil
 }
@@ -322,6 +322,148 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes to the sizes specified in the operations.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	argsBySource := make(map[string][]storage.ResizeVolumeParams)
+	for _, op := range ops {
+		sourceName := string(op.provider)
+		argsBySource[sourceName] = append(argsBySource[sourceName], op.args)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	for sourceName, args := range argsBySource {
+		logger.Debugf("resizing volumes from %q: %v", sourceName, args)
+		volumeSource, err := volumeSource(
+			ctx.config.StorageDir, sourceName,
+			storage.ProviderType(sourceName), ctx.config.Registry,
+		)
+		if err != nil && errors.Cause(err) != errNonDynamic {
+			return errors.Annotate(err, "getting volume source")
+		}
+		resizer, ok := volumeSource.(storage.VolumeResizer)
+		if !ok {
+			// Resize requests are validated by the API server,
+			// so this should only happen if the provider has
+			// changed since the request was made.
+			logger.Warningf("storage provider %q does not support resizing volumes", sourceName)
+			continue
+		}
+		results, err := resizer.ResizeVolumes(args)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[args[i].Tag])
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(args[i].Tag),
+					result.Error,
+				)
+				continue
+			}
+			volumes = append(volumes, storage.Volume{
+				Tag:        args[i].Tag,
+				VolumeInfo: *result.VolumeInfo,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		updateVolume(ctx, volumes[i])
+	}
+	return nil
+}
+
+// snapshotVolumes takes snapshots of the volumes specified in the
+// operations, and records the volumes' snapshots in state.
+func snapshotVolumes(ctx *context, ops map[names.VolumeTag]*snapshotVolumeOp) error {
+	argsBySource := make(map[string][]storage.VolumeSnapshotParams)
+	for _, op := range ops {
+		sourceName := string(op.provider)
+		argsBySource[sourceName] = append(argsBySource[sourceName], op.args)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshots
+	for sourceName, args := range argsBySource {
+		logger.Debugf("snapshotting volumes from %q: %v", sourceName, args)
+		volumeSource, err := volumeSource(
+			ctx.config.StorageDir, sourceName,
+			storage.ProviderType(sourceName), ctx.config.Registry,
+		)
+		if err != nil && errors.Cause(err) != errNonDynamic {
+			return errors.Annotate(err, "getting volume source")
+		}
+		snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+		if !ok {
+			// Snapshot requests are validated by the API server,
+			// so this should only happen if the provider has
+			// changed since the request was made.
+			logger.Warningf("storage provider %q does not support volume snapshots", sourceName)
+			continue
+		}
+		results, err := snapshotter.CreateVolumeSnapshots(args)
+		if err != nil {
+			return errors.Annotatef(err, "snapshotting volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[args[i].Tag])
+				logger.Debugf(
+					"failed to snapshot %s: %v",
+					names.ReadableString(args[i].Tag),
+					result.Error,
+				)
+				continue
+			}
+			volumeSnapshots, err := snapshotter.ListVolumeSnapshots(args[i])
+			if err != nil {
+				reschedule = append(reschedule, ops[args[i].Tag])
+				logger.Debugf(
+					"failed to list snapshots of %s: %v",
+					names.ReadableString(args[i].Tag),
+					err,
+				)
+				continue
+			}
+			snapshots = append(snapshots, volumeSnapshotsFromStorage(args[i].Tag, volumeSnapshots))
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshots(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing snapshots of %s to state: %v",
+				snapshots[i].VolumeTag,
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -470,6 +612,38 @@ func (op *attachVolumeOp) key() interface{} {
 	}
 }
 
+type resizeVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	args     storage.ResizeVolumeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	// Resizing is keyed separately to the volume tag, so
+	// that it does not replace other volume operations.
+	return resizeVolumeKey{op.args.Tag}
+}
+
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+type snapshotVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	args     storage.VolumeSnapshotParams
+}
+
+func (op *snapshotVolumeOp) key() interface{} {
+	// Snapshotting is keyed separately to the volume tag, so
+	// that it does not replace other volume operations.
+	return snapshotVolumeKey{op.args.Tag}
+}
+
+type snapshotVolumeKey struct {
+	tag names.VolumeTag
+}
+
 type detachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
@@ -23,9 +23,10 @@ type deploy struct {
 	revert   bool
 	resolved bool
 
-	callbacks Callbacks
-	deployer  charm.Deployer
-	abort     <-chan struct{}
+	callbacks    Callbacks
+	deployer     charm.Deployer
+	abort        <-chan struct{}
+	waitParallel func(abort <-chan struct{}) error
 }
 
 // String is part of the Operation interface.
@@ -75,6 +76,13 @@ func (d *deploy) Prepare(state State) (*State, error) {
 // recorded in the supplied state.
 // Execute is part of the Operation interface.
 func (d *deploy) Execute(state State) (*State, error) {
+	// Actions running in the background use the charm directory, so
+	// it must not change until they have finished.
+	if d.waitParallel != nil {
+		if err := d.waitParallel(d.abort); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	if err := d.deployer.Deploy(); err == charm.ErrConflict {
 		return nil, NewDeployConflictError(d.charmURL)
 	} else if err != nil {
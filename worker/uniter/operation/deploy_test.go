@@ -384,6 +384,51 @@ func (s *DeploySuite) TestExecuteError_ResolvedUpgrade(c *gc.C) {
 	s.testExecuteError(c, (operation.Factory).NewResolvedUpgrade)
 }
 
+func (s *DeploySuite) TestExecuteWaitsForParallelActions(c *gc.C) {
+	deployer := NewMockDeployer()
+	abort := make(chan struct{})
+	var waitAbort <-chan struct{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		Deployer:  deployer,
+		Callbacks: NewDeployCallbacks(),
+		Abort:     abort,
+		WaitParallel: func(abort <-chan struct{}) error {
+			c.Check(deployer.MockDeploy.called, jc.IsFalse)
+			waitAbort = abort
+			return nil
+		},
+	})
+	op, err := factory.NewUpgrade(curl("cs:quantal/lol-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(waitAbort, gc.Equals, (<-chan struct{})(abort))
+	c.Check(deployer.MockDeploy.called, jc.IsTrue)
+}
+
+func (s *DeploySuite) TestExecuteWaitForParallelActionsError(c *gc.C) {
+	deployer := NewMockDeployer()
+	factory := operation.NewFactory(operation.FactoryParams{
+		Deployer:  deployer,
+		Callbacks: NewDeployCallbacks(),
+		WaitParallel: func(abort <-chan struct{}) error {
+			return errors.New("aborted")
+		},
+	})
+	op, err := factory.NewUpgrade(curl("cs:quantal/lol-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(*midState)
+	c.Check(newState, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "aborted")
+	c.Check(deployer.MockDeploy.called, jc.IsFalse)
+}
+
 func (s *DeploySuite) testExecuteSuccess(
 	c *gc.C, newDeploy newDeploy, before, after operation.State,
 ) {
@@ -8,6 +8,7 @@ import (
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -17,9 +18,23 @@ import (
 type FactoryParams struct {
 	Deployer       charm.Deployer
 	RunnerFactory  runner.Factory
+	ActionGetter   ActionGetter
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// RunParallel runs the supplied function, which runs an action
+	// the charm has marked as parallel, in the background. The function
+	// is passed a channel which is closed when the action must be
+	// aborted. If RunParallel is nil, parallel actions are run in the
+	// foreground like any other.
+	RunParallel func(run func(abort <-chan struct{}))
+
+	// WaitParallel blocks until the actions started with RunParallel
+	// have finished, or the supplied channel is closed. Charm installs
+	// and upgrades wait for it, so that the charm directory does not
+	// change under running actions. It may be nil if RunParallel is.
+	WaitParallel func(abort <-chan struct{}) error
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -42,13 +57,14 @@ func (f *factory) newDeploy(kind Kind, charmURL *corecharm.URL, revert, resolved
 		return nil, errors.Errorf("unknown deploy kind: %s", kind)
 	}
 	return &deploy{
-		kind:      kind,
-		charmURL:  charmURL,
-		revert:    revert,
-		resolved:  resolved,
-		callbacks: f.config.Callbacks,
-		deployer:  f.config.Deployer,
-		abort:     f.config.Abort,
+		kind:         kind,
+		charmURL:     charmURL,
+		revert:       revert,
+		resolved:     resolved,
+		callbacks:    f.config.Callbacks,
+		deployer:     f.config.Deployer,
+		abort:        f.config.Abort,
+		waitParallel: f.config.WaitParallel,
 	}, nil
 }
 
@@ -98,8 +114,23 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 	if !names.IsValidAction(actionId) {
 		return nil, errors.Errorf("invalid action id %q", actionId)
 	}
+	// The action is looked up now, rather than when it is prepared,
+	// because the executor needs to know whether to acquire the
+	// machine lock before then.
+	var parallel bool
+	action, err := f.config.ActionGetter.Action(names.NewActionTag(actionId))
+	switch {
+	case params.IsCodeNotFoundOrCodeUnauthorized(err), params.IsCodeActionNotAvailable(err):
+		// Prepare will find the same, and skip the action.
+	case err != nil:
+		return nil, errors.Annotatef(err, "cannot get action %q", actionId)
+	default:
+		parallel = action.Parallel()
+	}
 	return &runAction{
 		actionId:      actionId,
+		parallel:      parallel,
+		runParallel:   f.config.RunParallel,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
 	}, nil
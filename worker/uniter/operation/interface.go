@@ -9,6 +9,7 @@ import (
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -123,6 +124,11 @@ type CommandArgs struct {
 // of the original request.
 type CommandResponseFunc func(*utilexec.ExecResponse, error)
 
+// ActionGetter exposes the actions queued for the unit.
+type ActionGetter interface {
+	Action(tag names.ActionTag) (*uniter.Action, error)
+}
+
 // Callbacks exposes all the uniter code that's required by the various operations.
 // It's far from cohesive, and fundamentally represents inappropriate coupling, so
 // it's a prime candidate for future refactoring.
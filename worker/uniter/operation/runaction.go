@@ -12,15 +12,15 @@ import (
 )
 
 type runAction struct {
-	actionId string
+	actionId    string
+	parallel    bool
+	runParallel func(run func(abort <-chan struct{}))
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
 
 	name   string
 	runner runner.Runner
-
-	RequiresMachineLock
 }
 
 // String is part of the Operation interface.
@@ -28,6 +28,13 @@ func (ra *runAction) String() string {
 	return fmt.Sprintf("run action %s", ra.actionId)
 }
 
+// NeedsGlobalMachineLock is part of the Operation interface. Actions
+// which the charm has marked as parallel are run without the lock, so
+// that they do not wait for, or hold up, hooks and other actions.
+func (ra *runAction) NeedsGlobalMachineLock() bool {
+	return !ra.parallel
+}
+
 // Prepare ensures that the action is valid and can be executed. If not, it
 // will return ErrSkipExecute. It preserves any hook recorded in the supplied
 // state.
@@ -64,6 +71,10 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 }
 
 // Execute runs the action, and preserves any hook recorded in the supplied state.
+// Actions which the charm has marked as parallel are started in the
+// background, so that the executor can go on to run hooks and other
+// actions while they run; the runner records their results once they
+// complete, and the action is failed if the runner cannot.
 // Execute is part of the Operation interface.
 func (ra *runAction) Execute(state State) (*State, error) {
 	message := fmt.Sprintf("running action %s", ra.name)
@@ -72,8 +83,20 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
-	err := ra.runner.RunAction(ra.name)
-	if err != nil {
+	if ra.parallel && ra.runParallel != nil {
+		rnr, name, actionId, callbacks := ra.runner, ra.name, ra.actionId, ra.callbacks
+		ra.runParallel(func(abort <-chan struct{}) {
+			if err := rnr.RunAction(name, abort); err != nil {
+				// There is no operation to fail, so the action
+				// itself is failed rather than left running.
+				err = errors.Annotatef(err, "running action %q", name)
+				logger.Errorf("%v", err)
+				if err := callbacks.FailAction(actionId, err.Error()); err != nil {
+					logger.Errorf("cannot fail action %q: %v", actionId, err)
+				}
+			}
+		})
+	} else if err := ra.runner.RunAction(ra.name, nil); err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
 		return nil, errors.Annotatef(err, "running action %q", ra.name)
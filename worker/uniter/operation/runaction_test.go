@@ -9,7 +9,10 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -32,6 +35,7 @@ func (s *RunActionSuite) TestPrepareErrorBadActionAndFailSucceeds(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 		Callbacks:     callbacks,
 	})
 	op, err := factory.NewAction(someActionId)
@@ -55,6 +59,7 @@ func (s *RunActionSuite) TestPrepareErrorBadActionAndFailErrors(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 		Callbacks:     callbacks,
 	})
 	op, err := factory.NewAction(someActionId)
@@ -74,6 +79,7 @@ func (s *RunActionSuite) TestPrepareErrorActionNotAvailable(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -90,6 +96,7 @@ func (s *RunActionSuite) TestPrepareErrorOther(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -111,6 +118,7 @@ func (s *RunActionSuite) TestPrepareCtxCalled(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -133,6 +141,7 @@ func (s *RunActionSuite) TestPrepareCtxError(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -147,6 +156,7 @@ func (s *RunActionSuite) TestPrepareSuccessCleanState(c *gc.C) {
 	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -165,6 +175,7 @@ func (s *RunActionSuite) TestPrepareSuccessDirtyState(c *gc.C) {
 	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -211,6 +222,7 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 		callbacks := &RunActionCallbacks{}
 		factory := operation.NewFactory(operation.FactoryParams{
 			RunnerFactory: runnerFactory,
+			ActionGetter:  &MockActionGetter{},
 			Callbacks:     callbacks,
 		})
 		op, err := factory.NewAction(someActionId)
@@ -272,7 +284,7 @@ func (s *RunActionSuite) TestCommit(c *gc.C) {
 
 	for i, test := range stateChangeTests {
 		c.Logf("test %d: %s", i, test.description)
-		factory := operation.NewFactory(operation.FactoryParams{})
+		factory := operation.NewFactory(operation.FactoryParams{ActionGetter: &MockActionGetter{}})
 		op, err := factory.NewAction(someActionId)
 		c.Assert(err, jc.ErrorIsNil)
 
@@ -282,8 +294,86 @@ func (s *RunActionSuite) TestCommit(c *gc.C) {
 }
 
 func (s *RunActionSuite) TestNeedsGlobalMachineLock(c *gc.C) {
-	factory := operation.NewFactory(operation.FactoryParams{})
+	actionGetter := &MockActionGetter{}
+	factory := operation.NewFactory(operation.FactoryParams{ActionGetter: actionGetter})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+	c.Assert(*actionGetter.gotTag, gc.Equals, names.NewActionTag(someActionId))
+}
+
+func (s *RunActionSuite) TestParallelDoesNotNeedGlobalMachineLock(c *gc.C) {
+	action, err := uniter.NewAction("some-action-name", nil, true, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	factory := operation.NewFactory(operation.FactoryParams{
+		ActionGetter: &MockActionGetter{action: action},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+}
+
+func (s *RunActionSuite) TestExecuteParallelInBackground(c *gc.C) {
+	action, err := uniter.NewAction("some-action-name", nil, true, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	runnerFactory := NewRunActionRunnerFactory(errors.New("blam"))
+	callbacks := &RunActionCallbacks{MockFailAction: &MockFailAction{}}
+	var background []func(<-chan struct{})
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		ActionGetter:  &MockActionGetter{action: action},
+		Callbacks:     callbacks,
+		RunParallel: func(run func(abort <-chan struct{})) {
+			background = append(background, run)
+		},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The action is handed off to run in the background, so the
+	// operation completes without running it, and errors from the
+	// runner do not fail the operation.
+	newState, err := op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Done,
+		ActionId: &someActionId,
+	})
+	c.Assert(runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.IsNil)
+	c.Assert(background, gc.HasLen, 1)
+
+	// Errors from the runner fail the action, since there is no
+	// operation left to fail.
+	background[0](make(chan struct{}))
+	c.Assert(*runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.Equals, "some-action-name")
+	c.Assert(*callbacks.MockFailAction.gotActionId, gc.Equals, someActionId)
+	c.Assert(*callbacks.MockFailAction.gotMessage, gc.Equals, `running action "some-action-name": blam`)
+}
+
+func (s *RunActionSuite) TestNewActionNotAvailable(c *gc.C) {
+	for i, getErr := range []error{
+		&params.Error{Code: params.CodeNotFound},
+		&params.Error{Code: params.CodeUnauthorized},
+		&params.Error{Code: params.CodeActionNotAvailable},
+	} {
+		c.Logf("test %d: %v", i, getErr)
+		factory := operation.NewFactory(operation.FactoryParams{
+			ActionGetter: &MockActionGetter{err: getErr},
+		})
+		op, err := factory.NewAction(someActionId)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+	}
+}
+
+func (s *RunActionSuite) TestNewActionError(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{
+		ActionGetter: &MockActionGetter{err: errors.New("pow")},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(op, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `cannot get action ".*": pow`)
 }
@@ -9,7 +9,9 @@ import (
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -100,6 +102,20 @@ func (d *MockDeployer) Deploy() error {
 	return d.MockDeploy.Call()
 }
 
+type MockActionGetter struct {
+	gotTag *names.ActionTag
+	action *uniter.Action
+	err    error
+}
+
+func (mock *MockActionGetter) Action(tag names.ActionTag) (*uniter.Action, error) {
+	mock.gotTag = &tag
+	if mock.action == nil && mock.err == nil {
+		return uniter.NewAction("some-action-name", nil, false, 0)
+	}
+	return mock.action, mock.err
+}
+
 type MockFailAction struct {
 	gotActionId *string
 	gotMessage  *string
@@ -361,7 +377,7 @@ func (r *MockRunner) Context() runner.Context {
 	return r.context
 }
 
-func (r *MockRunner) RunAction(actionName string) error {
+func (r *MockRunner) RunAction(actionName string, abort <-chan struct{}) error {
 	return r.MockRunAction.Call(actionName)
 }
 
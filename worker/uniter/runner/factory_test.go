@@ -174,6 +174,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		testing.NewClock(time.Time{}),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
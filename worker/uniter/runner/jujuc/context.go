@@ -286,6 +286,9 @@ type ContextStorageAttachment interface {
 	// Location returns the location of the storage: the mount point for
 	// filesystem-kind stores, and the device path for block-kind stores.
 	Location() string
+
+	// Size returns the size of the storage, in MiB.
+	Size() uint64
 }
 
 // ContextVersion expresses the parts of a hook context related to
@@ -67,6 +67,7 @@ func (c *StorageGetCommand) Run(ctx *cmd.Context) error {
 	values := map[string]interface{}{
 		"kind":     storage.Kind().String(),
 		"location": storage.Location(),
+		"size":     storage.Size(),
 	}
 	if c.key == "" {
 		return c.out.Write(ctx, values)
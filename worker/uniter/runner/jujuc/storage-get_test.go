@@ -29,9 +29,10 @@ var storageGetTests = []struct {
 	out    interface{}
 }{
 	{[]string{"--format", "yaml"}, formatYaml, storageAttributes},
-	{[]string{"--format", "json"}, formatJson, storageAttributes},
+	{[]string{"--format", "json"}, formatJson, storageAttributesJSON},
 	{[]string{}, formatYaml, storageAttributes},
 	{[]string{"location"}, -1, "/dev/sda\n"},
+	{[]string{"size"}, -1, "0\n"},
 }
 
 func (s *storageGetSuite) TestOutputFormatKey(c *gc.C) {
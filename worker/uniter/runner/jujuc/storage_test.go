@@ -13,6 +13,14 @@ var (
 	storageAttributes = map[string]interface{}{
 		"location": "/dev/sda",
 		"kind":     "block",
+		"size":     0,
+	}
+
+	// JSON decodes all numbers as float64.
+	storageAttributesJSON = map[string]interface{}{
+		"location": "/dev/sda",
+		"kind":     "block",
+		"size":     float64(0),
 	}
 
 	storageName = "data/0"
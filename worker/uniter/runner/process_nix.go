@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup arranges for the command to be started in a new
+// process group, so that it can be killed along with its children.
+func setProcessGroup(ps *exec.Cmd) {
+	ps.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the process and, if it leads a process
+// group, the other processes in the group.
+func killProcessGroup(p *os.Process) error {
+	if pgid, err := syscall.Getpgid(p.Pid); err == nil && pgid == p.Pid {
+		return syscall.Kill(-pgid, syscall.SIGKILL)
+	}
+	return p.Kill()
+}
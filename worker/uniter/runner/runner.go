@@ -36,8 +36,10 @@ type Runner interface {
 	// RunHook executes the hook with the supplied name.
 	RunHook(name string) error
 
-	// RunAction executes the action with the supplied name.
-	RunAction(name string) error
+	// RunAction executes the action with the supplied name. If the
+	// abort channel is closed before the action finishes, the action's
+	// processes are killed and the action fails.
+	RunAction(name string, abort <-chan struct{}) error
 
 	// RunCommands executes the supplied script.
 	RunCommands(commands string) (*utilexec.ExecResponse, error)
@@ -59,13 +61,20 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return newRunner(context, paths, clock.WallClock)
+}
+
+// newRunner returns a Runner backed by the supplied context and paths,
+// which uses the supplied clock to time out actions.
+func newRunner(context Context, paths context.Paths, clock clock.Clock) Runner {
+	return &runner{context, paths, clock}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	clock   clock.Clock
 }
 
 func (runner *runner) Context() Context {
@@ -74,13 +83,14 @@ func (runner *runner) Context() Context {
 
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
-	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock)
+	result, err := runner.runCommandsWithTimeout(commands, 0, nil, runner.clock)
 	return result, runner.context.Flush("run commands", err)
 }
 
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
-// juju-run as an action
-func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+// juju-run as an action. The commands are cancelled if the timeout expires or
+// the abort channel is closed.
+func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, abort <-chan struct{}, clock clock.Clock) (*utilexec.ExecResponse, error) {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return nil, err
@@ -105,10 +115,21 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	runner.context.SetProcess(hookProcess{command.Process()})
 
 	var cancel chan struct{}
-	if timeout != 0 {
+	if timeout != 0 || abort != nil {
+		var timedOut <-chan time.Time
+		if timeout != 0 {
+			timedOut = clock.After(timeout)
+		}
 		cancel = make(chan struct{})
+		done := make(chan struct{})
+		defer close(done)
 		go func() {
-			<-clock.After(timeout)
+			select {
+			case <-timedOut:
+			case <-abort:
+			case <-done:
+				return
+			}
 			close(cancel)
 		}()
 	}
@@ -118,7 +139,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 }
 
 // runJujuRunAction is the function that executes when a juju-run action is ran.
-func (runner *runner) runJujuRunAction() (err error) {
+func (runner *runner) runJujuRunAction(abort <-chan struct{}) (err error) {
 	params, err := runner.context.ActionParams()
 	if err != nil {
 		return errors.Trace(err)
@@ -135,7 +156,7 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), abort, runner.clock)
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -188,22 +209,23 @@ func (runner *runner) updateActionResults(results *utilexec.ExecResponse) error
 }
 
 // RunAction exists to satisfy the Runner interface.
-func (runner *runner) RunAction(actionName string) error {
-	if _, err := runner.context.ActionData(); err != nil {
+func (runner *runner) RunAction(actionName string, abort <-chan struct{}) error {
+	actionData, err := runner.context.ActionData()
+	if err != nil {
 		return errors.Trace(err)
 	}
 	if actionName == actions.JujuRunActionName {
-		return runner.runJujuRunAction()
+		return runner.runJujuRunAction(abort)
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", actionData.Timeout, abort)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	return runner.runCharmHookWithLocation(hookName, "hooks", 0, nil)
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration, abort <-chan struct{}) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -226,12 +248,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout, abort)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration, abort <-chan struct{}) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
@@ -241,6 +263,11 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	if timeout > 0 || abort != nil {
+		// Any processes started by the hook must be stopped along
+		// with it if the timeout expires or the hook is aborted.
+		setProcessGroup(ps)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -258,13 +285,46 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes, the timeout expires, or
+		// the hook is aborted.
+		err = waitWithTimeout(ps, timeout, abort, runner.clock)
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// waitWithTimeout waits for the supplied command to finish. If it is
+// still running once the timeout has elapsed, or when the abort channel
+// is closed, the command's process group is killed and an error is
+// returned. A zero timeout waits indefinitely.
+func waitWithTimeout(ps *exec.Cmd, timeout time.Duration, abort <-chan struct{}, clock clock.Clock) error {
+	if timeout <= 0 && abort == nil {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var timedOut <-chan time.Time
+	if timeout > 0 {
+		timedOut = clock.After(timeout)
+	}
+	var err error
+	select {
+	case err := <-done:
+		return err
+	case <-timedOut:
+		err = errors.Errorf("timed out after %v", timeout)
+	case <-abort:
+		err = errors.New("aborted")
+	}
+	if killErr := killProcessGroup(ps.Process); killErr != nil {
+		logger.Warningf("cannot kill process %d: %v", ps.Process.Pid, killErr)
+	}
+	<-done
+	return err
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -6,8 +6,10 @@ package runner_test
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -263,7 +266,7 @@ func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 		name: hookName,
 		perm: 0700,
 	}, s.paths.GetCharmDir())
-	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened", nil)
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.IsNil)
@@ -282,20 +285,134 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 		perm: 0700,
 		code: 123,
 	}, s.paths.GetCharmDir())
-	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened", nil)
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunActionTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts are written for bash")
+	}
+	expectErr := errors.New("pew pew pew")
+	ctx := &MockContext{
+		flushResult: expectErr,
+		actionData:  &context.ActionData{Timeout: 50 * time.Millisecond},
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	clock := envtesting.NewClock(time.Time{})
+	go func() {
+		err := clock.WaitAdvance(50*time.Millisecond, 5*time.Second, 1)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	start := time.Now()
+	actualErr := runner.NewRunnerWithClock(ctx, s.paths, clock).RunAction("something-happened", nil)
+	c.Assert(actualErr, gc.Equals, expectErr)
+	c.Assert(time.Since(start) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "timed out after 50ms")
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimeoutKillsProcessGroup(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("process groups are only killed on unix")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: time.Second},
+	}
+	makeCharm(c, hookSpec{
+		dir:               "actions",
+		name:              hookName,
+		perm:              0700,
+		background:        "started",
+		backgroundPidFile: "background.pid",
+		sleep:             10,
+	}, s.paths.GetCharmDir())
+	clock := envtesting.NewClock(time.Time{})
+	pidFile := filepath.Join(s.paths.GetCharmDir(), "background.pid")
+	go func() {
+		// Only time out the action once the background process
+		// has been started.
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if _, err := os.Stat(pidFile); err == nil {
+				break
+			}
+		}
+		err := clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	err := runner.NewRunnerWithClock(ctx, s.paths, clock).RunAction("something-happened", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "timed out after 1s")
+
+	content, err := ioutil.ReadFile(pidFile)
+	c.Assert(err, jc.ErrorIsNil)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); processExists(pid); {
+		if !a.Next() {
+			c.Fatalf("background process %d still running", pid)
+		}
+	}
+}
+
+func (s *RunMockContextSuite) TestRunActionAbortKillsProcessGroup(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("process groups are only killed on unix")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+	}
+	makeCharm(c, hookSpec{
+		dir:               "actions",
+		name:              hookName,
+		perm:              0700,
+		background:        "started",
+		backgroundPidFile: "background.pid",
+		sleep:             10,
+	}, s.paths.GetCharmDir())
+	pidFile := filepath.Join(s.paths.GetCharmDir(), "background.pid")
+	abort := make(chan struct{})
+	go func() {
+		// Only abort the action once the background process
+		// has been started.
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if _, err := os.Stat(pidFile); err == nil {
+				break
+			}
+		}
+		close(abort)
+	}()
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened", abort)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "aborted")
+
+	content, err := ioutil.ReadFile(pidFile)
+	c.Assert(err, jc.ErrorIsNil)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); processExists(pid); {
+		if !a.Next() {
+			c.Fatalf("background process %d still running", pid)
+		}
+	}
+}
+
 func (s *RunMockContextSuite) TestRunActionParamsFailure(c *gc.C) {
 	expectErr := errors.New("stork")
 	ctx := &MockContext{
 		actionData:      &context.ActionData{},
 		actionParamsErr: expectErr,
 	}
-	actualErr := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("juju-run", nil)
 	c.Assert(errors.Cause(actualErr), gc.Equals, expectErr)
 }
 
@@ -308,7 +425,7 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 		},
 		actionResults: map[string]interface{}{},
 	}
-	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run", nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
 	c.Assert(ctx.flushFailure, gc.IsNil)
@@ -327,7 +444,7 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 		},
 		actionResults: map[string]interface{}{},
 	}
-	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run", nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
 	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
@@ -336,6 +453,23 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunJujuRunActionAborted(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "sleep 10",
+			"timeout": float64(0),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	abort := make(chan struct{})
+	close(abort)
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run", abort)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
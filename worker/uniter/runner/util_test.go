@@ -113,6 +113,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.uniter,
 		s.paths,
 		s.contextFactory,
+		jujutesting.NewClock(time.Time{}),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
@@ -199,6 +200,11 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// backgroundPidFile, if set, names the file in the charm directory
+	// to which the pid of the background process is written.
+	backgroundPidFile string
+	// sleep holds the number of seconds to sleep before exiting.
+	sleep int
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -242,6 +248,12 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// the hook execution will take much longer than
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
+		if spec.backgroundPidFile != "" {
+			printf("echo $! > %s", spec.backgroundPidFile)
+		}
+	}
+	if spec.sleep != 0 {
+		printf("sleep %d", spec.sleep)
 	}
 	printf("exit %d", spec.code)
 }
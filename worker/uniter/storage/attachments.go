@@ -137,6 +137,7 @@ func (a *Attachments) init() error {
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				size:     attachment.Size,
 			},
 		}
 	}
@@ -196,21 +197,21 @@ func (a *Attachments) StorageTags() ([]names.StorageTag, error) {
 
 // ValidateHook validates the hook against the current state.
 func (a *Attachments) ValidateHook(hi hook.Info) error {
-	storageState, err := a.storageStateForHook(hi)
+	storageAttachment, err := a.storageAttachmentForHook(hi)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return storageState.ValidateHook(hi)
+	return storageAttachment.ValidateHook(hi)
 }
 
 // CommitHook persists the state change encoded in the supplied storage
 // hook, or returns an error if the hook is invalid given current state.
 func (a *Attachments) CommitHook(hi hook.Info) error {
-	storageState, err := a.storageStateForHook(hi)
+	storageAttachment, err := a.storageAttachmentForHook(hi)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := storageState.CommitHook(hi); err != nil {
+	if err := storageAttachment.commitHook(hi, storageAttachment.Size()); err != nil {
 		return err
 	}
 	storageTag := names.NewStorageTag(hi.StorageId)
@@ -234,13 +235,13 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 	return nil
 }
 
-func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
-		return nil, errors.Errorf("not a storage hook: %#v", hi)
+func (a *Attachments) storageAttachmentForHook(hi hook.Info) (storageAttachment, error) {
+	if !hook.IsStorage(hi.Kind) {
+		return storageAttachment{}, errors.Errorf("not a storage hook: %#v", hi)
 	}
-	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
+	attachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
 	if !ok {
-		return nil, errors.Errorf("unknown storage %q", hi.StorageId)
+		return storageAttachment{}, errors.Errorf("unknown storage %q", hi.StorageId)
 	}
-	return storageAttachment.stateFile, nil
+	return attachment, nil
 }
@@ -136,8 +136,12 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and changes to its size.
+			if !storageResized(storageAttachment, snap) {
+				return nil, resolver.ErrNoOperation
+			}
+			hookInfo.Kind = hook.StorageResized
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -175,8 +179,27 @@ func (s *storageResolver) nextHookOp(
 			tag:      tag,
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
+			size:     snap.Size,
 		},
 	}
 
 	return opFactory.NewRunHook(hookInfo)
 }
+
+// storageResized reports whether the size of the attached storage has
+// changed since it was last reported to the charm.
+func storageResized(attachment storageAttachment, snap remotestate.StorageSnapshot) bool {
+	if snap.Size == 0 {
+		// The size is not known, e.g. because
+		// the controller does not report it.
+		return false
+	}
+	if attachment.size == 0 {
+		// The size was not recorded when the storage-attached
+		// hook was committed by an older agent; record the
+		// current size, so later changes are reported.
+		attachment.size = snap.Size
+		return false
+	}
+	return snap.Size != attachment.size
+}
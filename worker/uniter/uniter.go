@@ -74,6 +74,10 @@ type Uniter struct {
 	operationExecutor    operation.Executor
 	newOperationExecutor NewExecutorFunc
 
+	// parallelActions tracks the actions which are running in the
+	// background, so that charm upgrades can wait for them to finish.
+	parallelActions sync.WaitGroup
+
 	leadershipTracker leadership.Tracker
 	charmDirGuard     fortress.Guard
 
@@ -454,7 +458,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.clock,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -462,9 +466,12 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,
+		ActionGetter:   u.st,
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		RunParallel:    u.runParallel,
+		WaitParallel:   u.waitParallel,
 	})
 
 	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, u.getServiceCharmURL, u.acquireExecutionLock)
@@ -500,6 +507,40 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	return nil
 }
 
+// runParallel runs an action which the charm has marked as parallel in
+// the background, as a worker in the uniter's catacomb. The action is
+// aborted, and its processes killed, when the uniter stops.
+func (u *Uniter) runParallel(run func(abort <-chan struct{})) {
+	u.parallelActions.Add(1)
+	w := worker.NewSimpleWorker(func(stop <-chan struct{}) error {
+		defer u.parallelActions.Done()
+		run(stop)
+		return nil
+	})
+	if err := u.catacomb.Add(w); err != nil {
+		// The uniter is stopping; Add has already stopped the
+		// worker, and so aborted the action.
+		logger.Debugf("parallel action aborted: %v", err)
+	}
+}
+
+// waitParallel waits for the actions running in the background to
+// finish, so that the charm directory does not change under them.
+func (u *Uniter) waitParallel(abort <-chan struct{}) error {
+	done := make(chan struct{})
+	go func() {
+		u.parallelActions.Wait()
+		close(done)
+	}()
+	logger.Debugf("waiting for running actions to finish before changing the charm")
+	select {
+	case <-done:
+		return nil
+	case <-abort:
+		return resolver.ErrLoopAborted
+	}
+}
+
 func (u *Uniter) Kill() {
 	u.catacomb.Kill(nil)
 }